@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// triggerRequest asks the main loop to download a date for a schedule
+type triggerRequest struct {
+	Schedule string `json:"schedule"`
+	Date     string `json:"date"`
+}
+
+// reloadRequest asks the main loop to reload the configuration
+type reloadRequest struct {
+	done chan error
+}
+
+// adminServer serves the daemon's admin API: /status, /trigger, /history,
+// /reload, /pause, /resume, /progress and /metrics. Triggered runs and reloads are
+// queued for the main loop, so they never overlap a scheduled download.
+type adminServer struct {
+	jobs     *jobList
+	runner   *runner
+	tracker  *DownloadTracker
+	history  *runHistory
+	metrics  *daemonMetrics
+	triggers chan triggerRequest
+	reloads  chan reloadRequest
+	token    string
+	started  time.Time
+	logger   *zap.Logger
+}
+
+func newAdminServer(jobs *jobList, r *runner, token string, logger *zap.Logger) *adminServer {
+	return &adminServer{
+		jobs:     jobs,
+		runner:   r,
+		tracker:  r.tracker,
+		history:  r.history,
+		metrics:  r.metrics,
+		triggers: make(chan triggerRequest, 16),
+		reloads:  make(chan reloadRequest),
+		token:    token,
+		started:  time.Now(),
+		logger:   logger,
+	}
+}
+
+// Handler returns the admin API routes. /metrics needs no token, for
+// Prometheus to scrape.
+func (a *adminServer) Handler() http.Handler {
+	admin := http.NewServeMux()
+	admin.HandleFunc("GET /status", a.handleStatus)
+	admin.HandleFunc("POST /trigger", a.handleTrigger)
+	admin.HandleFunc("GET /history", a.handleHistory)
+	admin.HandleFunc("POST /reload", a.handleReload)
+	admin.HandleFunc("POST /pause", a.handlePause)
+	admin.HandleFunc("POST /resume", a.handleResume)
+	admin.HandleFunc("GET /progress", a.runner.progress.handler)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", a.metrics.handler)
+	mux.Handle("/", a.authorize(admin))
+	return mux
+}
+
+// Serve listens on addr until ctx is cancelled
+func (a *adminServer) Serve(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           a.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// authorize requires the bearer token, when one is configured
+func (a *adminServer) authorize(next http.Handler) http.Handler {
+	if a.token == "" {
+		return next
+	}
+	want := []byte("Bearer " + a.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			writeJSONError(w, http.StatusUnauthorized, "missing or invalid admin token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type scheduleStatus struct {
+	Name             string     `json:"name"`
+	Schedule         string     `json:"schedule"`
+	Timezone         string     `json:"timezone"`
+	Tickers          []string   `json:"tickers"`
+	LastDownloadDate string     `json:"last_download_date,omitempty"`
+	NextRun          time.Time  `json:"next_run,omitzero"`
+	LastRun          *runRecord `json:"last_run,omitempty"`
+}
+
+func (a *adminServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	jobs := a.jobs.Load()
+	schedules := make([]scheduleStatus, 0, len(jobs))
+	for _, job := range jobs {
+		schedules = append(schedules, scheduleStatus{
+			Name:             job.name,
+			Schedule:         job.scheduler.schedule.String(),
+			Timezone:         job.scheduler.Location().String(),
+			Tickers:          job.cfg.Tickers,
+			LastDownloadDate: a.tracker.GetLastDownloadDate(job.name),
+			NextRun:          job.scheduler.NextRun(),
+			LastRun:          a.history.Last(job.name),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"started":   a.started,
+		"paused":    a.runner.paused.Load(),
+		"running":   a.history.Current(),
+		"queued":    len(a.triggers),
+		"schedules": schedules,
+	})
+}
+
+// handleTrigger queues a download of ?date= (default: today) for
+// ?schedule=, or for every schedule when it is omitted
+func (a *adminServer) handleTrigger(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("schedule")
+	date := r.URL.Query().Get("date")
+
+	var jobs []*scheduledJob
+	for _, job := range a.jobs.Load() {
+		if name == "" || job.name == name {
+			jobs = append(jobs, job)
+		}
+	}
+	if len(jobs) == 0 {
+		writeJSONError(w, http.StatusNotFound, fmt.Sprintf("unknown schedule %q", name))
+		return
+	}
+
+	var queued []triggerRequest
+	for _, job := range jobs {
+		req := triggerRequest{Schedule: job.name, Date: date}
+		if req.Date == "" {
+			req.Date = job.scheduler.TodayDate()
+		}
+		if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("invalid date %q, want YYYY-MM-DD", req.Date))
+			return
+		}
+		if req.Date > job.scheduler.TodayDate() {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("date %s is in the future", req.Date))
+			return
+		}
+		if !job.scheduler.IsMarketDay(req.Date) {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a market day for schedule %q", req.Date, job.name))
+			return
+		}
+		queued = append(queued, req)
+	}
+
+	if cap(a.triggers)-len(a.triggers) < len(queued) {
+		writeJSONError(w, http.StatusServiceUnavailable, "trigger queue is full")
+		return
+	}
+	for _, req := range queued {
+		a.triggers <- req
+		a.logger.Info("download triggered", zap.String("schedule", req.Schedule), zap.String("date", req.Date))
+	}
+	writeJSON(w, http.StatusAccepted, map[string]any{"queued": queued})
+}
+
+// handleHistory lists finished runs, newest first, optionally of one
+// ?schedule= and at most ?limit= (default 20)
+func (a *adminServer) handleHistory(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit %q", s))
+			return
+		}
+		limit = n
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"runs": a.history.Runs(r.URL.Query().Get("schedule"), limit),
+	})
+}
+
+// handleReload reloads the configuration like SIGHUP. While a download
+// runs, the reload waits for it and the request returns 202 Accepted.
+func (a *adminServer) handleReload(w http.ResponseWriter, r *http.Request) {
+	req := reloadRequest{done: make(chan error, 1)}
+	wait := time.NewTimer(30 * time.Second)
+	defer wait.Stop()
+
+	select {
+	case a.reloads <- req:
+	case <-wait.C:
+		// The main loop is busy; hand the request over in the background
+		go func() { a.reloads <- req }()
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "queued until the current download finishes"})
+		return
+	case <-r.Context().Done():
+		return
+	}
+
+	if err := <-req.done; err != nil {
+		writeJSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "reloaded"})
+}
+
+// handlePause pauses scheduled downloads and retries like SIGUSR1
+func (a *adminServer) handlePause(w http.ResponseWriter, r *http.Request) {
+	a.runner.setPaused(true, "admin API")
+	writeJSON(w, http.StatusOK, map[string]bool{"paused": true})
+}
+
+// handleResume resumes scheduled downloads and retries; held ones start
+// within a minute
+func (a *adminServer) handleResume(w http.ResponseWriter, r *http.Request) {
+	a.runner.setPaused(false, "admin API")
+	writeJSON(w, http.StatusOK, map[string]bool{"paused": false})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+)
+
+func testConfig() *config.Config {
+	return &config.Config{
+		Tickers: []string{"SPX"},
+		Packages: config.PackagesConfig{
+			Classic: config.PackageConfig{Enabled: true, Categories: []string{"gex_full"}},
+		},
+	}
+}
+
+func newTestJob(t *testing.T, name string) *scheduledJob {
+	t.Helper()
+	job, err := newScheduledJob(ScheduleEntry{Name: name, Schedule: "0 20 * * *", Timezone: "America/New_York"}, testConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+// newTestRunner returns a runner of jobs with its state in a temporary file
+func newTestRunner(t *testing.T, jobs ...*scheduledJob) (*runner, *jobList) {
+	t.Helper()
+	var list jobList
+	list.Store(jobs)
+	tracker := NewDownloadTracker(filepath.Join(t.TempDir(), "state"))
+	r := &runner{
+		tracker:  tracker,
+		history:  &runHistory{tracker: tracker},
+		metrics:  newDaemonMetrics(&list, tracker),
+		progress: newProgressHub(),
+		logger:   zap.NewNop(),
+		retries:  make(map[retryKey]*pendingRetry),
+		delayed:  make(map[string]delayedRun),
+		wake:     time.NewTimer(0),
+	}
+	r.wake.Stop()
+	return r, &list
+}
+
+func TestAdminServer(t *testing.T) {
+	r, jobs := newTestRunner(t, newTestJob(t, "equities"), newTestJob(t, "futures"))
+	admin := newAdminServer(jobs, r, "secret", zap.NewNop())
+	srv := httptest.NewServer(admin.Handler())
+	defer srv.Close()
+
+	do := func(method, path, token string) (int, map[string]any) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		var body map[string]any
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return resp.StatusCode, body
+	}
+
+	t.Run("token", func(t *testing.T) {
+		if status, _ := do("GET", "/status", ""); status != http.StatusUnauthorized {
+			t.Errorf("without token: status = %d, want 401", status)
+		}
+		if status, _ := do("GET", "/status", "wrong"); status != http.StatusUnauthorized {
+			t.Errorf("wrong token: status = %d, want 401", status)
+		}
+		if status, _ := do("GET", "/metrics", ""); status != http.StatusOK {
+			t.Errorf("/metrics without token: status = %d, want 200", status)
+		}
+	})
+
+	t.Run("status", func(t *testing.T) {
+		status, body := do("GET", "/status", "secret")
+		if status != http.StatusOK {
+			t.Fatalf("status = %d, want 200", status)
+		}
+		schedules, _ := body["schedules"].([]any)
+		if len(schedules) != 2 {
+			t.Fatalf("schedules = %v, want equities and futures", body["schedules"])
+		}
+		if s := schedules[0].(map[string]any); s["name"] != "equities" || s["timezone"] != "America/New_York" {
+			t.Errorf("first schedule = %v", s)
+		}
+		if body["paused"] != false {
+			t.Errorf("paused = %v, want false", body["paused"])
+		}
+	})
+
+	t.Run("trigger", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			query  string
+			status int
+		}{
+			{"invalid date", "date=2025-1-2", http.StatusBadRequest},
+			{"future date", "date=2999-01-02", http.StatusBadRequest},
+			{"weekend", "date=2025-01-04", http.StatusBadRequest},
+			{"unknown schedule", "schedule=crypto&date=2025-01-02", http.StatusNotFound},
+			{"one schedule", "schedule=futures&date=2025-01-02", http.StatusAccepted},
+			{"all schedules", "date=2025-01-03", http.StatusAccepted},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if status, body := do("POST", "/trigger?"+tt.query, "secret"); status != tt.status {
+					t.Errorf("status = %d, want %d: %v", status, tt.status, body)
+				}
+			})
+		}
+
+		want := []triggerRequest{
+			{Schedule: "futures", Date: "2025-01-02"},
+			{Schedule: "equities", Date: "2025-01-03"},
+			{Schedule: "futures", Date: "2025-01-03"},
+		}
+		if len(admin.triggers) != len(want) {
+			t.Fatalf("queued %d triggers, want %d", len(admin.triggers), len(want))
+		}
+		for _, w := range want {
+			if got := <-admin.triggers; got != w {
+				t.Errorf("queued %+v, want %+v", got, w)
+			}
+		}
+	})
+
+	t.Run("trigger queue full", func(t *testing.T) {
+		for len(admin.triggers) < cap(admin.triggers)-1 {
+			admin.triggers <- triggerRequest{}
+		}
+		defer func() {
+			for len(admin.triggers) > 0 {
+				<-admin.triggers
+			}
+		}()
+		if status, _ := do("POST", "/trigger?date=2025-01-02", "secret"); status != http.StatusServiceUnavailable {
+			t.Errorf("status = %d, want 503", status)
+		}
+		if len(admin.triggers) != cap(admin.triggers)-1 {
+			t.Errorf("a full queue took %d triggers", len(admin.triggers)-cap(admin.triggers)+1)
+		}
+	})
+
+	t.Run("history", func(t *testing.T) {
+		if status, _ := do("GET", "/history?limit=0", "secret"); status != http.StatusBadRequest {
+			t.Errorf("limit=0: status = %d, want 400", status)
+		}
+		if status, _ := do("GET", "/history?limit=5&schedule=equities", "secret"); status != http.StatusOK {
+			t.Errorf("status = %d, want 200", status)
+		}
+	})
+
+	t.Run("pause and resume", func(t *testing.T) {
+		if status, body := do("POST", "/pause", "secret"); status != http.StatusOK || body["paused"] != true {
+			t.Errorf("pause: %d %v", status, body)
+		}
+		if !r.paused.Load() {
+			t.Error("runner not paused")
+		}
+		if _, body := do("GET", "/status", "secret"); body["paused"] != true {
+			t.Errorf("status paused = %v, want true", body["paused"])
+		}
+		if status, body := do("POST", "/resume", "secret"); status != http.StatusOK || body["paused"] != false {
+			t.Errorf("resume: %d %v", status, body)
+		}
+		if r.paused.Load() {
+			t.Error("runner still paused")
+		}
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		// Stands in for the main loop
+		answer := func(err error) {
+			go func() {
+				req := <-admin.reloads
+				req.done <- err
+			}()
+		}
+
+		answer(nil)
+		if status, body := do("POST", "/reload", "secret"); status != http.StatusOK || body["status"] != "reloaded" {
+			t.Errorf("reload: %d %v", status, body)
+		}
+		answer(errors.New("bad schedule"))
+		if status, body := do("POST", "/reload", "secret"); status != http.StatusUnprocessableEntity || body["error"] != "bad schedule" {
+			t.Errorf("failed reload: %d %v, want 422", status, body)
+		}
+	})
+}
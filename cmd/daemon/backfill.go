@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// backfillDates returns the dates among a schedule's last limit market days
+// that it still has to download, oldest first: those after its last
+// download, and those whose last run failed. Today counts once its
+// scheduled time has passed.
+func backfillDates(job *scheduledJob, state scheduleState, limit int) []string {
+	scheduler := job.scheduler
+	if state.LastDownloadDate == "" {
+		return nil
+	}
+
+	// Walk back from today over the last limit market days, within a year
+	var window []string
+	day := time.Now().In(scheduler.Location())
+	if !scheduler.PassedToday() {
+		day = day.AddDate(0, 0, -1)
+	}
+	for oldest := day.AddDate(-1, 0, 0); len(window) < limit && day.After(oldest); day = day.AddDate(0, 0, -1) {
+		date := day.Format("2006-01-02")
+		if date <= state.LastDownloadDate && len(state.Dates) == 0 {
+			break
+		}
+		if scheduler.IsMarketDay(date) {
+			window = append(window, date)
+		}
+	}
+	slices.Reverse(window)
+
+	var dates []string
+	for _, date := range window {
+		rec, ok := state.Dates[date]
+		switch {
+		case ok && !rec.Succeeded():
+			dates = append(dates, date)
+		case !ok && date > state.LastDownloadDate:
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// backfill downloads the dates each schedule missed among its last limit
+// market days, e.g. while the daemon was down, and retries the ones that
+// failed. Schedules that never downloaded have nothing to catch up on.
+func (r *runner) backfill(ctx context.Context, jobs []*scheduledJob, limit int) {
+	for _, job := range jobs {
+		logger := r.logger.With(zap.String("schedule", job.name))
+		state := r.tracker.schedule(job.name)
+		dates := backfillDates(job, state, limit)
+		if len(dates) == 0 {
+			continue
+		}
+		logger.Info("backfilling missed dates", zap.String("lastDownload", state.LastDownloadDate), zap.Strings("dates", dates))
+		for _, date := range dates {
+			if ctx.Err() != nil {
+				return
+			}
+			r.runJob(ctx, job, date, triggerBackfill)
+		}
+	}
+}
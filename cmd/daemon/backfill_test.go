@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// lastMarketDays returns a schedule's last n market days, oldest first,
+// counting today once its scheduled time has passed
+func lastMarketDays(job *scheduledJob, n int) []string {
+	day := time.Now().In(job.scheduler.Location())
+	if !job.scheduler.PassedToday() {
+		day = day.AddDate(0, 0, -1)
+	}
+	var days []string
+	for ; len(days) < n; day = day.AddDate(0, 0, -1) {
+		if date := day.Format("2006-01-02"); job.scheduler.IsMarketDay(date) {
+			days = append(days, date)
+		}
+	}
+	slices.Reverse(days)
+	return days
+}
+
+func TestBackfillDates(t *testing.T) {
+	job := newTestJob(t, "equities")
+	d := lastMarketDays(job, 5)
+
+	tests := []struct {
+		name  string
+		state scheduleState
+		limit int
+		want  []string
+	}{
+		{"never downloaded", scheduleState{}, 5, nil},
+		{"up to date", scheduleState{LastDownloadDate: d[4]}, 5, nil},
+		{"missed days", scheduleState{LastDownloadDate: d[2]}, 5, []string{d[3], d[4]}},
+		{"missed more than the limit", scheduleState{LastDownloadDate: d[0]}, 2, []string{d[3], d[4]}},
+		{"failed run", scheduleState{
+			LastDownloadDate: d[4],
+			Dates: map[string]runRecord{
+				d[1]: {Result: resultFailed},
+				d[2]: {Result: resultPartial},
+				d[3]: {Result: resultSuccess},
+				d[4]: {Result: resultNoTasks},
+			},
+		}, 5, []string{d[1], d[2]}},
+		{"failed run outside the limit", scheduleState{
+			LastDownloadDate: d[4],
+			Dates:            map[string]runRecord{d[1]: {Result: resultFailed}},
+		}, 3, nil},
+		{"missed and failed", scheduleState{
+			LastDownloadDate: d[2],
+			Dates:            map[string]runRecord{d[0]: {Result: resultFailed}, d[2]: {Result: resultSuccess}},
+		}, 5, []string{d[0], d[3], d[4]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backfillDates(job, tt.state, tt.limit); !slices.Equal(got, tt.want) {
+				t.Errorf("backfillDates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
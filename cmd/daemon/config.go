@@ -1,32 +1,82 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"time"
 )
 
 // DaemonConfig holds daemon-specific configuration
 type DaemonConfig struct {
-	ConfigPath     string // Path to downloader config YAML
-	ScheduleHour   int    // Hour in timezone (default: 20 for 8 PM)
-	ScheduleMinute int    // Minute (default: 0)
-	Timezone       string // Timezone (default: America/New_York)
-	StateFile      string // File to track last download date
-	RunOnStartup   bool   // Check/download on startup if missed
+	ConfigPath    string // Path to downloader config YAML
+	Schedule      string // Cron expression in Timezone (default: "0 20 * * *", 8 PM)
+	SchedulesFile string // JSON list of schedules, replacing Schedule
+	Timezone      string // Timezone (default: America/New_York)
+	StateFile     string // File to track last download date
+	RunOnStartup  bool   // Check/download on startup if missed
+	PruneKeepDays int    // Market days of data to keep after each download (0: never prune)
+	BackfillDays  int    // Missed market days to download on startup (0: none)
+
+	RetryInterval time.Duration // Wait before retrying the failed tasks of a run (default: 30m)
+	RetryAttempts int           // Retries of the failed tasks of a run (0: never retry)
+	RunTimeout    time.Duration // Stop the transfers of a run after this long (0: no limit)
+
+	Jitter time.Duration // Random delay of scheduled downloads, up to this long (0: none)
+	Window string        // Time of day downloads may start, e.g. "20:00-23:00" (empty: any)
+
+	IntradayInterval time.Duration // Poll today's files this often during market hours (0: disabled)
+
+	AdminAddr  string // Listen address of the admin API (empty: disabled)
+	AdminToken string // Bearer token required by the admin API (empty: none)
+
+	ServerURL   string // Faker server to reload with each new date (empty: none)
+	ServerToken string // Bearer token sent with the reload (empty: none)
+
+	Digest     string // Send a "daily" or "weekly" digest instead of a notification per run (empty: per run)
+	DigestTime string // Time of day, in Timezone, the digest is sent (default: 08:00; weekly on Mondays)
 }
 
 // LoadDaemonConfig loads configuration from environment variables
 func LoadDaemonConfig() *DaemonConfig {
 	return &DaemonConfig{
-		ConfigPath:     getEnvOrDefault("DAEMON_CONFIG_PATH", "/app/configs/default.yaml"),
-		ScheduleHour:   getEnvIntOrDefault("DAEMON_SCHEDULE_HOUR", 20),
-		ScheduleMinute: getEnvIntOrDefault("DAEMON_SCHEDULE_MINUTE", 0),
-		Timezone:       getEnvOrDefault("DAEMON_TIMEZONE", "America/New_York"),
-		StateFile:      getEnvOrDefault("DAEMON_STATE_FILE", "/app/data/.daemon-state"),
-		RunOnStartup:   getEnvBoolOrDefault("DAEMON_RUN_ON_STARTUP", true),
+		ConfigPath:    getEnvOrDefault("DAEMON_CONFIG_PATH", "/app/configs/default.yaml"),
+		Schedule:      getEnvOrDefault("DAEMON_SCHEDULE", legacySchedule()),
+		SchedulesFile: getEnvOrDefault("DAEMON_SCHEDULES_FILE", ""),
+		Timezone:      getEnvOrDefault("DAEMON_TIMEZONE", "America/New_York"),
+		StateFile:     getEnvOrDefault("DAEMON_STATE_FILE", "/app/data/.daemon-state"),
+		RunOnStartup:  getEnvBoolOrDefault("DAEMON_RUN_ON_STARTUP", true),
+		PruneKeepDays: getEnvIntOrDefault("DAEMON_PRUNE_KEEP_DAYS", 0),
+		BackfillDays:  getEnvIntOrDefault("DAEMON_BACKFILL_DAYS", 0),
+
+		RetryInterval: getEnvDurationOrDefault("DAEMON_RETRY_INTERVAL", 30*time.Minute),
+		RetryAttempts: getEnvIntOrDefault("DAEMON_RETRY_ATTEMPTS", 3),
+		RunTimeout:    getEnvDurationOrDefault("DAEMON_RUN_TIMEOUT", 3*time.Hour),
+
+		Jitter: getEnvDurationOrDefault("DAEMON_JITTER", 0),
+		Window: getEnvOrDefault("DAEMON_WINDOW", ""),
+
+		IntradayInterval: getEnvDurationOrDefault("DAEMON_INTRADAY_INTERVAL", 0),
+
+		AdminAddr:  getEnvOrDefault("DAEMON_ADMIN_ADDR", ""),
+		AdminToken: getEnvOrDefault("DAEMON_ADMIN_TOKEN", ""),
+
+		ServerURL:   getEnvOrDefault("DAEMON_SERVER_URL", ""),
+		ServerToken: getEnvOrDefault("DAEMON_SERVER_TOKEN", ""),
+
+		Digest:     getEnvOrDefault("DAEMON_DIGEST", ""),
+		DigestTime: getEnvOrDefault("DAEMON_DIGEST_TIME", "08:00"),
 	}
 }
 
+// legacySchedule builds the default schedule from DAEMON_SCHEDULE_HOUR and
+// DAEMON_SCHEDULE_MINUTE, which DAEMON_SCHEDULE replaces.
+func legacySchedule() string {
+	hour := getEnvIntOrDefault("DAEMON_SCHEDULE_HOUR", 20)
+	minute := getEnvIntOrDefault("DAEMON_SCHEDULE_MINUTE", 0)
+	return fmt.Sprintf("%d %d * * *", minute, hour)
+}
+
 func getEnvOrDefault(key, defaultVal string) string {
 	if val := os.Getenv(key); val != "" {
 		return val
@@ -51,3 +101,12 @@ func getEnvBoolOrDefault(key string, defaultVal bool) bool {
 	}
 	return defaultVal
 }
+
+func getEnvDurationOrDefault(key string, defaultVal time.Duration) time.Duration {
+	if val := os.Getenv(key); val != "" {
+		if d, err := time.ParseDuration(val); err == nil {
+			return d
+		}
+	}
+	return defaultVal
+}
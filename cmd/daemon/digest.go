@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/notify"
+)
+
+// digestSchedule is when the digest replacing per-run notifications is
+// sent: every day, or every Monday, at a time of day in DAEMON_TIMEZONE
+type digestSchedule struct {
+	period   string        // notify.DigestDaily or notify.DigestWeekly
+	at       time.Duration // since midnight
+	location *time.Location
+}
+
+// parseDigest parses DAEMON_DIGEST and DAEMON_DIGEST_TIME. An empty period
+// is no digest.
+func parseDigest(period, at, timezone string) (*digestSchedule, error) {
+	if period == "" {
+		return nil, nil
+	}
+	if period != notify.DigestDaily && period != notify.DigestWeekly {
+		return nil, fmt.Errorf("invalid DAEMON_DIGEST %q, want daily or weekly", period)
+	}
+	clock, err := parseClock(at)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DAEMON_DIGEST_TIME: %w", err)
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return &digestSchedule{period: period, at: clock, location: loc}, nil
+}
+
+// last returns the last time a digest was due, at or before now
+func (d *digestSchedule) last(now time.Time) time.Time {
+	now = now.In(d.location)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, d.location)
+	due := day.Add(d.at)
+	if due.After(now) {
+		day = day.AddDate(0, 0, -1)
+	}
+	if d.period == notify.DigestWeekly {
+		day = day.AddDate(0, 0, -int((day.Weekday()+6)%7)) // back to Monday
+		if day.Add(d.at).After(now) {
+			day = day.AddDate(0, 0, -7)
+		}
+	}
+	return day.Add(d.at)
+}
+
+// length returns how long a digest period is
+func (d *digestSchedule) length() time.Duration {
+	if d.period == notify.DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// sendDigest sends the digest once it is due, covering the time since the
+// last one, or one period when none was sent. A digest that fails to send
+// is not sent again; queue notifications to retry it.
+func (r *runner) sendDigest(ctx context.Context, jobs []*scheduledJob) {
+	if r.digest == nil {
+		return
+	}
+	to := r.digest.last(time.Now())
+	from := r.tracker.LastDigest()
+	if !from.Before(to) {
+		return
+	}
+	if from.IsZero() {
+		from = to.Add(-r.digest.length())
+	}
+
+	digest := buildDigest(r.tracker, jobs, r.digest.period, from, to)
+	if err := r.notifier.SendDigest(ctx, digest); err != nil {
+		r.logger.Warn("failed to send digest", zap.Error(err))
+	} else {
+		r.logger.Info("digest sent", zap.String("period", digest.Period), zap.Time("from", from), zap.Time("to", to))
+	}
+	if err := r.tracker.SetLastDigest(to); err != nil {
+		r.logger.Error("failed to record digest", zap.Error(err))
+	}
+}
+
+// buildDigest summarizes the runs of each schedule that finished between
+// from and to: the dates downloaded and failed by their last run, and the
+// market days whose scheduled time passed in the period without a run.
+// Schedules that never downloaded have no gaps.
+func buildDigest(tracker *DownloadTracker, jobs []*scheduledJob, period string, from, to time.Time) *notify.Digest {
+	digest := &notify.Digest{Period: period, From: from, To: to}
+	for _, job := range jobs {
+		state := tracker.schedule(job.name)
+		s := notify.DigestSchedule{Downloaded: []string{}, Failed: []string{}, Gaps: []string{}}
+		if job.name != defaultScheduleName {
+			s.Name = job.name
+		}
+
+		for date, rec := range state.Dates {
+			if rec.Finished.Before(from) || !rec.Finished.Before(to) {
+				continue
+			}
+			s.Bytes += rec.Bytes
+			if rec.Succeeded() {
+				s.Downloaded = append(s.Downloaded, date)
+			} else {
+				s.Failed = append(s.Failed, date)
+				s.FailedFiles += rec.Failed
+			}
+		}
+
+		if state.LastDownloadDate != "" {
+			loc := job.scheduler.Location()
+			for day := from.In(loc).AddDate(0, 0, -1); day.Before(to); day = day.AddDate(0, 0, 1) {
+				date := day.Format("2006-01-02")
+				first := job.scheduler.FirstRunOn(date)
+				if first.IsZero() || first.Before(from) || !first.Before(to) || !job.scheduler.IsMarketDay(date) {
+					continue
+				}
+				if _, ok := state.Dates[date]; !ok {
+					s.Gaps = append(s.Gaps, date)
+				}
+			}
+		}
+
+		slices.Sort(s.Downloaded)
+		slices.Sort(s.Failed)
+		digest.Schedules = append(digest.Schedules, s)
+	}
+	return digest
+}
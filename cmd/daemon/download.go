@@ -2,10 +2,11 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -13,65 +14,24 @@ import (
 	"github.com/dgnsrekt/gexbot-downloader/internal/api"
 	"github.com/dgnsrekt/gexbot-downloader/internal/config"
 	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+	"github.com/dgnsrekt/gexbot-downloader/internal/export"
 	"github.com/dgnsrekt/gexbot-downloader/internal/staging"
 )
 
-// DownloadTracker tracks the last successfully downloaded date
-type DownloadTracker struct {
-	stateFile string
-}
-
-// NewDownloadTracker creates a new tracker with the given state file path
-func NewDownloadTracker(stateFile string) *DownloadTracker {
-	return &DownloadTracker{stateFile: stateFile}
-}
-
-// GetLastDownloadDate reads the last successful download date from state file
-func (t *DownloadTracker) GetLastDownloadDate() string {
-	data, err := os.ReadFile(t.stateFile)
-	if err != nil {
-		return ""
-	}
-	return strings.TrimSpace(string(data))
-}
-
-// SetLastDownloadDate writes the date to the state file
-func (t *DownloadTracker) SetLastDownloadDate(date string) error {
-	// Ensure directory exists
-	dir := filepath.Dir(t.stateFile)
-	if err := os.MkdirAll(dir, 0750); err != nil {
-		return err
-	}
-	return os.WriteFile(t.stateFile, []byte(date+"\n"), 0600)
-}
+// errRunTimedOut is returned by runs whose transfers did not finish in time.
+var errRunTimedOut = errors.New("run timed out")
 
-// AlreadyDownloaded checks if the given date was already downloaded
-func (t *DownloadTracker) AlreadyDownloaded(date string) bool {
-	return t.GetLastDownloadDate() == date
+// runOptions tune a daemon run
+type runOptions struct {
+	timeout  time.Duration // stop transfers after this long (0: no limit)
+	progress *progressHub  // receives task events (nil: none)
 }
 
 // executeDownload runs the download for the given date using existing internal packages.
 // Returns the batch result and any error that occurred.
-func executeDownload(ctx context.Context, cfg *config.Config, date string, logger *zap.Logger) (*download.BatchResult, error) {
+func executeDownload(ctx context.Context, cfg *config.Config, date string, opts runOptions, logger *zap.Logger) (*download.BatchResult, error) {
 	logger.Info("starting download", zap.String("date", date))
 
-	// Create API client
-	client := api.NewClient(
-		cfg.API.BaseURL,
-		cfg.API.APIKey,
-		cfg.Download.RatePerSecond,
-		time.Duration(cfg.API.TimeoutSec)*time.Second,
-		time.Duration(cfg.API.RetryDelay)*time.Second,
-		cfg.API.RetryCount,
-		logger,
-	)
-
-	// Create staging manager
-	stgMgr := staging.NewManager(cfg.Output.Directory)
-
-	// Create download manager
-	dlMgr := download.NewManager(client, stgMgr, cfg.Download.Workers, logger)
-
 	// Generate tasks for this date
 	tasks := generateTasksForDate(cfg, date)
 	logger.Info("generated tasks", zap.Int("count", len(tasks)))
@@ -80,49 +40,259 @@ func executeDownload(ctx context.Context, cfg *config.Config, date string, logge
 		logger.Warn("no tasks generated, check config")
 		return nil, nil
 	}
+	return executeTasks(ctx, cfg, date, tasks, opts, logger)
+}
+
+// executeTasks downloads tasks of a date, commits them and runs the
+// post-download steps. Transfers still running after opts.timeout are
+// stopped and count as failed, and the files that completed are committed
+// before errRunTimedOut is returned.
+func executeTasks(ctx context.Context, cfg *config.Config, date string, tasks []download.Task, opts runOptions, logger *zap.Logger) (*download.BatchResult, error) {
+	timeout := opts.timeout
+	client, err := newAPIClient(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create staging manager
+	stgMgr, err := newStagingManager(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep a manual download from staging at the same time
+	unlock, err := lockStaging(ctx, stgMgr, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Create download manager
+	dlMgr := download.NewManager(client, stgMgr, cfg.Download.Workers, logger)
+	dlMgr.SetSkipExisting(cfg.Download.ResumeEnabled)
+	dlMgr.SetVerifyExisting(cfg.Download.VerifyExisting)
+	dlMgr.SetValidatePayloads(cfg.Download.ValidatePayloads)
+	dlMgr.SetPackageWorkers(cfg.Packages.PackageWorkers())
+	if opts.progress != nil {
+		opts.progress.SetTotal(len(tasks))
+		dlMgr.SetProgress(opts.progress)
+	}
+
+	// Fail early instead of running out of space halfway through
+	if cfg.Download.DiskPreflight {
+		if err := dlMgr.Preflight(tasks, int64(cfg.Download.DiskHeadroomMB)<<20); err != nil {
+			return &download.BatchResult{Total: len(tasks)}, err
+		}
+	}
 
 	// Execute downloads
-	result, err := dlMgr.Execute(ctx, tasks)
+	execCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		execCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	result, err := dlMgr.Execute(execCtx, tasks)
 	if err != nil {
 		return result, err
 	}
+	timedOut := ctx.Err() == nil && errors.Is(execCtx.Err(), context.DeadlineExceeded)
+	if timedOut {
+		logger.Warn("download timed out", zap.String("date", date), zap.Duration("timeout", timeout))
+		result.MarkUnfinished(tasks, fmt.Sprintf("%s after %s", errRunTimedOut, timeout))
+	}
 
 	// Commit staging to final location and cleanup (only if there were actual downloads)
 	if result.Success > 0 {
-		if err := stgMgr.CommitStaging(date); err != nil {
-			logger.Warn("failed to commit staging", zap.String("date", date), zap.Error(err))
+		commitErr := stgMgr.CommitStaging(date)
+		if commitErr != nil {
+			logger.Warn("failed to commit staging", zap.String("date", date), zap.Error(commitErr))
 		}
 		if err := stgMgr.CleanupStaging(date); err != nil {
 			logger.Warn("failed to cleanup staging", zap.String("date", date), zap.Error(err))
 		}
 
-		// Auto-convert JSON to JSONL if enabled
-		if cfg.Output.AutoConvertToJSONL {
-			logger.Info("auto-converting JSON to JSONL")
+		// Convert to the configured output format
+		if format := cfg.Output.OutputFormat(); format != config.FormatJSON && !stgMgr.Remote() {
+			logger.Info("auto-converting output", zap.String("format", format))
 			dir := filepath.Join(cfg.Output.Directory, date)
-			if err := convertJSONToJSONL(dir, logger); err != nil {
+			if err := convertOutput(cfg, dir, logger); err != nil {
 				logger.Warn("auto-conversion failed", zap.String("date", date), zap.Error(err))
 			}
 		}
+		download.DedupeOutput(cfg, []string{date}, logger)
+		download.EncryptOutput(cfg, []string{date}, logger)
+		if commitErr == nil {
+			download.ArchiveOutput(ctx, cfg, []string{date}, logger)
+			download.RunPostDownloadHook(ctx, cfg, stgMgr, []string{date}, logger)
+		}
 	}
 
+	throughput := result.Throughput()
 	logger.Info("download complete",
+		zap.String("output", stgMgr.Location()),
 		zap.Int("total", result.Total),
 		zap.Int("success", result.Success),
 		zap.Int("skipped", result.Skipped),
 		zap.Int("not_found", result.NotFound),
 		zap.Int("failed", result.Failed),
+		zap.Int64("bytes", throughput.Bytes),
+		zap.Float64("p10_mb_per_sec", throughput.P10MBPerSec),
+		zap.Float64("p50_mb_per_sec", throughput.P50MBPerSec),
+		zap.Duration("p90_duration", throughput.P90Duration),
+		zap.Duration("max_duration", throughput.MaxDuration),
 	)
 
+	download.LogMirrorHealth(client, logger)
+
+	// Export run metrics to the Pushgateway and/or textfile
+	if err := dlMgr.Metrics().Export(ctx, cfg.Metrics.PushgatewayURL, cfg.Metrics.Job, cfg.Metrics.Textfile); err != nil {
+		logger.Warn("failed to export metrics", zap.Error(err))
+	}
+
 	if result.Failed > 0 {
 		for _, e := range result.Errors {
 			logger.Error("download error", zap.String("error", e))
 		}
 	}
 
+	if timedOut {
+		return result, fmt.Errorf("%w after %s", errRunTimedOut, timeout)
+	}
 	return result, nil
 }
 
+// newAPIClient creates the API client from the downloader config.
+func newAPIClient(cfg *config.Config, logger *zap.Logger) (*api.HTTPClient, error) {
+	// TLS customization for intercepting proxies (nil when unset)
+	tlsConfig, err := api.LoadTLSConfig(api.TLSOptions{
+		CAFile:             cfg.API.TLS.CAFile,
+		ClientCertFile:     cfg.API.TLS.ClientCertFile,
+		ClientKeyFile:      cfg.API.TLS.ClientKeyFile,
+		InsecureSkipVerify: cfg.API.TLS.InsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("configuring TLS: %w", err)
+	}
+
+	return api.NewClient(
+		cfg.API.BaseURL,
+		cfg.API.APIKey,
+		cfg.Download.RatePerSecond,
+		time.Duration(cfg.API.TimeoutSec)*time.Second,
+		time.Duration(cfg.API.RetryDelay)*time.Second,
+		cfg.API.RetryCount,
+		logger,
+		api.WithProxy(cfg.API.ProxyURL),
+		api.WithNoProxy(cfg.API.NoProxy),
+		api.WithProxyAuth(cfg.API.ProxyAuth.Username, cfg.API.ProxyAuth.Password),
+		api.WithTLSConfig(tlsConfig),
+		api.WithMirrors(cfg.API.Mirrors),
+		api.WithPackageRates(cfg.Packages.PackageRates()),
+		api.WithTimeouts(api.Timeouts{
+			Connect:        time.Duration(cfg.API.ConnectTimeoutSec) * time.Second,
+			ResponseHeader: time.Duration(cfg.API.HeaderTimeoutSec) * time.Second,
+			DownloadIdle:   time.Duration(cfg.API.DownloadIdleTimeoutSec) * time.Second,
+		}),
+		api.WithSegmentedDownload(api.SegmentOptions{
+			Segments: cfg.Download.Segments,
+			MinSize:  int64(cfg.Download.SegmentMinSizeMB) << 20,
+		}),
+	), nil
+}
+
+// recoverStaging commits or discards staging data left by a run that died
+// before committing, so completed files are not downloaded again
+func recoverStaging(ctx context.Context, cfg *config.Config, logger *zap.Logger) {
+	stgMgr, err := newStagingManager(cfg, logger)
+	if err != nil {
+		logger.Warn("staging recovery failed", zap.Error(err))
+		return
+	}
+	// The staging of a manual download still running is not left over
+	unlock, err := lockStaging(ctx, stgMgr, logger)
+	if err != nil {
+		logger.Warn("staging recovery failed", zap.Error(err))
+		return
+	}
+	defer unlock()
+	result, err := stgMgr.Recover()
+	if err != nil {
+		logger.Warn("staging recovery failed", zap.Error(err))
+		return
+	}
+	if len(result.Dates) == 0 {
+		return
+	}
+
+	logger.Info("recovered leftover staging",
+		zap.Strings("dates", result.Dates),
+		zap.Int("committed", result.Committed),
+		zap.Int("discarded", result.Discarded),
+	)
+
+	if cfg.Output.OutputFormat() != config.FormatJSON && !stgMgr.Remote() && result.Committed > 0 {
+		for _, date := range result.Dates {
+			dir := filepath.Join(cfg.Output.Directory, date)
+			if err := convertOutput(cfg, dir, logger); err != nil {
+				logger.Warn("auto-conversion failed", zap.String("date", date), zap.Error(err))
+			}
+		}
+	}
+}
+
+// lockStaging waits for other runs, such as a manual download, to release
+// the staging area and locks it
+func lockStaging(ctx context.Context, stgMgr *staging.Manager, logger *zap.Logger) (func(), error) {
+	unlock, err := stgMgr.Lock(ctx, func(holder string) {
+		logger.Info("waiting for another run to release the output directory", zap.String("holder", holder))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("locking output directory: %w", err)
+	}
+	return unlock, nil
+}
+
+// newStagingManager stages into the output directory, or into the local work
+// directory when the output is an s3://, gs:// or azblob:// URI. Remote dates
+// are converted to the output format before upload.
+func newStagingManager(cfg *config.Config, logger *zap.Logger) (*staging.Manager, error) {
+	if !cfg.Output.Remote() {
+		return staging.NewManager(cfg.Output.Directory), nil
+	}
+
+	storage, err := staging.OpenStorage(cfg.Output.Directory)
+	if err != nil {
+		return nil, err
+	}
+	workDir := cfg.Output.WorkDirectory()
+	if err := os.MkdirAll(workDir, 0750); err != nil {
+		return nil, fmt.Errorf("creating staging directory: %w", err)
+	}
+
+	stgMgr := staging.NewRemoteManager(workDir, storage)
+	stgMgr.SetPrepare(func(dir string) error {
+		return convertOutput(cfg, dir, logger)
+	})
+	return stgMgr, nil
+}
+
+// tradingCalendars returns the distinct trading calendars of the configured
+// tickers.
+func tradingCalendars(cfg *config.Config) []string {
+	tickers := cfg.Tickers
+	if len(tickers) == 0 {
+		tickers = config.DefaultTickers()
+	}
+	var calendars []string
+	for _, ticker := range tickers {
+		if cal := cfg.Calendar(ticker); !slices.Contains(calendars, cal) {
+			calendars = append(calendars, cal)
+		}
+	}
+	return calendars
+}
+
 // generateTasksForDate creates download tasks for a single date based on config
 func generateTasksForDate(cfg *config.Config, date string) []download.Task {
 	var tasks []download.Task
@@ -157,9 +327,19 @@ func generateTasksForDate(cfg *config.Config, date string) []download.Task {
 		}
 		pkgCategories["orderflow"] = cats
 	}
+	if cfg.Packages.Volatility.Enabled {
+		cats := cfg.Packages.Volatility.Categories
+		if len(cats) == 0 {
+			cats = config.ValidCategories[config.PackageVolatility]
+		}
+		pkgCategories["volatility"] = cats
+	}
 
-	// Generate tasks for all combinations
+	// Generate tasks for all combinations, for the tickers trading on date
 	for _, ticker := range tickers {
+		if !config.IsTradingDay(cfg.Calendar(ticker), date) {
+			continue
+		}
 		for pkg, categories := range pkgCategories {
 			for _, category := range categories {
 				tasks = append(tasks, download.Task{
@@ -175,100 +355,17 @@ func generateTasksForDate(cfg *config.Config, date string) []download.Task {
 	return tasks
 }
 
-// convertJSONToJSONL converts JSON files in a directory to JSONL format
-func convertJSONToJSONL(dir string, logger *zap.Logger) error {
-	var converted, skipped, failed int
-
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip directories and non-JSON files
-		if info.IsDir() || !strings.HasSuffix(path, ".json") {
-			return nil
-		}
-
-		// Skip staging directory
-		if strings.Contains(path, ".staging") {
-			return nil
-		}
-
-		jsonlPath := strings.TrimSuffix(path, ".json") + ".jsonl"
-
-		// Skip if JSONL already exists
-		if _, err := os.Stat(jsonlPath); err == nil {
-			logger.Debug("skipping, JSONL exists", zap.String("file", path))
-			skipped++
-			return nil
-		}
-
-		logger.Debug("converting", zap.String("file", path))
-
-		if err := convertFile(path, jsonlPath); err != nil {
-			logger.Error("conversion failed", zap.String("file", path), zap.Error(err))
-			failed++
-			return nil // Continue with other files
-		}
-
-		// Delete original JSON after successful conversion
-		if err := os.Remove(path); err != nil {
-			logger.Warn("failed to delete original", zap.String("file", path), zap.Error(err))
-		}
-
-		converted++
+// convertOutput converts a committed date directory to the configured
+// output format
+func convertOutput(cfg *config.Config, dir string, logger *zap.Logger) error {
+	switch cfg.Output.OutputFormat() {
+	case config.FormatJSONL:
+		return export.ConvertDirToJSONL(dir, false, logger)
+	case config.FormatJSONLZstd:
+		return export.ConvertDirToJSONL(dir, true, logger)
+	case config.FormatParquet:
+		return export.ConvertDirToParquet(dir, false, logger)
+	default:
 		return nil
-	})
-
-	if err != nil {
-		return err
 	}
-
-	logger.Info("conversion complete",
-		zap.Int("converted", converted),
-		zap.Int("skipped", skipped),
-		zap.Int("failed", failed),
-	)
-
-	return nil
-}
-
-// convertFile converts a single JSON array file to JSONL format
-func convertFile(jsonPath, jsonlPath string) error {
-	// Read JSON file
-	data, err := os.ReadFile(jsonPath)
-	if err != nil {
-		return err
-	}
-
-	// Parse as array of raw JSON messages
-	var items []json.RawMessage
-	if err := json.Unmarshal(data, &items); err != nil {
-		return err
-	}
-
-	// Create JSONL file
-	outFile, err := os.Create(jsonlPath)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = outFile.Close() }()
-
-	// Write each item as a line
-	for _, item := range items {
-		// Compact the JSON (remove whitespace)
-		compact, err := json.Marshal(item)
-		if err != nil {
-			return err
-		}
-
-		if _, err := outFile.Write(compact); err != nil {
-			return err
-		}
-		if _, err := outFile.WriteString("\n"); err != nil {
-			return err
-		}
-	}
-
-	return nil
 }
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// runErrorsKept is the number of download errors stored with a run.
+const runErrorsKept = 20
+
+// What started a run
+const (
+	triggerSchedule = "schedule"
+	triggerManual   = "manual"
+	triggerBackfill = "backfill"
+	triggerRetry    = "retry" // of the tasks that failed in a run
+)
+
+// Outcome of a run
+const (
+	resultRunning = "running"
+	resultSuccess = "success"
+	resultPartial = "partial" // some files failed
+	resultFailed  = "failed"
+	resultNoTasks = "no_tasks"
+)
+
+// runRecord describes one download run of a schedule
+type runRecord struct {
+	Schedule string    `json:"schedule"`
+	Date     string    `json:"date"`
+	Trigger  string    `json:"trigger"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished,omitzero"`
+	Duration string    `json:"duration,omitempty"`
+	Result   string    `json:"result"`
+	Error    string    `json:"error,omitempty"`
+	Attempts int       `json:"attempts,omitempty"` // runs of the date so far, this one included
+
+	Total    int      `json:"total"`
+	Success  int      `json:"success"`
+	Skipped  int      `json:"skipped"`
+	NotFound int      `json:"not_found"`
+	Failed   int      `json:"failed"`
+	Bytes    int64    `json:"bytes,omitempty"`  // downloaded by the runs of the date so far
+	Errors   []string `json:"errors,omitempty"` // first runErrorsKept download errors
+}
+
+// Succeeded reports whether the run downloaded everything it could
+func (r runRecord) Succeeded() bool {
+	return r.Result == resultSuccess || r.Result == resultNoTasks
+}
+
+// runHistory keeps the run in progress in memory and stores finished runs
+// in the state file, for the admin API and backfill
+type runHistory struct {
+	tracker *DownloadTracker
+
+	mu      sync.Mutex
+	current *runRecord
+}
+
+// Start records the start of a run
+func (h *runHistory) Start(schedule, date, trigger string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.current = &runRecord{
+		Schedule: schedule,
+		Date:     date,
+		Trigger:  trigger,
+		Started:  time.Now(),
+		Result:   resultRunning,
+	}
+}
+
+// Finish stores the outcome of the run in progress and returns it
+func (h *runHistory) Finish(result *download.BatchResult, err error) (runRecord, error) {
+	h.mu.Lock()
+	if h.current == nil {
+		h.mu.Unlock()
+		return runRecord{}, nil
+	}
+	rec := *h.current
+	h.current = nil
+	h.mu.Unlock()
+
+	rec.Finished = time.Now()
+	rec.Duration = rec.Finished.Sub(rec.Started).Round(time.Second).String()
+	if result != nil {
+		rec.Total = result.Total
+		rec.Success = result.Success
+		rec.Skipped = result.Skipped
+		rec.NotFound = result.NotFound
+		rec.Failed = result.Failed
+		rec.Bytes = result.Throughput().Bytes
+		rec.Errors = result.Errors[:min(len(result.Errors), runErrorsKept)]
+	}
+	switch {
+	case err != nil:
+		rec.Result = resultFailed
+		rec.Error = err.Error()
+	case result == nil:
+		rec.Result = resultNoTasks
+	case result.Failed > 0:
+		rec.Result = resultPartial
+	default:
+		rec.Result = resultSuccess
+	}
+	return rec, h.tracker.RecordRun(rec)
+}
+
+// Current returns the run in progress, or nil
+func (h *runHistory) Current() *runRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.current == nil {
+		return nil
+	}
+	rec := *h.current
+	return &rec
+}
+
+// Last returns the last finished run of a schedule, or nil
+func (h *runHistory) Last(schedule string) *runRecord {
+	if runs := h.tracker.Runs(schedule, 1); len(runs) > 0 {
+		return &runs[0]
+	}
+	return nil
+}
+
+// Runs returns up to limit finished runs, newest first, of one schedule or
+// of all when schedule is empty. Each date keeps its last run only.
+func (h *runHistory) Runs(schedule string, limit int) []runRecord {
+	return h.tracker.Runs(schedule, limit)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api"
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// intradayPoller re-fetches today's files during market hours and appends
+// the records newer than the last one on disk to the final JSONL files, so
+// the faker can replay the session so far.
+type intradayPoller struct {
+	cfg    *config.Config
+	client *api.HTTPClient
+	logger *zap.Logger
+
+	date       string
+	validators map[download.Task]api.Validators // skip files unchanged since the last poll
+	last       map[download.Task]int64          // newest timestamp appended per file
+}
+
+func newIntradayPoller(cfg *config.Config, logger *zap.Logger) (*intradayPoller, error) {
+	client, err := newAPIClient(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &intradayPoller{cfg: cfg, client: client, logger: logger}, nil
+}
+
+// Reconfigure switches to a reloaded config, keeping what was polled today
+func (p *intradayPoller) Reconfigure(cfg *config.Config) error {
+	client, err := newAPIClient(cfg, p.logger)
+	if err != nil {
+		return err
+	}
+	p.cfg, p.client = cfg, client
+	return nil
+}
+
+// Poll fetches today's files of the tickers whose market is open at now and
+// appends new records.
+func (p *intradayPoller) Poll(ctx context.Context, now time.Time) {
+	date := config.TradingDate(now)
+	if date != p.date {
+		p.date = date
+		p.validators = make(map[download.Task]api.Validators)
+		p.last = make(map[download.Task]int64)
+	}
+
+	start := time.Now()
+	appended, files := 0, 0
+	for _, task := range generateTasksForDate(p.cfg, date) {
+		if ctx.Err() != nil {
+			break
+		}
+		if !config.IsTradingOpen(p.cfg.Calendar(task.Ticker), now) {
+			continue
+		}
+		n, err := p.pollTask(ctx, task)
+		switch {
+		case errors.Is(err, api.ErrNotFound), errors.Is(err, api.ErrNotModified):
+		case err != nil:
+			p.logger.Warn("intraday poll failed", zap.String("task", task.String()), zap.Error(err))
+		case n > 0:
+			appended += n
+			files++
+		}
+	}
+
+	p.logger.Info("intraday poll complete",
+		zap.String("date", date),
+		zap.Int("records", appended),
+		zap.Int("files", files),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
+
+func (p *intradayPoller) pollTask(ctx context.Context, task download.Task) (int, error) {
+	url, err := p.client.GetDownloadURL(ctx, task.Ticker, task.Package, task.Category, task.Date)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp("", "gexbot-intraday-*.json")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, validators, err := p.client.DownloadFileConditional(ctx, url, p.validators[task], tmp)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	path := filepath.Join(p.cfg.Output.Directory, task.Date, task.Ticker, task.Package, task.Category+data.JSONLExt)
+	last, ok := p.last[task]
+	if !ok {
+		if last, err = lastTimestamp(path); err != nil {
+			return 0, err
+		}
+	}
+
+	n, newest, err := appendNewer(tmp, path, last)
+	if err != nil {
+		return 0, err
+	}
+	p.validators[task] = validators
+	p.last[task] = max(last, newest)
+	return n, nil
+}
+
+// lastTimestamp returns the timestamp of the last record in a JSONL file, or
+// 0 when the file does not exist yet.
+func lastTimestamp(path string) (int64, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var line []byte
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		if b := bytes.TrimSpace(scanner.Bytes()); len(b) > 0 {
+			line = append(line[:0], b...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if line == nil {
+		return 0, nil
+	}
+
+	var rec struct {
+		Timestamp int64 `json:"timestamp"`
+	}
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return 0, fmt.Errorf("last record of %s: %w", path, err)
+	}
+	return rec.Timestamp, nil
+}
+
+// appendNewer reads a JSON array from src and appends the records with a
+// timestamp after the given one to the JSONL file at path, in a single
+// write. It returns the records appended and the newest timestamp seen.
+func appendNewer(src io.Reader, path string, after int64) (int, int64, error) {
+	dec := json.NewDecoder(src)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return 0, 0, fmt.Errorf("expected JSON array")
+	}
+
+	var buf bytes.Buffer
+	n, newest := 0, after
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return 0, 0, fmt.Errorf("decoding record: %w", err)
+		}
+		var rec struct {
+			Timestamp int64 `json:"timestamp"`
+		}
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			return 0, 0, fmt.Errorf("decoding record: %w", err)
+		}
+		if rec.Timestamp <= after {
+			continue
+		}
+		if err := json.Compact(&buf, raw); err != nil {
+			return 0, 0, err
+		}
+		buf.WriteByte('\n')
+		n++
+		newest = max(newest, rec.Timestamp)
+	}
+	if n == 0 {
+		return 0, newest, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return 0, 0, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		_ = file.Close()
+		return 0, 0, err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return 0, 0, err
+	}
+	return n, newest, file.Close()
+}
+
+// discardIntraday removes the JSONL files polled for date so the end-of-day
+// download replaces them with the complete files instead of skipping them as
+// existing.
+func discardIntraday(cfg *config.Config, date string, logger *zap.Logger) {
+	removed := 0
+	for _, task := range generateTasksForDate(cfg, date) {
+		path := filepath.Join(cfg.Output.Directory, date, task.Ticker, task.Package, task.Category+data.JSONLExt)
+		if err := os.Remove(path); err == nil {
+			removed++
+		} else if !errors.Is(err, os.ErrNotExist) {
+			logger.Warn("failed to remove intraday file", zap.String("file", path), zap.Error(err))
+		}
+	}
+	if removed > 0 {
+		logger.Info("discarded intraday files before end-of-day download",
+			zap.String("date", date),
+			zap.Int("files", removed),
+		)
+	}
+}
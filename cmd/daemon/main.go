@@ -2,16 +2,26 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/dedupe"
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
 	"github.com/dgnsrekt/gexbot-downloader/internal/notify"
+	"github.com/dgnsrekt/gexbot-downloader/internal/retention"
+	"github.com/dgnsrekt/gexbot-downloader/internal/sdnotify"
 )
 
 func main() {
@@ -27,67 +37,126 @@ func run() int {
 	}
 	defer func() { _ = logger.Sync() }()
 
-	// Load daemon config
-	daemonCfg := LoadDaemonConfig()
-
-	logger.Info("daemon configuration loaded",
-		zap.Int("scheduleHour", daemonCfg.ScheduleHour),
-		zap.Int("scheduleMinute", daemonCfg.ScheduleMinute),
-		zap.String("timezone", daemonCfg.Timezone),
-		zap.String("configPath", daemonCfg.ConfigPath),
-		zap.String("stateFile", daemonCfg.StateFile),
-		zap.Bool("runOnStartup", daemonCfg.RunOnStartup),
-	)
-
-	// Load downloader config
-	cfg, err := config.Load(daemonCfg.ConfigPath)
+	// Load daemon, downloader and notification config and the schedules
+	s, err := loadSettings(logger)
 	if err != nil {
-		logger.Error("failed to load downloader config", zap.Error(err))
-		return 1
-	}
-
-	logger.Info("downloader configuration loaded",
-		zap.String("outputDir", cfg.Output.Directory),
-		zap.Int("workers", cfg.Download.Workers),
-		zap.Int("tickers", len(cfg.Tickers)),
-	)
-
-	// Load notification config
-	notifyCfg := notify.LoadConfig()
-	if err := notifyCfg.Validate(); err != nil {
-		logger.Error("invalid notification config", zap.Error(err))
+		logger.Error("failed to load configuration", zap.Error(err))
 		return 1
 	}
-	notifier := notify.New(notifyCfg, logger)
-
-	logger.Info("notification configuration loaded",
-		zap.Bool("enabled", notifyCfg.Enabled),
-		zap.String("server", notifyCfg.Server),
-		zap.String("topic", notifyCfg.Topic),
-	)
+	daemonCfg, cfg := s.daemonCfg, s.cfg
+	data.SetEncryptionKey(s.encryptionKey)
+	var jobs jobList
+	jobs.Store(s.jobs)
 
 	// Setup context with cancellation for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Setup signal handling
+	// Setup signal handling; SIGHUP reloads the configuration and SIGUSR1
+	// pauses or resumes scheduled downloads
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	pauseCh := make(chan os.Signal, 1)
+	if len(pauseSignals) > 0 {
+		signal.Notify(pauseCh, pauseSignals...)
+	}
 
-	// Create scheduler and tracker
-	scheduler := NewScheduler(daemonCfg.ScheduleHour, daemonCfg.ScheduleMinute, daemonCfg.Timezone)
 	tracker := NewDownloadTracker(daemonCfg.StateFile)
 
-	logger.Info("daemon started",
-		zap.String("schedule", fmt.Sprintf("%02d:%02d %s", daemonCfg.ScheduleHour, daemonCfg.ScheduleMinute, daemonCfg.Timezone)),
-	)
+	logger.Info("daemon started", zap.Int("schedules", len(s.jobs)))
+
+	// Commit or discard staging left by a previous crash
+	recoverStaging(ctx, cfg, logger)
+
+	// Intraday polling appends to the final files, so needs local output
+	var intraday *intradayPoller
+	if daemonCfg.IntradayInterval > 0 {
+		if cfg.Output.Remote() {
+			logger.Warn("intraday polling disabled for remote output", zap.String("output", cfg.Output.Directory))
+		} else if intraday, err = newIntradayPoller(cfg, logger); err != nil {
+			logger.Error("failed to create intraday poller", zap.Error(err))
+			return 1
+		}
+	}
+	var nextPoll time.Time
+
+	r := &runner{
+		tracker:       tracker,
+		history:       &runHistory{tracker: tracker},
+		metrics:       newDaemonMetrics(&jobs, tracker),
+		progress:      newProgressHub(),
+		notifier:      s.notifier,
+		intraday:      intraday != nil,
+		pruneKeepDays: daemonCfg.PruneKeepDays,
+		retryInterval: daemonCfg.RetryInterval,
+		retryAttempts: daemonCfg.RetryAttempts,
+		runTimeout:    daemonCfg.RunTimeout,
+		serverURL:     daemonCfg.ServerURL,
+		serverToken:   daemonCfg.ServerToken,
+		jitter:        daemonCfg.Jitter,
+		window:        s.window,
+		digest:        s.digest,
+		logger:        logger,
+		retries:       make(map[retryKey]*pendingRetry),
+		delayed:       make(map[string]delayedRun),
+		wake:          time.NewTimer(0),
+	}
+	r.wake.Stop()
+
+	// reload replaces the settings, keeping the running ones if the new
+	// ones fail to load
+	reload := func() error {
+		s, err := r.reload(&jobs, daemonCfg, intraday)
+		if err != nil {
+			return err
+		}
+		cfg = s.cfg
+		return nil
+	}
+
+	// Admin API; triggered downloads and reloads are run by the main loop
+	var triggers <-chan triggerRequest
+	var reloads <-chan reloadRequest
+	if daemonCfg.AdminAddr != "" {
+		admin := newAdminServer(&jobs, r, daemonCfg.AdminToken, logger)
+		triggers = admin.triggers
+		reloads = admin.reloads
+		go func() {
+			if err := admin.Serve(ctx, daemonCfg.AdminAddr); err != nil {
+				logger.Error("admin server failed", zap.Error(err))
+			}
+		}()
+		logger.Info("admin API listening", zap.String("addr", daemonCfg.AdminAddr))
+	}
+
+	// Tell systemd the daemon is up; the heartbeat keeps its watchdog fed
+	// while the main loop is alive
+	notifySystemd(sdnotify.Ready+"\n"+sdnotify.Status("idle"), logger)
+	var wd *watchdog
+	var heartbeat <-chan time.Time
+	if interval := sdnotify.WatchdogInterval(); interval > 0 {
+		wd = startWatchdog(ctx, interval, r.busy.Load, logger)
+		beat := time.NewTicker(interval / 4)
+		defer beat.Stop()
+		heartbeat = beat.C
+		logger.Info("systemd watchdog enabled", zap.Duration("interval", interval))
+	}
+
+	// Catch up on market days missed while the daemon was down, but none
+	// that pruning would delete again
+	if backfillDays := daemonCfg.BackfillDays; backfillDays > 0 {
+		if daemonCfg.PruneKeepDays > 0 {
+			backfillDays = min(backfillDays, daemonCfg.PruneKeepDays)
+		}
+		r.backfill(ctx, jobs.Load(), backfillDays)
+	}
 
 	// Check on startup if enabled
 	if daemonCfg.RunOnStartup {
 		logger.Info("checking for missed download on startup")
-		if shouldDownload(scheduler, tracker, logger) {
-			runDownload(ctx, cfg, scheduler, tracker, notifier, logger)
-		}
+		r.runDueJobs(ctx, jobs.Load())
 	}
 
 	// Main loop - check every minute
@@ -98,12 +167,45 @@ func run() int {
 		select {
 		case sig := <-sigCh:
 			logger.Info("received shutdown signal", zap.String("signal", sig.String()))
+			notifySystemd(sdnotify.Stopping, logger)
 			cancel()
 			return 0
 
+		case <-hupCh:
+			logger.Info("received SIGHUP, reloading configuration")
+			notifySystemd(sdnotify.Reloading, logger)
+			_ = reload()
+			notifySystemd(sdnotify.Ready, logger)
+
+		case sig := <-pauseCh:
+			r.setPaused(!r.paused.Load(), sig.String())
+
+		case req := <-reloads:
+			notifySystemd(sdnotify.Reloading, logger)
+			req.done <- reload()
+			notifySystemd(sdnotify.Ready, logger)
+
+		case <-heartbeat:
+			wd.Beat()
+
+		case req := <-triggers:
+			// The schedule may have been removed by a reload since
+			if job := findJob(jobs.Load(), req.Schedule); job != nil {
+				r.runJob(ctx, job, req.Date, triggerManual)
+			}
+
+		case <-r.wake.C:
+			r.runDelayed(ctx, jobs.Load())
+
 		case <-ticker.C:
-			if shouldDownload(scheduler, tracker, logger) {
-				runDownload(ctx, cfg, scheduler, tracker, notifier, logger)
+			r.runDueJobs(ctx, jobs.Load())
+			r.runRetries(ctx, jobs.Load())
+			r.flushNotifications(ctx)
+			r.sendDigest(ctx, jobs.Load())
+
+			if now := time.Now(); intraday != nil && !now.Before(nextPoll) && shouldPollIntraday(cfg, now, jobs.Load(), tracker) {
+				intraday.Poll(ctx, now)
+				nextPoll = now.Add(daemonCfg.IntradayInterval)
 			}
 
 		case <-ctx.Done():
@@ -113,12 +215,176 @@ func run() int {
 	}
 }
 
+// runner runs the downloads of schedules, both scheduled and triggered
+// through the admin API, and records them in the tracker and history
+type runner struct {
+	tracker       *DownloadTracker
+	history       *runHistory
+	metrics       *daemonMetrics
+	progress      *progressHub
+	notifier      notify.Notifier
+	intraday      bool // intraday polling is enabled
+	pruneKeepDays int
+	retryInterval time.Duration
+	retryAttempts int
+	runTimeout    time.Duration
+	serverURL     string // faker server reloaded after each new date
+	serverToken   string
+	jitter        time.Duration
+	window        *downloadWindow
+	digest        *digestSchedule // replaces per-run notifications (nil: none)
+	logger        *zap.Logger
+
+	busy    atomic.Bool // a job is running
+	paused  atomic.Bool // scheduled downloads and retries wait
+	retries map[retryKey]*pendingRetry
+	delayed map[string]delayedRun // by schedule
+	wake    *time.Timer           // fires at the earliest delayed run
+}
+
+// delayedRun is a scheduled download held back by jitter
+type delayedRun struct {
+	date  string
+	start time.Time
+}
+
+// runDueJobs runs the download of every schedule that is due, one after
+// another. Runs outside the download window are skipped, runs due while
+// paused are held, and with jitter runs are delayed by a random time up to
+// it.
+func (r *runner) runDueJobs(ctx context.Context, jobs []*scheduledJob) {
+	r.runDelayed(ctx, jobs)
+	for _, job := range jobs {
+		logger := r.logger.With(zap.String("schedule", job.name))
+		if _, ok := r.delayed[job.name]; ok || !shouldDownload(job, r.tracker, logger) {
+			continue
+		}
+		date := job.scheduler.TodayDate()
+		now := time.Now()
+		if r.window != nil && !r.window.Contains(now.In(job.scheduler.Location())) {
+			logger.Info("scheduled run outside the download window, skipped", zap.String("window", r.window.String()))
+			continue
+		}
+		if r.paused.Load() {
+			r.delayed[job.name] = delayedRun{date: date, start: now}
+			logger.Info("download held while paused", zap.String("date", date))
+			continue
+		}
+		if r.jitter > 0 {
+			delay := rand.N(r.jitter)
+			r.delayed[job.name] = delayedRun{date: date, start: now.Add(delay)}
+			logger.Info("download delayed by jitter", zap.String("date", date), zap.Duration("delay", delay.Round(time.Second)))
+			continue
+		}
+		r.runJob(ctx, job, date, triggerSchedule)
+	}
+	r.resetWake()
+}
+
+// runDelayed runs the delayed downloads that are due, unless paused. Those
+// of schedules removed by a reload are dropped.
+func (r *runner) runDelayed(ctx context.Context, jobs []*scheduledJob) {
+	if r.paused.Load() {
+		return
+	}
+	now := time.Now()
+	for name, run := range r.delayed {
+		if now.Before(run.start) {
+			continue
+		}
+		delete(r.delayed, name)
+		job := findJob(jobs, name)
+		if job == nil || ctx.Err() != nil || r.tracker.AlreadyDownloaded(name, run.date) {
+			continue
+		}
+		r.runJob(ctx, job, run.date, triggerSchedule)
+	}
+	r.resetWake()
+}
+
+// resetWake sets the wake timer to the earliest delayed download
+func (r *runner) resetWake() {
+	var next time.Time
+	for _, run := range r.delayed {
+		if next.IsZero() || run.start.Before(next) {
+			next = run.start
+		}
+	}
+	if next.IsZero() || r.paused.Load() {
+		r.wake.Stop()
+		return
+	}
+	r.wake.Reset(time.Until(next))
+}
+
+// runJob downloads date for a schedule and prunes old dates. Intraday files
+// of the schedule's scope are discarded first when date is today.
+func (r *runner) runJob(ctx context.Context, job *scheduledJob, date, trigger string) {
+	logger := r.logger.With(zap.String("schedule", job.name))
+	r.busy.Store(true)
+	defer r.busy.Store(false)
+	notifySystemd(sdnotify.Status(fmt.Sprintf("downloading %s for schedule %s", date, job.name)), logger)
+	defer func() { notifySystemd(sdnotify.Status(r.idleStatus()), logger) }()
+	if r.intraday && date == job.scheduler.TodayDate() {
+		discardIntraday(job.cfg, date, logger)
+	}
+	r.runDownload(ctx, job, date, trigger, nil, logger)
+	runPrune(job.cfg, r.pruneKeepDays, logger)
+}
+
+// idleStatus is the systemd status between downloads
+func (r *runner) idleStatus() string {
+	if r.paused.Load() {
+		return "paused"
+	}
+	return "idle"
+}
+
+// flushNotifications retries the notifications that failed to send and
+// are due
+func (r *runner) flushNotifications(ctx context.Context) {
+	if f, ok := r.notifier.(notify.Flusher); ok {
+		if err := f.Flush(ctx); err != nil {
+			r.logger.Warn("queued notifications failed again", zap.Error(err))
+		}
+	}
+}
+
+// observe records a finished run in the metrics. Scheduled runs drift from
+// the minute they were due, backfill runs from the first scheduled time of
+// their date.
+func (r *runner) observe(job *scheduledJob, rec runRecord, result *download.BatchResult, start time.Time) {
+	var bytes int64
+	if result != nil {
+		bytes = result.Throughput().Bytes
+	}
+	var due time.Time
+	switch rec.Trigger {
+	case triggerSchedule:
+		due = start.Truncate(time.Minute)
+	case triggerBackfill:
+		due = job.scheduler.FirstRunOn(rec.Date)
+	}
+	r.metrics.observe(rec, bytes, start.Sub(due), !due.IsZero())
+}
+
+// findJob returns the schedule named name, or nil
+func findJob(jobs []*scheduledJob, name string) *scheduledJob {
+	for _, job := range jobs {
+		if job.name == name {
+			return job
+		}
+	}
+	return nil
+}
+
 // shouldDownload checks if conditions are met for triggering a download
-func shouldDownload(scheduler *Scheduler, tracker *DownloadTracker, logger *zap.Logger) bool {
+func shouldDownload(job *scheduledJob, tracker *DownloadTracker, logger *zap.Logger) bool {
+	scheduler := job.scheduler
 	today := scheduler.TodayDate()
 
 	// Check if already downloaded today
-	if tracker.AlreadyDownloaded(today) {
+	if tracker.AlreadyDownloaded(job.name, today) {
 		return false
 	}
 
@@ -141,21 +407,79 @@ func shouldDownload(scheduler *Scheduler, tracker *DownloadTracker, logger *zap.
 	return true
 }
 
-// runDownload executes the download and updates the tracker
-func runDownload(ctx context.Context, cfg *config.Config, scheduler *Scheduler, tracker *DownloadTracker, notifier notify.Notifier, logger *zap.Logger) {
-	today := scheduler.TodayDate()
+// shouldPollIntraday reports whether today's files should be polled: a
+// configured ticker's market is open and some schedule has not run its
+// end-of-day download yet.
+func shouldPollIntraday(cfg *config.Config, now time.Time, jobs []*scheduledJob, tracker *DownloadTracker) bool {
+	open := slices.ContainsFunc(tradingCalendars(cfg), func(cal string) bool {
+		return config.IsTradingOpen(cal, now)
+	})
+	date := config.TradingDate(now)
+	return open && slices.ContainsFunc(jobs, func(job *scheduledJob) bool {
+		return !tracker.AlreadyDownloaded(job.name, date)
+	})
+}
 
-	logger.Info("starting scheduled download", zap.String("date", today))
-	start := time.Now()
+// runDownload executes the download of a date for a schedule, or of tasks
+// when not nil, schedules a retry of the tasks that failed and, for today,
+// updates the tracker and reloads the faker server
+func (r *runner) runDownload(ctx context.Context, job *scheduledJob, date, trigger string, tasks []download.Task, logger *zap.Logger) {
+	cfg := job.cfg
+	notifier := r.notifier
+	notifyRun := r.digest == nil // the digest reports runs instead
+	isToday := date == job.scheduler.TodayDate()
+
+	// Tell the notifications of several schedules and of retries apart
+	var labels []string
+	if job.name != defaultScheduleName {
+		labels = append(labels, job.name)
+	}
+	if p, ok := r.retries[retryKey{schedule: job.name, date: date}]; ok && trigger == triggerRetry {
+		labels = append(labels, fmt.Sprintf("retry %d", p.attempt))
+	}
+	label := date
+	if len(labels) > 0 {
+		label = fmt.Sprintf("%s (%s)", date, strings.Join(labels, ", "))
+	}
 
-	result, err := executeDownload(ctx, cfg, today, logger)
+	logger.Info("starting download run", zap.String("date", date), zap.String("trigger", trigger))
+	start := time.Now()
+	r.history.Start(job.name, date, trigger)
+	r.progress.Begin(job.name, date, trigger)
+
+	var result *download.BatchResult
+	var err error
+	opts := runOptions{timeout: r.runTimeout, progress: r.progress}
+	if tasks != nil {
+		result, err = executeTasks(ctx, cfg, date, tasks, opts, logger)
+	} else {
+		result, err = executeDownload(ctx, cfg, date, opts, logger)
+	}
 	duration := time.Since(start)
+	rec, recordErr := r.history.Finish(result, err)
+	if recordErr != nil {
+		logger.Error("failed to record run", zap.Error(recordErr))
+	}
+	r.progress.End(rec.Result)
+	r.observe(job, rec, result, start)
+
+	retry := r.scheduleRetry(job, date, trigger, tasks, result, err)
+	if retry != nil {
+		logger.Warn("failed downloads will be retried",
+			zap.String("date", date),
+			zap.Int("tasks", len(retry.tasks)),
+			zap.Int("attempt", retry.attempt),
+			zap.Time("at", retry.next),
+		)
+	}
 
 	if err != nil {
-		logger.Error("download failed", zap.Error(err), zap.String("date", today))
-		// Send failure notification
-		if notifyErr := notifier.SendFailure(ctx, result, today, duration, err); notifyErr != nil {
-			logger.Warn("failed to send failure notification", zap.Error(notifyErr))
+		logger.Error("download failed", zap.Error(err), zap.String("date", date))
+		// Send failure notification, unless it is retried; time-outs always
+		if notifyRun && (retry == nil || errors.Is(err, errRunTimedOut)) {
+			if notifyErr := notifier.SendFailure(ctx, result, label, duration, err); notifyErr != nil {
+				logger.Warn("failed to send failure notification", zap.Error(notifyErr))
+			}
 		}
 		return
 	}
@@ -163,27 +487,81 @@ func runDownload(ctx context.Context, cfg *config.Config, scheduler *Scheduler,
 	// Check if there were any failed downloads
 	if result != nil && result.Failed > 0 {
 		logger.Warn("download completed with failures",
-			zap.String("date", today),
+			zap.String("date", date),
 			zap.Int("failed", result.Failed),
 			zap.Duration("duration", duration),
 		)
-		// Send failure notification for partial failures
-		if notifyErr := notifier.SendFailure(ctx, result, today, duration, fmt.Errorf("%d downloads failed", result.Failed)); notifyErr != nil {
-			logger.Warn("failed to send failure notification", zap.Error(notifyErr))
+		// Send failure notification for partial failures, unless retried
+		if notifyRun && retry == nil {
+			if notifyErr := notifier.SendFailure(ctx, result, label, duration, fmt.Errorf("%d downloads failed", result.Failed)); notifyErr != nil {
+				logger.Warn("failed to send failure notification", zap.Error(notifyErr))
+			}
 		}
 	} else {
 		logger.Info("download succeeded",
-			zap.String("date", today),
+			zap.String("date", date),
 			zap.Duration("duration", duration),
 		)
-		// Send success notification
-		if notifyErr := notifier.SendSuccess(ctx, result, today, duration); notifyErr != nil {
-			logger.Warn("failed to send success notification", zap.Error(notifyErr))
+		// Send success notification, unless late files are retried
+		if notifyRun && retry == nil {
+			if notifyErr := notifier.SendSuccess(ctx, result, label, duration); notifyErr != nil {
+				logger.Warn("failed to send success notification", zap.Error(notifyErr))
+			}
 		}
 	}
 
-	// Update tracker to prevent re-download
-	if err := tracker.SetLastDownloadDate(today); err != nil {
+	// Update tracker to prevent re-download; earlier dates triggered through
+	// the admin API or retried by backfill leave it alone
+	if !isToday && (trigger != triggerBackfill || date <= r.tracker.GetLastDownloadDate(job.name)) {
+		return
+	}
+	if err := r.tracker.SetLastDownloadDate(job.name, date); err != nil {
 		logger.Error("failed to update tracker", zap.Error(err))
 	}
+
+	// Have the faker server serve the newly committed files
+	if r.serverURL != "" && result != nil && result.Success > 0 {
+		if err := reloadServer(ctx, r.serverURL, r.serverToken, date); err != nil {
+			logger.Warn("failed to reload faker server", zap.String("date", date), zap.Error(err))
+		} else {
+			logger.Info("faker server reloaded", zap.String("date", date), zap.String("server", r.serverURL))
+		}
+	}
+}
+
+// runPrune deletes date directories outside the last keepDays market days.
+// A keepDays of 0 disables pruning.
+func runPrune(cfg *config.Config, keepDays int, logger *zap.Logger) {
+	if keepDays <= 0 {
+		return
+	}
+	if cfg.Output.Remote() {
+		logger.Warn("pruning skipped for remote output; use bucket lifecycle rules",
+			zap.String("output", cfg.Output.Directory))
+		return
+	}
+
+	cutoff := config.NthMarketDayBefore(time.Now(), keepDays)
+	result, err := retention.Prune(cfg.Output.Directory, cutoff, false)
+	if err != nil {
+		logger.Error("prune failed", zap.Error(err))
+		return
+	}
+	if len(result.Dates) > 0 {
+		logger.Info("pruned old dates",
+			zap.String("cutoff", cutoff),
+			zap.Strings("dates", result.Dates),
+			zap.String("reclaimed", fmt.Sprintf("%.1f MB", float64(result.Bytes)/(1<<20))),
+		)
+	}
+
+	// Deduplicated content lives on while later dates link to it
+	if cfg.Output.Dedupe != dedupe.ModeOff {
+		freed, err := dedupe.Prune(cfg.Output.Directory)
+		if err != nil {
+			logger.Error("dedupe prune failed", zap.Error(err))
+		} else if freed > 0 {
+			logger.Info("pruned unreferenced dedupe objects", zap.String("reclaimed", fmt.Sprintf("%.1f MB", float64(freed)/(1<<20))))
+		}
+	}
 }
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// daemonMetrics collects per-schedule metrics of the daemon's runs for
+// Prometheus, served at /metrics of the admin API. They complement the
+// per-run metrics.pushgateway_url/metrics.textfile export with what alerting
+// on missed or failing nightly pulls needs.
+type daemonMetrics struct {
+	mu        sync.Mutex
+	jobs      *jobList
+	schedules map[string]*scheduleMetrics
+}
+
+type scheduleMetrics struct {
+	runs        map[string]int64 // by result
+	failedTasks int64
+	bytes       int64
+	lastRun     time.Time
+	lastSuccess time.Time
+	drift       time.Duration
+	hasDrift    bool
+}
+
+// newDaemonMetrics returns metrics for jobs
+func newDaemonMetrics(jobs *jobList, tracker *DownloadTracker) *daemonMetrics {
+	m := &daemonMetrics{jobs: jobs, schedules: make(map[string]*scheduleMetrics)}
+	m.seed(jobs.Load(), tracker)
+	return m
+}
+
+// seed adds the schedules of jobs that have no metrics yet, with their last
+// success read from the state file so it survives restarts
+func (m *daemonMetrics) seed(jobs []*scheduledJob, tracker *DownloadTracker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, job := range jobs {
+		if _, ok := m.schedules[job.name]; ok {
+			continue
+		}
+		s := &scheduleMetrics{runs: make(map[string]int64)}
+		for _, rec := range tracker.Runs(job.name, stateDatesKept) {
+			if rec.Succeeded() {
+				s.lastSuccess = rec.Finished
+				break
+			}
+		}
+		m.schedules[job.name] = s
+	}
+}
+
+// observe records a finished run. drift is how late it started after its
+// scheduled time; manual runs have none.
+func (m *daemonMetrics) observe(rec runRecord, bytes int64, drift time.Duration, hasDrift bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.schedules[rec.Schedule]
+	if !ok {
+		return
+	}
+	s.runs[rec.Result]++
+	s.failedTasks += int64(rec.Failed)
+	s.bytes += bytes
+	s.lastRun = rec.Finished
+	if rec.Succeeded() {
+		s.lastSuccess = rec.Finished
+	}
+	if hasDrift {
+		s.drift, s.hasDrift = drift, true
+	}
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format.
+func (m *daemonMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.schedules))
+	for name := range m.schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	header := func(name, typ, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+
+	header("gexbot_daemon_runs_total", "counter", "Download runs by schedule and result.")
+	for _, name := range names {
+		for _, result := range []string{resultSuccess, resultPartial, resultFailed, resultNoTasks} {
+			fmt.Fprintf(&b, "gexbot_daemon_runs_total{schedule=%q,result=%q} %d\n", name, result, m.schedules[name].runs[result])
+		}
+	}
+
+	header("gexbot_daemon_failed_tasks_total", "counter", "Files that failed to download, by schedule.")
+	for _, name := range names {
+		fmt.Fprintf(&b, "gexbot_daemon_failed_tasks_total{schedule=%q} %d\n", name, m.schedules[name].failedTasks)
+	}
+
+	header("gexbot_daemon_bytes_total", "counter", "Bytes downloaded, by schedule.")
+	for _, name := range names {
+		fmt.Fprintf(&b, "gexbot_daemon_bytes_total{schedule=%q} %d\n", name, m.schedules[name].bytes)
+	}
+
+	header("gexbot_daemon_last_run_timestamp_seconds", "gauge", "Unix time the last run of a schedule finished.")
+	for _, name := range names {
+		if t := m.schedules[name].lastRun; !t.IsZero() {
+			fmt.Fprintf(&b, "gexbot_daemon_last_run_timestamp_seconds{schedule=%q} %d\n", name, t.Unix())
+		}
+	}
+
+	header("gexbot_daemon_last_success_timestamp_seconds", "gauge", "Unix time the last successful run of a schedule finished.")
+	for _, name := range names {
+		if t := m.schedules[name].lastSuccess; !t.IsZero() {
+			fmt.Fprintf(&b, "gexbot_daemon_last_success_timestamp_seconds{schedule=%q} %d\n", name, t.Unix())
+		}
+	}
+
+	header("gexbot_daemon_schedule_drift_seconds", "gauge", "How late the last scheduled or backfill run of a schedule started after its scheduled time.")
+	for _, name := range names {
+		if s := m.schedules[name]; s.hasDrift {
+			fmt.Fprintf(&b, "gexbot_daemon_schedule_drift_seconds{schedule=%q} %g\n", name, s.drift.Seconds())
+		}
+	}
+
+	header("gexbot_daemon_next_run_timestamp_seconds", "gauge", "Unix time of the next scheduled run of a schedule.")
+	for _, job := range m.jobs.Load() {
+		if t := job.scheduler.NextRun(); !t.IsZero() {
+			fmt.Fprintf(&b, "gexbot_daemon_next_run_timestamp_seconds{schedule=%q} %d\n", job.name, t.Unix())
+		}
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+func (m *daemonMetrics) handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
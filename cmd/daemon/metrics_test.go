@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDaemonMetrics(t *testing.T) {
+	r, jobs := newTestRunner(t, newTestJob(t, "equities"), newTestJob(t, "futures"))
+
+	// The last success survives restarts through the state file
+	seeded := time.Unix(1735862400, 0)
+	if err := r.tracker.RecordRun(runRecord{Schedule: "futures", Date: "2025-01-02", Result: resultSuccess, Finished: seeded}); err != nil {
+		t.Fatal(err)
+	}
+	m := newDaemonMetrics(jobs, r.tracker)
+
+	finished := time.Unix(1735948800, 0)
+	m.observe(runRecord{Schedule: "equities", Result: resultPartial, Failed: 2, Finished: finished}, 1000, 90*time.Second, true)
+	m.observe(runRecord{Schedule: "equities", Result: resultSuccess, Finished: finished.Add(time.Hour)}, 500, 0, false)
+	m.observe(runRecord{Schedule: "removed", Result: resultFailed, Finished: finished}, 0, 0, false)
+
+	var b bytes.Buffer
+	if err := m.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+
+	want := []string{
+		"# TYPE gexbot_daemon_runs_total counter",
+		`gexbot_daemon_runs_total{schedule="equities",result="partial"} 1`,
+		`gexbot_daemon_runs_total{schedule="equities",result="success"} 1`,
+		`gexbot_daemon_runs_total{schedule="futures",result="success"} 0`,
+		`gexbot_daemon_failed_tasks_total{schedule="equities"} 2`,
+		`gexbot_daemon_bytes_total{schedule="equities"} 1500`,
+		fmt.Sprintf(`gexbot_daemon_last_run_timestamp_seconds{schedule="equities"} %d`, finished.Add(time.Hour).Unix()),
+		fmt.Sprintf(`gexbot_daemon_last_success_timestamp_seconds{schedule="equities"} %d`, finished.Add(time.Hour).Unix()),
+		fmt.Sprintf(`gexbot_daemon_last_success_timestamp_seconds{schedule="futures"} %d`, seeded.Unix()),
+		`gexbot_daemon_schedule_drift_seconds{schedule="equities"} 90`,
+		`gexbot_daemon_next_run_timestamp_seconds{schedule="equities"}`,
+	}
+	for _, line := range want {
+		if !strings.Contains(out, line) {
+			t.Errorf("metrics missing %q", line)
+		}
+	}
+
+	unwanted := []string{
+		`schedule="removed"`,
+		`gexbot_daemon_last_run_timestamp_seconds{schedule="futures"}`,
+		`gexbot_daemon_schedule_drift_seconds{schedule="futures"}`,
+	}
+	for _, line := range unwanted {
+		if strings.Contains(out, line) {
+			t.Errorf("metrics contain %q", line)
+		}
+	}
+	if t.Failed() {
+		t.Log(out)
+	}
+}
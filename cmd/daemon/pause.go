@@ -0,0 +1,25 @@
+package main
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/sdnotify"
+)
+
+// setPaused pauses or resumes scheduled downloads and retries and reports
+// whether that changed anything. A download in progress runs to its end;
+// schedules that fall due while paused are held and start on resume.
+func (r *runner) setPaused(paused bool, source string) bool {
+	if r.paused.Swap(paused) == paused {
+		return false
+	}
+	if paused {
+		r.logger.Info("scheduled downloads paused", zap.String("by", source))
+	} else {
+		r.logger.Info("scheduled downloads resumed", zap.String("by", source))
+	}
+	if !r.busy.Load() {
+		notifySystemd(sdnotify.Status(r.idleStatus()), r.logger)
+	}
+	return true
+}
@@ -0,0 +1,11 @@
+//go:build linux || darwin || freebsd
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// pauseSignals toggle pausing scheduled downloads
+var pauseSignals = []os.Signal{syscall.SIGUSR1}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !freebsd
+
+package main
+
+import "os"
+
+// pauseSignals is empty where there is no SIGUSR1; use the admin API
+var pauseSignals []os.Signal
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSetPaused(t *testing.T) {
+	job := newTestJob(t, "equities")
+	r, _ := newTestRunner(t, job)
+
+	if !r.setPaused(true, "test") {
+		t.Error("pausing reported no change")
+	}
+	if r.setPaused(true, "test") {
+		t.Error("pausing again reported a change")
+	}
+	if r.idleStatus() != "paused" {
+		t.Errorf("idleStatus = %q, want paused", r.idleStatus())
+	}
+
+	// Due delayed runs and retries are held without running or using up
+	// attempts
+	past := time.Now().Add(-time.Minute)
+	r.delayed[job.name] = delayedRun{date: "2025-01-02", start: past}
+	key := retryKey{schedule: job.name, date: "2025-01-02"}
+	r.retries[key] = &pendingRetry{attempt: 1, next: past}
+
+	ctx := context.Background()
+	r.runDelayed(ctx, []*scheduledJob{job})
+	r.runRetries(ctx, []*scheduledJob{job})
+	if _, ok := r.delayed[job.name]; !ok {
+		t.Error("delayed run dropped while paused")
+	}
+	if p, ok := r.retries[key]; !ok || p.attempt != 1 {
+		t.Errorf("retry = %+v while paused, want attempt 1 still pending", p)
+	}
+	select {
+	case <-r.wake.C:
+		t.Error("wake timer fired while paused")
+	default:
+	}
+
+	if !r.setPaused(false, "test") {
+		t.Error("resuming reported no change")
+	}
+	if r.setPaused(false, "test") {
+		t.Error("resuming again reported a change")
+	}
+	if r.idleStatus() != "idle" {
+		t.Errorf("idleStatus = %q, want idle", r.idleStatus())
+	}
+}
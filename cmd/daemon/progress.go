@@ -0,0 +1,280 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// progressKeepAlive is how often an idle /progress stream gets a comment,
+// so proxies keep it open
+const progressKeepAlive = 15 * time.Second
+
+// Progress event types
+const (
+	eventRunStarted  = "run_started"
+	eventRunFinished = "run_finished"
+	eventStarted     = "started"
+	eventBytes       = "bytes" // staged bytes of a task in flight, every second
+	eventCompleted   = "completed"
+	eventSkipped     = "skipped"
+	eventNotFound    = "not_found"
+	eventFailed      = "failed"
+)
+
+// progressEvent is one event of the /progress stream
+type progressEvent struct {
+	Type     string    `json:"type"`
+	Time     time.Time `json:"time"`
+	Schedule string    `json:"schedule"`
+	Date     string    `json:"date"`
+	Trigger  string    `json:"trigger,omitempty"`
+	Task     string    `json:"task,omitempty"`
+	Worker   *int      `json:"worker,omitempty"`
+	Bytes    int64     `json:"bytes,omitempty"`
+	Duration string    `json:"duration,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Done     int       `json:"done"`
+	Total    int       `json:"total"`
+	Result   string    `json:"result,omitempty"` // of run_finished
+}
+
+// progressHub streams the task events of the running download to /progress
+// subscribers. It implements download.Progress for the run in progress.
+type progressHub struct {
+	mu       sync.Mutex
+	subs     map[chan progressEvent]struct{}
+	run      *progressEvent        // run in progress, nil when idle
+	inflight map[int]*inflightTask // by worker
+}
+
+type inflightTask struct {
+	task    string
+	path    string
+	started time.Time
+}
+
+func newProgressHub() *progressHub {
+	return &progressHub{
+		subs:     make(map[chan progressEvent]struct{}),
+		inflight: make(map[int]*inflightTask),
+	}
+}
+
+// Begin starts a run of a schedule's date
+func (h *progressHub) Begin(schedule, date, trigger string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.run = &progressEvent{Schedule: schedule, Date: date, Trigger: trigger}
+	clear(h.inflight)
+	h.publish(h.event(eventRunStarted))
+}
+
+// SetTotal sets the number of tasks of the run
+func (h *progressHub) SetTotal(total int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.run != nil {
+		h.run.Total = total
+	}
+}
+
+// End finishes the run with its result
+func (h *progressHub) End(result string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.run == nil {
+		return
+	}
+	e := h.event(eventRunFinished)
+	e.Result = result
+	h.publish(e)
+	h.run = nil
+	clear(h.inflight)
+}
+
+func (h *progressHub) TaskStarted(worker int, task download.Task, stagingPath string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.run == nil {
+		return
+	}
+	h.inflight[worker] = &inflightTask{task: task.String(), path: stagingPath, started: time.Now()}
+	e := h.event(eventStarted)
+	e.Task, e.Worker = task.String(), &worker
+	h.publish(e)
+}
+
+func (h *progressHub) TaskFinished(worker int, result download.TaskResult) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.run == nil {
+		return
+	}
+	delete(h.inflight, worker)
+	h.run.Done++
+
+	var e progressEvent
+	switch {
+	case result.Skipped:
+		e = h.event(eventSkipped)
+	case result.NotFound:
+		e = h.event(eventNotFound)
+	case result.Success:
+		e = h.event(eventCompleted)
+		e.Bytes = result.BytesSize
+		e.Duration = result.Duration.Round(time.Millisecond).String()
+	default:
+		e = h.event(eventFailed)
+		if result.Error != nil {
+			e.Error = result.Error.Error()
+		}
+	}
+	e.Task, e.Worker = result.Task.String(), &worker
+	h.publish(e)
+}
+
+// event returns an event of the run in progress; h.mu must be held
+func (h *progressHub) event(typ string) progressEvent {
+	e := *h.run
+	e.Type = typ
+	e.Time = time.Now()
+	return e
+}
+
+// publish sends e to every subscriber; h.mu must be held. Subscribers that
+// fall behind are disconnected.
+func (h *progressHub) publish(e progressEvent) {
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// snapshot returns the run in progress, or nil, and bytes events of its
+// tasks in flight
+func (h *progressHub) snapshot() (*progressEvent, []progressEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.run == nil {
+		return nil, nil
+	}
+	run := *h.run
+	var events []progressEvent
+	for worker, t := range h.inflight {
+		e := h.event(eventBytes)
+		e.Task, e.Worker = t.task, &worker
+		e.Bytes = stagedBytes(t.path)
+		e.Duration = time.Since(t.started).Round(time.Second).String()
+		events = append(events, e)
+	}
+	sort.Slice(events, func(i, j int) bool { return *events[i].Worker < *events[j].Worker })
+	return &run, events
+}
+
+func (h *progressHub) subscribe() chan progressEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan progressEvent, 256)
+	h.subs[ch] = struct{}{}
+	return ch
+}
+
+func (h *progressHub) unsubscribe(ch chan progressEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[ch]; ok {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+// handler streams events as server-sent events: first a snapshot of the
+// run in progress (null when idle), then its task events, with bytes
+// events of the tasks in flight every second
+func (h *progressHub) handler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSONError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+
+	run, inflight := h.snapshot()
+	if err := writeEvent(w, "snapshot", run); err != nil {
+		return
+	}
+	for _, e := range inflight {
+		_ = writeEvent(w, e.Type, e)
+	}
+	flusher.Flush()
+
+	bytesTicker := time.NewTicker(time.Second)
+	defer bytesTicker.Stop()
+	keepAlive := time.NewTicker(progressKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, e.Type, e); err != nil {
+				return
+			}
+		case <-bytesTicker.C:
+			_, inflight := h.snapshot()
+			if len(inflight) == 0 {
+				continue
+			}
+			for _, e := range inflight {
+				if err := writeEvent(w, e.Type, e); err != nil {
+					return
+				}
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, typ string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", typ, data)
+	return err
+}
+
+// stagedBytes returns how much of a task has been written to staging
+func stagedBytes(path string) int64 {
+	if info, err := os.Stat(path + ".tmp"); err == nil {
+		return info.Size()
+	}
+	if info, err := os.Stat(path); err == nil {
+		return info.Size()
+	}
+	return 0
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readFrame reads one server-sent event up to its blank line
+func readFrame(t *testing.T, r *bufio.Reader) (event, data string) {
+	t.Helper()
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading frame: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		default:
+			t.Fatalf("unexpected line %q", line)
+		}
+	}
+}
+
+func TestProgressHandlerFraming(t *testing.T) {
+	hub := newProgressHub()
+	srv := httptest.NewServer(http.HandlerFunc(hub.handler))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	body := bufio.NewReader(resp.Body)
+
+	// Idle: the snapshot is null
+	if event, data := readFrame(t, body); event != "snapshot" || data != "null" {
+		t.Fatalf("first frame = %q %q, want an idle snapshot", event, data)
+	}
+
+	hub.Begin("equities", "2025-01-02", triggerManual)
+	hub.SetTotal(3)
+	hub.End(resultSuccess)
+
+	for _, want := range []string{eventRunStarted, eventRunFinished} {
+		event, data := readFrame(t, body)
+		var e progressEvent
+		if err := json.Unmarshal([]byte(data), &e); err != nil {
+			t.Fatalf("%s data %q: %v", event, data, err)
+		}
+		if event != want || e.Type != want || e.Schedule != "equities" || e.Date != "2025-01-02" {
+			t.Errorf("frame = %q %+v, want %s of equities 2025-01-02", event, e, want)
+		}
+		if want == eventRunFinished && (e.Result != resultSuccess || e.Total != 3) {
+			t.Errorf("run_finished result = %q, total = %d; want success of 3", e.Result, e.Total)
+		}
+	}
+}
+
+func TestProgressHandlerDisconnect(t *testing.T) {
+	hub := newProgressHub()
+	hub.Begin("equities", "2025-01-02", triggerManual)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/progress", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		hub.handler(rec, req)
+		close(done)
+	}()
+
+	// Subscribed once the handler is past its snapshot
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		hub.mu.Lock()
+		n := len(hub.subs)
+		hub.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("handler never subscribed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler still streaming after the client went away")
+	}
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if len(hub.subs) != 0 {
+		t.Errorf("%d subscribers left after disconnect, want 0", len(hub.subs))
+	}
+}
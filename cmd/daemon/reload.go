@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/notify"
+)
+
+// settings are what the daemon loads on start and again on SIGHUP or
+// /reload: the daemon and downloader config, the schedules and the notifier
+type settings struct {
+	daemonCfg     *DaemonConfig
+	cfg           *config.Config
+	encryptionKey []byte
+	jobs          []*scheduledJob
+	window        *downloadWindow
+	digest        *digestSchedule
+	notifier      notify.Notifier
+}
+
+// loadSettings reads DAEMON_ENV_FILE, the environment and the downloader
+// config and builds the schedules. Nothing is applied, so a reload that
+// fails keeps the running settings.
+func loadSettings(logger *zap.Logger) (*settings, error) {
+	if path := os.Getenv("DAEMON_ENV_FILE"); path != "" {
+		if err := loadEnvFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	// Load daemon config
+	daemonCfg := LoadDaemonConfig()
+
+	logger.Info("daemon configuration loaded",
+		zap.String("schedule", daemonCfg.Schedule),
+		zap.String("schedulesFile", daemonCfg.SchedulesFile),
+		zap.String("timezone", daemonCfg.Timezone),
+		zap.String("configPath", daemonCfg.ConfigPath),
+		zap.String("stateFile", daemonCfg.StateFile),
+		zap.Bool("runOnStartup", daemonCfg.RunOnStartup),
+		zap.Int("pruneKeepDays", daemonCfg.PruneKeepDays),
+		zap.Int("backfillDays", daemonCfg.BackfillDays),
+		zap.Duration("retryInterval", daemonCfg.RetryInterval),
+		zap.Int("retryAttempts", daemonCfg.RetryAttempts),
+		zap.Duration("runTimeout", daemonCfg.RunTimeout),
+		zap.Duration("jitter", daemonCfg.Jitter),
+		zap.String("window", daemonCfg.Window),
+		zap.Duration("intradayInterval", daemonCfg.IntradayInterval),
+		zap.String("adminAddr", daemonCfg.AdminAddr),
+		zap.String("serverURL", daemonCfg.ServerURL),
+		zap.String("digest", daemonCfg.Digest),
+	)
+
+	window, err := parseWindow(daemonCfg.Window)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := parseDigest(daemonCfg.Digest, daemonCfg.DigestTime, daemonCfg.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load downloader config
+	cfg, err := config.Load(daemonCfg.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading downloader config: %w", err)
+	}
+
+	// Resume checks decrypt encrypted output to verify it
+	var key []byte
+	if cfg.Output.EncryptionKeyFile != "" {
+		if key, err = data.ReadKeyFile(cfg.Output.EncryptionKeyFile); err != nil {
+			return nil, fmt.Errorf("loading encryption key: %w", err)
+		}
+	}
+
+	logger.Info("downloader configuration loaded",
+		zap.String("outputDir", cfg.Output.Directory),
+		zap.Int("workers", cfg.Download.Workers),
+		zap.Int("tickers", len(cfg.Tickers)),
+	)
+
+	// Load notification config
+	notifyCfg := notify.LoadConfig()
+	if err := notifyCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid notification config: %w", err)
+	}
+
+	logger.Info("notification configuration loaded",
+		zap.Bool("enabled", notifyCfg.Enabled),
+		zap.String("server", notifyCfg.Server),
+		zap.String("topic", notifyCfg.Topic),
+		zap.Strings("backends", notifyCfg.EnabledBackends()),
+		zap.Strings("successBackends", notifyCfg.SuccessBackends()),
+		zap.Strings("failureBackends", notifyCfg.FailureBackends()),
+	)
+
+	// Create schedulers
+	entries, err := loadSchedules(daemonCfg)
+	if err != nil {
+		return nil, err
+	}
+	entries = splitTickerSchedules(entries, cfg)
+	var jobs []*scheduledJob
+	for _, entry := range entries {
+		job, err := newScheduledJob(entry, cfg)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+		logger.Info("schedule loaded",
+			zap.String("name", job.name),
+			zap.String("schedule", fmt.Sprintf("%s %s", entry.Schedule, job.scheduler.Location())),
+			zap.Strings("tickers", job.cfg.Tickers),
+			zap.Time("nextRun", job.scheduler.NextRun()),
+		)
+	}
+
+	return &settings{
+		daemonCfg:     daemonCfg,
+		cfg:           cfg,
+		encryptionKey: key,
+		jobs:          jobs,
+		window:        window,
+		digest:        digest,
+		notifier:      notify.New(notifyCfg, logger),
+	}, nil
+}
+
+// reload loads the settings again and applies them to r, jobs and the
+// intraday poller (nil: none), keeping the running ones if the new ones fail
+// to load. It returns the applied settings.
+func (r *runner) reload(jobs *jobList, running *DaemonConfig, intraday *intradayPoller) (*settings, error) {
+	s, err := loadSettings(r.logger)
+	if err != nil {
+		r.logger.Error("reload failed, keeping the running configuration", zap.Error(err))
+		return nil, err
+	}
+	warnRestartNeeded(running, s.daemonCfg, r.logger)
+	if intraday != nil {
+		if err := intraday.Reconfigure(s.cfg); err != nil {
+			r.logger.Error("reload failed, keeping the running configuration", zap.Error(err))
+			return nil, err
+		}
+	}
+	data.SetEncryptionKey(s.encryptionKey)
+	jobs.Store(s.jobs)
+	r.metrics.seed(s.jobs, r.tracker)
+	r.notifier = s.notifier
+	r.pruneKeepDays = s.daemonCfg.PruneKeepDays
+	r.retryInterval = s.daemonCfg.RetryInterval
+	r.retryAttempts = s.daemonCfg.RetryAttempts
+	r.runTimeout = s.daemonCfg.RunTimeout
+	r.serverURL = s.daemonCfg.ServerURL
+	r.serverToken = s.daemonCfg.ServerToken
+	r.jitter = s.daemonCfg.Jitter
+	r.window = s.window
+	r.digest = s.digest
+	r.logger.Info("configuration reloaded", zap.Int("schedules", len(s.jobs)))
+	return s, nil
+}
+
+// warnRestartNeeded logs the settings that changed but only apply on restart
+func warnRestartNeeded(old, cur *DaemonConfig, logger *zap.Logger) {
+	for name, changed := range map[string]bool{
+		"DAEMON_STATE_FILE":        old.StateFile != cur.StateFile,
+		"DAEMON_INTRADAY_INTERVAL": old.IntradayInterval != cur.IntradayInterval,
+		"DAEMON_ADMIN_ADDR":        old.AdminAddr != cur.AdminAddr,
+		"DAEMON_ADMIN_TOKEN":       old.AdminToken != cur.AdminToken,
+	} {
+		if changed {
+			logger.Warn("setting changed but needs a restart", zap.String("setting", name))
+		}
+	}
+}
+
+// loadEnvFile sets the variables of a KEY=VALUE file, such as
+// gexbot.example.env or a systemd EnvironmentFile, in the environment. Blank
+// lines and # comments are skipped, and values may be quoted.
+func loadEnvFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("reading DAEMON_ENV_FILE: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("DAEMON_ENV_FILE %s:%d: want KEY=VALUE", path, n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// jobList holds the current schedules, replaced on reload while the admin
+// API reads them
+type jobList struct {
+	p atomic.Pointer[[]*scheduledJob]
+}
+
+func (l *jobList) Load() []*scheduledJob {
+	if jobs := l.p.Load(); jobs != nil {
+		return *jobs
+	}
+	return nil
+}
+
+func (l *jobList) Store(jobs []*scheduledJob) {
+	l.p.Store(&jobs)
+}
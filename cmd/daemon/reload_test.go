@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testConfigYAML = `api:
+  api_key: test
+tickers: [SPX, NDX]
+packages:
+  classic:
+    enabled: true
+    categories: [gex_full]
+`
+
+func TestRunnerReload(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(cfgPath, []byte(testConfigYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DAEMON_ENV_FILE", "")
+	t.Setenv("DAEMON_CONFIG_PATH", cfgPath)
+	t.Setenv("DAEMON_SCHEDULES_FILE", "")
+	t.Setenv("DAEMON_SCHEDULE", "30 18 * * 1-5")
+	t.Setenv("DAEMON_RETRY_ATTEMPTS", "5")
+	t.Setenv("DAEMON_WINDOW", "18:00-23:00")
+
+	r, jobs := newTestRunner(t, newTestJob(t, "equities"))
+	running := LoadDaemonConfig()
+
+	s, err := r.reload(jobs, running, nil)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if len(s.cfg.Tickers) != 2 {
+		t.Errorf("settings tickers = %v, want SPX and NDX", s.cfg.Tickers)
+	}
+	loaded := jobs.Load()
+	if len(loaded) != 1 || loaded[0].name != defaultScheduleName || loaded[0].scheduler.schedule.String() != "30 18 * * 1-5" {
+		t.Fatalf("jobs after reload = %+v, want the default schedule", loaded)
+	}
+	if r.retryAttempts != 5 || r.window == nil {
+		t.Errorf("runner retryAttempts = %d, window = %v; want 5 and a window", r.retryAttempts, r.window)
+	}
+	if _, ok := r.metrics.schedules[defaultScheduleName]; !ok {
+		t.Error("reloaded schedule has no metrics")
+	}
+
+	// Each invalid setting fails the reload and keeps the running ones
+	tests := []struct {
+		name, env, value string
+	}{
+		{"cron expression", "DAEMON_SCHEDULE", "61 18 * * *"},
+		{"window", "DAEMON_WINDOW", "evening"},
+		{"config file", "DAEMON_CONFIG_PATH", filepath.Join(t.TempDir(), "missing.yaml")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DAEMON_RETRY_ATTEMPTS", "1")
+			t.Setenv(tt.env, tt.value)
+			if _, err := r.reload(jobs, running, nil); err == nil {
+				t.Fatal("reload succeeded")
+			}
+			if got := jobs.Load(); len(got) != 1 || got[0] != loaded[0] {
+				t.Errorf("jobs = %+v, want the running ones", got)
+			}
+			if r.retryAttempts != 5 {
+				t.Errorf("retryAttempts = %d, want the running 5", r.retryAttempts)
+			}
+		})
+	}
+}
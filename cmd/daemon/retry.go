@@ -0,0 +1,114 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+	"github.com/dgnsrekt/gexbot-downloader/internal/sdnotify"
+)
+
+// retryKey identifies the date of a schedule whose failed tasks are retried
+type retryKey struct {
+	schedule string
+	date     string
+}
+
+// pendingRetry is the next retry of the tasks that failed in a run
+type pendingRetry struct {
+	tasks   []download.Task
+	attempt int // 1 for the first retry
+	next    time.Time
+}
+
+// scheduleRetry replaces the pending retry of a schedule's date after one of
+// its runs. The tasks that failed are retried after DAEMON_RETRY_INTERVAL,
+// up to DAEMON_RETRY_ATTEMPTS times; a retry that fails as a whole is
+// repeated with the same tasks, and one that timed out with those it did
+// not finish. Within the download window, today's
+// failed and not yet published files are retried until it closes. Returns
+// nil when nothing is left to retry.
+func (r *runner) scheduleRetry(job *scheduledJob, date, trigger string, tasks []download.Task, result *download.BatchResult, err error) *pendingRetry {
+	key := retryKey{schedule: job.name, date: date}
+	attempt := 1
+	if prev, ok := r.retries[key]; ok && trigger == triggerRetry {
+		attempt = prev.attempt + 1
+	}
+	delete(r.retries, key)
+
+	next := time.Now().Add(r.retryInterval)
+	inWindow := r.window != nil && date == job.scheduler.TodayDate() && r.window.Contains(next.In(job.scheduler.Location()))
+
+	var failed []download.Task
+	switch {
+	case errors.Is(err, errRunTimedOut) && result != nil:
+		failed = result.FailedTasks()
+	case err != nil && trigger == triggerRetry:
+		failed = tasks
+	case err == nil && result != nil && inWindow:
+		failed = result.TasksWithStatus(download.StatusFailed, download.StatusNotFound)
+	case err == nil && result != nil:
+		failed = result.FailedTasks()
+	}
+	if len(failed) == 0 || (attempt > r.retryAttempts && !inWindow) {
+		return nil
+	}
+
+	p := &pendingRetry{tasks: failed, attempt: attempt, next: next}
+	r.retries[key] = p
+	return p
+}
+
+// runRetries retries the failed tasks that are due, oldest date first.
+// While paused they wait without using up attempts. Retries of schedules
+// removed by a reload are dropped.
+func (r *runner) runRetries(ctx context.Context, jobs []*scheduledJob) {
+	if r.paused.Load() {
+		return
+	}
+	now := time.Now()
+	var due []retryKey
+	for key, p := range r.retries {
+		if !now.Before(p.next) {
+			due = append(due, key)
+		}
+	}
+	slices.SortFunc(due, func(a, b retryKey) int {
+		return cmp.Or(cmp.Compare(a.date, b.date), cmp.Compare(a.schedule, b.schedule))
+	})
+
+	for _, key := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		job := findJob(jobs, key.schedule)
+		if job == nil {
+			delete(r.retries, key)
+			continue
+		}
+		r.runRetry(ctx, job, key.date, r.retries[key])
+	}
+}
+
+// runRetry downloads the failed tasks of a schedule's date again
+func (r *runner) runRetry(ctx context.Context, job *scheduledJob, date string, p *pendingRetry) {
+	logger := r.logger.With(zap.String("schedule", job.name))
+	r.busy.Store(true)
+	defer r.busy.Store(false)
+	notifySystemd(sdnotify.Status(fmt.Sprintf("retrying %d failed downloads of %s for schedule %s", len(p.tasks), date, job.name)), logger)
+	defer func() { notifySystemd(sdnotify.Status(r.idleStatus()), logger) }()
+
+	logger.Info("retrying failed downloads",
+		zap.String("date", date),
+		zap.Int("tasks", len(p.tasks)),
+		zap.Int("attempt", p.attempt),
+		zap.Int("maxAttempts", r.retryAttempts),
+	)
+	r.runDownload(ctx, job, date, triggerRetry, p.tasks, logger)
+}
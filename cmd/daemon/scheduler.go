@@ -1,37 +1,65 @@
 package main
 
 import (
+	"slices"
 	"time"
 
-	"github.com/scmhub/calendar"
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/cron"
 )
 
 // Scheduler handles time-based scheduling and market day validation
 type Scheduler struct {
-	hour     int
-	minute   int
+	schedule *cron.Schedule
 	location *time.Location
-	nyse     *calendar.Calendar
+
+	calendars []string // trading calendars of the configured tickers
 }
 
-// NewScheduler creates a new scheduler with the given schedule time and timezone
-func NewScheduler(hour, minute int, timezone string) *Scheduler {
+// NewScheduler creates a new scheduler running at the times of schedule in
+// timezone, on the days any of the calendars trades
+func NewScheduler(schedule *cron.Schedule, timezone string, calendars []string) *Scheduler {
 	loc, err := time.LoadLocation(timezone)
 	if err != nil {
 		loc = time.UTC
 	}
 	return &Scheduler{
-		hour:     hour,
-		minute:   minute,
+		schedule: schedule,
 		location: loc,
-		nyse:     calendar.XNYS(),
+
+		calendars: calendars,
 	}
 }
 
 // IsScheduledTime checks if current time matches the schedule (within the same minute)
 func (s *Scheduler) IsScheduledTime() bool {
-	now := time.Now().In(s.location)
-	return now.Hour() == s.hour && now.Minute() == s.minute
+	return s.schedule.Matches(time.Now().In(s.location))
+}
+
+// NextRun returns the next scheduled time after now, or the zero time if
+// the schedule never runs. It does not account for market days.
+func (s *Scheduler) NextRun() time.Time {
+	return s.schedule.Next(time.Now().In(s.location))
+}
+
+// PassedToday reports whether the schedule had a run time earlier today
+func (s *Scheduler) PassedToday() bool {
+	first := s.FirstRunOn(s.TodayDate())
+	return !first.IsZero() && !first.After(time.Now())
+}
+
+// FirstRunOn returns the first scheduled time on a date, or the zero time if
+// the schedule does not run that day
+func (s *Scheduler) FirstRunOn(date string) time.Time {
+	day, err := time.ParseInLocation("2006-01-02", date, s.location)
+	if err != nil {
+		return time.Time{}
+	}
+	first := s.schedule.Next(day.Add(-time.Minute))
+	if first.IsZero() || first.Format("2006-01-02") != date {
+		return time.Time{}
+	}
+	return first
 }
 
 // TodayDate returns today's date in YYYY-MM-DD format in the configured timezone
@@ -40,13 +68,11 @@ func (s *Scheduler) TodayDate() string {
 }
 
 // IsMarketDay checks if the given date is a trading day (not weekend/holiday)
+// on any of the scheduler's calendars
 func (s *Scheduler) IsMarketDay(dateStr string) bool {
-	// Parse as noon in the configured timezone to ensure correct date matching
-	t, err := time.ParseInLocation("2006-01-02 15:04:05", dateStr+" 12:00:00", s.location)
-	if err != nil {
-		return false
-	}
-	return s.nyse.IsBusinessDay(t)
+	return slices.ContainsFunc(s.calendars, func(cal string) bool {
+		return config.IsTradingDay(cal, dateStr)
+	})
 }
 
 // Location returns the scheduler's timezone location
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/cron"
+)
+
+// defaultScheduleName names the schedule built from DAEMON_SCHEDULE when no
+// DAEMON_SCHEDULES_FILE is given.
+const defaultScheduleName = "default"
+
+// ScheduleEntry is one independent download schedule from
+// DAEMON_SCHEDULES_FILE, e.g. an evening equities pull and an earlier
+// futures pull
+type ScheduleEntry struct {
+	Name     string   `json:"name"`
+	Schedule string   `json:"schedule"`           // cron expression
+	Timezone string   `json:"timezone,omitempty"` // default: DAEMON_TIMEZONE
+	Tickers  []string `json:"tickers,omitempty"`  // default: the config's tickers
+	Packages []string `json:"packages,omitempty"` // default: the config's enabled packages
+}
+
+// scheduledJob is a parsed schedule with the config scoped to it.
+type scheduledJob struct {
+	name      string
+	cfg       *config.Config
+	scheduler *Scheduler
+}
+
+// loadSchedules returns the schedules in DAEMON_SCHEDULES_FILE, or the
+// single schedule of DAEMON_SCHEDULE when no file is set.
+func loadSchedules(daemonCfg *DaemonConfig) ([]ScheduleEntry, error) {
+	if daemonCfg.SchedulesFile == "" {
+		return []ScheduleEntry{{Name: defaultScheduleName, Schedule: daemonCfg.Schedule, Timezone: daemonCfg.Timezone}}, nil
+	}
+	raw, err := os.ReadFile(daemonCfg.SchedulesFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading DAEMON_SCHEDULES_FILE: %w", err)
+	}
+	var entries []ScheduleEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("parsing DAEMON_SCHEDULES_FILE %s: %w", daemonCfg.SchedulesFile, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("DAEMON_SCHEDULES_FILE %s: no schedules", daemonCfg.SchedulesFile)
+	}
+
+	seen := make(map[string]bool)
+	for i := range entries {
+		e := &entries[i]
+		if e.Name == "" || seen[e.Name] {
+			return nil, fmt.Errorf("DAEMON_SCHEDULES_FILE %s: schedule %d needs a unique name", daemonCfg.SchedulesFile, i+1)
+		}
+		seen[e.Name] = true
+		if e.Schedule == "" {
+			return nil, fmt.Errorf("DAEMON_SCHEDULES_FILE %s: schedule %q has no cron expression", daemonCfg.SchedulesFile, e.Name)
+		}
+		if e.Timezone == "" {
+			e.Timezone = daemonCfg.Timezone
+		}
+	}
+	return entries, nil
+}
+
+// splitTickerSchedules moves the tickers of each schedule that have a
+// ticker_schedules entry into a schedule of their own per entry, named after
+// it. A schedule left without tickers is dropped.
+func splitTickerSchedules(entries []ScheduleEntry, cfg *config.Config) []ScheduleEntry {
+	if len(cfg.TickerSchedules) == 0 {
+		return entries
+	}
+	var split []ScheduleEntry
+	for _, e := range entries {
+		tickers := e.Tickers
+		if len(tickers) == 0 {
+			tickers = cfg.Tickers
+		}
+		if len(tickers) == 0 {
+			tickers = config.DefaultTickers()
+		}
+
+		var rest []string
+		groups := make(map[string]*ScheduleEntry)
+		var keys []string
+		for _, ticker := range tickers {
+			key, ts, ok := cfg.TickerScheduleFor(ticker)
+			if !ok {
+				rest = append(rest, ticker)
+				continue
+			}
+			g, ok := groups[key]
+			if !ok {
+				name := key
+				if e.Name != defaultScheduleName {
+					name = e.Name + "/" + key
+				}
+				g = &ScheduleEntry{Name: name, Schedule: ts.Schedule, Timezone: ts.Timezone, Packages: e.Packages}
+				if g.Timezone == "" {
+					g.Timezone = e.Timezone
+				}
+				groups[key] = g
+				keys = append(keys, key)
+			}
+			g.Tickers = append(g.Tickers, ticker)
+		}
+
+		if len(rest) > 0 {
+			e.Tickers = rest
+			split = append(split, e)
+		}
+		for _, key := range keys {
+			split = append(split, *groups[key])
+		}
+	}
+	return split
+}
+
+// newScheduledJob parses e and scopes cfg to its tickers and packages.
+func newScheduledJob(e ScheduleEntry, cfg *config.Config) (*scheduledJob, error) {
+	schedule, err := cron.Parse(e.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("schedule %q: %w", e.Name, err)
+	}
+	scoped, err := scopeConfig(cfg, e.Tickers, e.Packages)
+	if err != nil {
+		return nil, fmt.Errorf("schedule %q: %w", e.Name, err)
+	}
+	return &scheduledJob{
+		name:      e.Name,
+		cfg:       scoped,
+		scheduler: NewScheduler(schedule, e.Timezone, tradingCalendars(scoped)),
+	}, nil
+}
+
+// scopeConfig returns a copy of cfg downloading only tickers and packages;
+// empty lists keep the config's own.
+func scopeConfig(cfg *config.Config, tickers, packages []string) (*config.Config, error) {
+	scoped := *cfg
+	if len(tickers) > 0 {
+		scoped.Tickers = tickers
+	}
+	if len(packages) > 0 {
+		for _, pkg := range packages {
+			if _, ok := config.ValidCategories[config.Package(pkg)]; !ok {
+				return nil, fmt.Errorf("unknown package %q", pkg)
+			}
+		}
+		scoped.Packages.State.Enabled = slices.Contains(packages, string(config.PackageState))
+		scoped.Packages.Classic.Enabled = slices.Contains(packages, string(config.PackageClassic))
+		scoped.Packages.Orderflow.Enabled = slices.Contains(packages, string(config.PackageOrderflow))
+		scoped.Packages.Volatility.Enabled = slices.Contains(packages, string(config.PackageVolatility))
+	}
+	if err := config.ValidateDownloadConfig(scoped.Tickers, scoped.Packages); err != nil {
+		return nil, err
+	}
+	return &scoped, nil
+}
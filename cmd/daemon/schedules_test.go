@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadSchedules(t *testing.T) {
+	t.Run("single schedule", func(t *testing.T) {
+		entries, err := loadSchedules(&DaemonConfig{Schedule: "0 20 * * 1-5", Timezone: "America/Chicago"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := ScheduleEntry{Name: defaultScheduleName, Schedule: "0 20 * * 1-5", Timezone: "America/Chicago"}
+		if len(entries) != 1 || entries[0].Name != want.Name || entries[0].Schedule != want.Schedule || entries[0].Timezone != want.Timezone {
+			t.Errorf("entries = %+v, want %+v", entries, want)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"valid", `[
+			{"name": "equities", "schedule": "0 20 * * 1-5", "tickers": ["SPX"]},
+			{"name": "futures", "schedule": "0 18 * * *", "timezone": "America/Chicago", "packages": ["orderflow"]}
+		]`, ""},
+		{"invalid json", `{"name": "equities"}`, "parsing"},
+		{"empty", `[]`, "no schedules"},
+		{"missing name", `[{"schedule": "0 20 * * *"}]`, "unique name"},
+		{"duplicate name", `[{"name": "a", "schedule": "0 20 * * *"}, {"name": "a", "schedule": "0 18 * * *"}]`, "unique name"},
+		{"missing cron expression", `[{"name": "a"}]`, "no cron expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "schedules.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			entries, err := loadSchedules(&DaemonConfig{SchedulesFile: path, Timezone: "America/New_York"})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 2 || entries[0].Timezone != "America/New_York" || entries[1].Timezone != "America/Chicago" {
+				t.Errorf("entries = %+v, want the default timezone filled in for equities only", entries)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := loadSchedules(&DaemonConfig{SchedulesFile: filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+			t.Error("want an error")
+		}
+	})
+}
+
+func TestNewScheduledJob(t *testing.T) {
+	cfg := testConfig()
+	cfg.Tickers = []string{"SPX", "NDX"}
+	cfg.Packages.Orderflow.Enabled = true
+	cfg.Packages.Orderflow.Categories = []string{"orderflow"}
+
+	job, err := newScheduledJob(ScheduleEntry{Name: "spx", Schedule: "0 20 * * *", Timezone: "America/New_York", Tickers: []string{"SPX"}, Packages: []string{"orderflow"}}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(job.cfg.Tickers, []string{"SPX"}) || job.cfg.Packages.Classic.Enabled || !job.cfg.Packages.Orderflow.Enabled {
+		t.Errorf("scoped config = %v %+v, want SPX orderflow only", job.cfg.Tickers, job.cfg.Packages)
+	}
+	// The shared config is left alone
+	if len(cfg.Tickers) != 2 || !cfg.Packages.Classic.Enabled {
+		t.Errorf("config changed to %v %+v", cfg.Tickers, cfg.Packages)
+	}
+
+	job, err = newScheduledJob(ScheduleEntry{Name: "all", Schedule: "0 20 * * *", Timezone: "America/New_York"}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(job.cfg.Tickers) != 2 || !job.cfg.Packages.Classic.Enabled || !job.cfg.Packages.Orderflow.Enabled {
+		t.Errorf("unscoped config = %v %+v, want the config's own", job.cfg.Tickers, job.cfg.Packages)
+	}
+
+	for _, e := range []ScheduleEntry{
+		{Name: "cron", Schedule: "0 25 * * *"},
+		{Name: "ticker", Schedule: "0 20 * * *", Tickers: []string{"BOGUS"}},
+		{Name: "package", Schedule: "0 20 * * *", Packages: []string{"bogus"}},
+	} {
+		if _, err := newScheduledJob(e, cfg); err == nil || !strings.Contains(err.Error(), `schedule "`+e.Name+`"`) {
+			t.Errorf("newScheduledJob(%+v) err = %v, want an error naming the schedule", e, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// serverReloadTimeout bounds a faker server reload, which loads the whole
+// date in memory mode
+const serverReloadTimeout = 5 * time.Minute
+
+// reloadServer asks the faker server at baseURL to serve date. With a token,
+// the server's ADMIN_TOKEN, it calls /admin/reload with the token as bearer
+// token; without one, the unauthenticated /reload-date.
+func reloadServer(ctx context.Context, baseURL, token, date string) error {
+	ctx, cancel := context.WithTimeout(ctx, serverReloadTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"date": date})
+	if err != nil {
+		return err
+	}
+	path := "/reload-date"
+	if token != "" {
+		path = "/admin/reload"
+	}
+	url := strings.TrimSuffix(baseURL, "/") + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("reloading server: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(msg, &e) == nil && e.Error != "" {
+			return fmt.Errorf("server reload failed with status %d: %s", resp.StatusCode, e.Error)
+		}
+		return fmt.Errorf("server reload failed with status %d", resp.StatusCode)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
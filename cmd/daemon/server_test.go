@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReloadServer(t *testing.T) {
+	type call struct {
+		path, auth, date string
+	}
+	var got call
+	status, body := http.StatusOK, `{"status":"success"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Date string `json:"date"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		got = call{path: r.URL.Path, auth: r.Header.Get("Authorization"), date: req.Date}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		token   string
+		want    call
+	}{
+		{"without token", srv.URL, "", call{path: "/reload-date", date: "2025-01-02"}},
+		{"with token", srv.URL + "/", "secret", call{path: "/admin/reload", auth: "Bearer secret", date: "2025-01-02"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reloadServer(context.Background(), tt.baseURL, tt.token, "2025-01-02"); err != nil {
+				t.Fatalf("reloadServer: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("request = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		status, body = http.StatusNotFound, `{"error":"date 2025-01-02 not found"}`
+		err := reloadServer(context.Background(), srv.URL, "", "2025-01-02")
+		if err == nil || !strings.Contains(err.Error(), "404") || !strings.Contains(err.Error(), "date 2025-01-02 not found") {
+			t.Errorf("err = %v, want the status and the server's error", err)
+		}
+
+		status, body = http.StatusBadGateway, "bad gateway"
+		err = reloadServer(context.Background(), srv.URL, "", "2025-01-02")
+		if err == nil || err.Error() != "server reload failed with status 502" {
+			t.Errorf("err = %v, want the status only", err)
+		}
+	})
+}
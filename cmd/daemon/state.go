@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stateDatesKept is the number of dates whose results each schedule keeps
+// in the state file.
+const stateDatesKept = 100
+
+// daemonState is the state file: per schedule, the last downloaded date and
+// the result of the last run of each recent date
+type daemonState struct {
+	Schedules  map[string]*scheduleState `json:"schedules"`
+	LastDigest time.Time                 `json:"last_digest,omitzero"` // end of the period of the last digest
+}
+
+type scheduleState struct {
+	LastDownloadDate string               `json:"last_download_date,omitempty"`
+	Dates            map[string]runRecord `json:"dates,omitempty"`
+}
+
+// DownloadTracker tracks the downloads of each schedule in the state file
+type DownloadTracker struct {
+	stateFile string
+	mu        sync.Mutex
+}
+
+// NewDownloadTracker creates a new tracker with the given state file path
+func NewDownloadTracker(stateFile string) *DownloadTracker {
+	return &DownloadTracker{stateFile: stateFile}
+}
+
+// readState reads the state file. Older state files hold a map of schedule
+// to last date, or before schedules were named, the bare date of the
+// default schedule.
+func (t *DownloadTracker) readState() *daemonState {
+	data, err := os.ReadFile(t.stateFile)
+	if err != nil {
+		return &daemonState{Schedules: make(map[string]*scheduleState)}
+	}
+
+	state := &daemonState{}
+	if err := json.Unmarshal(data, state); err == nil && state.Schedules != nil {
+		return state
+	}
+	state.Schedules = make(map[string]*scheduleState)
+	var legacy map[string]string
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		for name, date := range legacy {
+			state.Schedules[name] = &scheduleState{LastDownloadDate: date}
+		}
+	} else if date := strings.TrimSpace(string(data)); date != "" {
+		state.Schedules[defaultScheduleName] = &scheduleState{LastDownloadDate: date}
+	}
+	return state
+}
+
+// writeState replaces the state file
+func (t *DownloadTracker) writeState(state *daemonState) error {
+	// Ensure directory exists
+	dir := filepath.Dir(t.stateFile)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := t.stateFile + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, t.stateFile)
+}
+
+// update applies fn to the state of a schedule and writes the state file
+func (t *DownloadTracker) update(schedule string, fn func(*scheduleState)) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state := t.readState()
+	s := state.Schedules[schedule]
+	if s == nil {
+		s = &scheduleState{}
+		state.Schedules[schedule] = s
+	}
+	fn(s)
+	return t.writeState(state)
+}
+
+// schedule returns the state of a schedule, empty when it has none
+func (t *DownloadTracker) schedule(name string) scheduleState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s := t.readState().Schedules[name]; s != nil {
+		return *s
+	}
+	return scheduleState{}
+}
+
+// GetLastDownloadDate reads the last successful download date of a schedule
+// from the state file
+func (t *DownloadTracker) GetLastDownloadDate(schedule string) string {
+	return t.schedule(schedule).LastDownloadDate
+}
+
+// SetLastDownloadDate records the date of a schedule in the state file
+func (t *DownloadTracker) SetLastDownloadDate(schedule, date string) error {
+	return t.update(schedule, func(s *scheduleState) {
+		s.LastDownloadDate = date
+	})
+}
+
+// AlreadyDownloaded checks if the given date was already downloaded by a
+// schedule
+func (t *DownloadTracker) AlreadyDownloaded(schedule, date string) bool {
+	return t.GetLastDownloadDate(schedule) == date
+}
+
+// RecordRun stores the result of a run as the result of its date, counting
+// the attempts at the date. Only the most recent stateDatesKept dates are
+// kept.
+func (t *DownloadTracker) RecordRun(rec runRecord) error {
+	return t.update(rec.Schedule, func(s *scheduleState) {
+		if s.Dates == nil {
+			s.Dates = make(map[string]runRecord)
+		}
+		rec.Attempts = s.Dates[rec.Date].Attempts + 1
+		rec.Bytes += s.Dates[rec.Date].Bytes
+		s.Dates[rec.Date] = rec
+
+		if len(s.Dates) > stateDatesKept {
+			dates := make([]string, 0, len(s.Dates))
+			for date := range s.Dates {
+				dates = append(dates, date)
+			}
+			sort.Strings(dates)
+			for _, date := range dates[:len(dates)-stateDatesKept] {
+				delete(s.Dates, date)
+			}
+		}
+	})
+}
+
+// LastDigest returns the end of the period of the last digest sent, or the
+// zero time
+func (t *DownloadTracker) LastDigest() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.readState().LastDigest
+}
+
+// SetLastDigest records the end of the period of the digest sent
+func (t *DownloadTracker) SetLastDigest(to time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state := t.readState()
+	state.LastDigest = to
+	return t.writeState(state)
+}
+
+// DateResult returns the result of the last run of a date for a schedule
+func (t *DownloadTracker) DateResult(schedule, date string) (runRecord, bool) {
+	rec, ok := t.schedule(schedule).Dates[date]
+	return rec, ok
+}
+
+// Runs returns up to limit date results, most recently finished first, of
+// one schedule or of all when schedule is empty
+func (t *DownloadTracker) Runs(schedule string, limit int) []runRecord {
+	t.mu.Lock()
+	state := t.readState()
+	t.mu.Unlock()
+
+	runs := []runRecord{}
+	for name, s := range state.Schedules {
+		if schedule != "" && name != schedule {
+			continue
+		}
+		for _, rec := range s.Dates {
+			runs = append(runs, rec)
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Finished.After(runs[j].Finished)
+	})
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadStateLegacyFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{"bare date", "2025-01-02\n", map[string]string{defaultScheduleName: "2025-01-02"}},
+		{"last date per schedule", `{"default":"2025-01-02","indexes":"2025-01-03"}`, map[string]string{"default": "2025-01-02", "indexes": "2025-01-03"}},
+		{"current", `{"schedules":{"indexes":{"last_download_date":"2025-01-03"}}}`, map[string]string{"indexes": "2025-01-03"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateFile := filepath.Join(t.TempDir(), "state")
+			if err := os.WriteFile(stateFile, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			tracker := NewDownloadTracker(stateFile)
+			if n := len(tracker.readState().Schedules); n != len(tt.want) {
+				t.Errorf("got %d schedules, want %d", n, len(tt.want))
+			}
+			for schedule, date := range tt.want {
+				if got := tracker.GetLastDownloadDate(schedule); got != date {
+					t.Errorf("GetLastDownloadDate(%q) = %q, want %q", schedule, got, date)
+				}
+			}
+		})
+	}
+}
+
+func TestReadStateMigratesOnWrite(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state")
+	if err := os.WriteFile(stateFile, []byte("2025-01-02\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tracker := NewDownloadTracker(stateFile)
+	if err := tracker.SetLastDownloadDate("indexes", "2025-01-03"); err != nil {
+		t.Fatal(err)
+	}
+	if got := tracker.GetLastDownloadDate(defaultScheduleName); got != "2025-01-02" {
+		t.Errorf("legacy date lost on write: got %q", got)
+	}
+	if got := tracker.GetLastDownloadDate("indexes"); got != "2025-01-03" {
+		t.Errorf("GetLastDownloadDate(indexes) = %q, want 2025-01-03", got)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/sdnotify"
+)
+
+// watchdog pings the systemd watchdog while the main loop is alive. The
+// loop beats between its checks and is trusted while it runs a download, so
+// systemd restarts the daemon only when the loop wedges outside a download.
+type watchdog struct {
+	interval time.Duration
+	lastBeat atomic.Int64 // unix nanoseconds
+	busy     func() bool
+}
+
+// startWatchdog pings systemd every half interval until ctx is cancelled
+func startWatchdog(ctx context.Context, interval time.Duration, busy func() bool, logger *zap.Logger) *watchdog {
+	w := &watchdog{interval: interval, busy: busy}
+	w.Beat()
+	go func() {
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !w.alive() {
+					logger.Warn("main loop unresponsive, withholding watchdog ping")
+					continue
+				}
+				if _, err := sdnotify.Notify(sdnotify.Watchdog); err != nil {
+					logger.Warn("failed to ping systemd watchdog", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return w
+}
+
+// Beat records that the main loop is alive
+func (w *watchdog) Beat() {
+	w.lastBeat.Store(time.Now().UnixNano())
+}
+
+func (w *watchdog) alive() bool {
+	return w.busy() || time.Since(time.Unix(0, w.lastBeat.Load())) < w.interval
+}
+
+// notifySystemd sends state to systemd when the daemon runs as a
+// Type=notify unit
+func notifySystemd(state string, logger *zap.Logger) {
+	if _, err := sdnotify.Notify(state); err != nil {
+		logger.Warn("failed to notify systemd", zap.Error(err))
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// downloadWindow is the time of day, in a schedule's timezone, scheduled
+// downloads may start and late files are retried. An end before the start
+// crosses midnight.
+type downloadWindow struct {
+	start, end time.Duration // since midnight
+}
+
+// parseWindow parses a window like "20:00-23:00". An empty string is no
+// window.
+func parseWindow(s string) (*downloadWindow, error) {
+	if s == "" {
+		return nil, nil
+	}
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid DAEMON_WINDOW %q, want HH:MM-HH:MM", s)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DAEMON_WINDOW %q: %w", s, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DAEMON_WINDOW %q: %w", s, err)
+	}
+	if start == end {
+		return nil, fmt.Errorf("invalid DAEMON_WINDOW %q: empty window", s)
+	}
+	return &downloadWindow{start: start, end: end}, nil
+}
+
+// parseClock parses HH:MM as the time since midnight
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t, in its location, falls within the window
+func (w *downloadWindow) Contains(t time.Time) bool {
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.start < w.end {
+		return d >= w.start && d < w.end
+	}
+	return d >= w.start || d < w.end
+}
+
+func (w *downloadWindow) String() string {
+	clock := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return clock(w.start) + "-" + clock(w.end)
+}
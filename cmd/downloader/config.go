@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dgnsrekt/gexbot-downloader/configs"
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+)
+
+func configCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Create or check a downloader config",
+	}
+	cmd.AddCommand(configInitCmd())
+	cmd.AddCommand(configValidateCmd())
+	return cmd
+}
+
+func configInitCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "init [PATH]",
+		Short: "Write the commented default config",
+		Long: `Write the default config, with every option commented, to PATH
+(default configs/default.yaml, where the downloader looks without -c).
+Use - to print it instead.
+
+Examples:
+  # Scaffold a config, then edit tickers and packages
+  gexbot-downloader config init
+  gexbot-downloader config init ~/.config/gexbot/downloader.yaml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := filepath.Join("configs", "default.yaml")
+			if len(args) > 0 {
+				path = args[0]
+			}
+			cmd.SilenceUsage = true
+			if path == "-" {
+				_, err := os.Stdout.Write(configs.Default)
+				return err
+			}
+
+			if !force {
+				if _, err := os.Stat(path); err == nil {
+					return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+				}
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+				return fmt.Errorf("creating config directory: %w", err)
+			}
+			if err := os.WriteFile(path, configs.Default, 0600); err != nil {
+				return fmt.Errorf("writing config: %w", err)
+			}
+
+			fmt.Printf("Wrote %s\n", path)
+			fmt.Printf("Set GEXBOT_API_KEY, then check it with: gexbot-downloader config validate -c %s\n", path)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite an existing file")
+
+	return cmd
+}
+
+func configValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check a config without downloading anything",
+		Long: `Load the config (-c, GEXBOT_DOWNLOADER_CONFIG or configs/default.yaml) and
+report every problem: a missing API key, invalid settings, unknown tickers
+or package categories, and output, staging or log directories that cannot
+be written. No API requests are made. Exits non-zero when a problem is
+found.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cfg, err := config.Read(cfgFile)
+			if err != nil {
+				return err
+			}
+
+			problems := cfg.Check()
+			if len(problems) == 0 {
+				fmt.Println("Config OK")
+				return nil
+			}
+
+			for _, p := range problems {
+				fmt.Printf("- %v\n", p)
+			}
+			return fmt.Errorf("%d config problems found", len(problems))
+		},
+	}
+}
@@ -1,17 +1,18 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/spf13/cobra"
-	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/export"
 )
 
 func convertCmd() *cobra.Command {
+	var compress bool
+
 	cmd := &cobra.Command{
 		Use:   "convert-to-jsonl YYYY-MM-DD",
 		Short: "Convert JSON files to JSONL format",
@@ -19,119 +20,80 @@ func convertCmd() *cobra.Command {
 
 Each JSON file containing an array will be converted to JSONL format,
 where each array element becomes a single line. Original JSON files
-are deleted after successful conversion.
+are deleted after successful conversion. With --zstd the JSONL is
+written zstd-compressed as .jsonl.zst, which the faker server also reads.
 
 Examples:
   # Convert JSON files for specific date
-  gexbot-downloader convert 2025-11-14`,
+  gexbot-downloader convert 2025-11-14
+
+  # Convert to compressed JSONL
+  gexbot-downloader convert-to-jsonl --zstd 2025-11-14`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Output.Remote() {
+				return fmt.Errorf("%s is remote storage; downloads there are converted before upload", cfg.Output.Directory)
+			}
 			date := args[0]
 			dir := filepath.Join(cfg.Output.Directory, date)
 
-			return convertJSONToJSONL(dir)
+			return export.ConvertDirToJSONL(dir, compress, logger)
 		},
 	}
 
+	cmd.Flags().BoolVar(&compress, "zstd", false, "write zstd-compressed .jsonl.zst files")
+
 	return cmd
 }
 
-func convertJSONToJSONL(dir string) error {
-	var converted, skipped, failed int
-
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip directories and non-JSON files
-		if info.IsDir() || !strings.HasSuffix(path, ".json") {
-			return nil
-		}
-
-		// Skip staging directory
-		if strings.Contains(path, ".staging") {
-			return nil
-		}
+func convertToParquetCmd() *cobra.Command {
+	var keep bool
 
-		jsonlPath := strings.TrimSuffix(path, ".json") + ".jsonl"
-
-		// Skip if JSONL already exists
-		if _, err := os.Stat(jsonlPath); err == nil {
-			logger.Debug("skipping, JSONL exists", zap.String("file", path))
-			skipped++
-			return nil
-		}
-
-		logger.Info("converting", zap.String("file", path))
+	cmd := &cobra.Command{
+		Use:   "convert-to-parquet YYYY-MM-DD",
+		Short: "Convert JSON/JSONL files to Parquet format",
+		Long: `Convert downloaded JSON and JSONL files to Parquet for DuckDB, Arrow
+and other columnar tools.
 
-		if err := convertFile(path, jsonlPath); err != nil {
-			logger.Error("conversion failed", zap.String("file", path), zap.Error(err))
-			failed++
-			return nil // Continue with other files
-		}
+Scalar fields become typed columns; nested arrays (strikes, max_priors,
+mini_contracts) are stored as JSON columns. Original files are deleted
+after successful conversion unless --keep is set. The faker server only
+serves JSON/JSONL, so keep them if you also replay the date.
 
-		// Delete original JSON after successful conversion
-		if err := os.Remove(path); err != nil {
-			logger.Warn("failed to delete original", zap.String("file", path), zap.Error(err))
-		}
+Examples:
+  # Convert files for specific date
+  gexbot-downloader convert-to-parquet 2025-11-14
 
-		converted++
-		return nil
-	})
+  # Query with DuckDB
+  duckdb -c "SELECT timestamp, spot, zero_gamma FROM 'data/2025-11-14/SPX/state/gex_zero.parquet'"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Output.Remote() {
+				return fmt.Errorf("%s is remote storage; downloads there are converted before upload", cfg.Output.Directory)
+			}
+			date := args[0]
+			dir := filepath.Join(cfg.Output.Directory, date)
 
-	if err != nil {
-		return fmt.Errorf("walking directory: %w", err)
+			return export.ConvertDirToParquet(dir, keep, logger)
+		},
 	}
 
-	logger.Info("conversion complete",
-		zap.Int("converted", converted),
-		zap.Int("skipped", skipped),
-		zap.Int("failed", failed),
-	)
+	cmd.Flags().BoolVar(&keep, "keep", false, "keep the original JSON/JSONL files")
 
-	if failed > 0 {
-		return fmt.Errorf("%d files failed to convert", failed)
-	}
-
-	return nil
+	return cmd
 }
 
-func convertFile(jsonPath, jsonlPath string) error {
-	// Read JSON file
-	data, err := os.ReadFile(jsonPath)
-	if err != nil {
-		return fmt.Errorf("reading file: %w", err)
-	}
-
-	// Parse as array of raw JSON messages
-	var items []json.RawMessage
-	if err := json.Unmarshal(data, &items); err != nil {
-		return fmt.Errorf("parsing JSON array: %w", err)
-	}
-
-	// Create JSONL file
-	outFile, err := os.Create(jsonlPath)
-	if err != nil {
-		return fmt.Errorf("creating output file: %w", err)
-	}
-	defer func() { _ = outFile.Close() }()
-
-	// Write each item as a line
-	for _, item := range items {
-		// Compact the JSON (remove whitespace)
-		compact, err := json.Marshal(item)
-		if err != nil {
-			return fmt.Errorf("compacting JSON: %w", err)
-		}
-
-		if _, err := outFile.Write(compact); err != nil {
-			return fmt.Errorf("writing line: %w", err)
-		}
-		if _, err := outFile.WriteString("\n"); err != nil {
-			return fmt.Errorf("writing newline: %w", err)
-		}
+// convertOutput converts a committed date directory to the configured
+// output format. JSON needs no conversion.
+func convertOutput(dir string) error {
+	switch cfg.Output.OutputFormat() {
+	case config.FormatJSONL:
+		return export.ConvertDirToJSONL(dir, false, logger)
+	case config.FormatJSONLZstd:
+		return export.ConvertDirToJSONL(dir, true, logger)
+	case config.FormatParquet:
+		return export.ConvertDirToParquet(dir, false, logger)
+	default:
+		return nil
 	}
-
-	return nil
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -9,18 +11,21 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
-	"github.com/dgnsrekt/gexbot-downloader/internal/api"
 	"github.com/dgnsrekt/gexbot-downloader/internal/config"
 	"github.com/dgnsrekt/gexbot-downloader/internal/download"
 	"github.com/dgnsrekt/gexbot-downloader/internal/notify"
-	"github.com/dgnsrekt/gexbot-downloader/internal/staging"
 )
 
 func downloadCmd() *cobra.Command {
 	var (
-		dryRun   bool
-		tickers  []string
-		packages []string
+		dryRun     bool
+		progress   bool
+		outputMode string
+		refresh    bool
+		allTickers bool
+		tasksFile  string
+		tickers    []string
+		packages   []string
 	)
 
 	cmd := &cobra.Command{
@@ -40,31 +45,96 @@ Examples:
   # Override tickers from config
   gexbot-downloader download --tickers SPX,NDX 2025-11-14
 
+  # Download every ticker the API key has access to
+  gexbot-downloader download --all-tickers 2025-11-14
+
   # Dry run to see what would be downloaded
-  gexbot-downloader download --dry-run 2025-11-14`,
-		Args: cobra.RangeArgs(1, 2),
+  gexbot-downloader download --dry-run 2025-11-14
+
+  # Show a live per-worker progress display instead of logs
+  gexbot-downloader download --progress 2025-11-01 2025-11-14
+
+  # Print a JSON summary of every task on stdout, e.g. for CI
+  gexbot-downloader download --output json 2025-11-14
+
+  # Re-check existing files, fetching only those republished upstream
+  gexbot-downloader download --refresh 2025-11-14
+
+  # Download the files listed by the gaps command
+  gexbot-downloader gaps 2025-11-01 2025-11-14 | gexbot-downloader download --tasks -`,
+		Args: cobra.RangeArgs(0, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
+			if outputMode != "text" && outputMode != "json" {
+				return fmt.Errorf("invalid --output %q: must be text or json", outputMode)
+			}
+			asJSON := outputMode == "json"
+
+			var (
+				dates []string
+				tasks []download.Task
+				err   error
+			)
+			switch {
+			case tasksFile != "" && len(args) > 0:
+				return fmt.Errorf("dates cannot be combined with --tasks")
+			case tasksFile != "":
+				// Explicit task list, e.g. from the gaps command
+				tasks, err = readTaskList(tasksFile)
+				if err != nil {
+					return err
+				}
+				if len(tasks) == 0 {
+					logger.Info("no tasks listed, nothing to download")
+					if asJSON {
+						return writeJSON(downloadSummary{Dates: []string{}, BatchResult: &download.BatchResult{}})
+					}
+					return nil
+				}
+				dates = taskDates(tasks)
+			case len(args) == 0:
+				return fmt.Errorf("requires a date or --tasks")
+			default:
+				// Parse dates; non-market days are filtered once the tickers
+				// are known
+				dates, err = parseDates(args)
+				if err != nil {
+					return err
+				}
+			}
 
-			// Parse dates
-			dates, err := parseDates(args)
+			client, err := newAPIClient()
 			if err != nil {
 				return err
 			}
 
-			// Filter out non-market days (weekends, NYSE holidays)
-			dates = filterMarketDays(dates, logger)
-			if len(dates) == 0 {
-				return fmt.Errorf("no valid market days in the specified range")
+			// Determine effective tickers for validation
+			effectiveTickers := resolveTickers(cfg, tickers)
+			if tasks != nil {
+				effectiveTickers = taskTickers(tasks)
 			}
 
-			// Determine effective tickers for validation
-			effectiveTickers := cfg.Tickers
-			if len(tickers) > 0 {
-				effectiveTickers = tickers
+			// Expand to every ticker the account can access; these come from
+			// the API itself, so only packages are validated below
+			if allTickers {
+				tickers, err = client.GetTickers(ctx)
+				if err != nil {
+					return fmt.Errorf("discovering tickers: %w", err)
+				}
+				if len(tickers) == 0 {
+					return fmt.Errorf("the API returned no tickers")
+				}
+				logger.Info("discovered tickers", zap.Int("count", len(tickers)), zap.Strings("tickers", tickers))
+				effectiveTickers = nil
 			}
-			if len(effectiveTickers) == 0 {
-				effectiveTickers = config.DefaultTickers()
+
+			// Filter out days none of the tickers trade (weekends, holidays
+			// of their NYSE or CME calendars)
+			if tasks == nil {
+				dates = filterMarketDays(dates, tickerCalendars(cfg, resolveTickers(cfg, tickers)), logger)
+				if len(dates) == 0 {
+					return fmt.Errorf("no valid market days in the specified range")
+				}
 			}
 
 			// Validate configuration before downloading
@@ -73,74 +143,145 @@ Examples:
 			}
 
 			// Generate tasks
-			tasks := generateTasks(cfg, dates, tickers, packages)
+			if tasks == nil {
+				tasks = generateTasks(cfg, dates, tickers, packages)
+			}
 
 			logger.Info("generated tasks", zap.Int("count", len(tasks)))
 
 			if dryRun {
+				if asJSON {
+					planned := make([]string, 0, len(tasks))
+					for _, t := range tasks {
+						planned = append(planned, t.String())
+					}
+					return writeJSON(dryRunSummary{DryRun: true, Dates: dates, Tasks: planned})
+				}
 				for _, t := range tasks {
 					fmt.Printf("Would download: %s\n", t)
 				}
 				return nil
 			}
 
-			// Create client
-			client := api.NewClient(
-				cfg.API.BaseURL,
-				cfg.API.APIKey,
-				cfg.Download.RatePerSecond,
-				time.Duration(cfg.API.TimeoutSec)*time.Second,
-				time.Duration(cfg.API.RetryDelay)*time.Second,
-				cfg.API.RetryCount,
-				logger,
-			)
-
 			// Create staging manager
-			stgMgr := staging.NewManager(cfg.Output.Directory)
+			stgMgr, err := newStagingManager(cfg)
+			if err != nil {
+				return err
+			}
+
+			// Wait for the daemon or another download using the output
+			unlock, err := stgMgr.Lock(ctx, func(holder string) {
+				logger.Info("waiting for another run to release the output directory", zap.String("holder", holder))
+			})
+			if err != nil {
+				return fmt.Errorf("locking output directory: %w", err)
+			}
+			defer unlock()
+
+			// Resolve staging left behind by an interrupted run
+			recoverStaging(stgMgr, cfg, logger)
 
 			// Create download manager
 			dlMgr := download.NewManager(client, stgMgr, cfg.Download.Workers, logger)
+			dlMgr.SetSkipExisting(cfg.Download.ResumeEnabled && !refresh)
+			dlMgr.SetVerifyExisting(cfg.Download.VerifyExisting)
+			dlMgr.SetValidatePayloads(cfg.Download.ValidatePayloads)
+			dlMgr.SetPackageWorkers(cfg.Packages.PackageWorkers())
+
+			// Fail early instead of running out of space halfway through
+			if cfg.Download.DiskPreflight {
+				if err := dlMgr.Preflight(tasks, int64(cfg.Download.DiskHeadroomMB)<<20); err != nil {
+					return err
+				}
+			}
+
+			// Logs only go to the log file while the display is drawn
+			var display *progressDisplay
+			if progressEnabled(cmd) {
+				display = startProgress(os.Stderr, len(tasks))
+				dlMgr.SetProgress(display)
+			} else if progress {
+				logger.Info("stderr is not a terminal, logging instead of showing progress")
+			}
 
 			// Execute downloads
 			start := time.Now()
 			result, err := dlMgr.Execute(ctx, tasks)
 			duration := time.Since(start)
+			if display != nil {
+				display.Stop()
+			}
 			if err != nil {
 				return err
 			}
 
 			// Commit staging to final location and cleanup (only if there were actual downloads)
 			if result.Success > 0 {
+				var committed []string
 				for _, date := range dates {
 					if err := stgMgr.CommitStaging(date); err != nil {
 						logger.Warn("failed to commit staging", zap.String("date", date), zap.Error(err))
+					} else {
+						committed = append(committed, date)
 					}
 					if err := stgMgr.CleanupStaging(date); err != nil {
 						logger.Warn("failed to cleanup staging", zap.String("date", date), zap.Error(err))
 					}
 				}
 
-				// Auto-convert JSON to JSONL if enabled
-				if cfg.Output.AutoConvertToJSONL {
-					logger.Info("auto-converting JSON to JSONL")
+				// Convert to the configured output format (remote output was
+				// converted in staging before upload)
+				if format := cfg.Output.OutputFormat(); format != config.FormatJSON && !stgMgr.Remote() {
+					logger.Info("auto-converting output", zap.String("format", format))
 					for _, date := range dates {
 						dir := filepath.Join(cfg.Output.Directory, date)
-						if err := convertJSONToJSONL(dir); err != nil {
+						if err := convertOutput(dir); err != nil {
 							logger.Warn("auto-conversion failed", zap.String("date", date), zap.Error(err))
 						}
 					}
 				}
+				download.DedupeOutput(cfg, dates, logger)
+				download.EncryptOutput(cfg, dates, logger)
+				download.ArchiveOutput(ctx, cfg, committed, logger)
+				download.RunPostDownloadHook(ctx, cfg, stgMgr, committed, logger)
 			}
 
 			// Print summary
+			throughput := result.Throughput()
 			logger.Info("download complete",
+				zap.String("output", stgMgr.Location()),
 				zap.Int("total", result.Total),
 				zap.Int("success", result.Success),
 				zap.Int("skipped", result.Skipped),
 				zap.Int("not_found", result.NotFound),
 				zap.Int("failed", result.Failed),
+				zap.Int64("bytes", throughput.Bytes),
+				zap.Float64("p10_mb_per_sec", throughput.P10MBPerSec),
+				zap.Float64("p50_mb_per_sec", throughput.P50MBPerSec),
+				zap.Duration("p90_duration", throughput.P90Duration),
+				zap.Duration("max_duration", throughput.MaxDuration),
 			)
 
+			download.LogMirrorHealth(client, logger)
+
+			if asJSON {
+				err := writeJSON(downloadSummary{
+					Dates:       dates,
+					Output:      stgMgr.Location(),
+					Duration:    duration,
+					BatchResult: result,
+					Throughput:  throughput,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			// Export run metrics to the Pushgateway and/or textfile
+			if err := dlMgr.Metrics().Export(ctx, cfg.Metrics.PushgatewayURL, cfg.Metrics.Job, cfg.Metrics.Textfile); err != nil {
+				logger.Warn("failed to export metrics", zap.Error(err))
+			}
+
 			// Send notification
 			notifyCfg := notify.LoadConfig()
 			if err := notifyCfg.Validate(); err != nil {
@@ -149,6 +290,13 @@ Examples:
 				notifier := notify.New(notifyCfg, logger)
 				dateStr := strings.Join(dates, ",")
 
+				// Retry notifications earlier runs failed to send
+				if f, ok := notifier.(notify.Flusher); ok {
+					if err := f.Flush(ctx); err != nil {
+						logger.Warn("queued notifications failed again", zap.Error(err))
+					}
+				}
+
 				if result.Failed > 0 {
 					if notifyErr := notifier.SendFailure(ctx, result, dateStr, duration, fmt.Errorf("%d downloads failed", result.Failed)); notifyErr != nil {
 						logger.Warn("failed to send notification", zap.Error(notifyErr))
@@ -163,6 +311,9 @@ Examples:
 			if result.Failed > 0 {
 				for _, e := range result.Errors {
 					logger.Error("download error", zap.String("error", e))
+					if display != nil {
+						fmt.Fprintln(os.Stderr, "download error:", e)
+					}
 				}
 				return fmt.Errorf("%d downloads failed", result.Failed)
 			}
@@ -172,8 +323,40 @@ Examples:
 	}
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be downloaded")
+	cmd.Flags().BoolVar(&progress, "progress", false, "show live per-worker progress instead of logs (needs a terminal)")
+	cmd.Flags().StringVarP(&outputMode, "output", "o", "text", "result format on stdout: text or json (a summary of every task)")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "re-check existing files with conditional requests instead of skipping them")
+	cmd.Flags().BoolVar(&allTickers, "all-tickers", false, "download every ticker the API serves to this key")
 	cmd.Flags().StringSliceVar(&tickers, "tickers", nil, "override tickers from config")
-	cmd.Flags().StringSliceVar(&packages, "packages", nil, "override packages from config (state,classic,orderflow)")
+	cmd.Flags().StringSliceVar(&packages, "packages", nil, "override packages from config (state,classic,orderflow,volatility)")
+	cmd.Flags().StringVar(&tasksFile, "tasks", "", "download the date/ticker/package/category lines in this file (- for stdin) instead of a date range")
+	cmd.MarkFlagsMutuallyExclusive("all-tickers", "tickers")
+	cmd.MarkFlagsMutuallyExclusive("tasks", "tickers")
+	cmd.MarkFlagsMutuallyExclusive("tasks", "packages")
+	cmd.MarkFlagsMutuallyExclusive("tasks", "all-tickers")
 
 	return cmd
 }
+
+// downloadSummary is the --output json result of a download run.
+type downloadSummary struct {
+	Dates    []string      `json:"dates"`
+	Output   string        `json:"output,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+	*download.BatchResult
+	Throughput download.ThroughputSummary `json:"throughput"`
+}
+
+// dryRunSummary is the --output json result of a dry run.
+type dryRunSummary struct {
+	DryRun bool     `json:"dry_run"`
+	Dates  []string `json:"dates"`
+	Tasks  []string `json:"tasks"`
+}
+
+// writeJSON prints v on stdout as indented JSON.
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/export"
+)
+
+func exportDBCmd() *cobra.Command {
+	var (
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-db YYYY-MM-DD",
+		Short: "Export a downloaded date to a SQLite or DuckDB database",
+		Long: `Load a downloaded date into normalized tables so it can be queried
+without the faker server:
+
+  gex         one row per GEX snapshot (state and classic gex_* categories)
+  strikes     one row per strike of each GEX snapshot
+  greeks      one row per delta/gamma/charm/vanna snapshot
+  orderflow   one row per orderflow snapshot
+  volatility  one row per IV snapshot
+  iv_strikes  one row per strike of each IV snapshot
+
+Every table starts with ticker, package, category and timestamp. Nested
+arrays without a fixed shape (max_priors, mini_contracts, strike priors)
+are stored as JSON text. JSON, JSONL(.zst) and Parquet files are read.
+
+DuckDB output needs the duckdb CLI on PATH.
+
+Examples:
+  # Write gexbot-2025-11-14.sqlite in the current directory
+  gexbot-downloader export-db 2025-11-14
+
+  # DuckDB, explicit path
+  gexbot-downloader export-db 2025-11-14 --format duckdb -o spx.duckdb
+  duckdb spx.duckdb -c "SELECT timestamp, spot, zero_gamma FROM gex WHERE category = 'gex_zero'"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Output.Remote() {
+				return fmt.Errorf("%s is remote storage; export from a local copy of the data", cfg.Output.Directory)
+			}
+			date := args[0]
+			if output == "" {
+				output = fmt.Sprintf("gexbot-%s.%s", date, format)
+			}
+			if _, err := os.Stat(output); err == nil {
+				return fmt.Errorf("%s already exists", output)
+			}
+
+			// Export failures are not usage errors
+			cmd.SilenceUsage = true
+			result, err := export.ExportDB(filepath.Join(cfg.Output.Directory, date), output, format, logger)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Exported %d files to %s\n", result.Files, output)
+			for _, table := range []string{"gex", "strikes", "greeks", "orderflow", "volatility", "iv_strikes"} {
+				fmt.Printf("  %-10s %d rows\n", table, result.Rows[table])
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", export.DBFormatSQLite, "database format (sqlite, duckdb)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "database file (default gexbot-DATE.FORMAT)")
+
+	return cmd
+}
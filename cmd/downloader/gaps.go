@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// gap is a missing file in the JSON output of the gaps command.
+type gap struct {
+	Date     string `json:"date"`
+	Ticker   string `json:"ticker"`
+	Package  string `json:"package"`
+	Category string `json:"category"`
+}
+
+func gapsCmd() *cobra.Command {
+	var (
+		tickers  []string
+		packages []string
+		asJSON   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gaps YYYY-MM-DD [END_DATE]",
+		Short: "List expected files missing from the output directory",
+		Long: `Compare the output directory with the market days in a date range and the
+configured tickers, packages and categories, and print every expected file
+that is missing. A file counts as present in any of the .json, .jsonl,
+.jsonl.zst or .parquet formats.
+
+Missing files are printed one per line as date/ticker/package/category, the
+format read by download --tasks; --json prints them as a JSON array instead.
+The summary goes to stderr.
+
+Examples:
+  # List missing files for a month
+  gexbot-downloader gaps 2025-10-01 2025-10-31
+
+  # Fill the gaps
+  gexbot-downloader gaps 2025-10-01 2025-10-31 | gexbot-downloader download --tasks -`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			dates, err := parseDates(args)
+			if err != nil {
+				return err
+			}
+			dates = filterMarketDays(dates, tickerCalendars(cfg, resolveTickers(cfg, tickers)), logger)
+			if len(dates) == 0 {
+				return fmt.Errorf("no valid market days in the specified range")
+			}
+
+			tasks := generateTasks(cfg, dates, tickers, packages)
+			if len(tasks) == 0 {
+				return fmt.Errorf("no tickers/categories selected, check config")
+			}
+
+			stgMgr, err := newStagingManager(cfg)
+			if err != nil {
+				return err
+			}
+
+			var missing []download.Task
+			for _, task := range tasks {
+				found := false
+				for _, path := range task.StoredPaths() {
+					if found, err = stgMgr.Exists(ctx, path); err != nil {
+						return fmt.Errorf("checking %s: %w", task, err)
+					}
+					if found {
+						break
+					}
+				}
+				if !found {
+					missing = append(missing, task)
+				}
+			}
+			logger.Debug("checked output", zap.String("output", stgMgr.Location()), zap.Int("files", len(tasks)))
+
+			if asJSON {
+				gaps := make([]gap, 0, len(missing))
+				for _, t := range missing {
+					gaps = append(gaps, gap{Date: t.Date, Ticker: t.Ticker, Package: t.Package, Category: t.Category})
+				}
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(gaps); err != nil {
+					return err
+				}
+			} else {
+				for _, t := range missing {
+					fmt.Println(t)
+				}
+			}
+
+			fmt.Fprintf(os.Stderr, "%d of %d files missing across %d market days\n", len(missing), len(tasks), len(dates))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&tickers, "tickers", nil, "override tickers from config")
+	cmd.Flags().StringSliceVar(&packages, "packages", nil, "override packages from config (state,classic,orderflow,volatility)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print missing files as a JSON array")
+
+	return cmd
+}
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/dgnsrekt/gexbot-downloader/internal/api"
 	"github.com/dgnsrekt/gexbot-downloader/internal/config"
 	"github.com/dgnsrekt/gexbot-downloader/internal/download"
-	"github.com/scmhub/calendar"
+	"github.com/dgnsrekt/gexbot-downloader/internal/staging"
 	"go.uber.org/zap"
 )
 
@@ -40,18 +48,50 @@ func parseDates(args []string) ([]string, error) {
 	return dates, nil
 }
 
+// newAPIClient creates the API client from the loaded config.
+func newAPIClient() (*api.HTTPClient, error) {
+	// TLS customization for intercepting proxies (nil when unset)
+	tlsConfig, err := api.LoadTLSConfig(api.TLSOptions{
+		CAFile:             cfg.API.TLS.CAFile,
+		ClientCertFile:     cfg.API.TLS.ClientCertFile,
+		ClientKeyFile:      cfg.API.TLS.ClientKeyFile,
+		InsecureSkipVerify: cfg.API.TLS.InsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("configuring TLS: %w", err)
+	}
+
+	return api.NewClient(
+		cfg.API.BaseURL,
+		cfg.API.APIKey,
+		cfg.Download.RatePerSecond,
+		time.Duration(cfg.API.TimeoutSec)*time.Second,
+		time.Duration(cfg.API.RetryDelay)*time.Second,
+		cfg.API.RetryCount,
+		logger,
+		api.WithProxy(cfg.API.ProxyURL),
+		api.WithNoProxy(cfg.API.NoProxy),
+		api.WithProxyAuth(cfg.API.ProxyAuth.Username, cfg.API.ProxyAuth.Password),
+		api.WithTLSConfig(tlsConfig),
+		api.WithMirrors(cfg.API.Mirrors),
+		api.WithPackageRates(cfg.Packages.PackageRates()),
+		api.WithTimeouts(api.Timeouts{
+			Connect:        time.Duration(cfg.API.ConnectTimeoutSec) * time.Second,
+			ResponseHeader: time.Duration(cfg.API.HeaderTimeoutSec) * time.Second,
+			DownloadIdle:   time.Duration(cfg.API.DownloadIdleTimeoutSec) * time.Second,
+		}),
+		api.WithSegmentedDownload(api.SegmentOptions{
+			Segments: cfg.Download.Segments,
+			MinSize:  int64(cfg.Download.SegmentMinSizeMB) << 20,
+		}),
+	), nil
+}
+
 // generateTasks creates download tasks based on config and overrides
 func generateTasks(cfg *config.Config, dates []string, tickerOverride, packageOverride []string) []download.Task {
 	var tasks []download.Task
 
-	// Determine tickers
-	tickers := cfg.Tickers
-	if len(tickerOverride) > 0 {
-		tickers = tickerOverride
-	}
-	if len(tickers) == 0 {
-		tickers = config.DefaultTickers()
-	}
+	tickers := resolveTickers(cfg, tickerOverride)
 
 	// Build package/category map
 	pkgCategories := make(map[string][]string)
@@ -84,6 +124,14 @@ func generateTasks(cfg *config.Config, dates []string, tickerOverride, packageOv
 					}
 					pkgCategories["orderflow"] = cats
 				}
+			case "volatility":
+				if cfg.Packages.Volatility.Enabled || len(packageOverride) > 0 {
+					cats := cfg.Packages.Volatility.Categories
+					if len(cats) == 0 {
+						cats = config.ValidCategories[config.PackageVolatility]
+					}
+					pkgCategories["volatility"] = cats
+				}
 			}
 		}
 	} else {
@@ -109,11 +157,21 @@ func generateTasks(cfg *config.Config, dates []string, tickerOverride, packageOv
 			}
 			pkgCategories["orderflow"] = cats
 		}
+		if cfg.Packages.Volatility.Enabled {
+			cats := cfg.Packages.Volatility.Categories
+			if len(cats) == 0 {
+				cats = config.ValidCategories[config.PackageVolatility]
+			}
+			pkgCategories["volatility"] = cats
+		}
 	}
 
-	// Generate tasks for all combinations
+	// Generate tasks for all combinations, on the dates each ticker trades
 	for _, date := range dates {
 		for _, ticker := range tickers {
+			if !config.IsTradingDay(cfg.Calendar(ticker), date) {
+				continue
+			}
 			for pkg, categories := range pkgCategories {
 				for _, category := range categories {
 					tasks = append(tasks, download.Task{
@@ -130,24 +188,99 @@ func generateTasks(cfg *config.Config, dates []string, tickerOverride, packageOv
 	return tasks
 }
 
-// filterMarketDays filters out non-trading days (weekends and NYSE holidays)
-// and logs warnings for skipped dates
-func filterMarketDays(dates []string, logger *zap.Logger) []string {
-	nyse := calendar.XNYS()
-	const layout = "2006-01-02 15:04:05"
+// readTaskList reads tasks listed one per line as date/ticker/package/category
+// from path, or from stdin when path is "-". Blank lines and lines starting
+// with # are ignored.
+func readTaskList(path string) ([]download.Task, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("opening task list: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
 
-	// NYSE operates in Eastern time
-	loc, err := time.LoadLocation("America/New_York")
-	if err != nil {
-		logger.Warn("failed to load America/New_York timezone, using UTC", zap.Error(err))
-		loc = time.UTC
+	var tasks []download.Task
+	seen := make(map[download.Task]bool)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		task, err := download.ParseTask(line)
+		if err != nil {
+			return nil, fmt.Errorf("task list line %d: %w", n, err)
+		}
+		if !seen[task] {
+			seen[task] = true
+			tasks = append(tasks, task)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading task list: %w", err)
 	}
+	return tasks, nil
+}
+
+// taskDates returns the sorted distinct dates of tasks.
+func taskDates(tasks []download.Task) []string {
+	return distinct(tasks, func(t download.Task) string { return t.Date })
+}
 
+// taskTickers returns the sorted distinct tickers of tasks.
+func taskTickers(tasks []download.Task) []string {
+	return distinct(tasks, func(t download.Task) string { return t.Ticker })
+}
+
+func distinct(tasks []download.Task, field func(download.Task) string) []string {
+	seen := make(map[string]bool)
+	var values []string
+	for _, t := range tasks {
+		if v := field(t); !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// resolveTickers returns the ticker override, else the configured tickers,
+// else the default tickers
+func resolveTickers(cfg *config.Config, override []string) []string {
+	if len(override) > 0 {
+		return override
+	}
+	if len(cfg.Tickers) > 0 {
+		return cfg.Tickers
+	}
+	return config.DefaultTickers()
+}
+
+// tickerCalendars returns the distinct trading calendars of tickers
+func tickerCalendars(cfg *config.Config, tickers []string) []string {
+	var calendars []string
+	for _, ticker := range tickers {
+		if cal := cfg.Calendar(ticker); !slices.Contains(calendars, cal) {
+			calendars = append(calendars, cal)
+		}
+	}
+	return calendars
+}
+
+// filterMarketDays filters out dates that none of the calendars trade on
+// (weekends and holidays; see config.IsTradingDay) and logs warnings for
+// skipped dates
+func filterMarketDays(dates, calendars []string, logger *zap.Logger) []string {
 	var marketDays []string
 	for _, dateStr := range dates {
-		// Parse as noon in NYC timezone to ensure correct date matching
-		t, _ := time.ParseInLocation(layout, dateStr+" 12:00:00", loc)
-		if nyse.IsBusinessDay(t) {
+		trading := slices.ContainsFunc(calendars, func(cal string) bool {
+			return config.IsTradingDay(cal, dateStr)
+		})
+		if trading {
 			marketDays = append(marketDays, dateStr)
 		} else {
 			logger.Warn("skipping non-market day", zap.String("date", dateStr))
@@ -155,3 +288,53 @@ func filterMarketDays(dates []string, logger *zap.Logger) []string {
 	}
 	return marketDays
 }
+
+// recoverStaging commits or discards staging data left by an interrupted run
+// and converts recovered dates to the configured output format
+func recoverStaging(stgMgr *staging.Manager, cfg *config.Config, logger *zap.Logger) {
+	result, err := stgMgr.Recover()
+	if err != nil {
+		logger.Warn("staging recovery failed", zap.Error(err))
+		return
+	}
+	if len(result.Dates) == 0 {
+		return
+	}
+
+	logger.Info("recovered leftover staging",
+		zap.Strings("dates", result.Dates),
+		zap.Int("committed", result.Committed),
+		zap.Int("discarded", result.Discarded),
+	)
+
+	if cfg.Output.OutputFormat() != config.FormatJSON && !stgMgr.Remote() && result.Committed > 0 {
+		for _, date := range result.Dates {
+			dir := filepath.Join(cfg.Output.Directory, date)
+			if err := convertOutput(dir); err != nil {
+				logger.Warn("auto-conversion failed", zap.String("date", date), zap.Error(err))
+			}
+		}
+	}
+}
+
+// newStagingManager stages into the output directory, or into the local work
+// directory when the output is an s3://, gs:// or azblob:// URI. Remote dates
+// are converted to the output format before upload.
+func newStagingManager(cfg *config.Config) (*staging.Manager, error) {
+	if !cfg.Output.Remote() {
+		return staging.NewManager(cfg.Output.Directory), nil
+	}
+
+	storage, err := staging.OpenStorage(cfg.Output.Directory)
+	if err != nil {
+		return nil, err
+	}
+	workDir := cfg.Output.WorkDirectory()
+	if err := os.MkdirAll(workDir, 0750); err != nil {
+		return nil, fmt.Errorf("creating staging directory: %w", err)
+	}
+
+	stgMgr := staging.NewRemoteManager(workDir, storage)
+	stgMgr.SetPrepare(convertOutput)
+	return stgMgr, nil
+}
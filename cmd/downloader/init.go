@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/sample"
+)
+
+func initCmd() *cobra.Command {
+	var (
+		dataDir  string
+		envFile  string
+		date     string
+		tickers  []string
+		interval time.Duration
+		seed     uint64
+		force    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generate a sample day and a ready-to-run server config",
+		Long: `Generate a synthetic sample day of every package into the data directory
+and write an env file that points the server at it. The sample has the
+shape of real GexBot history but random values, so it needs no API key and
+can be shared freely.
+
+The date defaults to today, or the last NYSE trading day on weekends and
+holidays.
+
+Examples:
+  # Quickstart: sample day in ./data plus .env for the server
+  gexbot-downloader init
+  just serve-gex-faker
+
+  # More tickers at 5 second resolution
+  gexbot-downloader init --tickers SPX,QQQ,IWM --interval 5s`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if date == "" {
+				date = config.MarketDayOnOrBefore(time.Now())
+			}
+			if len(tickers) == 0 {
+				tickers = sample.DefaultTickers
+			}
+			normalized := make([]string, 0, len(tickers))
+			for _, t := range tickers {
+				t = strings.ToUpper(strings.TrimSpace(t))
+				if !config.ValidTickers[t] {
+					return fmt.Errorf("invalid ticker: %s", t)
+				}
+				normalized = append(normalized, t)
+			}
+			tickers = normalized
+
+			if !force {
+				if _, err := os.Stat(envFile); err == nil {
+					return fmt.Errorf("%s already exists (use --force to overwrite or --env-file to write elsewhere)", envFile)
+				}
+				if _, err := os.Stat(filepath.Join(dataDir, date)); err == nil {
+					return fmt.Errorf("%s already exists (use --force to overwrite)", filepath.Join(dataDir, date))
+				}
+			}
+
+			result, err := sample.Generate(dataDir, sample.Options{
+				Date:     date,
+				Tickers:  tickers,
+				Interval: interval,
+				Seed:     seed,
+			})
+			if err != nil {
+				return err
+			}
+			logger.Info("sample data generated",
+				zap.String("dir", result.Dir),
+				zap.Int("files", result.Files),
+				zap.Int("recordsPerFile", result.Records),
+			)
+
+			if err := writeSampleEnv(envFile, dataDir, date); err != nil {
+				return err
+			}
+			logger.Info("server config written", zap.String("file", envFile))
+
+			fmt.Printf(`
+Sample day %s is ready. Start the server:
+
+  just serve-gex-faker
+  # or: set -a && . %s && set +a && go run ./cmd/server
+
+Then try:
+
+  curl "http://localhost:8080/%s/classic/full?key=demo"
+  curl "http://localhost:8080/negotiate?key=demo"
+`, date, envFile, tickers[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dataDir, "data-dir", "data", "data directory to write the sample day into")
+	cmd.Flags().StringVar(&envFile, "env-file", ".env", "server env file to write")
+	cmd.Flags().StringVar(&date, "date", "", "date of the sample day (YYYY-MM-DD, default today or the last market day)")
+	cmd.Flags().StringSliceVarP(&tickers, "tickers", "t", sample.DefaultTickers, "tickers to generate")
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "time between records")
+	cmd.Flags().Uint64Var(&seed, "seed", 1, "random seed (same seed, same data)")
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite an existing sample day and env file")
+
+	return cmd
+}
+
+// writeSampleEnv writes the server settings for replaying the sample day.
+func writeSampleEnv(path, dataDir, date string) error {
+	content := fmt.Sprintf(`# Written by gexbot-downloader init. See gexbot.example.env for all settings.
+PORT=8080
+DATA_DIR=%s
+DATA_DATE=%s
+DATA_MODE=memory
+# Loop the sample day instead of returning 404 at the end
+CACHE_MODE=rotation
+ENDPOINT_CACHE_MODE=shared
+WS_ENABLED=true
+WS_STREAM_INTERVAL=1s
+`, dataDir, date)
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api"
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// probeResult is the upstream availability of one task.
+type probeResult struct {
+	task      download.Task
+	available bool
+	err       error // lookup failed; availability unknown
+}
+
+func listRemoteCmd() *cobra.Command {
+	var (
+		tickers  []string
+		packages []string
+		detail   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list-remote YYYY-MM-DD [END_DATE]",
+		Short: "Show which dates and categories are available upstream",
+		Long: `Ask the hist API which of the configured files exist for each market
+day, without downloading them, and print a coverage matrix of available
+categories per date and ticker.
+
+Each cell is available/expected; "-" means nothing is available and "?"
+marks lookups that failed. Every file costs one API request, subject to
+download.rate_per_second.
+
+Examples:
+  # Coverage of the configured tickers for a month
+  gexbot-downloader list-remote 2025-10-01 2025-10-31
+
+  # Which SPX state categories are missing
+  gexbot-downloader list-remote --tickers SPX --packages state --detail 2025-10-01 2025-10-31`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dates, err := parseDates(args)
+			if err != nil {
+				return err
+			}
+			dates = filterMarketDays(dates, tickerCalendars(cfg, resolveTickers(cfg, tickers)), logger)
+			if len(dates) == 0 {
+				return fmt.Errorf("no valid market days in the specified range")
+			}
+
+			tasks := generateTasks(cfg, dates, tickers, packages)
+			if len(tasks) == 0 {
+				return fmt.Errorf("no tickers/categories selected, check config")
+			}
+
+			client, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			logger.Info("probing upstream", zap.Int("files", len(tasks)))
+			results := probeTasks(cmd.Context(), client, tasks, cfg.Download.Workers)
+			if err := cmd.Context().Err(); err != nil {
+				return err
+			}
+
+			printCoverage(results, detail)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&tickers, "tickers", nil, "override tickers from config")
+	cmd.Flags().StringSliceVar(&packages, "packages", nil, "override packages from config (state,classic,orderflow,volatility)")
+	cmd.Flags().BoolVar(&detail, "detail", false, "list the missing categories of partly available dates")
+
+	return cmd
+}
+
+// probeTasks looks up the download URL of every task with the given number
+// of workers; the client's rate limit applies.
+func probeTasks(ctx context.Context, client api.Client, tasks []download.Task, workers int) []probeResult {
+	results := make([]probeResult, len(tasks))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				task := tasks[i]
+				_, err := client.GetDownloadURL(ctx, task.Ticker, task.Package, task.Category, task.Date)
+				switch {
+				case err == nil:
+					results[i] = probeResult{task: task, available: true}
+				case errors.Is(err, api.ErrNotFound):
+					results[i] = probeResult{task: task}
+				default:
+					logger.Debug("probe failed", zap.String("task", task.String()), zap.Error(err))
+					results[i] = probeResult{task: task, err: err}
+				}
+			}
+		}()
+	}
+
+	for i := range tasks {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// coverageCell counts the probes of one date and ticker.
+type coverageCell struct {
+	available int
+	expected  int
+	failed    int
+	missing   []string // package/category
+}
+
+func printCoverage(results []probeResult, detail bool) {
+	cells := make(map[string]map[string]*coverageCell) // date -> ticker
+	var dates, tickers []string
+	seenTicker := make(map[string]bool)
+	available, failed := 0, 0
+
+	for _, r := range results {
+		byTicker, ok := cells[r.task.Date]
+		if !ok {
+			byTicker = make(map[string]*coverageCell)
+			cells[r.task.Date] = byTicker
+			dates = append(dates, r.task.Date)
+		}
+		if !seenTicker[r.task.Ticker] {
+			seenTicker[r.task.Ticker] = true
+			tickers = append(tickers, r.task.Ticker)
+		}
+		cell, ok := byTicker[r.task.Ticker]
+		if !ok {
+			cell = &coverageCell{}
+			byTicker[r.task.Ticker] = cell
+		}
+
+		cell.expected++
+		switch {
+		case r.err != nil:
+			cell.failed++
+			failed++
+		case r.available:
+			cell.available++
+			available++
+		default:
+			cell.missing = append(cell.missing, r.task.Package+"/"+r.task.Category)
+		}
+	}
+	sort.Strings(dates)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "DATE\t%s\n", strings.Join(tickers, "\t"))
+	for _, date := range dates {
+		row := make([]string, len(tickers))
+		for i, ticker := range tickers {
+			cell := cells[date][ticker]
+			switch {
+			case cell == nil:
+				row[i] = ""
+			case cell.failed > 0:
+				row[i] = fmt.Sprintf("%d/%d?", cell.available, cell.expected)
+			case cell.available == 0:
+				row[i] = "-"
+			default:
+				row[i] = fmt.Sprintf("%d/%d", cell.available, cell.expected)
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\n", date, strings.Join(row, "\t"))
+	}
+	_ = tw.Flush()
+
+	fmt.Printf("\n%d of %d files available", available, len(results))
+	if failed > 0 {
+		fmt.Printf(", %d lookups failed (run with -v for details)", failed)
+	}
+	fmt.Println()
+
+	if !detail {
+		return
+	}
+	for _, date := range dates {
+		for _, ticker := range tickers {
+			cell := cells[date][ticker]
+			if cell == nil || len(cell.missing) == 0 || cell.available == 0 {
+				continue
+			}
+			fmt.Printf("%s %s missing: %s\n", date, ticker, strings.Join(cell.missing, ", "))
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
 )
 
 var (
@@ -23,7 +24,9 @@ var (
 	cfg     *config.Config
 )
 
-func setupLogger(verbose bool, logCfg *config.LoggingConfig) (*zap.Logger, error) {
+// setupLogger builds the logger. Without console, logs only go to the log
+// file, e.g. while a progress display owns the terminal.
+func setupLogger(verbose, console bool, logCfg *config.LoggingConfig) (*zap.Logger, error) {
 	var zapConfig zap.Config
 	if verbose {
 		zapConfig = zap.NewDevelopmentConfig()
@@ -31,6 +34,9 @@ func setupLogger(verbose bool, logCfg *config.LoggingConfig) (*zap.Logger, error
 		zapConfig = zap.NewProductionConfig()
 		zapConfig.DisableStacktrace = true
 	}
+	if !console {
+		zapConfig.OutputPaths = nil
+	}
 
 	// Set log level from config
 	if logCfg != nil && logCfg.Level != "" {
@@ -58,11 +64,13 @@ func main() {
 		Use:   "gexbot-downloader",
 		Short: "Download historical data from Gexbot API",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Skip config loading for help commands
-			if cmd.Name() == "help" || cmd.Name() == "completion" {
+			// Skip config loading for help commands, init, which needs no API
+			// key, and the config commands, which load it themselves
+			inConfig := cmd.Parent() != nil && cmd.Parent().Name() == "config"
+			if cmd.Name() == "help" || cmd.Name() == "completion" || cmd.Name() == "init" || inConfig {
 				// Use basic logger for help commands
 				var err error
-				logger, err = setupLogger(verbose, nil)
+				logger, err = setupLogger(verbose, true, nil)
 				return err
 			}
 
@@ -73,8 +81,17 @@ func main() {
 				return err
 			}
 
+			// Encrypted output is decrypted on read, e.g. by verify and export
+			if cfg.Output.EncryptionKeyFile != "" {
+				key, err := data.ReadKeyFile(cfg.Output.EncryptionKeyFile)
+				if err != nil {
+					return err
+				}
+				data.SetEncryptionKey(key)
+			}
+
 			// Setup logger with config
-			logger, err = setupLogger(verbose, &cfg.Logging)
+			logger, err = setupLogger(verbose, !progressEnabled(cmd), &cfg.Logging)
 			if err != nil {
 				return err
 			}
@@ -87,7 +104,15 @@ func main() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 
 	rootCmd.AddCommand(downloadCmd())
+	rootCmd.AddCommand(listRemoteCmd())
 	rootCmd.AddCommand(convertCmd())
+	rootCmd.AddCommand(convertToParquetCmd())
+	rootCmd.AddCommand(exportDBCmd())
+	rootCmd.AddCommand(verifyCmd())
+	rootCmd.AddCommand(gapsCmd())
+	rootCmd.AddCommand(pruneCmd())
+	rootCmd.AddCommand(initCmd())
+	rootCmd.AddCommand(configCmd())
 
 	// Setup signal handling
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
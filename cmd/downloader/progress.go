@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// progressInterval is how often the progress display is redrawn.
+const progressInterval = 250 * time.Millisecond
+
+// progressEnabled reports whether cmd runs with a live progress display:
+// --progress was given and stderr is a terminal to draw it on.
+func progressEnabled(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("progress")
+	if flag == nil || flag.Value.String() != "true" {
+		return false
+	}
+	return isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd())
+}
+
+// workerState is the task a worker is downloading.
+type workerState struct {
+	task    download.Task
+	path    string // staging path; the transfer goes to path.tmp
+	started time.Time
+}
+
+// progressDisplay renders a live per-worker view of a download run. It
+// implements download.Progress.
+type progressDisplay struct {
+	out   io.Writer
+	total int
+	start time.Time
+
+	mu      sync.Mutex
+	workers map[int]*workerState
+	slots   int // worker IDs seen, which count up from 0
+	done    int
+	ok      int
+	skipped int
+	missing int
+	failed  int
+	bytes   int64
+	lastErr string
+	lines   int // lines drawn by the last render
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// startProgress starts redrawing a progress display for total tasks on out.
+func startProgress(out io.Writer, total int) *progressDisplay {
+	p := &progressDisplay{
+		out:     out,
+		total:   total,
+		start:   time.Now(),
+		workers: make(map[int]*workerState),
+		stop:    make(chan struct{}),
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.render(false)
+			}
+		}
+	}()
+	return p
+}
+
+func (p *progressDisplay) TaskStarted(worker int, task download.Task, stagingPath string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.workers[worker] = &workerState{task: task, path: stagingPath, started: time.Now()}
+	p.slots = max(p.slots, worker+1)
+}
+
+func (p *progressDisplay) TaskFinished(worker int, result download.TaskResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.workers, worker)
+	p.done++
+	switch {
+	case result.Skipped:
+		p.skipped++
+	case result.NotFound:
+		p.missing++
+	case result.Success:
+		p.ok++
+		p.bytes += result.BytesSize
+	default:
+		p.failed++
+		if result.Error != nil {
+			p.lastErr = fmt.Sprintf("%s: %v", result.Task, result.Error)
+		}
+	}
+}
+
+// Stop draws the final state without the idle worker lines.
+func (p *progressDisplay) Stop() {
+	close(p.stop)
+	p.wg.Wait()
+	p.render(true)
+}
+
+func (p *progressDisplay) render(final bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(p.start)
+	inflight := int64(0)
+
+	var lines []string
+	if !final {
+		for id := 0; id < p.slots; id++ {
+			w, ok := p.workers[id]
+			if !ok {
+				lines = append(lines, fmt.Sprintf("  worker %-3d idle", id+1))
+				continue
+			}
+			n := stagedBytes(w.path)
+			inflight += n
+			taskTime := now.Sub(w.started)
+			lines = append(lines, fmt.Sprintf("  worker %-3d %-44s %9s %11s %6s",
+				id+1, w.task, formatBytes(n), formatRate(n, taskTime), taskTime.Truncate(time.Second)))
+		}
+	}
+
+	eta := "--"
+	if p.done > 0 && p.done < p.total {
+		remaining := time.Duration(float64(elapsed) * float64(p.total-p.done) / float64(p.done))
+		eta = remaining.Truncate(time.Second).String()
+	} else if p.done >= p.total {
+		eta = "0s"
+	}
+	summary := []string{
+		fmt.Sprintf("%s %d/%d tasks  %s  %s  elapsed %s  ETA %s",
+			progressBar(p.done, p.total, 24), p.done, p.total,
+			formatBytes(p.bytes+inflight), formatRate(p.bytes+inflight, elapsed),
+			elapsed.Truncate(time.Second), eta),
+		fmt.Sprintf("  ok %d  skipped %d  not found %d  failed %d", p.ok, p.skipped, p.missing, p.failed),
+	}
+	if p.lastErr != "" {
+		summary = append(summary, "  last failure: "+truncate(p.lastErr, 110))
+	}
+	lines = append(summary, lines...)
+
+	// Move back over the previous frame and overwrite it line by line
+	var b strings.Builder
+	if p.lines > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", p.lines)
+	}
+	for _, line := range lines {
+		b.WriteString("\r\x1b[K")
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteString("\x1b[J") // clear lines left over from a taller frame
+	_, _ = io.WriteString(p.out, b.String())
+	p.lines = len(lines)
+}
+
+// stagedBytes returns how much of a download has been written so far.
+func stagedBytes(path string) int64 {
+	if info, err := os.Stat(path + ".tmp"); err == nil {
+		return info.Size()
+	}
+	if info, err := os.Stat(path); err == nil {
+		return info.Size()
+	}
+	return 0
+}
+
+func progressBar(done, total, width int) string {
+	filled := width
+	if total > 0 {
+		filled = min(done*width/total, width)
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", width-filled) + "]"
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func formatRate(n int64, d time.Duration) string {
+	if d <= 0 {
+		return "--/s"
+	}
+	return formatBytes(int64(float64(n)/d.Seconds())) + "/s"
+}
+
+func truncate(s string, n int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/dedupe"
+	"github.com/dgnsrekt/gexbot-downloader/internal/retention"
+)
+
+func pruneCmd() *cobra.Command {
+	var (
+		keepDays int
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune --keep-days N",
+		Short: "Delete date directories older than N market days",
+		Long: `Delete downloaded date directories that fall outside the N most recent
+NYSE market days, counting back from today.
+
+Examples:
+  # Keep the last 60 market days
+  gexbot-downloader prune --keep-days 60
+
+  # Show what would be deleted
+  gexbot-downloader prune --keep-days 60 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keepDays < 1 {
+				return fmt.Errorf("--keep-days must be at least 1")
+			}
+			if cfg.Output.Remote() {
+				return fmt.Errorf("%s is remote storage; use the bucket's lifecycle rules to expire old dates", cfg.Output.Directory)
+			}
+
+			cutoff := config.NthMarketDayBefore(time.Now(), keepDays)
+			result, err := retention.Prune(cfg.Output.Directory, cutoff, dryRun)
+			if err != nil {
+				return err
+			}
+
+			verb := "Deleted"
+			if dryRun {
+				verb = "Would delete"
+			}
+			for _, date := range result.Dates {
+				fmt.Printf("%s %s\n", verb, date)
+			}
+			fmt.Printf("%s %d dates older than %s, %.1f MB\n", verb, len(result.Dates), cutoff, float64(result.Bytes)/(1<<20))
+
+			// Deduplicated content lives on while later dates link to it
+			if cfg.Output.Dedupe != dedupe.ModeOff && !dryRun {
+				freed, err := dedupe.Prune(cfg.Output.Directory)
+				if err != nil {
+					return fmt.Errorf("pruning dedupe objects: %w", err)
+				}
+				if freed > 0 {
+					fmt.Printf("Deleted unreferenced dedupe objects, %.1f MB\n", float64(freed)/(1<<20))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&keepDays, "keep-days", 0, "number of recent market days to keep")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "list dates that would be deleted without deleting them")
+	_ = cmd.MarkFlagRequired("keep-days")
+
+	return cmd
+}
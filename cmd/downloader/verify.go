@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/verify"
+)
+
+func verifyCmd() *cobra.Command {
+	var (
+		tickers  []string
+		packages []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify YYYY-MM-DD [END_DATE]",
+		Short: "Check downloaded data for completeness and integrity",
+		Long: `Verify downloaded date directories.
+
+Every record in each JSON, JSONL and Parquet file must parse into the
+model for its package, carry the file's ticker, and have a timestamp no
+earlier than the record before it. Files listed in the date's
+manifest.json must match their recorded size and SHA-256. Every
+ticker/package/category the config (or --tickers/--packages) would
+download must be present.
+
+Exits non-zero when any date has problems.
+
+Examples:
+  # Verify a single date
+  gexbot-downloader verify 2025-11-14
+
+  # Verify a range, expecting only SPX
+  gexbot-downloader verify --tickers SPX 2025-11-01 2025-11-14`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Output.Remote() {
+				return fmt.Errorf("%s is remote storage; verify a local copy of the data", cfg.Output.Directory)
+			}
+
+			dates, err := parseDates(args)
+			if err != nil {
+				return err
+			}
+			dates = filterMarketDays(dates, tickerCalendars(cfg, resolveTickers(cfg, tickers)), logger)
+			if len(dates) == 0 {
+				return fmt.Errorf("no valid market days in the specified range")
+			}
+
+			// Problems are reported above; usage would only bury them
+			cmd.SilenceUsage = true
+			failed := 0
+			for _, date := range dates {
+				var expected []string
+				for _, t := range generateTasks(cfg, []string{date}, tickers, packages) {
+					expected = append(expected, t.Ticker+"/"+t.Package+"/"+t.Category)
+				}
+
+				report, err := verify.Dir(filepath.Join(cfg.Output.Directory, date), expected)
+				if err != nil {
+					fmt.Printf("%s: %v\n", date, err)
+					failed++
+					continue
+				}
+				printVerifyReport(date, report)
+				if !report.OK() {
+					failed++
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d dates failed verification", failed, len(dates))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&tickers, "tickers", nil, "override tickers from config")
+	cmd.Flags().StringSliceVar(&packages, "packages", nil, "override packages from config (state,classic,orderflow,volatility)")
+
+	return cmd
+}
+
+func printVerifyReport(date string, report *verify.Report) {
+	status := "OK"
+	if !report.OK() {
+		status = "FAILED"
+	}
+	manifest := "no manifest"
+	if report.Manifest {
+		manifest = "manifest checked"
+	}
+	fmt.Printf("%s: %s (%d files, %d records, %s)\n", date, status, report.Files, report.Records, manifest)
+
+	for _, key := range report.Missing {
+		fmt.Printf("  missing: %s\n", key)
+	}
+	for _, issue := range report.Issues {
+		fmt.Printf("  %s\n", issue)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,8 +12,11 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/dgnsrekt/gexbot-downloader/internal/audit"
 	"github.com/dgnsrekt/gexbot-downloader/internal/config"
 	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/maintenance"
+	"github.com/dgnsrekt/gexbot-downloader/internal/notify"
 	"github.com/dgnsrekt/gexbot-downloader/internal/server"
 	"github.com/dgnsrekt/gexbot-downloader/internal/sync"
 	"github.com/dgnsrekt/gexbot-downloader/internal/ws"
@@ -40,33 +44,69 @@ func run() int {
 
 	logger.Info("configuration loaded",
 		zap.String("port", cfg.Port),
+		zap.Strings("listenAddrs", cfg.ListenAddrs),
 		zap.String("dataDir", cfg.DataDir),
 		zap.String("dataDate", cfg.DataDate),
 		zap.String("dataMode", cfg.DataMode),
 		zap.String("cacheMode", cfg.CacheMode),
 		zap.String("endpointCacheMode", cfg.EndpointCacheMode),
+		zap.Duration("shutdownTimeout", cfg.ShutdownTimeout),
+		zap.Int("memoryLimitMB", cfg.MemoryLimitMB),
 		zap.Bool("wsEnabled", cfg.WSEnabled),
 		zap.Duration("wsStreamInterval", cfg.WSStreamInterval),
 		zap.Bool("syncBroadcastSystemEnabled", cfg.SyncBroadcastSystemEnabled),
 		zap.Duration("syncBroadcastSystemInterval", cfg.SyncBroadcastSystemInterval),
 	)
 
+	// Alerts on load failures and exhausted data (optional)
+	var alerter *server.Alerter
+	notifyCfg := notify.LoadConfig()
+	if err := notifyCfg.Validate(); err != nil {
+		logger.Error("invalid notification config", zap.Error(err))
+		return 1
+	}
+	if backends := notifyCfg.FailureBackends(); len(backends) > 0 {
+		alerter = server.NewAlerter(notify.New(notifyCfg, logger), logger)
+		logger.Info("alerts enabled", zap.Strings("backends", backends))
+	}
+
+	// Encrypted data files are decrypted as they are loaded
+	if cfg.EncryptionKeyFile != "" {
+		key, err := data.ReadKeyFile(cfg.EncryptionKeyFile)
+		if err != nil {
+			logger.Error("failed to load encryption key", zap.Error(err))
+			return 1
+		}
+		data.SetEncryptionKey(key)
+	}
+
 	// Load data
 	logger.Info("loading data...", zap.String("mode", cfg.DataMode))
 	start := time.Now()
 
-	var initialLoader data.DataLoader
+	var openDay func(date string) (data.DataLoader, error)
 	switch cfg.DataMode {
 	case "memory":
-		initialLoader, err = data.NewMemoryLoader(cfg.DataDir, cfg.DataDate, logger)
+		openDay = func(date string) (data.DataLoader, error) { return data.NewMemoryLoader(cfg.DataDir, date, logger) }
 	case "stream":
-		initialLoader, err = data.NewStreamLoader(cfg.DataDir, cfg.DataDate, logger)
+		openDay = func(date string) (data.DataLoader, error) { return data.NewStreamLoader(cfg.DataDir, date, logger) }
 	default:
 		logger.Error("unknown data mode", zap.String("mode", cfg.DataMode))
 		return 1
 	}
+
+	// Stitch DATA_DAYS dates into one replay
+	var initialLoader data.DataLoader
+	dates, err := config.StitchedDates(cfg.DataDir, cfg.DataDate, cfg.DataDays)
+	if err == nil {
+		if len(dates) > 1 {
+			logger.Info("stitching dates into one replay", zap.Strings("dates", dates))
+		}
+		initialLoader, err = data.OpenDays(dates, openDay)
+	}
 	if err != nil {
 		logger.Error("failed to load data", zap.Error(err))
+		alerter.LoadFailed(cfg.DataDate, err)
 		return 1
 	}
 
@@ -82,17 +122,127 @@ func run() int {
 		cacheMode = data.CacheModeRotation
 	}
 	cache := data.NewIndexCache(cacheMode)
+	for key, mode := range cfg.KeyCacheModes {
+		cache.SetMode(data.ResetFilter{APIKey: key}, data.CacheMode(mode))
+	}
+	if len(cfg.KeyCacheModes) > 0 {
+		logger.Info("per-key cache modes loaded", zap.Int("keys", len(cfg.KeyCacheModes)))
+	}
 
 	// Create reload manager for hot reload support
 	reloadManager := server.NewReloadManager(reloadableLoader, cache, cfg, logger)
+	defer func() { _ = reloadManager.Close() }()
+	reloadManager.RunPreflight(cfg.PreflightScan)
+	if alerter != nil {
+		reloadManager.SetAlerter(alerter)
+	}
 
-	// Create server with reload manager
-	srv := server.NewServer(reloadableLoader, cache, cfg, logger, reloadManager)
+	// Keep extra dates loaded for the date query parameter
+	for _, date := range cfg.LoadDates {
+		if err := reloadManager.PreloadDate(date); err != nil {
+			logger.Error("failed to preload date", zap.String("date", date), zap.Error(err))
+			return 1
+		}
+	}
+
+	// Pin API keys to their own dates (multi-tenant replay)
+	for key, date := range cfg.KeyDates {
+		if err := reloadManager.AssignDate(key, date); err != nil {
+			logger.Error("failed to pin api key to date", zap.String("date", date), zap.Error(err))
+			return 1
+		}
+	}
+	if len(cfg.KeyDates) > 0 {
+		logger.Info("per-key data dates loaded",
+			zap.Int("keys", len(cfg.KeyDates)),
+			zap.Strings("dates", reloadManager.PinnedDates()),
+		)
+	}
+
+	// Persist playback positions across restarts (optional)
+	if cfg.CacheStateFile != "" {
+		store, restored, err := data.OpenCacheStore(cfg.CacheStateFile, cache, reloadManager.CurrentDate, logger)
+		if store == nil {
+			logger.Error("failed to open cache state file", zap.Error(err))
+			return 1
+		}
+		if err != nil {
+			logger.Warn("cache positions not restored", zap.Error(err))
+		}
+		store.Start(cfg.CacheSnapshotInterval)
+		defer func() { _ = store.Close() }()
+		reloadManager.SetCacheStore(store)
+
+		logger.Info("cache positions persisted",
+			zap.String("file", cfg.CacheStateFile),
+			zap.Duration("snapshotInterval", cfg.CacheSnapshotInterval),
+			zap.Int("restored", restored),
+		)
+	}
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if alerter != nil {
+		go alerter.Flush(ctx, time.Minute)
+	}
+
+	// Memory watchdog (optional)
+	var watchdog *server.MemoryWatchdog
+	if cfg.MemoryLimitMB > 0 {
+		watchdog = server.NewMemoryWatchdog(reloadableLoader, reloadManager, cfg.MemoryLimitMB, cfg.MemoryCheckInterval, logger)
+		go watchdog.Run(ctx)
+	}
+
+	// Access audit log (optional)
+	var auditLog *audit.Logger
+	if cfg.AuditEnabled {
+		auditLog, err = audit.NewLogger(audit.Config{
+			FilePath:   cfg.AuditFile,
+			MaxSizeMB:  cfg.AuditMaxSizeMB,
+			MaxBackups: cfg.AuditMaxBackups,
+			BufferSize: cfg.AuditBufferSize,
+		}, logger)
+		if err != nil {
+			logger.Error("failed to open audit log", zap.Error(err))
+			return 1
+		}
+		defer func() { _ = auditLog.Close() }()
+
+		logger.Info("audit log enabled",
+			zap.String("file", cfg.AuditFile),
+			zap.Int("maxSizeMB", cfg.AuditMaxSizeMB),
+			zap.Int("maxBackups", cfg.AuditMaxBackups),
+		)
+	}
+
+	// Create server with reload manager
+	srv := server.NewServer(reloadableLoader, cache, cfg, logger, reloadManager, watchdog, auditLog)
+
+	// Replay records at their timestamps instead of one per request
+	var clock *data.PlaybackClock
+	switch {
+	case cfg.WallClockReplay && cfg.SessionStart > 0:
+		speed := cfg.PlaybackSpeed
+		if speed == 0 {
+			speed = 1
+		}
+		clock = data.NewSessionClock(config.NYSELocation(), cfg.SessionStart, speed)
+	case cfg.WallClockReplay:
+		clock = data.NewWallClock(config.NYSELocation())
+	case cfg.PlaybackSpeed > 0:
+		clock = data.NewPlaybackClock(cfg.PlaybackSpeed)
+	}
+	if clock != nil {
+		srv.SetPlaybackClock(clock)
+		logger.Info("playback clock enabled",
+			zap.Float64("speed", clock.Speed()),
+			zap.Bool("wallClock", cfg.WallClockReplay),
+			zap.Duration("sessionStart", cfg.SessionStart),
+		)
+	}
+
 	// WebSocket components (optional)
 	var wsHubs *server.WebSocketHubs
 	var negotiateHandler *ws.NegotiateHandler
@@ -100,6 +250,19 @@ func run() int {
 	if cfg.WSEnabled {
 		wsHubs = &server.WebSocketHubs{}
 
+		schemas, err := ws.LoadSchemaRegistry(cfg.WSSchemaFile, cfg.WSSchemaDefault)
+		if err != nil {
+			logger.Error("failed to load WebSocket schema versions", zap.Error(err))
+			return 1
+		}
+
+		keyIntervals, err := ws.NewKeyIntervals(cfg.WSKeyIntervals)
+		if err != nil {
+			logger.Error("invalid per-key stream interval", zap.Error(err))
+			return 1
+		}
+		srv.SetKeyIntervals(keyIntervals)
+
 		// Create orderflow hub with validator
 		orderflowHub := ws.NewHub("orderflow", logger, ws.IsValidOrderflowGroup)
 		go orderflowHub.Run(ctx)
@@ -117,6 +280,7 @@ func run() int {
 
 		// Create negotiate handler
 		negotiateHandler = ws.NewNegotiateHandler(logger, cfg.WSGroupPrefix)
+		negotiateHandler.SetSchemas(schemas)
 
 		// Create and start orderflow streamer
 		orderflowStreamer, err := ws.NewStreamer(orderflowHub, reloadableLoader, cache, cfg.WSStreamInterval, logger, reloadManager)
@@ -124,6 +288,8 @@ func run() int {
 			logger.Error("failed to create orderflow streamer", zap.Error(err))
 			return 1
 		}
+		orderflowStreamer.SetKeyIntervals(keyIntervals)
+		orderflowStreamer.SetPlaybackClock(clock)
 		go orderflowStreamer.Run(ctx)
 
 		// Create and start GEX streamer
@@ -132,6 +298,8 @@ func run() int {
 			logger.Error("failed to create gex streamer", zap.Error(err))
 			return 1
 		}
+		gexStreamer.SetKeyIntervals(keyIntervals)
+		gexStreamer.SetPlaybackClock(clock)
 		go gexStreamer.Run(ctx)
 
 		// Create and start classic streamer
@@ -140,6 +308,8 @@ func run() int {
 			logger.Error("failed to create classic streamer", zap.Error(err))
 			return 1
 		}
+		classicStreamer.SetKeyIntervals(keyIntervals)
+		classicStreamer.SetPlaybackClock(clock)
 		go classicStreamer.Run(ctx)
 
 		// Create state_greeks_zero hub with validator
@@ -153,6 +323,8 @@ func run() int {
 			logger.Error("failed to create greek streamer", zap.Error(err))
 			return 1
 		}
+		greekStreamer.SetKeyIntervals(keyIntervals)
+		greekStreamer.SetPlaybackClock(clock)
 		go greekStreamer.Run(ctx)
 
 		// Create state_greeks_one hub with validator
@@ -166,11 +338,64 @@ func run() int {
 			logger.Error("failed to create greek one streamer", zap.Error(err))
 			return 1
 		}
+		greekOneStreamer.SetKeyIntervals(keyIntervals)
+		greekOneStreamer.SetPlaybackClock(clock)
 		go greekOneStreamer.Run(ctx)
 
+		// Create volatility hub with validator
+		volatilityHub := ws.NewHub("volatility", logger, ws.IsValidVolatilityGroup)
+		go volatilityHub.Run(ctx)
+		wsHubs.Volatility = volatilityHub
+
+		// Create and start volatility streamer
+		volatilityStreamer, err := ws.NewVolatilityStreamer(volatilityHub, reloadableLoader, cache, cfg.WSStreamInterval, logger, reloadManager)
+		if err != nil {
+			logger.Error("failed to create volatility streamer", zap.Error(err))
+			return 1
+		}
+		volatilityStreamer.SetKeyIntervals(keyIntervals)
+		volatilityStreamer.SetPlaybackClock(clock)
+		go volatilityStreamer.Run(ctx)
+
+		// Inject delivery faults for client resilience testing
+		if cfg.WSChaosEnabled {
+			chaos := &ws.ChaosConfig{
+				DropRate:       cfg.WSChaosDropRate,
+				DuplicateRate:  cfg.WSChaosDuplicateRate,
+				DisconnectRate: cfg.WSChaosDisconnectRate,
+				AckDelay:       cfg.WSChaosAckDelay,
+				Keys:           cfg.WSChaosKeys,
+				Groups:         cfg.WSChaosGroups,
+			}
+			for _, hub := range []*ws.Hub{orderflowHub, stateGexHub, classicHub, stateGreeksZeroHub, stateGreeksOneHub, volatilityHub} {
+				hub.SetChaos(chaos)
+			}
+			logger.Warn("WebSocket chaos injection enabled",
+				zap.Float64("dropRate", cfg.WSChaosDropRate),
+				zap.Float64("duplicateRate", cfg.WSChaosDuplicateRate),
+				zap.Float64("disconnectRate", cfg.WSChaosDisconnectRate),
+				zap.Duration("ackDelay", cfg.WSChaosAckDelay),
+				zap.Strings("groups", cfg.WSChaosGroups),
+				zap.Int("keys", len(cfg.WSChaosKeys)),
+			)
+		}
+
+		for _, hub := range []*ws.Hub{orderflowHub, stateGexHub, classicHub, stateGreeksZeroHub, stateGreeksOneHub, volatilityHub} {
+			hub.SetSchemas(schemas)
+		}
+
+		// Record group joins in the audit log
+		if auditLog != nil {
+			for _, hub := range []*ws.Hub{orderflowHub, stateGexHub, classicHub, stateGreeksZeroHub, stateGreeksOneHub, volatilityHub} {
+				hub.SetJoinRecorder(auditLog)
+			}
+		}
+
 		logger.Info("WebSocket enabled",
-			zap.Strings("hubs", []string{"orderflow", "state_gex", "classic", "state_greeks_zero", "state_greeks_one"}),
+			zap.Strings("hubs", []string{"orderflow", "state_gex", "classic", "state_greeks_zero", "state_greeks_one", "volatility"}),
 			zap.Duration("streamInterval", cfg.WSStreamInterval),
+			zap.Strings("schemas", schemas.Names()),
+			zap.Int("keyIntervals", len(cfg.WSKeyIntervals)),
 		)
 	}
 
@@ -178,6 +403,7 @@ func run() int {
 	var syncBroadcaster *sync.SyncBroadcaster
 	if cfg.SyncBroadcastSystemEnabled {
 		syncBroadcaster = sync.NewSyncBroadcaster(cache, reloadableLoader, cfg, logger)
+		syncBroadcaster.SetKeyResolver(reloadManager)
 		go syncBroadcaster.Run(ctx)
 
 		logger.Info("Sync Broadcast System enabled",
@@ -186,6 +412,25 @@ func run() int {
 		)
 	}
 
+	// Maintenance simulation: scheduled windows and the admin toggle
+	// disconnect WebSocket clients when a window starts
+	if wsHubs != nil {
+		srv.Maintenance().OnStart(func(status maintenance.Status) {
+			n := wsHubs.DisconnectAll(status.Message)
+			logger.Info("maintenance started, websocket clients disconnected",
+				zap.String("source", status.Source),
+				zap.Int("clients", n),
+			)
+		})
+	}
+	go srv.Maintenance().Run(ctx)
+	if windows := cfg.MaintenanceWindows; len(windows) > 0 {
+		logger.Info("scheduled maintenance windows",
+			zap.Int("count", len(windows)),
+			zap.String("timezone", cfg.MaintenanceLocation.String()),
+		)
+	}
+
 	// Create router
 	router, err := server.NewRouter(srv, wsHubs, negotiateHandler, syncBroadcaster, logger)
 	if err != nil {
@@ -193,36 +438,59 @@ func run() int {
 		return 1
 	}
 
-	// Setup HTTP server
+	// Setup HTTP server (shared across all listen addresses)
 	httpServer := &http.Server{
-		Addr:         ":" + cfg.Port,
 		Handler:      router,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
 
-	// Start server in goroutine
-	go func() {
-		logger.Info("starting server", zap.String("addr", httpServer.Addr))
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Error("server error", zap.Error(err))
+	// Bind all listeners up front so a bad address fails startup
+	listeners := make([]net.Listener, 0, len(cfg.ListenAddrs))
+	for _, addr := range cfg.ListenAddrs {
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			logger.Error("failed to listen", zap.String("addr", addr), zap.Error(err))
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+			return 1
 		}
-	}()
+		listeners = append(listeners, ln)
+	}
+
+	// Start serving each listener in its own goroutine
+	for _, ln := range listeners {
+		go func(ln net.Listener) {
+			logger.Info("starting server", zap.String("addr", ln.Addr().String()))
+			if err := httpServer.Serve(ln); err != nil && err != http.ErrServerClosed {
+				logger.Error("server error", zap.String("addr", ln.Addr().String()), zap.Error(err))
+			}
+		}(ln)
+	}
 
 	// Wait for interrupt
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	logger.Info("shutting down server...")
+	logger.Info("shutting down server...", zap.Duration("timeout", cfg.ShutdownTimeout))
+
+	// Graceful shutdown budget shared by WebSocket drain and HTTP shutdown
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer shutdownCancel()
 
 	// Cancel context to stop WebSocket components
 	cancel()
 
-	// Graceful HTTP server shutdown
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer shutdownCancel()
+	// Let hubs send close frames before the HTTP server stops accepting work
+	if wsHubs != nil {
+		if err := wsHubs.Drain(shutdownCtx); err != nil {
+			logger.Warn("websocket drain incomplete", zap.Error(err))
+		}
+	}
 
+	// Graceful HTTP server shutdown
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
 		logger.Error("server shutdown error", zap.Error(err))
 		return 1
@@ -0,0 +1,10 @@
+// Package configs embeds the default downloader config so the binary can
+// scaffold it without the source tree.
+package configs
+
+import _ "embed"
+
+// Default is the commented default downloader config, configs/default.yaml.
+//
+//go:embed default.yaml
+var Default []byte
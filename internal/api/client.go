@@ -2,48 +2,167 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
-	"strings"
+	"net/url"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
-	"golang.org/x/time/rate"
-)
-
-const (
-	primaryHistDomain  = "hist.gex.bot"
-	fallbackHistDomain = "hist.gexbot.com"
 )
 
 // Client interface for testability
 type Client interface {
 	GetDownloadURL(ctx context.Context, ticker, pkg, category, date string) (string, error)
+	GetTickers(ctx context.Context) ([]string, error)
 	DownloadFile(ctx context.Context, url string, dest io.Writer) (int64, error)
 }
 
 type HTTPClient struct {
-	httpClient *http.Client
+	httpClient *http.Client // URL lookups, bounded by the overall timeout
+	downloads  *http.Client // file transfers, bounded by stall detection
+	timeouts   Timeouts
 	baseURL    string
 	apiKey     string
-	limiter    *rate.Limiter
+	limiter    *adaptiveLimiter
+	pkgLimits  map[string]*adaptiveLimiter // packages with their own rate
 	retryCount int
 	retryDelay time.Duration
 	logger     *zap.Logger
+	mirrors    *mirrorSet
+	segments   SegmentOptions
+	retries    atomic.Int64
 }
 
 type HistoryResponse struct {
 	URL string `json:"url"`
 }
 
-func NewClient(baseURL, apiKey string, ratePerSec int, timeout, retryDelay time.Duration, retryCount int, logger *zap.Logger) *HTTPClient {
+// TickersResponse is the ticker list returned by the /tickers endpoint.
+type TickersResponse struct {
+	Stocks  []string `json:"stocks"`
+	Indexes []string `json:"indexes"`
+	Futures []string `json:"futures"`
+}
+
+// All returns every ticker in the response, sorted and without duplicates.
+func (r TickersResponse) All() []string {
+	tickers := slices.Concat(r.Stocks, r.Indexes, r.Futures)
+	slices.Sort(tickers)
+	return slices.Compact(tickers)
+}
+
+// ClientOption customizes an HTTPClient beyond the required settings.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	proxyURL  string
+	noProxy   []string
+	proxyUser string
+	proxyPass string
+	tlsConfig *tls.Config
+	mirrors   []string
+	segments  SegmentOptions
+	timeouts  Timeouts
+	pkgRates  map[string]int
+}
+
+// WithProxy routes all requests through an explicit http(s) or socks5 proxy.
+// Hosts listed in NO_PROXY still bypass it. Without this option the
+// HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables are honored.
+func WithProxy(proxyURL string) ClientOption {
+	return func(o *clientOptions) {
+		o.proxyURL = proxyURL
+	}
+}
+
+// WithNoProxy lists hosts that bypass the proxy, in NO_PROXY syntax (exact
+// hosts, domain suffixes, CIDR ranges or "*"), in addition to the NO_PROXY
+// environment variable.
+func WithNoProxy(hosts []string) ClientOption {
+	return func(o *clientOptions) {
+		o.noProxy = hosts
+	}
+}
+
+// WithProxyAuth sets the credentials sent to the proxy given to WithProxy,
+// replacing any in its URL. An empty username keeps the URL's.
+func WithProxyAuth(username, password string) ClientOption {
+	return func(o *clientOptions) {
+		o.proxyUser = username
+		o.proxyPass = password
+	}
+}
+
+// WithTLSConfig sets the transport TLS configuration (see LoadTLSConfig).
+// A nil config keeps the defaults.
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithMirrors sets the ordered list of interchangeable hosts that serve
+// historical files. Downloads whose URL points at any of them fail over to
+// the others, preferring mirrors that have not failed recently. An empty
+// list keeps DefaultMirrors.
+func WithMirrors(hosts []string) ClientOption {
+	return func(o *clientOptions) {
+		o.mirrors = hosts
+	}
+}
+
+func NewClient(baseURL, apiKey string, ratePerSec int, timeout, retryDelay time.Duration, retryCount int, logger *zap.Logger, opts ...ClientOption) *HTTPClient {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	proxy, err := proxyFunc(options.proxyURL, options.noProxy, options.proxyUser, options.proxyPass)
+	if err != nil {
+		logger.Warn("ignoring invalid proxy, falling back to environment", zap.Error(err))
+		proxy = http.ProxyFromEnvironment
+	} else if options.proxyURL != "" {
+		logger.Info("using proxy",
+			zap.String("proxy", RedactProxyURL(options.proxyURL)),
+			zap.Bool("auth", options.proxyUser != ""),
+			zap.Strings("no_proxy", options.noProxy),
+		)
+	}
+	if options.tlsConfig != nil && options.tlsConfig.InsecureSkipVerify {
+		logger.Warn("TLS certificate verification disabled")
+	}
+
+	mirrors := options.mirrors
+	if len(mirrors) == 0 {
+		mirrors = DefaultMirrors
+	}
+
+	timeouts := options.timeouts.withDefaults()
+
+	pkgLimits := make(map[string]*adaptiveLimiter, len(options.pkgRates))
+	for pkg, rate := range options.pkgRates {
+		if rate > 0 {
+			pkgLimits[pkg] = newAdaptiveLimiter(rate)
+		}
+	}
+
 	transport := &http.Transport{
-		MaxIdleConns:       100,
-		MaxConnsPerHost:    10,
-		IdleConnTimeout:    90 * time.Second,
-		DisableCompression: false,
+		Proxy:                 proxy,
+		DialContext:           (&net.Dialer{Timeout: timeouts.Connect, KeepAlive: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   timeouts.Connect,
+		ResponseHeaderTimeout: timeouts.ResponseHeader,
+		TLSClientConfig:       options.tlsConfig,
+		MaxIdleConns:          100,
+		MaxConnsPerHost:       10,
+		IdleConnTimeout:       90 * time.Second,
+		DisableCompression:    false,
 	}
 
 	return &HTTPClient{
@@ -51,40 +170,95 @@ func NewClient(baseURL, apiKey string, ratePerSec int, timeout, retryDelay time.
 			Transport: transport,
 			Timeout:   timeout,
 		},
+		downloads:  &http.Client{Transport: transport},
+		timeouts:   timeouts,
 		baseURL:    baseURL,
 		apiKey:     apiKey,
-		limiter:    rate.NewLimiter(rate.Limit(ratePerSec), ratePerSec*2),
+		limiter:    newAdaptiveLimiter(ratePerSec),
+		pkgLimits:  pkgLimits,
 		retryCount: retryCount,
 		retryDelay: retryDelay,
 		logger:     logger,
+		mirrors:    newMirrorSet(mirrors, defaultMirrorCooldown),
+		segments:   options.segments,
 	}
 }
 
+// Retries returns the number of requests retried so far, including mirror
+// failovers.
+func (c *HTTPClient) Retries() int64 {
+	return c.retries.Load()
+}
+
+// MirrorStatus returns the current health of each configured mirror.
+func (c *HTTPClient) MirrorStatus() []MirrorStatus {
+	return c.mirrors.status()
+}
+
 func (c *HTTPClient) GetDownloadURL(ctx context.Context, ticker, pkg, category, date string) (string, error) {
-	// Wait for rate limiter
-	if err := c.limiter.Wait(ctx); err != nil {
-		return "", fmt.Errorf("rate limiter: %w", err)
+	url := fmt.Sprintf("%s/v2/hist/%s/%s/%s/%s?noredirect", c.baseURL, ticker, pkg, category, date)
+	body, err := c.getJSON(ctx, c.limiterFor(pkg), url)
+	if err != nil {
+		return "", err
 	}
 
-	url := fmt.Sprintf("%s/v2/hist/%s/%s/%s/%s?noredirect", c.baseURL, ticker, pkg, category, date)
+	var histResp HistoryResponse
+	if err := json.Unmarshal(body, &histResp); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+
+	return histResp.URL, nil
+}
+
+// GetTickers returns the tickers the API currently serves to this account,
+// sorted and without duplicates.
+func (c *HTTPClient) GetTickers(ctx context.Context) ([]string, error) {
+	body, err := c.getJSON(ctx, c.limiter, c.baseURL+"/tickers")
+	if err != nil {
+		return nil, err
+	}
+
+	var tickersResp TickersResponse
+	if err := json.Unmarshal(body, &tickersResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return tickersResp.All(), nil
+}
+
+// getJSON performs a retried GET of an API endpoint, paced by limiter, and
+// returns the body of a 200 response. A 429 slows the limiter down for every
+// later request and, with a Retry-After header, replaces the exponential
+// backoff.
+func (c *HTTPClient) getJSON(ctx context.Context, limiter *adaptiveLimiter, url string) ([]byte, error) {
 	c.logger.Debug("requesting", zap.String("url", url))
 
 	var lastErr error
+	serverDelay := false // the limiter already waits out the server's Retry-After
 	for attempt := 0; attempt <= c.retryCount; attempt++ {
 		if attempt > 0 {
-			delay := c.retryDelay * time.Duration(1<<(attempt-1)) // Exponential backoff
-			c.logger.Debug("retrying request", zap.Int("attempt", attempt), zap.Duration("delay", delay))
-
-			select {
-			case <-ctx.Done():
-				return "", ctx.Err()
-			case <-time.After(delay):
+			c.retries.Add(1)
+			if !serverDelay {
+				delay := c.retryDelay * time.Duration(1<<(attempt-1)) // Exponential backoff
+				c.logger.Debug("retrying request", zap.Int("attempt", attempt), zap.Duration("delay", delay))
+
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(delay):
+				}
 			}
 		}
+		serverDelay = false
+
+		// Wait for rate limiter
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limiter: %w", err)
+		}
 
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 		if err != nil {
-			return "", fmt.Errorf("creating request: %w", err)
+			return nil, fmt.Errorf("creating request: %w", err)
 		}
 
 		req.Header.Set("Authorization", "Basic "+c.apiKey)
@@ -106,10 +280,17 @@ func (c *HTTPClient) GetDownloadURL(ctx context.Context, ticker, pkg, category,
 		}
 
 		if resp.StatusCode == http.StatusNotFound {
-			return "", ErrNotFound
+			return nil, ErrNotFound
 		}
 
 		if resp.StatusCode == http.StatusTooManyRequests {
+			wait := retryAfter(resp.Header, time.Now())
+			if limiter.rateLimited(wait) {
+				c.logger.Warn("rate limited by API, slowing down",
+					zap.Float64("requests_per_sec", float64(limiter.Limit())),
+					zap.Duration("retry_after", wait))
+			}
+			serverDelay = wait > 0
 			lastErr = ErrRateLimited
 			continue
 		}
@@ -120,57 +301,192 @@ func (c *HTTPClient) GetDownloadURL(ctx context.Context, ticker, pkg, category,
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			return "", fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+			return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+		}
+
+		// Hold further requests when this one used up the rate-limit window
+		if wait := retryAfter(resp.Header, time.Now()); wait > 0 {
+			c.logger.Debug("rate-limit window exhausted, pausing", zap.Duration("wait", wait))
+			limiter.pause(wait)
+		}
+
+		return body, nil
+	}
+
+	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
+}
+
+func (c *HTTPClient) DownloadFile(ctx context.Context, rawURL string, dest io.Writer) (int64, error) {
+	size, _, err := c.DownloadFileConditional(ctx, rawURL, Validators{}, dest)
+	return size, err
+}
+
+// DownloadFileConditional downloads rawURL unless it still matches prev, in
+// which case ErrNotModified is returned and dest is untouched. The validators
+// of the downloaded file are returned for the next conditional request.
+func (c *HTTPClient) DownloadFileConditional(ctx context.Context, rawURL string, prev Validators, dest io.Writer) (int64, Validators, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || !c.mirrors.contains(u.Hostname()) {
+		return c.downloadFileOnce(ctx, rawURL, prev, dest)
+	}
+
+	var lastErr error
+	for i, host := range c.mirrors.order() {
+		if i > 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, Validators{}, err
+			}
+			// Discard any partial body from the failed mirror
+			if err := rewind(dest); err != nil {
+				return 0, Validators{}, fmt.Errorf("mirror %s failed (%v), cannot reset destination: %w", host, lastErr, err)
+			}
 		}
 
-		var histResp HistoryResponse
-		if err := json.Unmarshal(body, &histResp); err != nil {
-			return "", fmt.Errorf("decoding response: %w", err)
+		mirrorURL := withHost(u, host)
+		if i > 0 {
+			c.logger.Info("retrying with mirror",
+				zap.String("mirror", host),
+				zap.Error(lastErr))
+			c.retries.Add(1)
 		}
 
-		return histResp.URL, nil
+		size, validators, err := c.downloadFileOnce(ctx, mirrorURL, prev, dest)
+		if err == nil || errors.Is(err, ErrNotModified) {
+			c.mirrors.markSuccess(host)
+			return size, validators, err
+		}
+		c.mirrors.markFailure(host)
+		lastErr = err
 	}
 
-	return "", fmt.Errorf("max retries exceeded: %w", lastErr)
+	return 0, Validators{}, lastErr
 }
 
-func (c *HTTPClient) DownloadFile(ctx context.Context, url string, dest io.Writer) (int64, error) {
-	size, err := c.downloadFileOnce(ctx, url, dest)
-	if err == nil {
-		return size, nil
+// withHost returns u with its host replaced, keeping any explicit port.
+func withHost(u *url.URL, host string) string {
+	mirror := *u
+	if port := u.Port(); port != "" {
+		mirror.Host = host + ":" + port
+	} else {
+		mirror.Host = host
 	}
+	return mirror.String()
+}
+
+// truncateSeeker is a destination that can be reset for another attempt.
+type truncateSeeker interface {
+	io.Seeker
+	Truncate(size int64) error
+}
 
-	// Check if fallback is applicable
-	if !strings.Contains(url, primaryHistDomain) {
-		return 0, err
+func canRewind(dest io.Writer) bool {
+	_, ok := dest.(truncateSeeker)
+	return ok
+}
+
+// rewind truncates a seekable destination (such as an *os.File) so the next
+// attempt starts from an empty file. Other writers are left untouched.
+func rewind(dest io.Writer) error {
+	f, ok := dest.(truncateSeeker)
+	if !ok {
+		return nil
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
 	}
+	_, err := f.Seek(0, io.SeekStart)
+	return err
+}
+
+// downloadFileOnce downloads url from a single host. Stalled, truncated or
+// corrupt transfers are retried when dest can be rewound; interrupted ones
+// continue from where they stopped.
+func (c *HTTPClient) downloadFileOnce(ctx context.Context, url string, prev Validators, dest io.Writer) (int64, Validators, error) {
+	return c.downloadFrom(ctx, url, prev, 0, Validators{}, dest)
+}
+
+// downloadFrom is downloadFileOnce for a dest already holding the first
+// offset bytes of the file identified by partial.
+func (c *HTTPClient) downloadFrom(ctx context.Context, url string, prev Validators, offset int64, partial Validators, dest io.Writer) (int64, Validators, error) {
+	for attempt := 0; ; attempt++ {
+		var (
+			size       int64
+			validators Validators
+			err        error
+		)
+		if offset > 0 {
+			size, validators, err = c.downloadRange(ctx, url, offset, partial, dest)
+		} else {
+			size, validators, err = c.downloadAttempt(ctx, url, prev, dest)
+		}
+		if !isRetryableTransfer(err) || attempt >= c.retryCount || !canRewind(dest) {
+			return size, validators, err
+		}
 
-	// Try fallback domain
-	fallbackURL := strings.Replace(url, primaryHistDomain, fallbackHistDomain, 1)
-	c.logger.Info("retrying with fallback domain",
-		zap.String("original", url),
-		zap.String("fallback", fallbackURL),
-		zap.Error(err))
+		delay := c.retryDelay * time.Duration(1<<attempt) // Exponential backoff
+		c.logger.Warn("retrying incomplete download",
+			zap.Int("attempt", attempt+1),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+		c.retries.Add(1)
+
+		select {
+		case <-ctx.Done():
+			return 0, Validators{}, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		var partialErr *PartialDownloadError
+		if errors.As(err, &partialErr) {
+			offset, partial = partialErr.Written, partialErr.Validators
+			continue
+		}
+		offset, partial = 0, Validators{}
+		if err := rewind(dest); err != nil {
+			return 0, Validators{}, fmt.Errorf("resetting destination: %w", err)
+		}
+	}
+}
 
-	return c.downloadFileOnce(ctx, fallbackURL, dest)
+func (c *HTTPClient) downloadAttempt(ctx context.Context, url string, prev Validators, dest io.Writer) (int64, Validators, error) {
+	if w, ok := dest.(io.WriterAt); ok && c.segments.Segments > 1 {
+		size, validators, wantMD5, err := c.probeSize(ctx, url, prev)
+		switch {
+		case err == nil && size > 0 && size >= c.segments.MinSize:
+			c.logger.Debug("segmented download",
+				zap.Int64("bytes", size),
+				zap.Int("segments", c.segments.Segments))
+			size, err := c.downloadSegmented(ctx, url, w, size, validators, wantMD5)
+			return size, validators, err
+		case err != nil && !errors.Is(err, errRangeUnsupported):
+			return 0, Validators{}, err
+		}
+	}
+	return c.downloadStream(ctx, url, prev, dest)
 }
 
-func (c *HTTPClient) downloadFileOnce(ctx context.Context, url string, dest io.Writer) (int64, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// downloadStream fetches url in a single request.
+func (c *HTTPClient) downloadStream(ctx context.Context, url string, prev Validators, dest io.Writer) (int64, Validators, error) {
+	guard, stop := newStallGuard(ctx, c.timeouts.DownloadIdle)
+	defer stop()
+
+	req, err := http.NewRequestWithContext(guard.ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return 0, fmt.Errorf("creating request: %w", err)
+		return 0, Validators{}, fmt.Errorf("creating request: %w", err)
 	}
+	prev.apply(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.downloads.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("executing request: %w", err)
+		return 0, Validators{}, fmt.Errorf("executing request: %w", guard.err(err))
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusNotModified {
+		return 0, prev, ErrNotModified
+	}
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		return 0, Validators{}, fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
-
-	// Stream to destination
-	return io.Copy(dest, resp.Body)
+	return c.readBody(guard, resp, dest)
 }
@@ -1,11 +1,22 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -85,3 +96,547 @@ func TestGetDownloadURL_RateLimited(t *testing.T) {
 		t.Errorf("expected 3 attempts, got %d", attempts)
 	}
 }
+
+func TestGetDownloadURL_RetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	var first time.Time
+	var retryGap time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		retryGap = time.Since(first)
+		json.NewEncoder(w).Encode(map[string]string{"url": "https://example.com/file.json"})
+	}))
+	defer server.Close()
+
+	// A retry delay far longer than Retry-After shows the header replaces the backoff
+	client := NewClient(server.URL, "test-key", 8, 30*time.Second, time.Minute, 2, zap.NewNop())
+
+	if _, err := client.GetDownloadURL(context.Background(), "SPX", "state", "gex_full", "2025-11-14"); err != nil {
+		t.Fatalf("GetDownloadURL() error = %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts.Load())
+	}
+	if retryGap < time.Second || retryGap > 10*time.Second {
+		t.Errorf("retry after %v, want about the 1s Retry-After", retryGap)
+	}
+	if got := client.limiter.Limit(); got != 4 {
+		t.Errorf("rate after 429 = %v, want 4", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 11, 14, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"none", http.Header{}, 0},
+		{"seconds", http.Header{"Retry-After": {"30"}}, 30 * time.Second},
+		{"http date", http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}}, time.Minute},
+		{"window left", http.Header{"X-Ratelimit-Remaining": {"3"}, "X-Ratelimit-Reset": {"20"}}, 0},
+		{"window exhausted", http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"20"}}, 20 * time.Second},
+		{"unix reset", http.Header{"Ratelimit-Remaining": {"0"}, "Ratelimit-Reset": {"1763121645"}}, 45 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryAfter(tt.header, now); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTickers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tickers" {
+			t.Errorf("expected path /tickers, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TickersResponse{
+			Stocks:  []string{"TSLA", "AAPL"},
+			Indexes: []string{"SPX", "VIX"},
+			Futures: []string{"ES_SPX", "SPX"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", 10, 30*time.Second, time.Millisecond, 0, zap.NewNop())
+
+	tickers, err := client.GetTickers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"AAPL", "ES_SPX", "SPX", "TSLA", "VIX"}
+	if !slices.Equal(tickers, want) {
+		t.Errorf("got %v, want %v", tickers, want)
+	}
+}
+
+func TestMatchesNoProxy(t *testing.T) {
+	tests := []struct {
+		host    string
+		noProxy string
+		want    bool
+	}{
+		{"hist.gex.bot", "", false},
+		{"hist.gex.bot", "*", true},
+		{"hist.gex.bot", "gex.bot", true},
+		{"hist.gex.bot", ".gex.bot", true},
+		{"hist.gex.bot", "other.com, hist.gex.bot:443", true},
+		{"gexbot.com", "gex.bot", false},
+		{"notgex.bot", "gex.bot", false},
+		{"10.1.2.3", "10.0.0.0/8", true},
+		{"192.168.1.1", "10.0.0.0/8", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesNoProxy(tt.host, tt.noProxy); got != tt.want {
+			t.Errorf("matchesNoProxy(%q, %q) = %v, want %v", tt.host, tt.noProxy, got, tt.want)
+		}
+	}
+}
+
+func TestClientUsesExplicitProxy(t *testing.T) {
+	var proxied bool
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL
+		proxied = r.URL.Host == "hist.example.invalid"
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(HistoryResponse{URL: "https://storage.example.com/file.json"})
+	}))
+	defer proxy.Close()
+
+	t.Setenv("NO_PROXY", "")
+	client := NewClient("http://hist.example.invalid", "test-key", 10, 5*time.Second, 10*time.Millisecond, 0, zap.NewNop(), WithProxy(proxy.URL))
+
+	if _, err := client.GetDownloadURL(context.Background(), "SPX", "state", "gex_full", "2025-11-14"); err != nil {
+		t.Fatalf("GetDownloadURL through proxy: %v", err)
+	}
+	if !proxied {
+		t.Error("request did not go through the configured proxy")
+	}
+}
+
+func TestClientProxyAuthAndNoProxy(t *testing.T) {
+	var auth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Proxy-Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(HistoryResponse{URL: "https://storage.example.com/file.json"})
+	}))
+	defer proxy.Close()
+
+	t.Setenv("NO_PROXY", "")
+	client := NewClient("http://hist.example.invalid", "test-key", 10, 5*time.Second, 10*time.Millisecond, 0, zap.NewNop(),
+		WithProxy(strings.Replace(proxy.URL, "http://", "http://old:creds@", 1)),
+		WithProxyAuth("user", "secret"))
+	if _, err := client.GetDownloadURL(context.Background(), "SPX", "state", "gex_full", "2025-11-14"); err != nil {
+		t.Fatalf("GetDownloadURL through proxy: %v", err)
+	}
+	// base64("user:secret")
+	if auth != "Basic dXNlcjpzZWNyZXQ=" {
+		t.Errorf("expected configured proxy credentials, got %q", auth)
+	}
+
+	// Hosts on the no-proxy list are requested directly
+	direct := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(HistoryResponse{URL: "https://storage.example.com/file.json"})
+	}))
+	defer direct.Close()
+
+	auth = "unset"
+	client = NewClient(direct.URL, "test-key", 10, 5*time.Second, 10*time.Millisecond, 0, zap.NewNop(),
+		WithProxy(proxy.URL), WithNoProxy([]string{"127.0.0.0/8"}))
+	if _, err := client.GetDownloadURL(context.Background(), "SPX", "state", "gex_full", "2025-11-14"); err != nil {
+		t.Fatalf("GetDownloadURL direct: %v", err)
+	}
+	if auth != "unset" {
+		t.Error("no_proxy host went through the proxy")
+	}
+}
+
+func TestClientCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(HistoryResponse{URL: "https://storage.example.com/file.json"})
+	}))
+	defer server.Close()
+
+	// Without the CA the self-signed server certificate is rejected
+	plain := NewClient(server.URL, "test-key", 10, 5*time.Second, 10*time.Millisecond, 0, zap.NewNop())
+	if _, err := plain.GetDownloadURL(context.Background(), "SPX", "state", "gex_full", "2025-11-14"); err == nil {
+		t.Fatal("expected certificate error without custom CA")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tlsConfig, err := LoadTLSConfig(TLSOptions{CAFile: caFile})
+	if err != nil {
+		t.Fatalf("LoadTLSConfig: %v", err)
+	}
+	client := NewClient(server.URL, "test-key", 10, 5*time.Second, 10*time.Millisecond, 0, zap.NewNop(), WithTLSConfig(tlsConfig))
+	if _, err := client.GetDownloadURL(context.Background(), "SPX", "state", "gex_full", "2025-11-14"); err != nil {
+		t.Fatalf("GetDownloadURL with custom CA: %v", err)
+	}
+}
+
+func TestLoadTLSConfigErrors(t *testing.T) {
+	if cfg, err := LoadTLSConfig(TLSOptions{}); cfg != nil || err != nil {
+		t.Errorf("empty options = (%v, %v), want (nil, nil)", cfg, err)
+	}
+	if _, err := LoadTLSConfig(TLSOptions{ClientCertFile: "cert.pem"}); err == nil {
+		t.Error("expected error for cert without key")
+	}
+	if _, err := LoadTLSConfig(TLSOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Error("expected error for missing CA file")
+	}
+}
+
+func TestDownloadFileMirrorFailover(t *testing.T) {
+	var hits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := strings.Cut(r.Host, ":")
+		hits = append(hits, host)
+		if host == "127.0.0.1" {
+			_, _ = w.Write([]byte("partial"))
+			panic(http.ErrAbortHandler)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 0, zap.NewNop(),
+		WithMirrors([]string{"127.0.0.1", "localhost"}))
+
+	f, err := os.CreateTemp(t.TempDir(), "dl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	size, err := client.DownloadFile(context.Background(), server.URL+"/file.json", f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := os.ReadFile(f.Name())
+	if size != 2 || string(data) != "ok" {
+		t.Fatalf("got %d bytes %q, want \"ok\"", size, data)
+	}
+
+	// The failed mirror is cooling down, so the next download skips it
+	hits = nil
+	if _, err := client.DownloadFile(context.Background(), server.URL+"/file.json", io.Discard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hits) != 1 || hits[0] != "localhost" {
+		t.Errorf("expected healthy mirror first, got %v", hits)
+	}
+
+	status := client.MirrorStatus()
+	if status[0].Healthy || status[0].ConsecutiveFailures != 1 || !status[1].Healthy {
+		t.Errorf("unexpected mirror status: %+v", status)
+	}
+}
+
+func TestDownloadFileSegmented(t *testing.T) {
+	payload := make([]byte, 1<<20+7)
+	for i := range payload {
+		payload[i] = byte(i % 251)
+	}
+
+	var ranged, failedOnce atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rng := r.Header.Get("Range"); rng != "" && rng != "bytes=0-0" {
+			ranged.Add(1)
+			// Fail the first segment request to exercise per-segment retry
+			if failedOnce.CompareAndSwap(0, 1) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		http.ServeContent(w, r, "file.json", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 2, zap.NewNop(),
+		WithSegmentedDownload(SegmentOptions{Segments: 4, MinSize: 1024}))
+
+	f, err := os.CreateTemp(t.TempDir(), "dl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	size, err := client.DownloadFile(context.Background(), server.URL+"/file.json", f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := os.ReadFile(f.Name())
+	if size != int64(len(payload)) || !bytes.Equal(got, payload) {
+		t.Fatalf("downloaded %d bytes, content mismatch", size)
+	}
+	if n := ranged.Load(); n != 5 {
+		t.Errorf("expected 4 segments plus 1 retry, got %d ranged requests", n)
+	}
+}
+
+func TestDownloadFileSegmentedRestartsWhenFileChanges(t *testing.T) {
+	old := bytes.Repeat([]byte("a"), 4096)
+	republished := bytes.Repeat([]byte("b"), 4096)
+
+	var probes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The file is republished right after the first probe
+		content, etag := republished, `"v2"`
+		if r.Header.Get("Range") == "bytes=0-0" && probes.Add(1) == 1 {
+			content, etag = old, `"v1"`
+		}
+		w.Header().Set("ETag", etag)
+		http.ServeContent(w, r, "file.json", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 2, zap.NewNop(),
+		WithSegmentedDownload(SegmentOptions{Segments: 4, MinSize: 1024}))
+
+	f, err := os.CreateTemp(t.TempDir(), "dl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := client.DownloadFile(context.Background(), server.URL+"/file.json", f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := os.ReadFile(f.Name())
+	if !bytes.Equal(got, republished) {
+		t.Errorf("downloaded a mix of versions")
+	}
+	if n := probes.Load(); n != 2 {
+		t.Errorf("expected the download to restart once, got %d probes", n)
+	}
+}
+
+func TestDownloadFileSegmentedFallsBackWithoutRanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("whole file"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 0, zap.NewNop(),
+		WithSegmentedDownload(SegmentOptions{Segments: 4}))
+
+	f, err := os.CreateTemp(t.TempDir(), "dl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := client.DownloadFile(context.Background(), server.URL+"/file.json", f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := os.ReadFile(f.Name())
+	if string(got) != "whole file" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestDownloadFileConditional(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"abc"`)
+		http.ServeContent(w, r, "file.json", time.Time{}, strings.NewReader("data"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 0, zap.NewNop())
+
+	var buf bytes.Buffer
+	_, validators, err := client.DownloadFileConditional(context.Background(), server.URL, Validators{}, &buf)
+	if err != nil || validators.ETag != `"abc"` || buf.String() != "data" {
+		t.Fatalf("first download: err=%v validators=%+v body=%q", err, validators, buf.String())
+	}
+
+	buf.Reset()
+	_, _, err = client.DownloadFileConditional(context.Background(), server.URL, validators, &buf)
+	if !errors.Is(err, ErrNotModified) || buf.Len() != 0 {
+		t.Errorf("expected ErrNotModified with no body, got err=%v body=%q", err, buf.String())
+	}
+}
+
+func TestDownloadFileRetriesTruncatedBody(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "10")
+		if calls.Add(1) == 1 {
+			_, _ = w.Write([]byte("[1,2"))
+			return // connection closes short of Content-Length
+		}
+		_, _ = w.Write([]byte("[1,2,3,45]"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 2, zap.NewNop())
+
+	f, err := os.CreateTemp(t.TempDir(), "dl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	size, err := client.DownloadFile(context.Background(), server.URL+"/file.json", f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := os.ReadFile(f.Name())
+	if size != 10 || string(got) != "[1,2,3,45]" || calls.Load() != 2 {
+		t.Errorf("got %d bytes %q after %d calls", size, got, calls.Load())
+	}
+
+	// A non-rewindable destination surfaces the truncation instead
+	calls.Store(0)
+	_, err = client.DownloadFile(context.Background(), server.URL+"/file.json", io.Discard)
+	if !errors.Is(err, ErrIncompleteDownload) {
+		t.Errorf("expected ErrIncompleteDownload, got %v", err)
+	}
+}
+
+func TestDownloadFileChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Goog-Hash", "crc32c=AAAAAA==,md5=AAAAAAAAAAAAAAAAAAAAAA==")
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 0, zap.NewNop())
+	if _, err := client.DownloadFile(context.Background(), server.URL, io.Discard); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected ErrChecksumMismatch, got %v", err)
+	}
+}
+
+func TestDownloadIdleTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		stall := r.URL.Path == "/stall"
+		for i := 0; i < 6; i++ {
+			_, _ = w.Write([]byte("x"))
+			flusher.Flush()
+			if stall && i == 2 {
+				select {
+				case <-r.Context().Done():
+					return
+				case <-time.After(2 * time.Second):
+				}
+			}
+			time.Sleep(40 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	// Total timeout far below the transfer time: it must not apply to downloads
+	client := NewClient(server.URL, "k", 10, 50*time.Millisecond, time.Millisecond, 0, zap.NewNop(),
+		WithTimeouts(Timeouts{DownloadIdle: 150 * time.Millisecond}))
+
+	var buf bytes.Buffer
+	if _, err := client.DownloadFile(context.Background(), server.URL+"/slow", &buf); err != nil || buf.Len() != 6 {
+		t.Fatalf("steady download: err=%v bytes=%d", err, buf.Len())
+	}
+
+	_, err := client.DownloadFile(context.Background(), server.URL+"/stall", io.Discard)
+	if !errors.Is(err, ErrDownloadStalled) {
+		t.Errorf("expected ErrDownloadStalled, got %v", err)
+	}
+}
+
+func TestDownloadFileSegmentedChecksRanges(t *testing.T) {
+	payload := bytes.Repeat([]byte("0123456789"), 410)
+	sum := md5.Sum(payload)
+	goodHash := "md5=" + base64.StdEncoding.EncodeToString(sum[:])
+
+	download := func(t *testing.T, handler http.HandlerFunc) error {
+		t.Helper()
+		server := httptest.NewServer(handler)
+		defer server.Close()
+		client := NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 0, zap.NewNop(),
+			WithSegmentedDownload(SegmentOptions{Segments: 4, MinSize: 1024}))
+		f, err := os.CreateTemp(t.TempDir(), "dl")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = f.Close() }()
+		_, err = client.DownloadFile(context.Background(), server.URL+"/file.json", f)
+		return err
+	}
+
+	t.Run("matching digest", func(t *testing.T) {
+		err := download(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Goog-Hash", goodHash)
+			http.ServeContent(w, r, "file.json", time.Time{}, bytes.NewReader(payload))
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("corrupt segment", func(t *testing.T) {
+		corrupt := bytes.Clone(payload)
+		corrupt[len(corrupt)-1] = 'x'
+		err := download(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Goog-Hash", goodHash)
+			http.ServeContent(w, r, "file.json", time.Time{}, bytes.NewReader(corrupt))
+		})
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("expected ErrChecksumMismatch, got %v", err)
+		}
+	})
+
+	t.Run("wrong range", func(t *testing.T) {
+		err := download(t, func(w http.ResponseWriter, r *http.Request) {
+			// Serve every segment from the start of the file, at the right length
+			var first, last int64
+			if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &first, &last); err == nil {
+				r.Header.Set("Range", fmt.Sprintf("bytes=0-%d", last-first))
+			}
+			http.ServeContent(w, r, "file.json", time.Time{}, bytes.NewReader(payload))
+		})
+		if !errors.Is(err, ErrIncompleteDownload) {
+			t.Errorf("expected ErrIncompleteDownload, got %v", err)
+		}
+	})
+}
+
+func TestResumeFileChecksum(t *testing.T) {
+	payload := []byte("0123456789")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-Goog-Hash", "md5=AAAAAAAAAAAAAAAAAAAAAA==")
+		http.ServeContent(w, r, "file.json", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 0, zap.NewNop())
+	f, err := os.CreateTemp(t.TempDir(), "dl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.Write(payload[:4]); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.ResumeFile(context.Background(), server.URL+"/file.json", 4, Validators{ETag: `"v1"`}, f)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected ErrChecksumMismatch, got %v", err)
+	}
+}
@@ -6,4 +6,9 @@ var (
 	ErrNotFound    = errors.New("data not found for this ticker/date")
 	ErrRateLimited = errors.New("rate limited by API")
 	ErrAuthFailed  = errors.New("authentication failed")
+	ErrNotModified = errors.New("remote file not modified")
+
+	ErrIncompleteDownload = errors.New("download truncated")
+	ErrChecksumMismatch   = errors.New("download checksum mismatch")
+	ErrDownloadStalled    = errors.New("download stalled")
 )
@@ -23,10 +23,36 @@ import (
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
+// Defines values for AuditEntryKind.
+const (
+	Rest   AuditEntryKind = "rest"
+	WsJoin AuditEntryKind = "ws_join"
+)
+
+// Defines values for CacheBulkRequestMode.
+const (
+	CacheBulkRequestModeExhaust  CacheBulkRequestMode = "exhaust"
+	CacheBulkRequestModeRotation CacheBulkRequestMode = "rotation"
+)
+
+// Defines values for CacheBulkRequestOperation.
+const (
+	FastForward   CacheBulkRequestOperation = "fast_forward"
+	FastForwardTo CacheBulkRequestOperation = "fast_forward_to"
+	SetIndex      CacheBulkRequestOperation = "set_index"
+	SetMode       CacheBulkRequestOperation = "set_mode"
+)
+
+// Defines values for CachePositionMode.
+const (
+	CachePositionModeExhaust  CachePositionMode = "exhaust"
+	CachePositionModeRotation CachePositionMode = "rotation"
+)
+
 // Defines values for HealthResponseCacheMode.
 const (
-	Exhaust  HealthResponseCacheMode = "exhaust"
-	Rotation HealthResponseCacheMode = "rotation"
+	HealthResponseCacheModeExhaust  HealthResponseCacheMode = "exhaust"
+	HealthResponseCacheModeRotation HealthResponseCacheMode = "rotation"
 )
 
 // Defines values for HealthResponseDataMode.
@@ -35,11 +61,32 @@ const (
 	Stream HealthResponseDataMode = "stream"
 )
 
+// Defines values for MaintenanceStatusSource.
+const (
+	Manual    MaintenanceStatusSource = "manual"
+	Scheduled MaintenanceStatusSource = "scheduled"
+)
+
 // Defines values for PackageDataName.
 const (
-	Classic   PackageDataName = "classic"
-	Orderflow PackageDataName = "orderflow"
-	State     PackageDataName = "state"
+	Classic    PackageDataName = "classic"
+	Orderflow  PackageDataName = "orderflow"
+	State      PackageDataName = "state"
+	Volatility PackageDataName = "volatility"
+)
+
+// Defines values for PlaybackMode.
+const (
+	PlaybackModeExhaust  PlaybackMode = "exhaust"
+	PlaybackModePeek     PlaybackMode = "peek"
+	PlaybackModeRotation PlaybackMode = "rotation"
+)
+
+// Defines values for TickerAvailabilityType.
+const (
+	Futures TickerAvailabilityType = "futures"
+	Indexes TickerAvailabilityType = "indexes"
+	Stocks  TickerAvailabilityType = "stocks"
 )
 
 // Defines values for DownloadClassicGexParamsAggregation.
@@ -64,6 +111,12 @@ const (
 	DownloadStateDataParamsTypeZero      DownloadStateDataParamsType = "zero"
 )
 
+// Defines values for DownloadVolatilityParamsCategory.
+const (
+	DownloadVolatilityParamsCategoryIvOne  DownloadVolatilityParamsCategory = "iv_one"
+	DownloadVolatilityParamsCategoryIvZero DownloadVolatilityParamsCategory = "iv_zero"
+)
+
 // Defines values for GetClassicGexChainParamsAggregation.
 const (
 	GetClassicGexChainParamsAggregationFull GetClassicGexChainParamsAggregation = "full"
@@ -114,6 +167,47 @@ const (
 	GetStateGexMaxChangeParamsTypeZero GetStateGexMaxChangeParamsType = "zero"
 )
 
+// Defines values for GetVolatilityParamsCategory.
+const (
+	GetVolatilityParamsCategoryIvOne  GetVolatilityParamsCategory = "iv_one"
+	GetVolatilityParamsCategoryIvZero GetVolatilityParamsCategory = "iv_zero"
+)
+
+// AdminReloadRequest defines model for AdminReloadRequest.
+type AdminReloadRequest struct {
+	// Date Date to load (YYYY-MM-DD) or a DATA_DATE keyword
+	Date string `json:"date"`
+}
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// ApiKey Masked API key (first 4 characters)
+	ApiKey string `json:"api_key"`
+
+	// Endpoint Request method and path, or hub/group for WebSocket joins
+	Endpoint string `json:"endpoint"`
+
+	// Index Data index served (REST data endpoints only)
+	Index *int `json:"index,omitempty"`
+
+	// KeyHash Short SHA-256 prefix identifying the key
+	KeyHash string         `json:"key_hash"`
+	Kind    AuditEntryKind `json:"kind"`
+
+	// Status HTTP status, or 200/400 for accepted/rejected joins
+	Status *int      `json:"status,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// AuditEntryKind defines model for AuditEntry.Kind.
+type AuditEntryKind string
+
+// AuditLogResponse defines model for AuditLogResponse.
+type AuditLogResponse struct {
+	Count   int          `json:"count"`
+	Entries []AuditEntry `json:"entries"`
+}
+
 // AvailableDataResponse defines model for AvailableDataResponse.
 type AvailableDataResponse struct {
 	// Date The requested date
@@ -133,6 +227,139 @@ type AvailableDatesResponse struct {
 	Dates *[]string `json:"dates,omitempty"`
 }
 
+// CacheBulkPosition defines model for CacheBulkPosition.
+type CacheBulkPosition struct {
+	// CacheKey Cache key with the API key masked
+	CacheKey string `json:"cache_key"`
+
+	// DataLength Records available in the date the key replays
+	DataLength int `json:"data_length"`
+	Index      int `json:"index"`
+	Previous   int `json:"previous"`
+}
+
+// CacheBulkRequest defines model for CacheBulkRequest.
+type CacheBulkRequest struct {
+	// Count Records to skip for fast_forward
+	Count *int `json:"count,omitempty"`
+
+	// Index Target index for set_index
+	Index *int `json:"index,omitempty"`
+
+	// Mode Cache mode for set_mode
+	Mode      *CacheBulkRequestMode     `json:"mode,omitempty"`
+	Operation CacheBulkRequestOperation `json:"operation"`
+
+	// Selector Selects positions; empty fields match everything
+	Selector *CacheSelector `json:"selector,omitempty"`
+
+	// Timestamp Target Unix timestamp (seconds or milliseconds) for fast_forward_to
+	Timestamp *int64 `json:"timestamp,omitempty"`
+}
+
+// CacheBulkRequestMode Cache mode for set_mode
+type CacheBulkRequestMode string
+
+// CacheBulkRequestOperation defines model for CacheBulkRequest.Operation.
+type CacheBulkRequestOperation string
+
+// CacheBulkResponse defines model for CacheBulkResponse.
+type CacheBulkResponse struct {
+	// Count Number of positions changed
+	Count int `json:"count"`
+
+	// Mode Mode applied by set_mode
+	Mode      *string              `json:"mode,omitempty"`
+	Operation string               `json:"operation"`
+	Positions *[]CacheBulkPosition `json:"positions,omitempty"`
+
+	// Skipped Selected positions whose data could not be resolved
+	Skipped int    `json:"skipped"`
+	Status  string `json:"status"`
+}
+
+// CachePosition defines model for CachePosition.
+type CachePosition struct {
+	// ApiKey Masked API key
+	ApiKey string `json:"api_key"`
+
+	// CacheKey Cache key with the API key masked
+	CacheKey string `json:"cache_key"`
+
+	// Category Absent for shared-mode positions, which advance every category
+	Category *string `json:"category,omitempty"`
+
+	// DataLength Records available in the date the key replays (0 when not loaded)
+	DataLength int `json:"data_length"`
+
+	// Date Data date the API key replays
+	Date *string `json:"date,omitempty"`
+
+	// Exhausted Whether the position reached the end in exhaust mode
+	Exhausted bool `json:"exhausted"`
+
+	// Hub WebSocket hub, absent for REST positions
+	Hub *string `json:"hub,omitempty"`
+
+	// Index Index the next request returns
+	Index   int               `json:"index"`
+	Mode    CachePositionMode `json:"mode"`
+	Package string            `json:"package"`
+	Ticker  string            `json:"ticker"`
+}
+
+// CachePositionMode defines model for CachePosition.Mode.
+type CachePositionMode string
+
+// CachePositionRequest One of cache_key or key, and one of index, timestamp or time
+type CachePositionRequest struct {
+	// CacheKey Exact cache key, e.g. SPX/classic/abc123 or ws/classic/SPX/gex_full/abc123
+	CacheKey *string `json:"cache_key,omitempty"`
+
+	// Category Narrows key to this category
+	Category *string `json:"category,omitempty"`
+	Index    *int    `json:"index,omitempty"`
+
+	// Key API key whose existing positions move
+	Key *string `json:"key,omitempty"`
+
+	// Package Narrows key to this package (state, classic, orderflow, volatility) or WebSocket hub
+	Package *string `json:"package,omitempty"`
+
+	// Ticker Narrows key to this ticker
+	Ticker *string `json:"ticker,omitempty"`
+
+	// Time Time of day in New York on the date the key replays
+	Time *string `json:"time,omitempty"`
+
+	// Timestamp Unix timestamp in seconds or milliseconds
+	Timestamp *int64 `json:"timestamp,omitempty"`
+}
+
+// CachePositionsResponse defines model for CachePositionsResponse.
+type CachePositionsResponse struct {
+	Count int `json:"count"`
+
+	// DefaultMode Cache mode of positions no override applies to
+	DefaultMode string          `json:"default_mode"`
+	Positions   []CachePosition `json:"positions"`
+}
+
+// CacheSelector Selects positions; empty fields match everything
+type CacheSelector struct {
+	Category *string `json:"category,omitempty"`
+
+	// Key API key
+	Key *string `json:"key,omitempty"`
+
+	// Package Package (state, classic, orderflow, volatility) or WebSocket hub
+	Package *string `json:"package,omitempty"`
+
+	// Prefix Cache key prefix (e.g. ws/orderflow/)
+	Prefix *string `json:"prefix,omitempty"`
+	Ticker *string `json:"ticker,omitempty"`
+}
+
 // CurrentDateResponse defines model for CurrentDateResponse.
 type CurrentDateResponse struct {
 	// CurrentDate Currently loaded data date
@@ -173,6 +400,18 @@ type DownloadLinksSummary struct {
 	TotalLinks *int `json:"total_links,omitempty"`
 }
 
+// ErrorCounters Cumulative error counters since server start
+type ErrorCounters struct {
+	// EncodeFailures WebSocket payload encode failures
+	EncodeFailures *int64 `json:"encode_failures,omitempty"`
+
+	// HandlerErrors REST handler and response-writing errors
+	HandlerErrors *int64 `json:"handler_errors,omitempty"`
+
+	// RecoveredPanics Panics recovered by the HTTP middleware
+	RecoveredPanics *int64 `json:"recovered_panics,omitempty"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error *string `json:"error,omitempty"`
@@ -255,7 +494,13 @@ type HealthResponse struct {
 	CacheMode *HealthResponseCacheMode `json:"cache_mode,omitempty"`
 	DataDate  *string                  `json:"data_date,omitempty"`
 	DataMode  *HealthResponseDataMode  `json:"data_mode,omitempty"`
-	Status    *string                  `json:"status,omitempty"`
+
+	// Errors Cumulative error counters since server start
+	Errors *ErrorCounters `json:"errors,omitempty"`
+
+	// Memory Memory watchdog state (present only when MEMORY_LIMIT_MB is set)
+	Memory *MemoryStatus `json:"memory,omitempty"`
+	Status *string       `json:"status,omitempty"`
 }
 
 // HealthResponseCacheMode defines model for HealthResponse.CacheMode.
@@ -264,6 +509,108 @@ type HealthResponseCacheMode string
 // HealthResponseDataMode defines model for HealthResponse.DataMode.
 type HealthResponseDataMode string
 
+// KeyDateAssignment defines model for KeyDateAssignment.
+type KeyDateAssignment struct {
+	// ApiKey Masked API key (first 4 characters)
+	ApiKey string `json:"api_key"`
+	Date   string `json:"date"`
+
+	// KeyHash Short SHA-256 prefix identifying the key
+	KeyHash string `json:"key_hash"`
+}
+
+// KeyDateRequest defines model for KeyDateRequest.
+type KeyDateRequest struct {
+	// Date Date to replay (YYYY-MM-DD)
+	Date string `json:"date"`
+
+	// Key API key to pin
+	Key string `json:"key"`
+}
+
+// KeyDatesResponse defines model for KeyDatesResponse.
+type KeyDatesResponse struct {
+	// DataDate Date served to keys without a pin
+	DataDate string              `json:"data_date"`
+	Keys     []KeyDateAssignment `json:"keys"`
+
+	// LoadedDates Extra dates held in memory for pinned keys
+	LoadedDates []string `json:"loaded_dates"`
+}
+
+// KeyIntervalAssignment defines model for KeyIntervalAssignment.
+type KeyIntervalAssignment struct {
+	// ApiKey Masked API key (first 4 characters)
+	ApiKey   string `json:"api_key"`
+	Interval string `json:"interval"`
+
+	// KeyHash Short SHA-256 prefix identifying the key
+	KeyHash string `json:"key_hash"`
+}
+
+// KeyIntervalRequest defines model for KeyIntervalRequest.
+type KeyIntervalRequest struct {
+	// Interval Go duration between records (minimum 10ms)
+	Interval string `json:"interval"`
+
+	// Key API key
+	Key string `json:"key"`
+}
+
+// KeyIntervalsResponse defines model for KeyIntervalsResponse.
+type KeyIntervalsResponse struct {
+	// DefaultInterval WS_STREAM_INTERVAL, served to keys without an override
+	DefaultInterval string                  `json:"default_interval"`
+	Keys            []KeyIntervalAssignment `json:"keys"`
+}
+
+// MaintenanceRequest defines model for MaintenanceRequest.
+type MaintenanceRequest struct {
+	// Duration Go duration after which the manual window ends (omit for open-ended)
+	Duration *string `json:"duration,omitempty"`
+	Enabled  bool    `json:"enabled"`
+
+	// Message Message returned to clients (defaults to MAINTENANCE_MESSAGE)
+	Message *string `json:"message,omitempty"`
+}
+
+// MaintenanceStatus defines model for MaintenanceStatus.
+type MaintenanceStatus struct {
+	Active  bool    `json:"active"`
+	Message *string `json:"message,omitempty"`
+
+	// Source What started the current window (absent when inactive)
+	Source   *MaintenanceStatusSource `json:"source,omitempty"`
+	Timezone string                   `json:"timezone"`
+
+	// Until When the current window ends (absent when open-ended)
+	Until *time.Time `json:"until,omitempty"`
+
+	// Windows Scheduled recurring windows
+	Windows []string `json:"windows"`
+}
+
+// MaintenanceStatusSource What started the current window (absent when inactive)
+type MaintenanceStatusSource string
+
+// MemoryStatus Memory watchdog state (present only when MEMORY_LIMIT_MB is set)
+type MemoryStatus struct {
+	// Degraded True once the limit has been exceeded at least once
+	Degraded *bool `json:"degraded,omitempty"`
+
+	// EvictedKeys Data keys demoted from memory to on-disk reads
+	EvictedKeys *int `json:"evicted_keys,omitempty"`
+
+	// LimitBytes Configured RSS threshold
+	LimitBytes *int64 `json:"limit_bytes,omitempty"`
+
+	// RssBytes Last sampled resident set size
+	RssBytes *int64 `json:"rss_bytes,omitempty"`
+
+	// StreamModeForced Future reloads use stream mode regardless of DATA_MODE
+	StreamModeForced *bool `json:"stream_mode_forced,omitempty"`
+}
+
 // OrderflowData defines model for OrderflowData.
 type OrderflowData struct {
 	AggCallDex    *float32 `json:"agg_call_dex,omitempty"`
@@ -305,6 +652,12 @@ type OrderflowData struct {
 	Zvanna        *float32 `json:"zvanna,omitempty"`
 }
 
+// PackageAvailability defines model for PackageAvailability.
+type PackageAvailability struct {
+	Categories []string `json:"categories"`
+	Package    string   `json:"package"`
+}
+
 // PackageData defines model for PackageData.
 type PackageData struct {
 	// Categories Available categories in this package
@@ -317,6 +670,55 @@ type PackageData struct {
 // PackageDataName Package name
 type PackageDataName string
 
+// PlaybackMode Per-request playback mode. `rotation` wraps to the first record at the
+// end, `exhaust` returns 404 at the end, and `peek` returns the current
+// record without advancing the playback position.
+type PlaybackMode string
+
+// PreflightManifestMismatch defines model for PreflightManifestMismatch.
+type PreflightManifestMismatch struct {
+	Error string `json:"error"`
+	File  string `json:"file"`
+}
+
+// PreflightMissing defines model for PreflightMissing.
+type PreflightMissing struct {
+	Categories []string `json:"categories"`
+	Package    string   `json:"package"`
+	Ticker     string   `json:"ticker"`
+}
+
+// PreflightParseFailure defines model for PreflightParseFailure.
+type PreflightParseFailure struct {
+	Error string `json:"error"`
+	File  string `json:"file"`
+	Line  *int   `json:"line,omitempty"`
+}
+
+// PreflightReport defines model for PreflightReport.
+type PreflightReport struct {
+	Date       string   `json:"date"`
+	EmptyFiles []string `json:"empty_files"`
+
+	// FilesFound JSONL files found on disk for the date
+	FilesFound  int       `json:"files_found"`
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// KeysLoaded Data keys held by the loader
+	KeysLoaded int `json:"keys_loaded"`
+
+	// ManifestMismatches Files listed in the date's manifest.json that are missing or differ in size
+	ManifestMismatches []PreflightManifestMismatch `json:"manifest_mismatches"`
+	MissingCategories  []PreflightMissing          `json:"missing_categories"`
+
+	// NotLoaded Files on disk the loader skipped
+	NotLoaded []string `json:"not_loaded"`
+
+	// Ok True when no problems were found
+	Ok            bool                    `json:"ok"`
+	ParseFailures []PreflightParseFailure `json:"parse_failures"`
+}
+
 // ReloadDateRequest defines model for ReloadDateRequest.
 type ReloadDateRequest struct {
 	// Date New date to load (YYYY-MM-DD format)
@@ -349,6 +751,16 @@ type ResetCacheResponse struct {
 	Status  *string `json:"status,omitempty"`
 }
 
+// TickerAvailability defines model for TickerAvailability.
+type TickerAvailability struct {
+	Packages []PackageAvailability  `json:"packages"`
+	Ticker   string                 `json:"ticker"`
+	Type     TickerAvailabilityType `json:"type"`
+}
+
+// TickerAvailabilityType defines model for TickerAvailability.Type.
+type TickerAvailabilityType string
+
 // TickerData defines model for TickerData.
 type TickerData struct {
 	// Packages Available packages for this ticker
@@ -358,6 +770,11 @@ type TickerData struct {
 	Symbol *string `json:"symbol,omitempty"`
 }
 
+// TickersDetailResponse defines model for TickersDetailResponse.
+type TickersDetailResponse struct {
+	Tickers []TickerAvailability `json:"tickers"`
+}
+
 // TickersResponse defines model for TickersResponse.
 type TickersResponse struct {
 	// Futures Array of futures ticker symbols
@@ -370,6 +787,83 @@ type TickersResponse struct {
 	Stocks *[]string `json:"stocks,omitempty"`
 }
 
+// VolatilityData defines model for VolatilityData.
+type VolatilityData struct {
+	// AtmIv At-the-money implied volatility
+	AtmIv *float64 `json:"atm_iv,omitempty"`
+
+	// Butterfly25d Average 25-delta wing IV minus at-the-money IV
+	Butterfly25d *float64 `json:"butterfly_25d,omitempty"`
+	MinDte       *int     `json:"min_dte,omitempty"`
+
+	// RiskReversal25d 25-delta call IV minus 25-delta put IV
+	RiskReversal25d *float64 `json:"risk_reversal_25d,omitempty"`
+	SecMinDte       *int     `json:"sec_min_dte,omitempty"`
+	Spot            *float64 `json:"spot,omitempty"`
+
+	// Strikes Per-strike rows: [strike, call_iv, put_iv]
+	Strikes   *[][]float64 `json:"strikes,omitempty"`
+	Ticker    string       `json:"ticker"`
+	Timestamp int64        `json:"timestamp"`
+}
+
+// GetAuditLogParams defines parameters for GetAuditLog.
+type GetAuditLogParams struct {
+	// ApiKey Only return entries for this API key
+	ApiKey *string `form:"api_key,omitempty" json:"api_key,omitempty"`
+
+	// Limit Maximum number of entries to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetCachePositionsParams defines parameters for GetCachePositions.
+type GetCachePositionsParams struct {
+	// Key Only this API key
+	Key *string `form:"key,omitempty" json:"key,omitempty"`
+
+	// Ticker Only this ticker
+	Ticker *string `form:"ticker,omitempty" json:"ticker,omitempty"`
+
+	// Package Only this package (state, classic, orderflow) or WebSocket hub
+	Package *string `form:"package,omitempty" json:"package,omitempty"`
+
+	// Category Only this category (shared-mode positions cover every category)
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Prefix Only cache keys starting with this prefix (e.g. ws/orderflow/)
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
+}
+
+// DeleteKeyDateParams defines parameters for DeleteKeyDate.
+type DeleteKeyDateParams struct {
+	// Key API key to unpin
+	Key string `form:"key" json:"key"`
+}
+
+// SeekCacheParams defines parameters for SeekCache.
+type SeekCacheParams struct {
+	// Ts Unix timestamp to seek to, in seconds or milliseconds
+	Ts int64 `form:"ts" json:"ts"`
+
+	// Ticker Seek positions of this ticker
+	Ticker *string `form:"ticker,omitempty" json:"ticker,omitempty"`
+
+	// Key Seek positions of this API key
+	Key *string `form:"key,omitempty" json:"key,omitempty"`
+
+	// Package Only positions of this package (or WebSocket hub)
+	Package *string `form:"package,omitempty" json:"package,omitempty"`
+
+	// Category Only positions of this category
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+}
+
+// DeleteKeyIntervalParams defines parameters for DeleteKeyInterval.
+type DeleteKeyIntervalParams struct {
+	// Key API key to reset
+	Key string `form:"key" json:"key"`
+}
+
 // GetAvailableDataParams defines parameters for GetAvailableData.
 type GetAvailableDataParams struct {
 	// Ticker Filter to a specific ticker
@@ -382,16 +876,64 @@ type DownloadClassicGexParamsAggregation string
 // DownloadStateDataParamsType defines parameters for DownloadStateData.
 type DownloadStateDataParamsType string
 
+// DownloadVolatilityParamsCategory defines parameters for DownloadVolatility.
+type DownloadVolatilityParamsCategory string
+
+// GetHealthParams defines parameters for GetHealth.
+type GetHealthParams struct {
+	// Key Report the data date served to this API key
+	Key *string `form:"key,omitempty" json:"key,omitempty"`
+}
+
+// GetOrderflowHistoryParams defines parameters for GetOrderflowHistory.
+type GetOrderflowHistoryParams struct {
+	// Key API key for playback position tracking
+	Key string `form:"key" json:"key"`
+
+	// Minutes Window length in minutes, ending at the latest record served
+	Minutes *int `form:"minutes,omitempty" json:"minutes,omitempty"`
+
+	// From Window start timestamp (Unix seconds, inclusive)
+	From *int64 `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Window end timestamp (Unix seconds, inclusive)
+	To *int64 `form:"to,omitempty" json:"to,omitempty"`
+
+	// Date Read the history of this loaded date instead of the key's own,
+	// using that date's playback position
+	Date *string `form:"date,omitempty" json:"date,omitempty"`
+}
+
 // ResetCacheParams defines parameters for ResetCache.
 type ResetCacheParams struct {
 	// Key Reset only this API key (omit for all)
 	Key *string `form:"key,omitempty" json:"key,omitempty"`
+
+	// Ticker Reset only this ticker
+	Ticker *string `form:"ticker,omitempty" json:"ticker,omitempty"`
+
+	// Package Reset only this package (state, classic, orderflow) or WebSocket hub
+	Package *string `form:"package,omitempty" json:"package,omitempty"`
+
+	// Category Reset only this category (shared-mode positions cover every category)
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Prefix Reset only cache keys starting with this prefix (e.g. ws/orderflow/)
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
 }
 
 // GetClassicGexChainParams defines parameters for GetClassicGexChain.
 type GetClassicGexChainParams struct {
 	// Key API key for playback position tracking
 	Key string `form:"key" json:"key"`
+
+	// Mode Playback mode for this request only: `rotation` or `exhaust` override
+	// the cache mode, `peek` returns the current record without advancing.
+	Mode *PlaybackMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Date Replay this loaded date instead of the key's own, with playback
+	// positions separate from the key's own date
+	Date *string `form:"date,omitempty" json:"date,omitempty"`
 }
 
 // GetClassicGexChainParamsAggregation defines parameters for GetClassicGexChain.
@@ -401,6 +943,14 @@ type GetClassicGexChainParamsAggregation string
 type GetClassicGexMajorsParams struct {
 	// Key API key for playback position tracking
 	Key string `form:"key" json:"key"`
+
+	// Mode Playback mode for this request only: `rotation` or `exhaust` override
+	// the cache mode, `peek` returns the current record without advancing.
+	Mode *PlaybackMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Date Replay this loaded date instead of the key's own, with playback
+	// positions separate from the key's own date
+	Date *string `form:"date,omitempty" json:"date,omitempty"`
 }
 
 // GetClassicGexMajorsParamsAggregation defines parameters for GetClassicGexMajors.
@@ -410,6 +960,14 @@ type GetClassicGexMajorsParamsAggregation string
 type GetClassicGexMaxChangeParams struct {
 	// Key API key for playback position tracking
 	Key string `form:"key" json:"key"`
+
+	// Mode Playback mode for this request only: `rotation` or `exhaust` override
+	// the cache mode, `peek` returns the current record without advancing.
+	Mode *PlaybackMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Date Replay this loaded date instead of the key's own, with playback
+	// positions separate from the key's own date
+	Date *string `form:"date,omitempty" json:"date,omitempty"`
 }
 
 // GetClassicGexMaxChangeParamsAggregation defines parameters for GetClassicGexMaxChange.
@@ -419,12 +977,28 @@ type GetClassicGexMaxChangeParamsAggregation string
 type GetOrderflowLatestParams struct {
 	// Key API key for playback position tracking
 	Key string `form:"key" json:"key"`
+
+	// Mode Playback mode for this request only: `rotation` or `exhaust` override
+	// the cache mode, `peek` returns the current record without advancing.
+	Mode *PlaybackMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Date Replay this loaded date instead of the key's own, with playback
+	// positions separate from the key's own date
+	Date *string `form:"date,omitempty" json:"date,omitempty"`
 }
 
 // GetStateProfileParams defines parameters for GetStateProfile.
 type GetStateProfileParams struct {
 	// Key API key for playback position tracking
 	Key string `form:"key" json:"key"`
+
+	// Mode Playback mode for this request only: `rotation` or `exhaust` override
+	// the cache mode, `peek` returns the current record without advancing.
+	Mode *PlaybackMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Date Replay this loaded date instead of the key's own, with playback
+	// positions separate from the key's own date
+	Date *string `form:"date,omitempty" json:"date,omitempty"`
 }
 
 // GetStateProfileParamsType defines parameters for GetStateProfile.
@@ -434,6 +1008,14 @@ type GetStateProfileParamsType string
 type GetStateGexMajorsParams struct {
 	// Key API key for playback position tracking
 	Key string `form:"key" json:"key"`
+
+	// Mode Playback mode for this request only: `rotation` or `exhaust` override
+	// the cache mode, `peek` returns the current record without advancing.
+	Mode *PlaybackMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Date Replay this loaded date instead of the key's own, with playback
+	// positions separate from the key's own date
+	Date *string `form:"date,omitempty" json:"date,omitempty"`
 }
 
 // GetStateGexMajorsParamsType defines parameters for GetStateGexMajors.
@@ -443,16 +1025,104 @@ type GetStateGexMajorsParamsType string
 type GetStateGexMaxChangeParams struct {
 	// Key API key for playback position tracking
 	Key string `form:"key" json:"key"`
+
+	// Mode Playback mode for this request only: `rotation` or `exhaust` override
+	// the cache mode, `peek` returns the current record without advancing.
+	Mode *PlaybackMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Date Replay this loaded date instead of the key's own, with playback
+	// positions separate from the key's own date
+	Date *string `form:"date,omitempty" json:"date,omitempty"`
 }
 
 // GetStateGexMaxChangeParamsType defines parameters for GetStateGexMaxChange.
 type GetStateGexMaxChangeParamsType string
 
+// GetVolatilityParams defines parameters for GetVolatility.
+type GetVolatilityParams struct {
+	// Key API key for playback position tracking
+	Key string `form:"key" json:"key"`
+
+	// Mode Playback mode for this request only: `rotation` or `exhaust` override
+	// the cache mode, `peek` returns the current record without advancing.
+	Mode *PlaybackMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Date Replay this loaded date instead of the key's own, with playback
+	// positions separate from the key's own date
+	Date *string `form:"date,omitempty" json:"date,omitempty"`
+}
+
+// GetVolatilityParamsCategory defines parameters for GetVolatility.
+type GetVolatilityParamsCategory string
+
+// BulkCacheOperationJSONRequestBody defines body for BulkCacheOperation for application/json ContentType.
+type BulkCacheOperationJSONRequestBody = CacheBulkRequest
+
+// SetCachePositionJSONRequestBody defines body for SetCachePosition for application/json ContentType.
+type SetCachePositionJSONRequestBody = CachePositionRequest
+
+// SetKeyDateJSONRequestBody defines body for SetKeyDate for application/json ContentType.
+type SetKeyDateJSONRequestBody = KeyDateRequest
+
+// SetMaintenanceJSONRequestBody defines body for SetMaintenance for application/json ContentType.
+type SetMaintenanceJSONRequestBody = MaintenanceRequest
+
+// AdminReloadJSONRequestBody defines body for AdminReload for application/json ContentType.
+type AdminReloadJSONRequestBody = AdminReloadRequest
+
+// SetKeyIntervalJSONRequestBody defines body for SetKeyInterval for application/json ContentType.
+type SetKeyIntervalJSONRequestBody = KeyIntervalRequest
+
 // ReloadDateJSONRequestBody defines body for ReloadDate for application/json ContentType.
 type ReloadDateJSONRequestBody = ReloadDateRequest
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Query the access audit log
+	// (GET /admin/audit)
+	GetAuditLog(w http.ResponseWriter, r *http.Request, params GetAuditLogParams)
+	// Inspect playback positions
+	// (GET /admin/cache)
+	GetCachePositions(w http.ResponseWriter, r *http.Request, params GetCachePositionsParams)
+	// Apply one operation to many playback positions
+	// (POST /admin/cache/bulk)
+	BulkCacheOperation(w http.ResponseWriter, r *http.Request)
+	// Move one client's playback positions
+	// (POST /admin/cache/position)
+	SetCachePosition(w http.ResponseWriter, r *http.Request)
+	// Return an API key to the loaded date
+	// (DELETE /admin/key-dates)
+	DeleteKeyDate(w http.ResponseWriter, r *http.Request, params DeleteKeyDateParams)
+	// List API keys pinned to a data date
+	// (GET /admin/key-dates)
+	GetKeyDates(w http.ResponseWriter, r *http.Request)
+	// Pin an API key to a data date
+	// (POST /admin/key-dates)
+	SetKeyDate(w http.ResponseWriter, r *http.Request)
+	// Maintenance simulation status
+	// (GET /admin/maintenance)
+	GetMaintenance(w http.ResponseWriter, r *http.Request)
+	// Start or end simulated maintenance
+	// (POST /admin/maintenance)
+	SetMaintenance(w http.ResponseWriter, r *http.Request)
+	// Data preflight report
+	// (GET /admin/preflight)
+	GetPreflightReport(w http.ResponseWriter, r *http.Request)
+	// Hot reload data for a date, authenticated
+	// (POST /admin/reload)
+	AdminReload(w http.ResponseWriter, r *http.Request)
+	// Seek playback positions to a timestamp
+	// (POST /admin/seek)
+	SeekCache(w http.ResponseWriter, r *http.Request, params SeekCacheParams)
+	// Return an API key to the global stream interval
+	// (DELETE /admin/ws-intervals)
+	DeleteKeyInterval(w http.ResponseWriter, r *http.Request, params DeleteKeyIntervalParams)
+	// List per-key WebSocket stream intervals
+	// (GET /admin/ws-intervals)
+	GetKeyIntervals(w http.ResponseWriter, r *http.Request)
+	// Set an API key's WebSocket stream interval
+	// (POST /admin/ws-intervals)
+	SetKeyInterval(w http.ResponseWriter, r *http.Request)
 	// Get available data for a date
 	// (GET /available-data/{date})
 	GetAvailableData(w http.ResponseWriter, r *http.Request, date string, params GetAvailableDataParams)
@@ -474,9 +1144,15 @@ type ServerInterface interface {
 	// Download state dataset
 	// (GET /download/{date}/{ticker}/state/{type})
 	DownloadStateData(w http.ResponseWriter, r *http.Request, date string, ticker string, pType DownloadStateDataParamsType)
+	// Download volatility dataset
+	// (GET /download/{date}/{ticker}/volatility/{category})
+	DownloadVolatility(w http.ResponseWriter, r *http.Request, date string, ticker string, category DownloadVolatilityParamsCategory)
 	// Health check
 	// (GET /health)
-	GetHealth(w http.ResponseWriter, r *http.Request)
+	GetHealth(w http.ResponseWriter, r *http.Request, params GetHealthParams)
+	// Get past orderflow records
+	// (GET /orderflow/{ticker}/history)
+	GetOrderflowHistory(w http.ResponseWriter, r *http.Request, ticker string, params GetOrderflowHistoryParams)
 	// Hot reload data for a different date
 	// (POST /reload-date)
 	ReloadDate(w http.ResponseWriter, r *http.Request)
@@ -486,6 +1162,9 @@ type ServerInterface interface {
 	// List available tickers
 	// (GET /tickers)
 	GetTickers(w http.ResponseWriter, r *http.Request)
+	// List loaded packages and categories per ticker
+	// (GET /tickers/detail)
+	GetTickersDetail(w http.ResponseWriter, r *http.Request)
 	// Get GEX chain data
 	// (GET /{ticker}/classic/{aggregation})
 	GetClassicGexChain(w http.ResponseWriter, r *http.Request, ticker string, aggregation GetClassicGexChainParamsAggregation, params GetClassicGexChainParams)
@@ -507,87 +1186,198 @@ type ServerInterface interface {
 	// Get GEX profile max change levels
 	// (GET /{ticker}/state/{type}/maxchange)
 	GetStateGexMaxChange(w http.ResponseWriter, r *http.Request, ticker string, pType GetStateGexMaxChangeParamsType, params GetStateGexMaxChangeParams)
+	// Get implied volatility surface
+	// (GET /{ticker}/volatility/{category})
+	GetVolatility(w http.ResponseWriter, r *http.Request, ticker string, category GetVolatilityParamsCategory, params GetVolatilityParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
-// Get available data for a date
-// (GET /available-data/{date})
-func (_ Unimplemented) GetAvailableData(w http.ResponseWriter, r *http.Request, date string, params GetAvailableDataParams) {
+// Query the access audit log
+// (GET /admin/audit)
+func (_ Unimplemented) GetAuditLog(w http.ResponseWriter, r *http.Request, params GetAuditLogParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List available dates
-// (GET /available-dates)
-func (_ Unimplemented) GetAvailableDates(w http.ResponseWriter, r *http.Request) {
+// Inspect playback positions
+// (GET /admin/cache)
+func (_ Unimplemented) GetCachePositions(w http.ResponseWriter, r *http.Request, params GetCachePositionsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get current date
-// (GET /current-date)
-func (_ Unimplemented) GetCurrentDate(w http.ResponseWriter, r *http.Request) {
+// Apply one operation to many playback positions
+// (POST /admin/cache/bulk)
+func (_ Unimplemented) BulkCacheOperation(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Download classic GEX dataset
-// (GET /download/{date}/{ticker}/classic/{aggregation})
-func (_ Unimplemented) DownloadClassicGex(w http.ResponseWriter, r *http.Request, date string, ticker string, aggregation DownloadClassicGexParamsAggregation) {
+// Move one client's playback positions
+// (POST /admin/cache/position)
+func (_ Unimplemented) SetCachePosition(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get available download links for a date/ticker
-// (GET /download/{date}/{ticker}/links)
-func (_ Unimplemented) GetDownloadLinks(w http.ResponseWriter, r *http.Request, date string, ticker string) {
+// Return an API key to the loaded date
+// (DELETE /admin/key-dates)
+func (_ Unimplemented) DeleteKeyDate(w http.ResponseWriter, r *http.Request, params DeleteKeyDateParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Download orderflow dataset
-// (GET /download/{date}/{ticker}/orderflow)
-func (_ Unimplemented) DownloadOrderflow(w http.ResponseWriter, r *http.Request, date string, ticker string) {
+// List API keys pinned to a data date
+// (GET /admin/key-dates)
+func (_ Unimplemented) GetKeyDates(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Download state dataset
-// (GET /download/{date}/{ticker}/state/{type})
-func (_ Unimplemented) DownloadStateData(w http.ResponseWriter, r *http.Request, date string, ticker string, pType DownloadStateDataParamsType) {
+// Pin an API key to a data date
+// (POST /admin/key-dates)
+func (_ Unimplemented) SetKeyDate(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Health check
-// (GET /health)
-func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request) {
+// Maintenance simulation status
+// (GET /admin/maintenance)
+func (_ Unimplemented) GetMaintenance(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Hot reload data for a different date
-// (POST /reload-date)
-func (_ Unimplemented) ReloadDate(w http.ResponseWriter, r *http.Request) {
+// Start or end simulated maintenance
+// (POST /admin/maintenance)
+func (_ Unimplemented) SetMaintenance(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Reset playback positions
-// (POST /reset-cache)
-func (_ Unimplemented) ResetCache(w http.ResponseWriter, r *http.Request, params ResetCacheParams) {
+// Data preflight report
+// (GET /admin/preflight)
+func (_ Unimplemented) GetPreflightReport(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List available tickers
-// (GET /tickers)
-func (_ Unimplemented) GetTickers(w http.ResponseWriter, r *http.Request) {
+// Hot reload data for a date, authenticated
+// (POST /admin/reload)
+func (_ Unimplemented) AdminReload(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get GEX chain data
-// (GET /{ticker}/classic/{aggregation})
-func (_ Unimplemented) GetClassicGexChain(w http.ResponseWriter, r *http.Request, ticker string, aggregation GetClassicGexChainParamsAggregation, params GetClassicGexChainParams) {
+// Seek playback positions to a timestamp
+// (POST /admin/seek)
+func (_ Unimplemented) SeekCache(w http.ResponseWriter, r *http.Request, params SeekCacheParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get GEX major levels
-// (GET /{ticker}/classic/{aggregation}/majors)
-func (_ Unimplemented) GetClassicGexMajors(w http.ResponseWriter, r *http.Request, ticker string, aggregation GetClassicGexMajorsParamsAggregation, params GetClassicGexMajorsParams) {
+// Return an API key to the global stream interval
+// (DELETE /admin/ws-intervals)
+func (_ Unimplemented) DeleteKeyInterval(w http.ResponseWriter, r *http.Request, params DeleteKeyIntervalParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List per-key WebSocket stream intervals
+// (GET /admin/ws-intervals)
+func (_ Unimplemented) GetKeyIntervals(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set an API key's WebSocket stream interval
+// (POST /admin/ws-intervals)
+func (_ Unimplemented) SetKeyInterval(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get available data for a date
+// (GET /available-data/{date})
+func (_ Unimplemented) GetAvailableData(w http.ResponseWriter, r *http.Request, date string, params GetAvailableDataParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List available dates
+// (GET /available-dates)
+func (_ Unimplemented) GetAvailableDates(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get current date
+// (GET /current-date)
+func (_ Unimplemented) GetCurrentDate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download classic GEX dataset
+// (GET /download/{date}/{ticker}/classic/{aggregation})
+func (_ Unimplemented) DownloadClassicGex(w http.ResponseWriter, r *http.Request, date string, ticker string, aggregation DownloadClassicGexParamsAggregation) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get available download links for a date/ticker
+// (GET /download/{date}/{ticker}/links)
+func (_ Unimplemented) GetDownloadLinks(w http.ResponseWriter, r *http.Request, date string, ticker string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download orderflow dataset
+// (GET /download/{date}/{ticker}/orderflow)
+func (_ Unimplemented) DownloadOrderflow(w http.ResponseWriter, r *http.Request, date string, ticker string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download state dataset
+// (GET /download/{date}/{ticker}/state/{type})
+func (_ Unimplemented) DownloadStateData(w http.ResponseWriter, r *http.Request, date string, ticker string, pType DownloadStateDataParamsType) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download volatility dataset
+// (GET /download/{date}/{ticker}/volatility/{category})
+func (_ Unimplemented) DownloadVolatility(w http.ResponseWriter, r *http.Request, date string, ticker string, category DownloadVolatilityParamsCategory) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Health check
+// (GET /health)
+func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request, params GetHealthParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get past orderflow records
+// (GET /orderflow/{ticker}/history)
+func (_ Unimplemented) GetOrderflowHistory(w http.ResponseWriter, r *http.Request, ticker string, params GetOrderflowHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Hot reload data for a different date
+// (POST /reload-date)
+func (_ Unimplemented) ReloadDate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset playback positions
+// (POST /reset-cache)
+func (_ Unimplemented) ResetCache(w http.ResponseWriter, r *http.Request, params ResetCacheParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List available tickers
+// (GET /tickers)
+func (_ Unimplemented) GetTickers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List loaded packages and categories per ticker
+// (GET /tickers/detail)
+func (_ Unimplemented) GetTickersDetail(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get GEX chain data
+// (GET /{ticker}/classic/{aggregation})
+func (_ Unimplemented) GetClassicGexChain(w http.ResponseWriter, r *http.Request, ticker string, aggregation GetClassicGexChainParamsAggregation, params GetClassicGexChainParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get GEX major levels
+// (GET /{ticker}/classic/{aggregation}/majors)
+func (_ Unimplemented) GetClassicGexMajors(w http.ResponseWriter, r *http.Request, ticker string, aggregation GetClassicGexMajorsParamsAggregation, params GetClassicGexMajorsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -621,6 +1411,12 @@ func (_ Unimplemented) GetStateGexMaxChange(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get implied volatility surface
+// (GET /{ticker}/volatility/{category})
+func (_ Unimplemented) GetVolatility(w http.ResponseWriter, r *http.Request, ticker string, category GetVolatilityParamsCategory, params GetVolatilityParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -630,33 +1426,32 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// GetAvailableData operation middleware
-func (siw *ServerInterfaceWrapper) GetAvailableData(w http.ResponseWriter, r *http.Request) {
+// GetAuditLog operation middleware
+func (siw *ServerInterfaceWrapper) GetAuditLog(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "date" -------------
-	var date string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuditLogParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "api_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "api_key", r.URL.Query(), &params.ApiKey)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "api_key", Err: err})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAvailableDataParams
-
-	// ------------- Optional query parameter "ticker" -------------
+	// ------------- Optional query parameter "limit" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "ticker", r.URL.Query(), &params.Ticker)
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAvailableData(w, r, date, params)
+		siw.Handler.GetAuditLog(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -666,68 +1461,56 @@ func (siw *ServerInterfaceWrapper) GetAvailableData(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// GetAvailableDates operation middleware
-func (siw *ServerInterfaceWrapper) GetAvailableDates(w http.ResponseWriter, r *http.Request) {
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAvailableDates(w, r)
-	}))
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// GetCachePositions operation middleware
+func (siw *ServerInterfaceWrapper) GetCachePositions(w http.ResponseWriter, r *http.Request) {
 
-	handler.ServeHTTP(w, r)
-}
+	var err error
 
-// GetCurrentDate operation middleware
-func (siw *ServerInterfaceWrapper) GetCurrentDate(w http.ResponseWriter, r *http.Request) {
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCachePositionsParams
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCurrentDate(w, r)
-	}))
+	// ------------- Optional query parameter "key" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
-
-// DownloadClassicGex operation middleware
-func (siw *ServerInterfaceWrapper) DownloadClassicGex(w http.ResponseWriter, r *http.Request) {
+	// ------------- Optional query parameter "ticker" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "ticker", r.URL.Query(), &params.Ticker)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
 
-	// ------------- Path parameter "date" -------------
-	var date string
+	// ------------- Optional query parameter "package" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "package", r.URL.Query(), &params.Package)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "package", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
+	// ------------- Optional query parameter "category" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "aggregation" -------------
-	var aggregation DownloadClassicGexParamsAggregation
+	// ------------- Optional query parameter "prefix" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "aggregation", chi.URLParam(r, "aggregation"), &aggregation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "prefix", r.URL.Query(), &params.Prefix)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "aggregation", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prefix", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DownloadClassicGex(w, r, date, ticker, aggregation)
+		siw.Handler.GetCachePositions(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -737,31 +1520,25 @@ func (siw *ServerInterfaceWrapper) DownloadClassicGex(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// GetDownloadLinks operation middleware
-func (siw *ServerInterfaceWrapper) GetDownloadLinks(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// BulkCacheOperation operation middleware
+func (siw *ServerInterfaceWrapper) BulkCacheOperation(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Path parameter "date" -------------
-	var date string
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BulkCacheOperation(w, r)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
-		return
-	}
+// SetCachePosition operation middleware
+func (siw *ServerInterfaceWrapper) SetCachePosition(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDownloadLinks(w, r, date, ticker)
+		siw.Handler.SetCachePosition(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -771,31 +1548,31 @@ func (siw *ServerInterfaceWrapper) GetDownloadLinks(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// DownloadOrderflow operation middleware
-func (siw *ServerInterfaceWrapper) DownloadOrderflow(w http.ResponseWriter, r *http.Request) {
+// DeleteKeyDate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteKeyDate(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "date" -------------
-	var date string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteKeyDateParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
 		return
 	}
 
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DownloadOrderflow(w, r, date, ticker)
+		siw.Handler.DeleteKeyDate(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -805,40 +1582,39 @@ func (siw *ServerInterfaceWrapper) DownloadOrderflow(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// DownloadStateData operation middleware
-func (siw *ServerInterfaceWrapper) DownloadStateData(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// GetKeyDates operation middleware
+func (siw *ServerInterfaceWrapper) GetKeyDates(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Path parameter "date" -------------
-	var date string
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetKeyDates(w, r)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
-		return
-	}
+// SetKeyDate operation middleware
+func (siw *ServerInterfaceWrapper) SetKeyDate(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Path parameter "type" -------------
-	var pType DownloadStateDataParamsType
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetKeyDate(w, r)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "type", chi.URLParam(r, "type"), &pType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// GetMaintenance operation middleware
+func (siw *ServerInterfaceWrapper) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DownloadStateData(w, r, date, ticker, pType)
+		siw.Handler.GetMaintenance(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -848,11 +1624,11 @@ func (siw *ServerInterfaceWrapper) DownloadStateData(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+// SetMaintenance operation middleware
+func (siw *ServerInterfaceWrapper) SetMaintenance(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetHealth(w, r)
+		siw.Handler.SetMaintenance(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -862,11 +1638,11 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// ReloadDate operation middleware
-func (siw *ServerInterfaceWrapper) ReloadDate(w http.ResponseWriter, r *http.Request) {
+// GetPreflightReport operation middleware
+func (siw *ServerInterfaceWrapper) GetPreflightReport(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReloadDate(w, r)
+		siw.Handler.GetPreflightReport(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -876,25 +1652,12 @@ func (siw *ServerInterfaceWrapper) ReloadDate(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ResetCache operation middleware
-func (siw *ServerInterfaceWrapper) ResetCache(w http.ResponseWriter, r *http.Request) {
+// AdminReload operation middleware
+func (siw *ServerInterfaceWrapper) AdminReload(w http.ResponseWriter, r *http.Request) {
 
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ResetCacheParams
-
-	// ------------- Optional query parameter "key" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "key", r.URL.Query(), &params.Key)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
-		return
-	}
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ResetCache(w, r, params)
-	}))
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminReload(w, r)
+	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
@@ -903,63 +1666,63 @@ func (siw *ServerInterfaceWrapper) ResetCache(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetTickers operation middleware
-func (siw *ServerInterfaceWrapper) GetTickers(w http.ResponseWriter, r *http.Request) {
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTickers(w, r)
-	}))
+// SeekCache operation middleware
+func (siw *ServerInterfaceWrapper) SeekCache(w http.ResponseWriter, r *http.Request) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	var err error
 
-	handler.ServeHTTP(w, r)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SeekCacheParams
 
-// GetClassicGexChain operation middleware
-func (siw *ServerInterfaceWrapper) GetClassicGexChain(w http.ResponseWriter, r *http.Request) {
+	// ------------- Required query parameter "ts" -------------
 
-	var err error
+	if paramValue := r.URL.Query().Get("ts"); paramValue != "" {
 
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "ts"})
+		return
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, true, "ts", r.URL.Query(), &params.Ts)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ts", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "aggregation" -------------
-	var aggregation GetClassicGexChainParamsAggregation
+	// ------------- Optional query parameter "ticker" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "aggregation", chi.URLParam(r, "aggregation"), &aggregation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "ticker", r.URL.Query(), &params.Ticker)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "aggregation", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetClassicGexChainParams
+	// ------------- Optional query parameter "key" -------------
 
-	// ------------- Required query parameter "key" -------------
+	err = runtime.BindQueryParameter("form", true, false, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
 
-	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+	// ------------- Optional query parameter "package" -------------
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+	err = runtime.BindQueryParameter("form", true, false, "package", r.URL.Query(), &params.Package)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "package", Err: err})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetClassicGexChain(w, r, ticker, aggregation, params)
+		siw.Handler.SeekCache(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -969,31 +1732,13 @@ func (siw *ServerInterfaceWrapper) GetClassicGexChain(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// GetClassicGexMajors operation middleware
-func (siw *ServerInterfaceWrapper) GetClassicGexMajors(w http.ResponseWriter, r *http.Request) {
+// DeleteKeyInterval operation middleware
+func (siw *ServerInterfaceWrapper) DeleteKeyInterval(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
-		return
-	}
-
-	// ------------- Path parameter "aggregation" -------------
-	var aggregation GetClassicGexMajorsParamsAggregation
-
-	err = runtime.BindStyledParameterWithOptions("simple", "aggregation", chi.URLParam(r, "aggregation"), &aggregation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "aggregation", Err: err})
-		return
-	}
-
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetClassicGexMajorsParams
+	var params DeleteKeyIntervalParams
 
 	// ------------- Required query parameter "key" -------------
 
@@ -1011,7 +1756,7 @@ func (siw *ServerInterfaceWrapper) GetClassicGexMajors(w http.ResponseWriter, r
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetClassicGexMajors(w, r, ticker, aggregation, params)
+		siw.Handler.DeleteKeyInterval(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1021,49 +1766,25 @@ func (siw *ServerInterfaceWrapper) GetClassicGexMajors(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// GetClassicGexMaxChange operation middleware
-func (siw *ServerInterfaceWrapper) GetClassicGexMaxChange(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
-		return
-	}
+// GetKeyIntervals operation middleware
+func (siw *ServerInterfaceWrapper) GetKeyIntervals(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Path parameter "aggregation" -------------
-	var aggregation GetClassicGexMaxChangeParamsAggregation
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetKeyIntervals(w, r)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "aggregation", chi.URLParam(r, "aggregation"), &aggregation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "aggregation", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetClassicGexMaxChangeParams
-
-	// ------------- Required query parameter "key" -------------
-
-	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
-		return
-	}
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
-		return
-	}
+// SetKeyInterval operation middleware
+func (siw *ServerInterfaceWrapper) SetKeyInterval(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetClassicGexMaxChange(w, r, ticker, aggregation, params)
+		siw.Handler.SetKeyInterval(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1073,40 +1794,33 @@ func (siw *ServerInterfaceWrapper) GetClassicGexMaxChange(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetOrderflowLatest operation middleware
-func (siw *ServerInterfaceWrapper) GetOrderflowLatest(w http.ResponseWriter, r *http.Request) {
+// GetAvailableData operation middleware
+func (siw *ServerInterfaceWrapper) GetAvailableData(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
+	// ------------- Path parameter "date" -------------
+	var date string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
 		return
 	}
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetOrderflowLatestParams
-
-	// ------------- Required query parameter "key" -------------
-
-	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+	var params GetAvailableDataParams
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
-		return
-	}
+	// ------------- Optional query parameter "ticker" -------------
 
-	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	err = runtime.BindQueryParameter("form", true, false, "ticker", r.URL.Query(), &params.Ticker)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetOrderflowLatest(w, r, ticker, params)
+		siw.Handler.GetAvailableData(w, r, date, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1116,49 +1830,25 @@ func (siw *ServerInterfaceWrapper) GetOrderflowLatest(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// GetStateProfile operation middleware
-func (siw *ServerInterfaceWrapper) GetStateProfile(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
-		return
-	}
+// GetAvailableDates operation middleware
+func (siw *ServerInterfaceWrapper) GetAvailableDates(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Path parameter "type" -------------
-	var pType GetStateProfileParamsType
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAvailableDates(w, r)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "type", chi.URLParam(r, "type"), &pType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetStateProfileParams
-
-	// ------------- Required query parameter "key" -------------
-
-	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
-		return
-	}
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
-		return
-	}
+// GetCurrentDate operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentDate(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStateProfile(w, r, ticker, pType, params)
+		siw.Handler.GetCurrentDate(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1168,49 +1858,40 @@ func (siw *ServerInterfaceWrapper) GetStateProfile(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// GetStateGexMajors operation middleware
-func (siw *ServerInterfaceWrapper) GetStateGexMajors(w http.ResponseWriter, r *http.Request) {
+// DownloadClassicGex operation middleware
+func (siw *ServerInterfaceWrapper) DownloadClassicGex(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "ticker" -------------
-	var ticker string
+	// ------------- Path parameter "date" -------------
+	var date string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "type" -------------
-	var pType GetStateGexMajorsParamsType
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "type", chi.URLParam(r, "type"), &pType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetStateGexMajorsParams
-
-	// ------------- Required query parameter "key" -------------
-
-	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
-		return
-	}
+	// ------------- Path parameter "aggregation" -------------
+	var aggregation DownloadClassicGexParamsAggregation
 
-	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	err = runtime.BindStyledParameterWithOptions("simple", "aggregation", chi.URLParam(r, "aggregation"), &aggregation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "aggregation", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStateGexMajors(w, r, ticker, pType, params)
+		siw.Handler.DownloadClassicGex(w, r, date, ticker, aggregation)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1220,11 +1901,20 @@ func (siw *ServerInterfaceWrapper) GetStateGexMajors(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetStateGexMaxChange operation middleware
-func (siw *ServerInterfaceWrapper) GetStateGexMaxChange(w http.ResponseWriter, r *http.Request) {
+// GetDownloadLinks operation middleware
+func (siw *ServerInterfaceWrapper) GetDownloadLinks(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "date" -------------
+	var date string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
 	// ------------- Path parameter "ticker" -------------
 	var ticker string
 
@@ -1234,8 +1924,76 @@ func (siw *ServerInterfaceWrapper) GetStateGexMaxChange(w http.ResponseWriter, r
 		return
 	}
 
-	// ------------- Path parameter "type" -------------
-	var pType GetStateGexMaxChangeParamsType
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDownloadLinks(w, r, date, ticker)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DownloadOrderflow operation middleware
+func (siw *ServerInterfaceWrapper) DownloadOrderflow(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "date" -------------
+	var date string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DownloadOrderflow(w, r, date, ticker)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DownloadStateData operation middleware
+func (siw *ServerInterfaceWrapper) DownloadStateData(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "date" -------------
+	var date string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "type" -------------
+	var pType DownloadStateDataParamsType
 
 	err = runtime.BindStyledParameterWithOptions("simple", "type", chi.URLParam(r, "type"), &pType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -1243,8 +2001,103 @@ func (siw *ServerInterfaceWrapper) GetStateGexMaxChange(w http.ResponseWriter, r
 		return
 	}
 
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DownloadStateData(w, r, date, ticker, pType)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DownloadVolatility operation middleware
+func (siw *ServerInterfaceWrapper) DownloadVolatility(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "date" -------------
+	var date string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", chi.URLParam(r, "date"), &date, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "category" -------------
+	var category DownloadVolatilityParamsCategory
+
+	err = runtime.BindStyledParameterWithOptions("simple", "category", chi.URLParam(r, "category"), &category, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DownloadVolatility(w, r, date, ticker, category)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetStateGexMaxChangeParams
+	var params GetHealthParams
+
+	// ------------- Optional query parameter "key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOrderflowHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetOrderflowHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOrderflowHistoryParams
 
 	// ------------- Required query parameter "key" -------------
 
@@ -1261,8 +2114,40 @@ func (siw *ServerInterfaceWrapper) GetStateGexMaxChange(w http.ResponseWriter, r
 		return
 	}
 
+	// ------------- Optional query parameter "minutes" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minutes", r.URL.Query(), &params.Minutes)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minutes", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStateGexMaxChange(w, r, ticker, pType, params)
+		siw.Handler.GetOrderflowHistory(w, r, ticker, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1272,859 +2157,2544 @@ func (siw *ServerInterfaceWrapper) GetStateGexMaxChange(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// ReloadDate operation middleware
+func (siw *ServerInterfaceWrapper) ReloadDate(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReloadDate(w, r)
+	}))
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
+	handler.ServeHTTP(w, r)
 }
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+// ResetCache operation middleware
+func (siw *ServerInterfaceWrapper) ResetCache(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	var err error
 
-type RequiredParamError struct {
-	ParamName string
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ResetCacheParams
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	// ------------- Optional query parameter "key" -------------
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindQueryParameter("form", true, false, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	// ------------- Optional query parameter "ticker" -------------
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindQueryParameter("form", true, false, "ticker", r.URL.Query(), &params.Ticker)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Optional query parameter "package" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "package", r.URL.Query(), &params.Package)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "package", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", r.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prefix", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetCache(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTickers operation middleware
+func (siw *ServerInterfaceWrapper) GetTickers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTickers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTickersDetail operation middleware
+func (siw *ServerInterfaceWrapper) GetTickersDetail(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTickersDetail(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetClassicGexChain operation middleware
+func (siw *ServerInterfaceWrapper) GetClassicGexChain(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "aggregation" -------------
+	var aggregation GetClassicGexChainParamsAggregation
+
+	err = runtime.BindStyledParameterWithOptions("simple", "aggregation", chi.URLParam(r, "aggregation"), &aggregation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "aggregation", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClassicGexChainParams
+
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClassicGexChain(w, r, ticker, aggregation, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetClassicGexMajors operation middleware
+func (siw *ServerInterfaceWrapper) GetClassicGexMajors(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "aggregation" -------------
+	var aggregation GetClassicGexMajorsParamsAggregation
+
+	err = runtime.BindStyledParameterWithOptions("simple", "aggregation", chi.URLParam(r, "aggregation"), &aggregation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "aggregation", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClassicGexMajorsParams
+
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClassicGexMajors(w, r, ticker, aggregation, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetClassicGexMaxChange operation middleware
+func (siw *ServerInterfaceWrapper) GetClassicGexMaxChange(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "aggregation" -------------
+	var aggregation GetClassicGexMaxChangeParamsAggregation
+
+	err = runtime.BindStyledParameterWithOptions("simple", "aggregation", chi.URLParam(r, "aggregation"), &aggregation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "aggregation", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClassicGexMaxChangeParams
+
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClassicGexMaxChange(w, r, ticker, aggregation, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOrderflowLatest operation middleware
+func (siw *ServerInterfaceWrapper) GetOrderflowLatest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOrderflowLatestParams
+
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOrderflowLatest(w, r, ticker, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStateProfile operation middleware
+func (siw *ServerInterfaceWrapper) GetStateProfile(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "type" -------------
+	var pType GetStateProfileParamsType
+
+	err = runtime.BindStyledParameterWithOptions("simple", "type", chi.URLParam(r, "type"), &pType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStateProfileParams
+
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStateProfile(w, r, ticker, pType, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStateGexMajors operation middleware
+func (siw *ServerInterfaceWrapper) GetStateGexMajors(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "type" -------------
+	var pType GetStateGexMajorsParamsType
+
+	err = runtime.BindStyledParameterWithOptions("simple", "type", chi.URLParam(r, "type"), &pType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStateGexMajorsParams
+
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStateGexMajors(w, r, ticker, pType, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStateGexMaxChange operation middleware
+func (siw *ServerInterfaceWrapper) GetStateGexMaxChange(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "type" -------------
+	var pType GetStateGexMaxChangeParamsType
+
+	err = runtime.BindStyledParameterWithOptions("simple", "type", chi.URLParam(r, "type"), &pType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStateGexMaxChangeParams
+
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStateGexMaxChange(w, r, ticker, pType, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetVolatility operation middleware
+func (siw *ServerInterfaceWrapper) GetVolatility(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "ticker" -------------
+	var ticker string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ticker", chi.URLParam(r, "ticker"), &ticker, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ticker", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "category" -------------
+	var category GetVolatilityParamsCategory
+
+	err = runtime.BindStyledParameterWithOptions("simple", "category", chi.URLParam(r, "category"), &category, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetVolatilityParams
+
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVolatility(w, r, ticker, category, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/audit", wrapper.GetAuditLog)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/cache", wrapper.GetCachePositions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/cache/bulk", wrapper.BulkCacheOperation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/cache/position", wrapper.SetCachePosition)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/key-dates", wrapper.DeleteKeyDate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/key-dates", wrapper.GetKeyDates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/key-dates", wrapper.SetKeyDate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/maintenance", wrapper.GetMaintenance)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/maintenance", wrapper.SetMaintenance)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/preflight", wrapper.GetPreflightReport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/reload", wrapper.AdminReload)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/seek", wrapper.SeekCache)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/ws-intervals", wrapper.DeleteKeyInterval)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/ws-intervals", wrapper.GetKeyIntervals)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/ws-intervals", wrapper.SetKeyInterval)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/available-data/{date}", wrapper.GetAvailableData)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/available-dates", wrapper.GetAvailableDates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/current-date", wrapper.GetCurrentDate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/download/{date}/{ticker}/classic/{aggregation}", wrapper.DownloadClassicGex)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/download/{date}/{ticker}/links", wrapper.GetDownloadLinks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/download/{date}/{ticker}/orderflow", wrapper.DownloadOrderflow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/download/{date}/{ticker}/state/{type}", wrapper.DownloadStateData)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/download/{date}/{ticker}/volatility/{category}", wrapper.DownloadVolatility)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/health", wrapper.GetHealth)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orderflow/{ticker}/history", wrapper.GetOrderflowHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/reload-date", wrapper.ReloadDate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/reset-cache", wrapper.ResetCache)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tickers", wrapper.GetTickers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tickers/detail", wrapper.GetTickersDetail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{ticker}/classic/{aggregation}", wrapper.GetClassicGexChain)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{ticker}/classic/{aggregation}/majors", wrapper.GetClassicGexMajors)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{ticker}/classic/{aggregation}/maxchange", wrapper.GetClassicGexMaxChange)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{ticker}/orderflow/orderflow", wrapper.GetOrderflowLatest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{ticker}/state/{type}", wrapper.GetStateProfile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{ticker}/state/{type}/majors", wrapper.GetStateGexMajors)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{ticker}/state/{type}/maxchange", wrapper.GetStateGexMaxChange)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{ticker}/volatility/{category}", wrapper.GetVolatility)
+	})
+
+	return r
+}
+
+type GetAuditLogRequestObject struct {
+	Params GetAuditLogParams
+}
+
+type GetAuditLogResponseObject interface {
+	VisitGetAuditLogResponse(w http.ResponseWriter) error
+}
+
+type GetAuditLog200JSONResponse AuditLogResponse
+
+func (response GetAuditLog200JSONResponse) VisitGetAuditLogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuditLog404JSONResponse ErrorResponse
+
+func (response GetAuditLog404JSONResponse) VisitGetAuditLogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCachePositionsRequestObject struct {
+	Params GetCachePositionsParams
+}
+
+type GetCachePositionsResponseObject interface {
+	VisitGetCachePositionsResponse(w http.ResponseWriter) error
+}
+
+type GetCachePositions200JSONResponse CachePositionsResponse
+
+func (response GetCachePositions200JSONResponse) VisitGetCachePositionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkCacheOperationRequestObject struct {
+	Body *BulkCacheOperationJSONRequestBody
+}
+
+type BulkCacheOperationResponseObject interface {
+	VisitBulkCacheOperationResponse(w http.ResponseWriter) error
+}
+
+type BulkCacheOperation200JSONResponse CacheBulkResponse
+
+func (response BulkCacheOperation200JSONResponse) VisitBulkCacheOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BulkCacheOperation400JSONResponse ErrorResponse
+
+func (response BulkCacheOperation400JSONResponse) VisitBulkCacheOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetCachePositionRequestObject struct {
+	Body *SetCachePositionJSONRequestBody
+}
+
+type SetCachePositionResponseObject interface {
+	VisitSetCachePositionResponse(w http.ResponseWriter) error
+}
+
+type SetCachePosition200JSONResponse CacheBulkResponse
+
+func (response SetCachePosition200JSONResponse) VisitSetCachePositionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetCachePosition400JSONResponse ErrorResponse
+
+func (response SetCachePosition400JSONResponse) VisitSetCachePositionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetCachePosition404JSONResponse ErrorResponse
+
+func (response SetCachePosition404JSONResponse) VisitSetCachePositionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteKeyDateRequestObject struct {
+	Params DeleteKeyDateParams
+}
+
+type DeleteKeyDateResponseObject interface {
+	VisitDeleteKeyDateResponse(w http.ResponseWriter) error
+}
+
+type DeleteKeyDate200JSONResponse KeyDatesResponse
+
+func (response DeleteKeyDate200JSONResponse) VisitDeleteKeyDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteKeyDate404JSONResponse ErrorResponse
+
+func (response DeleteKeyDate404JSONResponse) VisitDeleteKeyDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetKeyDatesRequestObject struct {
+}
+
+type GetKeyDatesResponseObject interface {
+	VisitGetKeyDatesResponse(w http.ResponseWriter) error
+}
+
+type GetKeyDates200JSONResponse KeyDatesResponse
+
+func (response GetKeyDates200JSONResponse) VisitGetKeyDatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetKeyDateRequestObject struct {
+	Body *SetKeyDateJSONRequestBody
+}
+
+type SetKeyDateResponseObject interface {
+	VisitSetKeyDateResponse(w http.ResponseWriter) error
+}
+
+type SetKeyDate200JSONResponse KeyDatesResponse
+
+func (response SetKeyDate200JSONResponse) VisitSetKeyDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetKeyDate400JSONResponse ErrorResponse
+
+func (response SetKeyDate400JSONResponse) VisitSetKeyDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMaintenanceRequestObject struct {
+}
+
+type GetMaintenanceResponseObject interface {
+	VisitGetMaintenanceResponse(w http.ResponseWriter) error
+}
+
+type GetMaintenance200JSONResponse MaintenanceStatus
+
+func (response GetMaintenance200JSONResponse) VisitGetMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMaintenanceRequestObject struct {
+	Body *SetMaintenanceJSONRequestBody
+}
+
+type SetMaintenanceResponseObject interface {
+	VisitSetMaintenanceResponse(w http.ResponseWriter) error
+}
+
+type SetMaintenance200JSONResponse MaintenanceStatus
+
+func (response SetMaintenance200JSONResponse) VisitSetMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMaintenance400JSONResponse ErrorResponse
+
+func (response SetMaintenance400JSONResponse) VisitSetMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPreflightReportRequestObject struct {
+}
+
+type GetPreflightReportResponseObject interface {
+	VisitGetPreflightReportResponse(w http.ResponseWriter) error
+}
+
+type GetPreflightReport200JSONResponse PreflightReport
+
+func (response GetPreflightReport200JSONResponse) VisitGetPreflightReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdminReloadRequestObject struct {
+	Body *AdminReloadJSONRequestBody
+}
+
+type AdminReloadResponseObject interface {
+	VisitAdminReloadResponse(w http.ResponseWriter) error
+}
+
+type AdminReload200JSONResponse ReloadDateResponse
+
+func (response AdminReload200JSONResponse) VisitAdminReloadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdminReload400JSONResponse ErrorResponse
+
+func (response AdminReload400JSONResponse) VisitAdminReloadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdminReload401JSONResponse ErrorResponse
+
+func (response AdminReload401JSONResponse) VisitAdminReloadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdminReload403JSONResponse ErrorResponse
+
+func (response AdminReload403JSONResponse) VisitAdminReloadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdminReload409JSONResponse ErrorResponse
+
+func (response AdminReload409JSONResponse) VisitAdminReloadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdminReload500JSONResponse ErrorResponse
+
+func (response AdminReload500JSONResponse) VisitAdminReloadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SeekCacheRequestObject struct {
+	Params SeekCacheParams
+}
+
+type SeekCacheResponseObject interface {
+	VisitSeekCacheResponse(w http.ResponseWriter) error
+}
+
+type SeekCache200JSONResponse CacheBulkResponse
+
+func (response SeekCache200JSONResponse) VisitSeekCacheResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SeekCache400JSONResponse ErrorResponse
+
+func (response SeekCache400JSONResponse) VisitSeekCacheResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SeekCache404JSONResponse ErrorResponse
+
+func (response SeekCache404JSONResponse) VisitSeekCacheResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteKeyIntervalRequestObject struct {
+	Params DeleteKeyIntervalParams
+}
+
+type DeleteKeyIntervalResponseObject interface {
+	VisitDeleteKeyIntervalResponse(w http.ResponseWriter) error
+}
+
+type DeleteKeyInterval200JSONResponse KeyIntervalsResponse
+
+func (response DeleteKeyInterval200JSONResponse) VisitDeleteKeyIntervalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteKeyInterval404JSONResponse ErrorResponse
+
+func (response DeleteKeyInterval404JSONResponse) VisitDeleteKeyIntervalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetKeyIntervalsRequestObject struct {
+}
+
+type GetKeyIntervalsResponseObject interface {
+	VisitGetKeyIntervalsResponse(w http.ResponseWriter) error
+}
+
+type GetKeyIntervals200JSONResponse KeyIntervalsResponse
+
+func (response GetKeyIntervals200JSONResponse) VisitGetKeyIntervalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetKeyIntervalRequestObject struct {
+	Body *SetKeyIntervalJSONRequestBody
+}
+
+type SetKeyIntervalResponseObject interface {
+	VisitSetKeyIntervalResponse(w http.ResponseWriter) error
+}
+
+type SetKeyInterval200JSONResponse KeyIntervalsResponse
+
+func (response SetKeyInterval200JSONResponse) VisitSetKeyIntervalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetKeyInterval400JSONResponse ErrorResponse
+
+func (response SetKeyInterval400JSONResponse) VisitSetKeyIntervalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAvailableDataRequestObject struct {
+	Date   string `json:"date"`
+	Params GetAvailableDataParams
+}
+
+type GetAvailableDataResponseObject interface {
+	VisitGetAvailableDataResponse(w http.ResponseWriter) error
+}
+
+type GetAvailableData200JSONResponse AvailableDataResponse
+
+func (response GetAvailableData200JSONResponse) VisitGetAvailableDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAvailableDatesRequestObject struct {
+}
+
+type GetAvailableDatesResponseObject interface {
+	VisitGetAvailableDatesResponse(w http.ResponseWriter) error
+}
+
+type GetAvailableDates200JSONResponse AvailableDatesResponse
+
+func (response GetAvailableDates200JSONResponse) VisitGetAvailableDatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCurrentDateRequestObject struct {
+}
+
+type GetCurrentDateResponseObject interface {
+	VisitGetCurrentDateResponse(w http.ResponseWriter) error
+}
+
+type GetCurrentDate200JSONResponse CurrentDateResponse
+
+func (response GetCurrentDate200JSONResponse) VisitGetCurrentDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DownloadClassicGexRequestObject struct {
+	Date        string                              `json:"date"`
+	Ticker      string                              `json:"ticker"`
+	Aggregation DownloadClassicGexParamsAggregation `json:"aggregation"`
+}
+
+type DownloadClassicGexResponseObject interface {
+	VisitDownloadClassicGexResponse(w http.ResponseWriter) error
+}
+
+type DownloadClassicGex200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response DownloadClassicGex200ApplicationxNdjsonResponse) VisitDownloadClassicGexResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type DownloadClassicGex404JSONResponse ErrorResponse
+
+func (response DownloadClassicGex404JSONResponse) VisitDownloadClassicGexResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDownloadLinksRequestObject struct {
+	Date   string `json:"date"`
+	Ticker string `json:"ticker"`
+}
+
+type GetDownloadLinksResponseObject interface {
+	VisitGetDownloadLinksResponse(w http.ResponseWriter) error
+}
+
+type GetDownloadLinks200JSONResponse DownloadLinksResponse
+
+func (response GetDownloadLinks200JSONResponse) VisitGetDownloadLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDownloadLinks404JSONResponse ErrorResponse
+
+func (response GetDownloadLinks404JSONResponse) VisitGetDownloadLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DownloadOrderflowRequestObject struct {
+	Date   string `json:"date"`
+	Ticker string `json:"ticker"`
+}
+
+type DownloadOrderflowResponseObject interface {
+	VisitDownloadOrderflowResponse(w http.ResponseWriter) error
+}
+
+type DownloadOrderflow200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response DownloadOrderflow200ApplicationxNdjsonResponse) VisitDownloadOrderflowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type DownloadOrderflow404JSONResponse ErrorResponse
+
+func (response DownloadOrderflow404JSONResponse) VisitDownloadOrderflowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DownloadStateDataRequestObject struct {
+	Date   string                      `json:"date"`
+	Ticker string                      `json:"ticker"`
+	Type   DownloadStateDataParamsType `json:"type"`
+}
+
+type DownloadStateDataResponseObject interface {
+	VisitDownloadStateDataResponse(w http.ResponseWriter) error
+}
+
+type DownloadStateData200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response DownloadStateData200ApplicationxNdjsonResponse) VisitDownloadStateDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type DownloadStateData404JSONResponse ErrorResponse
+
+func (response DownloadStateData404JSONResponse) VisitDownloadStateDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DownloadVolatilityRequestObject struct {
+	Date     string                           `json:"date"`
+	Ticker   string                           `json:"ticker"`
+	Category DownloadVolatilityParamsCategory `json:"category"`
+}
+
+type DownloadVolatilityResponseObject interface {
+	VisitDownloadVolatilityResponse(w http.ResponseWriter) error
+}
+
+type DownloadVolatility200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response DownloadVolatility200ApplicationxNdjsonResponse) VisitDownloadVolatilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type DownloadVolatility404JSONResponse ErrorResponse
+
+func (response DownloadVolatility404JSONResponse) VisitDownloadVolatilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthRequestObject struct {
+	Params GetHealthParams
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse HealthResponse
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrderflowHistoryRequestObject struct {
+	Ticker string `json:"ticker"`
+	Params GetOrderflowHistoryParams
+}
+
+type GetOrderflowHistoryResponseObject interface {
+	VisitGetOrderflowHistoryResponse(w http.ResponseWriter) error
+}
+
+type GetOrderflowHistory200JSONResponse []OrderflowData
+
+func (response GetOrderflowHistory200JSONResponse) VisitGetOrderflowHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrderflowHistory400JSONResponse ErrorResponse
+
+func (response GetOrderflowHistory400JSONResponse) VisitGetOrderflowHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrderflowHistory404JSONResponse ErrorResponse
+
+func (response GetOrderflowHistory404JSONResponse) VisitGetOrderflowHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadDateRequestObject struct {
+	Body *ReloadDateJSONRequestBody
+}
+
+type ReloadDateResponseObject interface {
+	VisitReloadDateResponse(w http.ResponseWriter) error
+}
+
+type ReloadDate200JSONResponse ReloadDateResponse
+
+func (response ReloadDate200JSONResponse) VisitReloadDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadDate400JSONResponse ErrorResponse
+
+func (response ReloadDate400JSONResponse) VisitReloadDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadDate409JSONResponse ErrorResponse
+
+func (response ReloadDate409JSONResponse) VisitReloadDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadDate500JSONResponse ErrorResponse
+
+func (response ReloadDate500JSONResponse) VisitReloadDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetCacheRequestObject struct {
+	Params ResetCacheParams
+}
+
+type ResetCacheResponseObject interface {
+	VisitResetCacheResponse(w http.ResponseWriter) error
+}
+
+type ResetCache200JSONResponse ResetCacheResponse
+
+func (response ResetCache200JSONResponse) VisitResetCacheResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+type GetTickersRequestObject struct {
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+type GetTickersResponseObject interface {
+	VisitGetTickersResponse(w http.ResponseWriter) error
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+type GetTickers200JSONResponse TickersResponse
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+func (response GetTickers200JSONResponse) VisitGetTickersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+type GetTickersDetailRequestObject struct {
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+type GetTickersDetailResponseObject interface {
+	VisitGetTickersDetailResponse(w http.ResponseWriter) error
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
-
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+type GetTickersDetail200JSONResponse TickersDetailResponse
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/available-data/{date}", wrapper.GetAvailableData)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/available-dates", wrapper.GetAvailableDates)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/current-date", wrapper.GetCurrentDate)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/download/{date}/{ticker}/classic/{aggregation}", wrapper.DownloadClassicGex)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/download/{date}/{ticker}/links", wrapper.GetDownloadLinks)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/download/{date}/{ticker}/orderflow", wrapper.DownloadOrderflow)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/download/{date}/{ticker}/state/{type}", wrapper.DownloadStateData)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/health", wrapper.GetHealth)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/reload-date", wrapper.ReloadDate)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/reset-cache", wrapper.ResetCache)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/tickers", wrapper.GetTickers)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/{ticker}/classic/{aggregation}", wrapper.GetClassicGexChain)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/{ticker}/classic/{aggregation}/majors", wrapper.GetClassicGexMajors)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/{ticker}/classic/{aggregation}/maxchange", wrapper.GetClassicGexMaxChange)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/{ticker}/orderflow/orderflow", wrapper.GetOrderflowLatest)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/{ticker}/state/{type}", wrapper.GetStateProfile)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/{ticker}/state/{type}/majors", wrapper.GetStateGexMajors)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/{ticker}/state/{type}/maxchange", wrapper.GetStateGexMaxChange)
-	})
+func (response GetTickersDetail200JSONResponse) VisitGetTickersDetailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	return r
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAvailableDataRequestObject struct {
-	Date   string `json:"date"`
-	Params GetAvailableDataParams
+type GetClassicGexChainRequestObject struct {
+	Ticker      string                              `json:"ticker"`
+	Aggregation GetClassicGexChainParamsAggregation `json:"aggregation"`
+	Params      GetClassicGexChainParams
 }
 
-type GetAvailableDataResponseObject interface {
-	VisitGetAvailableDataResponse(w http.ResponseWriter) error
+type GetClassicGexChainResponseObject interface {
+	VisitGetClassicGexChainResponse(w http.ResponseWriter) error
 }
 
-type GetAvailableData200JSONResponse AvailableDataResponse
+type GetClassicGexChain200JSONResponse GexData
 
-func (response GetAvailableData200JSONResponse) VisitGetAvailableDataResponse(w http.ResponseWriter) error {
+func (response GetClassicGexChain200JSONResponse) VisitGetClassicGexChainResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAvailableDatesRequestObject struct {
+type GetClassicGexChain400JSONResponse ErrorResponse
+
+func (response GetClassicGexChain400JSONResponse) VisitGetClassicGexChainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAvailableDatesResponseObject interface {
-	VisitGetAvailableDatesResponse(w http.ResponseWriter) error
+type GetClassicGexChain401JSONResponse ErrorResponse
+
+func (response GetClassicGexChain401JSONResponse) VisitGetClassicGexChainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAvailableDates200JSONResponse AvailableDatesResponse
+type GetClassicGexChain404JSONResponse ErrorResponse
 
-func (response GetAvailableDates200JSONResponse) VisitGetAvailableDatesResponse(w http.ResponseWriter) error {
+func (response GetClassicGexChain404JSONResponse) VisitGetClassicGexChainResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCurrentDateRequestObject struct {
+type GetClassicGexMajorsRequestObject struct {
+	Ticker      string                               `json:"ticker"`
+	Aggregation GetClassicGexMajorsParamsAggregation `json:"aggregation"`
+	Params      GetClassicGexMajorsParams
 }
 
-type GetCurrentDateResponseObject interface {
-	VisitGetCurrentDateResponse(w http.ResponseWriter) error
+type GetClassicGexMajorsResponseObject interface {
+	VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error
 }
 
-type GetCurrentDate200JSONResponse CurrentDateResponse
+type GetClassicGexMajors200JSONResponse GexMajorsData
 
-func (response GetCurrentDate200JSONResponse) VisitGetCurrentDateResponse(w http.ResponseWriter) error {
+func (response GetClassicGexMajors200JSONResponse) VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DownloadClassicGexRequestObject struct {
-	Date        string                              `json:"date"`
-	Ticker      string                              `json:"ticker"`
-	Aggregation DownloadClassicGexParamsAggregation `json:"aggregation"`
-}
+type GetClassicGexMajors400JSONResponse ErrorResponse
 
-type DownloadClassicGexResponseObject interface {
-	VisitDownloadClassicGexResponse(w http.ResponseWriter) error
-}
+func (response GetClassicGexMajors400JSONResponse) VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-type DownloadClassicGex200ApplicationxNdjsonResponse struct {
-	Body          io.Reader
-	ContentLength int64
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response DownloadClassicGex200ApplicationxNdjsonResponse) VisitDownloadClassicGexResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/x-ndjson")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
-	}
-	w.WriteHeader(200)
+type GetClassicGexMajors401JSONResponse ErrorResponse
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
-	}
-	_, err := io.Copy(w, response.Body)
-	return err
+func (response GetClassicGexMajors401JSONResponse) VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DownloadClassicGex404JSONResponse ErrorResponse
+type GetClassicGexMajors404JSONResponse ErrorResponse
 
-func (response DownloadClassicGex404JSONResponse) VisitDownloadClassicGexResponse(w http.ResponseWriter) error {
+func (response GetClassicGexMajors404JSONResponse) VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDownloadLinksRequestObject struct {
-	Date   string `json:"date"`
-	Ticker string `json:"ticker"`
+type GetClassicGexMaxChangeRequestObject struct {
+	Ticker      string                                  `json:"ticker"`
+	Aggregation GetClassicGexMaxChangeParamsAggregation `json:"aggregation"`
+	Params      GetClassicGexMaxChangeParams
 }
 
-type GetDownloadLinksResponseObject interface {
-	VisitGetDownloadLinksResponse(w http.ResponseWriter) error
+type GetClassicGexMaxChangeResponseObject interface {
+	VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error
 }
 
-type GetDownloadLinks200JSONResponse DownloadLinksResponse
+type GetClassicGexMaxChange200JSONResponse GexMaxChangeData
 
-func (response GetDownloadLinks200JSONResponse) VisitGetDownloadLinksResponse(w http.ResponseWriter) error {
+func (response GetClassicGexMaxChange200JSONResponse) VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDownloadLinks404JSONResponse ErrorResponse
+type GetClassicGexMaxChange400JSONResponse ErrorResponse
 
-func (response GetDownloadLinks404JSONResponse) VisitGetDownloadLinksResponse(w http.ResponseWriter) error {
+func (response GetClassicGexMaxChange400JSONResponse) VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DownloadOrderflowRequestObject struct {
-	Date   string `json:"date"`
-	Ticker string `json:"ticker"`
-}
-
-type DownloadOrderflowResponseObject interface {
-	VisitDownloadOrderflowResponse(w http.ResponseWriter) error
-}
-
-type DownloadOrderflow200ApplicationxNdjsonResponse struct {
-	Body          io.Reader
-	ContentLength int64
-}
+type GetClassicGexMaxChange401JSONResponse ErrorResponse
 
-func (response DownloadOrderflow200ApplicationxNdjsonResponse) VisitDownloadOrderflowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/x-ndjson")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
-	}
-	w.WriteHeader(200)
+func (response GetClassicGexMaxChange401JSONResponse) VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
-	}
-	_, err := io.Copy(w, response.Body)
-	return err
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DownloadOrderflow404JSONResponse ErrorResponse
+type GetClassicGexMaxChange404JSONResponse ErrorResponse
 
-func (response DownloadOrderflow404JSONResponse) VisitDownloadOrderflowResponse(w http.ResponseWriter) error {
+func (response GetClassicGexMaxChange404JSONResponse) VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DownloadStateDataRequestObject struct {
-	Date   string                      `json:"date"`
-	Ticker string                      `json:"ticker"`
-	Type   DownloadStateDataParamsType `json:"type"`
+type GetOrderflowLatestRequestObject struct {
+	Ticker string `json:"ticker"`
+	Params GetOrderflowLatestParams
 }
 
-type DownloadStateDataResponseObject interface {
-	VisitDownloadStateDataResponse(w http.ResponseWriter) error
+type GetOrderflowLatestResponseObject interface {
+	VisitGetOrderflowLatestResponse(w http.ResponseWriter) error
 }
 
-type DownloadStateData200ApplicationxNdjsonResponse struct {
-	Body          io.Reader
-	ContentLength int64
-}
+type GetOrderflowLatest200JSONResponse OrderflowData
 
-func (response DownloadStateData200ApplicationxNdjsonResponse) VisitDownloadStateDataResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/x-ndjson")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
-	}
+func (response GetOrderflowLatest200JSONResponse) VisitGetOrderflowLatestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
-	}
-	_, err := io.Copy(w, response.Body)
-	return err
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DownloadStateData404JSONResponse ErrorResponse
+type GetOrderflowLatest400JSONResponse ErrorResponse
 
-func (response DownloadStateData404JSONResponse) VisitDownloadStateDataResponse(w http.ResponseWriter) error {
+func (response GetOrderflowLatest400JSONResponse) VisitGetOrderflowLatestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetHealthRequestObject struct {
-}
-
-type GetHealthResponseObject interface {
-	VisitGetHealthResponse(w http.ResponseWriter) error
-}
-
-type GetHealth200JSONResponse HealthResponse
+type GetOrderflowLatest404JSONResponse ErrorResponse
 
-func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+func (response GetOrderflowLatest404JSONResponse) VisitGetOrderflowLatestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReloadDateRequestObject struct {
-	Body *ReloadDateJSONRequestBody
+type GetStateProfileRequestObject struct {
+	Ticker string                    `json:"ticker"`
+	Type   GetStateProfileParamsType `json:"type"`
+	Params GetStateProfileParams
 }
 
-type ReloadDateResponseObject interface {
-	VisitReloadDateResponse(w http.ResponseWriter) error
+type GetStateProfileResponseObject interface {
+	VisitGetStateProfileResponse(w http.ResponseWriter) error
 }
 
-type ReloadDate200JSONResponse ReloadDateResponse
+type GetStateProfile200JSONResponse struct {
+	union json.RawMessage
+}
 
-func (response ReloadDate200JSONResponse) VisitReloadDateResponse(w http.ResponseWriter) error {
+func (response GetStateProfile200JSONResponse) VisitGetStateProfileResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.union)
 }
 
-type ReloadDate400JSONResponse ErrorResponse
+type GetStateProfile400JSONResponse ErrorResponse
 
-func (response ReloadDate400JSONResponse) VisitReloadDateResponse(w http.ResponseWriter) error {
+func (response GetStateProfile400JSONResponse) VisitGetStateProfileResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReloadDate409JSONResponse ErrorResponse
+type GetStateProfile401JSONResponse ErrorResponse
 
-func (response ReloadDate409JSONResponse) VisitReloadDateResponse(w http.ResponseWriter) error {
+func (response GetStateProfile401JSONResponse) VisitGetStateProfileResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReloadDate500JSONResponse ErrorResponse
+type GetStateProfile404JSONResponse ErrorResponse
 
-func (response ReloadDate500JSONResponse) VisitReloadDateResponse(w http.ResponseWriter) error {
+func (response GetStateProfile404JSONResponse) VisitGetStateProfileResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ResetCacheRequestObject struct {
-	Params ResetCacheParams
+type GetStateGexMajorsRequestObject struct {
+	Ticker string                      `json:"ticker"`
+	Type   GetStateGexMajorsParamsType `json:"type"`
+	Params GetStateGexMajorsParams
 }
 
-type ResetCacheResponseObject interface {
-	VisitResetCacheResponse(w http.ResponseWriter) error
+type GetStateGexMajorsResponseObject interface {
+	VisitGetStateGexMajorsResponse(w http.ResponseWriter) error
 }
 
-type ResetCache200JSONResponse ResetCacheResponse
+type GetStateGexMajors200JSONResponse GexMajorsData
 
-func (response ResetCache200JSONResponse) VisitResetCacheResponse(w http.ResponseWriter) error {
+func (response GetStateGexMajors200JSONResponse) VisitGetStateGexMajorsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetTickersRequestObject struct {
+type GetStateGexMajors400JSONResponse ErrorResponse
+
+func (response GetStateGexMajors400JSONResponse) VisitGetStateGexMajorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetTickersResponseObject interface {
-	VisitGetTickersResponse(w http.ResponseWriter) error
+type GetStateGexMajors401JSONResponse ErrorResponse
+
+func (response GetStateGexMajors401JSONResponse) VisitGetStateGexMajorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetTickers200JSONResponse TickersResponse
+type GetStateGexMajors404JSONResponse ErrorResponse
 
-func (response GetTickers200JSONResponse) VisitGetTickersResponse(w http.ResponseWriter) error {
+func (response GetStateGexMajors404JSONResponse) VisitGetStateGexMajorsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClassicGexChainRequestObject struct {
-	Ticker      string                              `json:"ticker"`
-	Aggregation GetClassicGexChainParamsAggregation `json:"aggregation"`
-	Params      GetClassicGexChainParams
+type GetStateGexMaxChangeRequestObject struct {
+	Ticker string                         `json:"ticker"`
+	Type   GetStateGexMaxChangeParamsType `json:"type"`
+	Params GetStateGexMaxChangeParams
 }
 
-type GetClassicGexChainResponseObject interface {
-	VisitGetClassicGexChainResponse(w http.ResponseWriter) error
+type GetStateGexMaxChangeResponseObject interface {
+	VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error
 }
 
-type GetClassicGexChain200JSONResponse GexData
+type GetStateGexMaxChange200JSONResponse GexMaxChangeData
 
-func (response GetClassicGexChain200JSONResponse) VisitGetClassicGexChainResponse(w http.ResponseWriter) error {
+func (response GetStateGexMaxChange200JSONResponse) VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClassicGexChain400JSONResponse ErrorResponse
+type GetStateGexMaxChange400JSONResponse ErrorResponse
 
-func (response GetClassicGexChain400JSONResponse) VisitGetClassicGexChainResponse(w http.ResponseWriter) error {
+func (response GetStateGexMaxChange400JSONResponse) VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClassicGexChain401JSONResponse ErrorResponse
+type GetStateGexMaxChange401JSONResponse ErrorResponse
 
-func (response GetClassicGexChain401JSONResponse) VisitGetClassicGexChainResponse(w http.ResponseWriter) error {
+func (response GetStateGexMaxChange401JSONResponse) VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClassicGexChain404JSONResponse ErrorResponse
+type GetStateGexMaxChange404JSONResponse ErrorResponse
 
-func (response GetClassicGexChain404JSONResponse) VisitGetClassicGexChainResponse(w http.ResponseWriter) error {
+func (response GetStateGexMaxChange404JSONResponse) VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClassicGexMajorsRequestObject struct {
-	Ticker      string                               `json:"ticker"`
-	Aggregation GetClassicGexMajorsParamsAggregation `json:"aggregation"`
-	Params      GetClassicGexMajorsParams
+type GetVolatilityRequestObject struct {
+	Ticker   string                      `json:"ticker"`
+	Category GetVolatilityParamsCategory `json:"category"`
+	Params   GetVolatilityParams
 }
 
-type GetClassicGexMajorsResponseObject interface {
-	VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error
+type GetVolatilityResponseObject interface {
+	VisitGetVolatilityResponse(w http.ResponseWriter) error
 }
 
-type GetClassicGexMajors200JSONResponse GexMajorsData
+type GetVolatility200JSONResponse VolatilityData
 
-func (response GetClassicGexMajors200JSONResponse) VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error {
+func (response GetVolatility200JSONResponse) VisitGetVolatilityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClassicGexMajors400JSONResponse ErrorResponse
+type GetVolatility400JSONResponse ErrorResponse
 
-func (response GetClassicGexMajors400JSONResponse) VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error {
+func (response GetVolatility400JSONResponse) VisitGetVolatilityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClassicGexMajors401JSONResponse ErrorResponse
+type GetVolatility404JSONResponse ErrorResponse
 
-func (response GetClassicGexMajors401JSONResponse) VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error {
+func (response GetVolatility404JSONResponse) VisitGetVolatilityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClassicGexMajors404JSONResponse ErrorResponse
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Query the access audit log
+	// (GET /admin/audit)
+	GetAuditLog(ctx context.Context, request GetAuditLogRequestObject) (GetAuditLogResponseObject, error)
+	// Inspect playback positions
+	// (GET /admin/cache)
+	GetCachePositions(ctx context.Context, request GetCachePositionsRequestObject) (GetCachePositionsResponseObject, error)
+	// Apply one operation to many playback positions
+	// (POST /admin/cache/bulk)
+	BulkCacheOperation(ctx context.Context, request BulkCacheOperationRequestObject) (BulkCacheOperationResponseObject, error)
+	// Move one client's playback positions
+	// (POST /admin/cache/position)
+	SetCachePosition(ctx context.Context, request SetCachePositionRequestObject) (SetCachePositionResponseObject, error)
+	// Return an API key to the loaded date
+	// (DELETE /admin/key-dates)
+	DeleteKeyDate(ctx context.Context, request DeleteKeyDateRequestObject) (DeleteKeyDateResponseObject, error)
+	// List API keys pinned to a data date
+	// (GET /admin/key-dates)
+	GetKeyDates(ctx context.Context, request GetKeyDatesRequestObject) (GetKeyDatesResponseObject, error)
+	// Pin an API key to a data date
+	// (POST /admin/key-dates)
+	SetKeyDate(ctx context.Context, request SetKeyDateRequestObject) (SetKeyDateResponseObject, error)
+	// Maintenance simulation status
+	// (GET /admin/maintenance)
+	GetMaintenance(ctx context.Context, request GetMaintenanceRequestObject) (GetMaintenanceResponseObject, error)
+	// Start or end simulated maintenance
+	// (POST /admin/maintenance)
+	SetMaintenance(ctx context.Context, request SetMaintenanceRequestObject) (SetMaintenanceResponseObject, error)
+	// Data preflight report
+	// (GET /admin/preflight)
+	GetPreflightReport(ctx context.Context, request GetPreflightReportRequestObject) (GetPreflightReportResponseObject, error)
+	// Hot reload data for a date, authenticated
+	// (POST /admin/reload)
+	AdminReload(ctx context.Context, request AdminReloadRequestObject) (AdminReloadResponseObject, error)
+	// Seek playback positions to a timestamp
+	// (POST /admin/seek)
+	SeekCache(ctx context.Context, request SeekCacheRequestObject) (SeekCacheResponseObject, error)
+	// Return an API key to the global stream interval
+	// (DELETE /admin/ws-intervals)
+	DeleteKeyInterval(ctx context.Context, request DeleteKeyIntervalRequestObject) (DeleteKeyIntervalResponseObject, error)
+	// List per-key WebSocket stream intervals
+	// (GET /admin/ws-intervals)
+	GetKeyIntervals(ctx context.Context, request GetKeyIntervalsRequestObject) (GetKeyIntervalsResponseObject, error)
+	// Set an API key's WebSocket stream interval
+	// (POST /admin/ws-intervals)
+	SetKeyInterval(ctx context.Context, request SetKeyIntervalRequestObject) (SetKeyIntervalResponseObject, error)
+	// Get available data for a date
+	// (GET /available-data/{date})
+	GetAvailableData(ctx context.Context, request GetAvailableDataRequestObject) (GetAvailableDataResponseObject, error)
+	// List available dates
+	// (GET /available-dates)
+	GetAvailableDates(ctx context.Context, request GetAvailableDatesRequestObject) (GetAvailableDatesResponseObject, error)
+	// Get current date
+	// (GET /current-date)
+	GetCurrentDate(ctx context.Context, request GetCurrentDateRequestObject) (GetCurrentDateResponseObject, error)
+	// Download classic GEX dataset
+	// (GET /download/{date}/{ticker}/classic/{aggregation})
+	DownloadClassicGex(ctx context.Context, request DownloadClassicGexRequestObject) (DownloadClassicGexResponseObject, error)
+	// Get available download links for a date/ticker
+	// (GET /download/{date}/{ticker}/links)
+	GetDownloadLinks(ctx context.Context, request GetDownloadLinksRequestObject) (GetDownloadLinksResponseObject, error)
+	// Download orderflow dataset
+	// (GET /download/{date}/{ticker}/orderflow)
+	DownloadOrderflow(ctx context.Context, request DownloadOrderflowRequestObject) (DownloadOrderflowResponseObject, error)
+	// Download state dataset
+	// (GET /download/{date}/{ticker}/state/{type})
+	DownloadStateData(ctx context.Context, request DownloadStateDataRequestObject) (DownloadStateDataResponseObject, error)
+	// Download volatility dataset
+	// (GET /download/{date}/{ticker}/volatility/{category})
+	DownloadVolatility(ctx context.Context, request DownloadVolatilityRequestObject) (DownloadVolatilityResponseObject, error)
+	// Health check
+	// (GET /health)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Get past orderflow records
+	// (GET /orderflow/{ticker}/history)
+	GetOrderflowHistory(ctx context.Context, request GetOrderflowHistoryRequestObject) (GetOrderflowHistoryResponseObject, error)
+	// Hot reload data for a different date
+	// (POST /reload-date)
+	ReloadDate(ctx context.Context, request ReloadDateRequestObject) (ReloadDateResponseObject, error)
+	// Reset playback positions
+	// (POST /reset-cache)
+	ResetCache(ctx context.Context, request ResetCacheRequestObject) (ResetCacheResponseObject, error)
+	// List available tickers
+	// (GET /tickers)
+	GetTickers(ctx context.Context, request GetTickersRequestObject) (GetTickersResponseObject, error)
+	// List loaded packages and categories per ticker
+	// (GET /tickers/detail)
+	GetTickersDetail(ctx context.Context, request GetTickersDetailRequestObject) (GetTickersDetailResponseObject, error)
+	// Get GEX chain data
+	// (GET /{ticker}/classic/{aggregation})
+	GetClassicGexChain(ctx context.Context, request GetClassicGexChainRequestObject) (GetClassicGexChainResponseObject, error)
+	// Get GEX major levels
+	// (GET /{ticker}/classic/{aggregation}/majors)
+	GetClassicGexMajors(ctx context.Context, request GetClassicGexMajorsRequestObject) (GetClassicGexMajorsResponseObject, error)
+	// Get GEX max change levels
+	// (GET /{ticker}/classic/{aggregation}/maxchange)
+	GetClassicGexMaxChange(ctx context.Context, request GetClassicGexMaxChangeRequestObject) (GetClassicGexMaxChangeResponseObject, error)
+	// Get latest orderflow metrics
+	// (GET /{ticker}/orderflow/orderflow)
+	GetOrderflowLatest(ctx context.Context, request GetOrderflowLatestRequestObject) (GetOrderflowLatestResponseObject, error)
+	// Get state profile data (GEX or Greeks)
+	// (GET /{ticker}/state/{type})
+	GetStateProfile(ctx context.Context, request GetStateProfileRequestObject) (GetStateProfileResponseObject, error)
+	// Get GEX profile major levels
+	// (GET /{ticker}/state/{type}/majors)
+	GetStateGexMajors(ctx context.Context, request GetStateGexMajorsRequestObject) (GetStateGexMajorsResponseObject, error)
+	// Get GEX profile max change levels
+	// (GET /{ticker}/state/{type}/maxchange)
+	GetStateGexMaxChange(ctx context.Context, request GetStateGexMaxChangeRequestObject) (GetStateGexMaxChangeResponseObject, error)
+	// Get implied volatility surface
+	// (GET /{ticker}/volatility/{category})
+	GetVolatility(ctx context.Context, request GetVolatilityRequestObject) (GetVolatilityResponseObject, error)
+}
 
-func (response GetClassicGexMajors404JSONResponse) VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
 
-	return json.NewEncoder(w).Encode(response)
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
 }
 
-type GetClassicGexMaxChangeRequestObject struct {
-	Ticker      string                                  `json:"ticker"`
-	Aggregation GetClassicGexMaxChangeParamsAggregation `json:"aggregation"`
-	Params      GetClassicGexMaxChangeParams
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
 }
 
-type GetClassicGexMaxChangeResponseObject interface {
-	VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
 }
 
-type GetClassicGexMaxChange200JSONResponse GexMaxChangeData
-
-func (response GetClassicGexMaxChange200JSONResponse) VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
 }
 
-type GetClassicGexMaxChange400JSONResponse ErrorResponse
-
-func (response GetClassicGexMaxChange400JSONResponse) VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// GetAuditLog operation middleware
+func (sh *strictHandler) GetAuditLog(w http.ResponseWriter, r *http.Request, params GetAuditLogParams) {
+	var request GetAuditLogRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type GetClassicGexMaxChange401JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAuditLog(ctx, request.(GetAuditLogRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAuditLog")
+	}
 
-func (response GetClassicGexMaxChange401JSONResponse) VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAuditLogResponseObject); ok {
+		if err := validResponse.VisitGetAuditLogResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetClassicGexMaxChange404JSONResponse ErrorResponse
+// GetCachePositions operation middleware
+func (sh *strictHandler) GetCachePositions(w http.ResponseWriter, r *http.Request, params GetCachePositionsParams) {
+	var request GetCachePositionsRequestObject
 
-func (response GetClassicGexMaxChange404JSONResponse) VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCachePositions(ctx, request.(GetCachePositionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCachePositions")
+	}
 
-type GetOrderflowLatestRequestObject struct {
-	Ticker string `json:"ticker"`
-	Params GetOrderflowLatestParams
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type GetOrderflowLatestResponseObject interface {
-	VisitGetOrderflowLatestResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCachePositionsResponseObject); ok {
+		if err := validResponse.VisitGetCachePositionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetOrderflowLatest200JSONResponse OrderflowData
-
-func (response GetOrderflowLatest200JSONResponse) VisitGetOrderflowLatestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// BulkCacheOperation operation middleware
+func (sh *strictHandler) BulkCacheOperation(w http.ResponseWriter, r *http.Request) {
+	var request BulkCacheOperationRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body BulkCacheOperationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GetOrderflowLatest400JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.BulkCacheOperation(ctx, request.(BulkCacheOperationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BulkCacheOperation")
+	}
 
-func (response GetOrderflowLatest400JSONResponse) VisitGetOrderflowLatestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(BulkCacheOperationResponseObject); ok {
+		if err := validResponse.VisitBulkCacheOperationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetOrderflowLatest404JSONResponse ErrorResponse
-
-func (response GetOrderflowLatest404JSONResponse) VisitGetOrderflowLatestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// SetCachePosition operation middleware
+func (sh *strictHandler) SetCachePosition(w http.ResponseWriter, r *http.Request) {
+	var request SetCachePositionRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body SetCachePositionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GetStateProfileRequestObject struct {
-	Ticker string                    `json:"ticker"`
-	Type   GetStateProfileParamsType `json:"type"`
-	Params GetStateProfileParams
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetCachePosition(ctx, request.(SetCachePositionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetCachePosition")
+	}
 
-type GetStateProfileResponseObject interface {
-	VisitGetStateProfileResponse(w http.ResponseWriter) error
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type GetStateProfile200JSONResponse struct {
-	union json.RawMessage
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetCachePositionResponseObject); ok {
+		if err := validResponse.VisitSetCachePositionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response GetStateProfile200JSONResponse) VisitGetStateProfileResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// DeleteKeyDate operation middleware
+func (sh *strictHandler) DeleteKeyDate(w http.ResponseWriter, r *http.Request, params DeleteKeyDateParams) {
+	var request DeleteKeyDateRequestObject
 
-	return json.NewEncoder(w).Encode(response.union)
-}
+	request.Params = params
 
-type GetStateProfile400JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteKeyDate(ctx, request.(DeleteKeyDateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteKeyDate")
+	}
 
-func (response GetStateProfile400JSONResponse) VisitGetStateProfileResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteKeyDateResponseObject); ok {
+		if err := validResponse.VisitDeleteKeyDateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStateProfile401JSONResponse ErrorResponse
+// GetKeyDates operation middleware
+func (sh *strictHandler) GetKeyDates(w http.ResponseWriter, r *http.Request) {
+	var request GetKeyDatesRequestObject
 
-func (response GetStateProfile401JSONResponse) VisitGetStateProfileResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetKeyDates(ctx, request.(GetKeyDatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetKeyDates")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetKeyDatesResponseObject); ok {
+		if err := validResponse.VisitGetKeyDatesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStateProfile404JSONResponse ErrorResponse
+// SetKeyDate operation middleware
+func (sh *strictHandler) SetKeyDate(w http.ResponseWriter, r *http.Request) {
+	var request SetKeyDateRequestObject
 
-func (response GetStateProfile404JSONResponse) VisitGetStateProfileResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var body SetKeyDateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetKeyDate(ctx, request.(SetKeyDateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetKeyDate")
+	}
 
-type GetStateGexMajorsRequestObject struct {
-	Ticker string                      `json:"ticker"`
-	Type   GetStateGexMajorsParamsType `json:"type"`
-	Params GetStateGexMajorsParams
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type GetStateGexMajorsResponseObject interface {
-	VisitGetStateGexMajorsResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetKeyDateResponseObject); ok {
+		if err := validResponse.VisitSetKeyDateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStateGexMajors200JSONResponse GexMajorsData
+// GetMaintenance operation middleware
+func (sh *strictHandler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	var request GetMaintenanceRequestObject
 
-func (response GetStateGexMajors200JSONResponse) VisitGetStateGexMajorsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMaintenance(ctx, request.(GetMaintenanceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMaintenance")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMaintenanceResponseObject); ok {
+		if err := validResponse.VisitGetMaintenanceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStateGexMajors400JSONResponse ErrorResponse
+// SetMaintenance operation middleware
+func (sh *strictHandler) SetMaintenance(w http.ResponseWriter, r *http.Request) {
+	var request SetMaintenanceRequestObject
 
-func (response GetStateGexMajors400JSONResponse) VisitGetStateGexMajorsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var body SetMaintenanceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetMaintenance(ctx, request.(SetMaintenanceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetMaintenance")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetMaintenanceResponseObject); ok {
+		if err := validResponse.VisitSetMaintenanceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStateGexMajors401JSONResponse ErrorResponse
+// GetPreflightReport operation middleware
+func (sh *strictHandler) GetPreflightReport(w http.ResponseWriter, r *http.Request) {
+	var request GetPreflightReportRequestObject
 
-func (response GetStateGexMajors401JSONResponse) VisitGetStateGexMajorsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPreflightReport(ctx, request.(GetPreflightReportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPreflightReport")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPreflightReportResponseObject); ok {
+		if err := validResponse.VisitGetPreflightReportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStateGexMajors404JSONResponse ErrorResponse
+// AdminReload operation middleware
+func (sh *strictHandler) AdminReload(w http.ResponseWriter, r *http.Request) {
+	var request AdminReloadRequestObject
+
+	var body AdminReloadJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AdminReload(ctx, request.(AdminReloadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdminReload")
+	}
 
-func (response GetStateGexMajors404JSONResponse) VisitGetStateGexMajorsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AdminReloadResponseObject); ok {
+		if err := validResponse.VisitAdminReloadResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStateGexMaxChangeRequestObject struct {
-	Ticker string                         `json:"ticker"`
-	Type   GetStateGexMaxChangeParamsType `json:"type"`
-	Params GetStateGexMaxChangeParams
-}
+// SeekCache operation middleware
+func (sh *strictHandler) SeekCache(w http.ResponseWriter, r *http.Request, params SeekCacheParams) {
+	var request SeekCacheRequestObject
 
-type GetStateGexMaxChangeResponseObject interface {
-	VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type GetStateGexMaxChange200JSONResponse GexMaxChangeData
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SeekCache(ctx, request.(SeekCacheRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SeekCache")
+	}
 
-func (response GetStateGexMaxChange200JSONResponse) VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SeekCacheResponseObject); ok {
+		if err := validResponse.VisitSeekCacheResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStateGexMaxChange400JSONResponse ErrorResponse
-
-func (response GetStateGexMaxChange400JSONResponse) VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// DeleteKeyInterval operation middleware
+func (sh *strictHandler) DeleteKeyInterval(w http.ResponseWriter, r *http.Request, params DeleteKeyIntervalParams) {
+	var request DeleteKeyIntervalRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type GetStateGexMaxChange401JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteKeyInterval(ctx, request.(DeleteKeyIntervalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteKeyInterval")
+	}
 
-func (response GetStateGexMaxChange401JSONResponse) VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteKeyIntervalResponseObject); ok {
+		if err := validResponse.VisitDeleteKeyIntervalResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStateGexMaxChange404JSONResponse ErrorResponse
+// GetKeyIntervals operation middleware
+func (sh *strictHandler) GetKeyIntervals(w http.ResponseWriter, r *http.Request) {
+	var request GetKeyIntervalsRequestObject
 
-func (response GetStateGexMaxChange404JSONResponse) VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetKeyIntervals(ctx, request.(GetKeyIntervalsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetKeyIntervals")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Get available data for a date
-	// (GET /available-data/{date})
-	GetAvailableData(ctx context.Context, request GetAvailableDataRequestObject) (GetAvailableDataResponseObject, error)
-	// List available dates
-	// (GET /available-dates)
-	GetAvailableDates(ctx context.Context, request GetAvailableDatesRequestObject) (GetAvailableDatesResponseObject, error)
-	// Get current date
-	// (GET /current-date)
-	GetCurrentDate(ctx context.Context, request GetCurrentDateRequestObject) (GetCurrentDateResponseObject, error)
-	// Download classic GEX dataset
-	// (GET /download/{date}/{ticker}/classic/{aggregation})
-	DownloadClassicGex(ctx context.Context, request DownloadClassicGexRequestObject) (DownloadClassicGexResponseObject, error)
-	// Get available download links for a date/ticker
-	// (GET /download/{date}/{ticker}/links)
-	GetDownloadLinks(ctx context.Context, request GetDownloadLinksRequestObject) (GetDownloadLinksResponseObject, error)
-	// Download orderflow dataset
-	// (GET /download/{date}/{ticker}/orderflow)
-	DownloadOrderflow(ctx context.Context, request DownloadOrderflowRequestObject) (DownloadOrderflowResponseObject, error)
-	// Download state dataset
-	// (GET /download/{date}/{ticker}/state/{type})
-	DownloadStateData(ctx context.Context, request DownloadStateDataRequestObject) (DownloadStateDataResponseObject, error)
-	// Health check
-	// (GET /health)
-	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
-	// Hot reload data for a different date
-	// (POST /reload-date)
-	ReloadDate(ctx context.Context, request ReloadDateRequestObject) (ReloadDateResponseObject, error)
-	// Reset playback positions
-	// (POST /reset-cache)
-	ResetCache(ctx context.Context, request ResetCacheRequestObject) (ResetCacheResponseObject, error)
-	// List available tickers
-	// (GET /tickers)
-	GetTickers(ctx context.Context, request GetTickersRequestObject) (GetTickersResponseObject, error)
-	// Get GEX chain data
-	// (GET /{ticker}/classic/{aggregation})
-	GetClassicGexChain(ctx context.Context, request GetClassicGexChainRequestObject) (GetClassicGexChainResponseObject, error)
-	// Get GEX major levels
-	// (GET /{ticker}/classic/{aggregation}/majors)
-	GetClassicGexMajors(ctx context.Context, request GetClassicGexMajorsRequestObject) (GetClassicGexMajorsResponseObject, error)
-	// Get GEX max change levels
-	// (GET /{ticker}/classic/{aggregation}/maxchange)
-	GetClassicGexMaxChange(ctx context.Context, request GetClassicGexMaxChangeRequestObject) (GetClassicGexMaxChangeResponseObject, error)
-	// Get latest orderflow metrics
-	// (GET /{ticker}/orderflow/orderflow)
-	GetOrderflowLatest(ctx context.Context, request GetOrderflowLatestRequestObject) (GetOrderflowLatestResponseObject, error)
-	// Get state profile data (GEX or Greeks)
-	// (GET /{ticker}/state/{type})
-	GetStateProfile(ctx context.Context, request GetStateProfileRequestObject) (GetStateProfileResponseObject, error)
-	// Get GEX profile major levels
-	// (GET /{ticker}/state/{type}/majors)
-	GetStateGexMajors(ctx context.Context, request GetStateGexMajorsRequestObject) (GetStateGexMajorsResponseObject, error)
-	// Get GEX profile max change levels
-	// (GET /{ticker}/state/{type}/maxchange)
-	GetStateGexMaxChange(ctx context.Context, request GetStateGexMaxChangeRequestObject) (GetStateGexMaxChangeResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetKeyIntervalsResponseObject); ok {
+		if err := validResponse.VisitGetKeyIntervalsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// SetKeyInterval operation middleware
+func (sh *strictHandler) SetKeyInterval(w http.ResponseWriter, r *http.Request) {
+	var request SetKeyIntervalRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	var body SetKeyIntervalJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetKeyInterval(ctx, request.(SetKeyIntervalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetKeyInterval")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetKeyIntervalResponseObject); ok {
+		if err := validResponse.VisitSetKeyIntervalResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
 // GetAvailableData operation middleware
@@ -2312,10 +4882,40 @@ func (sh *strictHandler) DownloadStateData(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// DownloadVolatility operation middleware
+func (sh *strictHandler) DownloadVolatility(w http.ResponseWriter, r *http.Request, date string, ticker string, category DownloadVolatilityParamsCategory) {
+	var request DownloadVolatilityRequestObject
+
+	request.Date = date
+	request.Ticker = ticker
+	request.Category = category
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DownloadVolatility(ctx, request.(DownloadVolatilityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DownloadVolatility")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DownloadVolatilityResponseObject); ok {
+		if err := validResponse.VisitDownloadVolatilityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetHealth operation middleware
-func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request, params GetHealthParams) {
 	var request GetHealthRequestObject
 
+	request.Params = params
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetHealth(ctx, request.(GetHealthRequestObject))
 	}
@@ -2336,6 +4936,33 @@ func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetOrderflowHistory operation middleware
+func (sh *strictHandler) GetOrderflowHistory(w http.ResponseWriter, r *http.Request, ticker string, params GetOrderflowHistoryParams) {
+	var request GetOrderflowHistoryRequestObject
+
+	request.Ticker = ticker
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrderflowHistory(ctx, request.(GetOrderflowHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrderflowHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOrderflowHistoryResponseObject); ok {
+		if err := validResponse.VisitGetOrderflowHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ReloadDate operation middleware
 func (sh *strictHandler) ReloadDate(w http.ResponseWriter, r *http.Request) {
 	var request ReloadDateRequestObject
@@ -2417,6 +5044,30 @@ func (sh *strictHandler) GetTickers(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetTickersDetail operation middleware
+func (sh *strictHandler) GetTickersDetail(w http.ResponseWriter, r *http.Request) {
+	var request GetTickersDetailRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTickersDetail(ctx, request.(GetTickersDetailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTickersDetail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTickersDetailResponseObject); ok {
+		if err := validResponse.VisitGetTickersDetailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetClassicGexChain operation middleware
 func (sh *strictHandler) GetClassicGexChain(w http.ResponseWriter, r *http.Request, ticker string, aggregation GetClassicGexChainParamsAggregation, params GetClassicGexChainParams) {
 	var request GetClassicGexChainRequestObject
@@ -2612,80 +5263,213 @@ func (sh *strictHandler) GetStateGexMaxChange(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// GetVolatility operation middleware
+func (sh *strictHandler) GetVolatility(w http.ResponseWriter, r *http.Request, ticker string, category GetVolatilityParamsCategory, params GetVolatilityParams) {
+	var request GetVolatilityRequestObject
+
+	request.Ticker = ticker
+	request.Category = category
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVolatility(ctx, request.(GetVolatilityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVolatility")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetVolatilityResponseObject); ok {
+		if err := validResponse.VisitGetVolatilityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xcX1MbubL/Kqq55wGqxsaGOCfLGyfJZnMrm3ADuye7MdclZtq21hppjqQxdii++yn9",
-	"mX8ezdgQYJNdXhJAUqvV6v6p1d2a6yDiScoZMCWD4+tARnNIsPnxZIkJxZcUXmGFP4JMOZOgG1LBUxCK",
-	"gOkWY2X+GoOMBEkV4Sw4Ds7ngAT8JwOpIEamTxjACicpheA4OBwcjnrDw97gWRAGap3qv0klCJsFN2Eg",
-	"syTBYq2p/kPANDgO/uegZPPA8Xig+TpzXW/CQJFoAUI2eSkWglwXdEXUHKk5EIFSHC3wDGQQBkRBIrdN",
-	"em5I6KnNnJZ1LAReBzflH/jlHxAp3aMqRZDtYox4xlST9/dZcgkC8SnCxSq0NGVVnIfFvIQpmIHQE9te",
-	"DYJnXOgdoUSqJlUUEwGR4oLUJ/jsNmzYG+oNy385fBFcVMTW2Mft0nmZCQFMadl0iMZ2mvg1zZGga0Q5",
-	"jq2y4TaNMzx7NG5KKMiJJdC1CYa26exmq84x9O6D7TfBns09JwlIhZMUXc2BWeJX2Ee6ZP+H8+GL4+Ho",
-	"eDD4PQiDKReJpmx2u6dIAs3V+eReNZ2GvBVXmE7MKj0860bEPBKp8vts5BOFJdxqp6WYa3aqZ6jSPmqS",
-	"9i6RXzEtyHeELeRt4etVhw61oRbVE2lSOI6JpoPpaW2qXQ0l3GDmR4pVYbCxWxZKsZpLNBM8SyFGl+sc",
-	"yaocXwcRxVKSSJvwQT70oFzHwdnppwPX52CaURqE2/t9AcG14XMRg5hSftVJvex1EQZSGXF3dDc9DmKg",
-	"Ck/sRL7N3fWEqOpA46jwGaT+O5Lr5JLT2tafnX7yWpY+44jQoPE5cPriiOcKcbFNN7fYYaFWW+ww1wvb",
-	"vwpLo90M5rUQXLQbCuhm80MhlLdsiSmJnbmWYtsBgN7AypyhTXs0Oy+IXEwELEFITGuTDqqgx7NLWkE8",
-	"Kw1NPsF/cDFhMJtw8lXDl/zu06dcfs30evhdp19NUkG4qMGOB2cSwiaxxb/qFE3klhBNfJ2PvJ1Trmq9",
-	"nr84POz/MNqJd600C9jGuMySyQxWm+J9djR6PuofHu02k6NxNxmXELIFJHRXd8zXeg//+fzZ0bPB4eCw",
-	"Mh9h6nnlcKkIVWPhZIaTBN+a2Q2YKtkpVnHht9CftR5Kv50mHuMavRjsqKA+09p9tMewng9vM3hz6p1H",
-	"M1BfrXc5jU0mhoejwaC/o5V8jYm1q26CV++AzdQ8OB4ZdMh/O3xktR79MHpgzV69nGM2A79yuxtH62UD",
-	"JXiF3rz+hCJDBH22qBUis6+YZnARNC9GlR3YgLMpmSoA1pxvOOolhGUKEOV8cYmjxcbUt5xm6fF173UK",
-	"zjwzDO9zBuWV0+Bep5gTodbNWY7ud5ZvxgzvaEYCYHEquL78tZwRxo+hnM08Jv589GJ0O2cMK6e/dzgy",
-	"co+KNGjsfnIYGnLOhfqq5ezqcyWEkUnEmRI4Ur6wllYk7frnfexd3OiX9GhiqXibztSjOXffu8r/BJiq",
-	"eUekCkdzmCQ8toJkWaKJw2qOM6mCMBBcYbN7F9W7ZdneWKne0SLutWswywzaZCKBhIt1YBxswEmdg6Kx",
-	"GYpVWGWyPjtf7HbH+5Df/HN08AdGpphKCDckiWezSYQpncSw8qKp7tDVlmaqtT1aCm7jFp7GGFbtjbOu",
-	"Ru3YdfKsO3S1dfHMJwktUMfXKjtaozkWSUvTUvgbZi1/ZzDZujl5p23tnQtmMOncKN2hc7N0h9m2Dole",
-	"SHtrmqnWxq37nXfa1t4phiVmzL+tORTfCnjvDrS7ePOdSvqlU0m/tCvplzYlNbeH9h20zW1b+KVFw7+0",
-	"SfxuZ8apDcu2XDSwgplNt3SkrcpeiDCk5kT6gr2fNTpNXBDXXFIZuJ/yiO3uEWiGE48j79aCTGtYnC15",
-	"kDmP71ZDw7Vzpuy4w/HxESjHsU0NmUTirsH793Bls1mKm1QK2vvtt99+6/38c+/VK2S1eL89rp9ipUBo",
-	"Ov8/HsfXz256+r/D/L9/7BYGvtiyoDYP4l4SUf7sy+6JKGYFuCUZddg7/Of5cHR8NLhFMkofdFeT1n2r",
-	"ZfFuk3xJBSwJz2QL6VPXvJV+m09V+kF1wh9BZlQh11ylJ7MoAil31XUJ6qX2Hr8iQ2yvN5xJJDS57anJ",
-	"BKTUIFLz7k4oRcaP3aSnzYmwGFZosKuneCsZVDLrjbUXWfoOlMz7aBO3IFlkYnbK7FeB2ndLstmNe8gZ",
-	"tay8I0k5zVQmoOsO6HrUUzEbOfzXZxPL0vv/m7x/9el2B4LZ+U4WrG50MeBmf6X//fWt/vfjL+e3Y0Mq",
-	"Hi26uDAdOrk4OTl9p9n49dVJEAbnZ+9Ovq6K4cZIZ8qbTP1EpOKCRJiaGJGBVAliCcJmtlMQvZPTt70F",
-	"rJHUZxxTBFOUUry+xNGiP2ZnurdE/3v24f27Kuab4RFnUzLLhPMRtMnqW5/sj5lxSZTRQj3zj1hL4+T0",
-	"bRAGSxDSsnfYH/QHxsdMgeGUBMfBUX/QP7KH4NxI46AoEOnp6Q+uNW7e6JYZKB8cqkwwieYEBBbR3Kyd",
-	"EqkImzXKTbCxVIxkChGZkshgsl70nF/lmizDwq5DhFlc9YXUHCsEKyKVdYsc0byGZW3loO3IXLrfxloa",
-	"oGp1TWatAiegTEnCZ08hAGjqDQ+i/WwiepwWYJC7UXlmuPQSlMggdMVWFt9u73RssvojoQqERumKSAsI",
-	"3IQlw+V/MjDXfsdm0dnL2OeT3u8X18Nw5GXnQi/P4pdRnMPBwB5aTLlIOk5TSiKzFQd/SM7KcrNtyOyv",
-	"RDOG13YYFOrl1AKM5RZlA1oP/MqYJ/HxTKuDNewLPbZuCXaRnTYgd6200vpljAtNOY1BePzVEMEqolkM",
-	"EvWlwjPCZvvbdNtU5DzKnlTq2rZtCsiNjXinxdOsbfPI3yVlermH1yl8ve2RpzIMQnslKC5vFlXKOooN",
-	"n7oh4UrF2kOK11cY55Ftno+KLUxNuUfNo0ofv2SLWhyL7gfXFghuioqfazybCROA56wd/POqFid9rvFG",
-	"webhZezMES6PRc9J0JB+Tv+lHfwGVjugN85l8z1AeM2XRHtZmoKIsIT9NgCv81jg905cduN5g7dX569R",
-	"RQ2080J4/V5owgxezioDO9nLIwouiuEIcla9UN/xyFn1WNy0weLOekkY9oXAmzZn9dmocm442ol6Nnh2",
-	"b9ZfL8Xy8PCjnp1xhaY8Y/GG0ed20rAyeynMAaBgfgsIFAVonYCLKa3CeK0azVOliPYcc6G5O0OIipCR",
-	"92CrVcw9mf2Dmf1DunH+itxuj6Fe1fjYZvae56dTxmJzRml9OHAC7/Qo6wZQ+pYHxW7d1g4r5bZffwAX",
-	"xG5//BZpvScz/EoznGg7HA7uwRD/hodbXYPvdrTZYvNrLZV7cWsNPXPccoFmAmBxe/M60zR2DE08mdc9",
-	"Obfn6xRQIW20V3V0i63UVPZ3dHjNjHfzdMOg8vohDEzGNP/FtrjEnmmwP5vcad7J5C/zX2yL7WUbnrzp",
-	"uwGONe6tYDM3NUIVNGn4sraK6CGjBxt1Sp4ln9kwNJHI8rveWLWlgKI5RAt/0ECYbGYRjUm59GDnLw45",
-	"KzEIbAIu9s+1KJ2DRxen6Y/Zv+HyjEcLUMjWLBE2Qyb1nUndKdMqhSwb/THz5a2wgDJ3NUB4qo27GNGA",
-	"4DJB60wXpPoXj9f3ti/NlPZNPXusUeLmARXDk4L2KIfthVz2bppRa5GDx7PI/GWROd4sqiDnfVcNVXP1",
-	"w+Nx5eSCqQAcr/Wxmwo+EyDNzWT0mAJyrEwxobCJWD9x5ZS8Ftgm0yn4AoE4TggrjFqC6hkzajdqk6w2",
-	"F37t8uROzcnpW7SAdZG9qphhLXG8aXJ54nubu2Nn5YyubXI3n2+PJ0TZNVK635LZWMC6ltZ4zGuvJ7fv",
-	"i+Qa6LJgVTW82s5aGTQl3LKblZe2bWeRSz0/5GG0md3uvPDnLHcmCVTBtOdcumPw+lTwJYlBIowojmMQ",
-	"PanWFNCcSMVnAieIT41nf7lGH1Jg6C1TIEDzxWL0K6dZos+sl9osdDciEQOlIEZ4hgmTCp1myrRoVQUc",
-	"zZF9NNIfs7fM5XbmZcJ4HORvDMaBNeOUE6akmc6UoqMI0yij2CSZYAlUtiQ8y3j5yzkmbJuhbfjp0J/1",
-	"Q3R2+ulb8NNPGgHoY6Q96BBpdzfUgGRd3T8tKN1k2eGU3vaG5SIlcLTQI6ssK5BqeHj0rBPM2vmtFHUO",
-	"HzfCl7/q9Zi4eyTjUp1/mkPhvDpUsQDDyvDxWPmZSKk9V6cYj37bMXGDwonSJuMeIDQciTeg0Ma+lZCb",
-	"13LugroHBrDkdvDV9wBtLHpWi2lo73cQHL3Rl90QmRep6NS94zl47x4FFcD8dsxKON5HU8ETG7WpZEIS",
-	"HgPto3PtRBCN95KSJIG4py9yyJXHID4dMz0y0UsvhQAsNji8FWrt29knrH3C2ofD2sr77BbEtY6CtaQn",
-	"zP2eMLe2c3dG3ZV7l9oGvC85U9o7tQEY++mF4utYKOFSIUlmTF/wMFPVJ898CQItsSA8k2NWvIe1QCHR",
-	"nov4hGgYolGIhoMQDUe22OZogOwzWrnfRydUcrRgGnqxROMgwStkv10xDnYAWfeM+wlnn3D2IXG2+rWA",
-	"Vqhd5bbx5OF+f2hbbN6ukFvkH3dIzFeCCwIwNW9jkGQ4lXNuikO1MZUJzQSUIJEsIuMMsACprG/LYKUQ",
-	"rFIiCMg+KmIHueVDbCsaQaEYMAW9+JTLTADae/X60344Zm9efwpRxNkSVkStQ2TyQq68eo5FEmoovgJK",
-	"9f8lW4TFesO4aAs0FJUB77A2928OlLvy7X9rjKu/1PZY1IeGbn6TGPfNYgw1BtE08QrMVJ5M1oFmpyKF",
-	"X5hNneX300o5Qmo/0WF2rD9mY/bvOTCTzDbXX1ar6tyreR4M9o/HDKG8yE9DZZUc6mmckYhnCpHkElPM",
-	"IohRhCmVRdyz0pBmSmp65mFmpJnDAjTGTGtuZmVEjl0exl1Wfq/MloeoTJaHCFTU32DfDNhYgDSvTTRc",
-	"FtOaT1AKzCSObALDAbGmVWYpDbUQYUr5lT7tMMN0LYlZTZRJxRMQiHI2Q0vZR+a7IQV+EDZrwVBT/uG+",
-	"qvLX8mqbuoU+fHR7Yja1eyf/soUXf/mThzP4MDXqu1PsONzSb/OzQzcXHng+rRr5nqNts4SlYsoQbVIz",
-	"XQywyP0nF/57ceGbBx3ac1V4b9xelget/UZBxyF7q2h1NU5Txpv15E6rQkSMi66lWYmyjFkRZqFYzMx2",
-	"u7A22tMH6L5z412Eey/N1L7NLObHlHbDt0axUS2InYuoEsb+wOgaySxNuVCy5gtoYcgmaodmX8qSONl1",
-	"kj1Fwu/xgHoKzdwhBJ6r/FMo/HsNznh38HZ4vi0O7p6c7xQEd3Eih6gFGo9ZNSSO7hwRH7OukHgRE6qc",
-	"MI8D4k+R9icc/5ND7CUQPIXa/wJo3h5yLyDdfJ1ULP1Qkz9+tz2CMMgEDY6Dg0ArqyPVGLP58Dz3hGVp",
-	"KHnAv2mUZ8X7nvpYtFdE73qXWEK8X1Kza2nS+lB/gefho4wINkf/K6PubVHx0tBDofKi4rrlAQCz1dUa",
-	"VDwE7CcFGoPLyvwynTEF8PJwBZfS9PXQOYkTwohUwt5xPKNtOevNxc1/AwAA//8MHZHOqWoAAA==",
+	"H4sIAAAAAAAC/+x9+3PbNtbov4LR3Zm1dyhZduy0dWfnjjd2U9/Gib/YTdKtciWYhCTWJMAFQNtqvvzv",
+	"3xw8SJAEJcqvJP28P2xqEQQOD84L54VPvZClGaOEStHb/9QT4ZykWP3nQZTG9C1JGI7ekv/kREj4NeMs",
+	"I1zGRI2JsCTqXyJCHmcyZrS33zvEkiDJELyKNn777bff+icn/cPDTcQ4wujw4PxgfHhwfoQuyeKa8agX",
+	"9MgNTrOE9PZ7CZawVNDLsJSEw3z/f2M0ij7tfu7DPzv2n//WI80//RTzSyL7EV78t2QRXvQZ72ecXMUs",
+	"F87Dzb/1gp5cZLCUkDyms97nz0GPk//kMSdRb/93/VEfi1Hs4g8Syt7noHeQR7E8opIvmpjAWTy+JIsm",
+	"Mk6wuCQROjg9hs9FG9OYC4l2UTjHHIeScLFZ+X74mH/84x//aIIZ9AiNMhZT2VzF7BBKiZyzCGEaoQzL",
+	"eQAYn+cXWzPO8gxNGUfvycUZCy+JRH+wmIrK2i+PztHW2emHrTDBQsTh1p+EMx8gMY3IjXfjMVLPkCD8",
+	"ikRo4+3R2TmK4HcLvECMJovKR+/uFGvEVJIZ4bDIJVmM51jMm+uczRmX6Ozng/7O3nOUcTKNb1AcESrj",
+	"6SKmMyTnBLBd+bgfpt8/j4bfb3///W7o+6bLmEawFKF5CmTANRleizEgqvfR84qQWOaiCd/P5+enSD9U",
+	"O7AzHG7tDocK/zgMSSZJtMUJ0BWJmvuwMxz68CHjVDHblPEUy96+ItS++nUVSZtB6hODglYdFDu01Ur5",
+	"r9jsLREZo4I06T9kuabL8it830Co5OaNWJJU/cffOJn29nv/Z6sURVtGDm05LPe5mA9zjheNb9QQlEt4",
+	"v+MKxwm+SAhQavvH+MXa+ZwgrvmMREiNcelrZ7iz19/e6Q93fdQl8jTFfLHqewGuMzNUbXl4SbiHwooP",
+	"QWYIuo7lHOg+5ijD4SWeEaCpTkg+V1PA0l4kL8UiER1oogr76zy9IByxKcLFVwA2qzzgox49qikOGIcd",
+	"SWIhm7OiKOYklIzH1QV+Nxu23d+GDbN/7Hzf++igrbGPq7HzAodz8q88uTxlItYQNhADQ/zqQr2tNIXd",
+	"0EJ1pEqTVGjOldX4ItweDAY+4gP5O04Incm5T3WEjEfCQVtM1boKe0aSIk6yBC8qGHz2g1dMFbqhGPid",
+	"dzOtcq4M3fYMrfN5gTxnDrts9Vs/LtueVqOmhWwtniRD4jLWynSKhRxPGb/GVSvm+TDopTGNU1Al20ux",
+	"VJMxmM+INDoUVhBEju2nOVhy5/fuQsoi0kZd8KyYXA0MCq1HbuY4V4qPM4nVaz7NB/jClrjtuy6sNcy4",
+	"f44l6wW9Ym2vYiWJ4tlVkkt9z5kdbHSkkDjNWlH7K41vUDEMbQgSMhoJUNNpnCSx+Xuzsb8a7HIPvnu+",
+	"+2x3uDPcCUqNHFP5fLfX3I8aCZfYW0Ght5asmZE9AuxMOquKje2d7hRzArSCsyyJSYQuFhWSsfOVpLKS",
+	"UopXavTReK34gM6mQlPuNsR10APOzUjk0SGKiEjkYO56zgTRtmvI8iRClEl0QRAngiVXVZR6ebA0D8vv",
+	"FnkYEiFWWmzmXRd/QWHi2K9opZ521dPxnFLZXtAsbUeSB1FlM3IzhqPH1vKVJZkx7ln44EIQKrWEm2NO",
+	"or6SeMXOBuh6HodzhKMrTEOCyBXhC1TM50JlIXlYrYo2huh6TqiiMDg1k2izg6ZtPX3jchWLcY/+dm0e",
+	"z+cZTeBjlvdzIueEqwUsVhEnsN+R+pHQCL7XTIHqAmOKE0GKJS8YSwhW7DrPLzyrFSfWeX4RIFzurjpc",
+	"lpLC/TZDSmscXo/hZwU9JTfSWvqIE5nz6uTfLZWfa2pSY6xXpcQS6LW9Xx1+dvphpUBxzabyAGhmK8Hw",
+	"G1IuNZgvXSl7HPuqiug3lICKKgAC1XtJFoHyWjD9UAEROHqacWQOsZ1N6aMbHEq9jJ6fDGYDVDeZd57B",
+	"3Nei+BEGANtP8yQxI9YTP68x5+xaKLaTDMl5LNYXLk0ruoPR50WDlQBanZGbWEhwkZR6LmVXpLecMld/",
+	"oBmMNkBxkQAZbIL7IyJ8mrDrAF2xBMs4ieVCeQIrfN2VeUvyXw1TQdwrOKV0rdQsxjhVxBjhBUiz1+Qa",
+	"/cb4JWLdzke97d39Z8OqJ1O7Lvf1Pxvm383/+7c2oFps2ZoRG1PUYsbexWJdzt5iHV+Q9wgUkSnOE2NO",
+	"LjuqVOxZyhC7IpzHhV0KR7IK3kvJe19mZbtJWfcdu99UGmzlsq1i88w58vhMU1Hi4EdE0kwu0DQmSSRQ",
+	"imU410aMnMN3NmVkKaq6SZ9lgmQtUXH6SGJB+4CX2aB6BNpQSuBabBUAbG3eVc02NzTnnFAJ7rElXKIH",
+	"jf1WnJkiWRh7UJ9DWpyObSbcNE6IGOsJlp0W1dxqsFlt9WlRjxtj6RebWi4pm1ZNfo19U5fg/3C+/f3+",
+	"9t7+cPjvXtDVw93Au+s9beBbMomTsfpKD8zwEFEPRipxij0fKvTEra7aEs0VVy2sULHxu8ngQ3ZNAZGv",
+	"Ynop1vVgHy6hoTbHdQILwVQ4ipT4wclpZamuvtKgBsxPCZaFzzYyn6WiVgKpcJV2OJSGaQHxp0IS7P/e",
+	"27KvbpXfUYlhgR3XC1aPU7LwY9ArBMPS2ctRH/VBnywdrkZsRSSRWAvdj77N7RokcGmgES3wMST8jsQi",
+	"vWDJanPIFw51TgqaID6uos0VfFiQ1Qo+tHShx7tiaa8bwxxxzvgL0MNe7nyRpzmonyuCCIxEoRmKRExD",
+	"ouOYHAmJuWwoVkJDFpHxFMdJzn1ipdRlGV6o79CvoOKVqg9ppVkW9OaYRgnhYwWtZ0l1JjaD1ImKGyHR",
+	"v+axsvzNm2uvzEkIlheJxhmmcSh82h5+R8VA4F8wkFU8NI2jKCHXmJN1l27d1nb5p76xqr2P6RVO4gjJ",
+	"Gjd00CsvyY2KjjXFrGJoHovLMQcDTOCksqj7dRHLLxJHkWkih+lT/AfjY0pmYxbf6fUrdvvlMybusjy8",
+	"ftvlb8YZjxmvaBOP+khjOo4kqS/h8buScOwb/Mw7OGPVs8rz73d2Bj/sdYIdiOaSrAJc5OkYrO0aenef",
+	"7T3fG+w867aSmeN2OO5szdZOnbc7OwY9UHHjGU5TvDawnswFDU7xFR/9HHoCdCj8fJp6mGvv+2FHAvWx",
+	"Vve3PYz1fHudl+tLd36bEnlnurNz1IHY3tkbDgcdueQuLNZOuim+eWW873tKOti/dh6ZrPd+2Htgyr55",
+	"oaJ4fuI2B8nWMyRK8Q16efTBhALR71pqBUjtK05y8rHXTHlwdqAmzqbxVBJCm+tt7/XTmOaSoISxywsc",
+	"XtaWXnOZK88R5l6XYNSzwvZ9riC9eBre6xLzmEuPu+bZ/a7y1bDhLdmIE3J5yhmc6Vt0hLJjEkZnHhZ/",
+	"vvf93nrGmDpT3FJlWIsqbszxfHutOQQkSt7pc7raXBCYGIeMSo5D6UtYA0JSYR8zRrtYFH0JDyWWhFf7",
+	"+/GMu2+d5H8mOJHzJQ5IFTrrFrbs5FxXEUPreerqo1Qv1YFISaqDZUJygtMqBMXDxlzlmXiZ+6TqEfgc",
+	"2AlXvHaiRp3pvIyW7A522e1A+QtZgHP4QIh4RlOjvL9AQvmSzfquJS7weCnZNar3Zi235uobDN+yckFH",
+	"8yq1C71gHSy1h2ElQ1lMV36t/tJVn7fcA4zHS77SJOhLBlBpnzTLJcIGuq7sCy93jqU1yd4j4E1koSXX",
+	"9+hGcu3DFmhOEpVmovlXZYRkMaUkUp/kT/bd+W7N/N6aK9Tg1Hx4DdqWnTqmkvArnHxxdo8NIFWW3x6K",
+	"b4LXC+hXoLmV593Pr37RS4aiXKfZoQsirwmhiJsMrg2Tc4G2h2kN1+2YWyN66mX8rt+6TACYUHT7V78/",
+	"G5+dvz06OBkfvz4/evvu4FXQKhZoEXWvYkDch1DwMEjXQHvxdWZVH75OMIyimIZL9EFeZqm20waeSsJN",
+	"9iAQeYppjhN0HdOIXSMCCRgbLI11dhrLCO0TWk/m623vpf7iLkgTjByp5CTGpUQIb4D9RD8waWp658Ik",
+	"BgyjDYMklbN+cgCb/Prg9Yuj8cnR2dnBy6MqWGeQvZcnJEJpia+V5GqhXoH3s8JYqom90B5xln6zAyXh",
+	"V3FIkCRpxjjmcbJAOS2zLAHxS+EPeoLlPCS+rEYsdaDHZDEax4rd3g2Teaii2jHVoG86ufOaGnpBT1hU",
+	"ejP+ZJySPxmtfdhBSngc4q3X5HoMWUY+yHMqYx8fA0AegDU9ulBXSbJLmD3o6cl8ZTcFwXACK4Pkt4Mr",
+	"uvcMS7Szsz8c9ofw/3dQwIZcSqAcbHop0LXZPbwDT9E15M9EbIZUkBZtZJwojEGZokbbydHJm7e/jV8d",
+	"nxyfj0/+hWKBBJGbjXhgRGbcn2txznOCGA11wlgSg4yYY4EuCIE02ZCQiEQIAuIEQ0Rck+7qhFlyFYeS",
+	"RGMrcT3xfniEIpIyIOspZ6m1lyRDjPajWFwiTnAkVuazK7DHFwuvYfaC0Wk8yzmJ0NuzMyTnnIg5S6qp",
+	"JMPvnn23u/39zm63gKMQbau9AiwJNa2KcCrrAzYFifjPCuq+e7Y7HD7bGXaLcerzpjqPQnVC6NvLn3KZ",
+	"c4K4Ko0WKBcE6dd0thonM8yjhAgB7g5V7Hzy5vCow3b6TopvbJ6BdVr50zDMjDXhOpuNQ5wkY5NK2vBw",
+	"wIBlz7Jctj4PrzjTWRKehxG5aX84W/YQ4g1LYYYBy54tg5mN06RwhvmeiiVPweBOWx5dcf+DWcvvlIxX",
+	"bo4dtOr50g+mZLx0o2DA0s2CAbNVA1L4kPanWS5bH67cbzto1fOlaLjClPq31XoI1/IH3t7/1yXItJRI",
+	"/1xKpH+2E+mfbUSqglrtO6gft23hny0U/mcbxm/nyjRppKbwWOWK+vyZKs3V/OXYHzabvxeUSa9r2CDr",
+	"1mjUPtG+HLgALvnIliBf5ePaisHLUbrcqEzMr1pkNYwwSm6NG4rTJXm/6mlpIZc40ylzbrZd0CsTgasO",
+	"32WYbmIxwQsIdp14s8pPCe/byp7MjFRqe4Am1t89QdccZ0KXERCkHS7aGwAWmpyTESU0CtDE+MIntkgI",
+	"7Q53zRCkRmAaoUlGyGU5xLHTR9TMWpyyVUGa9aAU8NnE78GIOrh0ii5Lnzys5T10nHIyTeLZXJ5gGk+J",
+	"kCexUInjnXKnwKhC28Od3QCl5n2VuinQznC3NfW4EToxaZCW0AZ/CEaTlQykpjLOfT/jFN8WCwEzdBcN",
+	"SkwZ+leyc2yOEncUDpa87618y1OotUqcWKycYi7ITzrhsNNuxyZT7v+dvXkNxS6aA5KYku477ZZygqRp",
+	"22uVT0pWFYvcmiLekozxJe7/zsWQUHBRJo+7h9sKWTNKzJeuRUNq4vGU5dRz3oBteGXS89UQ2BN1bANn",
+	"h61EWp2nPiOUcCyLrP1ux384PraWEJQHTOWLN+meajRfDZAVJePUyCKfcvtJfTcIGxK5NbR/F4UoUhhH",
+	"Etw3mBOUaimAGEdRPJ0SDq+ZY2Enj2S7rPRmJarVxlURs+Y6eg6vemWyFfsaNZYYSswjWx++Dgmyyxav",
+	"hSlMRhlnFwlJBbomnGhCdLdY8tzrocgwF9UU6fVwU5Feq/xDKv5quKFC7lUWq1J1lbsbEFf2wLvffkr2",
+	"ySXdTu0WgUmoPYxa2qohzcib7UUdtQLEeie1O7REcz+oLRBxL1VIfgnSvQqJkusOlUg7/Z3vzrf39p8N",
+	"16hECnqUXI9b961SwrVO5Y1tbtMy9al5vHL+Np3W1r3sLRF5Ik3/sl7QsXmFhzQEkar+7l76mHCYrkMX",
+	"E1/Q4CBJTB14bT5gJ91rZ7gcQ7fEga68WX5sLbp1dRaNnsPwWhl7LbrAaeQjWXhZNFVSEm6qnJ6iejAr",
+	"H3c0XtWwoPzkj60485+CXVy1nYHtGGMduXXg66DX3w4t6Jm6kXsosmr5cnFIJI6TdqZxKg3XaO+2nFy8",
+	"G7Zsf5YEni2ptOcAmhHVUpxa0OjobKwR9/q/xq8PP6xnT1vCbAdBc/0yAMzqh/D/747h/9/+er4eGIaP",
+	"2qFQA5ZCcXBw+grAeHd40At652evDu7an+5d4WXx8xiW6Ti+8gAt+3JO+imjZIHiVHeEclw2bvRosL27",
+	"0ynH8iKXEvw/i/HOXuTjasLBjbSz11elVhBfnKHjdwgymwXCLkjH76ogDIfP7zfFtVLm5Ye3gBPcqSWc",
+	"xc9ZLmtw9oeD4bPtToB+kbKmpvtMP0TQbWO/rCVQPvz4KoBPHMdXlYqC4j86LL0q/fdxK5lu463+rOTP",
+	"lHnawsZCMh6HOFFZ+MocNfWlqiQ8I7x/cHrchzwvAecDKmOcFH7AwYhC9gMRSHsEHHtZvR6aCKzxBNv2",
+	"GUK7DWUsTY/fD+gnDPLm4PQYnK6EC0O8g+FgaFq2UZzFUEMwGA6e6QPEXO3gFo7SmG7hPIoVec2It1Ni",
+	"6epMmXaeEiqReguZ/qxog5JrIqT2Lm3qyDS8EdO+DlCP6EUOx/cBOlINulRlq3Xegme1rK7V7Y2hkS4K",
+	"MecqCQ1TmzQ3orEwDlwS/aj9FcpRoFLrBuinOJGEg/ei6JUD+JyYLLTJYKSaGcWgsg5+PTw+Hx+9PvjX",
+	"q6PDf0qeE43eok3bcQRIJtK2zNUHSpwSXX/8e7MbUrIwzuECNYXlUiaNxTD2PzlRic/a5+5kyWlV71EI",
+	"n4NmPuGNymYra6ztqpIZOFqWU+H/ymImvwgC+0OVFW8aXg6HwxUNMD9/DHq2MFkR1s5wqA8EVJr8SNXc",
+	"JVQ43QIHT9mevFPTYLdbseLJmmZxaRGIfne4e28AVIuTW1dP2GwGlBoL8ODoPKrPbhuA3n/BDiiuwOrU",
+	"YVgoUWQl8UyopBhgyd5HeNOwp2L+VvZ8pRz3uuldI8ZgOnZX2GtT80MshenLNaLGC4eRbhAGZGR+Imqc",
+	"0xIJuu3pdnGxhE8tOomNKKwCo0thFSChm/leLMrOXQPLBz62FSMasvQipgRtHLw+3EQJaCcAZksd8fp6",
+	"mqke3MKs1c5GnVi2A3+uzZvlxOWRxTNv8bCceuVpo32p1Z27vG15fICVsQkfZEvCeO3Q2d5FaMPbyhGp",
+	"TgO1Do6bLeA5Tdh88LU3RGoBsCBQodMGdQKcnBu8Lm045EWfemMpyTyk2Gxp7+URX6d1qSFqcuuYioyE",
+	"silexGq5tXWRJ8oXnTFf98AD0/MLU1SwMaguTQKN9nZeeTaiykkL4ya21fDkR5hSN9ayv5n/EAisedjn",
+	"AbJtukzzrXWFz4j2YUnTJ3myr5rvAfgT/YN67vbGnewXbUovFmiiHGYTm5veGD2WzJnTF71m3CQxTwpj",
+	"djKiCG2olnJtzZADJAjm4bwSgjFdRgkO59rKQlbkF18JzDrZR+I6hhZlNrO+jJur+HgRdx+gX2mBQAYq",
+	"A+YsdllovsKJYE7nN1cWcIJVI3gsCS+3EyYpdlSXvmrb1NjexopRu3OsHQcow6II48MpPbNhfVfp1Tqc",
+	"qq+B1IERjSmyX2WyC34BKaFCVHN8RVSj1/IugQUpfi4JV7IRhZ38EbhIJ46mWrxUuKUYr3BZtMOc+DQd",
+	"dEdWXP4mK5sKGzD+xaLF/YoSt8n65+pRSvKcfH5oUVZpoe2RYgUSDDVF2gwcPp4Z+Cu9pOzaFWSK8Uz4",
+	"sgSPz3JdFFERsiAJF7phqisIU0wXtxO8mds12it8T9iV4Z2C7PRRqaS7oGDDeAoWX8SIUOSuRDOQejCi",
+	"jLfKazYtTmETPR9VrT21TTjRts8kGNGJsTYmlvK1cp8EOrGZIDbdNwLXCltsBaH6QT0qpeD+cnmJa53j",
+	"i7dXvyidPqIbP/+8f3KCmBJt6r/3z86CsrcojN1c1mHUtLFVvUX/+hLrrGabP6S8qjcu/hpl1mmla+/j",
+	"S6yTMrcC3E1JHCruNV2cpbpi4dFP068dGtTR9/o5GuSWkgm6NOrvYl0BeUkWfaciNSHaB1sl1kP1uyly",
+	"XXWIdOqBc6prbtvPkFUq/FIHhEbRsU+lZZES/llMH9+t8gtEJrS20YXANTLQjknHM2it5GoAvU4Ggd+P",
+	"clrWGtuCcX3/Eqh0JbrV2SNwjs4g8cSChqZgZBNKCRNIJ1XKUJm8CqwpSxLoZl2DbYBOYyqQyPkVNCzc",
+	"0iUoijAHI/pLzUHid3XYTex9YUKxzYk0oVS2CRxUpctH7yTsFK40wW3ukt9meaWqdErv1FS1kC5QnQsi",
+	"wE5ZmO3hRJ3lhdW7fxcj2hQWdidADRJOFNqB7KDHoiQUqdGSoV+OflNXHZ6Nfzp+ddSi4UqJ8RC6rdYJ",
+	"4ZG12u1kxiNqNNsWMlLOL/h/tZEm0ZIDsehAi5trWaXX07guU5ZTaqlW3ErV9mhKxoAerRcVIxGrnqXV",
+	"Sl1b9AknZFUlOUDvQbiYv4IRLa9irAZPyosZTRhib/hMDQkZpfpSnGLwiNriYk5CAkIIg+/ajATMxSEZ",
+	"IHV3qDOxkHhRXoXSIplOKmW7D0aRzapkn53jIFbYURWLwh2g9wPMDzO2u3SamALqfQScOEFG+OBaafkG",
+	"y3TRX6L7XExsSfpEnwdGdLK9l042f0TlhKomcKKrgGM5QGW9rp5UqJjbiLqV4e+PXx++eX+m5FlO8XSq",
+	"9r9FbtU37P5ll6dy/5HlVydqsQIs9VDNlxJnhj5qZHsG5AViTZ0SfXJkqbzKbFZuq7Q6kzwPpSoDjmI8",
+	"o0zEwkaHKp3sza2MiwAVibrI1t/nmY4N6QO0No60rbNfyYO/EtpE0BMHxbUICRGBCpKPqErmLdotm/Yp",
+	"Wwkuz+qmivhHs04SU+IAos6vI3r69uinV8cvfz4fn704eK3ivpu6vIfcZFpGOrVX1oWTEW4iOVvGWdEi",
+	"+ur1Cg9I0PWlfIdMOwRxO8alIZX0n9XHLCMbvXftbqUznBI0Vw4GGIiwqNi2gRJ5xt3MOBqZS6JHPbSh",
+	"72uCRIIRVc+nLInA+2sHOXc/j3pqx0a9ZRdEj3ojam5409784rrqzQEq0gBGvYNczhmP/1R7sI/+RTAn",
+	"HI3y4fBZeHB4cvx6fP7ml6PX6gcCk8LSTqRXm/7IGQpPcyqI9BGJcxv3A8laz33fjyxrPcnkHurUo5DJ",
+	"w53myRc1GVWVScVo1OBsfxGfjK3cUmyHJLskVIPz7BFzG6oUDXgR1in0w+OBYcgEJ5zgSN3flHE240Qo",
+	"nbw3HD46KKCEGi6Jn0upVxwzsDmM4FzOCZUAFYmWClhByOUqr33N4d4Mi1Z88NbRDkO2mEoWG9E2p/wy",
+	"T7zHN678/zYKKSaB0eYXC3QRU8wXJtg4UE/VgQY1Lrwa0ZZY5QAVlR+OU7yi8Evn+Ih28Y6jhnPcaw8T",
+	"HVtb5firfYpkCHYPSRZ0vsfr2fbz7Z2h+l9LhohY6jTskPfYUNEAYxlqZdNanko9AaVj3srKRIuWdX3X",
+	"lEoi5PbOs917zcZpLl3ky9STYjZbLqvqmilzW4Ba7xY0Ff6dU2EePdnkW4l2mAR9kAfqIklH0xaM/BVG",
+	"PTTvNHyX2kFVyWVuVSzXom877HULfRyX/fi6hj9sZdc3EP5otl1c4g2wDRO/TBwEuos5dyV2jYTMEnaB",
+	"E9vQyumu2DEoooIQplpbecatn7AUlbaxJpZOvMSuZI7hI9oMhjTbVQ7QgXbR2/hLGVrWOVNiRFXTtYzw",
+	"IvQvLUjCxLi7hEyKje99BQRmQycOgTXjJ1BMANgr8V7b03W8lOf4kghElDvQJieoW5JBMg5QATVSDefV",
+	"zdCYejYMEu9MfpeGhnBtQ6ib9glX5q82VJWgFQxdEpLpTAJVuAuJ/ULnH0tVB6BoY4BemLyutkDM+7Mx",
+	"xGIsIKviMY4Ye6CYTL1b7ePHZe4izL7AadvSbTUZuSVx/oxIR7j9XbSzQZv6s8EK8EDhrU+Ahc8rK17m",
+	"MeFwdFE1Pok5abGpcwu8c8gTGQnjaRwiE789m6uQry6vDIo6Vu1mdLyLKidHZ1NVkjGtR7WtIMXCcKhv",
+	"nlyqnlWL8JiiRpeB9vp1pb6hUKjU3lEZ1fSr79s0JvB0wVASh7koveu5xAHs94P+vz9+2g72vOA8aB2L",
+	"u2NLXS5N8moJVb4EvvARYy1UqQrYPJxAxEoeMAUc9mbPymqkoFLTokwxl/HZenpaBIjchEkeEYEGQmKo",
+	"ltlcRdsPm9pQXanzpvh1NK4N8eLfNAvr2y4QK0vuQs/VwSRQf5RWv5YqZRFYre9Gs0KmvNL4IdHruzl5",
+	"iQWkMzoAV00yD50xfswWl7Vq6b71SQuCz0Xzqk94NuPqKh9G24W/vfbUYJ+BvJGkXqSp+MxMXJZ/ejRB",
+	"A/t2/hf65ZfkpoP0xij6dkR4pXcC2sizjPAQC7LZJsCrMBbyuxOUy+V5A7bD8yPkkAHKCI9ZtXeMKRry",
+	"QOa8uBQ823jDeGzMhNWu1rdUOTd9GjV5sPAAao+rBxENnivbkBW38j76wRY6XrmRlmpc0kDV4DLtXrAC",
+	"oAB+hRAobiheKnChMsgR45Xrij3XWKONorzOlNv5L8L3ieDK9cpPIuDBRMBDmnT+69uXWw8VmvoSbkaj",
+	"qSBGMzXhzi2D8KXWZZUZSjtzq9itdXnSuZv97sq4mGx9VVx0ZX9iwzuy4Rj4cHt4D4z4v1DRVSn4dmpO",
+	"9y79BFi5FxNXzadUL+Noxgm5XJ+9INOuq5viib3uydA9X2QEFdhGG67RW2wlzLLZ0fhVK97O6g3Mzfrm",
+	"J9Xw3v6hn+hR+oHbvtkM0n2dzR9lY+fAafj8ZFmvL3A0c99e2JQW7tYnG4K+F6lTTry+tHnn9jB7EjcP",
+	"pc2D5lWOWex07ajAFF+N2wWL88Zq4eLMdPXE97fk+xp7LWX+ubp2uJWp36t6cZ1ENiku1JyYNGLh1j3j",
+	"ogrM7SUUh/MR1Y29Rdk1zHF16oa/tmw6LirIrmKM6uWcLbESfXXyKnGgM6hr7TDKCxTvoUPQQx5Fa9dD",
+	"e2jjTPfHiAXSe7qo5y6qX1E4J+Gl38Na9rwpVMBcNcFbrHSsFK+W+QImlRMogURFZNdFc4AgkgC54Crl",
+	"cIAObfcB20Sl7WKNEf1VEDTRN+aLSRFDsYtDGUBM0WtkRhQ1DViFoRm1O6+7G0yAMCdbE8km1dYqm2pm",
+	"DJmGsHGxvawPkh3ZBLk3Nqr8RDO/TV1US/xYQFXkN1pnd5kAwQlSZaXFzZAtpF4cZX82G7OC6Gs6BYqP",
+	"AnR2+uHr0ClWXgCSG9uMJMfhJby5dtpgO7TO7U/bHQB8r3bbyTg19BQgQiPVtlC6dFXZ90oS6J4fXDNd",
+	"RaqUjfl2d1f15WsDWJXDOGmrFaKGzNUwyYW9D7PWa7M1TRWYpHfHxNT3xV2Xt4TuhzboJLsrbG8J1jeJ",
+	"GqFXpGy62iqmQsI4I1B0ugK7psGI5jr7EBShufKiQdMj/2Xd223kbOzOO9mZd9VKnbpWVy8+bHY2brbz",
+	"qSsMqwx0+vkXy1sprpsqpepjG3nq3NJm5IHTVumRhsZ1dHrxzCh2pzqrve7gV3N6cyKxuvZY/1zJVTBH",
+	"NdvoYETrCTumUWaGc0FUeSP8oMEYjKivwz/mpOzyPzQFB/oNlVdAMhC1TKjePFBHOKJlr4FaJ4cW2/EV",
+	"FIjpfDXz9B+Is1ySAPE7lIp5S8NaCsPKoqkHylVr3lnyVBa2uixMa4u26rCnOqiOdVDqmOfJ4nBT9ZxO",
+	"i+2ySN1G0pIMb24AGSB1NqXM9ms0nG3Holio0lAifyxGVLs/SoZEyDJiTg6CSNOty1RT/fPs9ANw+c7z",
+	"S7L4J74IJ2pCXfLPdM9TLI0JcHb6waY145AzITwlW1YEwXpVASQeUwLZi15WH5hhE1i9fa5zZz5Oks37",
+	"qt+pr/bgPXXrC36NnXXrMH6l/XUdMP9iXXY91yL5EtxwaEVIRaXVijj8Mq1FTjr3xhjPS8MZYO52ecgM",
+	"v/r1MUtzHyzIS3MnZQG0xwNlHm5F6lKdlV4n0wNCH8/0u2Xzc81mU/PdShIrd5K9cAjTaESdRG0cylx1",
+	"bDHzWVvXzUxUNQ62p06Iqe6mcwWtb20XCaUP8wx8nVS6x0UwSneHu21OzMp1Qo+wpbV7i3z1fIT3DVLV",
+	"R+HKnUTNPTZ4cxHsJsKX7TX8e3/LfM5Tzq7iCJZDCY4ideXJIjGH+BnHKeAeAtwXC/QmI1SXv9hLKd6x",
+	"JE/hAPMCUsNgGBTBE6n6m8wwbB86zaV6AgSh2ifrW1QG0M3TpDvPy7tCRr2EsUtg8VFP4810VYLlUvwH",
+	"4yjESWhauSTkiiStfe6LFNIXcxzTVfr60X19a4SrDxo5mfsIAskBAh2jajR1xPeL5Wl+e87JU/dG7PIW",
+	"EuvBAHW8796TDY7uope3rRDSxVnulQ7tN2CjtguwBzW/FszQggNYoxd0FFWV28G9dofp49jVSWdu7jHT",
+	"jmhpOQkCvCVJGaMq3lJzfoueu2WofUlutKuuKfdB1oUgcJT4+uo8cV+gV4sNC35ZTyAwcHEdi8ctWNu3",
+	"UsnaA0cXPbulVJRYrW5txBZW1VoMbfybcIZe4jTFATpRqk73BLgiW6/VAlekUMXHI1oqYOdCKTcdHIRF",
+	"MkDnwN+qsYhI4jQlUR+C2MjchYXYVAuxFD69RILtZ7hSuZ7oL37Srk/a9Um7PmnXe9KuWqos07H6MKBl",
+	"55OW/Za0bGXnbq1nb/TtNq2q9gWjEk6gtgFDfGnvjiyuahTxjCoXBy3UP50RJX7QFebQLHFE7XnUqAaB",
+	"NozECdB2gPYCtD0M0PaerjF9NrQ5DpsDdACX9+hLTzA4plN8gzIeMy5GvQ5q9Ua3eXjSrE+a9UmzPmnW",
+	"+9OsRrAsV643Vho+nWK/Pf1abF5XJVtGklZXHTouY05w0lc3PgmKMwFdjXV6iZNfkxLJ41AUAQFKMFdZ",
+	"q6onA7mRkB0a8xhaVBUeYSvrSaRbNxCJIoITAh+fMZFzgjYOjz5sBiP68uhDgEJGr8hNLBcBUkUvpo8M",
+	"1MIEoHyvCZQuu4m2MY1gw1jrNalFitUrlZ34lCr6pNWetNrXqdVq6ZPL0iWtNPoqtdpXq1Vs4n8djUuS",
+	"Jteruf2V6nxI63V0qmszznTVEJZY1S68VxUvi4wopyatNCzZqJwuKNnch6sHbcAblKM7HeqDZhEI+D9O",
+	"L3CCaaguN0gSUcQvnQdZLgXMJxlKWAjAYU6wKo9wj5LOG1ZbeQA3RaYbZfFngMrazwARGQ5q4KsXah8g",
+	"VCM1SpxllajgmAocanlgVC/MVaaeqtkC3VNQ5eJTnCzMBRZhLiRLCdc9CK/EAKleh4XGKMRkQ2uqauZT",
+	"DeJf6+TapC305q3ZE7Wpy3fyL1tH/GRrPNkafzFbg1HyZqoEVqcYcLBiHIgIIxL1Cx+999A4Yn3DzK2I",
+	"09FvIkD12dQQpUrE5tMx/Vs5pjdNG7Rh2oi8NHtZmlZq8DKzaq2os+t9L5kWFjdUZUrIIl2OVfjOR7Rw",
+	"nieYz9R2m/A02gCTadMc1U2keiPL5aaatzBM4Ki9MhqNKsFoiyInHK1uBhB5pkuoXesPkCGaejpQ+1L2",
+	"9BDLbJeniPY9miRP5sKTufDkcF8ZyrZC7imk/a263L07uJ4GXxXPNh3TOwWz9VQoplX9O6JuaBvdOrI9",
+	"ostC24Wn37EpHkdtP0XMnzT3k+Z+0tyPGiovRf9TyPwvoL/bQ+deJb5ek7+ikTZFcZolMal0Gyti6LVQ",
+	"Odowfd1UBS2EzEd0Qzd429TB88U+wrIv56SfMkoW6PhdgHb2+srri3gsLhEncMLGidLlF7mUEKxZBDoK",
+	"n6myLzAqVOxjK8slOn6nwJgTLFOctene7u0Ev+74+NfTnu9Jyz5p2f9FWrYUIG069p0jIHM+xSF5Ct6v",
+	"pd98mkYj0lFu5cOejk6oLmxeSW7vo9EjekEv50lvv7fV+/yxmK/xTv0uGOvPFY4o1WN6nntxizbb1XfR",
+	"RpF10L/AgkSb5WxaW3vula02wvfAUczpefu4FZe+mcpRnqngPlgNQ3F3gGcKp1fqp5aOlVS33wFt4Jkg",
+	"VhcGNTvJOTdU2hy+KSFeGK7JhVBjPfOoi/RjIbl2+nve1l0ZPn/8/D8DAD1gCFx/+wAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
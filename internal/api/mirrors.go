@@ -0,0 +1,124 @@
+package api
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMirrors is the ordered list of historical data hosts tried when the
+// signed download URL points at one of them.
+var DefaultMirrors = []string{"hist.gex.bot", "hist.gexbot.com"}
+
+// defaultMirrorCooldown is how long a failing mirror is deprioritized.
+const defaultMirrorCooldown = 5 * time.Minute
+
+// MirrorStatus is a snapshot of one mirror's health.
+type MirrorStatus struct {
+	Host                string
+	Healthy             bool
+	ConsecutiveFailures int
+	LastFailure         time.Time
+}
+
+type mirrorHealth struct {
+	failures    int
+	lastFailure time.Time
+}
+
+// mirrorSet tracks an ordered list of interchangeable download hosts and
+// their recent failures so healthy mirrors are tried first.
+type mirrorSet struct {
+	mu       sync.Mutex
+	hosts    []string
+	health   map[string]*mirrorHealth
+	cooldown time.Duration
+	now      func() time.Time
+}
+
+func newMirrorSet(hosts []string, cooldown time.Duration) *mirrorSet {
+	m := &mirrorSet{
+		health:   make(map[string]*mirrorHealth),
+		cooldown: cooldown,
+		now:      time.Now,
+	}
+	for _, h := range hosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "" || m.health[h] != nil {
+			continue
+		}
+		m.hosts = append(m.hosts, h)
+		m.health[h] = &mirrorHealth{}
+	}
+	return m
+}
+
+// contains reports whether host is one of the configured mirrors.
+func (m *mirrorSet) contains(host string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.health[strings.ToLower(host)] != nil
+}
+
+// healthyLocked reports whether a mirror is outside its failure cooldown.
+func (m *mirrorSet) healthyLocked(h *mirrorHealth, now time.Time) bool {
+	return h.failures == 0 || now.Sub(h.lastFailure) >= m.cooldown
+}
+
+// order returns every mirror in attempt order: healthy mirrors in configured
+// order, then mirrors still cooling down, least recently failed first.
+func (m *mirrorSet) order() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	var healthy, cooling []string
+	for _, host := range m.hosts {
+		if m.healthyLocked(m.health[host], now) {
+			healthy = append(healthy, host)
+		} else {
+			cooling = append(cooling, host)
+		}
+	}
+	sort.SliceStable(cooling, func(i, j int) bool {
+		return m.health[cooling[i]].lastFailure.Before(m.health[cooling[j]].lastFailure)
+	})
+	return append(healthy, cooling...)
+}
+
+func (m *mirrorSet) markSuccess(host string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h := m.health[host]; h != nil {
+		h.failures = 0
+	}
+}
+
+func (m *mirrorSet) markFailure(host string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h := m.health[host]; h != nil {
+		h.failures++
+		h.lastFailure = m.now()
+	}
+}
+
+// status returns a health snapshot in configured order.
+func (m *mirrorSet) status() []MirrorStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	out := make([]MirrorStatus, 0, len(m.hosts))
+	for _, host := range m.hosts {
+		h := m.health[host]
+		out = append(out, MirrorStatus{
+			Host:                host,
+			Healthy:             m.healthyLocked(h, now),
+			ConsecutiveFailures: h.failures,
+			LastFailure:         h.lastFailure,
+		})
+	}
+	return out
+}
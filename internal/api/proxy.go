@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// proxyFunc returns the transport proxy resolver.
+// With no explicit proxy the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+// environment variables apply. An explicit proxy (http, https, socks5 or
+// socks5h URL) is used for every request except hosts matched by NO_PROXY.
+// Hosts in noProxy bypass either proxy; a username replaces the explicit
+// proxy URL's credentials.
+func proxyFunc(proxyURL string, noProxy []string, username, password string) (func(*http.Request) (*url.URL, error), error) {
+	extra := strings.Join(noProxy, ",")
+	if proxyURL == "" {
+		if extra == "" {
+			return http.ProxyFromEnvironment, nil
+		}
+		return func(req *http.Request) (*url.URL, error) {
+			if matchesNoProxy(req.URL.Hostname(), extra) {
+				return nil, nil
+			}
+			return http.ProxyFromEnvironment(req)
+		}, nil
+	}
+
+	u, err := ParseProxyURL(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	if username != "" {
+		u.User = url.UserPassword(username, password)
+	}
+
+	envNoProxy := os.Getenv("NO_PROXY")
+	if envNoProxy == "" {
+		envNoProxy = os.Getenv("no_proxy")
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		host := req.URL.Hostname()
+		if matchesNoProxy(host, envNoProxy) || matchesNoProxy(host, extra) {
+			return nil, nil
+		}
+		return u, nil
+	}, nil
+}
+
+// ParseProxyURL validates an explicit proxy URL.
+func ParseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https, socks5 or socks5h", RedactProxyURL(proxyURL))
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", RedactProxyURL(proxyURL))
+	}
+	return u, nil
+}
+
+// RedactProxyURL hides proxy credentials for logging.
+func RedactProxyURL(proxyURL string) string {
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.User == nil {
+		return proxyURL
+	}
+	return u.Redacted()
+}
+
+// matchesNoProxy reports whether host is excluded by a NO_PROXY list.
+// Supports "*", exact hosts/IPs, CIDR ranges and domain suffixes
+// ("example.com" and ".example.com" both match sub.example.com).
+func matchesNoProxy(host, noProxy string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if ip != nil {
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+				return true
+			}
+		}
+		// Drop any port; NO_PROXY matching is by host only
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
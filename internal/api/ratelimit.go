@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// minAdaptiveRate is the floor 429 responses can slow requests to.
+	minAdaptiveRate = rate.Limit(0.1)
+
+	// rateCutInterval groups 429s from concurrent workers: a burst of them
+	// within this interval of the last cut halves the rate only once.
+	rateCutInterval = time.Second
+
+	// maxRetryAfter caps the pause a server can request.
+	maxRetryAfter = 15 * time.Minute
+)
+
+// adaptiveLimiter paces API requests. Each 429 response halves the rate for
+// the rest of the client's life, down to minAdaptiveRate, and a server-sent
+// Retry-After or rate-limit reset pauses every request until it passes.
+type adaptiveLimiter struct {
+	mu       sync.Mutex
+	limiter  *rate.Limiter
+	resumeAt time.Time // no requests before this time
+	lastCut  time.Time
+	now      func() time.Time
+}
+
+// WithPackageRates gives the download URL lookups of some packages their own
+// request rate, e.g. a higher one for small orderflow files. Packages not in
+// rates share the client's rate. Rates <= 0 are ignored.
+func WithPackageRates(rates map[string]int) ClientOption {
+	return func(o *clientOptions) {
+		o.pkgRates = rates
+	}
+}
+
+func newAdaptiveLimiter(ratePerSec int) *adaptiveLimiter {
+	return &adaptiveLimiter{
+		limiter: rate.NewLimiter(rate.Limit(ratePerSec), ratePerSec*2),
+		now:     time.Now,
+	}
+}
+
+// limiterFor returns the limiter pacing requests for pkg.
+func (c *HTTPClient) limiterFor(pkg string) *adaptiveLimiter {
+	if l, ok := c.pkgLimits[pkg]; ok {
+		return l
+	}
+	return c.limiter
+}
+
+// Wait blocks until a request may be sent.
+func (a *adaptiveLimiter) Wait(ctx context.Context) error {
+	a.mu.Lock()
+	pause := a.resumeAt.Sub(a.now())
+	a.mu.Unlock()
+
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return a.limiter.Wait(ctx)
+}
+
+// Limit returns the current request rate.
+func (a *adaptiveLimiter) Limit() rate.Limit {
+	return a.limiter.Limit()
+}
+
+// rateLimited records a 429 response: it halves the rate, unless it was cut
+// within rateCutInterval, and pauses requests for retryAfter. It reports
+// whether the rate was cut.
+func (a *adaptiveLimiter) rateLimited(retryAfter time.Duration) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	a.pauseLocked(now, retryAfter)
+	if now.Sub(a.lastCut) < rateCutInterval {
+		return false
+	}
+	a.lastCut = now
+
+	limit := max(a.limiter.Limit()/2, minAdaptiveRate)
+	a.limiter.SetLimitAt(now, limit)
+	a.limiter.SetBurstAt(now, 1)
+	return true
+}
+
+// pause holds requests for d, e.g. until a rate-limit window resets.
+func (a *adaptiveLimiter) pause(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pauseLocked(a.now(), d)
+}
+
+func (a *adaptiveLimiter) pauseLocked(now time.Time, d time.Duration) {
+	if until := now.Add(min(d, maxRetryAfter)); d > 0 && until.After(a.resumeAt) {
+		a.resumeAt = until
+	}
+}
+
+// retryAfter returns how long the server asks clients to wait: Retry-After in
+// seconds or as an HTTP date, else the reset of an exhausted rate-limit
+// window (RateLimit-Reset or X-RateLimit-Reset, in seconds or as a Unix
+// time). It returns 0 when the headers ask for no wait.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return max(time.Duration(secs)*time.Second, 0)
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0)
+		}
+	}
+
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		remaining := strings.TrimSpace(h.Get(prefix + "Remaining"))
+		reset := strings.TrimSpace(h.Get(prefix + "Reset"))
+		if remaining != "0" || reset == "" {
+			continue
+		}
+		secs, err := strconv.ParseInt(reset, 10, 64)
+		if err != nil {
+			continue
+		}
+		// Large values are Unix times rather than seconds from now
+		if secs > 1_000_000_000 {
+			return max(time.Unix(secs, 0).Sub(now), 0)
+		}
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	return 0
+}
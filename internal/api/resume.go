@@ -0,0 +1,215 @@
+package api
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ResumableClient is implemented by clients that can continue an interrupted
+// download with a Range request.
+type ResumableClient interface {
+	// ResumeFile downloads the rest of url into dest, which holds the first
+	// offset bytes of the file identified by partial. When the remote file
+	// has changed or the server ignores the range, dest is rewound and the
+	// whole file is downloaded. It returns the size of the complete file.
+	ResumeFile(ctx context.Context, url string, offset int64, partial Validators, dest io.Writer) (int64, Validators, error)
+}
+
+// PartialDownloadError is returned when a transfer is interrupted after
+// writing a prefix of the file to dest. Validators identify that file for
+// a later ResumeFile.
+type PartialDownloadError struct {
+	Written    int64
+	Validators Validators
+	Err        error
+}
+
+func (e *PartialDownloadError) Error() string {
+	return fmt.Sprintf("%v (%d bytes received)", e.Err, e.Written)
+}
+
+func (e *PartialDownloadError) Unwrap() error {
+	return e.Err
+}
+
+// CanResume reports whether a partial file with these validators can be
+// resumed safely. If-Range needs a strong ETag or a Last-Modified date.
+func (v Validators) CanResume() bool {
+	return (v.ETag != "" && !strings.HasPrefix(v.ETag, "W/")) || v.LastModified != ""
+}
+
+// applyIfRange makes a ranged request fall back to the full file when it no
+// longer matches v.
+func (v Validators) applyIfRange(req *http.Request) {
+	if v.ETag != "" && !strings.HasPrefix(v.ETag, "W/") {
+		req.Header.Set("If-Range", v.ETag)
+	} else if v.LastModified != "" {
+		req.Header.Set("If-Range", v.LastModified)
+	}
+}
+
+// interrupted wraps a transfer error as a PartialDownloadError when the
+// bytes already written to dest can be resumed.
+func interrupted(err error, written int64, v Validators) error {
+	if written <= 0 || !v.CanResume() {
+		return err
+	}
+	return &PartialDownloadError{Written: written, Validators: v, Err: err}
+}
+
+func (c *HTTPClient) ResumeFile(ctx context.Context, url string, offset int64, partial Validators, dest io.Writer) (int64, Validators, error) {
+	if offset <= 0 || !partial.CanResume() || !canRewind(dest) {
+		if err := rewind(dest); err != nil {
+			return 0, Validators{}, fmt.Errorf("resetting destination: %w", err)
+		}
+		return c.downloadFrom(ctx, url, Validators{}, 0, Validators{}, dest)
+	}
+
+	// Drop anything past the known prefix and append from there
+	f := dest.(truncateSeeker)
+	if err := f.Truncate(offset); err != nil {
+		return 0, Validators{}, fmt.Errorf("truncating partial file: %w", err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, Validators{}, fmt.Errorf("seeking partial file: %w", err)
+	}
+	return c.downloadFrom(ctx, url, Validators{}, offset, partial, dest)
+}
+
+// downloadRange fetches url from offset onwards into dest, which holds the
+// first offset bytes of the file identified by partial. A server that sends
+// the full file instead restarts the download from the beginning. The
+// complete file is checked against an advertised whole-file MD5 when dest can
+// be read back. The size returned is that of the complete file.
+func (c *HTTPClient) downloadRange(ctx context.Context, url string, offset int64, partial Validators, dest io.Writer) (int64, Validators, error) {
+	guard, stop := newStallGuard(ctx, c.timeouts.DownloadIdle)
+	defer stop()
+
+	req, err := http.NewRequestWithContext(guard.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, Validators{}, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	partial.applyIfRange(req)
+
+	resp, err := c.downloads.Do(req)
+	if err != nil {
+		return 0, Validators{}, interrupted(fmt.Errorf("executing request: %w", guard.err(err)), offset, partial)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The file changed or ranges are unsupported: take the full body
+		c.logger.Info("cannot resume download, starting over", zap.Int64("offset", offset))
+		if err := rewind(dest); err != nil {
+			return 0, Validators{}, fmt.Errorf("resetting destination: %w", err)
+		}
+		return c.readBody(guard, resp, dest)
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is no shorter than the remote one, so it is not a
+		// prefix of it
+		if err := rewind(dest); err != nil {
+			return 0, Validators{}, fmt.Errorf("resetting destination: %w", err)
+		}
+		return 0, Validators{}, fmt.Errorf("%w: range from %d not satisfiable", ErrIncompleteDownload, offset)
+	default:
+		return 0, Validators{}, interrupted(fmt.Errorf("unexpected status: %d", resp.StatusCode), offset, partial)
+	}
+
+	start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+	if !ok || start != offset {
+		if err := rewind(dest); err != nil {
+			return 0, Validators{}, fmt.Errorf("resetting destination: %w", err)
+		}
+		return 0, Validators{}, fmt.Errorf("%w: unexpected Content-Range %q", ErrIncompleteDownload, resp.Header.Get("Content-Range"))
+	}
+
+	validators := validatorsFrom(resp)
+	if validators.IsZero() {
+		validators = partial
+	}
+
+	n, err := io.Copy(dest, guard.reader(resp.Body))
+	if err = guard.err(err); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			err = fmt.Errorf("%w: %v", ErrIncompleteDownload, err)
+		}
+		return offset + n, Validators{}, interrupted(err, offset+n, validators)
+	}
+	if total >= 0 && offset+n != total {
+		err := fmt.Errorf("%w: got %d of %d bytes", ErrIncompleteDownload, offset+n, total)
+		return offset + n, Validators{}, interrupted(err, offset+n, validators)
+	}
+	// The digest covers the whole file, partial prefix included
+	if err := verifyFileMD5(dest, offset+n, expectedObjectMD5(resp.Header)); err != nil {
+		return offset + n, Validators{}, err
+	}
+	return offset + n, validators, nil
+}
+
+// readBody streams a full-file response into dest, verifying its length and
+// any advertised MD5.
+func (c *HTTPClient) readBody(guard *stallGuard, resp *http.Response, dest io.Writer) (int64, Validators, error) {
+	// Stream to destination, hashing when the server advertises a digest
+	var out io.Writer = dest
+	wantMD5 := expectedMD5(resp.Header)
+	hasher := md5.New()
+	if wantMD5 != nil {
+		out = io.MultiWriter(dest, hasher)
+	}
+
+	validators := validatorsFrom(resp)
+	size, err := io.Copy(out, guard.reader(resp.Body))
+	if err = guard.err(err); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			err = fmt.Errorf("%w: %v", ErrIncompleteDownload, err)
+		}
+		return size, Validators{}, interrupted(err, size, validators)
+	}
+	if err := verifyTransfer(size, resp.ContentLength, wantMD5, hasher); err != nil {
+		if errors.Is(err, ErrIncompleteDownload) {
+			err = interrupted(err, size, validators)
+		}
+		return size, Validators{}, err
+	}
+	return size, validators, nil
+}
+
+// parseContentRange parses "bytes start-end/total". total is -1 when the
+// server does not know it.
+func parseContentRange(cr string) (start, total int64, ok bool) {
+	rng, ok := strings.CutPrefix(cr, "bytes ")
+	if !ok {
+		return 0, 0, false
+	}
+	span, size, ok := strings.Cut(rng, "/")
+	if !ok {
+		return 0, 0, false
+	}
+	first, _, ok := strings.Cut(span, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, false
+	}
+	if size == "*" {
+		return start, -1, true
+	}
+	total, err = strconv.ParseInt(size, 10, 64)
+	if err != nil || total < 0 {
+		return 0, 0, false
+	}
+	return start, total, true
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// errRangeUnsupported means the server ignored the Range header, so the file
+// must be fetched as a single stream.
+var errRangeUnsupported = errors.New("range requests not supported")
+
+// errFileChanged means the file was republished while its segments were
+// being fetched. Retrying the segment cannot help; the whole download is
+// restarted instead.
+var errFileChanged = errors.New("file changed during segmented download")
+
+// SegmentOptions controls parallel ranged downloads of large files.
+type SegmentOptions struct {
+	Segments int   // parallel ranged GETs per file; <= 1 disables segmenting
+	MinSize  int64 // files smaller than this are streamed in one request
+}
+
+// WithSegmentedDownload splits downloads of at least opts.MinSize bytes into
+// opts.Segments parallel ranged GETs when the destination supports
+// io.WriterAt (such as the staging temp file). Servers that ignore Range fall
+// back to a single stream.
+func WithSegmentedDownload(opts SegmentOptions) ClientOption {
+	return func(o *clientOptions) {
+		o.segments = opts
+	}
+}
+
+// probeSize asks for the first byte of url and returns the total size from
+// Content-Range along with the file's validators and advertised MD5 (nil when
+// none). errRangeUnsupported is returned when the server replies with the
+// full body instead of a partial one, and ErrNotModified when the file still
+// matches prev.
+func (c *HTTPClient) probeSize(ctx context.Context, url string, prev Validators) (int64, Validators, []byte, error) {
+	guard, stop := newStallGuard(ctx, c.timeouts.DownloadIdle)
+	defer stop()
+
+	req, err := http.NewRequestWithContext(guard.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, Validators{}, nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Range", "bytes=0-0")
+	prev.apply(req)
+
+	resp, err := c.downloads.Do(req)
+	if err != nil {
+		return 0, Validators{}, nil, fmt.Errorf("executing request: %w", guard.err(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusNotModified:
+		return 0, prev, nil, ErrNotModified
+	case http.StatusOK:
+		return 0, Validators{}, nil, errRangeUnsupported
+	default:
+		return 0, Validators{}, nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	// Content-Range: bytes 0-0/12345
+	cr := resp.Header.Get("Content-Range")
+	_, total, ok := strings.Cut(cr, "/")
+	if !ok || total == "*" {
+		return 0, Validators{}, nil, errRangeUnsupported
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil || size < 0 {
+		return 0, Validators{}, nil, fmt.Errorf("invalid Content-Range %q", cr)
+	}
+	return size, validatorsFrom(resp), expectedObjectMD5(resp.Header), nil
+}
+
+// downloadSegmented fetches url as parallel byte ranges written at their
+// offsets in dest. Each segment is retried independently. Segments are tied
+// to the version identified by version with If-Range, so a file republished
+// mid-download is never stitched together from two versions. The assembled
+// file is checked against wantMD5 when the server advertised one.
+func (c *HTTPClient) downloadSegmented(ctx context.Context, url string, dest io.WriterAt, size int64, version Validators, wantMD5 []byte) (int64, error) {
+	segments := int64(c.segments.Segments)
+	segSize := (size + segments - 1) / segments
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for start := int64(0); start < size; start += segSize {
+		end := min(start+segSize, size) - 1
+		wg.Add(1)
+		go func(start, end int64) {
+			defer wg.Done()
+			if err := c.downloadSegmentWithRetry(ctx, url, dest, start, end, version); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	if errors.Is(firstErr, errFileChanged) {
+		return 0, fmt.Errorf("%w: %w", ErrIncompleteDownload, firstErr)
+	}
+	if firstErr != nil {
+		return 0, firstErr
+	}
+	if err := verifyFileMD5(dest, size, wantMD5); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func (c *HTTPClient) downloadSegmentWithRetry(ctx context.Context, url string, dest io.WriterAt, start, end int64, version Validators) error {
+	var lastErr error
+	for attempt := 0; attempt <= c.retryCount; attempt++ {
+		if attempt > 0 {
+			delay := c.retryDelay * time.Duration(1<<(attempt-1)) // Exponential backoff
+			c.logger.Debug("retrying segment",
+				zap.Int64("start", start),
+				zap.Int64("end", end),
+				zap.Int("attempt", attempt),
+				zap.Error(lastErr))
+			c.retries.Add(1)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		lastErr = c.downloadSegment(ctx, url, dest, start, end, version)
+		if lastErr == nil || errors.Is(lastErr, errFileChanged) {
+			return lastErr
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return fmt.Errorf("segment %d-%d: %w", start, end, lastErr)
+}
+
+func (c *HTTPClient) downloadSegment(ctx context.Context, url string, dest io.WriterAt, start, end int64, version Validators) error {
+	guard, stop := newStallGuard(ctx, c.timeouts.DownloadIdle)
+	defer stop()
+
+	req, err := http.NewRequestWithContext(guard.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	version.applyIfRange(req)
+
+	resp, err := c.downloads.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", guard.err(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// If-Range answers with the whole file once it no longer matches
+	if resp.StatusCode == http.StatusOK && version.CanResume() {
+		return errFileChanged
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	cr := resp.Header.Get("Content-Range")
+	if first, _, ok := parseContentRange(cr); !ok || first != start {
+		return fmt.Errorf("%w: unexpected Content-Range %q for segment at %d", ErrIncompleteDownload, cr, start)
+	}
+
+	want := end - start + 1
+	n, err := io.Copy(io.NewOffsetWriter(dest, start), io.LimitReader(guard.reader(resp.Body), want))
+	if err != nil {
+		return guard.err(err)
+	}
+	if n != want {
+		return fmt.Errorf("%w: segment got %d of %d bytes", ErrIncompleteDownload, n, want)
+	}
+	return nil
+}
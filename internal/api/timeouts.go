@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	defaultConnectTimeout      = 10 * time.Second
+	defaultHeaderTimeout       = 30 * time.Second
+	defaultDownloadIdleTimeout = 60 * time.Second
+)
+
+// Timeouts separates the phases of a request. Zero fields use the defaults
+// (10s connect, 30s response headers, 60s download idle).
+type Timeouts struct {
+	Connect        time.Duration // TCP connect and TLS handshake
+	ResponseHeader time.Duration // waiting for response headers after sending the request
+	DownloadIdle   time.Duration // longest gap without body bytes during a file download
+}
+
+func (t Timeouts) withDefaults() Timeouts {
+	if t.Connect <= 0 {
+		t.Connect = defaultConnectTimeout
+	}
+	if t.ResponseHeader <= 0 {
+		t.ResponseHeader = defaultHeaderTimeout
+	}
+	if t.DownloadIdle <= 0 {
+		t.DownloadIdle = defaultDownloadIdleTimeout
+	}
+	return t
+}
+
+// WithTimeouts sets per-phase timeouts. The overall timeout passed to
+// NewClient only bounds URL lookups; file downloads have no total limit and
+// are instead aborted when no data arrives for DownloadIdle.
+func WithTimeouts(t Timeouts) ClientOption {
+	return func(o *clientOptions) {
+		o.timeouts = t
+	}
+}
+
+// stallGuard cancels a download's context when its body makes no progress
+// for the idle timeout.
+type stallGuard struct {
+	ctx   context.Context
+	timer *time.Timer
+	idle  time.Duration
+}
+
+func newStallGuard(ctx context.Context, idle time.Duration) (*stallGuard, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &stallGuard{
+		ctx:  ctx,
+		idle: idle,
+		timer: time.AfterFunc(idle, func() {
+			cancel(ErrDownloadStalled)
+		}),
+	}
+	return g, func() {
+		g.timer.Stop()
+		cancel(nil)
+	}
+}
+
+// reader wraps r so every read that returns data pushes the deadline back.
+func (g *stallGuard) reader(r io.Reader) io.Reader {
+	return &progressReader{r: r, guard: g}
+}
+
+// err replaces a cancellation caused by the guard with ErrDownloadStalled.
+func (g *stallGuard) err(err error) error {
+	if err != nil && errors.Is(context.Cause(g.ctx), ErrDownloadStalled) {
+		return fmt.Errorf("%w: no data for %s", ErrDownloadStalled, g.idle)
+	}
+	return err
+}
+
+type progressReader struct {
+	r     io.Reader
+	guard *stallGuard
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.guard.timer.Reset(p.guard.idle)
+	}
+	return n, err
+}
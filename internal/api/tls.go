@@ -0,0 +1,63 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSOptions configures the client transport for TLS-intercepting networks.
+type TLSOptions struct {
+	CAFile             string // PEM bundle appended to the system roots
+	ClientCertFile     string // PEM client certificate (requires ClientKeyFile)
+	ClientKeyFile      string // PEM client private key
+	InsecureSkipVerify bool   // disable server certificate verification (testing only)
+}
+
+// IsZero reports whether no TLS customization was requested.
+func (o TLSOptions) IsZero() bool {
+	return o == TLSOptions{}
+}
+
+// LoadTLSConfig builds a tls.Config from the given options.
+// Returns nil when no options are set so the default transport config is used.
+func LoadTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	if opts.IsZero() {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify, // #nosec G402 -- explicit opt-in
+	}
+
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", opts.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if opts.ClientCertFile != "" || opts.ClientKeyFile != "" {
+		if opts.ClientCertFile == "" || opts.ClientKeyFile == "" {
+			return nil, errors.New("client_cert_file and client_key_file must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// Validators identify a specific version of a remote file so later
+// downloads can be made conditional on it having changed.
+type Validators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// IsZero reports whether no validator is known.
+func (v Validators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// apply adds If-None-Match/If-Modified-Since headers for known validators.
+func (v Validators) apply(req *http.Request) {
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+func validatorsFrom(resp *http.Response) Validators {
+	return Validators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+}
+
+// ConditionalClient is implemented by clients that can skip transferring
+// files that have not changed since a previous download.
+type ConditionalClient interface {
+	DownloadFileConditional(ctx context.Context, url string, prev Validators, dest io.Writer) (int64, Validators, error)
+}
+
+// RetryCounter is implemented by clients that count retried requests.
+type RetryCounter interface {
+	Retries() int64
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// expectedMD5 returns the MD5 digest advertised by the response, from
+// Content-MD5 or a GCS-style "x-goog-hash: md5=..." header. It returns nil
+// when no usable digest is present.
+func expectedMD5(h http.Header) []byte {
+	if v := h.Get("Content-MD5"); v != "" {
+		if sum, err := base64.StdEncoding.DecodeString(v); err == nil && len(sum) == 16 {
+			return sum
+		}
+	}
+	return expectedObjectMD5(h)
+}
+
+// expectedObjectMD5 returns the MD5 digest of the whole file from a
+// GCS-style "x-goog-hash: md5=..." header, which partial responses carry
+// too, unlike Content-MD5 that covers only the body sent. It returns nil
+// when no usable digest is present.
+func expectedObjectMD5(h http.Header) []byte {
+	for _, header := range h.Values("X-Goog-Hash") {
+		for _, part := range strings.Split(header, ",") {
+			name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if !ok || name != "md5" {
+				continue
+			}
+			if sum, err := base64.StdEncoding.DecodeString(value); err == nil && len(sum) == 16 {
+				return sum
+			}
+		}
+	}
+	return nil
+}
+
+// verifyTransfer checks the bytes received against Content-Length (when
+// known, i.e. >= 0) and the advertised MD5 (when non-nil).
+func verifyTransfer(size, contentLength int64, wantMD5 []byte, hasher hash.Hash) error {
+	if contentLength >= 0 && size != contentLength {
+		return fmt.Errorf("%w: got %d of %d bytes", ErrIncompleteDownload, size, contentLength)
+	}
+	if wantMD5 != nil && !bytes.Equal(hasher.Sum(nil), wantMD5) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+// verifyFileMD5 checks the first size bytes written to dest against wantMD5
+// (when non-nil), for files assembled from ranges. Destinations that cannot
+// be read back are not checked.
+func verifyFileMD5(dest any, size int64, wantMD5 []byte) error {
+	if wantMD5 == nil {
+		return nil
+	}
+	r, ok := dest.(io.ReaderAt)
+	if !ok {
+		return nil
+	}
+	hasher := md5.New()
+	if _, err := io.Copy(hasher, io.NewSectionReader(r, 0, size)); err != nil {
+		return fmt.Errorf("reading back download: %w", err)
+	}
+	if !bytes.Equal(hasher.Sum(nil), wantMD5) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+// isRetryableTransfer reports whether err means the body stalled, arrived
+// incomplete or was corrupted, which is worth retrying.
+func isRetryableTransfer(err error) bool {
+	return errors.Is(err, ErrIncompleteDownload) ||
+		errors.Is(err, ErrChecksumMismatch) ||
+		errors.Is(err, ErrDownloadStalled)
+}
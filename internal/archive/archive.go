@@ -0,0 +1,208 @@
+// Package archive mirrors committed date directories to object storage (S3,
+// GCS or Azure Blob), so the downloader can feed an archival bucket next to
+// its local output.
+package archive
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/staging"
+)
+
+// IndexFile records what was mirrored where, in the output directory, so
+// files are only uploaded again when they change.
+const IndexFile = ".archive.json"
+
+// Entry is the mirrored version of one file.
+type Entry struct {
+	Size int64  `json:"size"`
+	MD5  string `json:"md5"` // hex
+}
+
+// Index maps keys (date/ticker/package/file) to what was uploaded.
+type Index struct {
+	path        string
+	Destination string           `json:"destination"`
+	Files       map[string]Entry `json:"files"`
+}
+
+// LoadIndex reads the index of the output directory root for destination.
+// An index kept for another destination starts over.
+func LoadIndex(root, destination string) (*Index, error) {
+	idx := &Index{path: filepath.Join(root, IndexFile)}
+	data, err := os.ReadFile(idx.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, idx); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", IndexFile, err)
+		}
+	}
+	if idx.Destination != destination || idx.Files == nil {
+		idx.Destination = destination
+		idx.Files = make(map[string]Entry)
+	}
+	return idx, nil
+}
+
+// Save writes the index atomically.
+func (i *Index) Save() error {
+	data, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := i.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, i.path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Result summarizes a mirrored date.
+type Result struct {
+	Files    int   // files in the date directory
+	Uploaded int   // files uploaded because they were new or changed
+	Bytes    int64 // bytes uploaded
+}
+
+// Archiver uploads date directories to storage.
+type Archiver struct {
+	storage     staging.Storage
+	concurrency int
+	verify      bool
+}
+
+// New returns an archiver uploading concurrency files at a time. With
+// verify, storage that supports it checks each upload against its MD5.
+func New(storage staging.Storage, concurrency int, verify bool) *Archiver {
+	return &Archiver{storage: storage, concurrency: max(concurrency, 1), verify: verify}
+}
+
+// file is a file of a date directory to mirror.
+type file struct {
+	path string
+	key  string
+}
+
+// Date uploads the files of root/date that are not in idx as they are now,
+// and records them in idx. Links left by dedupe are uploaded as the content
+// they point at.
+func (a *Archiver) Date(ctx context.Context, root, date string, idx *Index) (Result, error) {
+	var result Result
+	var files []file
+	dateDir := filepath.Join(root, date)
+	err := filepath.Walk(dateDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			if info, err = os.Stat(path); err != nil {
+				return err
+			}
+		}
+		if !info.Mode().IsRegular() || strings.HasSuffix(path, ".tmp") || strings.HasSuffix(path, ".dedupe") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, file{path: path, key: filepath.ToSlash(rel)})
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Files = len(files)
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	jobs := make(chan file)
+	for i := 0; i < a.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range jobs {
+				uploaded, entry, err := a.upload(ctx, f, idx, &mu)
+				mu.Lock()
+				switch {
+				case err != nil:
+					errs = append(errs, fmt.Errorf("%s: %w", f.key, err))
+				case uploaded:
+					idx.Files[f.key] = entry
+					result.Uploaded++
+					result.Bytes += entry.Size
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, f := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- f
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return result, errors.Join(errs...)
+}
+
+// upload uploads f unless idx shows it unchanged. mu guards idx.
+func (a *Archiver) upload(ctx context.Context, f file, idx *Index, mu *sync.Mutex) (bool, Entry, error) {
+	entry, sum, err := hashFile(f.path)
+	if err != nil {
+		return false, entry, err
+	}
+	mu.Lock()
+	prev, ok := idx.Files[f.key]
+	mu.Unlock()
+	if ok && prev == entry {
+		return false, entry, nil
+	}
+
+	if uploader, ok := a.storage.(staging.ChecksumUploader); ok && a.verify {
+		err = uploader.UploadChecksum(ctx, f.path, f.key, sum)
+	} else {
+		err = a.storage.Upload(ctx, f.path, f.key)
+	}
+	return err == nil, entry, err
+}
+
+// hashFile returns the size and MD5 of the file at path.
+func hashFile(path string) (Entry, []byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Entry{}, nil, err
+	}
+	defer func() { _ = f.Close() }()
+	h := md5.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return Entry{}, nil, err
+	}
+	sum := h.Sum(nil)
+	return Entry{Size: n, MD5: hex.EncodeToString(sum)}, sum, nil
+}
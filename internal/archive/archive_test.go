@@ -0,0 +1,110 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// memStorage keeps uploads in memory and records which were checksummed.
+type memStorage struct {
+	mu       sync.Mutex
+	objects  map[string]string
+	verified map[string]bool
+}
+
+func (m *memStorage) Exists(_ context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.objects[key]
+	return ok, nil
+}
+
+func (m *memStorage) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return io.NopCloser(strings.NewReader(m.objects[key])), nil
+}
+
+func (m *memStorage) Upload(_ context.Context, src, key string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = string(data)
+	return nil
+}
+
+func (m *memStorage) UploadChecksum(ctx context.Context, src, key string, md5sum []byte) error {
+	if err := m.Upload(ctx, src, key); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verified[key] = len(md5sum) == 16
+	return nil
+}
+
+func (m *memStorage) Rename(context.Context, string, string) error { return nil }
+func (m *memStorage) Delete(context.Context, string) error         { return nil }
+func (m *memStorage) String() string                               { return "mem://archive" }
+
+func TestArchiverDate(t *testing.T) {
+	root := t.TempDir()
+	for rel, content := range map[string]string{
+		"2025-11-14/SPX/state/gex_zero.jsonl":   "zero",
+		"2025-11-14/SPX/state/gex_full.jsonl":   "full",
+		"2025-11-14/manifest.json":              "{}",
+		"2025-11-14/SPX/state/gex_one.json.tmp": "partial",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	storage := &memStorage{objects: make(map[string]string), verified: make(map[string]bool)}
+	archiver := New(storage, 2, true)
+	mirror := func() Result {
+		t.Helper()
+		idx, err := LoadIndex(root, storage.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := archiver.Date(context.Background(), root, "2025-11-14", idx)
+		if err != nil {
+			t.Fatalf("Date() error = %v", err)
+		}
+		if err := idx.Save(); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	if result := mirror(); result.Files != 3 || result.Uploaded != 3 || result.Bytes != 10 {
+		t.Errorf("first mirror = %+v", result)
+	}
+	if got := storage.objects["2025-11-14/SPX/state/gex_zero.jsonl"]; got != "zero" || !storage.verified["2025-11-14/SPX/state/gex_zero.jsonl"] {
+		t.Errorf("gex_zero mirrored as %q, verified %v", got, storage.verified["2025-11-14/SPX/state/gex_zero.jsonl"])
+	}
+
+	// Only changed files are uploaded again
+	if result := mirror(); result.Uploaded != 0 {
+		t.Errorf("unchanged mirror uploaded %d files", result.Uploaded)
+	}
+	if err := os.WriteFile(filepath.Join(root, "2025-11-14/SPX/state/gex_full.jsonl"), []byte("fuller"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if result := mirror(); result.Uploaded != 1 || storage.objects["2025-11-14/SPX/state/gex_full.jsonl"] != "fuller" {
+		t.Errorf("changed mirror = %+v", result)
+	}
+}
@@ -0,0 +1,239 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// writeBuffer is the number of entries queued for the file writer. Entries
+// beyond it are dropped from the file, but still kept in memory.
+const writeBuffer = 1 << 14
+
+// Entry kinds
+const (
+	KindREST   = "rest"
+	KindWSJoin = "ws_join"
+)
+
+// Entry is a single audit record. API keys are never stored in clear text.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	APIKey   string    `json:"api_key"`  // masked (first 4 chars)
+	KeyHash  string    `json:"key_hash"` // short SHA-256 prefix for exact filtering
+	Endpoint string    `json:"endpoint"`
+	Index    *int      `json:"index,omitempty"`
+	Status   int       `json:"status,omitempty"`
+}
+
+// Config controls where and how much audit data is kept.
+type Config struct {
+	FilePath   string // JSONL audit file path
+	MaxSizeMB  int    // rotate once the file exceeds this size
+	MaxBackups int    // number of rotated files to keep (file.1 .. file.N)
+	BufferSize int    // recent entries kept in memory for queries
+}
+
+// Logger records audit entries to a rotating JSONL file and an in-memory ring
+// buffer. The file is written by a background writer, so requests never wait
+// on disk.
+type Logger struct {
+	writer *rotatingWriter
+	logger *zap.Logger
+
+	entries chan Entry
+	dropped atomic.Int64 // entries the file writer could not keep up with
+	stop    chan struct{}
+	done    chan struct{}
+	stopped sync.Once
+
+	mu     sync.RWMutex
+	ring   []Entry
+	next   int
+	filled bool
+}
+
+// NewLogger opens the audit file and returns a ready Logger.
+func NewLogger(cfg Config, logger *zap.Logger) (*Logger, error) {
+	writer, err := newRotatingWriter(cfg.FilePath, int64(cfg.MaxSizeMB)*1024*1024, cfg.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	bufferSize := cfg.BufferSize
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+
+	l := &Logger{
+		writer:  writer,
+		logger:  logger,
+		entries: make(chan Entry, writeBuffer),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		ring:    make([]Entry, bufferSize),
+	}
+	go l.run()
+	return l, nil
+}
+
+// Record stores an entry. apiKey is masked and hashed before being kept.
+func (l *Logger) Record(kind, apiKey, endpoint string, index *int, status int) {
+	entry := Entry{
+		Time:     time.Now().UTC(),
+		Kind:     kind,
+		APIKey:   MaskKey(apiKey),
+		KeyHash:  HashKey(apiKey),
+		Endpoint: endpoint,
+		Index:    index,
+		Status:   status,
+	}
+
+	l.mu.Lock()
+	l.ring[l.next] = entry
+	l.next = (l.next + 1) % len(l.ring)
+	if l.next == 0 {
+		l.filled = true
+	}
+	l.mu.Unlock()
+
+	select {
+	case l.entries <- entry:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// run writes queued entries to the file until Close, flushing whenever the
+// queue is empty.
+func (l *Logger) run() {
+	defer close(l.done)
+	for {
+		select {
+		case entry := <-l.entries:
+			l.write(entry)
+			if len(l.entries) == 0 {
+				l.flush()
+			}
+		case <-l.stop:
+			for len(l.entries) > 0 {
+				l.write(<-l.entries)
+			}
+			l.flush()
+			return
+		}
+	}
+}
+
+func (l *Logger) write(entry Entry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := l.writer.WriteLine(line); err != nil {
+		l.logger.Warn("failed to write audit entry", zap.Error(err))
+	}
+}
+
+func (l *Logger) flush() {
+	if err := l.writer.Flush(); err != nil {
+		l.logger.Warn("failed to write audit file", zap.Error(err))
+	}
+	if n := l.dropped.Swap(0); n > 0 {
+		l.logger.Warn("audit file writer fell behind, entries dropped", zap.Int64("dropped", n))
+	}
+}
+
+// RecordJoin records a WebSocket group join. Implements ws.JoinRecorder.
+func (l *Logger) RecordJoin(apiKey, hub, group string, accepted bool) {
+	status := 200
+	if !accepted {
+		status = 400
+	}
+	l.Record(KindWSJoin, apiKey, hub+"/"+group, nil, status)
+}
+
+// Query returns the most recent entries (newest first), optionally filtered by API key.
+func (l *Logger) Query(apiKey string, limit int) []Entry {
+	hash := ""
+	if apiKey != "" {
+		hash = HashKey(apiKey)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	size := l.next
+	if l.filled {
+		size = len(l.ring)
+	}
+
+	result := []Entry{}
+	for i := 0; i < size && (limit <= 0 || len(result) < limit); i++ {
+		idx := (l.next - 1 - i + len(l.ring)) % len(l.ring)
+		entry := l.ring[idx]
+		if hash != "" && entry.KeyHash != hash {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// Close writes the queued entries and closes the audit file. Entries
+// recorded afterwards are only kept in memory.
+func (l *Logger) Close() error {
+	l.stopped.Do(func() { close(l.stop) })
+	<-l.done
+	return l.writer.Close()
+}
+
+// MaskKey masks all but the first 4 characters of an API key.
+func MaskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return key[:4] + "****"
+}
+
+// HashKey returns a short, stable, non-reversible identifier for an API key.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:6])
+}
+
+// contextKey is the private type for request-scoped audit data.
+type contextKey struct{}
+
+// requestInfo carries handler-populated details back to the audit middleware.
+type requestInfo struct {
+	index *int
+}
+
+// WithRequest returns a context that handlers can annotate via SetIndex.
+func WithRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, &requestInfo{})
+}
+
+// SetIndex records the data index served for the current request.
+// No-op when the request is not being audited.
+func SetIndex(ctx context.Context, index int) {
+	if info, ok := ctx.Value(contextKey{}).(*requestInfo); ok {
+		info.index = &index
+	}
+}
+
+// indexFrom returns the index set by the handler, if any.
+func indexFrom(ctx context.Context) *int {
+	if info, ok := ctx.Value(contextKey{}).(*requestInfo); ok {
+		return info.index
+	}
+	return nil
+}
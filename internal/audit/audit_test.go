@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestLogger(t *testing.T) (*Logger, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := NewLogger(Config{FilePath: path, MaxSizeMB: 1, MaxBackups: 1, BufferSize: 10}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l, path
+}
+
+// readEntries returns the entries written to path.
+func readEntries(t *testing.T, path string) []Entry {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("audit line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestMiddleware(t *testing.T) {
+	l, path := newTestLogger(t)
+	handler := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetIndex(r.Context(), 7)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/SPX/classic/full?key=secret-key", nil))
+	// Without an API key there is nothing to attribute the request to
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tickers", nil))
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	entries := readEntries(t, path)
+	if len(entries) != 1 {
+		t.Fatalf("entries = %+v, want one", entries)
+	}
+	e := entries[0]
+	if e.Kind != KindREST || e.APIKey != "secr****" || e.KeyHash != HashKey("secret-key") ||
+		e.Endpoint != "GET /SPX/classic/full" || e.Index == nil || *e.Index != 7 ||
+		e.Status != http.StatusNotFound || e.Time.IsZero() {
+		t.Errorf("entry = %+v", e)
+	}
+
+	if got := l.Query("secret-key", 0); len(got) != 1 || got[0].Endpoint != e.Endpoint {
+		t.Errorf("Query = %+v, want the entry", got)
+	}
+	if got := l.Query("other", 0); len(got) != 0 {
+		t.Errorf("Query(other) = %+v, want none", got)
+	}
+}
+
+func TestRecordJoin(t *testing.T) {
+	l, path := newTestLogger(t)
+	l.RecordJoin("key1", "orderflow", "blue_SPX_orderflow_orderflow", true)
+	l.RecordJoin("key1", "orderflow", "bad", false)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := readEntries(t, path)
+	if len(entries) != 2 || entries[0].Kind != KindWSJoin || entries[0].Status != 200 ||
+		entries[0].Endpoint != "orderflow/blue_SPX_orderflow_orderflow" || entries[1].Status != 400 {
+		t.Errorf("entries = %+v", entries)
+	}
+	// Newest first, capped by limit
+	if got := l.Query("", 1); len(got) != 1 || got[0].Endpoint != "orderflow/bad" {
+		t.Errorf("Query limit 1 = %+v", got)
+	}
+}
+
+func TestRecordAfterClose(t *testing.T) {
+	l, path := newTestLogger(t)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	l.Record(KindREST, "key1", "GET /tickers", nil, 200)
+
+	if len(readEntries(t, path)) != 0 {
+		t.Error("entry written after Close")
+	}
+	if len(l.Query("", 0)) != 1 {
+		t.Error("entry not kept in memory after Close")
+	}
+}
@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Middleware records every REST request that carries a "key" query parameter.
+// Handlers report the served data index via SetIndex on the request context.
+func (l *Logger) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey := r.URL.Query().Get("key")
+		if apiKey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := WithRequest(r.Context())
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		l.Record(KindREST, apiKey, r.Method+" "+r.URL.Path, indexFrom(ctx), status)
+	})
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// rotatingWriter appends lines to a file and rotates it by size.
+type rotatingWriter struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	w          *bufio.Writer
+	size       int64
+}
+
+func newRotatingWriter(path string, maxBytes int64, maxBackups int) (*rotatingWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("creating audit directory: %w", err)
+	}
+
+	w := &rotatingWriter{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *rotatingWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("opening audit file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("stat audit file: %w", err)
+	}
+	w.file = f
+	if w.w == nil {
+		w.w = bufio.NewWriter(f)
+	} else {
+		w.w.Reset(f)
+	}
+	w.size = info.Size()
+	return nil
+}
+
+// WriteLine buffers line plus a newline, rotating first if the size limit
+// would be exceeded. Flush writes the buffer to the file.
+func (w *rotatingWriter) WriteLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.maxBytes > 0 && w.size+int64(len(line))+1 > w.maxBytes && w.size > 0 {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.w.Write(append(line, '\n'))
+	w.size += int64(n)
+	return err
+}
+
+// Flush writes the buffered lines to the file.
+func (w *rotatingWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Flush()
+}
+
+// rotate shifts audit.jsonl -> audit.jsonl.1 -> ... -> audit.jsonl.N, dropping the oldest.
+func (w *rotatingWriter) rotate() error {
+	if err := w.w.Flush(); err != nil {
+		return fmt.Errorf("writing audit file: %w", err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("closing audit file: %w", err)
+	}
+
+	if w.maxBackups > 0 {
+		_ = os.Remove(fmt.Sprintf("%s.%d", w.path, w.maxBackups))
+		for i := w.maxBackups - 1; i >= 1; i-- {
+			_ = os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1))
+		}
+		if err := os.Rename(w.path, w.path+".1"); err != nil {
+			return fmt.Errorf("rotating audit file: %w", err)
+		}
+	} else if err := os.Remove(w.path); err != nil {
+		return fmt.Errorf("truncating audit file: %w", err)
+	}
+
+	return w.open()
+}
+
+// Close flushes and closes the underlying file.
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	flushErr := w.w.Flush()
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	return flushErr
+}
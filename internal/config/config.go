@@ -3,9 +3,15 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/cron"
 )
 
 type Config struct {
@@ -15,36 +21,186 @@ type Config struct {
 	Packages PackagesConfig `mapstructure:"packages"`
 	Output   OutputConfig   `mapstructure:"output"`
 	Logging  LoggingConfig  `mapstructure:"logging"`
+	Metrics  MetricsConfig  `mapstructure:"metrics"`
+	Archive  ArchiveConfig  `mapstructure:"archive"`
+
+	// TickerCalendars sets the trading calendar (nyse or cme) of tickers
+	// whose default from TickerCalendar is wrong.
+	TickerCalendars map[string]string `mapstructure:"ticker_calendars"`
+
+	// TickerSchedules sets when the daemon downloads tickers, by ticker or
+	// calendar, instead of with the rest of their schedule's tickers.
+	TickerSchedules map[string]TickerSchedule `mapstructure:"ticker_schedules"`
+}
+
+// TickerSchedule is a daemon schedule for the tickers of a ticker_schedules
+// entry.
+type TickerSchedule struct {
+	Schedule string `mapstructure:"schedule"` // cron expression
+	Timezone string `mapstructure:"timezone"` // empty: that of the schedule it overrides
 }
 
 type APIConfig struct {
-	BaseURL    string `mapstructure:"base_url"`
-	APIKey     string `mapstructure:"api_key"`
-	TimeoutSec int    `mapstructure:"timeout_sec"`
-	RetryCount int    `mapstructure:"retry_count"`
-	RetryDelay int    `mapstructure:"retry_delay_sec"`
+	BaseURL    string    `mapstructure:"base_url"`
+	APIKey     string    `mapstructure:"api_key"`
+	TimeoutSec int       `mapstructure:"timeout_sec"` // total limit for URL lookups
+	RetryCount int       `mapstructure:"retry_count"`
+	RetryDelay int       `mapstructure:"retry_delay_sec"`
+	ProxyURL   string    `mapstructure:"proxy_url"` // http(s)/socks5 proxy; empty uses HTTP(S)_PROXY env
+	NoProxy    []string  `mapstructure:"no_proxy"`  // hosts bypassing the proxy, added to NO_PROXY
+	ProxyAuth  ProxyAuth `mapstructure:"proxy_auth"`
+	TLS        TLSConfig `mapstructure:"tls"`
+	Mirrors    []string  `mapstructure:"mirrors"` // ordered hosts serving historical files
+
+	ConnectTimeoutSec      int `mapstructure:"connect_timeout_sec"`       // TCP connect and TLS handshake
+	HeaderTimeoutSec       int `mapstructure:"header_timeout_sec"`        // wait for response headers
+	DownloadIdleTimeoutSec int `mapstructure:"download_idle_timeout_sec"` // abort downloads stalled this long
+}
+
+// ProxyAuth holds proxy credentials kept out of proxy_url, e.g. so the
+// password can come from GEXBOT_PROXY_PASSWORD.
+type ProxyAuth struct {
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// TLSConfig customizes certificate handling for TLS-intercepting middleboxes.
+type TLSConfig struct {
+	CAFile             string `mapstructure:"ca_file"`
+	ClientCertFile     string `mapstructure:"client_cert_file"`
+	ClientKeyFile      string `mapstructure:"client_key_file"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
 }
 
 type DownloadConfig struct {
-	Workers       int  `mapstructure:"workers"`
-	RatePerSecond int  `mapstructure:"rate_per_second"`
-	ResumeEnabled bool `mapstructure:"resume_enabled"`
+	Workers          int    `mapstructure:"workers"`
+	RatePerSecond    int    `mapstructure:"rate_per_second"`
+	ResumeEnabled    bool   `mapstructure:"resume_enabled"`
+	VerifyExisting   string `mapstructure:"verify_existing"`     // off, size or checksum: check files before skipping them
+	ValidatePayloads bool   `mapstructure:"validate_payloads"`   // quarantine downloads that do not match the data model
+	Segments         int    `mapstructure:"segments"`            // parallel ranged GETs per large file (1 = off)
+	SegmentMinSizeMB int    `mapstructure:"segment_min_size_mb"` // files below this are streamed in one request
+	DiskPreflight    bool   `mapstructure:"disk_preflight"`      // abort when staging lacks room for the estimated downloads
+	DiskHeadroomMB   int    `mapstructure:"disk_headroom_mb"`    // space the preflight keeps free beyond the estimate
 }
 
 type PackagesConfig struct {
-	State     PackageConfig `mapstructure:"state"`
-	Classic   PackageConfig `mapstructure:"classic"`
-	Orderflow PackageConfig `mapstructure:"orderflow"`
+	State      PackageConfig `mapstructure:"state"`
+	Classic    PackageConfig `mapstructure:"classic"`
+	Orderflow  PackageConfig `mapstructure:"orderflow"`
+	Volatility PackageConfig `mapstructure:"volatility"`
 }
 
 type PackageConfig struct {
-	Enabled    bool     `mapstructure:"enabled"`
-	Categories []string `mapstructure:"categories"`
+	Enabled       bool     `mapstructure:"enabled"`
+	Categories    []string `mapstructure:"categories"`
+	Workers       int      `mapstructure:"workers"`         // own worker pool; 0 shares download.workers
+	RatePerSecond int      `mapstructure:"rate_per_second"` // own URL lookup rate; 0 shares download.rate_per_second
+}
+
+// byName returns the packages keyed by their API name.
+func (p PackagesConfig) byName() map[string]PackageConfig {
+	return map[string]PackageConfig{
+		"state":      p.State,
+		"classic":    p.Classic,
+		"orderflow":  p.Orderflow,
+		"volatility": p.Volatility,
+	}
+}
+
+// PackageWorkers returns the worker pool size of each package that sets
+// its own workers.
+func (p PackagesConfig) PackageWorkers() map[string]int {
+	workers := make(map[string]int)
+	for name, pkg := range p.byName() {
+		if pkg.Workers > 0 {
+			workers[name] = pkg.Workers
+		}
+	}
+	return workers
+}
+
+// PackageRates returns the request rate of each package that sets its own
+// rate_per_second.
+func (p PackagesConfig) PackageRates() map[string]int {
+	rates := make(map[string]int)
+	for name, pkg := range p.byName() {
+		if pkg.RatePerSecond > 0 {
+			rates[name] = pkg.RatePerSecond
+		}
+	}
+	return rates
 }
 
 type OutputConfig struct {
-	Directory          string `mapstructure:"directory"`
+	Directory          string `mapstructure:"directory"`         // local path, s3://bucket/prefix, gs://bucket/prefix or azblob://account/container/prefix
+	StagingDirectory   string `mapstructure:"staging_directory"` // local staging for remote output
+	Format             string `mapstructure:"format"`            // json, jsonl, jsonl.zst or parquet; empty follows auto_convert_to_jsonl
 	AutoConvertToJSONL bool   `mapstructure:"auto_convert_to_jsonl"`
+	Dedupe             string `mapstructure:"dedupe"`              // off, hardlink or symlink: link files identical to an earlier download
+	PostDownloadHook   string `mapstructure:"post_download_hook"`  // shell command run with DATE and DIR after each date is committed
+	EncryptionKeyFile  string `mapstructure:"encryption_key_file"` // AES-256 key (base64 or hex) to encrypt committed JSONL files with
+}
+
+// Calendar returns the trading calendar of a ticker, honoring
+// ticker_calendars.
+func (c *Config) Calendar(ticker string) string {
+	return TickerCalendar(ticker, c.TickerCalendars)
+}
+
+// TickerScheduleFor returns the ticker_schedules entry of ticker and its key:
+// the ticker's own, else that of its calendar.
+func (c *Config) TickerScheduleFor(ticker string) (string, TickerSchedule, bool) {
+	for key, s := range c.TickerSchedules {
+		if strings.EqualFold(key, ticker) {
+			return strings.ToLower(key), s, true
+		}
+	}
+	cal := c.Calendar(ticker)
+	for key, s := range c.TickerSchedules {
+		if strings.EqualFold(key, cal) {
+			return cal, s, true
+		}
+	}
+	return "", TickerSchedule{}, false
+}
+
+// Remote reports whether the output directory is an object storage URI.
+func (o OutputConfig) Remote() bool {
+	return isStorageURI(o.Directory)
+}
+
+func isStorageURI(s string) bool {
+	return strings.HasPrefix(s, "s3://") || strings.HasPrefix(s, "gs://") || strings.HasPrefix(s, "azblob://")
+}
+
+// WorkDirectory returns the local directory downloads are staged in before
+// they are uploaded to remote output.
+func (o OutputConfig) WorkDirectory() string {
+	if o.StagingDirectory != "" {
+		return o.StagingDirectory
+	}
+	return filepath.Join(os.TempDir(), "gexbot-downloader")
+}
+
+// Output formats for downloaded files.
+const (
+	FormatJSON      = "json"      // files as downloaded
+	FormatJSONL     = "jsonl"     // one record per line, served by the faker
+	FormatJSONLZstd = "jsonl.zst" // zstd-compressed JSONL, also served by the faker
+	FormatParquet   = "parquet"   // columnar, for DuckDB/Arrow pipelines
+)
+
+// OutputFormat returns the configured format, falling back to
+// auto_convert_to_jsonl when output.format is unset.
+func (o OutputConfig) OutputFormat() string {
+	if o.Format != "" {
+		return o.Format
+	}
+	if o.AutoConvertToJSONL {
+		return FormatJSONL
+	}
+	return FormatJSON
 }
 
 type LoggingConfig struct {
@@ -53,7 +209,37 @@ type LoggingConfig struct {
 	Level     string `mapstructure:"level"`
 }
 
+// ArchiveConfig mirrors each committed date to object storage after it is
+// converted and deduped, e.g. for an archival bucket next to local output.
+type ArchiveConfig struct {
+	Destination string `mapstructure:"destination"` // s3://bucket/prefix, gs://bucket/prefix or azblob://account/container/prefix; empty disables
+	Concurrency int    `mapstructure:"concurrency"` // parallel uploads
+	Verify      bool   `mapstructure:"verify"`      // have the service check each upload against its MD5
+}
+
+// MetricsConfig controls where Prometheus metrics of download runs go.
+type MetricsConfig struct {
+	PushgatewayURL string `mapstructure:"pushgateway_url"` // push at the end of each run
+	Job            string `mapstructure:"job"`             // Pushgateway job label
+	Textfile       string `mapstructure:"textfile"`        // node_exporter textfile collector path
+}
+
 func Load(configPath string) (*Config, error) {
+	cfg, err := Read(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validating config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Read loads the config file, defaults and environment like Load, without
+// validating the result.
+func Read(configPath string) (*Config, error) {
 	v := viper.New()
 
 	// Set defaults
@@ -61,14 +247,43 @@ func Load(configPath string) (*Config, error) {
 	v.SetDefault("api.timeout_sec", 300)
 	v.SetDefault("api.retry_count", 3)
 	v.SetDefault("api.retry_delay_sec", 5)
+	v.SetDefault("api.proxy_url", "")
+	v.SetDefault("api.no_proxy", []string{})
+	v.SetDefault("api.proxy_auth.username", "")
+	v.SetDefault("api.proxy_auth.password", "")
+	v.SetDefault("api.tls.ca_file", "")
+	v.SetDefault("api.tls.client_cert_file", "")
+	v.SetDefault("api.tls.client_key_file", "")
+	v.SetDefault("api.tls.insecure_skip_verify", false)
+	v.SetDefault("api.connect_timeout_sec", 10)
+	v.SetDefault("api.header_timeout_sec", 30)
+	v.SetDefault("api.download_idle_timeout_sec", 60)
+	v.SetDefault("api.mirrors", []string{"hist.gex.bot", "hist.gexbot.com"})
 	v.SetDefault("download.workers", 3)
 	v.SetDefault("download.rate_per_second", 2)
 	v.SetDefault("download.resume_enabled", true)
+	v.SetDefault("download.verify_existing", "size")
+	v.SetDefault("download.validate_payloads", true)
+	v.SetDefault("download.segments", 4)
+	v.SetDefault("download.segment_min_size_mb", 64)
+	v.SetDefault("download.disk_preflight", true)
+	v.SetDefault("download.disk_headroom_mb", 512)
 	v.SetDefault("output.directory", "data")
+	v.SetDefault("output.staging_directory", "")
+	v.SetDefault("output.format", "")
 	v.SetDefault("output.auto_convert_to_jsonl", true)
+	v.SetDefault("output.dedupe", "off")
+	v.SetDefault("output.post_download_hook", "")
+	v.SetDefault("output.encryption_key_file", "")
 	v.SetDefault("logging.enabled", true)
 	v.SetDefault("logging.directory", "logs")
 	v.SetDefault("logging.level", "info")
+	v.SetDefault("metrics.pushgateway_url", "")
+	v.SetDefault("metrics.job", "gexbot_downloader")
+	v.SetDefault("metrics.textfile", "")
+	v.SetDefault("archive.destination", "")
+	v.SetDefault("archive.concurrency", 4)
+	v.SetDefault("archive.verify", true)
 
 	// Environment variable support
 	v.SetEnvPrefix("GEXBOT")
@@ -77,6 +292,12 @@ func Load(configPath string) (*Config, error) {
 
 	// Explicitly bind nested keys to env vars
 	_ = v.BindEnv("api.api_key", "GEXBOT_API_KEY")
+	_ = v.BindEnv("api.proxy_url", "GEXBOT_PROXY_URL")
+	_ = v.BindEnv("api.no_proxy", "GEXBOT_NO_PROXY")
+	_ = v.BindEnv("api.proxy_auth.username", "GEXBOT_PROXY_USERNAME")
+	_ = v.BindEnv("api.proxy_auth.password", "GEXBOT_PROXY_PASSWORD")
+	_ = v.BindEnv("api.tls.ca_file", "GEXBOT_CA_FILE")
+	_ = v.BindEnv("api.mirrors", "GEXBOT_MIRRORS")
 
 	// Load config file
 	if configPath != "" {
@@ -100,10 +321,6 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("unmarshaling config: %w", err)
 	}
 
-	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("validating config: %w", err)
-	}
-
 	return &cfg, nil
 }
 
@@ -114,5 +331,118 @@ func (c *Config) Validate() error {
 	if c.Download.Workers < 1 {
 		return fmt.Errorf("workers must be >= 1")
 	}
+	for name, pkg := range c.Packages.byName() {
+		if pkg.Workers < 0 || pkg.RatePerSecond < 0 {
+			return fmt.Errorf("packages.%s.workers and rate_per_second must be >= 0", name)
+		}
+	}
+	if c.API.ConnectTimeoutSec < 0 || c.API.HeaderTimeoutSec < 0 || c.API.DownloadIdleTimeoutSec < 0 {
+		return fmt.Errorf("timeouts must be >= 0")
+	}
+	switch c.Download.VerifyExisting {
+	case "off", "size", "checksum":
+	default:
+		return fmt.Errorf("verify_existing must be off, size or checksum")
+	}
+	for ticker, cal := range c.TickerCalendars {
+		switch strings.ToLower(cal) {
+		case CalendarNYSE, CalendarCME:
+		default:
+			return fmt.Errorf("ticker_calendars.%s must be nyse or cme", strings.ToUpper(ticker))
+		}
+	}
+	for key, s := range c.TickerSchedules {
+		if _, err := cron.Parse(s.Schedule); err != nil {
+			return fmt.Errorf("ticker_schedules.%s: %w", strings.ToUpper(key), err)
+		}
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("ticker_schedules.%s: unknown timezone %q", strings.ToUpper(key), s.Timezone)
+		}
+	}
+	if c.Download.Segments < 1 {
+		return fmt.Errorf("segments must be >= 1")
+	}
+	if c.Download.SegmentMinSizeMB < 0 {
+		return fmt.Errorf("segment_min_size_mb must be >= 0")
+	}
+	if c.Download.DiskHeadroomMB < 0 {
+		return fmt.Errorf("disk_headroom_mb must be >= 0")
+	}
+	if c.Output.Remote() {
+		_, rest, _ := strings.Cut(c.Output.Directory, "://")
+		if bucket, _, _ := strings.Cut(rest, "/"); bucket == "" {
+			return fmt.Errorf("output.directory %q has no bucket", c.Output.Directory)
+		}
+	}
+	switch c.Output.Format {
+	case "", FormatJSON, FormatJSONL, FormatJSONLZstd, FormatParquet:
+	default:
+		return fmt.Errorf("output.format must be json, jsonl, jsonl.zst or parquet")
+	}
+	switch c.Output.Dedupe {
+	case "off":
+	case "hardlink", "symlink":
+		if c.Output.Remote() {
+			return fmt.Errorf("output.dedupe is not supported for remote output")
+		}
+	default:
+		return fmt.Errorf("output.dedupe must be off, hardlink or symlink")
+	}
+	if c.Output.EncryptionKeyFile != "" {
+		switch {
+		case c.Output.Remote():
+			return fmt.Errorf("output.encryption_key_file is not supported for remote output")
+		case c.Output.OutputFormat() != FormatJSONL && c.Output.OutputFormat() != FormatJSONLZstd:
+			return fmt.Errorf("output.encryption_key_file requires output.format jsonl or jsonl.zst")
+		case c.Output.Dedupe != "off":
+			return fmt.Errorf("output.encryption_key_file cannot be combined with output.dedupe")
+		}
+	}
+	if (c.API.TLS.ClientCertFile == "") != (c.API.TLS.ClientKeyFile == "") {
+		return fmt.Errorf("tls.client_cert_file and tls.client_key_file must be set together")
+	}
+	for _, m := range c.API.Mirrors {
+		if m = strings.TrimSpace(m); m == "" || strings.ContainsAny(m, "/: ") {
+			return fmt.Errorf("mirrors must be bare hostnames like hist.gex.bot, got %q", m)
+		}
+	}
+	if c.Archive.Destination != "" {
+		_, rest, _ := strings.Cut(c.Archive.Destination, "://")
+		if bucket, _, _ := strings.Cut(rest, "/"); !isStorageURI(c.Archive.Destination) || bucket == "" {
+			return fmt.Errorf("archive.destination must be s3://bucket/prefix, gs://bucket/prefix or azblob://account/container/prefix")
+		}
+		if c.Output.Remote() {
+			return fmt.Errorf("archive.destination is not supported for remote output")
+		}
+		if c.Archive.Concurrency < 1 {
+			return fmt.Errorf("archive.concurrency must be >= 1")
+		}
+	}
+	if c.Metrics.PushgatewayURL != "" {
+		u, err := url.Parse(c.Metrics.PushgatewayURL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("metrics.pushgateway_url must be a URL like http://pushgateway:9091")
+		}
+		if c.Metrics.Job == "" {
+			return fmt.Errorf("metrics.job is required with metrics.pushgateway_url")
+		}
+	}
+	if c.API.ProxyURL != "" {
+		u, err := url.Parse(c.API.ProxyURL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("proxy_url must be a URL like http://host:port or socks5://host:port")
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return fmt.Errorf("proxy_url scheme must be http, https, socks5 or socks5h")
+		}
+	}
+	if c.API.ProxyAuth.Username != "" && c.API.ProxyURL == "" {
+		return fmt.Errorf("proxy_auth requires proxy_url")
+	}
+	if c.API.ProxyAuth.Password != "" && c.API.ProxyAuth.Username == "" {
+		return fmt.Errorf("proxy_auth.password requires proxy_auth.username")
+	}
 	return nil
 }
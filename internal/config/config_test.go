@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -35,3 +36,109 @@ func TestLoadWithoutAPIKey(t *testing.T) {
 		t.Fatal("expected error when API key is missing")
 	}
 }
+
+func TestLoadMirrorsFromEnv(t *testing.T) {
+	t.Setenv("GEXBOT_API_KEY", "test-key-123")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.API.Mirrors) != 2 || cfg.API.Mirrors[0] != "hist.gex.bot" {
+		t.Errorf("expected default mirrors, got %v", cfg.API.Mirrors)
+	}
+
+	t.Setenv("GEXBOT_MIRRORS", "hist.gexbot.com,mirror.example.com")
+	cfg, err = Load("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.API.Mirrors) != 2 || cfg.API.Mirrors[1] != "mirror.example.com" {
+		t.Errorf("expected mirrors from env, got %v", cfg.API.Mirrors)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Setenv("GEXBOT_API_KEY", "")
+
+	dir := t.TempDir()
+	path := dir + "/config.yaml"
+	content := `api:
+  api_key: "${GEXBOT_API_KEY}"
+tickers: [SPX, NOPE]
+packages:
+  classic:
+    enabled: true
+    categories: [gex_full, delta_zero]
+output:
+  directory: ` + dir + `/data
+logging:
+  enabled: false
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	problems := cfg.Check()
+	if len(problems) != 2 {
+		t.Fatalf("expected missing key and invalid ticker/category, got %v", problems)
+	}
+	if !strings.Contains(problems[0].Error(), "api_key") {
+		t.Errorf("expected api_key problem first, got %v", problems[0])
+	}
+	if msg := problems[1].Error(); !strings.Contains(msg, "NOPE") || !strings.Contains(msg, "classic/delta_zero") {
+		t.Errorf("expected ticker and category problems, got %v", msg)
+	}
+
+	cfg.API.APIKey = "key"
+	cfg.Tickers = []string{"SPX"}
+	cfg.Packages.Classic.Categories = []string{"gex_full"}
+	if problems := cfg.Check(); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+	if _, err := os.Stat(dir + "/data"); !os.IsNotExist(err) {
+		t.Errorf("check should not create the output directory: %v", err)
+	}
+
+	cfg.Output.Directory = path + "/data"
+	if problems := cfg.Check(); len(problems) != 1 || !strings.Contains(problems[0].Error(), "output.directory") {
+		t.Errorf("expected unwritable output directory, got %v", problems)
+	}
+}
+
+func TestTickerSchedules(t *testing.T) {
+	t.Setenv("GEXBOT_API_KEY", "test-key-123")
+
+	path := t.TempDir() + "/config.yaml"
+	content := `tickers: [SPX, ES_SPX, NQ_NDX]
+ticker_schedules:
+  cme:
+    schedule: "20 17 * * 0-4"
+    timezone: America/Chicago
+  NQ_NDX:
+    schedule: "0 18 * * 0-4"
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	for ticker, want := range map[string]string{"ES_SPX": "cme", "NQ_NDX": "nq_ndx", "SPX": ""} {
+		key, _, ok := cfg.TickerScheduleFor(ticker)
+		if key != want || ok != (want != "") {
+			t.Errorf("TickerScheduleFor(%s) = %q, %v, want %q", ticker, key, ok, want)
+		}
+	}
+
+	cfg.TickerSchedules["cme"] = TickerSchedule{Schedule: "20 17 * *"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "ticker_schedules.CME") {
+		t.Errorf("Validate() = %v, want invalid ticker_schedules.CME", err)
+	}
+}
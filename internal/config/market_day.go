@@ -0,0 +1,231 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scmhub/calendar"
+)
+
+// DATA_DATE keywords resolved against the NYSE calendar.
+const (
+	DataDateLatest                   = "latest"                       // newest date folder
+	DataDateLatestMarketDay          = "latest-market-day"            // newest date folder that is a trading day
+	DataDateTodayOrPreviousMarketDay = "today-or-previous-market-day" // today's trading day, or the one before a weekend/holiday
+)
+
+// maxMarketDayLookback bounds the search for the previous trading day.
+const maxMarketDayLookback = 14
+
+// ResolveDataDate turns a DATA_DATE value into a date folder. Explicit dates
+// are returned unchanged; keywords are resolved against the folders in dataDir.
+func ResolveDataDate(dataDir, value string, now time.Time) (string, error) {
+	switch value {
+	case "", DataDateLatest:
+		date, err := detectLatestDate(dataDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to detect latest date in %s: %w", dataDir, err)
+		}
+		return date, nil
+
+	case DataDateLatestMarketDay:
+		dates, err := dateFolders(dataDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to detect latest market day in %s: %w", dataDir, err)
+		}
+		for _, date := range dates {
+			if isMarketDay(date) {
+				return date, nil
+			}
+		}
+		return "", fmt.Errorf("no market-day folders found in %s", dataDir)
+
+	case DataDateTodayOrPreviousMarketDay:
+		date := MarketDayOnOrBefore(now)
+		dates, err := dateFolders(dataDir)
+		if err != nil || !slices.Contains(dates, date) {
+			return "", fmt.Errorf("no data for market day %s in %s (DATA_DATE=%s)", date, dataDir, value)
+		}
+		return date, nil
+
+	default:
+		return value, nil
+	}
+}
+
+// nyseCalendar is built once; constructing it computes years of holidays.
+var nyseCalendar = sync.OnceValue(func() *calendar.Calendar { return calendar.XNYS() })
+
+// NYSELocation returns the time zone trading days are evaluated in.
+func NYSELocation() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ParseTimeOfDay parses HH:MM or HH:MM:SS into the time since midnight.
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time %q, want HH:MM or HH:MM:SS", s)
+}
+
+// isMarketDay reports whether a YYYY-MM-DD date is an NYSE trading day
+// (not a weekend or holiday).
+func isMarketDay(date string) bool {
+	// Parse as noon in NYC timezone to ensure correct date matching
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", date+" 12:00:00", NYSELocation())
+	if err != nil {
+		return false
+	}
+	return nyseCalendar().IsBusinessDay(t)
+}
+
+// MarketDayOnOrBefore returns the NYSE trading day on or before now's date in
+// New York time.
+func MarketDayOnOrBefore(now time.Time) string {
+	loc := NYSELocation()
+	nyse := nyseCalendar()
+	day := now.In(loc)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, loc)
+	for range maxMarketDayLookback {
+		if nyse.IsBusinessDay(day) {
+			break
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return day.Format("2006-01-02")
+}
+
+// NthMarketDayBefore returns the n-th most recent NYSE trading day on or
+// before now's date in New York time; n of 1 is MarketDayOnOrBefore.
+func NthMarketDayBefore(now time.Time, n int) string {
+	loc := NYSELocation()
+	nyse := nyseCalendar()
+	day := now.In(loc)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, loc)
+	for found := 0; ; day = day.AddDate(0, 0, -1) {
+		if nyse.IsBusinessDay(day) {
+			found++
+			if found >= n {
+				return day.Format("2006-01-02")
+			}
+		}
+	}
+}
+
+// IsMarketOpen reports whether now falls within NYSE regular trading hours,
+// including early closes.
+func IsMarketOpen(now time.Time) bool {
+	return nyseCalendar().IsOpen(now.In(NYSELocation()))
+}
+
+// Trading calendars a ticker's market days follow.
+const (
+	CalendarNYSE = "nyse" // NYSE equities and index options
+	CalendarCME  = "cme"  // CME Globex equity index futures
+)
+
+// TickerCalendar returns the calendar a ticker trades on. overrides maps
+// tickers to CalendarNYSE or CalendarCME; otherwise futures tickers, those
+// containing an underscore like ES_SPX (see ClassifyTicker), follow the CME
+// and everything else the NYSE.
+func TickerCalendar(ticker string, overrides map[string]string) string {
+	for t, cal := range overrides {
+		if strings.EqualFold(t, ticker) {
+			return strings.ToLower(cal)
+		}
+	}
+	if strings.Contains(ticker, "_") {
+		return CalendarCME
+	}
+	return CalendarNYSE
+}
+
+// IsTradingDay reports whether a YYYY-MM-DD date has a session on the named
+// calendar. CME days run Sunday through Friday, since the Globex week opens
+// Sunday evening, except New Year's Day, Good Friday and Christmas and the
+// Sundays before them; other NYSE holidays have a shortened CME session.
+func IsTradingDay(cal, date string) bool {
+	if cal != CalendarCME {
+		return isMarketDay(date)
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", date+" 12:00:00", NYSELocation())
+	if err != nil {
+		return false
+	}
+	return isCMEDay(t)
+}
+
+func isCMEDay(day time.Time) bool {
+	switch day.Weekday() {
+	case time.Saturday:
+		return false
+	case time.Sunday:
+		return !isCMEClosure(day.AddDate(0, 0, 1))
+	default:
+		return !isCMEClosure(day)
+	}
+}
+
+// isCMEClosure reports whether CME equity futures are closed all day: the
+// NYSE holidays of New Year's Day, Good Friday and Christmas.
+func isCMEClosure(day time.Time) bool {
+	nyse := nyseCalendar()
+	if !nyse.IsHoliday(day) {
+		return false
+	}
+	_, h := nyse.NextHoliday(calendar.BOD(day).Add(-time.Second))
+	if h == nil {
+		return false
+	}
+	switch h.Name {
+	case calendar.NewYear.Name, calendar.GoodFriday.Name, calendar.ChristmasDay.Name:
+		return true
+	}
+	return false
+}
+
+// TradingDate returns now's date in New York time, the date an open
+// session's records are filed under.
+func TradingDate(now time.Time) string {
+	return now.In(NYSELocation()).Format("2006-01-02")
+}
+
+// IsTradingOpen reports whether now falls within a session of the named
+// calendar. CME Globex trades from 18:00 to 17:00 New York time the next
+// day, halting at 13:00 on NYSE holidays it trades through.
+func IsTradingOpen(cal string, now time.Time) bool {
+	if cal != CalendarCME {
+		return IsMarketOpen(now)
+	}
+
+	loc := NYSELocation()
+	now = now.In(loc)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, loc)
+	if !isCMEDay(day) {
+		return false
+	}
+
+	minute := now.Hour()*60 + now.Minute()
+	switch {
+	case minute >= 17*60 && minute < 18*60:
+		return false // daily maintenance halt
+	case minute >= 18*60:
+		// No evening open before a weekend or full closure
+		return day.Weekday() != time.Friday && isCMEDay(day.AddDate(0, 0, 1))
+	case day.Weekday() == time.Sunday:
+		return false
+	case !nyseCalendar().IsBusinessDay(day) && minute >= 13*60:
+		return false // holiday session ends early
+	}
+	return true
+}
@@ -1,25 +1,75 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/maintenance"
 )
 
 type ServerConfig struct {
 	Port              string
+	ListenAddrs       []string // host:port pairs to bind, defaults to ":"+Port
 	DataDir           string
 	DataDate          string
-	DataMode          string // "memory" or "stream"
-	CacheMode         string // "exhaust" or "rotation"
-	EndpointCacheMode string // "shared" or "independent"
+	KeyDates          map[string]string // API key -> pinned date (from KEY_DATES_FILE)
+	LoadDates         []string          // extra dates kept loaded for the date query parameter
+	DataDays          int               // consecutive dates stitched into one replay per load
+	EncryptionKeyFile string            // key decrypting data files the downloader encrypted
+	DataMode          string            // "memory" or "stream"
+	CacheMode         string            // "exhaust" or "rotation"
+	KeyCacheModes     map[string]string // API key -> cache mode overriding CacheMode (from KEY_CACHE_MODES_FILE)
+	EndpointCacheMode string            // "shared" or "independent"
+	PlaybackSpeed     float64           // replay at this multiple of real time (0: one record per request)
+	WallClockReplay   bool              // serve the record at the current New York time of day
+	SessionStart      time.Duration     // simulated time of day wall-clock replay starts at (0: the real one)
+	RequestValidation string            // "all", "non-data" or "off"
+	PreflightScan     bool              // validate every data line in the startup preflight
+	ShutdownTimeout   time.Duration
+	AdminToken        string // bearer token the admin routes require (empty: /admin/reload disabled)
+	// Ticker classification for /tickers (explicit lists win over the underscore heuristic)
+	TickerIndexes map[string]bool
+	TickerFutures map[string]bool
+	// Memory watchdog configuration
+	MemoryLimitMB       int           // RSS threshold in MiB (0 disables the watchdog)
+	MemoryCheckInterval time.Duration // How often RSS is sampled
+	ResponseCacheMB     int           // LRU budget for marshalled REST responses (0 disables)
+	// Cache position persistence
+	CacheStateFile        string        // snapshot of playback positions (empty disables)
+	CacheSnapshotInterval time.Duration // how often the snapshot is rewritten
+	// Access audit log configuration
+	AuditEnabled    bool
+	AuditFile       string // JSONL audit file path
+	AuditMaxSizeMB  int    // Rotate the audit file beyond this size
+	AuditMaxBackups int    // Rotated audit files to keep
+	AuditBufferSize int    // Recent entries kept in memory for /admin/audit
 	// WebSocket configuration
 	WSEnabled        bool
 	WSStreamInterval time.Duration
 	WSGroupPrefix    string
+	WSSchemaFile     string                   // JSON list of extra wire-format versions
+	WSSchemaDefault  string                   // version served when a client does not ask for one
+	WSKeyIntervals   map[string]time.Duration // API key -> stream interval (from WS_KEY_INTERVALS_FILE)
+	// WebSocket chaos injection (fault testing)
+	WSChaosEnabled        bool
+	WSChaosDropRate       float64
+	WSChaosDuplicateRate  float64
+	WSChaosDisconnectRate float64
+	WSChaosAckDelay       time.Duration
+	WSChaosKeys           []string // restrict faults to these API keys (empty = all)
+	WSChaosGroups         []string // restrict faults to groups containing these substrings (empty = all)
+	// Simulated maintenance windows (REST 503 + WebSocket disconnect)
+	MaintenanceWindows  []maintenance.Window
+	MaintenanceLocation *time.Location // time zone the windows are evaluated in
+	MaintenanceMessage  string
 	// Sync Broadcast System configuration
 	SyncBroadcastSystemEnabled  bool
 	SyncBroadcastSystemID       string
@@ -30,13 +80,10 @@ func LoadServerConfig() (*ServerConfig, error) {
 	dataDir := getEnvOrDefault("DATA_DIR", "./data")
 	dataDate := getEnvOrDefault("DATA_DATE", "")
 
-	// Auto-detect latest date if DATA_DATE is empty or "latest"
-	if dataDate == "" || dataDate == "latest" {
-		detected, err := detectLatestDate(dataDir)
-		if err != nil {
-			return nil, fmt.Errorf("failed to detect latest date in %s: %w", dataDir, err)
-		}
-		dataDate = detected
+	// Auto-detect the date for "", "latest" and the market-day keywords
+	dataDate, err := ResolveDataDate(dataDir, dataDate, time.Now())
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse WebSocket stream interval
@@ -46,6 +93,68 @@ func LoadServerConfig() (*ServerConfig, error) {
 		wsInterval = time.Second // Default to 1s on parse error
 	}
 
+	// Parse WebSocket chaos injection settings
+	chaosDropRate, err := parseRate("WS_CHAOS_DROP_RATE")
+	if err != nil {
+		return nil, err
+	}
+	chaosDuplicateRate, err := parseRate("WS_CHAOS_DUPLICATE_RATE")
+	if err != nil {
+		return nil, err
+	}
+	chaosDisconnectRate, err := parseRate("WS_CHAOS_DISCONNECT_RATE")
+	if err != nil {
+		return nil, err
+	}
+	if chaosDropRate+chaosDuplicateRate+chaosDisconnectRate > 1 {
+		return nil, fmt.Errorf("WS_CHAOS_DROP_RATE + WS_CHAOS_DUPLICATE_RATE + WS_CHAOS_DISCONNECT_RATE must not exceed 1")
+	}
+	chaosAckDelay, err := parseAckDelay("WS_CHAOS_ACK_DELAY")
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse graceful shutdown timeout
+	shutdownTimeoutStr := getEnvOrDefault("SHUTDOWN_TIMEOUT", "30s")
+	shutdownTimeout, err := time.ParseDuration(shutdownTimeoutStr)
+	if err != nil || shutdownTimeout <= 0 {
+		shutdownTimeout = 30 * time.Second // Default to 30s on parse error
+	}
+
+	// Parse memory watchdog settings
+	memoryLimitMB, err := strconv.Atoi(getEnvOrDefault("MEMORY_LIMIT_MB", "0"))
+	if err != nil || memoryLimitMB < 0 {
+		return nil, fmt.Errorf("invalid MEMORY_LIMIT_MB: %s (must be a non-negative integer)", os.Getenv("MEMORY_LIMIT_MB"))
+	}
+	responseCacheMB, err := strconv.Atoi(getEnvOrDefault("RESPONSE_CACHE_MB", "0"))
+	if err != nil || responseCacheMB < 0 {
+		return nil, fmt.Errorf("invalid RESPONSE_CACHE_MB: %s (must be a non-negative integer)", os.Getenv("RESPONSE_CACHE_MB"))
+	}
+	memoryCheckInterval, err := time.ParseDuration(getEnvOrDefault("MEMORY_CHECK_INTERVAL", "10s"))
+	if err != nil || memoryCheckInterval <= 0 {
+		memoryCheckInterval = 10 * time.Second // Default to 10s on parse error
+	}
+
+	// Parse cache persistence settings
+	cacheSnapshotInterval, err := time.ParseDuration(getEnvOrDefault("CACHE_SNAPSHOT_INTERVAL", "30s"))
+	if err != nil || cacheSnapshotInterval <= 0 {
+		cacheSnapshotInterval = 30 * time.Second // Default to 30s on parse error
+	}
+
+	// Parse audit log settings
+	auditMaxSizeMB, err := strconv.Atoi(getEnvOrDefault("AUDIT_MAX_SIZE_MB", "50"))
+	if err != nil || auditMaxSizeMB < 0 {
+		return nil, fmt.Errorf("invalid AUDIT_MAX_SIZE_MB: %s (must be a non-negative integer)", os.Getenv("AUDIT_MAX_SIZE_MB"))
+	}
+	auditMaxBackups, err := strconv.Atoi(getEnvOrDefault("AUDIT_MAX_BACKUPS", "5"))
+	if err != nil || auditMaxBackups < 0 {
+		return nil, fmt.Errorf("invalid AUDIT_MAX_BACKUPS: %s (must be a non-negative integer)", os.Getenv("AUDIT_MAX_BACKUPS"))
+	}
+	auditBufferSize, err := strconv.Atoi(getEnvOrDefault("AUDIT_BUFFER_SIZE", "1000"))
+	if err != nil || auditBufferSize < 1 {
+		return nil, fmt.Errorf("invalid AUDIT_BUFFER_SIZE: %s (must be a positive integer)", os.Getenv("AUDIT_BUFFER_SIZE"))
+	}
+
 	// Parse Sync Broadcast System interval
 	syncIntervalStr := getEnvOrDefault("SYNC_BROADCAST_SYSTEM_INTERVAL", "1s")
 	syncInterval, err := time.ParseDuration(syncIntervalStr)
@@ -64,16 +173,129 @@ func LoadServerConfig() (*ServerConfig, error) {
 		}
 	}
 
+	// Parse scheduled maintenance windows (e.g. "Sat 22:00-02:00,03:00-03:15")
+	maintenanceWindows, err := maintenance.ParseWindows(getEnvOrDefault("MAINTENANCE_WINDOWS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAINTENANCE_WINDOWS: %w", err)
+	}
+	maintenanceLocation, err := time.LoadLocation(getEnvOrDefault("MAINTENANCE_TIMEZONE", "America/New_York"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAINTENANCE_TIMEZONE: %w", err)
+	}
+
+	// Load per-API-key date pins (multi-tenant replay)
+	keyDates, err := loadKeyDates(getEnvOrDefault("KEY_DATES_FILE", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse extra dates to keep loaded
+	loadDates := parseList(getEnvOrDefault("LOAD_DATES", ""))
+	for _, date := range loadDates {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("invalid LOAD_DATES entry: %s (expected YYYY-MM-DD)", date)
+		}
+	}
+
+	// Parse the number of dates stitched into one replay
+	dataDays, err := strconv.Atoi(getEnvOrDefault("DATA_DAYS", "1"))
+	if err != nil || dataDays < 1 {
+		return nil, fmt.Errorf("invalid DATA_DAYS: %s (must be a positive integer)", os.Getenv("DATA_DAYS"))
+	}
+
+	// Load per-API-key cache modes
+	keyCacheModes, err := loadKeyCacheModes(getEnvOrDefault("KEY_CACHE_MODES_FILE", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the playback clock speed
+	playbackSpeed, err := strconv.ParseFloat(getEnvOrDefault("PLAYBACK_SPEED", "0"), 64)
+	if err != nil || playbackSpeed < 0 {
+		return nil, fmt.Errorf("invalid PLAYBACK_SPEED: %s (must be a non-negative number)", os.Getenv("PLAYBACK_SPEED"))
+	}
+
+	// Parse the simulated session start of wall-clock replay
+	var sessionStart time.Duration
+	if raw := getEnvOrDefault("SESSION_START", ""); raw != "" {
+		if sessionStart, err = ParseTimeOfDay(raw); err != nil {
+			return nil, fmt.Errorf("invalid SESSION_START: %w", err)
+		}
+	}
+	wallClockReplay := getEnvOrDefault("WALL_CLOCK_REPLAY", "false") == "true"
+	if wallClockReplay && dataDays > 1 {
+		// The wall clock maps the time of day onto a single date
+		return nil, fmt.Errorf("WALL_CLOCK_REPLAY cannot be combined with DATA_DAYS > 1")
+	}
+
+	// Load per-API-key WebSocket stream intervals
+	wsKeyIntervals, err := loadKeyIntervals(getEnvOrDefault("WS_KEY_INTERVALS_FILE", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	port := getEnvOrDefault("PORT", "8080")
+
+	// Parse listen addresses (comma-separated, e.g. "[::1]:8080,0.0.0.0:8081")
+	listenAddrs, err := parseListenAddrs(getEnvOrDefault("LISTEN_ADDRS", ""), port)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &ServerConfig{
-		Port:              getEnvOrDefault("PORT", "8080"),
+		Port:              port,
+		ListenAddrs:       listenAddrs,
 		DataDir:           dataDir,
 		DataDate:          dataDate,
+		KeyDates:          keyDates,
+		LoadDates:         loadDates,
+		DataDays:          dataDays,
+		EncryptionKeyFile: getEnvOrDefault("ENCRYPTION_KEY_FILE", ""),
 		DataMode:          getEnvOrDefault("DATA_MODE", "memory"),
 		CacheMode:         getEnvOrDefault("CACHE_MODE", "exhaust"),
+		KeyCacheModes:     keyCacheModes,
 		EndpointCacheMode: getEnvOrDefault("ENDPOINT_CACHE_MODE", "shared"),
-		WSEnabled:         getEnvOrDefault("WS_ENABLED", "true") == "true",
-		WSStreamInterval:  wsInterval,
-		WSGroupPrefix:     getEnvOrDefault("WS_GROUP_PREFIX", "blue"),
+		PlaybackSpeed:     playbackSpeed,
+		WallClockReplay:   wallClockReplay,
+		SessionStart:      sessionStart,
+		RequestValidation: getEnvOrDefault("REQUEST_VALIDATION", "all"),
+		PreflightScan:     getEnvOrDefault("PREFLIGHT_SCAN", "false") == "true",
+		ShutdownTimeout:   shutdownTimeout,
+		AdminToken:        getEnvOrDefault("ADMIN_TOKEN", ""),
+		// Ticker classification
+		TickerIndexes: parseTickerSet(getEnvOrDefault("TICKER_INDEXES", "SPX,VIX,NDX,RUT")),
+		TickerFutures: parseTickerSet(getEnvOrDefault("TICKER_FUTURES", "")),
+		// Memory watchdog
+		MemoryLimitMB:       memoryLimitMB,
+		MemoryCheckInterval: memoryCheckInterval,
+		ResponseCacheMB:     responseCacheMB,
+		// Cache position persistence
+		CacheStateFile:        getEnvOrDefault("CACHE_STATE_FILE", ""),
+		CacheSnapshotInterval: cacheSnapshotInterval,
+		// Access audit log
+		AuditEnabled:     getEnvOrDefault("AUDIT_ENABLED", "false") == "true",
+		AuditFile:        getEnvOrDefault("AUDIT_FILE", "./logs/audit.jsonl"),
+		AuditMaxSizeMB:   auditMaxSizeMB,
+		AuditMaxBackups:  auditMaxBackups,
+		AuditBufferSize:  auditBufferSize,
+		WSEnabled:        getEnvOrDefault("WS_ENABLED", "true") == "true",
+		WSStreamInterval: wsInterval,
+		WSGroupPrefix:    getEnvOrDefault("WS_GROUP_PREFIX", "blue"),
+		WSSchemaFile:     getEnvOrDefault("WS_SCHEMA_FILE", ""),
+		WSSchemaDefault:  getEnvOrDefault("WS_SCHEMA_DEFAULT", "v1"),
+		WSKeyIntervals:   wsKeyIntervals,
+		// WebSocket chaos injection
+		WSChaosEnabled:        getEnvOrDefault("WS_CHAOS_ENABLED", "false") == "true",
+		WSChaosDropRate:       chaosDropRate,
+		WSChaosDuplicateRate:  chaosDuplicateRate,
+		WSChaosDisconnectRate: chaosDisconnectRate,
+		WSChaosAckDelay:       chaosAckDelay,
+		WSChaosKeys:           parseList(getEnvOrDefault("WS_CHAOS_KEYS", "")),
+		WSChaosGroups:         parseList(getEnvOrDefault("WS_CHAOS_GROUPS", "")),
+		// Maintenance simulation
+		MaintenanceWindows:  maintenanceWindows,
+		MaintenanceLocation: maintenanceLocation,
+		MaintenanceMessage:  getEnvOrDefault("MAINTENANCE_MESSAGE", maintenance.DefaultMessage),
 		// Sync Broadcast System
 		SyncBroadcastSystemEnabled:  getEnvOrDefault("SYNC_BROADCAST_SYSTEM_ENABLED", "false") == "true",
 		SyncBroadcastSystemID:       syncBroadcastID,
@@ -90,17 +312,228 @@ func LoadServerConfig() (*ServerConfig, error) {
 	if cfg.EndpointCacheMode != "shared" && cfg.EndpointCacheMode != "independent" {
 		return nil, fmt.Errorf("invalid ENDPOINT_CACHE_MODE: %s (must be 'shared' or 'independent')", cfg.EndpointCacheMode)
 	}
+	if cfg.RequestValidation != "all" && cfg.RequestValidation != "non-data" && cfg.RequestValidation != "off" {
+		return nil, fmt.Errorf("invalid REQUEST_VALIDATION: %s (must be 'all', 'non-data' or 'off')", cfg.RequestValidation)
+	}
 
 	return cfg, nil
 }
 
+// loadKeyDates reads a JSON object mapping API keys to the date they replay,
+// e.g. {"team-a-key": "2025-01-02"}. An empty path means no pins.
+func loadKeyDates(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading KEY_DATES_FILE: %w", err)
+	}
+	var keyDates map[string]string
+	if err := json.Unmarshal(raw, &keyDates); err != nil {
+		return nil, fmt.Errorf("parsing KEY_DATES_FILE %s: %w", path, err)
+	}
+
+	datePattern := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	for key, date := range keyDates {
+		if key == "" {
+			return nil, fmt.Errorf("KEY_DATES_FILE %s: empty API key", path)
+		}
+		if !datePattern.MatchString(date) {
+			return nil, fmt.Errorf("KEY_DATES_FILE %s: invalid date %q (expected YYYY-MM-DD)", path, date)
+		}
+	}
+	return keyDates, nil
+}
+
+// loadKeyCacheModes reads a JSON object mapping API keys to the cache mode
+// they replay in, e.g. {"demo-key": "rotation"}. An empty path means none.
+func loadKeyCacheModes(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading KEY_CACHE_MODES_FILE: %w", err)
+	}
+	var modes map[string]string
+	if err := json.Unmarshal(raw, &modes); err != nil {
+		return nil, fmt.Errorf("parsing KEY_CACHE_MODES_FILE %s: %w", path, err)
+	}
+
+	for key, mode := range modes {
+		if key == "" {
+			return nil, fmt.Errorf("KEY_CACHE_MODES_FILE %s: empty API key", path)
+		}
+		if mode != "exhaust" && mode != "rotation" {
+			return nil, fmt.Errorf("KEY_CACHE_MODES_FILE %s: invalid cache mode %q (expected exhaust or rotation)", path, mode)
+		}
+	}
+	return modes, nil
+}
+
+// loadKeyIntervals reads a JSON object mapping API keys to the WebSocket stream
+// interval they receive, e.g. {"logger-key": "10s"}. An empty path means none.
+func loadKeyIntervals(path string) (map[string]time.Duration, error) {
+	if path == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading WS_KEY_INTERVALS_FILE: %w", err)
+	}
+	var values map[string]string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("parsing WS_KEY_INTERVALS_FILE %s: %w", path, err)
+	}
+
+	intervals := make(map[string]time.Duration, len(values))
+	for key, value := range values {
+		if key == "" {
+			return nil, fmt.Errorf("WS_KEY_INTERVALS_FILE %s: empty API key", path)
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("WS_KEY_INTERVALS_FILE %s: invalid interval %q (expected e.g. 250ms, 10s)", path, value)
+		}
+		intervals[key] = d
+	}
+	return intervals, nil
+}
+
+// parseListenAddrs splits a comma-separated list of listen addresses.
+// An empty list falls back to all interfaces on the given port.
+// IPv6 hosts must be bracketed (e.g. "[::1]:8080").
+func parseListenAddrs(raw, port string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return []string{":" + port}, nil
+	}
+
+	seen := make(map[string]bool)
+	var addrs []string
+	for _, addr := range strings.Split(raw, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("invalid LISTEN_ADDRS entry %q: %w", addr, err)
+		}
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		addrs = append(addrs, addr)
+	}
+
+	if len(addrs) == 0 {
+		return []string{":" + port}, nil
+	}
+	return addrs, nil
+}
+
+// ClassifyTicker returns "indexes", "futures" or "stocks" for a ticker.
+// Explicit TICKER_INDEXES/TICKER_FUTURES entries take precedence; otherwise
+// tickers containing an underscore (e.g. ES_SPX) are treated as futures.
+func (c *ServerConfig) ClassifyTicker(ticker string) string {
+	switch {
+	case c.TickerIndexes[ticker]:
+		return "indexes"
+	case c.TickerFutures[ticker]:
+		return "futures"
+	case strings.Contains(ticker, "_"):
+		return "futures"
+	default:
+		return "stocks"
+	}
+}
+
+// parseTickerSet parses a comma-separated ticker list into an uppercase set.
+func parseTickerSet(raw string) map[string]bool {
+	set := make(map[string]bool)
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if t != "" {
+			set[t] = true
+		}
+	}
+	return set
+}
+
+// parseRate reads a probability in [0,1] from the environment (default 0).
+func parseRate(name string) (float64, error) {
+	raw := getEnvOrDefault(name, "0")
+	rate, err := strconv.ParseFloat(raw, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("invalid %s: %s (must be between 0 and 1)", name, raw)
+	}
+	return rate, nil
+}
+
+// maxChaosAckDelay bounds WS_CHAOS_ACK_DELAY well below the WebSocket pong
+// wait (60s), so delayed acks never outlast a connection's keepalive.
+const maxChaosAckDelay = 30 * time.Second
+
+// parseAckDelay reads a chaos ack delay in [0, maxChaosAckDelay] from the
+// environment (default 0).
+func parseAckDelay(name string) (time.Duration, error) {
+	raw := getEnvOrDefault(name, "0s")
+	delay, err := time.ParseDuration(raw)
+	if err != nil || delay < 0 || delay > maxChaosAckDelay {
+		return 0, fmt.Errorf("invalid %s: %s (must be a duration between 0s and %s)", name, raw, maxChaosAckDelay)
+	}
+	return delay, nil
+}
+
+// parseList splits a comma-separated list, dropping empty entries.
+func parseList(raw string) []string {
+	var list []string
+	for _, v := range strings.Split(raw, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
 // detectLatestDate scans the data directory for date folders and returns the most recent one
 func detectLatestDate(dataDir string) (string, error) {
+	dates, err := dateFolders(dataDir)
+	if err != nil {
+		return "", err
+	}
+	return dates[0], nil
+}
+
+// StitchedDates returns date followed by the data folders after it, up to n
+// dates oldest first, which DATA_DAYS replays as one stream. n of 1 returns
+// date alone.
+func StitchedDates(dataDir, date string, n int) ([]string, error) {
+	if n <= 1 {
+		return []string{date}, nil
+	}
+	folders, err := dateFolders(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(folders)
+
+	dates := []string{date}
+	for _, folder := range folders {
+		if folder > date && len(dates) < n {
+			dates = append(dates, folder)
+		}
+	}
+	return dates, nil
+}
+
+// dateFolders returns the non-empty YYYY-MM-DD folders in dataDir, newest first.
+func dateFolders(dataDir string) ([]string, error) {
 	datePattern := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
 
 	entries, err := os.ReadDir(dataDir)
 	if err != nil {
-		return "", fmt.Errorf("reading data directory: %w", err)
+		return nil, fmt.Errorf("reading data directory: %w", err)
 	}
 
 	var dates []string
@@ -120,13 +553,13 @@ func detectLatestDate(dataDir string) (string, error) {
 	}
 
 	if len(dates) == 0 {
-		return "", fmt.Errorf("no date folders found in %s", dataDir)
+		return nil, fmt.Errorf("no date folders found in %s", dataDir)
 	}
 
 	// Sort descending (newest first) - YYYY-MM-DD format sorts lexicographically
 	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
 
-	return dates[0], nil
+	return dates, nil
 }
 
 func getEnvOrDefault(key, defaultVal string) string {
@@ -0,0 +1,250 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseListenAddrs_Default(t *testing.T) {
+	addrs, err := parseListenAddrs("", "8080")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(addrs, []string{":8080"}) {
+		t.Errorf("expected [:8080], got %v", addrs)
+	}
+}
+
+func TestParseListenAddrs_MultipleWithIPv6(t *testing.T) {
+	addrs, err := parseListenAddrs("[::1]:8080, 0.0.0.0:8081,[::1]:8080", "8080")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"[::1]:8080", "0.0.0.0:8081"}
+	if !reflect.DeepEqual(addrs, expected) {
+		t.Errorf("expected %v, got %v", expected, addrs)
+	}
+}
+
+func TestParseListenAddrs_Invalid(t *testing.T) {
+	if _, err := parseListenAddrs("::1:8080", "8080"); err == nil {
+		t.Error("expected error for unbracketed IPv6 address")
+	}
+}
+
+func TestClassifyTicker(t *testing.T) {
+	cfg := &ServerConfig{
+		TickerIndexes: parseTickerSet("SPX, vix,XSP"),
+		TickerFutures: parseTickerSet("MES"),
+	}
+
+	tests := map[string]string{
+		"SPX":    "indexes",
+		"VIX":    "indexes",
+		"XSP":    "indexes",
+		"MES":    "futures",
+		"ES_SPX": "futures",
+		"AAPL":   "stocks",
+	}
+	for ticker, expected := range tests {
+		if got := cfg.ClassifyTicker(ticker); got != expected {
+			t.Errorf("ClassifyTicker(%s): expected %s, got %s", ticker, expected, got)
+		}
+	}
+}
+
+func TestLoadKeyDates(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "key-dates.json")
+	if err := os.WriteFile(path, []byte(`{"team-a": "2025-01-02", "team-b": "2025-01-03"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	keyDates, err := loadKeyDates(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{"team-a": "2025-01-02", "team-b": "2025-01-03"}
+	if !reflect.DeepEqual(keyDates, expected) {
+		t.Errorf("expected %v, got %v", expected, keyDates)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"team-a": "Jan 2"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadKeyDates(path); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestLoadKeyCacheModes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key-cache-modes.json")
+	if err := os.WriteFile(path, []byte(`{"demo": "rotation", "ci": "exhaust"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modes, err := loadKeyCacheModes(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{"demo": "rotation", "ci": "exhaust"}
+	if !reflect.DeepEqual(modes, expected) {
+		t.Errorf("expected %v, got %v", expected, modes)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"demo": "loop"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadKeyCacheModes(path); err == nil {
+		t.Error("expected error for invalid cache mode")
+	}
+}
+
+func TestResolveDataDate(t *testing.T) {
+	dir := t.TempDir()
+	// Thursday, then a Saturday folder after the July 4th holiday
+	for _, date := range []string{"2025-07-03", "2025-07-05"} {
+		if err := os.MkdirAll(filepath.Join(dir, date, "SPX"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tz data")
+	}
+	sunday := time.Date(2025, 7, 6, 9, 0, 0, 0, ny)
+
+	tests := []struct {
+		value string
+		now   time.Time
+		want  string
+	}{
+		{"", sunday, "2025-07-05"},
+		{"latest", sunday, "2025-07-05"},
+		{"latest-market-day", sunday, "2025-07-03"},
+		{"today-or-previous-market-day", sunday, "2025-07-03"},
+		{"2025-07-05", sunday, "2025-07-05"},
+	}
+	for _, tt := range tests {
+		got, err := ResolveDataDate(dir, tt.value, tt.now)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	// Monday's folder has not been downloaded yet
+	monday := time.Date(2025, 7, 7, 9, 0, 0, 0, ny)
+	if _, err := ResolveDataDate(dir, "today-or-previous-market-day", monday); err == nil {
+		t.Error("expected error for missing market day folder")
+	}
+}
+
+func TestNthMarketDayBefore(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tz data")
+	}
+	sunday := time.Date(2025, 7, 6, 9, 0, 0, 0, ny)
+
+	// July 4th is a holiday
+	for n, want := range map[int]string{1: "2025-07-03", 2: "2025-07-02", 4: "2025-06-30"} {
+		if got := NthMarketDayBefore(sunday, n); got != want {
+			t.Errorf("n=%d: got %s, want %s", n, got, want)
+		}
+	}
+}
+
+func TestIsMarketOpen(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tz data")
+	}
+
+	cases := map[time.Time]bool{
+		time.Date(2025, 7, 3, 9, 0, 0, 0, ny):        false, // before the open
+		time.Date(2025, 7, 3, 10, 0, 0, 0, ny):       true,
+		time.Date(2025, 7, 3, 13, 30, 0, 0, ny):      false, // early close
+		time.Date(2025, 7, 2, 15, 30, 0, 0, ny):      true,
+		time.Date(2025, 7, 4, 11, 0, 0, 0, ny):       false, // holiday
+		time.Date(2025, 7, 2, 14, 0, 0, 0, time.UTC): true,
+	}
+	for now, want := range cases {
+		if got := IsMarketOpen(now); got != want {
+			t.Errorf("%s: got %v, want %v", now, got, want)
+		}
+	}
+}
+
+func TestTradingCalendars(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tz data")
+	}
+
+	overrides := map[string]string{"mes": CalendarCME}
+	for ticker, want := range map[string]string{"SPX": CalendarNYSE, "ES_SPX": CalendarCME, "MES": CalendarCME} {
+		if got := TickerCalendar(ticker, overrides); got != want {
+			t.Errorf("TickerCalendar(%s) = %s, want %s", ticker, got, want)
+		}
+	}
+
+	days := map[string][2]bool{ // date: NYSE, CME
+		"2025-07-02": {true, true},
+		"2025-07-04": {false, true},  // Independence Day: shortened CME session
+		"2025-07-05": {false, false}, // Saturday
+		"2025-07-06": {false, true},  // Sunday evening open
+		"2025-04-18": {false, false}, // Good Friday
+		"2025-12-25": {false, false}, // Christmas
+		"2022-12-25": {false, false}, // Sunday before observed Christmas
+	}
+	for date, want := range days {
+		if got := IsTradingDay(CalendarNYSE, date); got != want[0] {
+			t.Errorf("NYSE %s: got %v, want %v", date, got, want[0])
+		}
+		if got := IsTradingDay(CalendarCME, date); got != want[1] {
+			t.Errorf("CME %s: got %v, want %v", date, got, want[1])
+		}
+	}
+
+	open := map[time.Time]bool{
+		time.Date(2025, 7, 6, 12, 0, 0, 0, ny):  false, // Sunday before the open
+		time.Date(2025, 7, 6, 19, 0, 0, 0, ny):  true,
+		time.Date(2025, 7, 7, 3, 0, 0, 0, ny):   true,  // overnight
+		time.Date(2025, 7, 7, 17, 30, 0, 0, ny): false, // maintenance halt
+		time.Date(2025, 7, 4, 12, 0, 0, 0, ny):  true,
+		time.Date(2025, 7, 4, 14, 0, 0, 0, ny):  false, // holiday halt
+		time.Date(2025, 7, 11, 19, 0, 0, 0, ny): false, // Friday evening
+		time.Date(2025, 4, 17, 19, 0, 0, 0, ny): false, // eve of Good Friday
+	}
+	for now, want := range open {
+		if got := IsTradingOpen(CalendarCME, now); got != want {
+			t.Errorf("CME %s: got %v, want %v", now, got, want)
+		}
+	}
+}
+
+func TestParseAckDelay(t *testing.T) {
+	for _, tt := range []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"250ms", 250 * time.Millisecond, false},
+		{"30s", 30 * time.Second, false},
+		{"31s", 0, true},
+		{"-1s", 0, true},
+		{"soon", 0, true},
+	} {
+		t.Setenv("WS_CHAOS_ACK_DELAY", tt.value)
+		got, err := parseAckDelay("WS_CHAOS_ACK_DELAY")
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseAckDelay(%q) = %s, %v; want %s, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
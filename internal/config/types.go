@@ -4,9 +4,10 @@ package config
 type Package string
 
 const (
-	PackageState     Package = "state"
-	PackageClassic   Package = "classic"
-	PackageOrderflow Package = "orderflow"
+	PackageState      Package = "state"
+	PackageClassic    Package = "classic"
+	PackageOrderflow  Package = "orderflow"
+	PackageVolatility Package = "volatility"
 )
 
 // ValidCategories returns valid categories for each package
@@ -18,8 +19,9 @@ var ValidCategories = map[Package][]string{
 		"vanna_zero", "vanna_one",
 		"charm_zero", "charm_one",
 	},
-	PackageClassic:   {"gex_full", "gex_zero", "gex_one"},
-	PackageOrderflow: {"orderflow"},
+	PackageClassic:    {"gex_full", "gex_zero", "gex_one"},
+	PackageOrderflow:  {"orderflow"},
+	PackageVolatility: {"iv_zero", "iv_one"},
 }
 
 // DefaultTickers returns a default set of common tickers
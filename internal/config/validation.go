@@ -1,7 +1,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 )
@@ -42,7 +45,7 @@ func (e *ValidationErrors) Error() string {
 		for _, p := range e.InvalidPackages {
 			sb.WriteString(fmt.Sprintf("  - %s\n", p))
 		}
-		sb.WriteString("\nValid packages: state, classic, orderflow\n")
+		sb.WriteString("\nValid packages: state, classic, orderflow, volatility\n")
 	}
 
 	if len(e.InvalidCategories) > 0 {
@@ -72,6 +75,7 @@ func ValidateDownloadConfig(tickers []string, packages PackagesConfig) error {
 	validatePackageCategories(errs, "state", packages.State)
 	validatePackageCategories(errs, "classic", packages.Classic)
 	validatePackageCategories(errs, "orderflow", packages.Orderflow)
+	validatePackageCategories(errs, "volatility", packages.Volatility)
 
 	if errs.HasErrors() {
 		return errs
@@ -108,3 +112,83 @@ func validTickersList() string {
 	sort.Strings(tickers)
 	return strings.Join(tickers, ", ")
 }
+
+// Check returns every problem that would stop or break a download run: a
+// missing API key, settings rejected by Validate, unknown tickers and
+// categories, and local directories that cannot be written. It makes no
+// network requests.
+func (c *Config) Check() []error {
+	var problems []error
+
+	// The default config holds a "${GEXBOT_API_KEY}" placeholder that is
+	// only replaced when the variable is set
+	v := *c
+	if key := strings.TrimSpace(c.API.APIKey); key == "" || strings.HasPrefix(key, "${") {
+		problems = append(problems, fmt.Errorf("api_key is required (set GEXBOT_API_KEY env var)"))
+		v.API.APIKey = "unset"
+	}
+	if err := v.Validate(); err != nil {
+		problems = append(problems, err)
+	}
+
+	if err := ValidateDownloadConfig(c.Tickers, c.Packages); err != nil {
+		problems = append(problems, err)
+	}
+	p := c.Packages
+	if !p.State.Enabled && !p.Classic.Enabled && !p.Orderflow.Enabled && !p.Volatility.Enabled {
+		problems = append(problems, fmt.Errorf("no packages are enabled"))
+	}
+
+	dirs := map[string]string{"output.directory": c.Output.Directory}
+	if c.Output.Remote() {
+		dirs = map[string]string{"output.staging_directory": c.Output.WorkDirectory()}
+	}
+	if c.Logging.Enabled {
+		dirs["logging.directory"] = c.Logging.Directory
+	}
+	keys := make([]string, 0, len(dirs))
+	for key := range dirs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := checkWritable(dirs[key]); err != nil {
+			problems = append(problems, fmt.Errorf("%s %q is not writable: %w", key, dirs[key], err))
+		}
+	}
+
+	return problems
+}
+
+// checkWritable reports whether files can be created in dir, or in its
+// nearest existing parent when dir does not exist yet. Nothing is left
+// behind.
+func checkWritable(dir string) error {
+	if dir == "" {
+		return fmt.Errorf("not set")
+	}
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return err
+		}
+		dir = parent
+	}
+
+	f, err := os.CreateTemp(dir, ".gexbot-write-check-*")
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
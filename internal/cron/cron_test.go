@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	tests := []struct {
+		expr string
+		at   time.Time
+		want bool
+	}{
+		{"30 20 * * 1-5", time.Date(2025, 11, 14, 20, 30, 0, 0, ny), true},  // Friday
+		{"30 20 * * 1-5", time.Date(2025, 11, 15, 20, 30, 0, 0, ny), false}, // Saturday
+		{"30 20 * * 1-5", time.Date(2025, 11, 14, 20, 31, 0, 0, ny), false},
+		{"*/15 9-16 * * mon-fri", time.Date(2025, 11, 14, 9, 45, 0, 0, ny), true},
+		{"*/15 9-16 * * mon-fri", time.Date(2025, 11, 14, 9, 50, 0, 0, ny), false},
+		{"0 18,22 * * *", time.Date(2025, 11, 16, 22, 0, 0, 0, ny), true},
+		{"0 0 * * 7", time.Date(2025, 11, 16, 0, 0, 0, 0, ny), true}, // 7 is Sunday
+		{"0 0 1 * 5", time.Date(2025, 11, 14, 0, 0, 0, 0, ny), true}, // either day field
+		{"0 0 1 * 5", time.Date(2025, 11, 13, 0, 0, 0, 0, ny), false},
+		{"0 0 1 jan *", time.Date(2026, 1, 1, 0, 0, 0, 0, ny), true},
+		{"@daily", time.Date(2026, 1, 1, 0, 0, 0, 0, ny), true},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.expr, err)
+		}
+		if got := s.Matches(tt.at); got != tt.want {
+			t.Errorf("Parse(%q).Matches(%s) = %v, want %v", tt.expr, tt.at, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "* * * foo *"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) accepted an invalid expression", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	s, err := Parse("30 20 * * 1-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	friday := time.Date(2025, 11, 14, 20, 30, 0, 0, time.UTC)
+	if got, want := s.Next(friday), time.Date(2025, 11, 17, 20, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next(%s) = %s, want %s", friday, got, want)
+	}
+
+	never, _ := Parse("0 0 30 2 *")
+	if got := never.Next(friday); !got.IsZero() {
+		t.Errorf("Next() of February 30th = %s", got)
+	}
+}
@@ -1,6 +1,12 @@
 package data
 
-import "sync"
+import (
+	"hash/maphash"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // CacheMode defines how playback handles end-of-data
 type CacheMode string
@@ -10,18 +16,70 @@ const (
 	CacheModeRotation CacheMode = "rotation" // wrap to 0
 )
 
-// IndexCache tracks playback positions per API key
+// defaultCacheShards is the number of independently locked shards in an IndexCache.
+// Must be a power of two.
+const defaultCacheShards = 64
+
+// IndexCache tracks playback positions per API key.
+// Positions are spread over shards by key hash so concurrent REST requests and
+// WebSocket ticks for different keys do not contend on a single lock.
 type IndexCache struct {
-	mu      sync.RWMutex
-	indexes map[string]int // key: ticker/pkg/category/apiKey
-	mode    CacheMode
+	shards []*cacheShard
+	seed   maphash.Seed
+
+	modeMu sync.Mutex                // serializes mode table writers
+	modes  atomic.Pointer[modeTable] // read lock-free on every advance
+
+	onExhausted  atomic.Pointer[func()] // called once every position is exhausted
+	allExhausted atomic.Bool            // set while every position is exhausted
+
+	journal atomic.Pointer[CacheStore] // records changes when persisted
+}
+
+// modeTable is the default cache mode plus overrides for selected keys.
+// Tables are replaced, never mutated, so readers need no lock.
+type modeTable struct {
+	def   CacheMode
+	rules []modeRule // later rules win
+
+	resolved sync.Map // key -> CacheMode, filled by ModeFor
+}
+
+// modeRule overrides the cache mode for keys matching filter.
+type modeRule struct {
+	filter ResetFilter
+	mode   CacheMode
+}
+
+// cacheShard holds a subset of positions behind its own lock.
+type cacheShard struct {
+	mu        sync.RWMutex
+	indexes   map[string]int      // key: ticker/pkg/category/apiKey
+	exhausted map[string]struct{} // positions that hit the end in exhaust mode
 }
 
 func NewIndexCache(mode CacheMode) *IndexCache {
-	return &IndexCache{
-		indexes: make(map[string]int),
-		mode:    mode,
+	return newIndexCache(mode, defaultCacheShards)
+}
+
+// newIndexCache creates a cache with the given shard count (a power of two).
+func newIndexCache(mode CacheMode, shardCount int) *IndexCache {
+	shards := make([]*cacheShard, shardCount)
+	for i := range shards {
+		shards[i] = &cacheShard{indexes: make(map[string]int), exhausted: make(map[string]struct{})}
 	}
+	c := &IndexCache{
+		shards: shards,
+		seed:   maphash.MakeSeed(),
+	}
+	c.modes.Store(&modeTable{def: mode})
+	return c
+}
+
+// shard returns the shard owning the given key.
+func (c *IndexCache) shard(key string) *cacheShard {
+	h := maphash.String(c.seed, key)
+	return c.shards[h&uint64(len(c.shards)-1)]
 }
 
 // CacheKey creates the composite key for index tracking (independent mode)
@@ -35,6 +93,24 @@ func SharedCacheKey(ticker, pkg, apiKey string) string {
 	return ticker + "/" + pkg + "/" + apiKey
 }
 
+// DatedKey returns the API key part of cache keys for apiKey replaying date
+// through the date query parameter, so each date keeps its own positions.
+func DatedKey(apiKey, date string) string {
+	return apiKey + "@" + date
+}
+
+// SplitDatedKey reverses DatedKey. date is empty for a plain API key.
+func SplitDatedKey(key string) (apiKey, date string) {
+	i := strings.LastIndexByte(key, '@')
+	if i < 0 {
+		return key, ""
+	}
+	if _, err := time.Parse("2006-01-02", key[i+1:]); err != nil {
+		return key, ""
+	}
+	return key[:i], key[i+1:]
+}
+
 // WSCacheKey creates the composite key for WebSocket index tracking.
 // Format: ws/{hub}/{ticker}/{category}/{apiKey}
 // The "ws/" prefix distinguishes WebSocket positions from REST positions.
@@ -45,80 +121,376 @@ func WSCacheKey(hub, ticker, category, apiKey string) string {
 // GetAndAdvance returns the current index and advances it
 // Returns (index, isExhausted)
 func (c *IndexCache) GetAndAdvance(key string, dataLength int) (int, bool) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	return c.GetAndAdvanceMode(key, dataLength, "")
+}
+
+// GetAndAdvanceMode is GetAndAdvance playing back in mode instead of the
+// mode of key, e.g. when a request overrides it. An empty mode uses ModeFor.
+func (c *IndexCache) GetAndAdvanceMode(key string, dataLength int, mode CacheMode) (int, bool) {
+	if mode == "" {
+		mode = c.ModeFor(key)
+	}
+	newlyExhausted := false
+	defer func() {
+		// Runs after the shard is unlocked, as it locks every shard
+		if newlyExhausted {
+			c.checkAllExhausted()
+		}
+	}()
+
+	sh := c.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
 
-	idx := c.indexes[key]
+	idx := sh.indexes[key]
 
 	// Check exhaustion in exhaust mode
-	if c.mode == CacheModeExhaust && idx >= dataLength {
+	if mode == CacheModeExhaust && idx >= dataLength {
+		if _, marked := sh.exhausted[key]; !marked {
+			sh.exhausted[key] = struct{}{}
+			sh.indexes[key] = idx // counts keys exhausted by empty data
+			newlyExhausted = true
+		}
 		return idx, true
 	}
+	// Nothing to rotate through
+	if dataLength <= 0 {
+		return 0, true
+	}
+	if len(sh.exhausted) > 0 {
+		delete(sh.exhausted, key)
+	}
+	if c.allExhausted.Load() {
+		c.allExhausted.Store(false)
+	}
 
 	// Get current index (may need wrap in rotation mode)
 	currentIdx := idx
-	if c.mode == CacheModeRotation && idx >= dataLength {
+	if mode == CacheModeRotation && idx >= dataLength {
 		currentIdx = idx % dataLength
 	}
 
 	// Advance for next request
-	if c.mode == CacheModeRotation {
-		c.indexes[key] = (idx + 1) % dataLength
+	if mode == CacheModeRotation {
+		sh.indexes[key] = (idx + 1) % dataLength
 	} else {
-		c.indexes[key] = idx + 1
+		sh.indexes[key] = idx + 1
 	}
+	c.record(cacheChange{Key: key, Index: sh.indexes[key]})
 
 	return currentIdx, false
 }
 
+// Peek returns the index the next GetAndAdvance would return, without
+// advancing or marking the position exhausted.
+// Returns (index, isExhausted)
+func (c *IndexCache) Peek(key string, dataLength int) (int, bool) {
+	idx := c.GetIndex(key)
+	if idx < dataLength {
+		return idx, false
+	}
+	if c.ModeFor(key) == CacheModeRotation && dataLength > 0 {
+		return idx % dataLength, false
+	}
+	return idx, true
+}
+
+// record journals a change of a position; the shard lock must be held so
+// the changes of a key are journaled in order.
+func (c *IndexCache) record(change cacheChange) {
+	if j := c.journal.Load(); j != nil {
+		j.record(change)
+	}
+}
+
+// OnAllExhausted registers fn to be called when the last position still
+// playing back is exhausted, i.e. every client has run out of data. It is
+// called again only after a position plays back again. fn runs on the
+// request goroutine and must not block.
+func (c *IndexCache) OnAllExhausted(fn func()) {
+	c.onExhausted.Store(&fn)
+}
+
+// checkAllExhausted calls the OnAllExhausted hook when every position is
+// exhausted and it was not already called for this.
+func (c *IndexCache) checkAllExhausted() {
+	fn := c.onExhausted.Load()
+	if fn == nil {
+		return
+	}
+	for _, sh := range c.shards {
+		sh.mu.RLock()
+		done := len(sh.exhausted) == len(sh.indexes)
+		sh.mu.RUnlock()
+		if !done {
+			return
+		}
+	}
+	if c.allExhausted.CompareAndSwap(false, true) {
+		(*fn)()
+	}
+}
+
 // Reset resets indexes, optionally for a specific API key pattern
 func (c *IndexCache) Reset(apiKey string) int {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	return c.ResetMatching(ResetFilter{APIKey: apiKey})
+}
 
-	if apiKey == "" {
-		// Reset all
-		count := len(c.indexes)
-		c.indexes = make(map[string]int)
-		return count
+// Category suffixes of the majors and max-change endpoints, which keep
+// positions apart from the full-record endpoint of the same category.
+const (
+	MajorsSuffix    = "_majors"
+	MaxChangeSuffix = "_maxchange"
+)
+
+// ResetFilter scopes a cache reset. Empty fields match everything; an empty
+// filter resets every position.
+type ResetFilter struct {
+	APIKey   string // exact API key, including its positions for other dates
+	Ticker   string // exact ticker (e.g. SPX)
+	Package  string // package (state, classic, orderflow) or WebSocket hub name
+	Category string // category; shared-mode keys carry none and match any category
+	Prefix   string // raw cache-key prefix (e.g. "ws/orderflow/")
+}
+
+// IsEmpty reports whether the filter matches every cache key.
+func (f ResetFilter) IsEmpty() bool {
+	return f == ResetFilter{}
+}
+
+// Matches reports whether a cache key satisfies every non-empty filter field.
+func (f ResetFilter) Matches(key string) bool {
+	if f.Prefix != "" && !strings.HasPrefix(key, f.Prefix) {
+		return false
+	}
+	if f.APIKey == "" && f.Ticker == "" && f.Package == "" && f.Category == "" {
+		return true
 	}
 
-	// Reset matching keys (ending with /apiKey)
-	suffix := "/" + apiKey
+	parts, ok := ParseCacheKey(key)
+	if !ok {
+		return false
+	}
+	if f.APIKey != "" && parts.APIKey != f.APIKey {
+		if apiKey, _ := SplitDatedKey(parts.APIKey); apiKey != f.APIKey {
+			return false
+		}
+	}
+	if f.Ticker != "" && parts.Ticker != f.Ticker {
+		return false
+	}
+	if f.Package != "" && parts.Package != f.Package && parts.Hub != f.Package {
+		return false
+	}
+	if f.Category != "" && parts.Category != "" && !f.matchesCategory(parts.Category) {
+		return false
+	}
+	return true
+}
+
+// matchesCategory reports whether category is the filter's category or its
+// majors or max-change position.
+func (f ResetFilter) matchesCategory(category string) bool {
+	switch category {
+	case f.Category, f.Category + MajorsSuffix, f.Category + MaxChangeSuffix:
+		return true
+	}
+	return false
+}
+
+// ResetMatching removes every position matching the filter and returns the count.
+func (c *IndexCache) ResetMatching(filter ResetFilter) int {
 	count := 0
-	for k := range c.indexes {
-		if len(k) > len(suffix) && k[len(k)-len(suffix):] == suffix {
-			delete(c.indexes, k)
+	for _, sh := range c.shards {
+		sh.mu.Lock()
+		if filter.IsEmpty() {
+			// Reset all
+			count += len(sh.indexes)
+			for k := range sh.indexes {
+				c.record(cacheChange{Key: k, Delete: true})
+			}
+			sh.indexes = make(map[string]int)
+			sh.exhausted = make(map[string]struct{})
+		} else {
+			for k := range sh.indexes {
+				if filter.Matches(k) {
+					delete(sh.indexes, k)
+					delete(sh.exhausted, k)
+					c.record(cacheChange{Key: k, Delete: true})
+					count++
+				}
+			}
+		}
+		sh.mu.Unlock()
+	}
+	return count
+}
+
+// ResetExceptKeys removes every position not owned by one of the given API
+// keys and returns the count. Positions of pinned keys, including those of
+// other dates, survive a date reload.
+func (c *IndexCache) ResetExceptKeys(keep map[string]bool) int {
+	if len(keep) == 0 {
+		return c.ResetMatching(ResetFilter{})
+	}
+	count := 0
+	for _, sh := range c.shards {
+		sh.mu.Lock()
+		for k := range sh.indexes {
+			if parts, ok := ParseCacheKey(k); ok {
+				if apiKey, _ := SplitDatedKey(parts.APIKey); keep[apiKey] {
+					continue
+				}
+			}
+			delete(sh.indexes, k)
+			delete(sh.exhausted, k)
+			c.record(cacheChange{Key: k, Delete: true})
 			count++
 		}
+		sh.mu.Unlock()
 	}
 	return count
 }
 
+// CacheKeyParts is the decoded form of a REST or WebSocket cache key.
+type CacheKeyParts struct {
+	Hub      string // WebSocket hub, empty for REST keys
+	Ticker   string
+	Package  string
+	Category string // empty for shared-mode REST keys
+	APIKey   string
+}
+
+// ParseCacheKey decodes any of the key formats produced by CacheKey,
+// SharedCacheKey and WSCacheKey.
+func ParseCacheKey(key string) (CacheKeyParts, bool) {
+	parts := strings.Split(key, "/")
+
+	if len(parts) == 5 && parts[0] == "ws" {
+		return CacheKeyParts{
+			Hub:      parts[1],
+			Ticker:   parts[2],
+			Package:  HubPackage(parts[1]),
+			Category: parts[3],
+			APIKey:   parts[4],
+		}, true
+	}
+
+	switch len(parts) {
+	case 4:
+		return CacheKeyParts{Ticker: parts[0], Package: parts[1], Category: parts[2], APIKey: parts[3]}, true
+	case 3:
+		return CacheKeyParts{Ticker: parts[0], Package: parts[1], APIKey: parts[2]}, true
+	default:
+		return CacheKeyParts{}, false
+	}
+}
+
+// HubPackage maps a WebSocket hub name to the data package it streams.
+func HubPackage(hub string) string {
+	switch hub {
+	case "state_gex", "state_greeks_zero", "state_greeks_one":
+		return "state"
+	default:
+		return hub
+	}
+}
+
 // GetIndex returns current index without advancing (for debugging)
 func (c *IndexCache) GetIndex(key string) int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return c.indexes[key]
+	sh := c.shard(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return sh.indexes[key]
+}
+
+// GetMatching returns every position matching the filter.
+func (c *IndexCache) GetMatching(filter ResetFilter) map[string]int {
+	result := make(map[string]int)
+	for _, sh := range c.shards {
+		sh.mu.RLock()
+		for k, v := range sh.indexes {
+			if filter.Matches(k) {
+				result[k] = v
+			}
+		}
+		sh.mu.RUnlock()
+	}
+	return result
+}
+
+// SetIndex moves a position to index. The next GetAndAdvance returns index.
+func (c *IndexCache) SetIndex(key string, index int) {
+	sh := c.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.indexes[key] = index
+	delete(sh.exhausted, key)
+	c.record(cacheChange{Key: key, Index: index})
+	c.allExhausted.Store(false)
 }
 
 // GetPositionsByAPIKey returns all positions matching the given API key suffix.
 // Cache keys are formatted as "ticker/pkg/category/apiKey" or "ws/hub/ticker/category/apiKey".
 func (c *IndexCache) GetPositionsByAPIKey(apiKey string) map[string]int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
 	suffix := "/" + apiKey
 	result := make(map[string]int)
-	for k, v := range c.indexes {
-		if len(k) > len(suffix) && k[len(k)-len(suffix):] == suffix {
-			result[k] = v
+	for _, sh := range c.shards {
+		sh.mu.RLock()
+		for k, v := range sh.indexes {
+			if len(k) > len(suffix) && k[len(k)-len(suffix):] == suffix {
+				result[k] = v
+			}
 		}
+		sh.mu.RUnlock()
 	}
 	return result
 }
 
-// GetMode returns the current cache mode.
+// GetMode returns the default cache mode.
 func (c *IndexCache) GetMode() CacheMode {
-	return c.mode
+	return c.modes.Load().def
+}
+
+// ModeFor returns the cache mode applied to key: the most recent override
+// matching it, or the default. Lookups are cached until the modes change.
+func (c *IndexCache) ModeFor(key string) CacheMode {
+	t := c.modes.Load()
+	if len(t.rules) == 0 {
+		return t.def
+	}
+	if mode, ok := t.resolved.Load(key); ok {
+		return mode.(CacheMode)
+	}
+	mode := t.def
+	for i := len(t.rules) - 1; i >= 0; i-- {
+		if t.rules[i].filter.Matches(key) {
+			mode = t.rules[i].mode
+			break
+		}
+	}
+	t.resolved.Store(key, mode)
+	return mode
+}
+
+// SetMode switches the cache mode of keys matching filter, including keys
+// created later. An empty filter changes the default and drops all overrides.
+func (c *IndexCache) SetMode(filter ResetFilter, mode CacheMode) {
+	c.modeMu.Lock()
+	defer c.modeMu.Unlock()
+
+	if filter.IsEmpty() {
+		c.modes.Store(&modeTable{def: mode})
+		return
+	}
+
+	old := c.modes.Load()
+	next := &modeTable{def: old.def, rules: make([]modeRule, 0, len(old.rules)+1)}
+	for _, r := range old.rules {
+		if r.filter != filter {
+			next.rules = append(next.rules, r)
+		}
+	}
+	next.rules = append(next.rules, modeRule{filter: filter, mode: mode})
+	c.modes.Store(next)
 }
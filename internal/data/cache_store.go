@@ -0,0 +1,261 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// cacheJournalBuffer is the number of changes buffered for the writer.
+// Changes beyond it are dropped and covered by an early snapshot instead.
+const cacheJournalBuffer = 1 << 16
+
+// cacheChange is one journaled change of a position.
+type cacheChange struct {
+	Key    string `json:"k"`
+	Index  int    `json:"i,omitempty"`
+	Delete bool   `json:"d,omitempty"`
+}
+
+// cacheSnapshot is the snapshot file.
+type cacheSnapshot struct {
+	Date      string         `json:"date"` // date the positions replay
+	Saved     time.Time      `json:"saved"`
+	Positions map[string]int `json:"positions"`
+}
+
+// CacheStore persists the positions of an IndexCache, so a restarted server
+// resumes every client where it was. Positions are saved in a snapshot file
+// every interval, and each change since is appended to a write-ahead log
+// (the snapshot path + ".wal") by a background writer.
+type CacheStore struct {
+	path   string
+	cache  *IndexCache
+	date   func() string
+	logger *zap.Logger
+
+	changes  chan cacheChange
+	overflow atomic.Bool // a change was dropped, snapshot early
+	requests chan chan error
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+
+	wal *os.File
+	w   *bufio.Writer
+}
+
+// OpenCacheStore restores the positions saved at path into cache when they
+// were saved for the date date returns, then journals the changes of cache.
+// Positions that cannot be restored are returned as an error along with a
+// store starting empty. Start begins writing.
+func OpenCacheStore(path string, cache *IndexCache, date func() string, logger *zap.Logger) (*CacheStore, int, error) {
+	s := &CacheStore{
+		path:     path,
+		cache:    cache,
+		date:     date,
+		logger:   logger,
+		changes:  make(chan cacheChange, cacheJournalBuffer),
+		requests: make(chan chan error),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	restored, restoreErr := s.restore()
+
+	// Compact the restored log into a fresh snapshot before journaling
+	if err := s.snapshot(); err != nil {
+		return nil, 0, err
+	}
+	cache.journal.Store(s)
+	return s, restored, restoreErr
+}
+
+// restore loads the snapshot and replays the log into the cache.
+func (s *CacheStore) restore() (int, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading cache snapshot: %w", err)
+	}
+	var snap cacheSnapshot
+	if err := json.Unmarshal(raw, &snap); err != nil {
+		return 0, fmt.Errorf("parsing cache snapshot %s: %w", s.path, err)
+	}
+	if date := s.date(); snap.Date != date {
+		s.logger.Info("cache snapshot is for another date, starting from index 0",
+			zap.String("snapshotDate", snap.Date),
+			zap.String("date", date),
+		)
+		return 0, nil
+	}
+
+	positions := snap.Positions
+	if positions == nil {
+		positions = make(map[string]int)
+	}
+	replayed, err := replayCacheLog(s.path+".wal", positions)
+	if err != nil {
+		s.logger.Warn("cache log partly replayed", zap.Int("changes", replayed), zap.Error(err))
+	}
+	for key, index := range positions {
+		s.cache.SetIndex(key, index)
+	}
+	return len(positions), nil
+}
+
+// replayCacheLog applies the changes logged at path to positions. A torn
+// last line, left by a crash mid-write, ends the replay.
+func replayCacheLog(path string, positions map[string]int) (int, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	n := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var change cacheChange
+		if err := json.Unmarshal(scanner.Bytes(), &change); err != nil {
+			return n, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		if change.Delete {
+			delete(positions, change.Key)
+		} else {
+			positions[change.Key] = change.Index
+		}
+		n++
+	}
+	return n, scanner.Err()
+}
+
+// record queues a change for the writer without blocking the request.
+func (s *CacheStore) record(change cacheChange) {
+	select {
+	case s.changes <- change:
+	default:
+		s.overflow.Store(true)
+	}
+}
+
+// Start writes changes to the log and a snapshot every interval until
+// Close.
+func (s *CacheStore) Start(interval time.Duration) {
+	go s.run(interval)
+}
+
+func (s *CacheStore) run(interval time.Duration) {
+	defer close(s.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case change := <-s.changes:
+			if err := json.NewEncoder(s.w).Encode(change); err != nil {
+				s.overflow.Store(true)
+			}
+			if len(s.changes) == 0 {
+				if err := s.w.Flush(); err != nil {
+					s.logger.Warn("failed to write cache log", zap.Error(err))
+					s.overflow.Store(true)
+				}
+			}
+		case <-ticker.C:
+			s.saveSnapshot()
+		case reply := <-s.requests:
+			reply <- s.snapshot()
+		case <-s.stop:
+			s.cache.journal.CompareAndSwap(s, nil)
+			s.saveSnapshot()
+			if err := s.wal.Close(); err != nil {
+				s.logger.Warn("failed to close cache log", zap.Error(err))
+			}
+			return
+		}
+
+		// Changes were lost; a snapshot covers them
+		if s.overflow.Swap(false) {
+			s.saveSnapshot()
+		}
+	}
+}
+
+// Snapshot saves the positions now, e.g. after a reload changed the date.
+func (s *CacheStore) Snapshot() error {
+	reply := make(chan error, 1)
+	select {
+	case s.requests <- reply:
+		return <-reply
+	case <-s.done:
+		return nil
+	}
+}
+
+// Close saves a final snapshot and stops writing.
+func (s *CacheStore) Close() error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+	return nil
+}
+
+func (s *CacheStore) saveSnapshot() {
+	if err := s.snapshot(); err != nil {
+		s.logger.Warn("failed to save cache snapshot", zap.String("path", s.path), zap.Error(err))
+	}
+}
+
+// snapshot writes the positions atomically and starts an empty log. Changes
+// still queued are at least as new as the snapshot and go to the new log;
+// those buffered for the old one are older and are discarded with it.
+func (s *CacheStore) snapshot() error {
+	snap := cacheSnapshot{
+		Date:      s.date(),
+		Saved:     time.Now().UTC(),
+		Positions: s.cache.GetMatching(ResetFilter{}),
+	}
+	raw, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if s.wal != nil {
+		_ = s.wal.Close()
+	}
+	wal, err := os.Create(s.path + ".wal")
+	if err != nil {
+		return err
+	}
+	s.wal = wal
+	if s.w == nil {
+		s.w = bufio.NewWriter(wal)
+	} else {
+		s.w.Reset(wal)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package data
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestCacheStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	date := "2025-01-02"
+	dateFn := func() string { return date }
+	alice := CacheKey("SPX", "classic", "gex_full", "alice")
+	bob := WSCacheKey("classic", "SPX", "gex_full", "bob")
+	carol := SharedCacheKey("SPX", "state", "carol")
+
+	cache := NewIndexCache(CacheModeExhaust)
+	store, _, err := OpenCacheStore(path, cache, dateFn, zap.NewNop())
+	if err != nil {
+		t.Fatalf("OpenCacheStore() error = %v", err)
+	}
+	store.Start(time.Hour)
+	for range 3 {
+		cache.GetAndAdvance(alice, 10)
+	}
+	cache.SetIndex(bob, 7)
+	cache.SetIndex(carol, 2)
+	cache.Reset("carol")
+
+	// Without Close, as in a crash: the positions come from the log
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		wal, _ := os.ReadFile(path + ".wal")
+		if bytes.Count(wal, []byte("\n")) == 6 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("log has %d lines, want 6", bytes.Count(wal, []byte("\n")))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	restored := NewIndexCache(CacheModeExhaust)
+	store2, n, err := OpenCacheStore(path, restored, dateFn, zap.NewNop())
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	store2.Start(time.Hour)
+	got := restored.GetMatching(ResetFilter{})
+	if n != 2 || got[alice] != 3 || got[bob] != 7 || len(got) != 2 {
+		t.Errorf("restored %d positions %v, want alice 3 and bob 7", n, got)
+	}
+	_ = store2.Close()
+
+	// Positions saved for another date are not restored
+	date = "2025-01-03"
+	other := NewIndexCache(CacheModeExhaust)
+	store3, n, err := OpenCacheStore(path, other, dateFn, zap.NewNop())
+	if err != nil {
+		t.Fatalf("reopen for another date error = %v", err)
+	}
+	if n != 0 || len(other.GetMatching(ResetFilter{})) != 0 {
+		t.Errorf("restored %d positions for another date, want 0", n)
+	}
+	store3.Start(time.Hour)
+	_ = store3.Close()
+}
@@ -0,0 +1,249 @@
+package data
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResetMatching(t *testing.T) {
+	keys := []string{
+		CacheKey("SPX", "classic", "gex_full", "alice"),
+		CacheKey("SPX", "state", "gex_zero", "alice"),
+		CacheKey("NDX", "classic", "gex_full", "alice"),
+		SharedCacheKey("SPX", "classic", "bob"),
+		WSCacheKey("state_gex", "SPX", "gex_full", "alice"),
+		WSCacheKey("orderflow", "SPX", "orderflow", "bob"),
+		CacheKey("SPX", "classic", "gex_zero"+MajorsSuffix, "carol"),
+		CacheKey("SPX", "classic", "gex_zero"+MaxChangeSuffix, "carol"),
+	}
+
+	tests := []struct {
+		name   string
+		filter ResetFilter
+		want   int
+	}{
+		{"empty filter resets all", ResetFilter{}, 8},
+		{"api key", ResetFilter{APIKey: "alice"}, 4},
+		{"ticker", ResetFilter{Ticker: "SPX"}, 7},
+		{"ticker and key", ResetFilter{Ticker: "SPX", APIKey: "bob"}, 2},
+		{"package includes ws hubs", ResetFilter{Package: "state"}, 2},
+		{"hub name", ResetFilter{Package: "state_gex"}, 1},
+		{"category matches shared, majors and max change keys", ResetFilter{Ticker: "SPX", Package: "classic", Category: "gex_zero"}, 3},
+		{"category with suffix", ResetFilter{Category: "gex_zero" + MajorsSuffix}, 2},
+		{"other category skips suffixed keys", ResetFilter{APIKey: "carol", Category: "gex_full"}, 0},
+		{"prefix", ResetFilter{Prefix: "ws/"}, 2},
+		{"prefix and key", ResetFilter{Prefix: "ws/", APIKey: "alice"}, 1},
+		{"no match", ResetFilter{Ticker: "RUT"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewIndexCache(CacheModeExhaust)
+			for _, k := range keys {
+				cache.GetAndAdvance(k, 10)
+			}
+
+			if got := cache.ResetMatching(tt.filter); got != tt.want {
+				t.Errorf("ResetMatching(%+v) = %d, want %d", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAndAdvanceConcurrent(t *testing.T) {
+	cache := NewIndexCache(CacheModeExhaust)
+	keys := benchmarkKeys(100)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				cache.GetAndAdvance(keys[i%len(keys)], 1<<20)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, k := range keys {
+		if got := cache.GetIndex(k); got != 80 {
+			t.Fatalf("GetIndex(%q) = %d, want 80", k, got)
+		}
+	}
+}
+
+func benchmarkKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = CacheKey("SPX", "classic", "gex_full", "key"+strconv.Itoa(i))
+	}
+	return keys
+}
+
+func benchmarkGetAndAdvance(b *testing.B, cache *IndexCache) {
+	keys := benchmarkKeys(5000)
+	var next atomic.Uint64
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := next.Add(7919)
+		for pb.Next() {
+			i++
+			cache.GetAndAdvance(keys[i%uint64(len(keys))], 1<<30)
+		}
+	})
+}
+
+// BenchmarkGetAndAdvanceSingleLock is the pre-sharding baseline: one lock for all keys.
+func BenchmarkGetAndAdvanceSingleLock(b *testing.B) {
+	benchmarkGetAndAdvance(b, newIndexCache(CacheModeExhaust, 1))
+}
+
+func BenchmarkGetAndAdvanceSharded(b *testing.B) {
+	benchmarkGetAndAdvance(b, NewIndexCache(CacheModeExhaust))
+}
+
+func TestSetMode(t *testing.T) {
+	cache := NewIndexCache(CacheModeExhaust)
+	alice := CacheKey("SPX", "classic", "gex_full", "alice")
+	bob := CacheKey("SPX", "classic", "gex_full", "bob")
+
+	cache.SetMode(ResetFilter{APIKey: "alice"}, CacheModeRotation)
+	if got := cache.ModeFor(alice); got != CacheModeRotation {
+		t.Errorf("alice mode = %s, want rotation", got)
+	}
+	if got := cache.ModeFor(bob); got != CacheModeExhaust {
+		t.Errorf("bob mode = %s, want exhaust", got)
+	}
+
+	// Overrides apply to positions before they are created
+	cache.SetIndex(alice, 2)
+	if idx, exhausted := cache.GetAndAdvance(alice, 2); idx != 0 || exhausted {
+		t.Errorf("alice at end: got (%d, %v), want wrap to 0", idx, exhausted)
+	}
+	cache.SetIndex(bob, 2)
+	if _, exhausted := cache.GetAndAdvance(bob, 2); !exhausted {
+		t.Error("bob at end: want exhausted")
+	}
+
+	// An empty filter replaces the default and drops overrides
+	cache.SetMode(ResetFilter{}, CacheModeRotation)
+	cache.SetMode(ResetFilter{Ticker: "SPX"}, CacheModeExhaust)
+	if got := cache.ModeFor(alice); got != CacheModeExhaust {
+		t.Errorf("alice mode after ticker override = %s, want exhaust", got)
+	}
+	if got := cache.GetMode(); got != CacheModeRotation {
+		t.Errorf("default mode = %s, want rotation", got)
+	}
+}
+
+func TestOnAllExhausted(t *testing.T) {
+	cache := NewIndexCache(CacheModeExhaust)
+	var calls int
+	cache.OnAllExhausted(func() { calls++ })
+	alice := CacheKey("SPX", "classic", "gex_full", "alice")
+	bob := CacheKey("SPX", "classic", "gex_full", "bob")
+
+	cache.GetAndAdvance(alice, 1)
+	cache.GetAndAdvance(bob, 1)
+	cache.GetAndAdvance(alice, 1) // alice exhausted, bob still playing
+	if calls != 0 {
+		t.Fatalf("calls = %d before bob is exhausted, want 0", calls)
+	}
+	cache.GetAndAdvance(bob, 1)
+	cache.GetAndAdvance(bob, 1)
+	if calls != 1 {
+		t.Fatalf("calls = %d once all are exhausted, want 1", calls)
+	}
+
+	// Called again only after a position plays back again
+	cache.Reset("alice")
+	cache.GetAndAdvance(alice, 1)
+	cache.GetAndAdvance(alice, 1)
+	if calls != 2 {
+		t.Errorf("calls = %d after alice was reset and exhausted again, want 2", calls)
+	}
+}
+
+func TestGetAndAdvanceModeAndPeek(t *testing.T) {
+	cache := NewIndexCache(CacheModeExhaust)
+	key := CacheKey("SPX", "classic", "gex_full", "alice")
+
+	cache.GetAndAdvance(key, 2)
+	if idx, exhausted := cache.Peek(key, 2); idx != 1 || exhausted {
+		t.Errorf("Peek = (%d, %v), want (1, false)", idx, exhausted)
+	}
+	if idx, _ := cache.Peek(key, 2); idx != 1 {
+		t.Errorf("second Peek = %d, want 1 (no advance)", idx)
+	}
+
+	cache.GetAndAdvance(key, 2)
+	if _, exhausted := cache.Peek(key, 2); !exhausted {
+		t.Error("Peek at end: want exhausted")
+	}
+	if idx, exhausted := cache.GetAndAdvanceMode(key, 2, CacheModeRotation); idx != 0 || exhausted {
+		t.Errorf("rotation override at end: got (%d, %v), want wrap to 0", idx, exhausted)
+	}
+	if got := cache.ModeFor(key); got != CacheModeExhaust {
+		t.Errorf("mode after override = %s, want exhaust", got)
+	}
+}
+
+func TestDatedKey(t *testing.T) {
+	dated := DatedKey("alice", "2025-11-14")
+	if key, date := SplitDatedKey(dated); key != "alice" || date != "2025-11-14" {
+		t.Errorf("SplitDatedKey(%q) = %q, %q", dated, key, date)
+	}
+	if key, date := SplitDatedKey("bob@example.com"); key != "bob@example.com" || date != "" {
+		t.Errorf("SplitDatedKey of an undated key = %q, %q", key, date)
+	}
+
+	// Resetting a key covers its positions for other dates
+	cache := NewIndexCache(CacheModeExhaust)
+	cache.SetIndex(CacheKey("SPX", "classic", "gex_full", "alice"), 1)
+	cache.SetIndex(CacheKey("SPX", "classic", "gex_full", dated), 1)
+	cache.SetIndex(CacheKey("SPX", "classic", "gex_full", "alicea"), 1)
+	if n := cache.Reset("alice"); n != 2 {
+		t.Errorf("Reset(alice) = %d, want 2", n)
+	}
+}
+
+func TestResetExceptKeys(t *testing.T) {
+	cache := NewIndexCache(CacheModeExhaust)
+	keys := []string{
+		CacheKey("SPX", "classic", "gex_full", "alice"),
+		CacheKey("SPX", "classic", "gex_full", DatedKey("alice", "2025-01-03")),
+		WSCacheKey("classic", "SPX", "gex_full", DatedKey("alice", "2025-01-03")),
+		CacheKey("SPX", "classic", "gex_full", "bob"),
+		CacheKey("SPX", "classic", "gex_full", DatedKey("bob", "2025-01-03")),
+	}
+	for _, k := range keys {
+		cache.SetIndex(k, 1)
+	}
+
+	if n := cache.ResetExceptKeys(map[string]bool{"alice": true}); n != 2 {
+		t.Errorf("ResetExceptKeys = %d, want bob's 2", n)
+	}
+	for i, k := range keys {
+		if want := i < 3; (cache.GetIndex(k) == 1) != want {
+			t.Errorf("position %q kept = %v, want %v", k, !want, want)
+		}
+	}
+}
+
+func TestGetAndAdvanceRotationEmptyData(t *testing.T) {
+	cache := newIndexCache(CacheModeRotation, 1)
+	empty := CacheKey("SPX", "classic", "gex_full", "alice")
+	other := CacheKey("SPX", "classic", "gex_zero", "alice")
+
+	if idx, exhausted := cache.GetAndAdvance(empty, 0); idx != 0 || !exhausted {
+		t.Errorf("empty data: got (%d, %v), want (0, true)", idx, exhausted)
+	}
+	// The shard is still usable
+	if idx, exhausted := cache.GetAndAdvance(other, 2); idx != 0 || exhausted {
+		t.Errorf("other key: got (%d, %v), want (0, false)", idx, exhausted)
+	}
+}
@@ -0,0 +1,220 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PlaybackClock replays data in time rather than one record per request:
+// each playback position serves the record whose timestamp matches a
+// simulated clock running speed times faster than real time. A position's
+// clock starts at the record it is at when first played, and starts again
+// from wherever the position is moved by a reset or an admin operation.
+//
+// A wall clock instead maps the time of day onto the date of the data for
+// every position alike, so a request at 10:05 simulated time always gets the
+// 10:05 record, however often it polls.
+type PlaybackClock struct {
+	speed float64
+	now   func() time.Time
+
+	loc       *time.Location
+	timeOfDay func(now time.Time) time.Duration // nil: each position runs its own clock
+
+	mu      sync.Mutex
+	anchors map[string]clockAnchor
+}
+
+// clockAnchor ties the simulated clock of a position to real time.
+type clockAnchor struct {
+	wall time.Time // real time the clock started
+	ts   int64     // data timestamp at wall
+	next int       // index the clock left the position at
+}
+
+// NewPlaybackClock creates a clock replaying at speed times real time.
+func NewPlaybackClock(speed float64) *PlaybackClock {
+	return &PlaybackClock{
+		speed:   speed,
+		now:     time.Now,
+		anchors: make(map[string]clockAnchor),
+	}
+}
+
+// NewWallClock creates a clock serving the record at the current time of
+// day in loc, on the date of the data.
+func NewWallClock(loc *time.Location) *PlaybackClock {
+	p := NewPlaybackClock(1)
+	p.loc = loc
+	p.timeOfDay = func(now time.Time) time.Duration {
+		h, m, s := now.In(loc).Clock()
+		return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	}
+	return p
+}
+
+// NewSessionClock creates a wall clock whose simulated time of day is start
+// when created, advancing at speed times real time.
+func NewSessionClock(loc *time.Location, start time.Duration, speed float64) *PlaybackClock {
+	p := NewPlaybackClock(speed)
+	p.loc = loc
+	created := p.now()
+	p.timeOfDay = func(now time.Time) time.Duration {
+		return start + time.Duration(float64(now.Sub(created))*speed)
+	}
+	return p
+}
+
+// Speed returns the playback speed.
+func (p *PlaybackClock) Speed() float64 {
+	return p.speed
+}
+
+// Advance moves the position key of cache to the record due at the
+// simulated time and returns it, like IndexCache.GetAndAdvanceMode. The same
+// record is returned until the clock reaches the next one. Data without
+// readable timestamps plays back one record per call.
+// Returns (index, isExhausted)
+func (p *PlaybackClock) Advance(ctx context.Context, cache *IndexCache, key string, loader DataLoader, ticker, pkg, category string, length int, mode CacheMode) (int, bool) {
+	return p.play(ctx, cache, key, loader, ticker, pkg, category, length, mode, true)
+}
+
+// Peek returns the record Advance would return now without moving the
+// position or starting its clock.
+// Returns (index, isExhausted)
+func (p *PlaybackClock) Peek(ctx context.Context, cache *IndexCache, key string, loader DataLoader, ticker, pkg, category string, length int, mode CacheMode) (int, bool) {
+	return p.play(ctx, cache, key, loader, ticker, pkg, category, length, mode, false)
+}
+
+func (p *PlaybackClock) play(ctx context.Context, cache *IndexCache, key string, loader DataLoader, ticker, pkg, category string, length int, mode CacheMode, advance bool) (int, bool) {
+	if mode == "" {
+		mode = cache.ModeFor(key)
+	}
+	fallback := func() (int, bool) {
+		if advance {
+			return cache.GetAndAdvanceMode(key, length, mode)
+		}
+		return cache.Peek(key, length)
+	}
+	if length == 0 {
+		return 0, true
+	}
+
+	now := p.now()
+	var ts int64
+	var anchor clockAnchor
+	if p.timeOfDay != nil {
+		wallTs, err := p.wallTimestamp(ctx, loader, ticker, pkg, category, now)
+		if err != nil {
+			return fallback()
+		}
+		ts = wallTs
+	} else {
+		current := cache.GetIndex(key)
+		p.mu.Lock()
+		saved, ok := p.anchors[key]
+		p.mu.Unlock()
+		anchor = saved
+
+		// Start the clock at the current record when the position is new or
+		// was moved since the clock last left it
+		if !ok || anchor.next != current {
+			start := current
+			if start >= length {
+				if mode == CacheModeExhaust {
+					return fallback()
+				}
+				start %= length
+			}
+			startTs, err := RecordTimestamp(ctx, loader, ticker, pkg, category, start)
+			if err != nil {
+				return fallback()
+			}
+			anchor = clockAnchor{wall: now, ts: startTs}
+		}
+		elapsed := float64(now.Sub(anchor.wall)) * p.speed
+		ts = anchor.ts + int64(elapsed/float64(time.Second))
+	}
+
+	idx, exhausted, err := recordAt(ctx, loader, ticker, pkg, category, length, ts, mode)
+	if err != nil {
+		return fallback()
+	}
+	if !advance {
+		return idx, exhausted
+	}
+
+	// Move the position, so admin views, history and persistence follow
+	// the clock
+	if exhausted {
+		idx = length
+		cache.SetIndex(key, idx)
+		anchor.next = idx
+		cache.GetAndAdvanceMode(key, length, mode)
+	} else {
+		cache.SetIndex(key, idx)
+		cache.GetAndAdvanceMode(key, length, mode)
+		anchor.next = idx + 1
+		if mode == CacheModeRotation {
+			anchor.next %= length
+		}
+	}
+	if p.timeOfDay == nil {
+		p.mu.Lock()
+		p.anchors[key] = anchor
+		p.mu.Unlock()
+	}
+	return idx, exhausted
+}
+
+// wallTimestamp maps the simulated time of day onto the date of the first
+// record. Stitched multi-day data would only ever play its first day, which
+// is why configuration rejects wall-clock replay with DATA_DAYS > 1.
+func (p *PlaybackClock) wallTimestamp(ctx context.Context, loader DataLoader, ticker, pkg, category string, now time.Time) (int64, error) {
+	first, err := RecordTimestamp(ctx, loader, ticker, pkg, category, 0)
+	if err != nil {
+		return 0, err
+	}
+	y, m, d := time.Unix(first, 0).In(p.loc).Date()
+	// time.Date normalizes the seconds as a wall time, so DST days map too
+	return time.Date(y, m, d, 0, 0, int(p.timeOfDay(now)/time.Second), 0, p.loc).Unix(), nil
+}
+
+// recordAt returns the last record at or before ts. Each record lasts the
+// average spacing of the data, after which the data is exhausted, or in
+// rotation mode starts over from the first record.
+func recordAt(ctx context.Context, loader DataLoader, ticker, pkg, category string, length int, ts int64, mode CacheMode) (int, bool, error) {
+	first, err := RecordTimestamp(ctx, loader, ticker, pkg, category, 0)
+	if err != nil {
+		return 0, false, err
+	}
+	last, err := RecordTimestamp(ctx, loader, ticker, pkg, category, length-1)
+	if err != nil {
+		return 0, false, err
+	}
+	end := last
+	if length > 1 {
+		end += (last - first) / int64(length-1)
+	}
+
+	if ts >= end && ts > last {
+		if mode != CacheModeRotation {
+			return length, true, nil
+		}
+		if span := end - first; span > 0 {
+			ts = first + (ts-first)%span
+		} else {
+			ts = first
+		}
+	}
+
+	idx, err := SearchTimestamp(ctx, loader, ticker, pkg, category, length, ts+1)
+	if err != nil {
+		return 0, false, err
+	}
+	if idx > 0 {
+		idx--
+	}
+	return idx, false, nil
+}
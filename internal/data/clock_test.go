@@ -0,0 +1,120 @@
+package data
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestPlaybackClock(t *testing.T) {
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "2025-01-02", "SPX", "orderflow")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"timestamp":100}` + "\n" + `{"timestamp":160}` + "\n" + `{"timestamp":220}` + "\n"
+	if err := os.WriteFile(filepath.Join(pkgDir, "orderflow.jsonl"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loader, err := NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+
+	ctx := context.Background()
+	cache := NewIndexCache(CacheModeExhaust)
+	key := CacheKey("SPX", "orderflow", "orderflow", "alice")
+
+	// 60x: one 60s record per real second
+	clock := NewPlaybackClock(60)
+	start := time.Unix(0, 0)
+	now := start
+	clock.now = func() time.Time { return now }
+	advance := func(after time.Duration) (int, bool) {
+		now = start.Add(after)
+		return clock.Advance(ctx, cache, key, loader, "SPX", "orderflow", "orderflow", 3, "")
+	}
+
+	for _, tc := range []struct {
+		after     time.Duration
+		want      int
+		exhausted bool
+	}{
+		{0, 0, false},
+		{500 * time.Millisecond, 0, false},
+		{time.Second, 1, false},
+		{2500 * time.Millisecond, 2, false},
+		{3 * time.Second, 3, true},
+	} {
+		idx, exhausted := advance(tc.after)
+		if idx != tc.want || exhausted != tc.exhausted {
+			t.Errorf("after %s: got (%d, %v), want (%d, %v)", tc.after, idx, exhausted, tc.want, tc.exhausted)
+		}
+	}
+
+	// A moved position restarts the clock there
+	cache.SetIndex(key, 1)
+	if idx, _ := advance(10 * time.Second); idx != 1 {
+		t.Errorf("after move: got %d, want 1", idx)
+	}
+	if idx, _ := advance(11 * time.Second); idx != 2 {
+		t.Errorf("1s after move: got %d, want 2", idx)
+	}
+
+	// Rotation starts over after the last record
+	if idx, exhausted := clock.Advance(ctx, cache, key, loader, "SPX", "orderflow", "orderflow", 3, CacheModeRotation); idx != 2 || exhausted {
+		t.Errorf("rotation: got (%d, %v), want (2, false)", idx, exhausted)
+	}
+	now = start.Add(12 * time.Second)
+	if idx, exhausted := clock.Advance(ctx, cache, key, loader, "SPX", "orderflow", "orderflow", 3, CacheModeRotation); idx != 0 || exhausted {
+		t.Errorf("rotation past end: got (%d, %v), want (0, false)", idx, exhausted)
+	}
+}
+
+func TestWallClock(t *testing.T) {
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "2025-01-02", "SPX", "orderflow")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// 00:01:40, 00:02:40 and 00:03:40 UTC
+	content := `{"timestamp":100}` + "\n" + `{"timestamp":160}` + "\n" + `{"timestamp":220}` + "\n"
+	if err := os.WriteFile(filepath.Join(pkgDir, "orderflow.jsonl"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loader, err := NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+
+	ctx := context.Background()
+	cache := NewIndexCache(CacheModeExhaust)
+	clock := NewWallClock(time.UTC)
+	at := func(clockTime string) (int, bool) {
+		now, err := time.Parse(time.RFC3339, "2026-03-10T"+clockTime+"Z")
+		if err != nil {
+			t.Fatal(err)
+		}
+		clock.now = func() time.Time { return now }
+		return clock.Advance(ctx, cache, CacheKey("SPX", "orderflow", "orderflow", "alice"), loader, "SPX", "orderflow", "orderflow", 3, "")
+	}
+
+	// Every poll at the same time of day gets the same record
+	for range 3 {
+		if idx, exhausted := at("00:02:50"); idx != 1 || exhausted {
+			t.Fatalf("at 00:02:50: got (%d, %v), want (1, false)", idx, exhausted)
+		}
+	}
+	if idx, _ := at("00:01:00"); idx != 0 {
+		t.Errorf("before the first record: got %d, want 0", idx)
+	}
+	if _, exhausted := at("00:05:00"); !exhausted {
+		t.Error("after the last record: want exhausted")
+	}
+}
@@ -0,0 +1,334 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// Encrypted data files keep their name and start with encryptMagic and a
+// random nonce prefix, followed by the content in AES-256-GCM sealed chunks.
+// Each chunk's nonce is the prefix, its big-endian index and a flag marking
+// the last chunk, so chunks cannot be reordered, dropped or truncated
+// without failing authentication. Compressed files are encrypted after
+// compression.
+const (
+	encryptMagic      = "GEXENC1\n"
+	encryptPrefixSize = 7
+	encryptHeaderSize = len(encryptMagic) + encryptPrefixSize
+	encryptChunkSize  = 64 << 10
+)
+
+// EncryptionKeySize is the size of an encryption key: AES-256.
+const EncryptionKeySize = 32
+
+// ErrNoKey is returned when opening an encrypted file without a key.
+var ErrNoKey = errors.New("file is encrypted and no encryption key is configured")
+
+// encryptionKey decrypts encrypted files opened by OpenJSONL.
+var encryptionKey atomic.Pointer[[]byte]
+
+// SetEncryptionKey sets the key OpenJSONL decrypts encrypted files with;
+// nil clears it.
+func SetEncryptionKey(key []byte) {
+	if key == nil {
+		encryptionKey.Store(nil)
+		return
+	}
+	encryptionKey.Store(&key)
+}
+
+// ReadKeyFile reads an encryption key stored as base64 or hex, e.g. made by
+// `openssl rand -base64 32`.
+func ReadKeyFile(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading encryption key: %w", err)
+	}
+	key, err := ParseKey(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// ParseKey decodes a 32-byte key written as base64 or hex.
+func ParseKey(s string) ([]byte, error) {
+	if key, err := hex.DecodeString(s); err == nil && len(key) == EncryptionKeySize {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(s); err == nil && len(key) == EncryptionKeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("encryption key must be %d bytes in base64 or hex", EncryptionKeySize)
+}
+
+// IsEncrypted reports whether the file at path is encrypted.
+func IsEncrypted(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = file.Close() }()
+	return hasEncryptMagic(file)
+}
+
+func hasEncryptMagic(r io.ReaderAt) (bool, error) {
+	buf := make([]byte, len(encryptMagic))
+	n, err := r.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	return n == len(buf) && string(buf) == encryptMagic, nil
+}
+
+// EncryptFile replaces the file at path with an encrypted copy. The copy is
+// written next to it and renamed over it, so the file is never left
+// partially encrypted.
+func EncryptFile(path string, key []byte) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	tmp := path + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	fail := func(err error) error {
+		_ = dst.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	enc, err := NewEncryptWriter(dst, key)
+	if err != nil {
+		return fail(err)
+	}
+	if _, err := io.Copy(enc, src); err != nil {
+		return fail(err)
+	}
+	if err := enc.Close(); err != nil {
+		return fail(err)
+	}
+	if err := dst.Sync(); err != nil {
+		return fail(err)
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes", EncryptionKeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of chunk n.
+func chunkNonce(prefix []byte, n uint32, last bool) []byte {
+	nonce := make([]byte, 0, encryptPrefixSize+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, n)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+type encryptWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	header []byte
+	buf    []byte
+	n      uint32
+	closed bool
+}
+
+// NewEncryptWriter returns a writer encrypting to w. Close must be called
+// to write the last chunk; it does not close w.
+func NewEncryptWriter(w io.Writer, key []byte) (io.WriteCloser, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, encryptHeaderSize)
+	copy(header, encryptMagic)
+	if _, err := rand.Read(header[len(encryptMagic):]); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, header: header, buf: make([]byte, 0, encryptChunkSize)}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("write to closed encrypted stream")
+	}
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is only sealed once more data follows, as the last
+		// chunk is sealed differently
+		if len(e.buf) == encryptChunkSize {
+			if err := e.seal(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(e.buf[len(e.buf):encryptChunkSize], p)
+		e.buf = e.buf[:len(e.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (e *encryptWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.seal(true)
+}
+
+func (e *encryptWriter) seal(last bool) error {
+	if e.n == ^uint32(0) {
+		return errors.New("encrypted stream too long")
+	}
+	nonce := chunkNonce(e.header[len(encryptMagic):], e.n, last)
+	sealed := e.aead.Seal(nil, nonce, e.buf, e.header)
+	e.n++
+	e.buf = e.buf[:0]
+	_, err := e.w.Write(sealed)
+	return err
+}
+
+type decryptReader struct {
+	r      *bufio.Reader
+	aead   cipher.AEAD
+	header []byte
+	chunk  []byte // sealed chunk being read
+	plain  []byte // unread plaintext of the current chunk
+	n      uint32
+	done   bool
+}
+
+// NewDecryptReader returns a reader decrypting an encrypted stream. Reads
+// fail for a stream that was truncated or modified, or encrypted with
+// another key.
+func NewDecryptReader(r io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, encryptHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("reading encryption header: %w", err)
+	}
+	if !bytes.HasPrefix(header, []byte(encryptMagic)) {
+		return nil, errors.New("not an encrypted file")
+	}
+	return &decryptReader{
+		r:      bufio.NewReaderSize(r, encryptChunkSize+aead.Overhead()+1),
+		aead:   aead,
+		header: header,
+		chunk:  make([]byte, encryptChunkSize+aead.Overhead()),
+	}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+// open reads and authenticates the next chunk. A chunk is the last one when
+// nothing follows it.
+func (d *decryptReader) open() error {
+	n, err := io.ReadFull(d.r, d.chunk)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return err
+	}
+	last := n < len(d.chunk)
+	if !last {
+		if _, err := d.r.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	nonce := chunkNonce(d.header[len(encryptMagic):], d.n, last)
+	plain, err := d.aead.Open(d.chunk[:0], nonce, d.chunk[:n], d.header)
+	if err != nil {
+		return errors.New("encrypted data is truncated, corrupt or encrypted with another key")
+	}
+	d.n++
+	d.plain = plain
+	d.done = last
+	return nil
+}
+
+// EncryptDate encrypts the JSONL files of the date directory dateDir that are
+// not encrypted yet and returns their paths relative to it, slash-separated.
+func EncryptDate(dateDir string, key []byte) ([]string, error) {
+	var encrypted []string
+	err := filepath.Walk(dateDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if _, ok := JSONLCategory(info.Name()); !ok {
+			return nil
+		}
+		if done, err := IsEncrypted(path); err != nil || done {
+			return err
+		}
+		if err := EncryptFile(path, key); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dateDir, path)
+		if err != nil {
+			return err
+		}
+		encrypted = append(encrypted, filepath.ToSlash(rel))
+		return nil
+	})
+	return encrypted, err
+}
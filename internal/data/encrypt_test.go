@@ -0,0 +1,159 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func testKey() []byte {
+	return bytes.Repeat([]byte{7}, EncryptionKeySize)
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	// Sizes around the chunk boundary, where the last chunk is decided
+	for _, size := range []int{0, 1, encryptChunkSize - 1, encryptChunkSize, encryptChunkSize + 1, 3 * encryptChunkSize} {
+		plain := bytes.Repeat([]byte("x"), size)
+		var sealed bytes.Buffer
+		w, err := NewEncryptWriter(&sealed, testKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(plain); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := NewDecryptReader(bytes.NewReader(sealed.Bytes()), testKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil || !bytes.Equal(got, plain) {
+			t.Errorf("size %d: read %d bytes, %v", size, len(got), err)
+		}
+
+		// Dropping the last chunk, or part of it, must not go unnoticed
+		last := size % encryptChunkSize
+		if last == 0 && size > 0 {
+			last = encryptChunkSize
+		}
+		for _, cut := range []int{1, last + 16} {
+			r, err := NewDecryptReader(bytes.NewReader(sealed.Bytes()[:sealed.Len()-cut]), testKey())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := io.ReadAll(r); err == nil {
+				t.Errorf("size %d: stream truncated by %d bytes read without error", size, cut)
+			}
+		}
+	}
+}
+
+func TestEncryptDetectsTampering(t *testing.T) {
+	var sealed bytes.Buffer
+	w, err := NewEncryptWriter(&sealed, testKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("{\"timestamp\":1}\n"))
+	_ = w.Close()
+
+	tampered := bytes.Clone(sealed.Bytes())
+	tampered[encryptHeaderSize] ^= 1
+	r, _ := NewDecryptReader(bytes.NewReader(tampered), testKey())
+	if _, err := io.ReadAll(r); err == nil {
+		t.Error("modified stream read without error")
+	}
+
+	otherKey := bytes.Repeat([]byte{8}, EncryptionKeySize)
+	r, _ = NewDecryptReader(bytes.NewReader(sealed.Bytes()), otherKey)
+	if _, err := io.ReadAll(r); err == nil {
+		t.Error("stream read with the wrong key")
+	}
+}
+
+func TestLoadersReadEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "2025-01-02", "SPX", "classic")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(pkgDir, "gex_full.jsonl.zst")
+	w, err := CreateJSONL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("{\"timestamp\":1}\n{\"timestamp\":2}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	plain := filepath.Join(pkgDir, "gex_zero.jsonl")
+	if err := os.WriteFile(plain, []byte("{\"timestamp\":3}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := EncryptDate(filepath.Join(dir, "2025-01-02"), testKey())
+	if err != nil || len(files) != 2 {
+		t.Fatalf("EncryptDate() = %v, %v", files, err)
+	}
+	if files, err := EncryptDate(filepath.Join(dir, "2025-01-02"), testKey()); err != nil || len(files) != 0 {
+		t.Errorf("second EncryptDate() = %v, %v, want nothing to do", files, err)
+	}
+
+	SetEncryptionKey(nil)
+	if _, err := OpenJSONL(path); !errors.Is(err, ErrNoKey) {
+		t.Errorf("OpenJSONL() without a key error = %v, want ErrNoKey", err)
+	}
+
+	SetEncryptionKey(testKey())
+	defer SetEncryptionKey(nil)
+
+	memory, err := NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewMemoryLoader: %v", err)
+	}
+	stream, err := NewStreamLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewStreamLoader: %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	for name, loader := range map[string]DataLoader{"memory": memory, "stream": stream} {
+		raw, err := loader.GetRawAtIndex(context.Background(), "SPX", "classic", "gex_full", 1)
+		if err != nil {
+			t.Fatalf("%s: GetRawAtIndex: %v", name, err)
+		}
+		if string(bytes.TrimSpace(raw)) != `{"timestamp":2}` {
+			t.Errorf("%s: record = %s", name, raw)
+		}
+		raw, err = loader.GetRawAtIndex(context.Background(), "SPX", "classic", "gex_zero", 0)
+		if err != nil || string(bytes.TrimSpace(raw)) != `{"timestamp":3}` {
+			t.Errorf("%s: gex_zero record = %s, %v", name, raw, err)
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	for _, s := range []string{
+		"BwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwc=",
+		"0707070707070707070707070707070707070707070707070707070707070707",
+	} {
+		if key, err := ParseKey(s); err != nil || !bytes.Equal(key, testKey()) {
+			t.Errorf("ParseKey(%q) = %x, %v", s, key, err)
+		}
+	}
+	if _, err := ParseKey("c2hvcnQ="); err == nil {
+		t.Error("ParseKey() accepted a short key")
+	}
+}
@@ -0,0 +1,170 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Data file extensions. JSONL files may be stored zstd-compressed; loaders
+// read both transparently.
+const (
+	JSONLExt     = ".jsonl"
+	JSONLZstdExt = ".jsonl.zst"
+)
+
+// JSONLCategory returns the category of a JSONL data file name such as
+// gex_zero.jsonl or gex_zero.jsonl.zst; ok is false for other files.
+func JSONLCategory(name string) (category string, ok bool) {
+	for _, ext := range []string{JSONLZstdExt, JSONLExt} {
+		if strings.HasSuffix(name, ext) {
+			return strings.TrimSuffix(name, ext), true
+		}
+	}
+	return "", false
+}
+
+// FindJSONL returns the JSONL file for base, a path without extension,
+// preferring an uncompressed .jsonl over .jsonl.zst.
+func FindJSONL(base string) (string, bool) {
+	for _, ext := range []string{JSONLExt, JSONLZstdExt} {
+		if _, err := os.Stat(base + ext); err == nil {
+			return base + ext, true
+		}
+	}
+	return "", false
+}
+
+// OpenJSONL opens a JSONL file for reading, decompressing .jsonl.zst files
+// and decrypting encrypted ones with the key set by SetEncryptionKey.
+func OpenJSONL(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	var r io.Reader = file
+	encrypted, err := hasEncryptMagic(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if encrypted {
+		key := encryptionKey.Load()
+		if key == nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("%s: %w", path, ErrNoKey)
+		}
+		if r, err = NewDecryptReader(file, *key); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+	}
+	if !strings.HasSuffix(path, JSONLZstdExt) {
+		if encrypted {
+			return &decryptReadCloser{Reader: r, file: file}, nil
+		}
+		return file, nil
+	}
+
+	dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("opening zstd stream: %w", err)
+	}
+	return &zstdReadCloser{dec: dec, file: file}, nil
+}
+
+type decryptReadCloser struct {
+	io.Reader
+	file *os.File
+}
+
+func (d *decryptReadCloser) Close() error {
+	return d.file.Close()
+}
+
+type zstdReadCloser struct {
+	dec  *zstd.Decoder
+	file *os.File
+}
+
+func (z *zstdReadCloser) Read(p []byte) (int, error) {
+	return z.dec.Read(p)
+}
+
+func (z *zstdReadCloser) Close() error {
+	z.dec.Close()
+	return z.file.Close()
+}
+
+// CreateJSONL creates a JSONL file for writing, compressing it when path
+// ends in .jsonl.zst. Close must be called to flush the compressed stream.
+// An existing file is replaced rather than truncated, so deduplicated hard
+// links to it keep their content.
+func CreateJSONL(path string) (io.WriteCloser, error) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, JSONLZstdExt) {
+		return file, nil
+	}
+
+	enc, err := zstd.NewWriter(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("opening zstd stream: %w", err)
+	}
+	return &zstdWriteCloser{enc: enc, file: file}, nil
+}
+
+type zstdWriteCloser struct {
+	enc  *zstd.Encoder
+	file *os.File
+}
+
+func (z *zstdWriteCloser) Write(p []byte) (int, error) {
+	return z.enc.Write(p)
+}
+
+func (z *zstdWriteCloser) Close() error {
+	err := z.enc.Close()
+	if closeErr := z.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// inflateJSONL decompresses or decrypts a JSONL file into an unlinked temp
+// file and returns it positioned at the start, for readers that need to
+// seek. The space is reclaimed when the file is closed.
+func inflateJSONL(path string) (*os.File, error) {
+	src, err := OpenJSONL(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = src.Close() }()
+
+	tmp, err := os.CreateTemp("", "gexbot-*.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	_ = os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		_ = tmp.Close()
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		_ = tmp.Close()
+		return nil, err
+	}
+	return tmp, nil
+}
@@ -0,0 +1,50 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestLoadersReadZstd(t *testing.T) {
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "2025-01-02", "SPX", "classic")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := CreateJSONL(filepath.Join(pkgDir, "gex_full.jsonl.zst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("{\"timestamp\":1}\n{\"timestamp\":2}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	memory, err := NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewMemoryLoader: %v", err)
+	}
+	stream, err := NewStreamLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewStreamLoader: %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	for name, loader := range map[string]DataLoader{"memory": memory, "stream": stream} {
+		raw, err := loader.GetRawAtIndex(context.Background(), "SPX", "classic", "gex_full", 1)
+		if err != nil {
+			t.Fatalf("%s: GetRawAtIndex: %v", name, err)
+		}
+		if string(bytes.TrimSpace(raw)) != `{"timestamp":2}` {
+			t.Errorf("%s: record = %s", name, raw)
+		}
+	}
+}
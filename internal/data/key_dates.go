@@ -0,0 +1,213 @@
+package data
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// KeyResolver maps an API key to the loader and date it replays.
+type KeyResolver interface {
+	Resolve(apiKey string) (DataLoader, string)
+}
+
+// KeyDateRouter pins API keys to dates other than the primary one so several
+// teams can replay different market days against one deployment. Unpinned keys
+// use the primary loader. Each pinned date gets its own loader, opened on first
+// assignment and closed once no key uses it. Preloaded dates stay loaded for
+// requests naming their date.
+type KeyDateRouter struct {
+	mu          sync.RWMutex
+	primary     DataLoader
+	primaryDate string
+	open        func(date string) (DataLoader, error)
+	keys        map[string]string     // apiKey -> pinned date
+	loaders     map[string]DataLoader // date -> loader, never the primary date
+	preloaded   map[string]bool       // dates kept loaded without a pinned key
+}
+
+// NewKeyDateRouter creates a router serving primaryDate from primary.
+// open loads the data for any other date a key is pinned to.
+func NewKeyDateRouter(primary DataLoader, primaryDate string, open func(date string) (DataLoader, error)) *KeyDateRouter {
+	return &KeyDateRouter{
+		primary:     primary,
+		primaryDate: primaryDate,
+		open:        open,
+		keys:        make(map[string]string),
+		loaders:     make(map[string]DataLoader),
+		preloaded:   make(map[string]bool),
+	}
+}
+
+// Resolve returns the loader and date serving apiKey.
+func (r *KeyDateRouter) Resolve(apiKey string) (DataLoader, string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if date, ok := r.keys[apiKey]; ok && date != r.primaryDate {
+		if loader, ok := r.loaders[date]; ok {
+			return loader, date
+		}
+	}
+	return r.primary, r.primaryDate
+}
+
+// ResolveDate returns the loader serving date, if it is loaded.
+func (r *KeyDateRouter) ResolveDate(date string) (DataLoader, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if date == r.primaryDate {
+		return r.primary, true
+	}
+	loader, ok := r.loaders[date]
+	return loader, ok
+}
+
+// Assign pins apiKey to date, loading the date if no other key uses it yet.
+func (r *KeyDateRouter) Assign(apiKey, date string) error {
+	return r.load(date, func() { r.keys[apiKey] = date })
+}
+
+// Preload loads date and keeps it loaded, pinned or not.
+func (r *KeyDateRouter) Preload(date string) error {
+	return r.load(date, func() { r.preloaded[date] = true })
+}
+
+// load loads date unless loaded, then records its use with mark under the
+// lock.
+func (r *KeyDateRouter) load(date string, mark func()) error {
+	r.mu.RLock()
+	_, loaded := r.loaders[date]
+	needsLoad := date != r.primaryDate && !loaded
+	r.mu.RUnlock()
+
+	// Load outside the lock so requests keep flowing while a date loads
+	var opened DataLoader
+	if needsLoad {
+		var err error
+		if opened, err = r.open(date); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	var unused []DataLoader
+	if opened != nil {
+		if _, ok := r.loaders[date]; ok || date == r.primaryDate {
+			unused = append(unused, opened) // lost a race with another assignment or reload
+		} else {
+			r.loaders[date] = opened
+		}
+	}
+	mark()
+	unused = append(unused, r.pruneLocked()...)
+	r.mu.Unlock()
+
+	return closeAll(unused)
+}
+
+// Unassign returns apiKey to the primary date. Reports whether it was pinned.
+func (r *KeyDateRouter) Unassign(apiKey string) (bool, error) {
+	r.mu.Lock()
+	_, ok := r.keys[apiKey]
+	delete(r.keys, apiKey)
+	unused := r.pruneLocked()
+	r.mu.Unlock()
+
+	return ok, closeAll(unused)
+}
+
+// Assignments returns a copy of the pinned keys and their dates.
+func (r *KeyDateRouter) Assignments() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string]string, len(r.keys))
+	for k, v := range r.keys {
+		out[k] = v
+	}
+	return out
+}
+
+// Dates returns the sorted non-primary dates currently loaded, pinned or
+// preloaded.
+func (r *KeyDateRouter) Dates() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	dates := make([]string, 0, len(r.loaders))
+	for d := range r.loaders {
+		dates = append(dates, d)
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// SetPrimaryDate records a hot reload of the primary loader from oldDate to
+// newDate. previous is the loader that served oldDate; it is kept for keys
+// still pinned to oldDate or when oldDate is preloaded, and closed otherwise. A separately loaded copy of
+// newDate becomes redundant and is closed.
+func (r *KeyDateRouter) SetPrimaryDate(newDate, oldDate string, previous DataLoader) error {
+	r.mu.Lock()
+	r.primaryDate = newDate
+	var unused []DataLoader
+	if r.inUseLocked(oldDate) && oldDate != newDate {
+		r.loaders[oldDate] = previous
+	} else {
+		unused = append(unused, previous)
+	}
+	unused = append(unused, r.pruneLocked()...)
+	r.mu.Unlock()
+
+	return closeAll(unused)
+}
+
+// Close releases every non-primary loader.
+func (r *KeyDateRouter) Close() error {
+	r.mu.Lock()
+	var loaders []DataLoader
+	for date, loader := range r.loaders {
+		loaders = append(loaders, loader)
+		delete(r.loaders, date)
+	}
+	r.mu.Unlock()
+
+	return closeAll(loaders)
+}
+
+// pruneLocked removes loaders for dates no key is pinned to and not preloaded
+// (or that became the primary date) and returns them for closing outside the
+// lock.
+func (r *KeyDateRouter) pruneLocked() []DataLoader {
+	var unused []DataLoader
+	for date, loader := range r.loaders {
+		if date == r.primaryDate || !r.inUseLocked(date) {
+			unused = append(unused, loader)
+			delete(r.loaders, date)
+		}
+	}
+	return unused
+}
+
+// inUseLocked reports whether date is preloaded or a key is pinned to it.
+func (r *KeyDateRouter) inUseLocked(date string) bool {
+	if r.preloaded[date] {
+		return true
+	}
+	for _, d := range r.keys {
+		if d == date {
+			return true
+		}
+	}
+	return false
+}
+
+func closeAll(loaders []DataLoader) error {
+	var errs []error
+	for _, l := range loaders {
+		if err := l.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Compile-time interface verification
+var _ KeyResolver = (*KeyDateRouter)(nil)
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"testing"
+)
+
+// dateLoader is a stub DataLoader that records whether it was closed.
+type dateLoader struct {
+	date   string
+	closed bool
+}
+
+func (l *dateLoader) GetAtIndex(ctx context.Context, ticker, pkg, category string, index int) (*GexData, error) {
+	return nil, ErrNotFound
+}
+
+func (l *dateLoader) GetRawAtIndex(ctx context.Context, ticker, pkg, category string, index int) ([]byte, error) {
+	return nil, ErrNotFound
+}
+
+func (l *dateLoader) GetLength(ticker, pkg, category string) (int, error) { return 0, ErrNotFound }
+func (l *dateLoader) Exists(ticker, pkg, category string) bool            { return false }
+func (l *dateLoader) GetLoadedKeys() []string                             { return nil }
+func (l *dateLoader) Close() error                                        { l.closed = true; return nil }
+
+func TestKeyDateRouter(t *testing.T) {
+	primary := &dateLoader{date: "2025-01-02"}
+	old := &dateLoader{date: "2025-01-02"}
+	opened := map[string]*dateLoader{}
+	r := NewKeyDateRouter(primary, "2025-01-02", func(date string) (DataLoader, error) {
+		l := &dateLoader{date: date}
+		opened[date] = l
+		return l, nil
+	})
+
+	if l, date := r.Resolve("alpha"); l != primary || date != "2025-01-02" {
+		t.Fatalf("unpinned key: got %v %s", l, date)
+	}
+
+	if err := r.Assign("alpha", "2025-01-03"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Assign("beta", "2025-01-03"); err != nil {
+		t.Fatal(err)
+	}
+	if len(opened) != 1 {
+		t.Fatalf("expected one shared loader for 2025-01-03, opened %d", len(opened))
+	}
+	if l, date := r.Resolve("alpha"); l != opened["2025-01-03"] || date != "2025-01-03" {
+		t.Fatalf("pinned key: got %v %s", l, date)
+	}
+
+	// The date stays loaded until its last key leaves
+	if _, err := r.Unassign("alpha"); err != nil {
+		t.Fatal(err)
+	}
+	if opened["2025-01-03"].closed {
+		t.Fatal("loader closed while beta still uses it")
+	}
+	if _, err := r.Unassign("beta"); err != nil {
+		t.Fatal(err)
+	}
+	if !opened["2025-01-03"].closed {
+		t.Fatal("expected unused loader to be closed")
+	}
+
+	// A reload keeps the old primary for keys pinned to it. In the server the
+	// primary is a ReloadableLoader, so only the loader it swapped out is passed.
+	if err := r.Assign("gamma", "2025-01-02"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetPrimaryDate("2025-01-06", "2025-01-02", old); err != nil {
+		t.Fatal(err)
+	}
+	if old.closed {
+		t.Fatal("previous primary closed while gamma is pinned to it")
+	}
+	if l, date := r.Resolve("gamma"); l != old || date != "2025-01-02" {
+		t.Fatalf("pinned to old primary: got %v %s", l, date)
+	}
+	if _, date := r.Resolve("delta"); date != "2025-01-06" {
+		t.Fatalf("unpinned key after reload: got %s", date)
+	}
+}
+
+func TestKeyDateRouterPreload(t *testing.T) {
+	primary := &dateLoader{date: "2025-01-02"}
+	opened := map[string]*dateLoader{}
+	r := NewKeyDateRouter(primary, "2025-01-02", func(date string) (DataLoader, error) {
+		l := &dateLoader{date: date}
+		opened[date] = l
+		return l, nil
+	})
+
+	if err := r.Preload("2025-01-03"); err != nil {
+		t.Fatal(err)
+	}
+	if l, ok := r.ResolveDate("2025-01-03"); !ok || l != opened["2025-01-03"] {
+		t.Fatalf("preloaded date: got %v %v", l, ok)
+	}
+	if l, ok := r.ResolveDate("2025-01-02"); !ok || l != primary {
+		t.Fatalf("primary date: got %v %v", l, ok)
+	}
+	if _, ok := r.ResolveDate("2025-01-06"); ok {
+		t.Fatal("date never loaded resolved")
+	}
+
+	// Preloaded dates outlive the keys pinned to them
+	if err := r.Assign("alpha", "2025-01-03"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Unassign("alpha"); err != nil {
+		t.Fatal(err)
+	}
+	if opened["2025-01-03"].closed {
+		t.Fatal("preloaded loader closed after its last key left")
+	}
+}
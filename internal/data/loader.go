@@ -32,6 +32,13 @@ type DataLoader interface {
 	Close() error
 }
 
+// Evictor is implemented by loaders that can shed memory under pressure.
+type Evictor interface {
+	// EvictCold releases the least recently used fraction (0..1] of cached data.
+	// Returns the number of entries evicted.
+	EvictCold(fraction float64) int
+}
+
 // DataKey creates a unique key for ticker/package/category
 func DataKey(ticker, pkg, category string) string {
 	return ticker + "/" + pkg + "/" + category
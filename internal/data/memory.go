@@ -2,11 +2,17 @@ package data
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -14,47 +20,75 @@ import (
 type MemoryLoader struct {
 	data   map[string][][]byte // key: ticker/pkg/category, stores raw JSON lines
 	logger *zap.Logger
+
+	// Eviction support: cold keys are demoted to on-disk reads via spill
+	mu         sync.RWMutex
+	paths      map[string]string        // key -> source JSONL path
+	lastAccess map[string]*atomic.Int64 // key -> last access (unix nanos)
+	spill      *StreamLoader            // evicted keys, read from disk
+	evictMu    sync.Mutex               // serializes EvictCold
 }
 
+// Compile-time interface verification
+var (
+	_ Evictor     = (*MemoryLoader)(nil)
+	_ RangeReader = (*MemoryLoader)(nil)
+)
+
 func NewMemoryLoader(dataDir, date string, logger *zap.Logger) (*MemoryLoader, error) {
 	loader := &MemoryLoader{
-		data:   make(map[string][][]byte),
-		logger: logger,
+		data:       make(map[string][][]byte),
+		logger:     logger,
+		paths:      make(map[string]string),
+		lastAccess: make(map[string]*atomic.Int64),
+		spill:      newEmptyStreamLoader(logger),
 	}
 
 	dateDir := filepath.Join(dataDir, date)
 
+	// Load summary counters
+	var summary jsonlStats
+	var filesFailed int
+
 	// Walk the date directory
 	err := filepath.Walk(dateDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() || filepath.Ext(path) != ".jsonl" {
+		if info.IsDir() {
+			return nil
+		}
+		category, ok := JSONLCategory(info.Name())
+		if !ok {
 			return nil
 		}
 
 		// Extract ticker/pkg/category from path
-		// Format: data/{date}/{ticker}/{pkg}/{category}.jsonl
+		// Format: data/{date}/{ticker}/{pkg}/{category}.jsonl[.zst]
 		rel, _ := filepath.Rel(dateDir, path)
 		// rel = "SPX/state/gex_full.jsonl"
 
 		ticker := filepath.Dir(filepath.Dir(rel))
 		pkg := filepath.Base(filepath.Dir(rel))
-		category := filepath.Base(rel)
-		category = category[:len(category)-6] // Remove .jsonl
 
 		key := DataKey(ticker, pkg, category)
 
-		data, err := loader.loadJSONL(path)
+		data, stats, err := loader.loadJSONL(path)
 		if err != nil {
 			logger.Warn("failed to load file", zap.String("path", path), zap.Error(err))
+			filesFailed++
 			return nil
 		}
+		summary.skippedLines += stats.skippedLines
+		summary.maxLineBytes = max(summary.maxLineBytes, stats.maxLineBytes)
 
 		loader.data[key] = data
+		loader.paths[key] = path
+		loader.lastAccess[key] = &atomic.Int64{}
 		logger.Info("loaded data",
 			zap.String("key", key),
 			zap.Int("count", len(data)),
+			zap.Int("skippedLines", stats.skippedLines),
 		)
 		return nil
 	})
@@ -63,6 +97,13 @@ func NewMemoryLoader(dataDir, date string, logger *zap.Logger) (*MemoryLoader, e
 		return nil, fmt.Errorf("walking data directory: %w", err)
 	}
 
+	logger.Info("memory load summary",
+		zap.Int("filesLoaded", len(loader.data)),
+		zap.Int("filesFailed", filesFailed),
+		zap.Int("skippedLines", summary.skippedLines),
+		zap.Int("maxLineBytes", summary.maxLineBytes),
+	)
+
 	if len(loader.data) == 0 {
 		return nil, fmt.Errorf("no JSONL files found in %s", dateDir)
 	}
@@ -70,37 +111,60 @@ func NewMemoryLoader(dataDir, date string, logger *zap.Logger) (*MemoryLoader, e
 	return loader, nil
 }
 
-func (m *MemoryLoader) loadJSONL(path string) ([][]byte, error) {
-	file, err := os.Open(path)
+// trimLineEnd strips the line ending the stream reader keeps, so spilled
+// records match the ones held in memory.
+func trimLineEnd(line []byte) []byte {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	return bytes.TrimSuffix(line, []byte("\r"))
+}
+
+// jsonlStats describes what loadJSONL read from a file.
+type jsonlStats struct {
+	skippedLines int // blank lines ignored
+	maxLineBytes int // longest record seen
+}
+
+// loadJSONL reads every non-empty line of a JSONL or .jsonl.zst file.
+// Lines have no size limit; full-chain records can be several MiB.
+func (m *MemoryLoader) loadJSONL(path string) ([][]byte, jsonlStats, error) {
+	var stats jsonlStats
+
+	file, err := OpenJSONL(path)
 	if err != nil {
-		return nil, err
+		return nil, stats, err
 	}
 	defer func() { _ = file.Close() }()
 
 	var data [][]byte
-	scanner := bufio.NewScanner(file)
-
-	// Increase buffer size for large lines
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 1024*1024)
+	reader := bufio.NewReaderSize(file, 64*1024)
 
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
+	for {
+		// ReadBytes returns a fresh slice, so no copy is needed
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if line[len(line)-1] == '\n' {
+				line = line[:len(line)-1]
+			}
+			if len(line) > 0 && line[len(line)-1] == '\r' {
+				line = line[:len(line)-1]
+			}
+			if len(line) == 0 {
+				stats.skippedLines++
+			} else {
+				stats.maxLineBytes = max(stats.maxLineBytes, len(line))
+				data = append(data, line)
+			}
 		}
 
-		// Make a copy since scanner reuses the buffer
-		lineCopy := make([]byte, len(line))
-		copy(lineCopy, line)
-		data = append(data, lineCopy)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, stats, err
+		}
 	}
 
-	return data, nil
+	return data, stats, nil
 }
 
 func (m *MemoryLoader) GetAtIndex(ctx context.Context, ticker, pkg, category string, index int) (*GexData, error) {
@@ -118,9 +182,18 @@ func (m *MemoryLoader) GetAtIndex(ctx context.Context, ticker, pkg, category str
 
 func (m *MemoryLoader) GetRawAtIndex(ctx context.Context, ticker, pkg, category string, index int) ([]byte, error) {
 	key := DataKey(ticker, pkg, category)
+
+	m.mu.RLock()
 	data, ok := m.data[key]
+	if ok {
+		m.lastAccess[key].Store(time.Now().UnixNano())
+	}
+	m.mu.RUnlock()
+
 	if !ok {
-		return nil, ErrNotFound
+		// Evicted keys are served from disk
+		line, err := m.spill.GetRawAtIndex(ctx, ticker, pkg, category, index)
+		return trimLineEnd(line), err
 	}
 	if index < 0 || index >= len(data) {
 		return nil, ErrIndexOutOfBounds
@@ -128,31 +201,125 @@ func (m *MemoryLoader) GetRawAtIndex(ctx context.Context, ticker, pkg, category
 	return data[index], nil
 }
 
+// GetRawRange returns the raw JSON records in [start, end).
+func (m *MemoryLoader) GetRawRange(ctx context.Context, ticker, pkg, category string, start, end int) ([][]byte, error) {
+	key := DataKey(ticker, pkg, category)
+
+	m.mu.RLock()
+	data, ok := m.data[key]
+	if ok {
+		m.lastAccess[key].Store(time.Now().UnixNano())
+	}
+	m.mu.RUnlock()
+
+	if !ok {
+		records, err := m.spill.GetRawRange(ctx, ticker, pkg, category, start, end)
+		for i, line := range records {
+			records[i] = trimLineEnd(line)
+		}
+		return records, err
+	}
+	if start < 0 || start > end || end > len(data) {
+		return nil, ErrIndexOutOfBounds
+	}
+	return data[start:end:end], nil
+}
+
 func (m *MemoryLoader) GetLength(ticker, pkg, category string) (int, error) {
 	key := DataKey(ticker, pkg, category)
+
+	m.mu.RLock()
 	data, ok := m.data[key]
+	m.mu.RUnlock()
+
 	if !ok {
-		return 0, ErrNotFound
+		return m.spill.GetLength(ticker, pkg, category)
 	}
 	return len(data), nil
 }
 
 func (m *MemoryLoader) Exists(ticker, pkg, category string) bool {
 	key := DataKey(ticker, pkg, category)
+
+	m.mu.RLock()
 	_, ok := m.data[key]
-	return ok
+	m.mu.RUnlock()
+
+	return ok || m.spill.Exists(ticker, pkg, category)
 }
 
 func (m *MemoryLoader) Close() error {
+	m.mu.Lock()
 	m.data = nil
-	return nil
+	m.mu.Unlock()
+	return m.spill.Close()
 }
 
 // GetLoadedKeys returns all loaded data keys (for /tickers endpoint)
 func (m *MemoryLoader) GetLoadedKeys() []string {
+	m.mu.RLock()
+	keys := make([]string, 0, len(m.data))
+	for k := range m.data {
+		keys = append(keys, k)
+	}
+	m.mu.RUnlock()
+
+	return append(keys, m.spill.GetLoadedKeys()...)
+}
+
+// EvictCold demotes the least recently accessed fraction of in-memory keys
+// to on-disk reads. Evicted keys remain available, just slower. The files are
+// indexed before the lock is taken, so reads carry on during an eviction.
+// Returns the number of keys evicted.
+func (m *MemoryLoader) EvictCold(fraction float64) int {
+	m.evictMu.Lock()
+	defer m.evictMu.Unlock()
+
+	m.mu.RLock()
+	if len(m.data) == 0 || fraction <= 0 {
+		m.mu.RUnlock()
+		return 0
+	}
 	keys := make([]string, 0, len(m.data))
 	for k := range m.data {
 		keys = append(keys, k)
 	}
-	return keys
+	sort.Slice(keys, func(i, j int) bool {
+		return m.lastAccess[keys[i]].Load() < m.lastAccess[keys[j]].Load()
+	})
+
+	target := int(float64(len(keys)) * fraction)
+	if target < 1 {
+		target = 1
+	}
+	paths := make(map[string]string, target)
+	for _, key := range keys[:target] {
+		paths[key] = m.paths[key]
+	}
+	m.mu.RUnlock()
+
+	// Index each file while it is still served from memory
+	demoted := make([]string, 0, len(paths))
+	for key, path := range paths {
+		if err := m.spill.addFile(key, path); err != nil {
+			m.logger.Warn("failed to demote key to disk", zap.String("key", key), zap.Error(err))
+			continue
+		}
+		demoted = append(demoted, key)
+	}
+
+	m.mu.Lock()
+	for _, key := range demoted {
+		delete(m.data, key)
+		delete(m.lastAccess, key)
+	}
+	m.mu.Unlock()
+	return len(demoted)
+}
+
+// InMemoryKeys returns the number of keys still held in memory.
+func (m *MemoryLoader) InMemoryKeys() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.data)
 }
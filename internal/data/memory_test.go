@@ -0,0 +1,98 @@
+package data
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryLoaderLargeLines(t *testing.T) {
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "2025-01-02", "SPX", "classic")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A 3 MiB record exceeds the old 1 MiB scanner cap
+	large := `{"timestamp":2,"pad":"` + strings.Repeat("x", 3*1024*1024) + `"}`
+	content := `{"timestamp":1}` + "\n\n" + large + "\n" + `{"timestamp":3}`
+	if err := os.WriteFile(filepath.Join(pkgDir, "gex_full.jsonl"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader, err := NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewMemoryLoader: %v", err)
+	}
+
+	length, err := loader.GetLength("SPX", "classic", "gex_full")
+	if err != nil {
+		t.Fatalf("GetLength: %v", err)
+	}
+	if length != 3 {
+		t.Fatalf("length = %d, want 3", length)
+	}
+
+	raw, err := loader.GetRawAtIndex(context.Background(), "SPX", "classic", "gex_full", 1)
+	if err != nil {
+		t.Fatalf("GetRawAtIndex: %v", err)
+	}
+	if string(raw) != large {
+		t.Errorf("large line truncated: got %d bytes, want %d", len(raw), len(large))
+	}
+}
+
+func TestMemoryLoaderEvictCold(t *testing.T) {
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "2025-01-02", "SPX", "classic")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, category := range []string{"gex_full", "gex_zero"} {
+		content := `{"timestamp":1}` + "\n" + `{"timestamp":2}` + "\n" + `{"timestamp":3}` + "\n"
+		if err := os.WriteFile(filepath.Join(pkgDir, category+".jsonl"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loader, err := NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewMemoryLoader: %v", err)
+	}
+	defer func() { _ = loader.Close() }()
+
+	// gex_full is hot, so gex_zero is the one demoted
+	ctx := context.Background()
+	if _, err := loader.GetRawAtIndex(ctx, "SPX", "classic", "gex_full", 0); err != nil {
+		t.Fatal(err)
+	}
+	if n := loader.EvictCold(0.5); n != 1 {
+		t.Fatalf("EvictCold = %d, want 1", n)
+	}
+	if n := loader.InMemoryKeys(); n != 1 {
+		t.Fatalf("InMemoryKeys = %d, want 1", n)
+	}
+
+	// The evicted key is still served, from disk
+	if !loader.Exists("SPX", "classic", "gex_zero") {
+		t.Error("evicted key no longer exists")
+	}
+	if length, err := loader.GetLength("SPX", "classic", "gex_zero"); err != nil || length != 3 {
+		t.Errorf("GetLength = %d, %v; want 3", length, err)
+	}
+	raw, err := loader.GetRawAtIndex(ctx, "SPX", "classic", "gex_zero", 1)
+	if err != nil || string(raw) != `{"timestamp":2}` {
+		t.Errorf("GetRawAtIndex = %q, %v", raw, err)
+	}
+	records, err := loader.GetRawRange(ctx, "SPX", "classic", "gex_zero", 1, 3)
+	if err != nil || len(records) != 2 || string(records[0]) != `{"timestamp":2}` || string(records[1]) != `{"timestamp":3}` {
+		t.Errorf("GetRawRange = %q, %v", records, err)
+	}
+	if keys := loader.GetLoadedKeys(); len(keys) != 2 {
+		t.Errorf("GetLoadedKeys = %v, want both keys", keys)
+	}
+}
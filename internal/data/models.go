@@ -3,74 +3,88 @@ package data
 import "encoding/json"
 
 type GexData struct {
-	Timestamp         int64           `json:"timestamp"`
-	Ticker            string          `json:"ticker"`
-	MinDTE            int             `json:"min_dte"`
-	SecMinDTE         int             `json:"sec_min_dte"`
-	Spot              float64         `json:"spot"`
-	ZeroGamma         float64         `json:"zero_gamma"`
-	MajorPosVol       float64         `json:"major_pos_vol"`
-	MajorPosOI        float64         `json:"major_pos_oi"`
-	MajorNegVol       float64         `json:"major_neg_vol"`
-	MajorNegOI        float64         `json:"major_neg_oi"`
-	Strikes           json.RawMessage `json:"strikes"`
-	SumGexVol         float64         `json:"sum_gex_vol"`
-	SumGexOI          float64         `json:"sum_gex_oi"`
-	DeltaRiskReversal float64         `json:"delta_risk_reversal"`
-	MaxPriors         json.RawMessage `json:"max_priors"`
+	Timestamp         int64           `json:"timestamp" parquet:"timestamp"`
+	Ticker            string          `json:"ticker" parquet:"ticker,dict"`
+	MinDTE            int             `json:"min_dte" parquet:"min_dte"`
+	SecMinDTE         int             `json:"sec_min_dte" parquet:"sec_min_dte"`
+	Spot              float64         `json:"spot" parquet:"spot"`
+	ZeroGamma         float64         `json:"zero_gamma" parquet:"zero_gamma"`
+	MajorPosVol       float64         `json:"major_pos_vol" parquet:"major_pos_vol"`
+	MajorPosOI        float64         `json:"major_pos_oi" parquet:"major_pos_oi"`
+	MajorNegVol       float64         `json:"major_neg_vol" parquet:"major_neg_vol"`
+	MajorNegOI        float64         `json:"major_neg_oi" parquet:"major_neg_oi"`
+	Strikes           json.RawMessage `json:"strikes" parquet:"strikes,json"`
+	SumGexVol         float64         `json:"sum_gex_vol" parquet:"sum_gex_vol"`
+	SumGexOI          float64         `json:"sum_gex_oi" parquet:"sum_gex_oi"`
+	DeltaRiskReversal float64         `json:"delta_risk_reversal" parquet:"delta_risk_reversal"`
+	MaxPriors         json.RawMessage `json:"max_priors" parquet:"max_priors,json"`
 }
 
 // GreekData represents options profile Greeks data (delta, gamma, charm, vanna)
 type GreekData struct {
-	Timestamp       int64           `json:"timestamp"`
-	Ticker          string          `json:"ticker"`
-	Spot            float64         `json:"spot"`
-	MinDTE          int             `json:"min_dte"`
-	SecMinDTE       int             `json:"sec_min_dte"`
-	MajorPositive   float64         `json:"major_positive"`
-	MajorNegative   float64         `json:"major_negative"`
-	MajorLongGamma  float64         `json:"major_long_gamma"`
-	MajorShortGamma float64         `json:"major_short_gamma"`
-	MiniContracts   json.RawMessage `json:"mini_contracts"`
+	Timestamp       int64           `json:"timestamp" parquet:"timestamp"`
+	Ticker          string          `json:"ticker" parquet:"ticker,dict"`
+	Spot            float64         `json:"spot" parquet:"spot"`
+	MinDTE          int             `json:"min_dte" parquet:"min_dte"`
+	SecMinDTE       int             `json:"sec_min_dte" parquet:"sec_min_dte"`
+	MajorPositive   float64         `json:"major_positive" parquet:"major_positive"`
+	MajorNegative   float64         `json:"major_negative" parquet:"major_negative"`
+	MajorLongGamma  float64         `json:"major_long_gamma" parquet:"major_long_gamma"`
+	MajorShortGamma float64         `json:"major_short_gamma" parquet:"major_short_gamma"`
+	MiniContracts   json.RawMessage `json:"mini_contracts" parquet:"mini_contracts,json"`
+}
+
+// VolatilityData represents an implied volatility surface snapshot. Strikes
+// holds [strike, call_iv, put_iv] rows for the heatmap.
+type VolatilityData struct {
+	Timestamp       int64           `json:"timestamp" parquet:"timestamp"`
+	Ticker          string          `json:"ticker" parquet:"ticker,dict"`
+	Spot            float64         `json:"spot" parquet:"spot"`
+	MinDTE          int             `json:"min_dte" parquet:"min_dte"`
+	SecMinDTE       int             `json:"sec_min_dte" parquet:"sec_min_dte"`
+	AtmIV           float64         `json:"atm_iv" parquet:"atm_iv"`
+	RiskReversal25d float64         `json:"risk_reversal_25d" parquet:"risk_reversal_25d"`
+	Butterfly25d    float64         `json:"butterfly_25d" parquet:"butterfly_25d"`
+	Strikes         json.RawMessage `json:"strikes" parquet:"strikes,json"`
 }
 
 // OrderflowData represents real-time orderflow metrics for nearest and next expiries
 type OrderflowData struct {
-	Timestamp     int64   `json:"timestamp"`
-	Ticker        string  `json:"ticker"`
-	Spot          float64 `json:"spot"`
-	ZMlgamma      float64 `json:"z_mlgamma"`
-	ZMsgamma      float64 `json:"z_msgamma"`
-	OMlgamma      float64 `json:"o_mlgamma"`
-	OMsgamma      float64 `json:"o_msgamma"`
-	ZeroMcall     float64 `json:"zero_mcall"`
-	ZeroMput      float64 `json:"zero_mput"`
-	OneMcall      float64 `json:"one_mcall"`
-	OneMput       float64 `json:"one_mput"`
-	Zcvr          float64 `json:"zcvr"`
-	Ocvr          float64 `json:"ocvr"`
-	Zgr           float64 `json:"zgr"`
-	Ogr           float64 `json:"ogr"`
-	Zvanna        float64 `json:"zvanna"`
-	Ovanna        float64 `json:"ovanna"`
-	Zcharm        float64 `json:"zcharm"`
-	Ocharm        float64 `json:"ocharm"`
-	AggDex        float64 `json:"agg_dex"`
-	OneAggDex     float64 `json:"one_agg_dex"`
-	AggCallDex    float64 `json:"agg_call_dex"`
-	OneAggCallDex float64 `json:"one_agg_call_dex"`
-	AggPutDex     float64 `json:"agg_put_dex"`
-	OneAggPutDex  float64 `json:"one_agg_put_dex"`
-	NetDex        float64 `json:"net_dex"`
-	OneNetDex     float64 `json:"one_net_dex"`
-	NetCallDex    float64 `json:"net_call_dex"`
-	OneNetCallDex float64 `json:"one_net_call_dex"`
-	NetPutDex     float64 `json:"net_put_dex"`
-	OneNetPutDex  float64 `json:"one_net_put_dex"`
-	Dexoflow      float64 `json:"dexoflow"`
-	Gexoflow      float64 `json:"gexoflow"`
-	Cvroflow      float64 `json:"cvroflow"`
-	OneDexoflow   float64 `json:"one_dexoflow"`
-	OneGexoflow   float64 `json:"one_gexoflow"`
-	OneCvroflow   float64 `json:"one_cvroflow"`
+	Timestamp     int64   `json:"timestamp" parquet:"timestamp"`
+	Ticker        string  `json:"ticker" parquet:"ticker,dict"`
+	Spot          float64 `json:"spot" parquet:"spot"`
+	ZMlgamma      float64 `json:"z_mlgamma" parquet:"z_mlgamma"`
+	ZMsgamma      float64 `json:"z_msgamma" parquet:"z_msgamma"`
+	OMlgamma      float64 `json:"o_mlgamma" parquet:"o_mlgamma"`
+	OMsgamma      float64 `json:"o_msgamma" parquet:"o_msgamma"`
+	ZeroMcall     float64 `json:"zero_mcall" parquet:"zero_mcall"`
+	ZeroMput      float64 `json:"zero_mput" parquet:"zero_mput"`
+	OneMcall      float64 `json:"one_mcall" parquet:"one_mcall"`
+	OneMput       float64 `json:"one_mput" parquet:"one_mput"`
+	Zcvr          float64 `json:"zcvr" parquet:"zcvr"`
+	Ocvr          float64 `json:"ocvr" parquet:"ocvr"`
+	Zgr           float64 `json:"zgr" parquet:"zgr"`
+	Ogr           float64 `json:"ogr" parquet:"ogr"`
+	Zvanna        float64 `json:"zvanna" parquet:"zvanna"`
+	Ovanna        float64 `json:"ovanna" parquet:"ovanna"`
+	Zcharm        float64 `json:"zcharm" parquet:"zcharm"`
+	Ocharm        float64 `json:"ocharm" parquet:"ocharm"`
+	AggDex        float64 `json:"agg_dex" parquet:"agg_dex"`
+	OneAggDex     float64 `json:"one_agg_dex" parquet:"one_agg_dex"`
+	AggCallDex    float64 `json:"agg_call_dex" parquet:"agg_call_dex"`
+	OneAggCallDex float64 `json:"one_agg_call_dex" parquet:"one_agg_call_dex"`
+	AggPutDex     float64 `json:"agg_put_dex" parquet:"agg_put_dex"`
+	OneAggPutDex  float64 `json:"one_agg_put_dex" parquet:"one_agg_put_dex"`
+	NetDex        float64 `json:"net_dex" parquet:"net_dex"`
+	OneNetDex     float64 `json:"one_net_dex" parquet:"one_net_dex"`
+	NetCallDex    float64 `json:"net_call_dex" parquet:"net_call_dex"`
+	OneNetCallDex float64 `json:"one_net_call_dex" parquet:"one_net_call_dex"`
+	NetPutDex     float64 `json:"net_put_dex" parquet:"net_put_dex"`
+	OneNetPutDex  float64 `json:"one_net_put_dex" parquet:"one_net_put_dex"`
+	Dexoflow      float64 `json:"dexoflow" parquet:"dexoflow"`
+	Gexoflow      float64 `json:"gexoflow" parquet:"gexoflow"`
+	Cvroflow      float64 `json:"cvroflow" parquet:"cvroflow"`
+	OneDexoflow   float64 `json:"one_dexoflow" parquet:"one_dexoflow"`
+	OneGexoflow   float64 `json:"one_gexoflow" parquet:"one_gexoflow"`
+	OneCvroflow   float64 `json:"one_cvroflow" parquet:"one_cvroflow"`
 }
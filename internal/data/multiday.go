@@ -0,0 +1,173 @@
+package data
+
+import (
+	"context"
+	"sort"
+)
+
+// MultiDayLoader stitches the loaders of consecutive dates into one stream per
+// ticker/package/category, so a single replay covers several days. Records of
+// each date follow those of the previous one; dates missing a category
+// contribute no records to it.
+type MultiDayLoader struct {
+	days []DataLoader // oldest first
+}
+
+// Compile-time interface verification
+var (
+	_ DataLoader  = (*MultiDayLoader)(nil)
+	_ Evictor     = (*MultiDayLoader)(nil)
+	_ RangeReader = (*MultiDayLoader)(nil)
+)
+
+// NewMultiDayLoader creates a loader replaying days in order, oldest first.
+func NewMultiDayLoader(days []DataLoader) *MultiDayLoader {
+	return &MultiDayLoader{days: days}
+}
+
+// OpenDays opens each date with open and stitches them into one loader. A
+// single date returns its loader as is. Loaders already opened are closed
+// when a later date fails.
+func OpenDays(dates []string, open func(date string) (DataLoader, error)) (DataLoader, error) {
+	days := make([]DataLoader, 0, len(dates))
+	for _, date := range dates {
+		loader, err := open(date)
+		if err != nil {
+			_ = closeAll(days)
+			return nil, err
+		}
+		days = append(days, loader)
+	}
+	if len(days) == 1 {
+		return days[0], nil
+	}
+	return NewMultiDayLoader(days), nil
+}
+
+// locate returns the day holding the stitched index and the index within it.
+func (m *MultiDayLoader) locate(ticker, pkg, category string, index int) (DataLoader, int, error) {
+	if index < 0 {
+		return nil, 0, ErrIndexOutOfBounds
+	}
+	found := false
+	for _, day := range m.days {
+		length, err := day.GetLength(ticker, pkg, category)
+		if err != nil {
+			continue
+		}
+		found = true
+		if index < length {
+			return day, index, nil
+		}
+		index -= length
+	}
+	if !found {
+		return nil, 0, ErrNotFound
+	}
+	return nil, 0, ErrIndexOutOfBounds
+}
+
+func (m *MultiDayLoader) GetAtIndex(ctx context.Context, ticker, pkg, category string, index int) (*GexData, error) {
+	day, local, err := m.locate(ticker, pkg, category, index)
+	if err != nil {
+		return nil, err
+	}
+	return day.GetAtIndex(ctx, ticker, pkg, category, local)
+}
+
+func (m *MultiDayLoader) GetRawAtIndex(ctx context.Context, ticker, pkg, category string, index int) ([]byte, error) {
+	day, local, err := m.locate(ticker, pkg, category, index)
+	if err != nil {
+		return nil, err
+	}
+	return day.GetRawAtIndex(ctx, ticker, pkg, category, local)
+}
+
+// GetRawRange returns the raw JSON records in [start, end), reading each
+// day's part with its range accessor.
+func (m *MultiDayLoader) GetRawRange(ctx context.Context, ticker, pkg, category string, start, end int) ([][]byte, error) {
+	if start < 0 || start > end {
+		return nil, ErrIndexOutOfBounds
+	}
+	records := make([][]byte, 0, end-start)
+	offset := 0
+	for _, day := range m.days {
+		if offset >= end {
+			break
+		}
+		length, err := day.GetLength(ticker, pkg, category)
+		if err != nil {
+			continue
+		}
+		from, to := max(start, offset), min(end, offset+length)
+		if from < to {
+			part, err := GetRawRange(ctx, day, ticker, pkg, category, from-offset, to-offset)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, part...)
+		}
+		offset += length
+	}
+	if offset < end {
+		return nil, ErrIndexOutOfBounds
+	}
+	return records, nil
+}
+
+// GetLength returns the number of records over all days.
+func (m *MultiDayLoader) GetLength(ticker, pkg, category string) (int, error) {
+	total, found := 0, false
+	for _, day := range m.days {
+		length, err := day.GetLength(ticker, pkg, category)
+		if err != nil {
+			continue
+		}
+		total += length
+		found = true
+	}
+	if !found {
+		return 0, ErrNotFound
+	}
+	return total, nil
+}
+
+func (m *MultiDayLoader) Exists(ticker, pkg, category string) bool {
+	for _, day := range m.days {
+		if day.Exists(ticker, pkg, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLoadedKeys returns the keys loaded on any day.
+func (m *MultiDayLoader) GetLoadedKeys() []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, day := range m.days {
+		for _, key := range day.GetLoadedKeys() {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// EvictCold evicts the same fraction from every day that supports eviction.
+func (m *MultiDayLoader) EvictCold(fraction float64) int {
+	evicted := 0
+	for _, day := range m.days {
+		if evictor, ok := day.(Evictor); ok {
+			evicted += evictor.EvictCold(fraction)
+		}
+	}
+	return evicted
+}
+
+func (m *MultiDayLoader) Close() error {
+	return closeAll(m.days)
+}
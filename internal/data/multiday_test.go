@@ -0,0 +1,59 @@
+package data
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMultiDayLoader(t *testing.T) {
+	dir := t.TempDir()
+	write := func(date, category, content string) {
+		pkgDir := filepath.Join(dir, date, "SPX", "orderflow")
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pkgDir, category+".jsonl"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("2025-01-02", "orderflow", `{"timestamp":100}`+"\n"+`{"timestamp":160}`+"\n")
+	write("2025-01-03", "orderflow", `{"timestamp":200}`+"\n")
+	write("2025-01-03", "extra", `{"timestamp":210}`+"\n")
+	write("2025-01-06", "orderflow", `{"timestamp":300}`+"\n"+`{"timestamp":360}`+"\n")
+
+	loader, err := OpenDays([]string{"2025-01-02", "2025-01-03", "2025-01-06"}, func(date string) (DataLoader, error) {
+		return NewStreamLoader(dir, date, zap.NewNop())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+
+	if n, err := loader.GetLength("SPX", "orderflow", "orderflow"); err != nil || n != 5 {
+		t.Fatalf("GetLength = %d, %v, want 5", n, err)
+	}
+	if n, err := loader.GetLength("SPX", "orderflow", "extra"); err != nil || n != 1 {
+		t.Fatalf("GetLength of a category loaded on one day = %d, %v, want 1", n, err)
+	}
+
+	ctx := context.Background()
+	for idx, want := range []int64{100, 160, 200, 300, 360} {
+		ts, err := RecordTimestamp(ctx, loader, "SPX", "orderflow", "orderflow", idx)
+		if err != nil || ts != want {
+			t.Errorf("record %d: timestamp %d, %v, want %d", idx, ts, err, want)
+		}
+	}
+	if _, err := loader.GetRawAtIndex(ctx, "SPX", "orderflow", "orderflow", 5); err != ErrIndexOutOfBounds {
+		t.Errorf("past the end: err = %v, want ErrIndexOutOfBounds", err)
+	}
+
+	records, err := GetRawRange(ctx, loader, "SPX", "orderflow", "orderflow", 1, 4)
+	if err != nil || len(records) != 3 || strings.TrimSpace(string(records[2])) != `{"timestamp":300}` {
+		t.Errorf("GetRawRange(1, 4) = %q, %v", records, err)
+	}
+}
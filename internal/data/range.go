@@ -0,0 +1,69 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// RangeReader is implemented by loaders that can read a run of consecutive
+// records more cheaply than one GetRawAtIndex call per record.
+type RangeReader interface {
+	// GetRawRange returns the raw JSON records in [start, end).
+	GetRawRange(ctx context.Context, ticker, pkg, category string, start, end int) ([][]byte, error)
+}
+
+// GetRawRange returns the raw JSON records in [start, end), using the
+// loader's range accessor when it has one.
+func GetRawRange(ctx context.Context, loader DataLoader, ticker, pkg, category string, start, end int) ([][]byte, error) {
+	if rr, ok := loader.(RangeReader); ok {
+		return rr.GetRawRange(ctx, ticker, pkg, category, start, end)
+	}
+	if start < 0 || start > end {
+		return nil, ErrIndexOutOfBounds
+	}
+	records := make([][]byte, 0, end-start)
+	for i := start; i < end; i++ {
+		raw, err := loader.GetRawAtIndex(ctx, ticker, pkg, category, i)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, raw)
+	}
+	return records, nil
+}
+
+// SearchTimestamp returns the first index in [0, n) whose record timestamp is
+// at least ts, or n if there is none. Records are assumed to be in timestamp
+// order, as downloaded.
+func SearchTimestamp(ctx context.Context, loader DataLoader, ticker, pkg, category string, n int, ts int64) (int, error) {
+	var searchErr error
+	idx := sort.Search(n, func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		recordTs, err := RecordTimestamp(ctx, loader, ticker, pkg, category, i)
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return recordTs >= ts
+	})
+	return idx, searchErr
+}
+
+// RecordTimestamp returns the timestamp of the record at index.
+func RecordTimestamp(ctx context.Context, loader DataLoader, ticker, pkg, category string, index int) (int64, error) {
+	raw, err := loader.GetRawAtIndex(ctx, ticker, pkg, category, index)
+	if err != nil {
+		return 0, err
+	}
+	var record struct {
+		Timestamp int64 `json:"timestamp"`
+	}
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return 0, fmt.Errorf("unmarshal timestamp: %w", err)
+	}
+	return record.Timestamp, nil
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestRangeAndSearch(t *testing.T) {
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "2025-01-02", "SPX", "orderflow")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"timestamp":100}` + "\n" + `{"timestamp":160}` + "\n\n" + `{"timestamp":220}` + "\n" + `{"timestamp":280}` + "\n"
+	if err := os.WriteFile(filepath.Join(pkgDir, "orderflow.jsonl"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	memory, err := NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memory.Close()
+	stream, err := NewStreamLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	ctx := context.Background()
+	for name, loader := range map[string]DataLoader{"memory": memory, "stream": stream} {
+		idx, err := SearchTimestamp(ctx, loader, "SPX", "orderflow", "orderflow", 4, 161)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if idx != 2 {
+			t.Errorf("%s: SearchTimestamp = %d, want 2", name, idx)
+		}
+
+		records, err := GetRawRange(ctx, loader, "SPX", "orderflow", "orderflow", 1, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 2 {
+			t.Fatalf("%s: got %d records, want 2", name, len(records))
+		}
+		if ts, _ := RecordTimestamp(ctx, loader, "SPX", "orderflow", "orderflow", 2); ts != 220 {
+			t.Errorf("%s: record 2 timestamp = %d, want 220", name, ts)
+		}
+
+		if _, err := GetRawRange(ctx, loader, "SPX", "orderflow", "orderflow", 2, 5); err != ErrIndexOutOfBounds {
+			t.Errorf("%s: out of range read: got %v", name, err)
+		}
+	}
+}
@@ -44,6 +44,13 @@ func (r *ReloadableLoader) GetRawAtIndex(ctx context.Context, ticker, pkg, categ
 	return r.current.GetRawAtIndex(ctx, ticker, pkg, category, index)
 }
 
+// GetRawRange returns the raw JSON records in [start, end).
+func (r *ReloadableLoader) GetRawRange(ctx context.Context, ticker, pkg, category string, start, end int) ([][]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return GetRawRange(ctx, r.current, ticker, pkg, category, start, end)
+}
+
 // GetLength returns the number of data points available.
 func (r *ReloadableLoader) GetLength(ticker, pkg, category string) (int, error) {
 	r.mu.RLock()
@@ -65,6 +72,17 @@ func (r *ReloadableLoader) GetLoadedKeys() []string {
 	return r.current.GetLoadedKeys()
 }
 
+// EvictCold delegates to the current loader if it supports eviction.
+// Returns 0 when the current loader holds nothing evictable (e.g. stream mode).
+func (r *ReloadableLoader) EvictCold(fraction float64) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if evictor, ok := r.current.(Evictor); ok {
+		return evictor.EvictCold(fraction)
+	}
+	return 0
+}
+
 // Close releases any resources held by the current loader.
 func (r *ReloadableLoader) Close() error {
 	r.mu.Lock()
@@ -73,4 +91,7 @@ func (r *ReloadableLoader) Close() error {
 }
 
 // Compile-time interface verification
-var _ DataLoader = (*ReloadableLoader)(nil)
+var (
+	_ DataLoader  = (*ReloadableLoader)(nil)
+	_ RangeReader = (*ReloadableLoader)(nil)
+)
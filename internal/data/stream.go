@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"go.uber.org/zap"
@@ -24,7 +25,19 @@ type StreamLoader struct {
 }
 
 // Compile-time interface verification
-var _ DataLoader = (*StreamLoader)(nil)
+var (
+	_ DataLoader  = (*StreamLoader)(nil)
+	_ RangeReader = (*StreamLoader)(nil)
+)
+
+// newEmptyStreamLoader creates a StreamLoader with no files; use addFile to populate it.
+func newEmptyStreamLoader(logger *zap.Logger) *StreamLoader {
+	return &StreamLoader{
+		indexes: make(map[string][]int64),
+		files:   make(map[string]*os.File),
+		logger:  logger,
+	}
+}
 
 func NewStreamLoader(dataDir, date string, logger *zap.Logger) (*StreamLoader, error) {
 	loader := &StreamLoader{
@@ -40,19 +53,21 @@ func NewStreamLoader(dataDir, date string, logger *zap.Logger) (*StreamLoader, e
 		if err != nil {
 			return err
 		}
-		if info.IsDir() || filepath.Ext(path) != ".jsonl" {
+		if info.IsDir() {
+			return nil
+		}
+		category, ok := JSONLCategory(info.Name())
+		if !ok {
 			return nil
 		}
 
 		// Extract ticker/pkg/category from path
-		// Format: data/{date}/{ticker}/{pkg}/{category}.jsonl
+		// Format: data/{date}/{ticker}/{pkg}/{category}.jsonl[.zst]
 		rel, _ := filepath.Rel(dateDir, path)
 		// rel = "SPX/state/gex_full.jsonl"
 
 		ticker := filepath.Dir(filepath.Dir(rel))
 		pkg := filepath.Base(filepath.Dir(rel))
-		category := filepath.Base(rel)
-		category = category[:len(category)-6] // Remove .jsonl
 
 		key := DataKey(ticker, pkg, category)
 
@@ -88,7 +103,7 @@ func NewStreamLoader(dataDir, date string, logger *zap.Logger) (*StreamLoader, e
 // indexFile scans the file and records byte offsets for each line.
 // Returns the offsets slice and keeps the file open for later reads.
 func (s *StreamLoader) indexFile(path string) ([]int64, *os.File, error) {
-	file, err := os.Open(path)
+	file, err := openSeekable(path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -125,6 +140,44 @@ func (s *StreamLoader) indexFile(path string) ([]int64, *os.File, error) {
 	return offsets, file, nil
 }
 
+// openSeekable opens a JSONL file for random access. Compressed and
+// encrypted files are decoded to a temp file first, since their streams
+// cannot seek.
+func openSeekable(path string) (*os.File, error) {
+	if strings.HasSuffix(path, JSONLZstdExt) {
+		return inflateJSONL(path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted, err := hasEncryptMagic(file); err != nil || encrypted {
+		_ = file.Close()
+		if err != nil {
+			return nil, err
+		}
+		return inflateJSONL(path)
+	}
+	return file, nil
+}
+
+// addFile indexes a single JSONL file and registers it under key.
+func (s *StreamLoader) addFile(key, path string) error {
+	offsets, file, err := s.indexFile(path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.files[key]; ok {
+		_ = old.Close()
+	}
+	s.indexes[key] = offsets
+	s.files[key] = file
+	return nil
+}
+
 func (s *StreamLoader) GetAtIndex(ctx context.Context, ticker, pkg, category string, index int) (*GexData, error) {
 	rawData, err := s.GetRawAtIndex(ctx, ticker, pkg, category, index)
 	if err != nil {
@@ -174,6 +227,42 @@ func (s *StreamLoader) GetRawAtIndex(ctx context.Context, ticker, pkg, category
 	return line, nil
 }
 
+// GetRawRange returns the raw JSON records in [start, end) under a single
+// file lock, so a history read is not interleaved with other requests.
+func (s *StreamLoader) GetRawRange(ctx context.Context, ticker, pkg, category string, start, end int) ([][]byte, error) {
+	key := DataKey(ticker, pkg, category)
+
+	s.mu.RLock()
+	offsets, ok := s.indexes[key]
+	file := s.files[key]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if start < 0 || start > end || end > len(offsets) {
+		return nil, ErrIndexOutOfBounds
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make([][]byte, 0, end-start)
+	reader := bufio.NewReader(file)
+	for i := start; i < end; i++ {
+		if _, err := file.Seek(offsets[i], io.SeekStart); err != nil {
+			return nil, fmt.Errorf("seek error: %w", err)
+		}
+		reader.Reset(file)
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("read error: %w", err)
+		}
+		records = append(records, line)
+	}
+	return records, nil
+}
+
 func (s *StreamLoader) GetLength(ticker, pkg, category string) (int, error) {
 	key := DataKey(ticker, pkg, category)
 
@@ -0,0 +1,277 @@
+// Package dedupe stores data files whose content is already in the output
+// directory as links to a single copy. Upstream files are sometimes
+// identical across adjacent dates when the data is stale.
+package dedupe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+// Dedupe modes.
+const (
+	ModeOff      = "off"
+	ModeHardlink = "hardlink" // duplicates become hard links to the first copy
+	ModeSymlink  = "symlink"  // every copy becomes a symlink into ObjectsDir
+)
+
+// IndexFile is the dedupe index in the output directory.
+const IndexFile = ".dedupe.json"
+
+// ObjectsDir is the directory in the output directory holding the content
+// symlinks point at, one file per digest.
+const ObjectsDir = ".dedupe"
+
+// Entry is the stored copy of one content digest.
+type Entry struct {
+	Path string `json:"path"` // relative to the output directory, slash-separated
+	Size int64  `json:"size"`
+}
+
+// Index maps SHA-256 digests to their stored copy.
+type Index struct {
+	path  string
+	Files map[string]Entry `json:"files"`
+}
+
+// LoadIndex reads the index of the output directory root. A missing index
+// is empty.
+func LoadIndex(root string) (*Index, error) {
+	idx := &Index{path: filepath.Join(root, IndexFile)}
+	data, err := os.ReadFile(idx.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, idx); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", IndexFile, err)
+		}
+	}
+	if idx.Files == nil {
+		idx.Files = make(map[string]Entry)
+	}
+	return idx, nil
+}
+
+// Save writes the index atomically.
+func (i *Index) Save() error {
+	data, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := i.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, i.path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Result summarizes a dedupe pass.
+type Result struct {
+	Files  int   // data files checked
+	Linked int   // files replaced by a link to stored content
+	Bytes  int64 // disk space saved
+}
+
+// Date replaces the data files of root/date whose content is already stored
+// with links to it, and records new content in idx. Symlinks are left alone,
+// so dates can be deduplicated again.
+func Date(root, date, mode string, idx *Index) (Result, error) {
+	var result Result
+	if mode != ModeHardlink && mode != ModeSymlink {
+		return result, fmt.Errorf("unknown dedupe mode %q", mode)
+	}
+
+	dateDir := filepath.Join(root, date)
+	err := filepath.Walk(dateDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || info.Name() == manifest.Name || strings.HasSuffix(path, ".tmp") || strings.HasSuffix(path, ".dedupe") {
+			return nil
+		}
+		rel, err := filepath.Rel(dateDir, path)
+		if err != nil || !manifest.IsDataPath(rel) {
+			return err
+		}
+
+		result.Files++
+		linked, err := dedupeFile(root, path, info, mode, idx)
+		if err != nil {
+			return fmt.Errorf("%s/%s: %w", date, filepath.ToSlash(rel), err)
+		}
+		if linked {
+			result.Linked++
+			result.Bytes += info.Size()
+		}
+		return nil
+	})
+	return result, err
+}
+
+// dedupeFile links path to the stored copy of its content, or records it as
+// that copy. It reports whether a duplicate was replaced.
+func dedupeFile(root, path string, info os.FileInfo, mode string, idx *Index) (bool, error) {
+	size, sum, err := manifest.HashFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	stored, ok := storedCopy(root, sum, size, mode, idx)
+	if ok {
+		if storedInfo, err := os.Stat(stored); err == nil && os.SameFile(storedInfo, info) {
+			return false, nil // already a hard link to it
+		}
+	}
+
+	switch mode {
+	case ModeHardlink:
+		if !ok {
+			idx.Files[sum] = Entry{Path: relSlash(root, path), Size: size}
+			return false, nil
+		}
+		return true, replace(path, func(tmp string) error {
+			return os.Link(stored, tmp)
+		})
+
+	default: // ModeSymlink
+		if ok {
+			return true, symlink(path, stored)
+		}
+
+		// The first copy moves into the object store
+		stored = filepath.Join(root, ObjectsDir, sum[:2], sum+dataExt(path))
+		if err := os.MkdirAll(filepath.Dir(stored), 0750); err != nil {
+			return false, err
+		}
+		if err := os.Rename(path, stored); err != nil {
+			return false, err
+		}
+		if err := symlink(path, stored); err != nil {
+			_ = os.Rename(stored, path)
+			return false, err
+		}
+		idx.Files[sum] = Entry{Path: relSlash(root, stored), Size: size}
+		return false, nil
+	}
+}
+
+// storedCopy returns the path of the stored copy of a digest, if it still
+// holds that content. Symlinks only point into ObjectsDir, so date
+// directories can be pruned without breaking them.
+func storedCopy(root, sum string, size int64, mode string, idx *Index) (string, bool) {
+	entry, ok := idx.Files[sum]
+	if !ok || entry.Size != size {
+		return "", false
+	}
+	if mode == ModeSymlink && !strings.HasPrefix(entry.Path, ObjectsDir+"/") {
+		return "", false
+	}
+	// The stored file may have been replaced since it was indexed
+	stored := filepath.Join(root, filepath.FromSlash(entry.Path))
+	if n, storedSum, err := manifest.HashFile(stored); err != nil || n != size || storedSum != sum {
+		return "", false
+	}
+	return stored, true
+}
+
+// symlink replaces path with a relative symlink to stored.
+func symlink(path, stored string) error {
+	target, err := filepath.Rel(filepath.Dir(path), stored)
+	if err != nil {
+		return err
+	}
+	return replace(path, func(tmp string) error {
+		return os.Symlink(target, tmp)
+	})
+}
+
+// replace atomically swaps path for the link made by link.
+func replace(path string, link func(tmp string) error) error {
+	tmp := path + ".dedupe"
+	_ = os.Remove(tmp)
+	if err := link(tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Prune deletes stored objects no symlink in root points at any more, e.g.
+// after their dates were pruned, and forgets index entries whose stored copy
+// is gone. It returns the disk space freed.
+func Prune(root string) (int64, error) {
+	idx, err := LoadIndex(root)
+	if err != nil {
+		return 0, err
+	}
+
+	referenced := make(map[string]bool)
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && path != root && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir // the object store and staging
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			if target, err := os.Readlink(path); err == nil {
+				if !filepath.IsAbs(target) {
+					target = filepath.Join(filepath.Dir(path), target)
+				}
+				referenced[target] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var freed int64
+	for sum, entry := range idx.Files {
+		stored := filepath.Join(root, filepath.FromSlash(entry.Path))
+		if strings.HasPrefix(entry.Path, ObjectsDir+"/") && !referenced[stored] {
+			if err := os.Remove(stored); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return freed, err
+			}
+			freed += entry.Size
+			delete(idx.Files, sum)
+			continue
+		}
+		if _, err := os.Stat(stored); errors.Is(err, os.ErrNotExist) {
+			delete(idx.Files, sum)
+		}
+	}
+	return freed, idx.Save()
+}
+
+func relSlash(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// dataExt returns the whole extension of a data file, e.g. .jsonl.zst.
+func dataExt(path string) string {
+	name := filepath.Base(path)
+	if dot := strings.Index(name, "."); dot >= 0 {
+		return name[dot:]
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package dedupe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func dedupeDates(t *testing.T, root, mode string, dates ...string) Result {
+	t.Helper()
+	idx, err := LoadIndex(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total Result
+	for _, date := range dates {
+		result, err := Date(root, date, mode, idx)
+		if err != nil {
+			t.Fatalf("Date(%s) error = %v", date, err)
+		}
+		total.Files += result.Files
+		total.Linked += result.Linked
+		total.Bytes += result.Bytes
+	}
+	if err := idx.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return total
+}
+
+func TestDateHardlink(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"2025-11-13/SPX/state/gex_zero.jsonl": "stale",
+		"2025-11-14/SPX/state/gex_zero.jsonl": "stale",
+		"2025-11-14/SPX/state/gex_full.jsonl": "fresh",
+	})
+
+	result := dedupeDates(t, root, ModeHardlink, "2025-11-13", "2025-11-14")
+	if result.Files != 3 || result.Linked != 1 || result.Bytes != 5 {
+		t.Errorf("result = %+v", result)
+	}
+
+	a, _ := os.Stat(filepath.Join(root, "2025-11-13/SPX/state/gex_zero.jsonl"))
+	b, _ := os.Stat(filepath.Join(root, "2025-11-14/SPX/state/gex_zero.jsonl"))
+	if !os.SameFile(a, b) {
+		t.Error("duplicate was not hard linked")
+	}
+
+	// A second pass finds nothing new
+	if result := dedupeDates(t, root, ModeHardlink, "2025-11-14"); result.Linked != 0 {
+		t.Errorf("second pass linked %d files", result.Linked)
+	}
+}
+
+func TestDateSymlinkAndPrune(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"2025-11-13/SPX/state/gex_zero.jsonl": "stale",
+		"2025-11-14/SPX/state/gex_zero.jsonl": "stale",
+	})
+
+	result := dedupeDates(t, root, ModeSymlink, "2025-11-13", "2025-11-14")
+	if result.Linked != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	for _, date := range []string{"2025-11-13", "2025-11-14"} {
+		path := filepath.Join(root, date, "SPX/state/gex_zero.jsonl")
+		if info, err := os.Lstat(path); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("%s is not a symlink", date)
+		}
+		if got, err := os.ReadFile(path); err != nil || string(got) != "stale" {
+			t.Errorf("%s reads %q, %v", date, got, err)
+		}
+	}
+
+	// Objects survive until no date links to them
+	if err := os.RemoveAll(filepath.Join(root, "2025-11-13")); err != nil {
+		t.Fatal(err)
+	}
+	if freed, err := Prune(root); err != nil || freed != 0 {
+		t.Errorf("Prune() = %d, %v with a link left", freed, err)
+	}
+	if err := os.RemoveAll(filepath.Join(root, "2025-11-14")); err != nil {
+		t.Fatal(err)
+	}
+	if freed, err := Prune(root); err != nil || freed != 5 {
+		t.Errorf("Prune() = %d, %v, want 5 bytes freed", freed, err)
+	}
+	idx, err := LoadIndex(root)
+	if err != nil || len(idx.Files) != 0 {
+		t.Errorf("index after prune = %+v, %v", idx, err)
+	}
+}
@@ -5,29 +5,89 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/dgnsrekt/gexbot-downloader/internal/api"
 	"github.com/dgnsrekt/gexbot-downloader/internal/staging"
+	"github.com/dgnsrekt/gexbot-downloader/internal/verify"
 )
 
 type Manager struct {
-	client  api.Client
-	staging *staging.Manager
-	workers int
-	logger  *zap.Logger
+	client       api.Client
+	staging      *staging.Manager
+	workers      int
+	pkgWorkers   map[string]int // packages with their own worker pool
+	logger       *zap.Logger
+	skipExisting bool
+	verify       string
+	validate     bool
+	validators   *ValidatorStore
+	metrics      *Metrics
+	progress     Progress
+}
+
+// Progress receives task events from Execute, e.g. to render a live
+// display. Methods are called from the worker goroutines.
+type Progress interface {
+	// TaskStarted is called when a worker picks up a task, which is staged
+	// at stagingPath while it downloads.
+	TaskStarted(worker int, task Task, stagingPath string)
+	// TaskFinished is called with the outcome of the worker's task.
+	TaskFinished(worker int, result TaskResult)
 }
 
 type BatchResult struct {
-	Total    int
-	Success  int
-	Skipped  int
-	NotFound int
-	Failed   int
-	Errors   []string
+	Total    int          `json:"total"`
+	Success  int          `json:"success"`
+	Skipped  int          `json:"skipped"`
+	NotFound int          `json:"not_found"`
+	Failed   int          `json:"failed"`
+	Errors   []string     `json:"errors,omitempty"`
+	Timings  []TaskTiming `json:"timings,omitempty"` // completed transfers, in completion order
+	Tasks    []TaskStatus `json:"tasks,omitempty"`   // every task, in completion order
+}
+
+// FailedTasks returns the tasks of the batch that failed, to retry them.
+func (r *BatchResult) FailedTasks() []Task {
+	return r.TasksWithStatus(StatusFailed)
+}
+
+// TasksWithStatus returns the tasks of the batch that ended with one of
+// statuses.
+func (r *BatchResult) TasksWithStatus(statuses ...string) []Task {
+	var tasks []Task
+	for _, status := range r.Tasks {
+		if !slices.Contains(statuses, status.Status) {
+			continue
+		}
+		if task, err := ParseTask(status.Task); err == nil {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// MarkUnfinished counts the tasks that have no outcome, as left by a
+// cancelled Execute, as failed with reason.
+func (r *BatchResult) MarkUnfinished(tasks []Task, reason string) {
+	done := make(map[string]bool, len(r.Tasks))
+	for _, status := range r.Tasks {
+		done[status.Task] = true
+	}
+	for _, task := range tasks {
+		if done[task.String()] {
+			continue
+		}
+		r.Tasks = append(r.Tasks, TaskStatus{Task: task.String(), Status: StatusFailed, Error: reason})
+		r.Failed++
+		r.Errors = append(r.Errors, fmt.Sprintf("%s: %s", task, reason))
+	}
 }
 
 func NewManager(client api.Client, staging *staging.Manager, workers int, logger *zap.Logger) *Manager {
@@ -36,9 +96,52 @@ func NewManager(client api.Client, staging *staging.Manager, workers int, logger
 		staging: staging,
 		workers: workers,
 		logger:  logger,
+
+		skipExisting: true,
+		verify:       VerifySize,
+		metrics:      NewMetrics(),
 	}
 }
 
+// Metrics returns the counters of every batch this manager executed.
+func (m *Manager) Metrics() *Metrics {
+	return m.metrics
+}
+
+// SetSkipExisting controls whether files already in the output directory are
+// skipped (the default). When disabled they are re-checked with conditional
+// requests using the stored ETag/Last-Modified, and only re-transferred when
+// the remote copy changed.
+func (m *Manager) SetSkipExisting(skip bool) {
+	m.skipExisting = skip
+}
+
+// SetVerifyExisting sets how existing files are checked before they are
+// skipped: VerifyOff, VerifySize (the default) or VerifyChecksum. Files that
+// fail are downloaded again. Remote output is not verified.
+func (m *Manager) SetVerifyExisting(mode string) {
+	m.verify = mode
+}
+
+// SetValidatePayloads sets whether each download is checked against its
+// package's data model before it can be committed. Files that do not match
+// are quarantined in the staging rejected directory and count as failed.
+func (m *Manager) SetValidatePayloads(validate bool) {
+	m.validate = validate
+}
+
+// SetPackageWorkers gives packages their own pools of workers, so slow
+// packages such as state cannot hold up small orderflow files. Tasks of
+// other packages share the default pool.
+func (m *Manager) SetPackageWorkers(workers map[string]int) {
+	m.pkgWorkers = workers
+}
+
+// SetProgress reports the start and outcome of every task to p.
+func (m *Manager) SetProgress(p Progress) {
+	m.progress = p
+}
+
 func (m *Manager) Execute(ctx context.Context, tasks []Task) (*BatchResult, error) {
 	result := &BatchResult{Total: len(tasks)}
 
@@ -46,31 +149,63 @@ func (m *Manager) Execute(ctx context.Context, tasks []Task) (*BatchResult, erro
 		return result, nil
 	}
 
-	jobs := make(chan Task, len(tasks))
-	results := make(chan TaskResult, len(tasks))
-
-	// Start workers
-	var wg sync.WaitGroup
-	for i := 0; i < m.workers; i++ {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
-			m.worker(ctx, workerID, jobs, results)
-		}(i)
+	m.metrics.runStarted(time.Now())
+	var retriesBefore int64
+	if rc, ok := m.client.(api.RetryCounter); ok {
+		retriesBefore = rc.Retries()
 	}
+	defer func() {
+		var retries int64
+		if rc, ok := m.client.(api.RetryCounter); ok {
+			retries = rc.Retries() - retriesBefore
+		}
+		m.metrics.runFinished(time.Now(), retries)
+	}()
 
-	// Send jobs
-	go func() {
-		for _, task := range tasks {
-			select {
-			case <-ctx.Done():
-				return
-			case jobs <- task:
-			}
+	validatorsPath := filepath.Join(m.staging.FinalDir(), ValidatorsFile)
+	store, err := LoadValidatorStore(validatorsPath)
+	if err != nil {
+		m.logger.Warn("ignoring unreadable validators", zap.Error(err))
+		store = newValidatorStore(validatorsPath)
+	}
+	m.validators = store
+	defer func() {
+		if err := store.Save(); err != nil {
+			m.logger.Warn("failed to save validators", zap.Error(err))
 		}
-		close(jobs)
 	}()
 
+	results := make(chan TaskResult, len(tasks))
+
+	// Start a pool of workers per queue
+	var (
+		wg       sync.WaitGroup
+		workerID int
+	)
+	for _, q := range m.queues(tasks) {
+		jobs := make(chan Task, len(q.tasks))
+		for i := 0; i < q.workers; i++ {
+			wg.Add(1)
+			go func(workerID int) {
+				defer wg.Done()
+				m.worker(ctx, workerID, jobs, results)
+			}(workerID)
+			workerID++
+		}
+
+		// Send jobs
+		go func(tasks []Task) {
+			for _, task := range tasks {
+				select {
+				case <-ctx.Done():
+					return
+				case jobs <- task:
+				}
+			}
+			close(jobs)
+		}(q.tasks)
+	}
+
 	// Wait for workers and close results
 	go func() {
 		wg.Wait()
@@ -79,12 +214,24 @@ func (m *Manager) Execute(ctx context.Context, tasks []Task) (*BatchResult, erro
 
 	// Collect results
 	for r := range results {
+		m.metrics.observe(r)
+		status := TaskStatus{Task: r.Task.String(), Status: r.Status(), Bytes: r.BytesSize, Duration: r.Duration}
+		if r.Error != nil {
+			status.Error = r.Error.Error()
+		}
+		result.Tasks = append(result.Tasks, status)
 		if r.Skipped {
 			result.Skipped++
 		} else if r.NotFound {
 			result.NotFound++
 		} else if r.Success {
 			result.Success++
+			result.Timings = append(result.Timings, TaskTiming{
+				Task:     r.Task.String(),
+				Bytes:    r.BytesSize,
+				Duration: r.Duration,
+				MBPerSec: r.MBPerSec(),
+			})
 		} else {
 			result.Failed++
 			if r.Error != nil {
@@ -96,6 +243,38 @@ func (m *Manager) Execute(ctx context.Context, tasks []Task) (*BatchResult, erro
 	return result, nil
 }
 
+// queue is a set of tasks worked on by its own pool of workers.
+type queue struct {
+	tasks   []Task
+	workers int
+}
+
+// queues splits tasks into one queue per package with its own workers and
+// a default queue for the rest, keeping task order within each.
+func (m *Manager) queues(tasks []Task) []queue {
+	shared := queue{workers: m.workers}
+	var own []queue
+	index := make(map[string]int)
+	for _, task := range tasks {
+		n := m.pkgWorkers[task.Package]
+		if n <= 0 {
+			shared.tasks = append(shared.tasks, task)
+			continue
+		}
+		i, ok := index[task.Package]
+		if !ok {
+			i = len(own)
+			index[task.Package] = i
+			own = append(own, queue{workers: n})
+		}
+		own[i].tasks = append(own[i].tasks, task)
+	}
+	if len(shared.tasks) > 0 {
+		own = append(own, shared)
+	}
+	return own
+}
+
 func (m *Manager) worker(ctx context.Context, id int, jobs <-chan Task, results chan<- TaskResult) {
 	for task := range jobs {
 		select {
@@ -104,7 +283,13 @@ func (m *Manager) worker(ctx context.Context, id int, jobs <-chan Task, results
 		default:
 		}
 
+		if m.progress != nil {
+			m.progress.TaskStarted(id, task, task.OutputPath(m.staging.StagingRoot()))
+		}
 		result := m.processTask(ctx, task)
+		if m.progress != nil {
+			m.progress.TaskFinished(id, result)
+		}
 
 		select {
 		case <-ctx.Done():
@@ -117,21 +302,42 @@ func (m *Manager) worker(ctx context.Context, id int, jobs <-chan Task, results
 func (m *Manager) processTask(ctx context.Context, task Task) TaskResult {
 	result := TaskResult{Task: task}
 
-	outputPath := task.OutputPath(m.staging.FinalDir())
+	// Path relative to the output root, which may be remote storage
+	outputPath := task.OutputPath("")
 
-	// Check if file exists (resume) - check both .json and .jsonl
-	jsonlPath := strings.TrimSuffix(outputPath, ".json") + ".jsonl"
-	if _, err := os.Stat(outputPath); err == nil {
-		m.logger.Debug("skipping existing file", zap.String("task", task.String()))
-		result.Skipped = true
-		result.Success = true
-		return result
+	// Check if file exists (resume) - check .json and its converted copies
+	exists, corrupt := false, false
+	for _, path := range task.StoredPaths() {
+		found, err := m.staging.Exists(ctx, path)
+		if err != nil {
+			result.Error = fmt.Errorf("checking existing file: %w", err)
+			return result
+		}
+		if found {
+			exists = true
+			if err := m.checkExisting(task, path); err != nil {
+				m.logger.Warn("existing file failed verification, downloading again",
+					zap.String("task", task.String()),
+					zap.String("file", filepath.Base(path)),
+					zap.Error(err),
+				)
+				corrupt = true
+				break
+			}
+			if m.skipExisting {
+				m.logger.Debug("skipping existing file", zap.String("task", task.String()), zap.String("file", filepath.Base(path)))
+				result.Skipped = true
+				result.Success = true
+				return result
+			}
+		}
 	}
-	if _, err := os.Stat(jsonlPath); err == nil {
-		m.logger.Debug("skipping existing file (jsonl)", zap.String("task", task.String()))
-		result.Skipped = true
-		result.Success = true
-		return result
+
+	// Conditional request when refreshing a file we have validators for; a
+	// corrupt copy must be transferred again even if upstream is unchanged
+	var prev api.Validators
+	if exists && !corrupt {
+		prev = m.validators.Get(task)
 	}
 
 	m.logger.Info("downloading", zap.String("task", task.String()))
@@ -150,15 +356,103 @@ func (m *Manager) processTask(ctx context.Context, task Task) TaskResult {
 
 	// Download to staging
 	stagingPath := task.OutputPath(m.staging.StagingRoot())
-	size, err := m.staging.DownloadToStaging(ctx, m.client, signedURL, stagingPath)
+	start := time.Now()
+	var (
+		size       int64
+		validators api.Validators
+	)
+	cc, conditional := m.client.(api.ConditionalClient)
+	if conditional {
+		size, validators, err = m.staging.DownloadToStagingConditional(ctx, cc, signedURL, stagingPath, prev)
+		if errors.Is(err, api.ErrNotModified) {
+			m.logger.Debug("unchanged, skipping", zap.String("task", task.String()))
+			result.Skipped = true
+			result.Success = true
+			return result
+		}
+	} else {
+		size, err = m.staging.DownloadToStaging(ctx, m.client, signedURL, stagingPath)
+	}
 	if err != nil {
 		result.Error = err
 		return result
 	}
 
+	// Keep payloads that do not match the data model out of the output; the
+	// validators are not recorded so the next run fetches the file again
+	if m.validate {
+		if err := m.validatePayload(task, stagingPath); err != nil {
+			result.Error = err
+			return result
+		}
+	}
+	if conditional {
+		m.validators.Set(task, validators)
+	}
+
+	// Record the checksum so later runs can verify the file before skipping
+	if written, sum, err := fileChecksum(stagingPath); err == nil {
+		m.validators.SetChecksum(task, written, sum)
+	} else {
+		m.logger.Warn("failed to checksum download", zap.String("task", task.String()), zap.Error(err))
+	}
+
+	// A refreshed file replaces its converted copies; drop the stale ones so
+	// auto-conversion regenerates them from the new download.
+	if exists {
+		for _, path := range convertedPaths(outputPath) {
+			if found, _ := m.staging.Exists(ctx, path); !found {
+				continue
+			}
+			if err := m.staging.Remove(ctx, path); err == nil {
+				m.logger.Info("removed outdated converted file", zap.String("task", task.String()), zap.String("file", filepath.Base(path)))
+			}
+		}
+	}
+
 	result.Success = true
 	result.BytesSize = size
-	m.logger.Info("downloaded", zap.String("task", task.String()), zap.Int64("bytes", size))
+	result.Duration = time.Since(start)
+	m.logger.Info("downloaded",
+		zap.String("task", task.String()),
+		zap.Int64("bytes", size),
+		zap.Duration("duration", result.Duration),
+		zap.String("rate", fmt.Sprintf("%.2f MB/s", result.MBPerSec())))
 
 	return result
 }
+
+// validatePayload checks a staged download against its package's data model
+// and quarantines it when it does not match.
+func (m *Manager) validatePayload(task Task, stagingPath string) error {
+	rel := filepath.ToSlash(filepath.Join(task.Ticker, task.Package, task.Category+".json"))
+	records, issues := verify.File(stagingPath, rel)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	problems := make([]string, len(issues))
+	for i, issue := range issues {
+		problems[i] = issue.String()
+	}
+	rejected, err := m.staging.Reject(stagingPath, problems)
+	if err != nil {
+		m.logger.Warn("failed to quarantine invalid payload", zap.String("task", task.String()), zap.Error(err))
+		_ = os.Remove(stagingPath)
+	} else {
+		m.logger.Warn("rejected invalid payload",
+			zap.String("task", task.String()),
+			zap.Int("records", records),
+			zap.Int("problems", len(issues)),
+			zap.String("file", rejected),
+		)
+	}
+	return fmt.Errorf("payload does not match the %s data model: %s", task.Package, problems[0])
+}
+
+// convertedPaths returns the JSONL and Parquet paths a downloaded .json file
+// may have been converted to.
+func convertedPaths(jsonPath string) []string {
+	base := strings.TrimSuffix(jsonPath, ".json")
+	return []string{base + ".jsonl", base + ".jsonl.zst", base + ".parquet"}
+}
@@ -2,10 +2,16 @@ package download
 
 import (
 	"context"
+	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -32,6 +38,10 @@ func (m *mockClient) GetDownloadURL(ctx context.Context, ticker, pkg, category,
 	return "https://example.com/file.json", nil
 }
 
+func (m *mockClient) GetTickers(ctx context.Context) ([]string, error) {
+	return []string{"SPX"}, nil
+}
+
 func (m *mockClient) DownloadFile(ctx context.Context, url string, dest io.Writer) (int64, error) {
 	n, err := dest.Write(m.data)
 	return int64(n), err
@@ -76,6 +86,14 @@ func TestDownloadManager(t *testing.T) {
 		t.Errorf("expected 1 not found, got %d", result.NotFound)
 	}
 
+	statuses := make(map[string]string)
+	for _, ts := range result.Tasks {
+		statuses[ts.Task] = ts.Status
+	}
+	if len(result.Tasks) != 3 || statuses["2025-11-14/SPX/state/gex_one"] != StatusNotFound || statuses["2025-11-14/SPX/state/gex_full"] != StatusSuccess {
+		t.Errorf("unexpected task statuses: %+v", result.Tasks)
+	}
+
 	// Verify files were created in staging (path includes date/ticker/package/category.json within staging)
 	stagingPath := filepath.Join(tmpDir, ".staging", "2025-11-14", "SPX", "state", "gex_full.json")
 	if _, err := os.Stat(stagingPath); os.IsNotExist(err) {
@@ -83,6 +101,57 @@ func TestDownloadManager(t *testing.T) {
 	}
 }
 
+func TestDownloadManager_Metrics(t *testing.T) {
+	client := &mockClient{
+		data:     []byte(`{"test": "data"}`),
+		notFound: []string{"SPX/state/gex_one/2025-11-14"},
+	}
+	mgr := NewManager(client, staging.NewManager(t.TempDir()), 2, zap.NewNop())
+
+	tasks := []Task{
+		{Ticker: "SPX", Package: "state", Category: "gex_full", Date: "2025-11-14"},
+		{Ticker: "SPX", Package: "state", Category: "gex_one", Date: "2025-11-14"},
+	}
+	if _, err := mgr.Execute(context.Background(), tasks); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	var pushed string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/metrics/job/gexbot_downloader" {
+			t.Errorf("unexpected push %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		pushed = string(body)
+	}))
+	defer gateway.Close()
+
+	textfile := filepath.Join(t.TempDir(), "downloader.prom")
+	if err := mgr.Metrics().Export(context.Background(), gateway.URL, "gexbot_downloader", textfile); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	written, err := os.ReadFile(textfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != pushed {
+		t.Error("textfile and pushed metrics differ")
+	}
+
+	for _, want := range []string{
+		`gexbot_downloader_tasks_total{result="success"} 1`,
+		`gexbot_downloader_tasks_total{result="not_found"} 1`,
+		`gexbot_downloader_bytes_total 16`,
+		`gexbot_downloader_task_duration_seconds_count{ticker="SPX"} 1`,
+		`gexbot_downloader_task_duration_seconds_bucket{ticker="SPX",le="+Inf"} 1`,
+		"gexbot_downloader_last_run_timestamp_seconds ",
+	} {
+		if !strings.Contains(pushed, want) {
+			t.Errorf("metrics missing %q:\n%s", want, pushed)
+		}
+	}
+}
+
 func TestDownloadManager_Resume(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "download-test-*")
 	if err != nil {
@@ -127,6 +196,269 @@ func TestDownloadManager_Resume(t *testing.T) {
 	}
 }
 
+// packageGateClient holds state downloads until every orderflow download
+// has finished.
+type packageGateClient struct {
+	mockClient
+	orderflow sync.WaitGroup
+}
+
+func (c *packageGateClient) GetDownloadURL(ctx context.Context, ticker, pkg, category, date string) (string, error) {
+	return "https://example.com/" + pkg, nil
+}
+
+func (c *packageGateClient) DownloadFile(ctx context.Context, url string, dest io.Writer) (int64, error) {
+	if strings.HasSuffix(url, "/orderflow") {
+		defer c.orderflow.Done()
+	} else {
+		done := make(chan struct{})
+		go func() { c.orderflow.Wait(); close(done) }()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			return 0, errors.New("orderflow downloads were starved")
+		}
+	}
+	return c.mockClient.DownloadFile(ctx, url, dest)
+}
+
+func TestDownloadManager_PackageWorkers(t *testing.T) {
+	client := &packageGateClient{mockClient: mockClient{data: []byte(`{"test": "data"}`)}}
+	client.orderflow.Add(2)
+
+	mgr := NewManager(client, staging.NewManager(t.TempDir()), 1, zap.NewNop())
+	mgr.SetPackageWorkers(map[string]int{"orderflow": 2})
+
+	// With one shared worker the state download would block the orderflow ones
+	tasks := []Task{
+		{Ticker: "SPX", Package: "state", Category: "gex_full", Date: "2025-11-14"},
+		{Ticker: "SPX", Package: "orderflow", Category: "orderflow", Date: "2025-11-14"},
+		{Ticker: "QQQ", Package: "orderflow", Category: "orderflow", Date: "2025-11-14"},
+	}
+	result, err := mgr.Execute(context.Background(), tasks)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Success != 3 {
+		t.Errorf("expected 3 successful, got %d: %v", result.Success, result.Errors)
+	}
+}
+
+type recordingProgress struct {
+	mu       sync.Mutex
+	started  map[string]string
+	finished int
+}
+
+func (p *recordingProgress) TaskStarted(worker int, task Task, stagingPath string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started[task.String()] = stagingPath
+}
+
+func (p *recordingProgress) TaskFinished(worker int, result TaskResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finished++
+}
+
+func TestDownloadManager_Progress(t *testing.T) {
+	stgMgr := staging.NewManager(t.TempDir())
+	mgr := NewManager(&mockClient{data: []byte(`{"test": "data"}`)}, stgMgr, 2, zap.NewNop())
+	progress := &recordingProgress{started: make(map[string]string)}
+	mgr.SetProgress(progress)
+
+	tasks := []Task{
+		{Ticker: "SPX", Package: "state", Category: "gex_full", Date: "2025-11-14"},
+		{Ticker: "QQQ", Package: "state", Category: "gex_full", Date: "2025-11-14"},
+	}
+	if _, err := mgr.Execute(context.Background(), tasks); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if progress.finished != len(tasks) {
+		t.Errorf("expected %d finished tasks, got %d", len(tasks), progress.finished)
+	}
+	for _, task := range tasks {
+		if got, want := progress.started[task.String()], task.OutputPath(stgMgr.StagingRoot()); got != want {
+			t.Errorf("%s started at %q, want %q", task, got, want)
+		}
+	}
+}
+
+func TestDownloadManager_ValidatePayloads(t *testing.T) {
+	tmpDir := t.TempDir()
+	stgMgr := staging.NewManager(tmpDir)
+	task := Task{Ticker: "SPX", Package: "classic", Category: "gex_full", Date: "2025-11-14"}
+
+	run := func(payload string) *BatchResult {
+		t.Helper()
+		mgr := NewManager(&mockClient{data: []byte(payload)}, stgMgr, 1, zap.NewNop())
+		mgr.SetValidatePayloads(true)
+		result, err := mgr.Execute(context.Background(), []Task{task})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		return result
+	}
+
+	// Spot must be a number
+	r := run(`[{"timestamp": 1731594600, "ticker": "SPX", "spot": "oops"}]`)
+	if r.Failed != 1 || len(r.Errors) != 1 || !strings.Contains(r.Errors[0], "data model") {
+		t.Fatalf("expected a rejected payload, got %+v", r)
+	}
+	stagingPath := task.OutputPath(stgMgr.StagingRoot())
+	if _, err := os.Stat(stagingPath); !os.IsNotExist(err) {
+		t.Errorf("rejected payload left in staging: %v", err)
+	}
+	rejected := filepath.Join(stgMgr.StagingRoot(), staging.RejectedDir, task.Date, "SPX", "classic", "gex_full.json")
+	if _, err := os.Stat(rejected); err != nil {
+		t.Errorf("expected quarantined file: %v", err)
+	}
+	report, err := os.ReadFile(rejected + ".report")
+	if err != nil || !strings.Contains(string(report), "gex_full.json:1: does not match") {
+		t.Errorf("unexpected report %q: %v", report, err)
+	}
+
+	// Recovery leaves the quarantine alone
+	if res, err := stgMgr.Recover(); err != nil || res.Committed != 0 {
+		t.Errorf("recover touched rejected files: %+v, %v", res, err)
+	}
+	if _, err := os.Stat(rejected); err != nil {
+		t.Errorf("quarantined file removed by recovery: %v", err)
+	}
+
+	if r := run(`[{"timestamp": 1731594600, "ticker": "SPX", "spot": 5900.5}]`); r.Success != 1 {
+		t.Errorf("expected a valid payload to pass, got %+v", r)
+	}
+}
+
+// conditionalClient serves data tagged with etag and answers 304 when the
+// caller already has it.
+type conditionalClient struct {
+	mockClient
+	etag string
+}
+
+func (c *conditionalClient) DownloadFileConditional(ctx context.Context, url string, prev api.Validators, dest io.Writer) (int64, api.Validators, error) {
+	if prev.ETag == c.etag {
+		return 0, prev, api.ErrNotModified
+	}
+	n, err := dest.Write(c.data)
+	return int64(n), api.Validators{ETag: c.etag}, err
+}
+
+func TestDownloadManager_ConditionalRefresh(t *testing.T) {
+	tmpDir := t.TempDir()
+	client := &conditionalClient{mockClient: mockClient{data: []byte(`{"v": 1}`)}, etag: `"v1"`}
+	stgMgr := staging.NewManager(tmpDir)
+	tasks := []Task{{Ticker: "SPX", Package: "state", Category: "gex_full", Date: "2025-11-14"}}
+
+	run := func() *BatchResult {
+		t.Helper()
+		mgr := NewManager(client, stgMgr, 1, zap.NewNop())
+		mgr.SetSkipExisting(false)
+		result, err := mgr.Execute(context.Background(), tasks)
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if err := stgMgr.CommitStaging("2025-11-14"); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	if r := run(); r.Success != 1 || r.Skipped != 0 {
+		t.Fatalf("first run: expected a download, got %+v", r)
+	}
+
+	// Unchanged upstream: conditional request short-circuits
+	if r := run(); r.Skipped != 1 {
+		t.Errorf("second run: expected unchanged file to be skipped, got %+v", r)
+	}
+
+	// Republished upstream: file is fetched again
+	client.etag = `"v2"`
+	client.data = []byte(`{"v": 2}`)
+	if r := run(); r.Success != 1 || r.Skipped != 0 {
+		t.Errorf("third run: expected re-download, got %+v", r)
+	}
+	content, _ := os.ReadFile(tasks[0].OutputPath(tmpDir))
+	if string(content) != `{"v": 2}` {
+		t.Errorf("expected refreshed content, got %q", content)
+	}
+}
+
+func TestDownloadManager_VerifyResume(t *testing.T) {
+	tmpDir := t.TempDir()
+	client := &conditionalClient{mockClient: mockClient{data: []byte(`[{"v": 1}]`)}, etag: `"v1"`}
+	stgMgr := staging.NewManager(tmpDir)
+	task := Task{Ticker: "SPX", Package: "state", Category: "gex_full", Date: "2025-11-14"}
+	finalPath := task.OutputPath(tmpDir)
+
+	run := func(mode string) *BatchResult {
+		t.Helper()
+		mgr := NewManager(client, stgMgr, 1, zap.NewNop())
+		mgr.SetVerifyExisting(mode)
+		result, err := mgr.Execute(context.Background(), []Task{task})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if result.Success > 0 {
+			if err := stgMgr.CommitStaging(task.Date); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return result
+	}
+
+	if r := run(VerifySize); r.Success != 1 {
+		t.Fatalf("first run: expected a download, got %+v", r)
+	}
+	if r := run(VerifySize); r.Skipped != 1 {
+		t.Fatalf("intact file: expected skip, got %+v", r)
+	}
+
+	// Truncated file: size mismatch, fetched again despite the unchanged ETag
+	if err := os.WriteFile(finalPath, []byte(`[{"v"`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if r := run(VerifyOff); r.Skipped != 1 {
+		t.Errorf("verification off: expected skip, got %+v", r)
+	}
+	if r := run(VerifySize); r.Success != 1 || r.Skipped != 0 {
+		t.Errorf("truncated file: expected re-download, got %+v", r)
+	}
+	if content, _ := os.ReadFile(finalPath); string(content) != `[{"v": 1}]` {
+		t.Errorf("expected restored content, got %q", content)
+	}
+
+	// Same size, different bytes: only checksum mode notices
+	if err := os.WriteFile(finalPath, []byte(`[{"v": 9}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if r := run(VerifySize); r.Skipped != 1 {
+		t.Errorf("size mode: expected skip, got %+v", r)
+	}
+	if r := run(VerifyChecksum); r.Success != 1 {
+		t.Errorf("checksum mode: expected re-download, got %+v", r)
+	}
+
+	// Converted copies are checked for completeness
+	jsonlPath := strings.TrimSuffix(finalPath, ".json") + ".jsonl"
+	if err := os.Remove(finalPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonlPath, []byte("{\"v\": 1}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if r := run(VerifySize); r.Success != 1 {
+		t.Errorf("truncated JSONL: expected re-download, got %+v", r)
+	}
+	if _, err := os.Stat(jsonlPath); !os.IsNotExist(err) {
+		t.Errorf("truncated JSONL was not removed")
+	}
+}
+
 func TestTask(t *testing.T) {
 	task := Task{
 		Ticker:   "SPX",
@@ -147,4 +479,70 @@ func TestTask(t *testing.T) {
 	if task.String() != "2025-11-14/SPX/state/gex_full" {
 		t.Errorf("unexpected String: %s", task.String())
 	}
+
+	parsed, err := ParseTask(task.String())
+	if err != nil || parsed != task {
+		t.Errorf("ParseTask(%q) = %+v, %v", task.String(), parsed, err)
+	}
+	for _, bad := range []string{"SPX/state/gex_full", "2025-11-14/SPX//gex_full", "11/14/SPX/state/gex_full", "x/SPX/state/gex_full"} {
+		if _, err := ParseTask(bad); err == nil {
+			t.Errorf("ParseTask(%q): expected error", bad)
+		}
+	}
+}
+
+func TestBatchResultThroughput(t *testing.T) {
+	result := &BatchResult{}
+	if tp := result.Throughput(); tp.Downloads != 0 {
+		t.Fatalf("expected empty summary, got %+v", tp)
+	}
+
+	for i := 1; i <= 10; i++ {
+		d := time.Duration(i) * time.Second
+		result.Timings = append(result.Timings, TaskTiming{
+			Bytes:    10 << 20,
+			Duration: d,
+			MBPerSec: mbPerSec(10<<20, d),
+		})
+	}
+
+	tp := result.Throughput()
+	if tp.Downloads != 10 || tp.Bytes != 100<<20 {
+		t.Errorf("unexpected totals: %+v", tp)
+	}
+	if tp.P10MBPerSec != 1 || tp.P90MBPerSec != 5 {
+		t.Errorf("unexpected rate percentiles: p10=%v p90=%v", tp.P10MBPerSec, tp.P90MBPerSec)
+	}
+	if tp.P50Duration != 5*time.Second || tp.MaxDuration != 10*time.Second {
+		t.Errorf("unexpected durations: p50=%v max=%v", tp.P50Duration, tp.MaxDuration)
+	}
+}
+
+func TestBatchResultFailedTasks(t *testing.T) {
+	result := &BatchResult{Tasks: []TaskStatus{
+		{Task: "2025-01-02/SPX/classic/gex_full", Status: StatusSuccess},
+		{Task: "2025-01-02/SPX/state/gex_full", Status: StatusFailed, Error: "timeout"},
+		{Task: "2025-01-02/NDX/classic/gex_zero", Status: StatusNotFound},
+	}}
+	failed := result.FailedTasks()
+	want := Task{Date: "2025-01-02", Ticker: "SPX", Package: "state", Category: "gex_full"}
+	if len(failed) != 1 || failed[0] != want {
+		t.Errorf("FailedTasks() = %v, want [%v]", failed, want)
+	}
+	if tasks := result.TasksWithStatus(StatusFailed, StatusNotFound); len(tasks) != 2 || tasks[1].Ticker != "NDX" {
+		t.Errorf("TasksWithStatus(failed, not_found) = %v", tasks)
+	}
+}
+
+func TestBatchResultMarkUnfinished(t *testing.T) {
+	done := Task{Date: "2025-01-02", Ticker: "SPX", Package: "classic", Category: "gex_full"}
+	hung := Task{Date: "2025-01-02", Ticker: "SPX", Package: "state", Category: "gex_full"}
+	result := &BatchResult{Total: 2, Success: 1, Tasks: []TaskStatus{{Task: done.String(), Status: StatusSuccess}}}
+	result.MarkUnfinished([]Task{done, hung}, "run timed out")
+	if result.Failed != 1 || len(result.Errors) != 1 {
+		t.Errorf("Failed = %d, Errors = %v, want 1 failure", result.Failed, result.Errors)
+	}
+	if failed := result.FailedTasks(); len(failed) != 1 || failed[0] != hung {
+		t.Errorf("FailedTasks() = %v, want [%v]", failed, hung)
+	}
 }
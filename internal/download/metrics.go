@@ -0,0 +1,223 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the task duration
+// histogram.
+var durationBuckets = []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+
+// Task results counted by Metrics, matching the BatchResult fields.
+const (
+	resultSuccess  = "success"
+	resultSkipped  = "skipped"
+	resultNotFound = "not_found"
+	resultFailed   = "failed"
+)
+
+// Metrics collects the counters of download runs for Prometheus: tasks by
+// result, bytes transferred, client retries and per-ticker transfer
+// durations. Write them to a node_exporter textfile or push them to a
+// Pushgateway when a run ends.
+type Metrics struct {
+	mu        sync.Mutex
+	tasks     map[string]int64
+	bytes     int64
+	retries   int64
+	durations map[string]*histogram // by ticker
+	started   time.Time
+	finished  time.Time
+}
+
+type histogram struct {
+	counts []int64 // per bucket, not cumulative
+	sum    float64
+	count  int64
+}
+
+// NewMetrics returns empty metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		tasks:     make(map[string]int64),
+		durations: make(map[string]*histogram),
+	}
+}
+
+func (m *Metrics) runStarted(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.started.IsZero() {
+		m.started = t
+	}
+}
+
+func (m *Metrics) runFinished(t time.Time, retries int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.finished = t
+	m.retries += retries
+}
+
+// observe records the outcome of one task.
+func (m *Metrics) observe(r TaskResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	case r.Skipped:
+		m.tasks[resultSkipped]++
+	case r.NotFound:
+		m.tasks[resultNotFound]++
+	case r.Success:
+		m.tasks[resultSuccess]++
+		m.bytes += r.BytesSize
+
+		h, ok := m.durations[r.Task.Ticker]
+		if !ok {
+			h = &histogram{counts: make([]int64, len(durationBuckets))}
+			m.durations[r.Task.Ticker] = h
+		}
+		secs := r.Duration.Seconds()
+		for i, le := range durationBuckets {
+			if secs <= le {
+				h.counts[i]++
+				break
+			}
+		}
+		h.sum += secs
+		h.count++
+	default:
+		m.tasks[resultFailed]++
+	}
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b bytes.Buffer
+	header := func(name, typ, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+
+	header("gexbot_downloader_tasks_total", "counter", "Download tasks by result.")
+	for _, result := range []string{resultSuccess, resultSkipped, resultNotFound, resultFailed} {
+		fmt.Fprintf(&b, "gexbot_downloader_tasks_total{result=%q} %d\n", result, m.tasks[result])
+	}
+
+	header("gexbot_downloader_bytes_total", "counter", "Bytes downloaded.")
+	fmt.Fprintf(&b, "gexbot_downloader_bytes_total %d\n", m.bytes)
+
+	header("gexbot_downloader_retries_total", "counter", "API requests and transfers retried, including mirror failovers.")
+	fmt.Fprintf(&b, "gexbot_downloader_retries_total %d\n", m.retries)
+
+	header("gexbot_downloader_task_duration_seconds", "histogram", "Transfer time of downloaded files by ticker.")
+	tickers := make([]string, 0, len(m.durations))
+	for ticker := range m.durations {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+	for _, ticker := range tickers {
+		h := m.durations[ticker]
+		var cumulative int64
+		for i, le := range durationBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "gexbot_downloader_task_duration_seconds_bucket{ticker=%q,le=\"%g\"} %d\n", ticker, le, cumulative)
+		}
+		fmt.Fprintf(&b, "gexbot_downloader_task_duration_seconds_bucket{ticker=%q,le=\"+Inf\"} %d\n", ticker, h.count)
+		fmt.Fprintf(&b, "gexbot_downloader_task_duration_seconds_sum{ticker=%q} %g\n", ticker, h.sum)
+		fmt.Fprintf(&b, "gexbot_downloader_task_duration_seconds_count{ticker=%q} %d\n", ticker, h.count)
+	}
+
+	if !m.finished.IsZero() {
+		header("gexbot_downloader_last_run_timestamp_seconds", "gauge", "Unix time the last run finished.")
+		fmt.Fprintf(&b, "gexbot_downloader_last_run_timestamp_seconds %d\n", m.finished.Unix())
+
+		header("gexbot_downloader_run_duration_seconds", "gauge", "Wall time of the last run.")
+		fmt.Fprintf(&b, "gexbot_downloader_run_duration_seconds %g\n", m.finished.Sub(m.started).Seconds())
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// WriteFile writes the metrics to path for the node_exporter textfile
+// collector. The file is replaced atomically so it is never read half
+// written.
+func (m *Metrics) WriteFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := m.WritePrometheus(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Push replaces the metrics of job on a Prometheus Pushgateway.
+func (m *Metrics) Push(ctx context.Context, gatewayURL, job string) error {
+	var body bytes.Buffer
+	if err := m.WritePrometheus(&body); err != nil {
+		return err
+	}
+
+	target := strings.TrimRight(gatewayURL, "/") + "/metrics/job/" + url.PathEscape(job)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, &body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("pushgateway returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Export writes the metrics to textfile and pushes them to gatewayURL; empty
+// destinations are skipped.
+func (m *Metrics) Export(ctx context.Context, gatewayURL, job, textfile string) error {
+	var errs []error
+	if textfile != "" {
+		if err := m.WriteFile(textfile); err != nil {
+			errs = append(errs, fmt.Errorf("writing metrics file: %w", err))
+		}
+	}
+	if gatewayURL != "" {
+		if err := m.Push(ctx, gatewayURL, job); err != nil {
+			errs = append(errs, fmt.Errorf("pushing metrics: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
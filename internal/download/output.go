@@ -0,0 +1,148 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api"
+	"github.com/dgnsrekt/gexbot-downloader/internal/archive"
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/dedupe"
+	"github.com/dgnsrekt/gexbot-downloader/internal/hook"
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+	"github.com/dgnsrekt/gexbot-downloader/internal/staging"
+)
+
+// ArchiveOutput mirrors committed dates to archive.destination.
+func ArchiveOutput(ctx context.Context, cfg *config.Config, dates []string, logger *zap.Logger) {
+	if cfg.Archive.Destination == "" || len(dates) == 0 {
+		return
+	}
+	storage, err := staging.OpenStorage(cfg.Archive.Destination)
+	if err != nil {
+		logger.Warn("archive skipped", zap.Error(err))
+		return
+	}
+	idx, err := archive.LoadIndex(cfg.Output.Directory, storage.String())
+	if err != nil {
+		logger.Warn("archive skipped", zap.Error(err))
+		return
+	}
+
+	archiver := archive.New(storage, cfg.Archive.Concurrency, cfg.Archive.Verify)
+	for _, date := range dates {
+		result, err := archiver.Date(ctx, cfg.Output.Directory, date, idx)
+		if err != nil {
+			logger.Warn("archive failed", zap.String("date", date), zap.Error(err))
+		}
+		logger.Info("archived output",
+			zap.String("date", date),
+			zap.String("destination", storage.String()),
+			zap.Int("files", result.Files),
+			zap.Int("uploaded", result.Uploaded),
+			zap.String("bytes", fmt.Sprintf("%.1f MB", float64(result.Bytes)/(1<<20))),
+		)
+	}
+	if err := idx.Save(); err != nil {
+		logger.Warn("failed to save archive index", zap.Error(err))
+	}
+}
+
+// DedupeOutput links data files of dates that are identical to ones already
+// in the output directory, per output.dedupe
+func DedupeOutput(cfg *config.Config, dates []string, logger *zap.Logger) {
+	if cfg.Output.Dedupe == dedupe.ModeOff || cfg.Output.Remote() {
+		return
+	}
+	idx, err := dedupe.LoadIndex(cfg.Output.Directory)
+	if err != nil {
+		logger.Warn("dedupe skipped", zap.Error(err))
+		return
+	}
+
+	var linked int
+	var saved int64
+	for _, date := range dates {
+		result, err := dedupe.Date(cfg.Output.Directory, date, cfg.Output.Dedupe, idx)
+		if err != nil {
+			logger.Warn("dedupe failed", zap.String("date", date), zap.Error(err))
+		}
+		linked += result.Linked
+		saved += result.Bytes
+	}
+	if err := idx.Save(); err != nil {
+		logger.Warn("failed to save dedupe index", zap.Error(err))
+	}
+	if linked > 0 {
+		logger.Info("deduplicated output",
+			zap.Int("linked", linked),
+			zap.String("saved", fmt.Sprintf("%.1f MB", float64(saved)/(1<<20))),
+		)
+	}
+}
+
+// EncryptOutput encrypts the JSONL files of dates with
+// output.encryption_key_file and records their new digests in the manifests.
+func EncryptOutput(cfg *config.Config, dates []string, logger *zap.Logger) {
+	if cfg.Output.EncryptionKeyFile == "" || cfg.Output.Remote() {
+		return
+	}
+	key, err := data.ReadKeyFile(cfg.Output.EncryptionKeyFile)
+	if err != nil {
+		logger.Warn("encryption skipped", zap.Error(err))
+		return
+	}
+
+	encrypted := 0
+	for _, date := range dates {
+		dir := filepath.Join(cfg.Output.Directory, date)
+		files, err := data.EncryptDate(dir, key)
+		if err != nil {
+			logger.Warn("encryption failed", zap.String("date", date), zap.Error(err))
+		}
+		if err := manifest.Rehash(dir, files); err != nil {
+			logger.Warn("failed to update manifest", zap.String("date", date), zap.Error(err))
+		}
+		encrypted += len(files)
+	}
+	if encrypted > 0 {
+		logger.Info("encrypted output", zap.Int("files", encrypted))
+	}
+}
+
+// RunPostDownloadHook runs output.post_download_hook for each committed date.
+// Its output goes to stderr, keeping stdout for results.
+func RunPostDownloadHook(ctx context.Context, cfg *config.Config, stgMgr *staging.Manager, dates []string, logger *zap.Logger) {
+	if cfg.Output.PostDownloadHook == "" {
+		return
+	}
+	for _, date := range dates {
+		dir := filepath.Join(cfg.Output.Directory, date)
+		if stgMgr.Remote() {
+			dir = stgMgr.Location() + "/" + date
+		}
+		logger.Info("running post-download hook", zap.String("date", date), zap.String("dir", dir))
+		if err := hook.Run(ctx, cfg.Output.PostDownloadHook, date, dir, os.Stderr); err != nil {
+			logger.Warn("post-download hook failed", zap.String("date", date), zap.Error(err))
+		}
+	}
+}
+
+// LogMirrorHealth warns about download mirrors that failed recently, so a
+// host that should be dropped from api.mirrors stands out
+func LogMirrorHealth(client *api.HTTPClient, logger *zap.Logger) {
+	for _, m := range client.MirrorStatus() {
+		if m.Healthy {
+			continue
+		}
+		logger.Warn("download mirror unhealthy",
+			zap.String("mirror", m.Host),
+			zap.Int("consecutive_failures", m.ConsecutiveFailures),
+			zap.Time("last_failure", m.LastFailure))
+	}
+}
@@ -0,0 +1,139 @@
+package download
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+// ErrInsufficientSpace is returned by Preflight when the staging filesystem
+// cannot hold the estimated downloads.
+var ErrInsufficientSpace = errors.New("insufficient disk space")
+
+// errFreeSpaceUnsupported is returned by freeSpace on platforms it cannot
+// query.
+var errFreeSpaceUnsupported = errors.New("free space check not supported on this platform")
+
+// Preflight checks that the staging filesystem has room for tasks plus
+// headroom bytes before any are downloaded, so a batch does not fail halfway
+// through. Sizes are estimated from earlier downloads recorded in the
+// validator store; without any history the check is skipped. Tasks already
+// downloaded are not counted when existing files are skipped.
+func (m *Manager) Preflight(tasks []Task, headroom int64) error {
+	store, err := LoadValidatorStore(filepath.Join(m.staging.FinalDir(), ValidatorsFile))
+	if err != nil {
+		m.logger.Warn("disk space preflight skipped", zap.Error(err))
+		return nil
+	}
+
+	var pending []Task
+	for _, task := range tasks {
+		if m.skipExisting && store.size(task) > 0 {
+			continue
+		}
+		pending = append(pending, task)
+	}
+	need, ok := store.estimate(pending)
+	if !ok {
+		m.logger.Debug("disk space preflight skipped, no download history")
+		return nil
+	}
+
+	path := existingParent(m.staging.StagingRoot())
+	free, err := freeSpace(path)
+	if err != nil {
+		m.logger.Warn("disk space preflight skipped", zap.String("path", path), zap.Error(err))
+		return nil
+	}
+
+	m.logger.Info("disk space preflight",
+		zap.String("path", path),
+		zap.Int("tasks", len(pending)),
+		zap.String("estimated", formatMB(need)),
+		zap.String("free", formatMB(free)),
+	)
+	if need+headroom > free {
+		return fmt.Errorf("%w in %s: %s free, about %s needed for %d downloads plus %s headroom",
+			ErrInsufficientSpace, path, formatMB(free), formatMB(need), len(pending), formatMB(headroom))
+	}
+	return nil
+}
+
+// estimate returns the expected size of tasks from recorded sizes: the mean
+// of the same ticker/package/category on other dates, else of the package,
+// else of every recorded file. ok is false when nothing is recorded.
+func (s *ValidatorStore) estimate(tasks []Task) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type mean struct{ sum, n int64 }
+	series := make(map[string]*mean)
+	packages := make(map[string]*mean)
+	var all mean
+	add := func(m map[string]*mean, key string, size int64) {
+		if m[key] == nil {
+			m[key] = &mean{}
+		}
+		m[key].sum += size
+		m[key].n++
+	}
+	for key, rec := range s.entries {
+		task, err := ParseTask(key)
+		if err != nil || rec.Size <= 0 {
+			continue
+		}
+		add(series, seriesKey(task), rec.Size)
+		add(packages, task.Package, rec.Size)
+		all.sum += rec.Size
+		all.n++
+	}
+	if all.n == 0 {
+		return 0, false
+	}
+
+	var total int64
+	for _, task := range tasks {
+		switch m := series[seriesKey(task)]; {
+		case m != nil:
+			total += m.sum / m.n
+		case packages[task.Package] != nil:
+			total += packages[task.Package].sum / packages[task.Package].n
+		default:
+			total += all.sum / all.n
+		}
+	}
+	return total, true
+}
+
+// size returns the recorded size of a task's file, or 0.
+func (s *ValidatorStore) size(task Task) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[task.String()].Size
+}
+
+func seriesKey(t Task) string {
+	return t.Ticker + "/" + t.Package + "/" + t.Category
+}
+
+// existingParent returns path or its nearest ancestor that exists, since the
+// staging root may not have been created yet.
+func existingParent(path string) string {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
+
+func formatMB(n int64) string {
+	return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+}
@@ -0,0 +1,64 @@
+package download
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/staging"
+)
+
+func TestValidatorStoreEstimate(t *testing.T) {
+	store := newValidatorStore("")
+	store.SetChecksum(Task{Date: "2025-11-13", Ticker: "SPX", Package: "state", Category: "gex_full"}, 100, "a")
+	store.SetChecksum(Task{Date: "2025-11-12", Ticker: "SPX", Package: "state", Category: "gex_full"}, 300, "b")
+	store.SetChecksum(Task{Date: "2025-11-13", Ticker: "SPX", Package: "orderflow", Category: "orderflow"}, 50, "c")
+
+	got, ok := store.estimate([]Task{
+		{Date: "2025-11-14", Ticker: "SPX", Package: "state", Category: "gex_full"},      // series mean: 200
+		{Date: "2025-11-14", Ticker: "QQQ", Package: "orderflow", Category: "orderflow"}, // package mean: 50
+		{Date: "2025-11-14", Ticker: "QQQ", Package: "classic", Category: "gex_full"},    // overall mean: 150
+	})
+	if !ok || got != 400 {
+		t.Errorf("estimate() = %d, %v, want 400", got, ok)
+	}
+
+	if _, ok := newValidatorStore("").estimate(nil); ok {
+		t.Error("estimate() without history should not be ok")
+	}
+}
+
+func TestDownloadManager_Preflight(t *testing.T) {
+	tmpDir := t.TempDir()
+	downloaded := Task{Date: "2025-11-13", Ticker: "QQQ", Package: "classic", Category: "gex_full"}
+	store := newValidatorStore(filepath.Join(tmpDir, ValidatorsFile))
+	store.SetChecksum(Task{Date: "2025-11-13", Ticker: "SPX", Package: "state", Category: "gex_full"}, 1<<20, "a")
+	store.SetChecksum(downloaded, 1<<60, "b")
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := NewManager(&mockClient{}, staging.NewManager(tmpDir), 1, zap.NewNop())
+	tasks := []Task{{Date: "2025-11-14", Ticker: "SPX", Package: "state", Category: "gex_full"}}
+	if err := mgr.Preflight(tasks, 0); err != nil {
+		t.Fatalf("Preflight() error = %v", err)
+	}
+
+	free, err := freeSpace(tmpDir)
+	if errors.Is(err, errFreeSpaceUnsupported) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.Preflight(tasks, free); !errors.Is(err, ErrInsufficientSpace) {
+		t.Errorf("Preflight() error = %v, want ErrInsufficientSpace", err)
+	}
+
+	// Files already downloaded are skipped, so they need no space
+	if err := mgr.Preflight([]Task{downloaded}, 0); err != nil {
+		t.Errorf("Preflight() of a downloaded file error = %v", err)
+	}
+}
@@ -3,6 +3,8 @@ package download
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 type Task struct {
@@ -20,15 +22,76 @@ func (t Task) OutputPath(baseDir string) string {
 	return filepath.Join(baseDir, t.Date, t.Ticker, t.Package, t.Category+".json")
 }
 
+// StoredPaths returns the paths, relative to the output root, a downloaded
+// task may be stored at: the .json file and its converted copies.
+func (t Task) StoredPaths() []string {
+	outputPath := t.OutputPath("")
+	return append([]string{outputPath}, convertedPaths(outputPath)...)
+}
+
 func (t Task) String() string {
 	return fmt.Sprintf("%s/%s/%s/%s", t.Date, t.Ticker, t.Package, t.Category)
 }
 
+// ParseTask parses a task in its String form, date/ticker/package/category.
+func ParseTask(s string) (Task, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 4 {
+		return Task{}, fmt.Errorf("invalid task %q: expected date/ticker/package/category", s)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return Task{}, fmt.Errorf("invalid task %q: empty field", s)
+		}
+	}
+	if _, err := time.Parse("2006-01-02", parts[0]); err != nil {
+		return Task{}, fmt.Errorf("invalid task %q: bad date", s)
+	}
+	return Task{Date: parts[0], Ticker: parts[1], Package: parts[2], Category: parts[3]}, nil
+}
+
 type TaskResult struct {
 	Task      Task
 	Success   bool
 	Skipped   bool
 	NotFound  bool
 	BytesSize int64
+	Duration  time.Duration // file transfer time, excluding the URL lookup
 	Error     error
 }
+
+// MBPerSec returns the transfer rate in MB/s (0 when nothing was timed).
+func (r TaskResult) MBPerSec() float64 {
+	return mbPerSec(r.BytesSize, r.Duration)
+}
+
+// Task statuses reported in BatchResult.Tasks.
+const (
+	StatusSuccess  = "success"
+	StatusSkipped  = "skipped"
+	StatusNotFound = "not_found"
+	StatusFailed   = "failed"
+)
+
+// Status returns the outcome of the task as one of the Status constants.
+func (r TaskResult) Status() string {
+	switch {
+	case r.Skipped:
+		return StatusSkipped
+	case r.NotFound:
+		return StatusNotFound
+	case r.Success:
+		return StatusSuccess
+	default:
+		return StatusFailed
+	}
+}
+
+// TaskStatus is the outcome of one task of a batch.
+type TaskStatus struct {
+	Task     string        `json:"task"`
+	Status   string        `json:"status"`
+	Bytes    int64         `json:"bytes,omitempty"`
+	Duration time.Duration `json:"duration_ns,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
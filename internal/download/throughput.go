@@ -0,0 +1,71 @@
+package download
+
+import (
+	"sort"
+	"time"
+)
+
+// TaskTiming records one completed transfer.
+type TaskTiming struct {
+	Task     string        `json:"task"`
+	Bytes    int64         `json:"bytes"`
+	Duration time.Duration `json:"duration_ns"`
+	MBPerSec float64       `json:"mb_per_sec"`
+}
+
+// ThroughputSummary aggregates the transfers of a batch. Low percentiles of
+// MB/s expose slow mirrors and throttling; high duration percentiles expose
+// stragglers.
+type ThroughputSummary struct {
+	Downloads   int           `json:"downloads"`
+	Bytes       int64         `json:"bytes"`
+	P10MBPerSec float64       `json:"p10_mb_per_sec"`
+	P50MBPerSec float64       `json:"p50_mb_per_sec"`
+	P90MBPerSec float64       `json:"p90_mb_per_sec"`
+	P50Duration time.Duration `json:"p50_duration_ns"`
+	P90Duration time.Duration `json:"p90_duration_ns"`
+	MaxDuration time.Duration `json:"max_duration_ns"`
+}
+
+// Throughput summarizes the timed downloads of the batch.
+func (r *BatchResult) Throughput() ThroughputSummary {
+	summary := ThroughputSummary{Downloads: len(r.Timings)}
+	if len(r.Timings) == 0 {
+		return summary
+	}
+
+	rates := make([]float64, len(r.Timings))
+	durations := make([]time.Duration, len(r.Timings))
+	for i, t := range r.Timings {
+		summary.Bytes += t.Bytes
+		rates[i] = t.MBPerSec
+		durations[i] = t.Duration
+	}
+	sort.Float64s(rates)
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	summary.P10MBPerSec = rates[percentileIndex(len(rates), 10)]
+	summary.P50MBPerSec = rates[percentileIndex(len(rates), 50)]
+	summary.P90MBPerSec = rates[percentileIndex(len(rates), 90)]
+	summary.P50Duration = durations[percentileIndex(len(durations), 50)]
+	summary.P90Duration = durations[percentileIndex(len(durations), 90)]
+	summary.MaxDuration = durations[len(durations)-1]
+	return summary
+}
+
+// percentileIndex returns the nearest-rank index of percentile p in a sorted
+// slice of length n.
+func percentileIndex(n, p int) int {
+	idx := (p*n+99)/100 - 1
+	if idx < 0 {
+		return 0
+	}
+	return idx
+}
+
+func mbPerSec(bytes int64, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(bytes) / (1 << 20) / d.Seconds()
+}
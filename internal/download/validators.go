@@ -0,0 +1,127 @@
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api"
+)
+
+// ValidatorsFile is the name of the ETag/Last-Modified and checksum store
+// kept in the output directory.
+const ValidatorsFile = ".validators.json"
+
+// fileRecord is what the store remembers about one downloaded file.
+type fileRecord struct {
+	api.Validators
+	Size int64  `json:"size,omitempty"`
+	MD5  string `json:"md5,omitempty"` // hex
+}
+
+// ValidatorStore remembers the HTTP validators and checksums of downloaded
+// files, keyed by task, so re-runs can issue conditional requests and verify
+// files before skipping them.
+type ValidatorStore struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]fileRecord
+	dirty   bool
+}
+
+func newValidatorStore(path string) *ValidatorStore {
+	return &ValidatorStore{path: path, entries: make(map[string]fileRecord)}
+}
+
+// LoadValidatorStore reads the store at path. A missing file yields an empty
+// store.
+func LoadValidatorStore(path string) (*ValidatorStore, error) {
+	s := newValidatorStore(path)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading validators: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, fmt.Errorf("parsing validators %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *ValidatorStore) Get(task Task) api.Validators {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[task.String()].Validators
+}
+
+func (s *ValidatorStore) Set(task Task, v api.Validators) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec := s.entries[task.String()]
+	rec.Validators = v
+	s.put(task, rec)
+}
+
+// Checksum returns the recorded size and MD5 of a task's file; ok is false
+// when none was recorded.
+func (s *ValidatorStore) Checksum(task Task) (size int64, md5 string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec := s.entries[task.String()]
+	return rec.Size, rec.MD5, rec.MD5 != ""
+}
+
+// SetChecksum records the size and MD5 of a task's downloaded file.
+func (s *ValidatorStore) SetChecksum(task Task, size int64, md5 string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec := s.entries[task.String()]
+	rec.Size, rec.MD5 = size, md5
+	s.put(task, rec)
+}
+
+// put stores rec, dropping empty records. Callers hold s.mu.
+func (s *ValidatorStore) put(task Task, rec fileRecord) {
+	if rec == (fileRecord{}) {
+		if _, ok := s.entries[task.String()]; !ok {
+			return
+		}
+		delete(s.entries, task.String())
+	} else {
+		s.entries[task.String()] = rec
+	}
+	s.dirty = true
+}
+
+// Save writes the store atomically if it changed since it was loaded.
+func (s *ValidatorStore) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	s.dirty = false
+	return nil
+}
@@ -0,0 +1,180 @@
+package download
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// Resume verification modes, checked before an existing file is skipped.
+const (
+	VerifyOff      = "off"      // skip any existing file
+	VerifySize     = "size"     // compare sizes against the recorded download
+	VerifyChecksum = "checksum" // compare sizes and MD5 digests
+)
+
+// fileChecksum returns the size and hex MD5 of a file.
+func fileChecksum(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := md5.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// checkExisting verifies an existing output file, rel being relative to the
+// output root, according to the verification mode.
+func (m *Manager) checkExisting(task Task, rel string) error {
+	if m.verify == VerifyOff {
+		return nil
+	}
+	path, ok := m.staging.LocalPath(rel)
+	if !ok {
+		return nil
+	}
+	return m.verifyExisting(task, path)
+}
+
+// verifyExisting checks an existing output file against what was recorded
+// when it was downloaded. A .json file is compared with its recorded size
+// (and MD5 in checksum mode); converted .jsonl and .parquet copies have no
+// recorded checksum and get a completeness check instead. Compressed
+// .jsonl.zst and encrypted copies must be decoded to check, so only checksum
+// mode does. Files downloaded before checksums were recorded pass. A non-nil
+// error means the file is incomplete or corrupt.
+func (m *Manager) verifyExisting(task Task, path string) error {
+	switch {
+	case strings.HasSuffix(path, ".jsonl"):
+		if encrypted, err := data.IsEncrypted(path); err != nil || !encrypted {
+			return checkJSONLComplete(path)
+		}
+		if m.verify == VerifyChecksum {
+			return checkDecodedComplete(path)
+		}
+		return nil
+	case strings.HasSuffix(path, ".jsonl.zst"):
+		if m.verify == VerifyChecksum {
+			return checkDecodedComplete(path)
+		}
+		return nil
+	case strings.HasSuffix(path, ".parquet"):
+		return checkParquetComplete(path)
+	}
+
+	wantSize, wantMD5, ok := m.validators.Checksum(task)
+	if !ok {
+		return nil
+	}
+	if m.verify == VerifyChecksum {
+		size, sum, err := fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		if size != wantSize {
+			return fmt.Errorf("size %d, expected %d", size, wantSize)
+		}
+		if sum != wantMD5 {
+			return fmt.Errorf("md5 %s, expected %s", sum, wantMD5)
+		}
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Size() != wantSize {
+		return fmt.Errorf("size %d, expected %d", info.Size(), wantSize)
+	}
+	return nil
+}
+
+// checkJSONLComplete reports a truncated JSONL file: every record the
+// converter writes ends with a newline.
+func checkJSONLComplete(path string) error {
+	tail, _, err := readTail(path, 1)
+	if err != nil {
+		return err
+	}
+	if len(tail) > 0 && tail[0] != '\n' {
+		return fmt.Errorf("truncated: last record has no newline")
+	}
+	return nil
+}
+
+// checkDecodedComplete decompresses or decrypts a JSONL file, which fails
+// for a truncated or corrupt stream, and checks the last record is complete.
+func checkDecodedComplete(path string) error {
+	r, err := data.OpenJSONL(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	last := byte('\n')
+	buf := make([]byte, 256<<10)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			last = buf[n-1]
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("corrupt: %w", err)
+		}
+	}
+	if last != '\n' {
+		return fmt.Errorf("truncated: last record has no newline")
+	}
+	return nil
+}
+
+// checkParquetComplete reports a truncated Parquet file, which lacks the
+// trailing magic after its footer.
+func checkParquetComplete(path string) error {
+	tail, size, err := readTail(path, 4)
+	if err != nil {
+		return err
+	}
+	if size < 8 || !bytes.Equal(tail, []byte("PAR1")) {
+		return fmt.Errorf("truncated: missing Parquet footer")
+	}
+	return nil
+}
+
+// readTail returns the last n bytes of a file (fewer if it is shorter) and
+// its size.
+func readTail(path string, n int64) ([]byte, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	n = min(n, info.Size())
+	buf := make([]byte, n)
+	if _, err := f.ReadAt(buf, info.Size()-n); err != nil {
+		return nil, 0, err
+	}
+	return buf, info.Size(), nil
+}
@@ -0,0 +1,162 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+// Converter converts the data files of a date directory to one format.
+type Converter struct {
+	// Output returns the path src converts to, and false for files the
+	// format does not convert.
+	Output func(src string) (string, bool)
+	// Done reports whether src has already been converted to dst.
+	Done func(src, dst string) bool
+	// Convert writes the converted copy of src to dst.
+	Convert func(src, dst string) error
+	// Keep leaves the original files in place.
+	Keep bool
+}
+
+// ConvertDir converts every data file under a date directory with c, skipping
+// files already converted and the staging directory, then points the manifest
+// at the converted files. Failed files are logged and the rest converted; an
+// error reports how many failed.
+func ConvertDir(dir string, c Converter, logger *zap.Logger) error {
+	var converted, skipped, failed int
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip directories and non-data files
+		if info.IsDir() || info.Name() == manifest.Name {
+			return nil
+		}
+		dst, ok := c.Output(path)
+		if !ok {
+			return nil
+		}
+
+		// Skip staging directory
+		if rel, _ := filepath.Rel(dir, path); strings.Contains(rel, ".staging") {
+			return nil
+		}
+
+		if c.Done(path, dst) {
+			logger.Debug("skipping, already converted", zap.String("file", path))
+			skipped++
+			return nil
+		}
+
+		logger.Debug("converting", zap.String("file", path))
+
+		if err := c.Convert(path, dst); err != nil {
+			logger.Error("conversion failed", zap.String("file", path), zap.Error(err))
+			failed++
+			return nil // Continue with other files
+		}
+
+		if !c.Keep {
+			if err := os.Remove(path); err != nil {
+				logger.Warn("failed to delete original", zap.String("file", path), zap.Error(err))
+			}
+		}
+
+		converted++
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("walking directory: %w", err)
+	}
+
+	// Point the manifest at the converted files
+	if err := manifest.Refresh(dir); err != nil {
+		return fmt.Errorf("updating manifest: %w", err)
+	}
+
+	logger.Info("conversion complete",
+		zap.Int("converted", converted),
+		zap.Int("skipped", skipped),
+		zap.Int("failed", failed),
+	)
+
+	if failed > 0 {
+		return fmt.Errorf("%d files failed to convert", failed)
+	}
+
+	return nil
+}
+
+// ConvertDirToJSONL converts the .json array files under a date directory to
+// JSONL, zstd-compressed as .jsonl.zst when compress is set, and deletes the
+// originals. Files with a JSONL copy of either kind are skipped.
+func ConvertDirToJSONL(dir string, compress bool, logger *zap.Logger) error {
+	ext := data.JSONLExt
+	if compress {
+		ext = data.JSONLZstdExt
+	}
+	return ConvertDir(dir, Converter{
+		Output: func(src string) (string, bool) {
+			base, ok := strings.CutSuffix(src, ".json")
+			return base + ext, ok
+		},
+		Done: func(src, _ string) bool {
+			_, ok := data.FindJSONL(strings.TrimSuffix(src, ".json"))
+			return ok
+		},
+		Convert: convertJSONArray,
+	}, logger)
+}
+
+// convertJSONArray writes each element of the JSON array in jsonPath as one
+// compact line of jsonlPath.
+func convertJSONArray(jsonPath, jsonlPath string) error {
+	raw, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return fmt.Errorf("parsing JSON array: %w", err)
+	}
+
+	outFile, err := data.CreateJSONL(jsonlPath)
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+	defer func() { _ = outFile.Close() }()
+
+	for _, item := range items {
+		// Compact the JSON (remove whitespace)
+		compact, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("compacting JSON: %w", err)
+		}
+
+		if _, err := outFile.Write(compact); err != nil {
+			return fmt.Errorf("writing line: %w", err)
+		}
+		if _, err := outFile.Write([]byte("\n")); err != nil {
+			return fmt.Errorf("writing newline: %w", err)
+		}
+	}
+
+	// Flush the compressed stream, if any
+	if err := outFile.Close(); err != nil {
+		return fmt.Errorf("closing output file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func writeConvertFile(t *testing.T, dir, rel, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestConvertDirToJSONL(t *testing.T) {
+	dir := t.TempDir()
+	src := writeConvertFile(t, dir, "SPX/state/gex_zero.json", `[{"timestamp": 1}, {"timestamp": 2}]`)
+	// Already converted: the JSON copy is left alone
+	done := writeConvertFile(t, dir, "SPX/state/gex_one.json", `[{"timestamp": 1}]`)
+	writeConvertFile(t, dir, "SPX/state/gex_one.jsonl", `{"timestamp":1}`+"\n")
+	staged := writeConvertFile(t, dir, ".staging/SPX/state/gex_full.json", `[{"timestamp": 1}]`)
+	broken := writeConvertFile(t, dir, "NDX/state/gex_zero.json", `{"truncated": [`)
+
+	err := ConvertDirToJSONL(dir, false, zap.NewNop())
+	if err == nil || !strings.Contains(err.Error(), "1 files failed") {
+		t.Fatalf("err = %v, want one failure", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "SPX/state/gex_zero.jsonl"))
+	if err != nil || string(got) != `{"timestamp":1}`+"\n"+`{"timestamp":2}`+"\n" {
+		t.Errorf("converted = %q, %v", got, err)
+	}
+	if exists(src) {
+		t.Error("original not deleted after conversion")
+	}
+	for _, path := range []string{done, staged, broken} {
+		if !exists(path) {
+			t.Errorf("%s should be left in place", path)
+		}
+	}
+}
+
+func TestConvertDirToParquet(t *testing.T) {
+	dir := t.TempDir()
+	lines := `{"timestamp":1,"ticker":"SPX","spot":6000.5,"strikes":[],"max_priors":[]}` + "\n"
+	src := writeConvertFile(t, dir, "SPX/state/gex_zero.jsonl", lines)
+	existing := writeConvertFile(t, dir, "SPX/state/gex_one.jsonl", lines)
+	writeConvertFile(t, dir, "SPX/state/gex_one.parquet", "already converted")
+
+	if err := ConvertDirToParquet(dir, true, zap.NewNop()); err != nil {
+		t.Fatalf("ConvertDirToParquet: %v", err)
+	}
+	if !exists(ParquetPath(src)) {
+		t.Error("Parquet copy not written")
+	}
+	// keep leaves the originals
+	for _, path := range []string{src, existing} {
+		if !exists(path) {
+			t.Errorf("%s removed despite keep", path)
+		}
+	}
+	if got, _ := os.ReadFile(ParquetPath(existing)); string(got) != "already converted" {
+		t.Error("existing Parquet file overwritten")
+	}
+}
@@ -0,0 +1,538 @@
+package export
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+// Database formats written by ExportDB.
+const (
+	DBFormatSQLite = "sqlite"
+	DBFormatDuckDB = "duckdb"
+)
+
+// column is a database column; typ is INTEGER, BIGINT, DOUBLE or TEXT.
+type column struct {
+	name string
+	typ  string
+}
+
+// table is a normalized output table.
+type table struct {
+	name    string
+	columns []column
+}
+
+func cols(typ string, names ...string) []column {
+	out := make([]column, len(names))
+	for i, name := range names {
+		out[i] = column{name: name, typ: typ}
+	}
+	return out
+}
+
+// snapshotKey identifies the file and snapshot a row came from.
+var snapshotKey = append(cols("TEXT", "ticker", "package", "category"), column{"timestamp", "BIGINT"})
+
+// The normalized tables. gex and greeks hold one row per snapshot; strikes
+// and iv_strikes break the strike ladders of gex and volatility snapshots
+// into one row per strike.
+var (
+	gexTable = &table{name: "gex", columns: slices.Concat(snapshotKey,
+		cols("DOUBLE", "spot", "zero_gamma", "major_pos_vol", "major_pos_oi", "major_neg_vol", "major_neg_oi",
+			"sum_gex_vol", "sum_gex_oi", "delta_risk_reversal"),
+		cols("INTEGER", "min_dte", "sec_min_dte"),
+		cols("TEXT", "max_priors"),
+	)}
+	strikesTable = &table{name: "strikes", columns: slices.Concat(snapshotKey,
+		cols("DOUBLE", "strike", "gex_vol", "gex_oi"),
+		cols("TEXT", "priors"),
+	)}
+	greeksTable = &table{name: "greeks", columns: slices.Concat(snapshotKey,
+		cols("DOUBLE", "spot", "major_positive", "major_negative", "major_long_gamma", "major_short_gamma"),
+		cols("INTEGER", "min_dte", "sec_min_dte"),
+		cols("TEXT", "mini_contracts"),
+	)}
+	orderflowTable = &table{name: "orderflow", columns: slices.Concat(snapshotKey,
+		cols("DOUBLE", orderflowColumns...),
+	)}
+	volatilityTable = &table{name: "volatility", columns: slices.Concat(snapshotKey,
+		cols("DOUBLE", "spot", "atm_iv", "risk_reversal_25d", "butterfly_25d"),
+		cols("INTEGER", "min_dte", "sec_min_dte"),
+	)}
+	ivStrikesTable = &table{name: "iv_strikes", columns: slices.Concat(snapshotKey,
+		cols("DOUBLE", "strike", "call_iv", "put_iv"),
+	)}
+
+	dbTables = []*table{gexTable, strikesTable, greeksTable, orderflowTable, volatilityTable, ivStrikesTable}
+)
+
+var orderflowColumns = []string{
+	"spot", "z_mlgamma", "z_msgamma", "o_mlgamma", "o_msgamma",
+	"zero_mcall", "zero_mput", "one_mcall", "one_mput",
+	"zcvr", "ocvr", "zgr", "ogr", "zvanna", "ovanna", "zcharm", "ocharm",
+	"agg_dex", "one_agg_dex", "agg_call_dex", "one_agg_call_dex", "agg_put_dex", "one_agg_put_dex",
+	"net_dex", "one_net_dex", "net_call_dex", "one_net_call_dex", "net_put_dex", "one_net_put_dex",
+	"dexoflow", "gexoflow", "cvroflow", "one_dexoflow", "one_gexoflow", "one_cvroflow",
+}
+
+// DBResult summarizes an ExportDB run.
+type DBResult struct {
+	Files int            // data files read
+	Rows  map[string]int // rows written per table
+}
+
+// dbWriter receives the rows of each table.
+type dbWriter interface {
+	insert(t *table, row []any) error
+	// finish writes the database; abort discards it.
+	finish() error
+	abort()
+}
+
+// ExportDB loads every data file of a date directory into normalized tables
+// (see dbTables) and writes them to dst as a SQLite or DuckDB database. DuckDB
+// output needs the duckdb CLI on PATH. dst is written atomically.
+func ExportDB(dateDir, dst, format string, logger *zap.Logger) (*DBResult, error) {
+	files, err := dbSourceFiles(dateDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no data files in %s", dateDir)
+	}
+
+	var w dbWriter
+	switch format {
+	case DBFormatSQLite:
+		w, err = newSQLiteWriter(dst)
+	case DBFormatDuckDB:
+		w, err = newDuckDBWriter(dst)
+	default:
+		return nil, fmt.Errorf("unknown database format %q (want %s or %s)", format, DBFormatSQLite, DBFormatDuckDB)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DBResult{Rows: make(map[string]int)}
+	counting := &countingWriter{dbWriter: w, rows: result.Rows}
+	for _, rel := range files {
+		logger.Debug("exporting", zap.String("file", rel))
+		if err := exportFile(counting, filepath.Join(dateDir, filepath.FromSlash(rel)), rel); err != nil {
+			w.abort()
+			return nil, fmt.Errorf("%s: %w", rel, err)
+		}
+		result.Files++
+	}
+
+	if err := w.finish(); err != nil {
+		w.abort()
+		return nil, err
+	}
+	return result, nil
+}
+
+type countingWriter struct {
+	dbWriter
+	rows map[string]int
+}
+
+func (c *countingWriter) insert(t *table, row []any) error {
+	c.rows[t.name]++
+	return c.dbWriter.insert(t, row)
+}
+
+// dbSourceFiles lists the data files of a date directory, one per
+// ticker/package/category, preferring JSONL over JSON over Parquet.
+func dbSourceFiles(dateDir string) ([]string, error) {
+	rank := map[string]int{data.JSONLExt: 0, data.JSONLZstdExt: 1, ".json": 2, ".parquet": 3}
+	best := make(map[string]string)
+
+	err := filepath.Walk(dateDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".staging" {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(dateDir, path)
+		if err != nil || info.IsDir() || !manifest.IsDataPath(rel) {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		for ext, r := range rank {
+			if !strings.HasSuffix(rel, ext) {
+				continue
+			}
+			base := strings.TrimSuffix(rel, ext)
+			if strings.Contains(filepath.Base(base), ".") {
+				continue
+			}
+			if prev, ok := best[base]; !ok || r < rank[strings.TrimPrefix(prev, base)] {
+				best[base] = rel
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking %s: %w", dateDir, err)
+	}
+
+	files := make([]string, 0, len(best))
+	for _, rel := range best {
+		files = append(files, rel)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// exportFile writes the records of one {ticker}/{package}/{category}.{ext}
+// file as table rows.
+func exportFile(w dbWriter, path, rel string) error {
+	parts := strings.Split(rel, "/")
+	ticker, pkg := parts[0], parts[1]
+	category := parts[2][:strings.Index(parts[2], ".")]
+	key := func(ts int64) []any { return []any{ticker, pkg, category, ts} }
+
+	switch config.Package(pkg) {
+	case config.PackageOrderflow:
+		return eachModel(path, func(r *data.OrderflowData) error {
+			return w.insert(orderflowTable, append(key(r.Timestamp),
+				r.Spot, r.ZMlgamma, r.ZMsgamma, r.OMlgamma, r.OMsgamma,
+				r.ZeroMcall, r.ZeroMput, r.OneMcall, r.OneMput,
+				r.Zcvr, r.Ocvr, r.Zgr, r.Ogr, r.Zvanna, r.Ovanna, r.Zcharm, r.Ocharm,
+				r.AggDex, r.OneAggDex, r.AggCallDex, r.OneAggCallDex, r.AggPutDex, r.OneAggPutDex,
+				r.NetDex, r.OneNetDex, r.NetCallDex, r.OneNetCallDex, r.NetPutDex, r.OneNetPutDex,
+				r.Dexoflow, r.Gexoflow, r.Cvroflow, r.OneDexoflow, r.OneGexoflow, r.OneCvroflow,
+			))
+		})
+
+	case config.PackageVolatility:
+		return eachModel(path, func(r *data.VolatilityData) error {
+			if err := w.insert(volatilityTable, append(key(r.Timestamp),
+				r.Spot, r.AtmIV, r.RiskReversal25d, r.Butterfly25d, r.MinDTE, r.SecMinDTE,
+			)); err != nil {
+				return err
+			}
+			var strikes [][]float64
+			if err := unmarshalOptional(r.Strikes, &strikes); err != nil {
+				return fmt.Errorf("strikes at %d: %w", r.Timestamp, err)
+			}
+			for _, s := range strikes {
+				if len(s) < 3 {
+					continue
+				}
+				if err := w.insert(ivStrikesTable, append(key(r.Timestamp), s[0], s[1], s[2])); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	case config.PackageState, config.PackageClassic:
+		if !strings.HasPrefix(category, "gex_") {
+			return eachModel(path, func(r *data.GreekData) error {
+				return w.insert(greeksTable, append(key(r.Timestamp),
+					r.Spot, r.MajorPositive, r.MajorNegative, r.MajorLongGamma, r.MajorShortGamma,
+					r.MinDTE, r.SecMinDTE, jsonText(r.MiniContracts),
+				))
+			})
+		}
+		return eachModel(path, func(r *data.GexData) error {
+			if err := w.insert(gexTable, append(key(r.Timestamp),
+				r.Spot, r.ZeroGamma, r.MajorPosVol, r.MajorPosOI, r.MajorNegVol, r.MajorNegOI,
+				r.SumGexVol, r.SumGexOI, r.DeltaRiskReversal, r.MinDTE, r.SecMinDTE, jsonText(r.MaxPriors),
+			)); err != nil {
+				return err
+			}
+			// [[strike, gex_vol, gex_oi, [priors]], ...]
+			var strikes [][]json.RawMessage
+			if err := unmarshalOptional(r.Strikes, &strikes); err != nil {
+				return fmt.Errorf("strikes at %d: %w", r.Timestamp, err)
+			}
+			for _, s := range strikes {
+				if len(s) < 3 {
+					continue
+				}
+				var v [3]float64
+				for i := range v {
+					if err := json.Unmarshal(s[i], &v[i]); err != nil {
+						return fmt.Errorf("strikes at %d: %w", r.Timestamp, err)
+					}
+				}
+				var priors any
+				if len(s) > 3 {
+					priors = jsonText(s[3])
+				}
+				if err := w.insert(strikesTable, append(key(r.Timestamp), v[0], v[1], v[2], priors)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	default:
+		return fmt.Errorf("unknown package: %s", pkg)
+	}
+}
+
+// eachModel decodes the records of a JSON, JSONL(.zst) or Parquet file.
+func eachModel[T any](path string, fn func(*T) error) error {
+	if !strings.HasSuffix(path, ".parquet") {
+		in, err := data.OpenJSONL(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = in.Close() }()
+
+		n := 0
+		return eachRecord(in, func(raw []byte) error {
+			n++
+			var rec T
+			if err := json.Unmarshal(raw, &rec); err != nil {
+				return fmt.Errorf("record %d: %w", n, err)
+			}
+			return fn(&rec)
+		})
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	file, err := parquet.OpenFile(f, info.Size())
+	if err != nil {
+		return fmt.Errorf("opening Parquet: %w", err)
+	}
+	reader := parquet.NewGenericReader[T](file)
+	defer func() { _ = reader.Close() }()
+
+	rows := make([]T, parquetBatch)
+	for {
+		n, err := reader.Read(rows)
+		for i := range rows[:n] {
+			if err := fn(&rows[i]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading Parquet: %w", err)
+		}
+	}
+}
+
+func unmarshalOptional(raw json.RawMessage, v any) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// jsonText returns a nested JSON value as text, or nil for SQL NULL.
+func jsonText(raw json.RawMessage) any {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return string(raw)
+}
+
+// createTableSQL returns the CREATE TABLE statement for t.
+func createTableSQL(t *table) string {
+	defs := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		defs[i] = c.name + " " + c.typ
+	}
+	return fmt.Sprintf("CREATE TABLE %s (%s)", t.name, strings.Join(defs, ", "))
+}
+
+// sqliteWriter inserts rows in a single transaction.
+type sqliteWriter struct {
+	db    *sql.DB
+	tx    *sql.Tx
+	stmts map[*table]*sql.Stmt
+	tmp   string
+	dst   string
+}
+
+func newSQLiteWriter(dst string) (*sqliteWriter, error) {
+	tmp := dst + ".tmp"
+	_ = os.Remove(tmp)
+	db, err := sql.Open("sqlite", tmp+"?_pragma=journal_mode(OFF)&_pragma=synchronous(OFF)")
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", tmp, err)
+	}
+	w := &sqliteWriter{db: db, stmts: make(map[*table]*sql.Stmt), tmp: tmp, dst: dst}
+
+	if w.tx, err = db.Begin(); err != nil {
+		w.abort()
+		return nil, err
+	}
+	for _, t := range dbTables {
+		if _, err := w.tx.Exec(createTableSQL(t)); err != nil {
+			w.abort()
+			return nil, fmt.Errorf("creating table %s: %w", t.name, err)
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(t.columns)), ", ")
+		stmt, err := w.tx.Prepare(fmt.Sprintf("INSERT INTO %s VALUES (%s)", t.name, placeholders))
+		if err != nil {
+			w.abort()
+			return nil, err
+		}
+		w.stmts[t] = stmt
+	}
+	return w, nil
+}
+
+func (w *sqliteWriter) insert(t *table, row []any) error {
+	_, err := w.stmts[t].Exec(row...)
+	return err
+}
+
+func (w *sqliteWriter) finish() error {
+	for _, t := range dbTables {
+		if _, err := w.tx.Exec(fmt.Sprintf("CREATE INDEX %s_ticker_ts ON %s (ticker, category, timestamp)", t.name, t.name)); err != nil {
+			return fmt.Errorf("indexing %s: %w", t.name, err)
+		}
+	}
+	if err := w.tx.Commit(); err != nil {
+		return err
+	}
+	if err := w.db.Close(); err != nil {
+		return err
+	}
+	return os.Rename(w.tmp, w.dst)
+}
+
+func (w *sqliteWriter) abort() {
+	if w.tx != nil {
+		_ = w.tx.Rollback()
+	}
+	_ = w.db.Close()
+	_ = os.Remove(w.tmp)
+}
+
+// duckDBWriter spools each table to CSV and loads them with the duckdb CLI,
+// keeping the binaries free of cgo.
+type duckDBWriter struct {
+	cli  string
+	dir  string
+	dst  string
+	csvs map[*table]*csvTable
+}
+
+type csvTable struct {
+	file *os.File
+	w    *csv.Writer
+}
+
+func newDuckDBWriter(dst string) (*duckDBWriter, error) {
+	cli, err := exec.LookPath("duckdb")
+	if err != nil {
+		return nil, fmt.Errorf("duckdb format needs the duckdb CLI on PATH: %w", err)
+	}
+	dir, err := os.MkdirTemp("", "gexbot-duckdb-*")
+	if err != nil {
+		return nil, err
+	}
+	return &duckDBWriter{cli: cli, dir: dir, dst: dst, csvs: make(map[*table]*csvTable)}, nil
+}
+
+func (w *duckDBWriter) insert(t *table, row []any) error {
+	ct, ok := w.csvs[t]
+	if !ok {
+		f, err := os.Create(filepath.Join(w.dir, t.name+".csv"))
+		if err != nil {
+			return err
+		}
+		ct = &csvTable{file: f, w: csv.NewWriter(f)}
+		w.csvs[t] = ct
+	}
+
+	record := make([]string, len(row))
+	for i, v := range row {
+		switch v := v.(type) {
+		case nil:
+			record[i] = ""
+		case string:
+			record[i] = v
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		case int:
+			record[i] = strconv.Itoa(v)
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'g', -1, 64)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return ct.w.Write(record)
+}
+
+func (w *duckDBWriter) finish() error {
+	var script strings.Builder
+	for _, t := range dbTables {
+		script.WriteString(createTableSQL(t) + ";\n")
+		ct, ok := w.csvs[t]
+		if !ok {
+			continue
+		}
+		ct.w.Flush()
+		if err := ct.w.Error(); err != nil {
+			return err
+		}
+		if err := ct.file.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(&script, "COPY %s FROM '%s' (FORMAT csv, HEADER false, NULLSTR '');\n",
+			t.name, strings.ReplaceAll(ct.file.Name(), "'", "''"))
+	}
+
+	tmp := w.dst + ".tmp"
+	_ = os.Remove(tmp)
+	cmd := exec.Command(w.cli, tmp)
+	cmd.Stdin = strings.NewReader(script.String())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("duckdb: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	if err := os.Rename(tmp, w.dst); err != nil {
+		return err
+	}
+	return os.RemoveAll(w.dir)
+}
+
+func (w *duckDBWriter) abort() {
+	for _, ct := range w.csvs {
+		_ = ct.file.Close()
+	}
+	_ = os.RemoveAll(w.dir)
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"database/sql"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/sample"
+)
+
+// exportSampleDB exports a generated day of SPX data to dir/name in format.
+func exportSampleDB(t *testing.T, name, format string) (string, *DBResult) {
+	t.Helper()
+	dir := t.TempDir()
+	day, err := sample.Generate(dir, sample.Options{Date: "2025-01-02", Tickers: []string{"SPX"}, Interval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(dir, name)
+	result, err := ExportDB(day.Dir, dst, format, zap.NewNop())
+	if err != nil {
+		t.Fatalf("ExportDB: %v", err)
+	}
+	if result.Files != day.Files {
+		t.Errorf("files = %d, want %d", result.Files, day.Files)
+	}
+	return dst, result
+}
+
+// orphanStrikesSQL counts strikes rows without their gex snapshot.
+const orphanStrikesSQL = `SELECT count(*) FROM strikes s LEFT JOIN gex g
+	ON g.ticker = s.ticker AND g.category = s.category AND g.timestamp = s.timestamp
+	WHERE g.timestamp IS NULL`
+
+func TestExportDBSQLite(t *testing.T) {
+	dst, result := exportSampleDB(t, "day.sqlite", DBFormatSQLite)
+
+	db, err := sql.Open("sqlite", dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+
+	for _, tbl := range dbTables {
+		var n int
+		if err := db.QueryRow("SELECT count(*) FROM " + tbl.name).Scan(&n); err != nil {
+			t.Fatalf("%s: %v", tbl.name, err)
+		}
+		if n == 0 || n != result.Rows[tbl.name] {
+			t.Errorf("%s: %d rows, result reports %d", tbl.name, n, result.Rows[tbl.name])
+		}
+	}
+
+	// Every gex snapshot's strikes join back to it
+	var orphans int
+	err = db.QueryRow(orphanStrikesSQL).Scan(&orphans)
+	if err != nil || orphans != 0 {
+		t.Errorf("orphan strikes = %d, err = %v", orphans, err)
+	}
+}
+
+func TestExportDBDuckDB(t *testing.T) {
+	cli, err := exec.LookPath("duckdb")
+	if err != nil {
+		t.Skip("duckdb CLI not on PATH")
+	}
+	dst, result := exportSampleDB(t, "day.duckdb", DBFormatDuckDB)
+
+	count := func(query string) int {
+		t.Helper()
+		out, err := exec.Command(cli, "-readonly", "-csv", "-noheader", dst, query).CombinedOutput()
+		if err != nil {
+			t.Fatalf("duckdb %q: %v: %s", query, err, out)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(string(out)))
+		if err != nil {
+			t.Fatalf("duckdb %q: %q is not a count", query, out)
+		}
+		return n
+	}
+
+	for _, tbl := range dbTables {
+		if n := count("SELECT count(*) FROM " + tbl.name); n == 0 || n != result.Rows[tbl.name] {
+			t.Errorf("%s: %d rows, result reports %d", tbl.name, n, result.Rows[tbl.name])
+		}
+	}
+	if orphans := count(orphanStrikesSQL); orphans != 0 {
+		t.Errorf("orphan strikes = %d", orphans)
+	}
+}
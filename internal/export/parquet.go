@@ -0,0 +1,198 @@
+// Package export writes downloaded GexBot history in formats for analysis
+// tools.
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// parquetBatch is the number of records buffered per write.
+const parquetBatch = 1024
+
+// maxLineSize bounds a single JSONL record (large strike ladders).
+const maxLineSize = 64 << 20
+
+// ConvertToParquet converts a downloaded .json array or .jsonl(.zst) file of the
+// given package and category to Parquet at dst. Scalar fields become typed
+// columns; nested arrays (strikes, max_priors, mini_contracts) are stored as
+// JSON columns. dst is written atomically. Returns the number of rows.
+func ConvertToParquet(src, dst, pkg, category string) (int, error) {
+	switch config.Package(pkg) {
+	case config.PackageOrderflow:
+		return convertParquet[data.OrderflowData](src, dst)
+	case config.PackageVolatility:
+		return convertParquet[data.VolatilityData](src, dst)
+	case config.PackageState, config.PackageClassic:
+		if strings.HasPrefix(category, "gex_") {
+			return convertParquet[data.GexData](src, dst)
+		}
+		return convertParquet[data.GreekData](src, dst)
+	default:
+		return 0, fmt.Errorf("unknown package: %s", pkg)
+	}
+}
+
+func convertParquet[T any](src, dst string) (int, error) {
+	in, err := data.OpenJSONL(src)
+	if err != nil {
+		return 0, fmt.Errorf("opening file: %w", err)
+	}
+	defer func() { _ = in.Close() }()
+
+	tmp := dst + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return 0, fmt.Errorf("creating output file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp) }()
+
+	w := parquet.NewGenericWriter[T](out, parquet.Compression(&parquet.Zstd))
+	batch := make([]T, 0, parquetBatch)
+	rows := 0
+	flush := func() error {
+		if _, err := w.Write(batch); err != nil {
+			return fmt.Errorf("writing rows: %w", err)
+		}
+		rows += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	err = eachRecord(in, func(raw []byte) error {
+		var record T
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return fmt.Errorf("record %d: %w", rows+len(batch)+1, err)
+		}
+		batch = append(batch, record)
+		if len(batch) == parquetBatch {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil && len(batch) > 0 {
+		err = flush()
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		return 0, fmt.Errorf("renaming output file: %w", err)
+	}
+	return rows, nil
+}
+
+// eachRecord streams the records of a JSON array or JSONL file to fn,
+// detecting the layout from the first non-space byte.
+func eachRecord(r io.Reader, fn func([]byte) error) error {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading file: %w", err)
+		}
+		if b[0] == '[' {
+			return eachArrayRecord(br, fn)
+		}
+		if !isSpace(b[0]) {
+			break
+		}
+		_, _ = br.ReadByte()
+	}
+
+	scanner := bufio.NewScanner(br)
+	scanner.Buffer(make([]byte, 0, 1<<20), maxLineSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading lines: %w", err)
+	}
+	return nil
+}
+
+// eachArrayRecord streams the elements of a JSON array.
+func eachArrayRecord(r io.Reader, fn func([]byte) error) error {
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("parsing JSON array: %w", err)
+	}
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("parsing JSON array: %w", err)
+		}
+		if err := fn(raw); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("parsing JSON array: %w", err)
+	}
+	return nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// ConvertDirToParquet converts every .json and .jsonl(.zst) file under a date
+// directory ({ticker}/{package}/{category}) to Parquet, skipping files that
+// already have a Parquet copy. Originals are removed unless keep is set.
+func ConvertDirToParquet(dir string, keep bool, logger *zap.Logger) error {
+	return ConvertDir(dir, Converter{
+		Output: func(src string) (string, bool) {
+			_, isJSONL := data.JSONLCategory(filepath.Base(src))
+			return ParquetPath(src), isJSONL || strings.HasSuffix(src, ".json")
+		},
+		Done: func(_, dst string) bool {
+			_, err := os.Stat(dst)
+			return err == nil
+		},
+		Convert: func(src, dst string) error {
+			// Layout is {ticker}/{package}/{category}.json[l]
+			pkg := filepath.Base(filepath.Dir(src))
+			category := strings.TrimSuffix(filepath.Base(dst), ".parquet")
+			_, err := ConvertToParquet(src, dst, pkg, category)
+			return err
+		},
+		Keep: keep,
+	}, logger)
+}
+
+// ParquetPath returns the Parquet path for a .json, .jsonl or .jsonl.zst
+// data file.
+func ParquetPath(path string) string {
+	if category, ok := data.JSONLCategory(filepath.Base(path)); ok {
+		return filepath.Join(filepath.Dir(path), category+".parquet")
+	}
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".parquet"
+}
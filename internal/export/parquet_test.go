@@ -0,0 +1,84 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+func TestConvertToParquet(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("jsonl gex", func(t *testing.T) {
+		src := filepath.Join(dir, "gex_zero.jsonl")
+		lines := `{"timestamp":1,"ticker":"SPX","spot":6000.5,"strikes":[[6000,1.5,2.5,[]]],"max_priors":[]}
+{"timestamp":2,"ticker":"SPX","spot":6001.25,"strikes":[[6005,3,4,[]]],"max_priors":[]}
+`
+		if err := os.WriteFile(src, []byte(lines), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		dst := ParquetPath(src)
+		n, err := ConvertToParquet(src, dst, "state", "gex_zero")
+		if err != nil {
+			t.Fatalf("ConvertToParquet: %v", err)
+		}
+		if n != 2 {
+			t.Fatalf("rows = %d, want 2", n)
+		}
+
+		rows, err := parquet.ReadFile[data.GexData](dst)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		if len(rows) != 2 || rows[1].Timestamp != 2 || rows[1].Spot != 6001.25 || rows[1].Ticker != "SPX" {
+			t.Fatalf("unexpected rows: %+v", rows)
+		}
+		if got := string(rows[0].Strikes); got != "[[6000,1.5,2.5,[]]]" {
+			t.Errorf("strikes = %s", got)
+		}
+	})
+
+	t.Run("json array orderflow", func(t *testing.T) {
+		src := filepath.Join(dir, "orderflow.json")
+		records := []data.OrderflowData{{Timestamp: 1, Ticker: "SPY", NetDex: -3.5}, {Timestamp: 2, Ticker: "SPY", NetDex: 4}}
+		b, _ := json.MarshalIndent(records, "", "  ")
+		if err := os.WriteFile(src, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		dst := ParquetPath(src)
+		if _, err := ConvertToParquet(src, dst, "orderflow", "orderflow"); err != nil {
+			t.Fatalf("ConvertToParquet: %v", err)
+		}
+		rows, err := parquet.ReadFile[data.OrderflowData](dst)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		if len(rows) != 2 || rows[0] != records[0] || rows[1] != records[1] {
+			t.Fatalf("rows = %+v, want %+v", rows, records)
+		}
+	})
+
+	t.Run("invalid record leaves no output", func(t *testing.T) {
+		src := filepath.Join(dir, "iv_zero.jsonl")
+		if err := os.WriteFile(src, []byte("{\"timestamp\":1}\nnot json\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		dst := ParquetPath(src)
+		if _, err := ConvertToParquet(src, dst, "volatility", "iv_zero"); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, err := os.Stat(dst); !os.IsNotExist(err) {
+			t.Errorf("output exists after failure")
+		}
+		if _, err := os.Stat(dst + ".tmp"); !os.IsNotExist(err) {
+			t.Errorf("temp file left behind")
+		}
+	})
+}
@@ -0,0 +1,31 @@
+// Package hook runs the user's post-download command after a date is
+// committed, e.g. to load it into a database or rsync it elsewhere.
+package hook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Run runs command through the shell with DATE set to the committed date and
+// DIR to its output directory (a bucket URL for remote output). The command's
+// stdout and stderr go to out.
+func Run(ctx context.Context, command, date, dir string, out io.Writer) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(), "DATE="+date, "DIR="+dir)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("post-download hook: %w", err)
+	}
+	return nil
+}
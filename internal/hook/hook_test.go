@@ -0,0 +1,26 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	var out bytes.Buffer
+	if err := Run(context.Background(), `echo "$DATE $DIR"`, "2025-11-14", "data/2025-11-14", &out); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "2025-11-14 data/2025-11-14" {
+		t.Errorf("hook printed %q", got)
+	}
+
+	if err := Run(context.Background(), "exit 3", "2025-11-14", "data/2025-11-14", &out); err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Errorf("Run() error = %v, want exit status 3", err)
+	}
+}
@@ -0,0 +1,268 @@
+// Package maintenance simulates upstream maintenance windows so clients can
+// rehearse their outage handling. A window is either toggled manually by an
+// admin or scheduled in config as a recurring daily or weekly time range.
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMessage is returned to clients when no message is configured.
+const DefaultMessage = "Service temporarily unavailable for maintenance"
+
+// Window is a recurring maintenance period in the controller's time zone.
+// End before Start wraps past midnight.
+type Window struct {
+	Weekday *time.Weekday // nil for every day
+	Start   int           // minutes after midnight
+	End     int           // minutes after midnight
+}
+
+// String formats the window in the syntax accepted by ParseWindows.
+func (w Window) String() string {
+	s := fmt.Sprintf("%02d:%02d-%02d:%02d", w.Start/60, w.Start%60, w.End/60, w.End%60)
+	if w.Weekday != nil {
+		s = w.Weekday.String()[:3] + " " + s
+	}
+	return s
+}
+
+// active reports whether t falls inside the window and, if so, when the
+// window ends.
+func (w Window) active(t time.Time) (bool, time.Time) {
+	minute := t.Hour()*60 + t.Minute()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	at := func(day time.Time, m int) time.Time {
+		return day.Add(time.Duration(m) * time.Minute)
+	}
+
+	if w.Start < w.End {
+		if w.matchesDay(t.Weekday()) && minute >= w.Start && minute < w.End {
+			return true, at(midnight, w.End)
+		}
+		return false, time.Time{}
+	}
+
+	// Wraps midnight: the evening part belongs to today, the morning part
+	// to a window that started yesterday
+	if w.matchesDay(t.Weekday()) && minute >= w.Start {
+		return true, at(midnight.AddDate(0, 0, 1), w.End)
+	}
+	if w.matchesDay((t.Weekday()+6)%7) && minute < w.End {
+		return true, at(midnight, w.End)
+	}
+	return false, time.Time{}
+}
+
+func (w Window) matchesDay(day time.Weekday) bool {
+	return w.Weekday == nil || *w.Weekday == day
+}
+
+// ParseWindows parses a comma-separated list of windows such as
+// "02:00-02:30" (daily) or "Sat 22:00-02:00" (weekly, wrapping midnight).
+func ParseWindows(spec string) ([]Window, error) {
+	var windows []Window
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		var w Window
+		fields := strings.Fields(item)
+		switch len(fields) {
+		case 1:
+		case 2:
+			day, err := parseWeekday(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("maintenance window %q: %w", item, err)
+			}
+			w.Weekday = &day
+		default:
+			return nil, fmt.Errorf("maintenance window %q: expected [Day] HH:MM-HH:MM", item)
+		}
+
+		startStr, endStr, ok := strings.Cut(fields[len(fields)-1], "-")
+		if !ok {
+			return nil, fmt.Errorf("maintenance window %q: expected HH:MM-HH:MM", item)
+		}
+		var err error
+		if w.Start, err = parseClock(startStr); err != nil {
+			return nil, fmt.Errorf("maintenance window %q: %w", item, err)
+		}
+		if w.End, err = parseClock(endStr); err != nil {
+			return nil, fmt.Errorf("maintenance window %q: %w", item, err)
+		}
+		if w.Start == w.End {
+			return nil, fmt.Errorf("maintenance window %q: start and end are equal", item)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(s, ":")
+	hour, herr := strconv.Atoi(h)
+	minute, merr := strconv.Atoi(m)
+	if !ok || herr != nil || merr != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return hour*60 + minute, nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()[:3]) || strings.EqualFold(s, d.String()) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+// Status describes the current maintenance state.
+type Status struct {
+	Active  bool
+	Source  string    // "manual" or "scheduled" when active
+	Message string    // client-facing message
+	Until   time.Time // end of the window; zero when open-ended
+}
+
+// Controller tracks manual and scheduled maintenance and notifies listeners
+// when maintenance begins.
+type Controller struct {
+	mu             sync.Mutex
+	windows        []Window
+	loc            *time.Location
+	defaultMessage string
+
+	manual        bool
+	manualMessage string
+	manualUntil   time.Time
+
+	wasActive bool
+	listeners []func(Status)
+	now       func() time.Time
+}
+
+// NewController creates a controller for the given scheduled windows,
+// evaluated in loc (UTC when nil).
+func NewController(windows []Window, loc *time.Location, message string) *Controller {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if message == "" {
+		message = DefaultMessage
+	}
+	return &Controller{
+		windows:        windows,
+		loc:            loc,
+		defaultMessage: message,
+		now:            time.Now,
+	}
+}
+
+// Windows returns the scheduled windows.
+func (c *Controller) Windows() []Window {
+	return c.windows
+}
+
+// OnStart registers fn to run whenever maintenance becomes active.
+// Call before Run.
+func (c *Controller) OnStart(fn func(Status)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, fn)
+}
+
+// Status returns the current maintenance state. Safe to call on a nil
+// controller, which is never in maintenance.
+func (c *Controller) Status() Status {
+	if c == nil {
+		return Status{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.statusLocked(c.now())
+}
+
+func (c *Controller) statusLocked(now time.Time) Status {
+	if c.manual && !c.manualUntil.IsZero() && !now.Before(c.manualUntil) {
+		c.manual = false
+	}
+	if c.manual {
+		return Status{Active: true, Source: "manual", Message: c.manualMessage, Until: c.manualUntil}
+	}
+
+	local := now.In(c.loc)
+	for _, w := range c.windows {
+		if ok, until := w.active(local); ok {
+			return Status{Active: true, Source: "scheduled", Message: c.defaultMessage, Until: until}
+		}
+	}
+	return Status{}
+}
+
+// Enable starts manual maintenance. A zero duration lasts until Disable.
+func (c *Controller) Enable(message string, duration time.Duration) Status {
+	c.mu.Lock()
+	if message == "" {
+		message = c.defaultMessage
+	}
+	c.manual = true
+	c.manualMessage = message
+	c.manualUntil = time.Time{}
+	if duration > 0 {
+		c.manualUntil = c.now().Add(duration)
+	}
+	c.mu.Unlock()
+
+	return c.check()
+}
+
+// Disable ends manual maintenance. Scheduled windows still apply.
+func (c *Controller) Disable() Status {
+	c.mu.Lock()
+	c.manual = false
+	c.mu.Unlock()
+
+	return c.check()
+}
+
+// Run evaluates scheduled windows every second until ctx is cancelled.
+func (c *Controller) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	c.check()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.check()
+		}
+	}
+}
+
+// check fires listeners on the transition into maintenance.
+func (c *Controller) check() Status {
+	c.mu.Lock()
+	status := c.statusLocked(c.now())
+	started := status.Active && !c.wasActive
+	c.wasActive = status.Active
+	listeners := c.listeners
+	c.mu.Unlock()
+
+	if started {
+		for _, fn := range listeners {
+			fn(status)
+		}
+	}
+	return status
+}
@@ -0,0 +1,76 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWindows(t *testing.T) {
+	windows, err := ParseWindows("02:00-02:30, Sat 22:00-02:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(windows) != 2 || windows[0].String() != "02:00-02:30" || windows[1].String() != "Sat 22:00-02:00" {
+		t.Errorf("unexpected windows: %v", windows)
+	}
+
+	for _, bad := range []string{"2-3", "25:00-01:00", "Funday 01:00-02:00", "01:00-01:00", "Mon Tue 01:00-02:00"} {
+		if _, err := ParseWindows(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestControllerScheduledWindows(t *testing.T) {
+	windows, _ := ParseWindows("Sat 22:00-02:00")
+	c := NewController(windows, time.UTC, "")
+
+	tests := []struct {
+		now    time.Time
+		active bool
+		until  time.Time
+	}{
+		{time.Date(2026, 1, 3, 21, 59, 0, 0, time.UTC), false, time.Time{}}, // Saturday
+		{time.Date(2026, 1, 3, 23, 0, 0, 0, time.UTC), true, time.Date(2026, 1, 4, 2, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 1, 4, 1, 30, 0, 0, time.UTC), true, time.Date(2026, 1, 4, 2, 0, 0, 0, time.UTC)}, // Sunday morning
+		{time.Date(2026, 1, 5, 1, 30, 0, 0, time.UTC), false, time.Time{}},                                // Monday morning
+	}
+	for _, tt := range tests {
+		c.now = func() time.Time { return tt.now }
+		s := c.Status()
+		if s.Active != tt.active || !s.Until.Equal(tt.until) {
+			t.Errorf("%s: got active=%v until=%v", tt.now, s.Active, s.Until)
+		}
+		if s.Active && (s.Source != "scheduled" || s.Message != DefaultMessage) {
+			t.Errorf("%s: unexpected status %+v", tt.now, s)
+		}
+	}
+}
+
+func TestControllerManualToggle(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	c := NewController(nil, nil, "")
+	c.now = func() time.Time { return now }
+
+	var started []Status
+	c.OnStart(func(s Status) { started = append(started, s) })
+
+	s := c.Enable("upgrading", time.Minute)
+	if !s.Active || s.Source != "manual" || s.Message != "upgrading" {
+		t.Fatalf("unexpected status after Enable: %+v", s)
+	}
+	c.Enable("still upgrading", 0) // already active: no second notification
+	if len(started) != 1 {
+		t.Errorf("expected one start notification, got %d", len(started))
+	}
+
+	if s := c.Disable(); s.Active {
+		t.Errorf("expected inactive after Disable, got %+v", s)
+	}
+
+	c.Enable("", time.Minute)
+	now = now.Add(2 * time.Minute)
+	if s := c.Status(); s.Active {
+		t.Errorf("expected manual window to expire, got %+v", s)
+	}
+}
@@ -0,0 +1,245 @@
+// Package manifest records the files of a downloaded date with their sizes
+// and SHA-256 digests, so a dataset can be proven complete and intact.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Name is the manifest file name in each date directory.
+const Name = "manifest.json"
+
+// Manifest lists the files of one date directory.
+type Manifest struct {
+	Date      string    `json:"date"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Files     []File    `json:"files"`
+}
+
+// File is one downloaded (or converted) data file.
+type File struct {
+	Path         string    `json:"path"` // relative to the date directory, slash-separated
+	Ticker       string    `json:"ticker"`
+	Package      string    `json:"package"`
+	Category     string    `json:"category"`
+	Size         int64     `json:"size"`
+	SHA256       string    `json:"sha256"`
+	DownloadedAt time.Time `json:"downloaded_at"`
+	Source       *Source   `json:"source,omitempty"` // download this file was converted from
+}
+
+// Source describes the downloaded file a converted file was made from.
+type Source struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// New returns an empty manifest for date.
+func New(date string) *Manifest {
+	return &Manifest{Date: date, Files: []File{}}
+}
+
+// Read loads a manifest file. A missing file returns an error satisfying
+// errors.Is(err, os.ErrNotExist).
+func Read(path string) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return Decode(f)
+}
+
+// Decode parses a manifest.
+func Decode(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// Write stores the manifest at path atomically.
+func (m *Manifest) Write(path string) error {
+	m.UpdatedAt = time.Now().UTC()
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Set adds f, replacing any entry for the same ticker/package/category.
+func (m *Manifest) Set(f File) {
+	for i, existing := range m.Files {
+		if existing.Ticker == f.Ticker && existing.Package == f.Package && existing.Category == f.Category {
+			m.Files[i] = f
+			return
+		}
+	}
+	m.Files = append(m.Files, f)
+}
+
+// IsDataPath reports whether rel, relative to a date directory, is laid out
+// as {ticker}/{package}/{category}.{ext}.
+func IsDataPath(rel string) bool {
+	return strings.Count(filepath.ToSlash(rel), "/") == 2
+}
+
+// NewFile describes the file at dateDir/rel, laid out as
+// {ticker}/{package}/{category}.{ext}. DownloadedAt is its modification time.
+func NewFile(dateDir, rel string) (File, error) {
+	if !IsDataPath(rel) {
+		return File{}, fmt.Errorf("unexpected data file path %q", rel)
+	}
+	rel = filepath.ToSlash(rel)
+	parts := strings.Split(rel, "/")
+
+	path := filepath.Join(dateDir, filepath.FromSlash(rel))
+	info, err := os.Stat(path)
+	if err != nil {
+		return File{}, err
+	}
+	size, sum, err := HashFile(path)
+	if err != nil {
+		return File{}, err
+	}
+
+	name := parts[2]
+	return File{
+		Path:         rel,
+		Ticker:       parts[0],
+		Package:      parts[1],
+		Category:     trimExt(name),
+		Size:         size,
+		SHA256:       sum,
+		DownloadedAt: info.ModTime().UTC(),
+	}, nil
+}
+
+// HashFile returns the size and hex SHA-256 of a file.
+func HashFile(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// convertedExts are the formats a downloaded .json file is converted to.
+var convertedExts = []string{".jsonl", ".jsonl.zst", ".parquet"}
+
+// trimExt removes the whole extension of a data file name or path, e.g.
+// .jsonl.zst; category names have no dots.
+func trimExt(p string) string {
+	start := strings.LastIndex(p, "/") + 1
+	if dot := strings.Index(p[start:], "."); dot >= 0 {
+		return p[:start+dot]
+	}
+	return p
+}
+
+// Refresh points entries whose file was converted (the .json is gone and a
+// .jsonl or .parquet copy exists) at the converted copy, keeping the
+// download as the entry's Source. A date without a manifest is left alone.
+func Refresh(dateDir string) error {
+	path := filepath.Join(dateDir, Name)
+	m, err := Read(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	changed := false
+	for i, f := range m.Files {
+		if _, err := os.Stat(filepath.Join(dateDir, filepath.FromSlash(f.Path))); err == nil {
+			continue
+		}
+		base := trimExt(f.Path)
+		for _, ext := range convertedExts {
+			converted, err := NewFile(dateDir, base+ext)
+			if err != nil {
+				continue
+			}
+			converted.DownloadedAt = f.DownloadedAt
+			converted.Source = f.Source
+			if converted.Source == nil {
+				converted.Source = &Source{Path: f.Path, Size: f.Size, SHA256: f.SHA256}
+			}
+			m.Files[i] = converted
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return m.Write(path)
+}
+
+// Rehash updates the entries of the files rels, relative to dateDir, after
+// their content was rewritten in place, e.g. encrypted. Their download time
+// and source are kept. A date without a manifest is left alone.
+func Rehash(dateDir string, rels []string) error {
+	path := filepath.Join(dateDir, Name)
+	m, err := Read(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	changed := make(map[string]bool, len(rels))
+	for _, rel := range rels {
+		changed[filepath.ToSlash(rel)] = true
+	}
+	updated := false
+	for i, f := range m.Files {
+		if !changed[f.Path] {
+			continue
+		}
+		rehashed, err := NewFile(dateDir, f.Path)
+		if err != nil {
+			return err
+		}
+		rehashed.DownloadedAt = f.DownloadedAt
+		rehashed.Source = f.Source
+		m.Files[i] = rehashed
+		updated = true
+	}
+	if !updated {
+		return nil
+	}
+	return m.Write(path)
+}
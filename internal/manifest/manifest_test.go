@@ -0,0 +1,72 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRefreshFollowsConversion(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, data string) {
+		t.Helper()
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("SPX/state/gex_zero.json", `[{"a":1}]`)
+	write("SPX/state/gex_one.json", `[]`)
+
+	m := New("2025-11-14")
+	for _, rel := range []string{"SPX/state/gex_zero.json", "SPX/state/gex_one.json"} {
+		f, err := NewFile(dir, rel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Set(f)
+	}
+	if err := m.Write(filepath.Join(dir, Name)); err != nil {
+		t.Fatal(err)
+	}
+	download := m.Files[1] // gex_zero after sorting
+
+	// Convert gex_zero
+	if err := os.Remove(filepath.Join(dir, "SPX", "state", "gex_zero.json")); err != nil {
+		t.Fatal(err)
+	}
+	write("SPX/state/gex_zero.jsonl", "{\"a\":1}\n")
+
+	if err := Refresh(dir); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	got, err := Read(filepath.Join(dir, Name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Files) != 2 {
+		t.Fatalf("files = %+v", got.Files)
+	}
+	if got.Files[0].Path != "SPX/state/gex_one.json" {
+		t.Errorf("unconverted entry changed: %+v", got.Files[0])
+	}
+	zero := got.Files[1]
+	if zero.Path != "SPX/state/gex_zero.jsonl" || zero.Category != "gex_zero" || zero.Size != 8 {
+		t.Errorf("converted entry = %+v", zero)
+	}
+	if zero.Source == nil || zero.Source.Path != download.Path || zero.Source.SHA256 != download.SHA256 {
+		t.Errorf("source = %+v, want download %+v", zero.Source, download)
+	}
+	if !zero.DownloadedAt.Equal(download.DownloadedAt) {
+		t.Errorf("DownloadedAt = %v, want %v", zero.DownloadedAt, download.DownloadedAt)
+	}
+}
+
+func TestRefreshWithoutManifest(t *testing.T) {
+	if err := Refresh(t.TempDir()); err != nil {
+		t.Errorf("Refresh without manifest: %v", err)
+	}
+}
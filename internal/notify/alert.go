@@ -0,0 +1,9 @@
+package notify
+
+// Alert is a notification about a problem outside of a download run, e.g.
+// the faker server failing to load data. Alerts are routed as failures.
+type Alert struct {
+	Title   string `json:"title"`   // e.g., "Faker Reload Failed: 2025-01-02"
+	Message string `json:"message"` // what happened and any error
+	Date    string `json:"date,omitempty"`
+}
@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// Route sends success and/or failure notifications to a backend.
+type Route struct {
+	Name     string // backend name, used in errors and the queue
+	Notifier Notifier
+	Success  bool // send success notifications
+	Failure  bool // send failure notifications
+}
+
+// Composite fans notifications out to several backends by their routes,
+// e.g., successes to ntfy and failures to Slack and a webhook. Backends are
+// notified concurrently, so a slow or rate limited one does not hold up
+// the others. With a queue, notifications a backend fails to send are
+// retried by Flush.
+type Composite struct {
+	routes []Route
+	queue  *Queue
+}
+
+// NewComposite creates a notifier sending to routes.
+func NewComposite(routes ...Route) *Composite {
+	return &Composite{routes: routes}
+}
+
+// WithQueue queues the notifications that fail to send in q.
+func (c *Composite) WithQueue(q *Queue) *Composite {
+	c.queue = q
+	return c
+}
+
+// SendSuccess sends a success notification to the backends routed to
+// successes.
+func (c *Composite) SendSuccess(ctx context.Context, result *download.BatchResult, date string, duration time.Duration) error {
+	return c.fanOut(ctx, notification{Result: result, Date: date, Duration: duration})
+}
+
+// SendFailure sends a failure notification to the backends routed to
+// failures.
+func (c *Composite) SendFailure(ctx context.Context, result *download.BatchResult, date string, duration time.Duration, err error) error {
+	n := notification{Failure: true, Result: result, Date: date, Duration: duration}
+	if err != nil {
+		n.Error = err.Error()
+	}
+	return c.fanOut(ctx, n)
+}
+
+// SendDigest sends a digest to the backends routed to failures when it
+// reports failures or gaps, and to those routed to successes otherwise.
+func (c *Composite) SendDigest(ctx context.Context, digest *Digest) error {
+	return c.fanOut(ctx, notification{Failure: !digest.Healthy(), Date: digest.LastDate(), Digest: digest})
+}
+
+// SendAlert sends an alert to the backends routed to failures.
+func (c *Composite) SendAlert(ctx context.Context, alert *Alert) error {
+	return c.fanOut(ctx, notification{Failure: true, Date: alert.Date, Alert: alert})
+}
+
+// Flush retries the queued notifications that are due.
+func (c *Composite) Flush(ctx context.Context) error {
+	if c.queue == nil {
+		return nil
+	}
+	backends := make(map[string]Notifier, len(c.routes))
+	for _, r := range c.routes {
+		backends[r.Name] = r.Notifier
+	}
+	return c.queue.Flush(ctx, backends)
+}
+
+// fanOut sends n to each route matching and joins their errors. Failed
+// sends are queued.
+func (c *Composite) fanOut(ctx context.Context, n notification) error {
+	errs := make([]error, len(c.routes))
+	var wg sync.WaitGroup
+	for i, r := range c.routes {
+		if (n.Failure && !r.Failure) || (!n.Failure && !r.Success) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := n.send(ctx, r.Notifier)
+			if err == nil {
+				return
+			}
+			if c.queue != nil {
+				c.queue.add(r.Name, n, err)
+				err = fmt.Errorf("%w (queued for retry)", err)
+			}
+			errs[i] = fmt.Errorf("%s: %w", r.Name, err)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,111 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// recorder records the notifications it is sent
+type recorder struct {
+	mu   sync.Mutex
+	sent []string
+	err  error
+}
+
+func (r *recorder) SendSuccess(_ context.Context, _ *download.BatchResult, date string, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, "success "+date)
+	return r.err
+}
+
+func (r *recorder) SendFailure(_ context.Context, _ *download.BatchResult, date string, _ time.Duration, _ error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, "failure "+date)
+	return r.err
+}
+
+func (r *recorder) SendDigest(_ context.Context, d *Digest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, "digest "+d.Period)
+	return r.err
+}
+
+func (r *recorder) SendAlert(_ context.Context, a *Alert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, "alert "+a.Title)
+	return r.err
+}
+
+func TestComposite(t *testing.T) {
+	ntfy, slack := &recorder{}, &recorder{err: errors.New("invalid_token")}
+	c := NewComposite(
+		Route{Name: BackendNtfy, Notifier: ntfy, Success: true},
+		Route{Name: BackendSlack, Notifier: slack, Failure: true},
+	)
+
+	ctx := context.Background()
+	if err := c.SendSuccess(ctx, nil, "2025-01-02", time.Second); err != nil {
+		t.Errorf("SendSuccess() error = %v", err)
+	}
+	err := c.SendFailure(ctx, nil, "2025-01-03", time.Second, errors.New("failed"))
+	if err == nil || !strings.Contains(err.Error(), "slack: invalid_token") {
+		t.Errorf("SendFailure() error = %v, want slack error", err)
+	}
+
+	// A digest with gaps goes to the failure routes
+	digest := &Digest{Period: DigestDaily, Schedules: []DigestSchedule{{Gaps: []string{"2025-01-03"}}}}
+	_ = c.SendDigest(ctx, digest)
+	digest.Schedules[0].Gaps = nil
+	_ = c.SendDigest(ctx, digest)
+
+	// Alerts go to the failure routes
+	_ = c.SendAlert(ctx, &Alert{Title: "Faker Load Failed"})
+
+	if got := strings.Join(ntfy.sent, ","); got != "success 2025-01-02,digest daily" {
+		t.Errorf("ntfy sent %q", got)
+	}
+	if got := strings.Join(slack.sent, ","); got != "failure 2025-01-03,digest daily,alert Faker Load Failed" {
+		t.Errorf("slack sent %q", got)
+	}
+}
+
+func TestConfigRouting(t *testing.T) {
+	cfg := &Config{
+		Backend:           "ntfy,discord",
+		Enabled:           true,
+		Topic:             "gexbot",
+		Priority:          "default",
+		DiscordEnabled:    true,
+		DiscordWebhookURL: "https://discord.com/api/webhooks/x",
+		SuccessBackend:    "ntfy",
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := strings.Join(cfg.SuccessBackends(), ","); got != "ntfy" {
+		t.Errorf("SuccessBackends() = %s", got)
+	}
+	if got := strings.Join(cfg.FailureBackends(), ","); got != "ntfy,discord" {
+		t.Errorf("FailureBackends() = %s, want all enabled", got)
+	}
+	if _, ok := New(cfg, zap.NewNop()).(*Composite); !ok {
+		t.Errorf("New() is not a Composite")
+	}
+
+	cfg.FailureBackend = "slack"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "slack is not enabled") {
+		t.Errorf("Validate() error = %v, want slack not enabled", err)
+	}
+}
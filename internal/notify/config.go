@@ -4,39 +4,190 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 )
 
-// Config holds ntfy notification configuration.
+// Notification backends, as named in NOTIFY_BACKEND
+const (
+	BackendNtfy    = "ntfy"
+	BackendSlack   = "slack"
+	BackendDiscord = "discord"
+	BackendWebhook = "webhook"
+)
+
+// Config holds ntfy, Slack, Discord and generic webhook notification
+// configuration. Any of them may be enabled.
 type Config struct {
-	Enabled  bool   // Whether notifications are enabled
+	// Backend is the comma-separated list of backends to notify (e.g.,
+	// "ntfy,discord"). When set it overrides NTFY_ENABLED and SLACK_ENABLED.
+	Backend string
+	// SuccessBackend and FailureBackend route success and failure
+	// notifications to some of the enabled backends (default: all).
+	SuccessBackend string
+	FailureBackend string
+	// QueueFile keeps notifications that failed to send for retries (default:
+	// none, failed sends are only logged)
+	QueueFile string
+
+	Enabled  bool   // Whether ntfy notifications are enabled
 	Server   string // ntfy server URL (default: https://ntfy.sh)
 	Topic    string // Topic name (required if enabled)
 	Priority string // Message priority: min, low, default, high, urgent
 	Tags     string // Comma-separated emoji tags (e.g., "package,rocket")
 	Token    string // Optional access token for private topics
+
+	SlackEnabled    bool   // Whether Slack notifications are enabled
+	SlackWebhookURL string // Slack incoming webhook URL (required if enabled)
+
+	DiscordEnabled    bool   // Whether Discord notifications are enabled
+	DiscordWebhookURL string // Discord webhook URL (required if enabled)
+
+	WebhookEnabled  bool   // Whether generic webhook notifications are enabled
+	WebhookURL      string // URL the rendered template is POSTed to (required if enabled)
+	WebhookTemplate string // Path to a Go text/template rendering the JSON body (default: built-in)
+	WebhookHeaders  string // Comma-separated "Name: value" request headers
 }
 
 // LoadConfig loads notification config from environment variables.
 func LoadConfig() *Config {
-	return &Config{
+	cfg := &Config{
+		Backend:        os.Getenv("NOTIFY_BACKEND"),
+		SuccessBackend: os.Getenv("NOTIFY_SUCCESS_BACKENDS"),
+		FailureBackend: os.Getenv("NOTIFY_FAILURE_BACKENDS"),
+		QueueFile:      os.Getenv("NOTIFY_QUEUE_FILE"),
+
 		Enabled:  getEnvBoolOrDefault("NTFY_ENABLED", false),
 		Server:   getEnvOrDefault("NTFY_SERVER", "https://ntfy.sh"),
 		Topic:    os.Getenv("NTFY_TOPIC"),
 		Priority: getEnvOrDefault("NTFY_PRIORITY", "default"),
 		Tags:     getEnvOrDefault("NTFY_TAGS", "package"),
 		Token:    os.Getenv("NTFY_TOKEN"),
+
+		SlackEnabled:    getEnvBoolOrDefault("SLACK_ENABLED", false),
+		SlackWebhookURL: os.Getenv("SLACK_WEBHOOK_URL"),
+
+		DiscordWebhookURL: os.Getenv("DISCORD_WEBHOOK_URL"),
+
+		WebhookURL:      os.Getenv("NOTIFY_WEBHOOK_URL"),
+		WebhookTemplate: os.Getenv("NOTIFY_WEBHOOK_TEMPLATE"),
+		WebhookHeaders:  os.Getenv("NOTIFY_WEBHOOK_HEADERS"),
+	}
+
+	if cfg.Backend != "" {
+		backends := cfg.Backends()
+		cfg.Enabled = slices.Contains(backends, BackendNtfy)
+		cfg.SlackEnabled = slices.Contains(backends, BackendSlack)
+		cfg.DiscordEnabled = slices.Contains(backends, BackendDiscord)
+		cfg.WebhookEnabled = slices.Contains(backends, BackendWebhook)
 	}
+	return cfg
+}
+
+// Backends returns the backends named in Backend.
+func (c *Config) Backends() []string {
+	return splitBackends(c.Backend)
+}
+
+// EnabledBackends returns the enabled backends, in a fixed order.
+func (c *Config) EnabledBackends() []string {
+	var backends []string
+	for _, b := range []struct {
+		name    string
+		enabled bool
+	}{
+		{BackendNtfy, c.Enabled},
+		{BackendSlack, c.SlackEnabled},
+		{BackendDiscord, c.DiscordEnabled},
+		{BackendWebhook, c.WebhookEnabled},
+	} {
+		if b.enabled {
+			backends = append(backends, b.name)
+		}
+	}
+	return backends
+}
+
+// SuccessBackends returns the backends success notifications go to.
+func (c *Config) SuccessBackends() []string {
+	if c.SuccessBackend == "" {
+		return c.EnabledBackends()
+	}
+	return splitBackends(c.SuccessBackend)
+}
+
+// FailureBackends returns the backends failure notifications go to.
+func (c *Config) FailureBackends() []string {
+	if c.FailureBackend == "" {
+		return c.EnabledBackends()
+	}
+	return splitBackends(c.FailureBackend)
+}
+
+func splitBackends(s string) []string {
+	var backends []string
+	for _, b := range strings.Split(s, ",") {
+		if b = strings.ToLower(strings.TrimSpace(b)); b != "" {
+			backends = append(backends, b)
+		}
+	}
+	return backends
+}
+
+func validBackend(b string) bool {
+	return b == BackendNtfy || b == BackendSlack || b == BackendDiscord || b == BackendWebhook
 }
 
 // Validate checks configuration is valid when enabled.
 func (c *Config) Validate() error {
+	for _, b := range c.Backends() {
+		if !validBackend(b) {
+			return fmt.Errorf("invalid NOTIFY_BACKEND: %s (valid: ntfy, slack, discord, webhook)", b)
+		}
+	}
+
+	enabled := c.EnabledBackends()
+	for setting, backends := range map[string]string{
+		"NOTIFY_SUCCESS_BACKENDS": c.SuccessBackend,
+		"NOTIFY_FAILURE_BACKENDS": c.FailureBackend,
+	} {
+		for _, b := range splitBackends(backends) {
+			if !validBackend(b) {
+				return fmt.Errorf("invalid %s: %s (valid: ntfy, slack, discord, webhook)", setting, b)
+			}
+			if !slices.Contains(enabled, b) {
+				return fmt.Errorf("invalid %s: %s is not enabled", setting, b)
+			}
+		}
+	}
+
+	if c.SlackEnabled && c.SlackWebhookURL == "" {
+		return errors.New("SLACK_WEBHOOK_URL is required when Slack notifications are enabled")
+	}
+
+	if c.DiscordEnabled && c.DiscordWebhookURL == "" {
+		return errors.New("DISCORD_WEBHOOK_URL is required when Discord notifications are enabled")
+	}
+
+	if c.WebhookEnabled {
+		if c.WebhookURL == "" {
+			return errors.New("NOTIFY_WEBHOOK_URL is required when webhook notifications are enabled")
+		}
+		if _, err := parseWebhookHeaders(c.WebhookHeaders); err != nil {
+			return err
+		}
+		if _, err := loadWebhookTemplate(c.WebhookTemplate); err != nil {
+			return err
+		}
+	}
+
 	if !c.Enabled {
 		return nil
 	}
 
 	if c.Topic == "" {
-		return errors.New("NTFY_TOPIC is required when NTFY_ENABLED=true")
+		return errors.New("NTFY_TOPIC is required when ntfy notifications are enabled")
 	}
 
 	validPriorities := map[string]bool{
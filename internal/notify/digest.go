@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Digest periods
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// Digest summarizes the downloads of a period, sent instead of a
+// notification per run.
+type Digest struct {
+	Period    string           `json:"period"` // daily or weekly
+	From      time.Time        `json:"from"`
+	To        time.Time        `json:"to"`
+	Schedules []DigestSchedule `json:"schedules"`
+}
+
+// DigestSchedule summarizes the downloads of one schedule.
+type DigestSchedule struct {
+	Name        string   `json:"name,omitempty"` // empty for the default schedule
+	Downloaded  []string `json:"downloaded"`     // dates downloaded in full
+	Failed      []string `json:"failed"`         // dates whose last run failed
+	Gaps        []string `json:"gaps"`           // market days that were never downloaded
+	Bytes       int64    `json:"bytes"`
+	FailedFiles int      `json:"failed_files"`
+}
+
+// Healthy reports whether every date of the period was downloaded.
+func (d *Digest) Healthy() bool {
+	for _, s := range d.Schedules {
+		if len(s.Failed) > 0 || len(s.Gaps) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// LastDate returns the last date of the period.
+func (d *Digest) LastDate() string {
+	return d.To.Add(-time.Second).Format("2006-01-02")
+}
+
+// Title returns the notification title of the digest.
+func (d *Digest) Title() string {
+	last := d.LastDate()
+	if d.Period == DigestWeekly {
+		return fmt.Sprintf("Weekly Digest: %s to %s", d.From.Format("2006-01-02"), last)
+	}
+	return fmt.Sprintf("Daily Digest: %s", last)
+}
+
+// FormatDigestMessage creates a digest notification body.
+func FormatDigestMessage(d *Digest) string {
+	var sb strings.Builder
+	for i, s := range d.Schedules {
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		if s.Name != "" {
+			sb.WriteString(s.Name + ":\n")
+		}
+		sb.WriteString(fmt.Sprintf("Downloaded: %d days%s\n", len(s.Downloaded), dateList(s.Downloaded)))
+		sb.WriteString(fmt.Sprintf("Data: %.1f MB\n", float64(s.Bytes)/(1<<20)))
+		sb.WriteString(fmt.Sprintf("Failed: %d days, %d files%s\n", len(s.Failed), s.FailedFiles, dateList(s.Failed)))
+		sb.WriteString(fmt.Sprintf("Gaps: %d days%s", len(s.Gaps), dateList(s.Gaps)))
+	}
+	if len(d.Schedules) == 0 {
+		sb.WriteString("No schedules")
+	}
+	return sb.String()
+}
+
+func dateList(dates []string) string {
+	if len(dates) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(dates, ", ") + ")"
+}
@@ -0,0 +1,297 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// Discord embed colors and limits
+const (
+	discordColorSuccess = 0x2ecc71
+	discordColorFailure = 0xe74c3c
+	discordColorWarning = 0xf1c40f
+
+	discordMaxDescription = 4096
+	discordMaxFieldValue  = 1024
+	discordMaxFields      = 25
+	discordMaxErrors      = 3
+
+	// discordMaxAttempts is how often a rate limited message is sent
+	discordMaxAttempts = 3
+	// discordMaxWait bounds a rate limit wait, so a notification never
+	// holds up the daemon for long
+	discordMaxWait = time.Minute
+)
+
+// DiscordClient sends notifications as embeds to a Discord webhook. It
+// honours the webhook's rate limits: a message is held while the current
+// bucket is exhausted, and one answered with 429 is sent again after the
+// wait Discord asks for.
+type DiscordClient struct {
+	httpClient *http.Client
+	webhookURL string
+	logger     *zap.Logger
+
+	mu      sync.Mutex
+	resetAt time.Time // when the exhausted rate limit bucket refills
+}
+
+// NewDiscordClient creates a new Discord webhook client.
+func NewDiscordClient(cfg *Config, logger *zap.Logger) *DiscordClient {
+	return &DiscordClient{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		webhookURL: cfg.DiscordWebhookURL,
+		logger:     logger,
+	}
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields,omitempty"`
+	Footer      *discordFooter `json:"footer,omitempty"`
+	Timestamp   string         `json:"timestamp"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+// SendSuccess sends a success notification.
+func (c *DiscordClient) SendSuccess(ctx context.Context, result *download.BatchResult, date string, duration time.Duration) error {
+	result = orEmpty(result)
+	embed := discordEmbed{
+		Title: fmt.Sprintf("Download Complete: %s", date),
+		Color: discordColorSuccess,
+		Fields: []discordField{
+			{Name: "Total", Value: fmt.Sprintf("%d files", result.Total), Inline: true},
+			{Name: "Success", Value: strconv.Itoa(result.Success), Inline: true},
+			{Name: "Skipped", Value: strconv.Itoa(result.Skipped), Inline: true},
+			{Name: "Not Found", Value: strconv.Itoa(result.NotFound), Inline: true},
+			{Name: "Duration", Value: duration.Round(time.Second).String(), Inline: true},
+		},
+		Footer:    throughputFooter(result),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	return c.send(ctx, discordMessage{Embeds: []discordEmbed{embed}})
+}
+
+// SendFailure sends a failure notification with the failed files per ticker.
+func (c *DiscordClient) SendFailure(ctx context.Context, result *download.BatchResult, date string, duration time.Duration, err error) error {
+	result = orEmpty(result)
+	embed := discordEmbed{
+		Title: fmt.Sprintf("Download Failed: %s", date),
+		Color: discordColorFailure,
+		Fields: []discordField{
+			{Name: "Total", Value: fmt.Sprintf("%d files", result.Total), Inline: true},
+			{Name: "Success", Value: strconv.Itoa(result.Success), Inline: true},
+			{Name: "Failed", Value: strconv.Itoa(result.Failed), Inline: true},
+			{Name: "Skipped", Value: strconv.Itoa(result.Skipped), Inline: true},
+			{Name: "Duration", Value: duration.Round(time.Second).String(), Inline: true},
+		},
+		Footer:    throughputFooter(result),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+	if err != nil {
+		embed.Description = truncate(err.Error(), discordMaxFieldValue)
+	}
+
+	for _, f := range FailuresByTicker(result) {
+		if len(embed.Fields) == discordMaxFields-1 {
+			break // leave room for the errors
+		}
+		embed.Fields = append(embed.Fields, discordField{
+			Name:  fmt.Sprintf("%s: %d failed", f.Ticker, len(f.Categories)),
+			Value: truncate(strings.Join(f.Categories, ", "), discordMaxFieldValue),
+		})
+	}
+
+	if len(result.Errors) > 0 {
+		var sb strings.Builder
+		for i, e := range result.Errors {
+			if i == discordMaxErrors {
+				sb.WriteString(fmt.Sprintf("... and %d more errors", len(result.Errors)-discordMaxErrors))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- %s\n", e))
+		}
+		embed.Fields = append(embed.Fields, discordField{Name: "Errors", Value: truncate(sb.String(), discordMaxFieldValue)})
+	}
+
+	return c.send(ctx, discordMessage{Embeds: []discordEmbed{embed}})
+}
+
+// SendDigest sends a digest notification.
+func (c *DiscordClient) SendDigest(ctx context.Context, digest *Digest) error {
+	embed := discordEmbed{
+		Title:       digest.Title(),
+		Description: truncate(FormatDigestMessage(digest), discordMaxDescription),
+		Color:       discordColorSuccess,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+	}
+	if !digest.Healthy() {
+		embed.Color = discordColorWarning
+	}
+
+	return c.send(ctx, discordMessage{Embeds: []discordEmbed{embed}})
+}
+
+// SendAlert sends an alert notification.
+func (c *DiscordClient) SendAlert(ctx context.Context, alert *Alert) error {
+	embed := discordEmbed{
+		Title:       alert.Title,
+		Description: truncate(alert.Message, discordMaxDescription),
+		Color:       discordColorFailure,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	return c.send(ctx, discordMessage{Embeds: []discordEmbed{embed}})
+}
+
+func (c *DiscordClient) send(ctx context.Context, msg discordMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encoding message: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := c.waitRateLimit(ctx); err != nil {
+			return err
+		}
+
+		retryAfter, err := c.post(ctx, body)
+		if err == nil || retryAfter == 0 {
+			return err
+		}
+		if attempt == discordMaxAttempts {
+			c.logger.Warn("Discord notification rate limited", zap.Int("attempts", attempt))
+			return err
+		}
+		c.logger.Debug("Discord notification rate limited, waiting", zap.Duration("retryAfter", retryAfter))
+		c.limitUntil(time.Now().Add(retryAfter))
+	}
+}
+
+// post sends one message. A rate limited message returns the wait Discord
+// asks for along with the error.
+func (c *DiscordClient) post(ctx context.Context, body []byte) (time.Duration, error) {
+	reply, err := postJSON(ctx, c.httpClient, c.webhookURL, nil, bytes.NewReader(body))
+	if err != nil {
+		c.logger.Warn("failed to send Discord notification", zap.Error(err))
+		return 0, fmt.Errorf("sending Discord notification: %w", err)
+	}
+
+	// Hold the next message while the bucket is exhausted
+	if reply.header.Get("X-RateLimit-Remaining") == "0" {
+		if wait, ok := parseSeconds(reply.header.Get("X-RateLimit-Reset-After")); ok {
+			c.limitUntil(time.Now().Add(wait))
+		}
+	}
+
+	if reply.status == http.StatusTooManyRequests {
+		var limited struct {
+			RetryAfter float64 `json:"retry_after"` // seconds
+		}
+		wait := time.Second
+		if json.Unmarshal(reply.body, &limited) == nil && limited.RetryAfter > 0 {
+			wait = time.Duration(limited.RetryAfter * float64(time.Second))
+		} else if w, ok := parseSeconds(reply.header.Get("Retry-After")); ok {
+			wait = w
+		}
+		return wait, fmt.Errorf("discord webhook rate limited for %s", wait)
+	}
+
+	if !reply.ok() {
+		c.logger.Warn("Discord notification failed",
+			zap.Int("status", reply.status),
+			zap.String("reply", string(reply.body)),
+		)
+		return 0, fmt.Errorf("discord webhook failed with status %d: %s", reply.status, strings.TrimSpace(string(reply.body)))
+	}
+
+	c.logger.Debug("Discord notification sent")
+	return 0, nil
+}
+
+// limitUntil holds messages until t, at most discordMaxWait from now
+func (c *DiscordClient) limitUntil(t time.Time) {
+	if limit := time.Now().Add(discordMaxWait); t.After(limit) {
+		t = limit
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.After(c.resetAt) {
+		c.resetAt = t
+	}
+}
+
+// waitRateLimit waits until the rate limit bucket refills or ctx is done
+func (c *DiscordClient) waitRateLimit(ctx context.Context) error {
+	c.mu.Lock()
+	wait := time.Until(c.resetAt)
+	c.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// throughputFooter returns the transfer volume and rate when any file was
+// downloaded.
+func throughputFooter(result *download.BatchResult) *discordFooter {
+	text, ok := formatThroughput(result)
+	if !ok {
+		return nil
+	}
+	return &discordFooter{Text: text}
+}
+
+// parseSeconds parses a decimal number of seconds, as in rate limit headers
+func parseSeconds(s string) (time.Duration, bool) {
+	secs, err := strconv.ParseFloat(s, 64)
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs * float64(time.Second)), true
+}
+
+// truncate shortens s to at most n bytes, marking the cut
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n-3], "") + "..."
+}
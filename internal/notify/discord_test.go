@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestDiscordClientRateLimit(t *testing.T) {
+	var requests int
+	var msg discordMessage
+	srv := captureServer(t, &msg, http.StatusNoContent, func(w http.ResponseWriter, r *http.Request) bool {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = io.WriteString(w, `{"message": "You are being rate limited.", "retry_after": 0.05, "global": false}`)
+			return true
+		}
+		return false
+	})
+
+	client := NewDiscordClient(&Config{DiscordEnabled: true, DiscordWebhookURL: srv.URL}, zap.NewNop())
+	result := failedBatch()
+	start := time.Now()
+	if err := client.SendFailure(context.Background(), result, "2025-01-02", time.Minute, errors.New("1 downloads failed")); err != nil {
+		t.Fatalf("SendFailure() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("sent %d requests, want 2", requests)
+	}
+	if waited := time.Since(start); waited < 50*time.Millisecond {
+		t.Errorf("retried after %s, want retry_after", waited)
+	}
+
+	if len(msg.Embeds) != 1 {
+		t.Fatalf("embeds = %d, want 1", len(msg.Embeds))
+	}
+	embed := msg.Embeds[0]
+	if embed.Title != "Download Failed: 2025-01-02" || embed.Color != discordColorFailure || embed.Description != "1 downloads failed" {
+		t.Errorf("embed = %+v", embed)
+	}
+	var found bool
+	for _, f := range embed.Fields {
+		if f.Name == "SPX: 1 failed" && f.Value == "classic/gex_one" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("fields missing SPX failures: %+v", embed.Fields)
+	}
+}
+
+func TestConfigBackend(t *testing.T) {
+	t.Setenv("NOTIFY_BACKEND", "discord, Slack")
+	t.Setenv("NTFY_ENABLED", "true")
+	t.Setenv("SLACK_WEBHOOK_URL", "https://hooks.slack.com/services/x")
+	t.Setenv("DISCORD_WEBHOOK_URL", "https://discord.com/api/webhooks/x")
+
+	cfg := LoadConfig()
+	if cfg.Enabled || !cfg.SlackEnabled || !cfg.DiscordEnabled {
+		t.Errorf("enabled ntfy=%v slack=%v discord=%v, want slack and discord", cfg.Enabled, cfg.SlackEnabled, cfg.DiscordEnabled)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.Backend = "ntfy,email"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "email") {
+		t.Errorf("Validate() error = %v, want invalid backend", err)
+	}
+}
@@ -2,14 +2,25 @@ package notify
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/dgnsrekt/gexbot-downloader/internal/download"
 )
 
+// orEmpty returns result, or an empty batch for runs that failed before
+// downloading.
+func orEmpty(result *download.BatchResult) *download.BatchResult {
+	if result == nil {
+		return &download.BatchResult{}
+	}
+	return result
+}
+
 // FormatSuccessMessage creates a success notification body.
 func FormatSuccessMessage(result *download.BatchResult, duration time.Duration) string {
+	result = orEmpty(result)
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("Total: %d files\n", result.Total))
@@ -17,12 +28,14 @@ func FormatSuccessMessage(result *download.BatchResult, duration time.Duration)
 	sb.WriteString(fmt.Sprintf("Skipped: %d\n", result.Skipped))
 	sb.WriteString(fmt.Sprintf("Not Found: %d\n", result.NotFound))
 	sb.WriteString(fmt.Sprintf("Duration: %s", duration.Round(time.Second)))
+	writeThroughput(&sb, result)
 
 	return sb.String()
 }
 
 // FormatFailureMessage creates a failure notification body.
 func FormatFailureMessage(result *download.BatchResult, duration time.Duration, err error) string {
+	result = orEmpty(result)
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("Total: %d files\n", result.Total))
@@ -30,6 +43,7 @@ func FormatFailureMessage(result *download.BatchResult, duration time.Duration,
 	sb.WriteString(fmt.Sprintf("Failed: %d\n", result.Failed))
 	sb.WriteString(fmt.Sprintf("Skipped: %d\n", result.Skipped))
 	sb.WriteString(fmt.Sprintf("Duration: %s", duration.Round(time.Second)))
+	writeThroughput(&sb, result)
 
 	if err != nil {
 		sb.WriteString(fmt.Sprintf("\n\nError: %v", err))
@@ -52,3 +66,49 @@ func FormatFailureMessage(result *download.BatchResult, duration time.Duration,
 
 	return sb.String()
 }
+
+// writeThroughput appends transfer volume and rate lines when any file was
+// downloaded.
+func writeThroughput(sb *strings.Builder, result *download.BatchResult) {
+	tp := result.Throughput()
+	if tp.Downloads == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("\nDownloaded: %.1f MB\n", float64(tp.Bytes)/(1<<20)))
+	sb.WriteString(fmt.Sprintf("Throughput: %.2f MB/s median, %.2f MB/s p10\n", tp.P50MBPerSec, tp.P10MBPerSec))
+	sb.WriteString(fmt.Sprintf("Slowest file: %s", tp.MaxDuration.Round(time.Millisecond)))
+}
+
+// formatThroughput returns the transfer volume and rate on one line, for
+// chat footers, when any file was downloaded.
+func formatThroughput(result *download.BatchResult) (string, bool) {
+	tp := result.Throughput()
+	if tp.Downloads == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("Downloaded %.1f MB · %.2f MB/s median, %.2f MB/s p10 · slowest file %s",
+		float64(tp.Bytes)/(1<<20), tp.P50MBPerSec, tp.P10MBPerSec, tp.MaxDuration.Round(time.Millisecond)), true
+}
+
+// TickerFailures lists the files of a ticker that failed to download.
+type TickerFailures struct {
+	Ticker     string   `json:"ticker"`
+	Categories []string `json:"categories"` // package/category
+}
+
+// FailuresByTicker groups the failed files of a batch by ticker, in ticker
+// order.
+func FailuresByTicker(result *download.BatchResult) []TickerFailures {
+	byTicker := make(map[string][]string)
+	for _, task := range orEmpty(result).FailedTasks() {
+		byTicker[task.Ticker] = append(byTicker[task.Ticker], task.Package+"/"+task.Category)
+	}
+
+	failures := make([]TickerFailures, 0, len(byTicker))
+	for ticker, categories := range byTicker {
+		sort.Strings(categories)
+		failures = append(failures, TickerFailures{Ticker: ticker, Categories: categories})
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Ticker < failures[j].Ticker })
+	return failures
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,6 +18,8 @@ import (
 type Notifier interface {
 	SendSuccess(ctx context.Context, result *download.BatchResult, date string, duration time.Duration) error
 	SendFailure(ctx context.Context, result *download.BatchResult, date string, duration time.Duration, err error) error
+	SendDigest(ctx context.Context, digest *Digest) error
+	SendAlert(ctx context.Context, alert *Alert) error
 }
 
 // Client implements the ntfy notification client.
@@ -64,6 +67,29 @@ func (c *Client) SendFailure(ctx context.Context, result *download.BatchResult,
 	return c.send(ctx, title, message, tags, priority)
 }
 
+// SendDigest sends a digest notification.
+func (c *Client) SendDigest(ctx context.Context, digest *Digest) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	tags := c.config.Tags + ",bar_chart"
+	if !digest.Healthy() {
+		tags = c.config.Tags + ",warning"
+	}
+
+	return c.send(ctx, digest.Title(), FormatDigestMessage(digest), tags, c.config.Priority)
+}
+
+// SendAlert sends an alert notification.
+func (c *Client) SendAlert(ctx context.Context, alert *Alert) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	return c.send(ctx, alert.Title, alert.Message, c.config.Tags+",warning", "high")
+}
+
 func (c *Client) send(ctx context.Context, title, message, tags, priority string) error {
 	url := fmt.Sprintf("%s/%s", strings.TrimSuffix(c.config.Server, "/"), c.config.Topic)
 
@@ -115,10 +141,49 @@ func (n *NoopNotifier) SendFailure(_ context.Context, _ *download.BatchResult, _
 	return nil
 }
 
-// New creates the appropriate notifier based on config.
+// SendDigest is a no-op.
+func (n *NoopNotifier) SendDigest(_ context.Context, _ *Digest) error {
+	return nil
+}
+
+// SendAlert is a no-op.
+func (n *NoopNotifier) SendAlert(_ context.Context, _ *Alert) error {
+	return nil
+}
+
+// New creates the appropriate notifier based on config: a Composite of
+// each enabled backend, routed by NOTIFY_SUCCESS_BACKENDS and
+// NOTIFY_FAILURE_BACKENDS and queuing failed sends in NOTIFY_QUEUE_FILE, or
+// a no-op when none is enabled.
 func New(cfg *Config, logger *zap.Logger) Notifier {
-	if !cfg.Enabled {
+	clients := map[string]func() Notifier{
+		BackendNtfy:    func() Notifier { return NewClient(cfg, logger) },
+		BackendSlack:   func() Notifier { return NewSlackClient(cfg, logger) },
+		BackendDiscord: func() Notifier { return NewDiscordClient(cfg, logger) },
+		BackendWebhook: func() Notifier { return NewWebhookClient(cfg, logger) },
+	}
+
+	success, failure := cfg.SuccessBackends(), cfg.FailureBackends()
+	var routes []Route
+	for _, name := range cfg.EnabledBackends() {
+		routes = append(routes, Route{
+			Name:     name,
+			Notifier: clients[name](),
+			Success:  slices.Contains(success, name),
+			Failure:  slices.Contains(failure, name),
+		})
+	}
+
+	if len(routes) == 0 {
 		return &NoopNotifier{}
 	}
-	return NewClient(cfg, logger)
+	c := NewComposite(routes...)
+	if cfg.QueueFile != "" {
+		q, err := OpenQueue(cfg.QueueFile, logger)
+		if err != nil {
+			logger.Warn("notification queue unreadable, starting empty", zap.Error(err))
+		}
+		c.WithQueue(q)
+	}
+	return c
 }
@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// failedBatch returns a two-task batch whose SPX classic/gex_one download failed.
+func failedBatch() *download.BatchResult {
+	return &download.BatchResult{Total: 2, Failed: 1, Success: 1,
+		Errors: []string{"SPX/classic/gex_one: status 500"},
+		Tasks:  []download.TaskStatus{{Task: "2025-01-02/SPX/classic/gex_one", Status: download.StatusFailed}},
+	}
+}
+
+// captureServer starts a webhook endpoint that decodes each request body into
+// v and replies with status. intercept, when non-nil, sees every request first
+// and answers it instead by returning true.
+func captureServer(t *testing.T, v any, status int, intercept func(w http.ResponseWriter, r *http.Request) bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if intercept != nil && intercept(w, r) {
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, v); err != nil {
+			t.Errorf("payload is not JSON: %v\n%s", err, body)
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
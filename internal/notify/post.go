@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxReplySize is how much of a webhook's reply is read, enough for the
+// error it explains a rejected message with.
+const maxReplySize = 4096
+
+// webhookReply is a webhook's answer to a posted message.
+type webhookReply struct {
+	status int
+	header http.Header
+	body   []byte // up to maxReplySize
+}
+
+// ok reports whether the webhook accepted the message.
+func (r *webhookReply) ok() bool {
+	return r.status >= 200 && r.status < 300
+}
+
+// postJSON posts a JSON message to a webhook with the extra headers. Only
+// failures to deliver it are errors; the caller judges the reply.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body io.Reader) (*webhookReply, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	reply, _ := io.ReadAll(io.LimitReader(resp.Body, maxReplySize))
+	return &webhookReply{status: resp.StatusCode, header: resp.Header, body: reply}, nil
+}
@@ -0,0 +1,232 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// Notification retry backoff and limits
+const (
+	queueBaseDelay   = time.Minute // before the first retry, doubled after each
+	queueMaxDelay    = time.Hour
+	queueMaxAttempts = 10 // retries over about five hours
+	queueMaxEntries  = 50 // the oldest are dropped beyond this
+)
+
+// Flusher is a Notifier that retries notifications that failed to send.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// notification is one notification to send.
+type notification struct {
+	Failure  bool                  `json:"failure"`
+	Result   *download.BatchResult `json:"result,omitempty"`
+	Date     string                `json:"date"`
+	Duration time.Duration         `json:"duration_ns"`
+	Error    string                `json:"error,omitempty"`
+	Digest   *Digest               `json:"digest,omitempty"` // digest notifications only
+	Alert    *Alert                `json:"alert,omitempty"`  // alert notifications only
+}
+
+func (n notification) send(ctx context.Context, to Notifier) error {
+	if n.Digest != nil {
+		return to.SendDigest(ctx, n.Digest)
+	}
+	if n.Alert != nil {
+		return to.SendAlert(ctx, n.Alert)
+	}
+	if !n.Failure {
+		return to.SendSuccess(ctx, n.Result, n.Date, n.Duration)
+	}
+	var err error
+	if n.Error != "" {
+		err = errors.New(n.Error)
+	}
+	return to.SendFailure(ctx, n.Result, n.Date, n.Duration, err)
+}
+
+// queuedNotification is a notification a backend failed to send.
+type queuedNotification struct {
+	Backend      string       `json:"backend"`
+	Notification notification `json:"notification"`
+	Queued       time.Time    `json:"queued"`
+	Attempts     int          `json:"attempts"` // retries so far
+	Next         time.Time    `json:"next"`
+	LastError    string       `json:"last_error"`
+}
+
+// Queue keeps the notifications that failed to send in a JSON file and
+// retries them with exponential backoff, so a transient outage of a
+// backend does not swallow an alert.
+type Queue struct {
+	mu      sync.Mutex
+	path    string
+	entries []*queuedNotification
+	logger  *zap.Logger
+}
+
+// OpenQueue loads the queue at path. A queue that cannot be read is
+// returned empty along with the error, so notifications still queue.
+func OpenQueue(path string, logger *zap.Logger) (*Queue, error) {
+	q := &Queue{path: path, logger: logger}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return q, fmt.Errorf("reading notification queue: %w", err)
+	}
+	if err := json.Unmarshal(data, &q.entries); err != nil {
+		return q, fmt.Errorf("parsing notification queue %s: %w", path, err)
+	}
+	return q, nil
+}
+
+// Len returns the number of queued notifications.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+// add queues a notification backend failed to send.
+func (q *Queue) add(backend string, n notification, sendErr error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	q.entries = append(q.entries, &queuedNotification{
+		Backend:      backend,
+		Notification: n,
+		Queued:       now,
+		Next:         now.Add(queueBaseDelay),
+		LastError:    sendErr.Error(),
+	})
+	if drop := len(q.entries) - queueMaxEntries; drop > 0 {
+		q.logger.Warn("notification queue full, dropping oldest", zap.Int("dropped", drop))
+		q.entries = q.entries[drop:]
+	}
+	q.saveLocked()
+}
+
+// Flush retries the queued notifications that are due with the backends
+// they failed on. Those that fail again wait twice as long, up to
+// queueMaxDelay, and are dropped after queueMaxAttempts retries or when
+// their backend is no longer configured.
+func (q *Queue) Flush(ctx context.Context, backends map[string]Notifier) error {
+	q.mu.Lock()
+	now := time.Now()
+	var due []*queuedNotification
+	for _, e := range q.entries {
+		if !now.Before(e.Next) {
+			due = append(due, e)
+		}
+	}
+	q.mu.Unlock()
+	if len(due) == 0 {
+		return nil
+	}
+
+	done := make(map[*queuedNotification]bool)
+	var errs []error
+	for _, e := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		to, ok := backends[e.Backend]
+		if !ok {
+			q.logger.Warn("dropping queued notification, backend not configured",
+				zap.String("backend", e.Backend), zap.String("date", e.Notification.Date))
+			done[e] = true
+			continue
+		}
+
+		err := e.Notification.send(ctx, to)
+		if err == nil {
+			q.logger.Info("queued notification sent",
+				zap.String("backend", e.Backend), zap.String("date", e.Notification.Date), zap.Int("attempts", e.Attempts+1))
+			done[e] = true
+			continue
+		}
+
+		q.mu.Lock()
+		e.Attempts++
+		e.LastError = err.Error()
+		e.Next = time.Now().Add(backoff(e.Attempts))
+		q.mu.Unlock()
+		if e.Attempts >= queueMaxAttempts {
+			q.logger.Error("dropping notification after retries",
+				zap.String("backend", e.Backend), zap.String("date", e.Notification.Date), zap.Int("attempts", e.Attempts), zap.Error(err))
+			done[e] = true
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", e.Backend, err))
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	kept := q.entries[:0]
+	for _, e := range q.entries {
+		if !done[e] {
+			kept = append(kept, e)
+		}
+	}
+	clear(q.entries[len(kept):])
+	q.entries = kept
+	q.saveLocked()
+	return errors.Join(errs...)
+}
+
+// backoff returns the wait after a notification failed attempts retries.
+func backoff(attempts int) time.Duration {
+	d := queueBaseDelay
+	for i := 0; i < attempts && d < queueMaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, queueMaxDelay)
+}
+
+// saveLocked writes the queue atomically, removing the file once empty;
+// q.mu must be held. A queue that cannot be saved stays in memory.
+func (q *Queue) saveLocked() {
+	if err := q.write(); err != nil {
+		q.logger.Warn("failed to save notification queue", zap.String("path", q.path), zap.Error(err))
+	}
+}
+
+func (q *Queue) write() error {
+	if len(q.entries) == 0 {
+		if err := os.Remove(q.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(q.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(q.path), 0750); err != nil {
+		return err
+	}
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, q.path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+func TestQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify-queue.json")
+	q, err := OpenQueue(path, zap.NewNop())
+	if err != nil {
+		t.Fatalf("OpenQueue() error = %v", err)
+	}
+	ntfy := &recorder{err: errors.New("503 Service Unavailable")}
+	c := NewComposite(Route{Name: BackendNtfy, Notifier: ntfy, Failure: true}).WithQueue(q)
+
+	ctx := context.Background()
+	result := &download.BatchResult{Total: 1, Failed: 1}
+	if err := c.SendFailure(ctx, result, "2025-01-02", time.Second, errors.New("1 downloads failed")); err == nil || !strings.Contains(err.Error(), "queued") {
+		t.Fatalf("SendFailure() error = %v, want queued", err)
+	}
+
+	// The queue survives a restart
+	q, err = OpenQueue(path, zap.NewNop())
+	if err != nil || q.Len() != 1 {
+		t.Fatalf("reopened queue has %d entries, error = %v", q.Len(), err)
+	}
+	c = NewComposite(Route{Name: BackendNtfy, Notifier: ntfy, Failure: true}).WithQueue(q)
+
+	// Not due yet
+	if err := c.Flush(ctx); err != nil || len(ntfy.sent) != 1 {
+		t.Fatalf("Flush() sent %d, error = %v, want nothing before the backoff", len(ntfy.sent), err)
+	}
+
+	q.entries[0].Next = time.Now()
+	if err := c.Flush(ctx); err == nil {
+		t.Fatalf("Flush() error = nil, want the backend error")
+	}
+	if e := q.entries[0]; e.Attempts != 1 || time.Until(e.Next) < time.Minute {
+		t.Fatalf("after a failed retry attempts = %d, next in %s", e.Attempts, time.Until(e.Next))
+	}
+
+	q.entries[0].Next = time.Now()
+	ntfy.err = nil
+	if err := c.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if q.Len() != 0 {
+		t.Errorf("queue has %d entries after a successful retry", q.Len())
+	}
+	if got := strings.Join(ntfy.sent, ","); got != "failure 2025-01-02,failure 2025-01-02,failure 2025-01-02" {
+		t.Errorf("sent %q", got)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("empty queue file not removed: %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{0: time.Minute, 1: 2 * time.Minute, 5: 32 * time.Minute, 6: time.Hour, 100: time.Hour} {
+		if got := backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// maxSlackErrors is how many error messages a Slack failure message lists.
+const maxSlackErrors = 3
+
+// SlackClient sends notifications to a Slack incoming webhook.
+type SlackClient struct {
+	httpClient *http.Client
+	webhookURL string
+	logger     *zap.Logger
+}
+
+// NewSlackClient creates a new Slack webhook client.
+func NewSlackClient(cfg *Config, logger *zap.Logger) *SlackClient {
+	return &SlackClient{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		webhookURL: cfg.SlackWebhookURL,
+		logger:     logger,
+	}
+}
+
+// slackMessage is a webhook payload. Text is the fallback shown in
+// notifications where blocks are not rendered.
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SendSuccess sends a success notification.
+func (c *SlackClient) SendSuccess(ctx context.Context, result *download.BatchResult, date string, duration time.Duration) error {
+	result = orEmpty(result)
+	title := fmt.Sprintf(":white_check_mark: Download Complete: %s", date)
+	blocks := []slackBlock{
+		headerBlock(title),
+		fieldsBlock(
+			fmt.Sprintf("*Total:* %d files", result.Total),
+			fmt.Sprintf("*Success:* %d", result.Success),
+			fmt.Sprintf("*Skipped:* %d", result.Skipped),
+			fmt.Sprintf("*Not Found:* %d", result.NotFound),
+			fmt.Sprintf("*Duration:* %s", duration.Round(time.Second)),
+		),
+	}
+	if b, ok := throughputBlock(result); ok {
+		blocks = append(blocks, b)
+	}
+
+	return c.send(ctx, slackMessage{Text: title, Blocks: blocks})
+}
+
+// SendFailure sends a failure notification with the failed files per ticker.
+func (c *SlackClient) SendFailure(ctx context.Context, result *download.BatchResult, date string, duration time.Duration, err error) error {
+	result = orEmpty(result)
+	title := fmt.Sprintf(":x: Download Failed: %s", date)
+	blocks := []slackBlock{
+		headerBlock(title),
+		fieldsBlock(
+			fmt.Sprintf("*Total:* %d files", result.Total),
+			fmt.Sprintf("*Success:* %d", result.Success),
+			fmt.Sprintf("*Failed:* %d", result.Failed),
+			fmt.Sprintf("*Skipped:* %d", result.Skipped),
+			fmt.Sprintf("*Duration:* %s", duration.Round(time.Second)),
+		),
+	}
+	if b, ok := throughputBlock(result); ok {
+		blocks = append(blocks, b)
+	}
+	if err != nil {
+		blocks = append(blocks, sectionBlock(fmt.Sprintf("*Error:* %v", err)))
+	}
+
+	if failures := FailuresByTicker(result); len(failures) > 0 {
+		var sb strings.Builder
+		sb.WriteString("*Failures by ticker*")
+		for _, f := range failures {
+			sb.WriteString(fmt.Sprintf("\n• *%s*: %d (%s)", f.Ticker, len(f.Categories), strings.Join(f.Categories, ", ")))
+		}
+		blocks = append(blocks, sectionBlock(sb.String()))
+	}
+
+	if len(result.Errors) > 0 {
+		var sb strings.Builder
+		sb.WriteString("*Errors*")
+		for i, e := range result.Errors {
+			if i == maxSlackErrors {
+				sb.WriteString(fmt.Sprintf("\n… and %d more errors", len(result.Errors)-maxSlackErrors))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("\n• `%s`", e))
+		}
+		blocks = append(blocks, sectionBlock(sb.String()))
+	}
+
+	return c.send(ctx, slackMessage{Text: title, Blocks: blocks})
+}
+
+// SendDigest sends a digest notification.
+func (c *SlackClient) SendDigest(ctx context.Context, digest *Digest) error {
+	title := ":bar_chart: " + digest.Title()
+	if !digest.Healthy() {
+		title = ":warning: " + digest.Title()
+	}
+	blocks := []slackBlock{
+		headerBlock(title),
+		sectionBlock(FormatDigestMessage(digest)),
+	}
+
+	return c.send(ctx, slackMessage{Text: title, Blocks: blocks})
+}
+
+// SendAlert sends an alert notification.
+func (c *SlackClient) SendAlert(ctx context.Context, alert *Alert) error {
+	title := ":warning: " + alert.Title
+	blocks := []slackBlock{
+		headerBlock(title),
+		sectionBlock(alert.Message),
+	}
+
+	return c.send(ctx, slackMessage{Text: title, Blocks: blocks})
+}
+
+func (c *SlackClient) send(ctx context.Context, msg slackMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encoding message: %w", err)
+	}
+
+	reply, err := postJSON(ctx, c.httpClient, c.webhookURL, nil, bytes.NewReader(body))
+	if err != nil {
+		c.logger.Warn("failed to send Slack notification", zap.Error(err))
+		return fmt.Errorf("sending Slack notification: %w", err)
+	}
+
+	// Slack explains rejected payloads in a short plain-text body
+	if !reply.ok() {
+		c.logger.Warn("Slack notification failed",
+			zap.Int("status", reply.status),
+			zap.String("reply", string(reply.body)),
+		)
+		return fmt.Errorf("slack webhook failed with status %d: %s", reply.status, strings.TrimSpace(string(reply.body)))
+	}
+
+	c.logger.Debug("Slack notification sent", zap.String("title", msg.Text))
+	return nil
+}
+
+func headerBlock(text string) slackBlock {
+	return slackBlock{Type: "header", Text: &slackText{Type: "plain_text", Text: text}}
+}
+
+func sectionBlock(markdown string) slackBlock {
+	return slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: markdown}}
+}
+
+func fieldsBlock(fields ...string) slackBlock {
+	b := slackBlock{Type: "section"}
+	for _, f := range fields {
+		b.Fields = append(b.Fields, slackText{Type: "mrkdwn", Text: f})
+	}
+	return b
+}
+
+// throughputBlock returns a context block with the transfer volume and rate
+// when any file was downloaded.
+func throughputBlock(result *download.BatchResult) (slackBlock, bool) {
+	text, ok := formatThroughput(result)
+	if !ok {
+		return slackBlock{}, false
+	}
+	return slackBlock{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: text}}}, true
+}
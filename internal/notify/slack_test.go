@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+func TestFailuresByTicker(t *testing.T) {
+	result := &download.BatchResult{Tasks: []download.TaskStatus{
+		{Task: "2025-01-02/SPX/classic/gex_one", Status: download.StatusFailed},
+		{Task: "2025-01-02/NDX/classic/gex_full", Status: download.StatusFailed},
+		{Task: "2025-01-02/SPX/classic/gex_full", Status: download.StatusFailed},
+		{Task: "2025-01-02/SPX/state/gamma_one", Status: download.StatusSuccess},
+	}}
+
+	got := FailuresByTicker(result)
+	if len(got) != 2 {
+		t.Fatalf("FailuresByTicker() = %+v, want 2 tickers", got)
+	}
+	if got[0].Ticker != "NDX" || strings.Join(got[0].Categories, ",") != "classic/gex_full" {
+		t.Errorf("first = %+v", got[0])
+	}
+	if got[1].Ticker != "SPX" || strings.Join(got[1].Categories, ",") != "classic/gex_full,classic/gex_one" {
+		t.Errorf("second = %+v", got[1])
+	}
+}
+
+func TestSlackClientSendFailure(t *testing.T) {
+	var msg slackMessage
+	reject := false
+	srv := captureServer(t, &msg, http.StatusOK, func(w http.ResponseWriter, r *http.Request) bool {
+		if reject {
+			http.Error(w, "invalid_blocks", http.StatusBadRequest)
+		}
+		return reject
+	})
+
+	client := NewSlackClient(&Config{SlackEnabled: true, SlackWebhookURL: srv.URL}, zap.NewNop())
+	result := failedBatch()
+	if err := client.SendFailure(context.Background(), result, "2025-01-02", time.Minute, errors.New("1 downloads failed")); err != nil {
+		t.Fatalf("SendFailure() error = %v", err)
+	}
+
+	if !strings.Contains(msg.Text, "Download Failed: 2025-01-02") {
+		t.Errorf("text = %q", msg.Text)
+	}
+	var sections []string
+	for _, b := range msg.Blocks {
+		if b.Text != nil {
+			sections = append(sections, b.Text.Text)
+		}
+	}
+	joined := strings.Join(sections, "\n")
+	for _, want := range []string{"*SPX*: 1 (classic/gex_one)", "1 downloads failed", "status 500"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("blocks missing %q:\n%s", want, joined)
+		}
+	}
+
+	reject = true
+	if err := client.SendSuccess(context.Background(), result, "2025-01-02", time.Minute); err == nil || !strings.Contains(err.Error(), "invalid_blocks") {
+		t.Errorf("SendSuccess() error = %v, want invalid_blocks", err)
+	}
+}
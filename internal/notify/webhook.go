@@ -0,0 +1,200 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/download"
+)
+
+// defaultWebhookTemplate is the body sent when NOTIFY_WEBHOOK_TEMPLATE is not
+// set.
+const defaultWebhookTemplate = `{
+  "event": {{json .Event}},
+  "title": {{json .Title}},
+  "date": {{json .Date}},
+  "duration_seconds": {{.DurationSeconds}},
+  "error": {{json .Error}},
+  "failures": {{json .Failures}},
+  "result": {{json .Result}},
+  "digest": {{json .Digest}},
+  "message": {{json .Message}}
+}`
+
+// WebhookData is what a webhook template is executed with.
+type WebhookData struct {
+	Event           string                // "success", "failure", "digest" or "alert"
+	Title           string                // e.g., "Download Failed: 2025-01-02"
+	Date            string                // date(s) downloaded
+	Duration        time.Duration         // run duration
+	DurationSeconds float64               // run duration in seconds
+	Error           string                // run error, empty on success
+	Result          *download.BatchResult // counts, errors and tasks
+	Failures        []TickerFailures      // failed files per ticker
+	Digest          *Digest               // digest events only
+	Message         string                // alert events only
+}
+
+// WebhookClient POSTs a JSON body rendered from a Go text/template to a URL,
+// to integrate PagerDuty, Opsgenie or in-house systems.
+type WebhookClient struct {
+	httpClient *http.Client
+	url        string
+	headers    http.Header
+	tmpl       *template.Template
+	tmplErr    error // reported on every send
+	logger     *zap.Logger
+}
+
+// NewWebhookClient creates a new webhook client. A template that fails to
+// load fails each notification; Config.Validate reports it up front.
+func NewWebhookClient(cfg *Config, logger *zap.Logger) *WebhookClient {
+	c := &WebhookClient{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		url:    cfg.WebhookURL,
+		logger: logger,
+	}
+	c.headers, c.tmplErr = parseWebhookHeaders(cfg.WebhookHeaders)
+	if c.tmplErr == nil {
+		c.tmpl, c.tmplErr = loadWebhookTemplate(cfg.WebhookTemplate)
+	}
+	return c
+}
+
+// loadWebhookTemplate parses the template file at path, or the default
+// template when path is empty.
+func loadWebhookTemplate(path string) (*template.Template, error) {
+	text := defaultWebhookTemplate
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading NOTIFY_WEBHOOK_TEMPLATE: %w", err)
+		}
+		text = string(b)
+	}
+
+	tmpl, err := template.New("webhook").Option("missingkey=error").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing NOTIFY_WEBHOOK_TEMPLATE: %w", err)
+	}
+	return tmpl, nil
+}
+
+// parseWebhookHeaders parses comma-separated "Name: value" pairs.
+func parseWebhookHeaders(s string) (http.Header, error) {
+	headers := make(http.Header)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid NOTIFY_WEBHOOK_HEADERS entry %q, want Name: value", strings.TrimSpace(pair))
+		}
+		headers.Add(name, strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// SendSuccess sends a success notification.
+func (c *WebhookClient) SendSuccess(ctx context.Context, result *download.BatchResult, date string, duration time.Duration) error {
+	result = orEmpty(result)
+	return c.send(ctx, WebhookData{
+		Event:           "success",
+		Title:           fmt.Sprintf("Download Complete: %s", date),
+		Date:            date,
+		Duration:        duration,
+		DurationSeconds: duration.Seconds(),
+		Result:          result,
+		Failures:        FailuresByTicker(result),
+	})
+}
+
+// SendFailure sends a failure notification.
+func (c *WebhookClient) SendFailure(ctx context.Context, result *download.BatchResult, date string, duration time.Duration, err error) error {
+	result = orEmpty(result)
+	data := WebhookData{
+		Event:           "failure",
+		Title:           fmt.Sprintf("Download Failed: %s", date),
+		Date:            date,
+		Duration:        duration,
+		DurationSeconds: duration.Seconds(),
+		Result:          result,
+		Failures:        FailuresByTicker(result),
+	}
+	if err != nil {
+		data.Error = err.Error()
+	}
+	return c.send(ctx, data)
+}
+
+// SendDigest sends a digest notification.
+func (c *WebhookClient) SendDigest(ctx context.Context, digest *Digest) error {
+	return c.send(ctx, WebhookData{
+		Event:  "digest",
+		Title:  digest.Title(),
+		Date:   digest.LastDate(),
+		Result: orEmpty(nil),
+		Digest: digest,
+	})
+}
+
+// SendAlert sends an alert notification.
+func (c *WebhookClient) SendAlert(ctx context.Context, alert *Alert) error {
+	return c.send(ctx, WebhookData{
+		Event:   "alert",
+		Title:   alert.Title,
+		Date:    alert.Date,
+		Message: alert.Message,
+		Result:  orEmpty(nil),
+	})
+}
+
+func (c *WebhookClient) send(ctx context.Context, data WebhookData) error {
+	if c.tmplErr != nil {
+		return c.tmplErr
+	}
+
+	var body bytes.Buffer
+	if err := c.tmpl.Execute(&body, data); err != nil {
+		return fmt.Errorf("rendering webhook template: %w", err)
+	}
+	if !json.Valid(body.Bytes()) {
+		return fmt.Errorf("webhook template rendered invalid JSON: %s", body.String())
+	}
+
+	reply, err := postJSON(ctx, c.httpClient, c.url, c.headers, &body)
+	if err != nil {
+		c.logger.Warn("failed to send webhook notification", zap.Error(err))
+		return fmt.Errorf("sending webhook notification: %w", err)
+	}
+
+	if !reply.ok() {
+		c.logger.Warn("webhook notification failed",
+			zap.Int("status", reply.status),
+			zap.String("url", c.url),
+			zap.String("reply", string(reply.body)),
+		)
+		return fmt.Errorf("webhook failed with status %d: %s", reply.status, strings.TrimSpace(string(reply.body)))
+	}
+
+	c.logger.Debug("webhook notification sent", zap.String("title", data.Title))
+	return nil
+}
@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestWebhookClient(t *testing.T) {
+	var got map[string]any
+	var auth string
+	srv := captureServer(t, &got, http.StatusAccepted, func(w http.ResponseWriter, r *http.Request) bool {
+		auth = r.Header.Get("Authorization")
+		return false
+	})
+
+	// A PagerDuty Events v2 style body
+	tmpl := filepath.Join(t.TempDir(), "pagerduty.json.tmpl")
+	if err := os.WriteFile(tmpl, []byte(`{
+  "routing_key": "abc",
+  "event_action": {{if eq .Event "failure"}}"trigger"{{else}}"resolve"{{end}},
+  "dedup_key": {{json (printf "gexbot-%s" .Date)}},
+  "payload": {
+    "summary": {{json .Title}},
+    "severity": "error",
+    "custom_details": {"failed": {{.Result.Failed}}, "error": {{json .Error}}, "tickers": {{json .Failures}}}
+  }
+}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{Backend: "webhook", WebhookEnabled: true, WebhookURL: srv.URL, WebhookTemplate: tmpl, WebhookHeaders: "Authorization: Token t0k"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	client := NewWebhookClient(cfg, zap.NewNop())
+
+	result := failedBatch()
+	if err := client.SendFailure(context.Background(), result, "2025-01-02", time.Minute, errors.New(`1 "downloads" failed`)); err != nil {
+		t.Fatalf("SendFailure() error = %v", err)
+	}
+	if auth != "Token t0k" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if got["event_action"] != "trigger" || got["dedup_key"] != "gexbot-2025-01-02" {
+		t.Errorf("body = %v", got)
+	}
+	details := got["payload"].(map[string]any)["custom_details"].(map[string]any)
+	if details["failed"] != float64(1) || details["error"] != `1 "downloads" failed` {
+		t.Errorf("custom_details = %v", details)
+	}
+
+	// A failure before any download has no result
+	if err := client.SendFailure(context.Background(), nil, "2025-01-02", time.Second, errors.New("no tickers")); err != nil {
+		t.Fatalf("SendFailure(nil) error = %v", err)
+	}
+
+	if err := NewWebhookClient(&Config{WebhookURL: srv.URL}, zap.NewNop()).SendSuccess(context.Background(), result, "2025-01-02", time.Minute); err != nil {
+		t.Fatalf("default template: SendSuccess() error = %v", err)
+	}
+	if got["event"] != "success" || got["result"].(map[string]any)["total"] != float64(2) {
+		t.Errorf("default body = %v", got)
+	}
+}
+
+func TestWebhookConfigValidate(t *testing.T) {
+	bad := filepath.Join(t.TempDir(), "bad.tmpl")
+	if err := os.WriteFile(bad, []byte(`{"date": {{.Date}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for name, cfg := range map[string]*Config{
+		"no url":       {WebhookEnabled: true},
+		"bad header":   {WebhookEnabled: true, WebhookURL: "http://x", WebhookHeaders: "Authorization"},
+		"bad template": {WebhookEnabled: true, WebhookURL: "http://x", WebhookTemplate: bad},
+		"no template":  {WebhookEnabled: true, WebhookURL: "http://x", WebhookTemplate: filepath.Join(t.TempDir(), "missing")},
+	} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: Validate() = nil, want error", name)
+		}
+	}
+}
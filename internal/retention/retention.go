@@ -0,0 +1,69 @@
+// Package retention removes date directories that have aged out of the
+// download output.
+package retention
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Result summarizes a prune.
+type Result struct {
+	Cutoff string   // oldest date kept
+	Dates  []string // dates removed, or that would be in a dry run
+	Bytes  int64    // disk space reclaimed
+}
+
+// Prune removes date directories (YYYY-MM-DD) in dir that are older than
+// cutoff. With dryRun nothing is deleted, but the result still lists what
+// would be.
+func Prune(dir, cutoff string, dryRun bool) (*Result, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+
+	result := &Result{Cutoff: cutoff}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name >= cutoff {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", name); err != nil {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		size, err := dirSize(path)
+		if err != nil {
+			return result, fmt.Errorf("sizing %s: %w", name, err)
+		}
+		if !dryRun {
+			if err := os.RemoveAll(path); err != nil {
+				return result, fmt.Errorf("removing %s: %w", name, err)
+			}
+		}
+		result.Dates = append(result.Dates, name)
+		result.Bytes += size
+	}
+	sort.Strings(result.Dates)
+
+	return result, nil
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,50 @@
+package retention
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	for _, rel := range []string{
+		"2025-11-12/SPX/state/gex_zero.jsonl",
+		"2025-11-13/SPX/state/gex_zero.jsonl",
+		"2025-11-14/SPX/state/gex_zero.jsonl",
+		"backup/old.jsonl",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("0123456789"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A dry run reports without deleting
+	result, err := Prune(dir, "2025-11-14", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Dates) != 2 || result.Dates[0] != "2025-11-12" || result.Bytes != 20 {
+		t.Errorf("dry run result = %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2025-11-12")); err != nil {
+		t.Errorf("dry run removed data: %v", err)
+	}
+
+	if _, err := Prune(dir, "2025-11-14", false); err != nil {
+		t.Fatal(err)
+	}
+	for name, kept := range map[string]bool{"2025-11-12": false, "2025-11-13": false, "2025-11-14": true, "backup": true} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if kept && err != nil {
+			t.Errorf("%s removed", name)
+		}
+		if !kept && err == nil {
+			t.Errorf("%s kept", name)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+// Package sample generates a synthetic trading day in the downloader's JSONL
+// layout. The data has the shape of real GexBot history but is random, so it
+// can be shared freely and replayed without an API key.
+package sample
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// DefaultTickers are generated when Options.Tickers is empty.
+var DefaultTickers = []string{"SPX", "SPY"}
+
+// Regular session in New York time.
+const (
+	sessionOpen  = 9*time.Hour + 30*time.Minute
+	sessionClose = 16 * time.Hour
+)
+
+// strikesPerSide is the number of strikes generated above and below spot.
+const strikesPerSide = 10
+
+// Options control the generated day.
+type Options struct {
+	Date     string        // YYYY-MM-DD
+	Tickers  []string      // default DefaultTickers
+	Interval time.Duration // time between records, default 1m
+	Seed     uint64        // same seed, same data
+}
+
+// Result summarizes a generated day.
+type Result struct {
+	Dir     string // {dataDir}/{date}
+	Files   int
+	Records int // per file
+}
+
+// Generate writes every package and category in config.ValidCategories for
+// each ticker under {dataDir}/{date}/{ticker}/{package}/{category}.jsonl.
+func Generate(dataDir string, opts Options) (*Result, error) {
+	if len(opts.Tickers) == 0 {
+		opts.Tickers = DefaultTickers
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.UTC
+	}
+	day, err := time.ParseInLocation("2006-01-02", opts.Date, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", opts.Date, err)
+	}
+
+	var timestamps []int64
+	for t := day.Add(sessionOpen); !t.After(day.Add(sessionClose)); t = t.Add(opts.Interval) {
+		timestamps = append(timestamps, t.Unix())
+	}
+
+	result := &Result{Dir: filepath.Join(dataDir, opts.Date), Records: len(timestamps)}
+	for _, ticker := range opts.Tickers {
+		// One price path per ticker, shared by every package
+		path := pricePath(newRand(opts.Seed, ticker), basePrice(ticker), len(timestamps))
+
+		for pkg, categories := range config.ValidCategories {
+			for _, category := range categories {
+				rng := newRand(opts.Seed, ticker+"/"+string(pkg)+"/"+category)
+				file := filepath.Join(result.Dir, ticker, string(pkg), category+".jsonl")
+				if err := writeFile(file, func(n int) any {
+					return record(rng, pkg, category, ticker, timestamps[n], path[n])
+				}, len(timestamps)); err != nil {
+					return nil, err
+				}
+				result.Files++
+			}
+		}
+	}
+	return result, nil
+}
+
+// newRand returns a generator seeded by seed and name, so each file's
+// content does not depend on the order files are written in.
+func newRand(seed uint64, name string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return rand.New(rand.NewPCG(seed, h.Sum64()))
+}
+
+// writeFile writes n records as JSON lines.
+func writeFile(path string, next func(int) any, n int) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for i := range n {
+		if err := enc.Encode(next(i)); err != nil {
+			f.Close()
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// basePrice returns a plausible opening price for ticker.
+func basePrice(ticker string) float64 {
+	switch ticker {
+	case "SPX", "ES_SPX":
+		return 6000
+	case "NDX", "NQ_NDX":
+		return 21000
+	case "RUT":
+		return 2200
+	case "VIX":
+		return 16
+	case "SPY":
+		return 600
+	case "QQQ":
+		return 510
+	case "IWM":
+		return 220
+	default:
+		return 100
+	}
+}
+
+// pricePath is a random walk of small per-record returns.
+func pricePath(rng *rand.Rand, open float64, n int) []float64 {
+	path := make([]float64, n)
+	price := open
+	for i := range path {
+		path[i] = round(price, 2)
+		price *= 1 + rng.NormFloat64()*0.0004
+	}
+	return path
+}
+
+// strikeStep returns the listed strike spacing for a price level.
+func strikeStep(spot float64) float64 {
+	switch {
+	case spot >= 10000:
+		return 25
+	case spot >= 1000:
+		return 5
+	case spot >= 50:
+		return 1
+	default:
+		return 0.5
+	}
+}
+
+// strikes returns the strikes around spot, lowest first.
+func strikes(spot float64) []float64 {
+	step := strikeStep(spot)
+	atm := math.Round(spot/step) * step
+	out := make([]float64, 0, 2*strikesPerSide+1)
+	for i := -strikesPerSide; i <= strikesPerSide; i++ {
+		out = append(out, atm+float64(i)*step)
+	}
+	return out
+}
+
+// record builds one record for a package and category.
+func record(rng *rand.Rand, pkg config.Package, category, ticker string, ts int64, spot float64) any {
+	switch pkg {
+	case config.PackageOrderflow:
+		return orderflowRecord(rng, ticker, ts, spot)
+	case config.PackageVolatility:
+		return volatilityRecord(rng, category, ticker, ts, spot)
+	default:
+		if category == "gex_full" || category == "gex_zero" || category == "gex_one" {
+			return gexRecord(rng, category, ticker, ts, spot)
+		}
+		return greekRecord(rng, category, ticker, ts, spot)
+	}
+}
+
+// dteFor returns min_dte/sec_min_dte for a category's expiry aggregation.
+func dteFor(category string) (int, int) {
+	if strings.HasSuffix(category, "_one") {
+		return 1, 2
+	}
+	return 0, 1
+}
+
+func gexRecord(rng *rand.Rand, category, ticker string, ts int64, spot float64) data.GexData {
+	scale := map[string]float64{"gex_full": 1, "gex_zero": 0.6, "gex_one": 0.3}[category]
+	zeroGamma := round(spot*(0.996+rng.Float64()*0.002), 2)
+	width := strikeStep(spot) * 6
+
+	var rows [][]any
+	var sumVol, sumOI float64
+	var posVol, negVol, posOI, negOI [2]float64 // strike, value
+	for _, k := range strikes(spot) {
+		shape := (k - zeroGamma) / width * math.Exp(-math.Pow((k-spot)/width, 2))
+		vol := round(scale*100*shape*(0.9+rng.Float64()*0.2), 3)
+		oi := round(scale*150*shape*(0.9+rng.Float64()*0.2), 3)
+		priors := make([]float64, 5)
+		for i := range priors {
+			priors[i] = round(vol*(rng.Float64()*0.1-0.05), 3)
+		}
+		rows = append(rows, []any{k, vol, oi, priors})
+
+		sumVol += vol
+		sumOI += oi
+		if vol > posVol[1] {
+			posVol = [2]float64{k, vol}
+		}
+		if vol < negVol[1] {
+			negVol = [2]float64{k, vol}
+		}
+		if oi > posOI[1] {
+			posOI = [2]float64{k, oi}
+		}
+		if oi < negOI[1] {
+			negOI = [2]float64{k, oi}
+		}
+	}
+
+	// Largest change over 1, 5, 10, 15 and 30 minutes, plus the current leader
+	maxPriors := make([][]float64, 6)
+	for i := range maxPriors {
+		row := rows[rng.IntN(len(rows))]
+		maxPriors[i] = []float64{row[0].(float64), round(rng.NormFloat64()*scale*5, 3)}
+	}
+
+	minDTE, secMinDTE := dteFor(category)
+	return data.GexData{
+		Timestamp:         ts,
+		Ticker:            ticker,
+		MinDTE:            minDTE,
+		SecMinDTE:         secMinDTE,
+		Spot:              spot,
+		ZeroGamma:         zeroGamma,
+		MajorPosVol:       posVol[0],
+		MajorPosOI:        posOI[0],
+		MajorNegVol:       negVol[0],
+		MajorNegOI:        negOI[0],
+		Strikes:           mustJSON(rows),
+		SumGexVol:         round(sumVol, 3),
+		SumGexOI:          round(sumOI, 3),
+		DeltaRiskReversal: round(0.05+rng.NormFloat64()*0.01, 3),
+		MaxPriors:         mustJSON(maxPriors),
+	}
+}
+
+func greekRecord(rng *rand.Rand, category, ticker string, ts int64, spot float64) data.GreekData {
+	width := strikeStep(spot) * 6
+	var contracts [][]any
+	var pos, neg [2]float64 // strike, value
+	for _, k := range strikes(spot) {
+		moneyness := (k - spot) / spot
+		callIV := round(0.15-moneyness*0.8+rng.Float64()*0.01, 4)
+		putIV := round(0.16-moneyness*1.0+rng.Float64()*0.01, 4)
+		value := round((k-spot)/width*math.Exp(-math.Pow((k-spot)/width, 2))*50*(0.9+rng.Float64()*0.2), 3)
+		callPriors := make([]float64, 5)
+		for i := range callPriors {
+			callPriors[i] = round(value*(rng.Float64()*0.1-0.05), 3)
+		}
+		putVolume := rng.IntN(5000)
+		putPriors := []int{rng.IntN(100), rng.IntN(100), rng.IntN(100), rng.IntN(100), rng.IntN(100)}
+		contracts = append(contracts, []any{k, callIV, putIV, value, callPriors, putVolume, putPriors})
+
+		if value > pos[1] {
+			pos = [2]float64{k, value}
+		}
+		if value < neg[1] {
+			neg = [2]float64{k, value}
+		}
+	}
+
+	minDTE, secMinDTE := dteFor(category)
+	return data.GreekData{
+		Timestamp:       ts,
+		Ticker:          ticker,
+		Spot:            spot,
+		MinDTE:          minDTE,
+		SecMinDTE:       secMinDTE,
+		MajorPositive:   pos[0],
+		MajorNegative:   neg[0],
+		MajorLongGamma:  pos[0],
+		MajorShortGamma: neg[0],
+		MiniContracts:   mustJSON(contracts),
+	}
+}
+
+func volatilityRecord(rng *rand.Rand, category, ticker string, ts int64, spot float64) data.VolatilityData {
+	atm := 0.14 + rng.Float64()*0.01
+	if category == "iv_one" {
+		atm += 0.01
+	}
+	var rows [][]float64
+	for _, k := range strikes(spot) {
+		moneyness := (k - spot) / spot
+		rows = append(rows, []float64{k, round(atm-moneyness*0.8, 4), round(atm+0.01-moneyness*1.0, 4)})
+	}
+
+	minDTE, secMinDTE := dteFor(category)
+	return data.VolatilityData{
+		Timestamp:       ts,
+		Ticker:          ticker,
+		Spot:            spot,
+		MinDTE:          minDTE,
+		SecMinDTE:       secMinDTE,
+		AtmIV:           round(atm, 4),
+		RiskReversal25d: round(-0.03+rng.NormFloat64()*0.005, 4),
+		Butterfly25d:    round(0.004+rng.Float64()*0.002, 4),
+		Strikes:         mustJSON(rows),
+	}
+}
+
+func orderflowRecord(rng *rand.Rand, ticker string, ts int64, spot float64) data.OrderflowData {
+	step := strikeStep(spot)
+	level := func(offset float64) float64 {
+		return math.Round(spot/step)*step + offset*step
+	}
+	flow := func() float64 { return round(rng.NormFloat64()*100, 2) }
+	return data.OrderflowData{
+		Timestamp:     ts,
+		Ticker:        ticker,
+		Spot:          spot,
+		ZMlgamma:      level(2),
+		ZMsgamma:      level(-2),
+		OMlgamma:      level(4),
+		OMsgamma:      level(-4),
+		ZeroMcall:     level(3),
+		ZeroMput:      level(-3),
+		OneMcall:      level(5),
+		OneMput:       level(-5),
+		Zcvr:          round(rng.Float64(), 3),
+		Ocvr:          round(rng.Float64(), 3),
+		Zgr:           round(rng.Float64(), 3),
+		Ogr:           round(rng.Float64(), 3),
+		Zvanna:        flow(),
+		Ovanna:        flow(),
+		Zcharm:        flow(),
+		Ocharm:        flow(),
+		AggDex:        flow(),
+		OneAggDex:     flow(),
+		AggCallDex:    flow(),
+		OneAggCallDex: flow(),
+		AggPutDex:     flow(),
+		OneAggPutDex:  flow(),
+		NetDex:        flow(),
+		OneNetDex:     flow(),
+		NetCallDex:    flow(),
+		OneNetCallDex: flow(),
+		NetPutDex:     flow(),
+		OneNetPutDex:  flow(),
+		Dexoflow:      flow(),
+		Gexoflow:      flow(),
+		Cvroflow:      flow(),
+		OneDexoflow:   flow(),
+		OneGexoflow:   flow(),
+		OneCvroflow:   flow(),
+	}
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
+
+func mustJSON(v any) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,75 @@
+package sample
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/ws"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{Date: "2025-01-02", Tickers: []string{"SPX"}, Interval: 30 * time.Minute, Seed: 7}
+	result, err := Generate(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Files != 17 || result.Records != 14 {
+		t.Fatalf("got %d files of %d records, want 17 of 14", result.Files, result.Records)
+	}
+
+	loader, err := data.NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+	enc, err := ws.NewEncoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer enc.Close()
+
+	// Every file loads and encodes for WebSocket streaming
+	ctx := context.Background()
+	checks := []struct {
+		pkg, category string
+		encode        func([]byte) ([]byte, error)
+	}{
+		{"classic", "gex_full", enc.EncodeGex},
+		{"state", "gex_one", enc.EncodeGex},
+		{"state", "delta_zero", enc.EncodeGreek},
+		{"orderflow", "orderflow", enc.EncodeOrderflow},
+		{"volatility", "iv_zero", enc.EncodeVolatility},
+	}
+	for _, c := range checks {
+		raw, err := loader.GetRawAtIndex(ctx, "SPX", c.pkg, c.category, 0)
+		if err != nil {
+			t.Fatalf("%s/%s: %v", c.pkg, c.category, err)
+		}
+		if _, err := c.encode(raw); err != nil {
+			t.Errorf("%s/%s: encode: %v", c.pkg, c.category, err)
+		}
+	}
+	if ts, _ := data.RecordTimestamp(ctx, loader, "SPX", "classic", "gex_full", 0); time.Unix(ts, 0).UTC().Hour() != 14 {
+		t.Errorf("first record at %s, want the 09:30 ET open", time.Unix(ts, 0).UTC())
+	}
+
+	// Same seed, same data
+	again := t.TempDir()
+	if _, err := Generate(again, opts); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join("2025-01-02", "SPX", "state", "gamma_one.jsonl")
+	a, _ := os.ReadFile(filepath.Join(dir, file))
+	b, _ := os.ReadFile(filepath.Join(again, file))
+	if len(a) == 0 || !bytes.Equal(a, b) {
+		t.Error("same seed produced different data")
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/notify"
+)
+
+// alertTimeout bounds sending one alert, including backend retries.
+const alertTimeout = 2 * time.Minute
+
+// Alerter sends notifications about problems a long-running faker server
+// would otherwise only log: data failing to load, a failed hot reload, and
+// every client running out of data. A nil Alerter sends nothing.
+type Alerter struct {
+	notifier notify.Notifier
+	logger   *zap.Logger
+}
+
+// NewAlerter creates an Alerter sending through notifier.
+func NewAlerter(notifier notify.Notifier, logger *zap.Logger) *Alerter {
+	return &Alerter{notifier: notifier, logger: logger}
+}
+
+// LoadFailed alerts that the data for date failed to load at startup. It
+// waits for the alert to be sent, as the server exits afterwards.
+func (a *Alerter) LoadFailed(date string, err error) {
+	if a == nil {
+		return
+	}
+	a.send(&notify.Alert{
+		Title:   fmt.Sprintf("Faker Load Failed: %s", date),
+		Message: fmt.Sprintf("The faker server failed to load data for %s and exited.\nError: %v", date, err),
+		Date:    date,
+	})
+}
+
+// ReloadFailed alerts that a hot reload to date failed. The previous data
+// keeps being served.
+func (a *Alerter) ReloadFailed(date, current string, err error) {
+	if a == nil {
+		return
+	}
+	go a.send(&notify.Alert{
+		Title:   fmt.Sprintf("Faker Reload Failed: %s", date),
+		Message: fmt.Sprintf("The faker server failed to reload data for %s and is still serving %s.\nError: %v", date, current, err),
+		Date:    date,
+	})
+}
+
+// Exhausted alerts that every playback position of date is exhausted, so
+// each client only gets 404s until a reload or cache reset.
+func (a *Alerter) Exhausted(date string) {
+	if a == nil {
+		return
+	}
+	go a.send(&notify.Alert{
+		Title:   fmt.Sprintf("Faker Data Exhausted: %s", date),
+		Message: fmt.Sprintf("Every API key has played back all data for %s. Reload a date or reset the cache to continue.", date),
+		Date:    date,
+	})
+}
+
+func (a *Alerter) send(alert *notify.Alert) {
+	ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+	defer cancel()
+	if err := a.notifier.SendAlert(ctx, alert); err != nil {
+		a.logger.Warn("failed to send alert", zap.String("title", alert.Title), zap.Error(err))
+	}
+}
+
+// Flush retries queued alerts every interval until ctx is done, when the
+// notifier queues alerts that failed to send.
+func (a *Alerter) Flush(ctx context.Context, interval time.Duration) {
+	f, ok := a.notifier.(notify.Flusher)
+	if !ok {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.Flush(ctx); err != nil {
+				a.logger.Warn("queued alerts failed again", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// BulkCacheOperation implements generated.StrictServerInterface
+func (s *Server) BulkCacheOperation(ctx context.Context, request generated.BulkCacheOperationRequestObject) (generated.BulkCacheOperationResponseObject, error) {
+	body := request.Body
+	var filter data.ResetFilter
+	if sel := body.Selector; sel != nil {
+		filter = data.ResetFilter{
+			APIKey:   derefString(sel.Key),
+			Ticker:   derefString(sel.Ticker),
+			Package:  derefString(sel.Package),
+			Category: derefString(sel.Category),
+			Prefix:   derefString(sel.Prefix),
+		}
+	}
+	op := string(body.Operation)
+
+	var target positionTarget
+
+	switch body.Operation {
+	case generated.SetIndex:
+		if body.Index == nil || *body.Index < 0 {
+			return bulkCacheError("set_index requires a non-negative index"), nil
+		}
+		target = func(_ context.Context, _ data.DataLoader, _ data.CacheKeyParts, _ string, _, _ int) (int, error) {
+			return *body.Index, nil
+		}
+	case generated.FastForward:
+		if body.Count == nil || *body.Count < 1 {
+			return bulkCacheError("fast_forward requires a positive count"), nil
+		}
+		target = func(_ context.Context, _ data.DataLoader, _ data.CacheKeyParts, _ string, current, _ int) (int, error) {
+			return current + *body.Count, nil
+		}
+	case generated.FastForwardTo:
+		if body.Timestamp == nil {
+			return bulkCacheError("fast_forward_to requires a timestamp"), nil
+		}
+		ts := unixSeconds(*body.Timestamp)
+		target = func(ctx context.Context, loader data.DataLoader, parts data.CacheKeyParts, category string, _, length int) (int, error) {
+			return data.SearchTimestamp(ctx, loader, parts.Ticker, parts.Package, category, length, ts)
+		}
+	case generated.SetMode:
+		if body.Mode == nil || (*body.Mode != generated.CacheBulkRequestModeExhaust && *body.Mode != generated.CacheBulkRequestModeRotation) {
+			return bulkCacheError("set_mode requires mode exhaust or rotation"), nil
+		}
+		return s.bulkSetMode(filter, data.CacheMode(*body.Mode)), nil
+	default:
+		return bulkCacheError("unknown operation: " + op), nil
+	}
+
+	current := s.cache.GetMatching(filter)
+	positions, skipped := s.movePositions(ctx, op, current, target)
+
+	s.logger.Info("bulk cache operation",
+		zap.String("operation", op),
+		zap.String("selector", describeResetFilter(filter)),
+		zap.Int("count", len(positions)),
+		zap.Int("skipped", skipped),
+	)
+
+	return generated.BulkCacheOperation200JSONResponse{
+		Status:    "success",
+		Operation: op,
+		Count:     len(positions),
+		Skipped:   skipped,
+		Positions: &positions,
+	}, nil
+}
+
+// positionTarget computes the new index of a position from its current
+// index and data length; an error leaves the position alone.
+type positionTarget func(ctx context.Context, loader data.DataLoader, parts data.CacheKeyParts, category string, current, length int) (int, error)
+
+// movePositions moves each position in current (cache key to index) to its
+// target, in cache key order. Positions whose data cannot be resolved or
+// whose target fails are skipped.
+func (s *Server) movePositions(ctx context.Context, op string, current map[string]int, target positionTarget) ([]generated.CacheBulkPosition, int) {
+	keys := make([]string, 0, len(current))
+	for k := range current {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	positions := make([]generated.CacheBulkPosition, 0, len(keys))
+	skipped := 0
+	for _, key := range keys {
+		parts, ok := data.ParseCacheKey(key)
+		if !ok {
+			skipped++
+			continue
+		}
+		loader, _ := s.dataFor(parts.APIKey)
+		category := parts.Category
+		if category == "" {
+			category = firstCategory(loader, parts.Ticker, parts.Package)
+		}
+		length, err := loader.GetLength(parts.Ticker, parts.Package, category)
+		if err != nil || length == 0 {
+			skipped++
+			continue
+		}
+
+		previous := current[key]
+		index, err := target(ctx, loader, parts, category, previous, length)
+		if err != nil {
+			s.logger.Warn("cache operation failed for key",
+				zap.String("operation", op),
+				zap.String("cacheKey", maskCacheKey(key)),
+				zap.Error(err),
+			)
+			skipped++
+			continue
+		}
+
+		// Past the end: stop at exhaustion, or wrap in rotation mode
+		if s.cache.ModeFor(key) == data.CacheModeRotation {
+			index %= length
+		} else {
+			index = min(index, length)
+		}
+		s.cache.SetIndex(key, index)
+
+		positions = append(positions, generated.CacheBulkPosition{
+			CacheKey:   maskCacheKey(key),
+			Previous:   previous,
+			Index:      index,
+			DataLength: length,
+		})
+	}
+	return positions, skipped
+}
+
+// bulkSetMode switches the cache mode for the selection. The returned count
+// covers existing positions; positions created later follow the new mode too.
+func (s *Server) bulkSetMode(filter data.ResetFilter, mode data.CacheMode) generated.BulkCacheOperationResponseObject {
+	s.cache.SetMode(filter, mode)
+	count := len(s.cache.GetMatching(filter))
+
+	s.logger.Info("bulk cache operation",
+		zap.String("operation", string(generated.SetMode)),
+		zap.String("selector", describeResetFilter(filter)),
+		zap.String("mode", string(mode)),
+		zap.Int("count", count),
+	)
+
+	return generated.BulkCacheOperation200JSONResponse{
+		Status:    "success",
+		Operation: string(generated.SetMode),
+		Count:     count,
+		Mode:      ptr(string(mode)),
+	}
+}
+
+// firstCategory returns the first loaded category of ticker/pkg, used to
+// size shared-mode positions, which advance every category together.
+func firstCategory(loader data.DataLoader, ticker, pkg string) string {
+	prefix := ticker + "/" + pkg + "/"
+	var categories []string
+	for _, key := range loader.GetLoadedKeys() {
+		if category, ok := strings.CutPrefix(key, prefix); ok {
+			categories = append(categories, category)
+		}
+	}
+	if len(categories) == 0 {
+		return ""
+	}
+	sort.Strings(categories)
+	return categories[0]
+}
+
+func bulkCacheError(msg string) generated.BulkCacheOperationResponseObject {
+	return generated.BulkCacheOperation400JSONResponse{
+		Error: ptr(msg),
+	}
+}
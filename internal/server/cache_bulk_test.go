@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+func TestBulkCacheOperation(t *testing.T) {
+	exhaust := data.CacheKey("SPX", "classic", "gex_full", "a")
+	rotating := data.CacheKey("SPX", "classic", "gex_full", "r")
+	shared := data.SharedCacheKey("SPX", "classic", "b")
+
+	mode := func(m generated.CacheBulkRequestMode) *generated.CacheBulkRequestMode { return &m }
+	selector := func(key string) *generated.CacheSelector { return &generated.CacheSelector{Key: ptr(key)} }
+
+	tests := []struct {
+		name    string
+		body    generated.CacheBulkRequest
+		status  int
+		count   int
+		skipped int
+		want    map[string]int // cache key to index after the call
+	}{
+		{"fast_forward stops at exhaustion", generated.CacheBulkRequest{Operation: generated.FastForward, Count: ptr(10), Selector: selector("a")},
+			200, 1, 0, map[string]int{exhaust: 5, rotating: 3}},
+		{"fast_forward wraps in rotation", generated.CacheBulkRequest{Operation: generated.FastForward, Count: ptr(4), Selector: selector("r")},
+			200, 1, 0, map[string]int{exhaust: 3, rotating: 2}},
+		{"set_index stops at exhaustion", generated.CacheBulkRequest{Operation: generated.SetIndex, Index: ptr(99), Selector: selector("a")},
+			200, 1, 0, map[string]int{exhaust: 5}},
+		// Sized by gex_full, the first category, not gex_zero's three records
+		{"shared key sized by first category", generated.CacheBulkRequest{Operation: generated.SetIndex, Index: ptr(4), Selector: selector("b")},
+			200, 1, 0, map[string]int{shared: 4}},
+		{"fast_forward_to in milliseconds", generated.CacheBulkRequest{Operation: generated.FastForwardTo, Timestamp: ptr(int64(open1+60) * 1000)},
+			200, 3, 0, map[string]int{exhaust: 1, rotating: 1, shared: 1}},
+		{"empty selector moves every position", generated.CacheBulkRequest{Operation: generated.SetIndex, Index: ptr(0)},
+			200, 3, 0, map[string]int{exhaust: 0, rotating: 0, shared: 0}},
+		{"no match", generated.CacheBulkRequest{Operation: generated.SetIndex, Index: ptr(0), Selector: selector("z")},
+			200, 0, 0, map[string]int{exhaust: 3}},
+		{"set_mode", generated.CacheBulkRequest{Operation: generated.SetMode, Mode: mode(generated.CacheBulkRequestModeRotation), Selector: selector("a")},
+			200, 1, 0, map[string]int{exhaust: 3}},
+		{"set_index without index", generated.CacheBulkRequest{Operation: generated.SetIndex}, 400, 0, 0, nil},
+		{"set_index negative", generated.CacheBulkRequest{Operation: generated.SetIndex, Index: ptr(-1)}, 400, 0, 0, nil},
+		{"fast_forward without count", generated.CacheBulkRequest{Operation: generated.FastForward, Count: ptr(0)}, 400, 0, 0, nil},
+		{"fast_forward_to without timestamp", generated.CacheBulkRequest{Operation: generated.FastForwardTo}, 400, 0, 0, nil},
+		{"set_mode without mode", generated.CacheBulkRequest{Operation: generated.SetMode}, 400, 0, 0, nil},
+		{"unknown operation", generated.CacheBulkRequest{Operation: "rewind"}, 400, 0, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			s.cache.SetMode(data.ResetFilter{APIKey: "r"}, data.CacheModeRotation)
+			for _, key := range []string{exhaust, rotating, shared} {
+				s.cache.SetIndex(key, 3)
+			}
+
+			body := tt.body
+			resp, err := s.BulkCacheOperation(context.Background(), generated.BulkCacheOperationRequestObject{Body: &body})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := resp.(generated.BulkCacheOperation400JSONResponse); ok {
+				if tt.status != 400 {
+					t.Fatalf("response = %+v, want %d", resp, tt.status)
+				}
+				return
+			}
+			got, ok := resp.(generated.BulkCacheOperation200JSONResponse)
+			if !ok || tt.status != 200 {
+				t.Fatalf("response = %T, want %d", resp, tt.status)
+			}
+			if got.Count != tt.count || got.Skipped != tt.skipped {
+				t.Errorf("count = %d, skipped = %d; want %d, %d", got.Count, got.Skipped, tt.count, tt.skipped)
+			}
+			for key, want := range tt.want {
+				if index := s.cache.GetIndex(key); index != want {
+					t.Errorf("GetIndex(%s) = %d, want %d", key, index, want)
+				}
+			}
+			if tt.body.Mode != nil {
+				if mode := s.cache.ModeFor(exhaust); mode != data.CacheMode(*tt.body.Mode) {
+					t.Errorf("ModeFor = %s, want %s", mode, *tt.body.Mode)
+				}
+			}
+		})
+	}
+}
+
+func TestMovePositions(t *testing.T) {
+	s := newTestServer(t)
+	current := map[string]int{
+		data.CacheKey("SPX", "classic", "gex_full", "a"): 1,
+		data.CacheKey("SPX", "classic", "gex_zero", "a"): 1,
+		data.CacheKey("NDX", "classic", "gex_full", "a"): 1, // not loaded
+		data.SharedCacheKey("SPX", "state", "a"):         1, // no loaded category
+		"unparsable":                                     1,
+	}
+	failing := data.CacheKey("SPX", "classic", "gex_zero", "a")
+	s.cache.SetIndex(failing, 1)
+
+	positions, skipped := s.movePositions(context.Background(), "test", current,
+		func(_ context.Context, _ data.DataLoader, parts data.CacheKeyParts, category string, previous, length int) (int, error) {
+			if category == "gex_zero" {
+				return 0, errors.New("target failed")
+			}
+			return previous + length, nil
+		})
+
+	if skipped != 4 {
+		t.Errorf("skipped = %d, want 4", skipped)
+	}
+	if len(positions) != 1 || positions[0].Previous != 1 || positions[0].Index != 5 || positions[0].DataLength != 5 {
+		t.Fatalf("positions = %+v, want SPX/classic/gex_full moved from 1 to 5", positions)
+	}
+	if got := s.cache.GetIndex(failing); got != 1 {
+		t.Errorf("failed target moved its position to %d", got)
+	}
+}
+
+func TestFirstCategory(t *testing.T) {
+	s := newTestServer(t)
+	tests := []struct {
+		ticker, pkg, want string
+	}{
+		{"SPX", "classic", "gex_full"},
+		{"SPX", "orderflow", "orderflow"},
+		{"SPX", "state", ""},
+		{"NDX", "classic", ""},
+	}
+	for _, tt := range tests {
+		if got := firstCategory(s.loader, tt.ticker, tt.pkg); got != tt.want {
+			t.Errorf("firstCategory(%s, %s) = %q, want %q", tt.ticker, tt.pkg, got, tt.want)
+		}
+	}
+}
+
+func TestBulkCacheEndpoint(t *testing.T) {
+	s := newTestServer(t)
+	s.config.AdminToken = "secret"
+	router, err := NewRouter(s, nil, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := data.CacheKey("SPX", "classic", "gex_full", "a")
+
+	tests := []struct {
+		name   string
+		auth   string
+		body   string
+		status int
+		want   string // substring of the response body
+	}{
+		{"fast_forward", "Bearer secret", `{"operation":"fast_forward","count":2,"selector":{"key":"a"}}`, http.StatusOK, `"count":1`},
+		{"set_index", "Bearer secret", `{"operation":"set_index","index":99}`, http.StatusOK, `"data_length":5`},
+		{"missing index", "Bearer secret", `{"operation":"set_index"}`, http.StatusBadRequest, "non-negative index"},
+		{"unknown operation", "Bearer secret", `{"operation":"rewind"}`, http.StatusBadRequest, ""},
+		{"malformed body", "Bearer secret", `{"operation":`, http.StatusBadRequest, ""},
+		{"no token", "", `{"operation":"set_index","index":0}`, http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.cache.SetIndex(key, 1)
+			req := httptest.NewRequest(http.MethodPost, "/admin/cache/bulk", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("body = %s, want %q", rec.Body, tt.want)
+			}
+			if tt.status != http.StatusOK && s.cache.GetIndex(key) != 1 {
+				t.Errorf("rejected request moved the position to %d", s.cache.GetIndex(key))
+			}
+		})
+	}
+}
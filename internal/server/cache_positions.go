@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// GetCachePositions implements generated.StrictServerInterface
+func (s *Server) GetCachePositions(ctx context.Context, request generated.GetCachePositionsRequestObject) (generated.GetCachePositionsResponseObject, error) {
+	p := request.Params
+	filter := data.ResetFilter{
+		APIKey:   derefString(p.Key),
+		Ticker:   derefString(p.Ticker),
+		Package:  derefString(p.Package),
+		Category: derefString(p.Category),
+		Prefix:   derefString(p.Prefix),
+	}
+
+	current := s.cache.GetMatching(filter)
+	keys := make([]string, 0, len(current))
+	for k := range current {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	positions := make([]generated.CachePosition, 0, len(keys))
+	for _, key := range keys {
+		parts, ok := data.ParseCacheKey(key)
+		if !ok {
+			continue
+		}
+		loader, date := s.dataFor(parts.APIKey)
+		category := parts.Category
+		if category == "" {
+			category = firstCategory(loader, parts.Ticker, parts.Package)
+		}
+		length, err := loader.GetLength(parts.Ticker, parts.Package, category)
+		if err != nil {
+			length = 0
+		}
+
+		index := current[key]
+		mode := s.cache.ModeFor(key)
+		pos := generated.CachePosition{
+			CacheKey:   maskCacheKey(key),
+			ApiKey:     maskAPIKey(parts.APIKey),
+			Ticker:     parts.Ticker,
+			Package:    parts.Package,
+			Date:       ptr(date),
+			Index:      index,
+			DataLength: length,
+			Exhausted:  mode == data.CacheModeExhaust && index >= length,
+			Mode:       generated.CachePositionMode(mode),
+		}
+		if parts.Hub != "" {
+			pos.Hub = ptr(parts.Hub)
+		}
+		if parts.Category != "" {
+			pos.Category = ptr(parts.Category)
+		}
+		positions = append(positions, pos)
+	}
+
+	return generated.GetCachePositions200JSONResponse{
+		DefaultMode: string(s.cache.GetMode()),
+		Count:       len(positions),
+		Positions:   positions,
+	}, nil
+}
+
+// SetCachePosition implements generated.StrictServerInterface
+func (s *Server) SetCachePosition(ctx context.Context, request generated.SetCachePositionRequestObject) (generated.SetCachePositionResponseObject, error) {
+	body := request.Body
+	cacheKey, apiKey := derefString(body.CacheKey), derefString(body.Key)
+
+	var current map[string]int
+	switch {
+	case (cacheKey == "") == (apiKey == ""):
+		return setPositionError("exactly one of cache_key or key is required"), nil
+	case cacheKey != "":
+		if _, ok := data.ParseCacheKey(cacheKey); !ok {
+			return setPositionError("invalid cache_key: " + cacheKey), nil
+		}
+		current = map[string]int{cacheKey: s.cache.GetIndex(cacheKey)}
+	default:
+		current = s.cache.GetMatching(data.ResetFilter{
+			APIKey:   apiKey,
+			Ticker:   derefString(body.Ticker),
+			Package:  derefString(body.Package),
+			Category: derefString(body.Category),
+		})
+	}
+
+	targets := 0
+	for _, set := range []bool{body.Index != nil, body.Timestamp != nil, body.Time != nil} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return setPositionError("exactly one of index, timestamp or time is required"), nil
+	}
+
+	op := string(generated.FastForwardTo)
+	var target positionTarget
+	switch {
+	case body.Index != nil:
+		if *body.Index < 0 {
+			return setPositionError("index must not be negative"), nil
+		}
+		op = string(generated.SetIndex)
+		target = func(_ context.Context, _ data.DataLoader, _ data.CacheKeyParts, _ string, _, _ int) (int, error) {
+			return *body.Index, nil
+		}
+	case body.Timestamp != nil:
+		ts := unixSeconds(*body.Timestamp)
+		target = func(ctx context.Context, loader data.DataLoader, parts data.CacheKeyParts, category string, _, length int) (int, error) {
+			return data.SearchTimestamp(ctx, loader, parts.Ticker, parts.Package, category, length, ts)
+		}
+	default:
+		clock, err := config.ParseTimeOfDay(*body.Time)
+		if err != nil {
+			return setPositionError(err.Error()), nil
+		}
+		target = func(ctx context.Context, loader data.DataLoader, parts data.CacheKeyParts, category string, _, length int) (int, error) {
+			_, date := s.dataFor(parts.APIKey)
+			day, err := time.ParseInLocation("2006-01-02", date, config.NYSELocation())
+			if err != nil {
+				return 0, fmt.Errorf("invalid data date %q: %w", date, err)
+			}
+			return data.SearchTimestamp(ctx, loader, parts.Ticker, parts.Package, category, length, day.Add(clock).Unix())
+		}
+	}
+
+	if len(current) == 0 {
+		return generated.SetCachePosition404JSONResponse{
+			Error: ptr("no cache position matches key " + maskAPIKey(apiKey)),
+		}, nil
+	}
+	positions, skipped := s.movePositions(ctx, op, current, target)
+
+	s.logger.Info("cache position set",
+		zap.String("operation", op),
+		zap.Int("count", len(positions)),
+		zap.Int("skipped", skipped),
+	)
+
+	return generated.SetCachePosition200JSONResponse{
+		Status:    "success",
+		Operation: op,
+		Count:     len(positions),
+		Skipped:   skipped,
+		Positions: &positions,
+	}, nil
+}
+
+// SeekCache implements generated.StrictServerInterface
+func (s *Server) SeekCache(ctx context.Context, request generated.SeekCacheRequestObject) (generated.SeekCacheResponseObject, error) {
+	p := request.Params
+	filter := data.ResetFilter{
+		APIKey:   derefString(p.Key),
+		Ticker:   derefString(p.Ticker),
+		Package:  derefString(p.Package),
+		Category: derefString(p.Category),
+	}
+	if filter.APIKey == "" && filter.Ticker == "" {
+		return generated.SeekCache400JSONResponse{
+			Error: ptr("ticker or key is required"),
+		}, nil
+	}
+	if p.Ts < 0 {
+		return generated.SeekCache400JSONResponse{
+			Error: ptr("ts must not be negative"),
+		}, nil
+	}
+	ts := unixSeconds(p.Ts)
+
+	current := s.cache.GetMatching(filter)
+	if len(current) == 0 {
+		return generated.SeekCache404JSONResponse{
+			Error: ptr("no cache position matches " + describeResetFilter(filter)),
+		}, nil
+	}
+
+	op := "seek"
+	positions, skipped := s.movePositions(ctx, op, current, func(ctx context.Context, loader data.DataLoader, parts data.CacheKeyParts, category string, _, length int) (int, error) {
+		return data.SearchTimestamp(ctx, loader, parts.Ticker, parts.Package, category, length, ts)
+	})
+
+	s.logger.Info("cache seek",
+		zap.String("selector", describeResetFilter(filter)),
+		zap.Int64("timestamp", ts),
+		zap.Int("count", len(positions)),
+		zap.Int("skipped", skipped),
+	)
+
+	return generated.SeekCache200JSONResponse{
+		Status:    "success",
+		Operation: op,
+		Count:     len(positions),
+		Skipped:   skipped,
+		Positions: &positions,
+	}, nil
+}
+
+func setPositionError(msg string) generated.SetCachePositionResponseObject {
+	return generated.SetCachePosition400JSONResponse{
+		Error: ptr(msg),
+	}
+}
+
+// millisThreshold separates Unix timestamps in milliseconds from seconds;
+// seconds only reach it in the year 33658.
+const millisThreshold = 1_000_000_000_000
+
+// unixSeconds converts a Unix timestamp given in seconds or milliseconds to
+// seconds, the unit of the data.
+func unixSeconds(ts int64) int64 {
+	if ts >= millisThreshold {
+		return ts / 1000
+	}
+	return ts
+}
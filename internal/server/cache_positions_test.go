@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+func TestGetCachePositions(t *testing.T) {
+	s := newTestServer(t)
+	s.cache.SetIndex(data.CacheKey("SPX", "classic", "gex_full", "a"), 2)
+	s.cache.SetIndex(data.CacheKey("SPX", "classic", "gex_full", data.DatedKey("a", "2025-01-03")), 3)
+	s.cache.SetIndex(data.SharedCacheKey("SPX", "classic", "b"), 1)
+
+	resp, err := s.GetCachePositions(context.Background(), generated.GetCachePositionsRequestObject{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := resp.(generated.GetCachePositions200JSONResponse)
+	if got.Count != 3 || got.DefaultMode != "exhaust" {
+		t.Fatalf("count = %d, default mode = %q; want 3, exhaust", got.Count, got.DefaultMode)
+	}
+
+	want := map[string]struct {
+		date      string
+		length    int
+		exhausted bool
+	}{
+		// A DatedKey reads its own date; shared keys are sized by the first category
+		"SPX/classic/gex_full/a":                          {"2025-01-02", 5, false},
+		maskCacheKey("SPX/classic/gex_full/a@2025-01-03"): {"2025-01-03", 3, true},
+		"SPX/classic/b":                                   {"2025-01-02", 5, false},
+	}
+	for _, pos := range got.Positions {
+		w, ok := want[pos.CacheKey]
+		if !ok {
+			t.Errorf("unexpected position %q", pos.CacheKey)
+			continue
+		}
+		if *pos.Date != w.date || pos.DataLength != w.length || pos.Exhausted != w.exhausted {
+			t.Errorf("%s: date %s, length %d, exhausted %v; want %s, %d, %v",
+				pos.CacheKey, *pos.Date, pos.DataLength, pos.Exhausted, w.date, w.length, w.exhausted)
+		}
+	}
+
+	resp, err = s.GetCachePositions(context.Background(), generated.GetCachePositionsRequestObject{
+		Params: generated.GetCachePositionsParams{Key: ptr("b")},
+	})
+	if err != nil || resp.(generated.GetCachePositions200JSONResponse).Count != 1 {
+		t.Errorf("filtered by key: %+v, %v; want one position", resp, err)
+	}
+}
+
+func TestSetCachePosition(t *testing.T) {
+	own := data.CacheKey("SPX", "classic", "gex_full", "a")
+	dated := data.CacheKey("SPX", "classic", "gex_full", data.DatedKey("a", "2025-01-03"))
+	rotating := data.CacheKey("SPX", "classic", "gex_full", "r")
+
+	tests := []struct {
+		name   string
+		body   generated.CachePositionRequest
+		status int
+		want   map[string]int // cache key to index after the call
+	}{
+		{"neither cache_key nor key", generated.CachePositionRequest{Index: ptr(1)}, 400, nil},
+		{"both cache_key and key", generated.CachePositionRequest{CacheKey: ptr(own), Key: ptr("a"), Index: ptr(1)}, 400, nil},
+		{"invalid cache_key", generated.CachePositionRequest{CacheKey: ptr("nope"), Index: ptr(1)}, 400, nil},
+		{"no target", generated.CachePositionRequest{Key: ptr("a")}, 400, nil},
+		{"two targets", generated.CachePositionRequest{Key: ptr("a"), Index: ptr(1), Timestamp: ptr(int64(open1))}, 400, nil},
+		{"negative index", generated.CachePositionRequest{Key: ptr("a"), Index: ptr(-1)}, 400, nil},
+		{"unknown key", generated.CachePositionRequest{Key: ptr("z"), Index: ptr(1)}, 404, nil},
+		{"index by key moves every date", generated.CachePositionRequest{Key: ptr("a"), Index: ptr(1)}, 200,
+			map[string]int{own: 1, dated: 1}},
+		{"index past the end stops at exhaustion", generated.CachePositionRequest{CacheKey: ptr(own), Index: ptr(99)}, 200,
+			map[string]int{own: 5, dated: 0}},
+		{"index past the end wraps in rotation", generated.CachePositionRequest{CacheKey: ptr(rotating), Index: ptr(7)}, 200,
+			map[string]int{rotating: 2}},
+		// open2+60 is past the end of the key's own date
+		{"timestamp on a DatedKey searches its date", generated.CachePositionRequest{CacheKey: ptr(dated), Timestamp: ptr(int64(open2 + 60))}, 200,
+			map[string]int{own: 0, dated: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			s.cache.SetIndex(own, 0)
+			s.cache.SetIndex(dated, 0)
+			s.cache.SetMode(data.ResetFilter{APIKey: "r"}, data.CacheModeRotation)
+			s.cache.SetIndex(rotating, 0)
+
+			body := tt.body
+			resp, err := s.SetCachePosition(context.Background(), generated.SetCachePositionRequestObject{Body: &body})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var status int
+			switch resp.(type) {
+			case generated.SetCachePosition200JSONResponse:
+				status = 200
+			case generated.SetCachePosition400JSONResponse:
+				status = 400
+			case generated.SetCachePosition404JSONResponse:
+				status = 404
+			}
+			if status != tt.status {
+				t.Fatalf("response = %T, want %d", resp, tt.status)
+			}
+			for key, want := range tt.want {
+				if got := s.cache.GetIndex(key); got != want {
+					t.Errorf("GetIndex(%s) = %d, want %d", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUnixSeconds(t *testing.T) {
+	tests := []struct {
+		ts   int64
+		want int64
+	}{
+		{1764340000, 1764340000},
+		{1764340000123, 1764340000},
+		{millisThreshold - 1, millisThreshold - 1},
+		{millisThreshold, millisThreshold / 1000},
+		{0, 0},
+	}
+	for _, tt := range tests {
+		if got := unixSeconds(tt.ts); got != tt.want {
+			t.Errorf("unixSeconds(%d) = %d, want %d", tt.ts, got, tt.want)
+		}
+	}
+}
+
+func TestSeekCache(t *testing.T) {
+	key := data.CacheKey("SPX", "classic", "gex_full", "a")
+
+	tests := []struct {
+		name   string
+		params generated.SeekCacheParams
+		status int
+		want   int
+	}{
+		{"seconds", generated.SeekCacheParams{Key: ptr("a"), Ts: open1 + 120}, 200, 2},
+		{"milliseconds", generated.SeekCacheParams{Key: ptr("a"), Ts: (open1 + 120) * 1000}, 200, 2},
+		{"between records", generated.SeekCacheParams{Key: ptr("a"), Ts: open1 + 90}, 200, 2},
+		{"before the first record", generated.SeekCacheParams{Key: ptr("a"), Ts: open1 - 60}, 200, 0},
+		{"after the last record", generated.SeekCacheParams{Key: ptr("a"), Ts: open2}, 200, 5},
+		{"no selector", generated.SeekCacheParams{Ts: open1}, 400, 3},
+		{"negative ts", generated.SeekCacheParams{Key: ptr("a"), Ts: -1}, 400, 3},
+		{"unknown key", generated.SeekCacheParams{Key: ptr("z"), Ts: open1}, 404, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			s.cache.SetIndex(key, 3)
+
+			resp, err := s.SeekCache(context.Background(), generated.SeekCacheRequestObject{Params: tt.params})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var status int
+			switch resp.(type) {
+			case generated.SeekCache200JSONResponse:
+				status = 200
+			case generated.SeekCache400JSONResponse:
+				status = 400
+			case generated.SeekCache404JSONResponse:
+				status = 404
+			}
+			if status != tt.status {
+				t.Fatalf("response = %T, want %d", resp, tt.status)
+			}
+			if got := s.cache.GetIndex(key); got != tt.want {
+				t.Errorf("index = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
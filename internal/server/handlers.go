@@ -18,8 +18,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/audit"
 	"github.com/dgnsrekt/gexbot-downloader/internal/config"
 	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/maintenance"
+	"github.com/dgnsrekt/gexbot-downloader/internal/stats"
+	"github.com/dgnsrekt/gexbot-downloader/internal/ws"
 )
 
 // Custom response types for GetStateProfile oneOf responses
@@ -46,9 +50,15 @@ type Server struct {
 	logger        *zap.Logger
 	loadedAt      time.Time
 	reloadManager *ReloadManager
+	watchdog      *MemoryWatchdog // nil when the memory watchdog is disabled
+	auditLog      *audit.Logger   // nil when auditing is disabled
+	responses     *ResponseCache  // nil when response caching is disabled
+	maintenance   *maintenance.Controller
+	wsIntervals   *ws.KeyIntervals    // nil when WebSocket streaming is disabled
+	clock         *data.PlaybackClock // nil plays one record per request
 }
 
-func NewServer(loader data.DataLoader, cache *data.IndexCache, cfg *config.ServerConfig, logger *zap.Logger, reloadManager *ReloadManager) *Server {
+func NewServer(loader data.DataLoader, cache *data.IndexCache, cfg *config.ServerConfig, logger *zap.Logger, reloadManager *ReloadManager, watchdog *MemoryWatchdog, auditLog *audit.Logger) *Server {
 	return &Server{
 		loader:        loader,
 		cache:         cache,
@@ -56,17 +66,61 @@ func NewServer(loader data.DataLoader, cache *data.IndexCache, cfg *config.Serve
 		logger:        logger,
 		loadedAt:      time.Now(),
 		reloadManager: reloadManager,
+		watchdog:      watchdog,
+		auditLog:      auditLog,
+		responses:     newResponseCacheFromConfig(cfg),
+		maintenance:   newMaintenanceFromConfig(cfg),
 	}
 }
 
 // Compile-time interface verification
 var _ generated.StrictServerInterface = (*Server)(nil)
 
+// dataFor returns the loader and date serving apiKey, which differ from the
+// loaded date when the key is pinned via /admin/key-dates. A DatedKey
+// resolves to its date while that date is loaded.
+func (s *Server) dataFor(apiKey string) (data.DataLoader, string) {
+	if s.reloadManager == nil {
+		return s.loader, s.config.DataDate
+	}
+	if key, date := data.SplitDatedKey(apiKey); date != "" {
+		if loader, ok := s.reloadManager.ResolveDate(date); ok {
+			return loader, date
+		}
+		apiKey = key
+	}
+	return s.reloadManager.Resolve(apiKey)
+}
+
+// dataForDate is dataFor honoring the date query parameter of data
+// endpoints. It also returns the API key to build cache keys with: a
+// DatedKey when date differs from the key's own, so each date keeps its own
+// playback positions. ok is false when date is not loaded.
+func (s *Server) dataForDate(apiKey string, date *string) (loader data.DataLoader, resolved, positionKey string, ok bool) {
+	loader, resolved = s.dataFor(apiKey)
+	if date == nil || *date == resolved {
+		return loader, resolved, apiKey, true
+	}
+	if s.reloadManager == nil {
+		return nil, "", "", false
+	}
+	if loader, ok = s.reloadManager.ResolveDate(*date); !ok {
+		return nil, "", "", false
+	}
+	return loader, *date, data.DatedKey(apiKey, *date), true
+}
+
 // GetClassicGexMajors implements generated.StrictServerInterface
 func (s *Server) GetClassicGexMajors(ctx context.Context, request generated.GetClassicGexMajorsRequestObject) (generated.GetClassicGexMajorsResponseObject, error) {
 	ticker := request.Ticker
 	aggregation := string(request.Aggregation)
 	apiKey := request.Params.Key
+	loader, date, positionKey, ok := s.dataForDate(apiKey, request.Params.Date)
+	if !ok {
+		return generated.GetClassicGexMajors404JSONResponse{
+			Error: ptr("Date not loaded: " + *request.Params.Date),
+		}, nil
+	}
 
 	// Map aggregation to internal category format
 	category := "gex_" + aggregation // full→gex_full, zero→gex_zero, one→gex_one
@@ -80,14 +134,14 @@ func (s *Server) GetClassicGexMajors(ctx context.Context, request generated.GetC
 	)
 
 	// Check if data exists
-	if !s.loader.Exists(ticker, pkg, category) {
+	if !loader.Exists(ticker, pkg, category) {
 		return generated.GetClassicGexMajors404JSONResponse{
 			Error: ptr("Data not found for " + ticker + "/classic/" + aggregation),
 		}, nil
 	}
 
 	// Get data length
-	length, err := s.loader.GetLength(ticker, pkg, category)
+	length, err := loader.GetLength(ticker, pkg, category)
 	if err != nil {
 		return generated.GetClassicGexMajors404JSONResponse{
 			Error: ptr(err.Error()),
@@ -97,12 +151,13 @@ func (s *Server) GetClassicGexMajors(ctx context.Context, request generated.GetC
 	// Build cache key based on endpoint cache mode
 	var cacheKey string
 	if s.config.EndpointCacheMode == "shared" {
-		cacheKey = data.SharedCacheKey(ticker, pkg, apiKey)
+		cacheKey = data.SharedCacheKey(ticker, pkg, positionKey)
 	} else {
 		// Independent mode - include category with _majors suffix
-		cacheKey = data.CacheKey(ticker, pkg, category+"_majors", apiKey)
+		cacheKey = data.CacheKey(ticker, pkg, category+data.MajorsSuffix, positionKey)
 	}
-	idx, exhausted := s.cache.GetAndAdvance(cacheKey, length)
+	idx, exhausted := s.advance(ctx, loader, ticker, pkg, category, cacheKey, length, request.Params.Mode)
+	audit.SetIndex(ctx, idx)
 
 	if exhausted {
 		s.logger.Debug("data exhausted",
@@ -115,8 +170,14 @@ func (s *Server) GetClassicGexMajors(ctx context.Context, request generated.GetC
 		}, nil
 	}
 
+	// Serve a previously marshalled body for this record if cached
+	respKey := s.responseKey(date, "classic_majors", ticker, pkg, category, idx)
+	if body, ok := s.responses.Get(respKey); ok {
+		return cachedJSONResponse(body), nil
+	}
+
 	// Get data at index
-	gexData, err := s.loader.GetAtIndex(ctx, ticker, pkg, category, idx)
+	gexData, err := loader.GetAtIndex(ctx, ticker, pkg, category, idx)
 	if err != nil {
 		if errors.Is(err, data.ErrIndexOutOfBounds) {
 			return generated.GetClassicGexMajors404JSONResponse{
@@ -134,7 +195,7 @@ func (s *Server) GetClassicGexMajors(ctx context.Context, request generated.GetC
 		zap.Int64("timestamp", gexData.Timestamp),
 	)
 
-	return generated.GetClassicGexMajors200JSONResponse{
+	response := generated.GetClassicGexMajors200JSONResponse{
 		Timestamp: gexData.Timestamp,
 		Ticker:    gexData.Ticker,
 		Spot:      &gexData.Spot,
@@ -145,7 +206,11 @@ func (s *Server) GetClassicGexMajors(ctx context.Context, request generated.GetC
 		ZeroGamma: &gexData.ZeroGamma,
 		NetGexVol: &gexData.SumGexVol,
 		NetGexOi:  &gexData.SumGexOI,
-	}, nil
+	}
+	if body, ok := s.responses.Store(respKey, response); ok {
+		return cachedJSONResponse(body), nil
+	}
+	return response, nil
 }
 
 // GetClassicGexMaxChange implements generated.StrictServerInterface
@@ -153,6 +218,12 @@ func (s *Server) GetClassicGexMaxChange(ctx context.Context, request generated.G
 	ticker := request.Ticker
 	aggregation := string(request.Aggregation)
 	apiKey := request.Params.Key
+	loader, date, positionKey, ok := s.dataForDate(apiKey, request.Params.Date)
+	if !ok {
+		return generated.GetClassicGexMaxChange404JSONResponse{
+			Error: ptr("Date not loaded: " + *request.Params.Date),
+		}, nil
+	}
 
 	// Map aggregation to internal category format
 	category := "gex_" + aggregation // full→gex_full, zero→gex_zero, one→gex_one
@@ -166,14 +237,14 @@ func (s *Server) GetClassicGexMaxChange(ctx context.Context, request generated.G
 	)
 
 	// Check if data exists
-	if !s.loader.Exists(ticker, pkg, category) {
+	if !loader.Exists(ticker, pkg, category) {
 		return generated.GetClassicGexMaxChange404JSONResponse{
 			Error: ptr("Data not found for " + ticker + "/classic/" + aggregation),
 		}, nil
 	}
 
 	// Get data length
-	length, err := s.loader.GetLength(ticker, pkg, category)
+	length, err := loader.GetLength(ticker, pkg, category)
 	if err != nil {
 		return generated.GetClassicGexMaxChange404JSONResponse{
 			Error: ptr(err.Error()),
@@ -183,12 +254,13 @@ func (s *Server) GetClassicGexMaxChange(ctx context.Context, request generated.G
 	// Build cache key based on endpoint cache mode
 	var cacheKey string
 	if s.config.EndpointCacheMode == "shared" {
-		cacheKey = data.SharedCacheKey(ticker, pkg, apiKey)
+		cacheKey = data.SharedCacheKey(ticker, pkg, positionKey)
 	} else {
 		// Independent mode - include category with _maxchange suffix
-		cacheKey = data.CacheKey(ticker, pkg, category+"_maxchange", apiKey)
+		cacheKey = data.CacheKey(ticker, pkg, category+data.MaxChangeSuffix, positionKey)
 	}
-	idx, exhausted := s.cache.GetAndAdvance(cacheKey, length)
+	idx, exhausted := s.advance(ctx, loader, ticker, pkg, category, cacheKey, length, request.Params.Mode)
+	audit.SetIndex(ctx, idx)
 
 	if exhausted {
 		s.logger.Debug("data exhausted",
@@ -201,8 +273,14 @@ func (s *Server) GetClassicGexMaxChange(ctx context.Context, request generated.G
 		}, nil
 	}
 
+	// Serve a previously marshalled body for this record if cached
+	respKey := s.responseKey(date, "classic_maxchange", ticker, pkg, category, idx)
+	if body, ok := s.responses.Get(respKey); ok {
+		return cachedJSONResponse(body), nil
+	}
+
 	// Get data at index
-	gexData, err := s.loader.GetAtIndex(ctx, ticker, pkg, category, idx)
+	gexData, err := loader.GetAtIndex(ctx, ticker, pkg, category, idx)
 	if err != nil {
 		if errors.Is(err, data.ErrIndexOutOfBounds) {
 			return generated.GetClassicGexMaxChange404JSONResponse{
@@ -243,6 +321,9 @@ func (s *Server) GetClassicGexMaxChange(ctx context.Context, request generated.G
 		response.Thirty = &maxPriors[5]
 	}
 
+	if body, ok := s.responses.Store(respKey, response); ok {
+		return cachedJSONResponse(body), nil
+	}
 	return response, nil
 }
 
@@ -251,6 +332,12 @@ func (s *Server) GetClassicGexChain(ctx context.Context, request generated.GetCl
 	ticker := request.Ticker
 	aggregation := string(request.Aggregation)
 	apiKey := request.Params.Key
+	loader, date, positionKey, ok := s.dataForDate(apiKey, request.Params.Date)
+	if !ok {
+		return generated.GetClassicGexChain404JSONResponse{
+			Error: ptr("Date not loaded: " + *request.Params.Date),
+		}, nil
+	}
 
 	// Map aggregation to internal category format
 	category := "gex_" + aggregation // full→gex_full, zero→gex_zero, one→gex_one
@@ -264,14 +351,14 @@ func (s *Server) GetClassicGexChain(ctx context.Context, request generated.GetCl
 	)
 
 	// Check if data exists
-	if !s.loader.Exists(ticker, pkg, category) {
+	if !loader.Exists(ticker, pkg, category) {
 		return generated.GetClassicGexChain404JSONResponse{
 			Error: ptr("Data not found for " + ticker + "/classic/" + aggregation),
 		}, nil
 	}
 
 	// Get data length
-	length, err := s.loader.GetLength(ticker, pkg, category)
+	length, err := loader.GetLength(ticker, pkg, category)
 	if err != nil {
 		return generated.GetClassicGexChain404JSONResponse{
 			Error: ptr(err.Error()),
@@ -281,12 +368,13 @@ func (s *Server) GetClassicGexChain(ctx context.Context, request generated.GetCl
 	// Build cache key based on endpoint cache mode
 	var cacheKey string
 	if s.config.EndpointCacheMode == "shared" {
-		cacheKey = data.SharedCacheKey(ticker, pkg, apiKey)
+		cacheKey = data.SharedCacheKey(ticker, pkg, positionKey)
 	} else {
 		// Independent mode - include category
-		cacheKey = data.CacheKey(ticker, pkg, category, apiKey)
+		cacheKey = data.CacheKey(ticker, pkg, category, positionKey)
 	}
-	idx, exhausted := s.cache.GetAndAdvance(cacheKey, length)
+	idx, exhausted := s.advance(ctx, loader, ticker, pkg, category, cacheKey, length, request.Params.Mode)
+	audit.SetIndex(ctx, idx)
 
 	if exhausted {
 		s.logger.Debug("data exhausted",
@@ -299,8 +387,14 @@ func (s *Server) GetClassicGexChain(ctx context.Context, request generated.GetCl
 		}, nil
 	}
 
+	// Serve a previously marshalled body for this record if cached
+	respKey := s.responseKey(date, "classic_chain", ticker, pkg, category, idx)
+	if body, ok := s.responses.Get(respKey); ok {
+		return cachedJSONResponse(body), nil
+	}
+
 	// Get data at index
-	gexData, err := s.loader.GetAtIndex(ctx, ticker, pkg, category, idx)
+	gexData, err := loader.GetAtIndex(ctx, ticker, pkg, category, idx)
 	if err != nil {
 		if errors.Is(err, data.ErrIndexOutOfBounds) {
 			return generated.GetClassicGexChain404JSONResponse{
@@ -333,7 +427,7 @@ func (s *Server) GetClassicGexChain(ctx context.Context, request generated.GetCl
 		}
 	}
 
-	return generated.GetClassicGexChain200JSONResponse{
+	response := generated.GetClassicGexChain200JSONResponse{
 		Timestamp:         gexData.Timestamp,
 		Ticker:            gexData.Ticker,
 		MinDte:            &gexData.MinDTE,
@@ -349,7 +443,11 @@ func (s *Server) GetClassicGexChain(ctx context.Context, request generated.GetCl
 		SumGexOi:          &gexData.SumGexOI,
 		DeltaRiskReversal: &gexData.DeltaRiskReversal,
 		MaxPriors:         &maxPriors,
-	}, nil
+	}
+	if body, ok := s.responses.Store(respKey, response); ok {
+		return cachedJSONResponse(body), nil
+	}
+	return response, nil
 }
 
 // GetTickers implements generated.StrictServerInterface
@@ -369,13 +467,12 @@ func (s *Server) GetTickers(ctx context.Context, request generated.GetTickersReq
 	stocks := []string{}
 	indexes := []string{}
 	futures := []string{}
-	knownIndexes := map[string]bool{"SPX": true, "VIX": true, "NDX": true, "RUT": true}
 
 	for ticker := range tickerSet {
-		switch {
-		case knownIndexes[ticker]:
+		switch s.config.ClassifyTicker(ticker) {
+		case "indexes":
 			indexes = append(indexes, ticker)
-		case strings.Contains(ticker, "_"):
+		case "futures":
 			futures = append(futures, ticker)
 		default:
 			stocks = append(stocks, ticker)
@@ -394,36 +491,119 @@ func (s *Server) GetTickers(ctx context.Context, request generated.GetTickersReq
 	}, nil
 }
 
+// GetTickersDetail implements generated.StrictServerInterface
+func (s *Server) GetTickersDetail(ctx context.Context, request generated.GetTickersDetailRequestObject) (generated.GetTickersDetailResponseObject, error) {
+	// ticker -> pkg -> categories
+	loaded := make(map[string]map[string][]string)
+	for _, key := range s.loader.GetLoadedKeys() {
+		parts := strings.Split(key, "/")
+		if len(parts) != 3 {
+			continue
+		}
+		ticker, pkg, category := parts[0], parts[1], parts[2]
+		if loaded[ticker] == nil {
+			loaded[ticker] = make(map[string][]string)
+		}
+		loaded[ticker][pkg] = append(loaded[ticker][pkg], category)
+	}
+
+	tickers := make([]string, 0, len(loaded))
+	for ticker := range loaded {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+
+	result := make([]generated.TickerAvailability, 0, len(tickers))
+	for _, ticker := range tickers {
+		pkgs := make([]string, 0, len(loaded[ticker]))
+		for pkg := range loaded[ticker] {
+			pkgs = append(pkgs, pkg)
+		}
+		sort.Strings(pkgs)
+
+		packages := make([]generated.PackageAvailability, 0, len(pkgs))
+		for _, pkg := range pkgs {
+			categories := loaded[ticker][pkg]
+			sort.Strings(categories)
+			packages = append(packages, generated.PackageAvailability{
+				Package:    pkg,
+				Categories: categories,
+			})
+		}
+
+		result = append(result, generated.TickerAvailability{
+			Ticker:   ticker,
+			Type:     generated.TickerAvailabilityType(s.config.ClassifyTicker(ticker)),
+			Packages: packages,
+		})
+	}
+
+	return generated.GetTickersDetail200JSONResponse{
+		Tickers: result,
+	}, nil
+}
+
 // GetHealth implements generated.StrictServerInterface
 func (s *Server) GetHealth(ctx context.Context, request generated.GetHealthRequestObject) (generated.GetHealthResponseObject, error) {
 	status := "ok"
 	dataMode := generated.HealthResponseDataMode(s.config.DataMode)
-	cacheMode := generated.HealthResponseCacheMode(s.config.CacheMode)
-	return generated.GetHealth200JSONResponse{
+	cacheMode := generated.HealthResponseCacheMode(s.cache.GetMode())
+	counters := stats.Read()
+	// Pinned keys see the date they replay
+	_, dataDate := s.dataFor(derefString(request.Params.Key))
+	response := generated.GetHealth200JSONResponse{
 		Status:    &status,
-		DataDate:  &s.config.DataDate,
+		DataDate:  &dataDate,
 		DataMode:  &dataMode,
 		CacheMode: &cacheMode,
-	}, nil
+		Errors: &generated.ErrorCounters{
+			RecoveredPanics: &counters.RecoveredPanics,
+			HandlerErrors:   &counters.HandlerErrors,
+			EncodeFailures:  &counters.EncodeFailures,
+		},
+	}
+
+	if s.watchdog != nil {
+		mem := s.watchdog.Status()
+		if mem.Degraded {
+			status = "degraded"
+		}
+		response.Memory = &generated.MemoryStatus{
+			RssBytes:         ptr(int64(mem.RSSBytes)),
+			LimitBytes:       ptr(int64(mem.LimitBytes)),
+			Degraded:         &mem.Degraded,
+			EvictedKeys:      &mem.EvictedKeys,
+			StreamModeForced: ptr(s.reloadManager != nil && s.reloadManager.StreamModeForced()),
+		}
+	}
+
+	return response, nil
 }
 
 // ResetCache implements generated.StrictServerInterface
 func (s *Server) ResetCache(ctx context.Context, request generated.ResetCacheRequestObject) (generated.ResetCacheResponseObject, error) {
-	apiKey := ""
-	if request.Params.Key != nil {
-		apiKey = *request.Params.Key
+	filter := data.ResetFilter{
+		APIKey:   derefString(request.Params.Key),
+		Ticker:   derefString(request.Params.Ticker),
+		Package:  derefString(request.Params.Package),
+		Category: derefString(request.Params.Category),
+		Prefix:   derefString(request.Params.Prefix),
 	}
 
-	count := s.cache.Reset(apiKey)
+	count := s.cache.ResetMatching(filter)
 
 	status := "success"
 	message := "All cache positions reset to index 0"
-	if apiKey != "" {
-		message = "Cache positions reset for key: " + maskAPIKey(apiKey)
+	if !filter.IsEmpty() {
+		message = "Cache positions reset for " + describeResetFilter(filter)
 	}
 
 	s.logger.Info("cache reset",
-		zap.String("apiKey", maskAPIKey(apiKey)),
+		zap.String("apiKey", maskAPIKey(filter.APIKey)),
+		zap.String("ticker", filter.Ticker),
+		zap.String("package", filter.Package),
+		zap.String("category", filter.Category),
+		zap.String("prefix", filter.Prefix),
 		zap.Int("count", count),
 	)
 
@@ -434,6 +614,27 @@ func (s *Server) ResetCache(ctx context.Context, request generated.ResetCacheReq
 	}, nil
 }
 
+// describeResetFilter renders the non-empty filter fields, masking the API key.
+func describeResetFilter(f data.ResetFilter) string {
+	var parts []string
+	if f.APIKey != "" {
+		parts = append(parts, "key: "+maskAPIKey(f.APIKey))
+	}
+	if f.Ticker != "" {
+		parts = append(parts, "ticker: "+f.Ticker)
+	}
+	if f.Package != "" {
+		parts = append(parts, "package: "+f.Package)
+	}
+	if f.Category != "" {
+		parts = append(parts, "category: "+f.Category)
+	}
+	if f.Prefix != "" {
+		parts = append(parts, "prefix: "+f.Prefix)
+	}
+	return strings.Join(parts, ", ")
+}
+
 // Type classification helpers
 var aggregationTypes = map[string]bool{"full": true, "zero": true, "one": true}
 var greekTypes = map[string]bool{
@@ -447,6 +648,12 @@ func (s *Server) GetStateProfile(ctx context.Context, request generated.GetState
 	ticker := request.Ticker
 	typeParam := string(request.Type)
 	apiKey := request.Params.Key
+	loader, date, positionKey, ok := s.dataForDate(apiKey, request.Params.Date)
+	if !ok {
+		return generated.GetStateProfile404JSONResponse{
+			Error: ptr("Date not loaded: " + *request.Params.Date),
+		}, nil
+	}
 	pkg := "state"
 
 	s.logger.Debug("state profile request",
@@ -469,14 +676,14 @@ func (s *Server) GetStateProfile(ctx context.Context, request generated.GetState
 	}
 
 	// Check if data exists
-	if !s.loader.Exists(ticker, pkg, category) {
+	if !loader.Exists(ticker, pkg, category) {
 		return generated.GetStateProfile404JSONResponse{
 			Error: ptr("Data not found for " + ticker + "/state/" + typeParam),
 		}, nil
 	}
 
 	// Get data length
-	length, err := s.loader.GetLength(ticker, pkg, category)
+	length, err := loader.GetLength(ticker, pkg, category)
 	if err != nil {
 		return generated.GetStateProfile404JSONResponse{
 			Error: ptr(err.Error()),
@@ -486,14 +693,15 @@ func (s *Server) GetStateProfile(ctx context.Context, request generated.GetState
 	// Build cache key based on endpoint cache mode
 	var cacheKey string
 	if s.config.EndpointCacheMode == "shared" {
-		cacheKey = data.SharedCacheKey(ticker, pkg, apiKey)
+		cacheKey = data.SharedCacheKey(ticker, pkg, positionKey)
 	} else {
 		// Independent mode - include category
-		cacheKey = data.CacheKey(ticker, pkg, category, apiKey)
+		cacheKey = data.CacheKey(ticker, pkg, category, positionKey)
 	}
 
 	// Get index and check exhaustion
-	idx, exhausted := s.cache.GetAndAdvance(cacheKey, length)
+	idx, exhausted := s.advance(ctx, loader, ticker, pkg, category, cacheKey, length, request.Params.Mode)
+	audit.SetIndex(ctx, idx)
 
 	if exhausted {
 		s.logger.Debug("data exhausted",
@@ -506,8 +714,14 @@ func (s *Server) GetStateProfile(ctx context.Context, request generated.GetState
 		}, nil
 	}
 
+	// Serve a previously marshalled body for this record if cached
+	respKey := s.responseKey(date, "state_profile", ticker, pkg, category, idx)
+	if body, ok := s.responses.Get(respKey); ok {
+		return cachedJSONResponse(body), nil
+	}
+
 	// Get raw data at index
-	rawData, err := s.loader.GetRawAtIndex(ctx, ticker, pkg, category, idx)
+	rawData, err := loader.GetRawAtIndex(ctx, ticker, pkg, category, idx)
 	if err != nil {
 		if errors.Is(err, data.ErrIndexOutOfBounds) {
 			return generated.GetStateProfile404JSONResponse{
@@ -543,7 +757,7 @@ func (s *Server) GetStateProfile(ctx context.Context, request generated.GetState
 			}
 		}
 
-		return stateProfileGreekDataResponse{
+		response := stateProfileGreekDataResponse{
 			Timestamp:       greekData.Timestamp,
 			Ticker:          greekData.Ticker,
 			Spot:            &greekData.Spot,
@@ -554,7 +768,11 @@ func (s *Server) GetStateProfile(ctx context.Context, request generated.GetState
 			MajorLongGamma:  &greekData.MajorLongGamma,
 			MajorShortGamma: &greekData.MajorShortGamma,
 			MiniContracts:   &miniContracts,
-		}, nil
+		}
+		if body, ok := s.responses.Store(respKey, response); ok {
+			return cachedJSONResponse(body), nil
+		}
+		return response, nil
 	}
 
 	// Parse into GexData and build GexData response
@@ -580,7 +798,7 @@ func (s *Server) GetStateProfile(ctx context.Context, request generated.GetState
 		}
 	}
 
-	return stateProfileGexDataResponse{
+	response := stateProfileGexDataResponse{
 		Timestamp:         gexData.Timestamp,
 		Ticker:            gexData.Ticker,
 		MinDte:            &gexData.MinDTE,
@@ -596,7 +814,11 @@ func (s *Server) GetStateProfile(ctx context.Context, request generated.GetState
 		SumGexOi:          &gexData.SumGexOI,
 		DeltaRiskReversal: &gexData.DeltaRiskReversal,
 		MaxPriors:         &maxPriors,
-	}, nil
+	}
+	if body, ok := s.responses.Store(respKey, response); ok {
+		return cachedJSONResponse(body), nil
+	}
+	return response, nil
 }
 
 // GetStateGexMajors implements generated.StrictServerInterface
@@ -604,6 +826,12 @@ func (s *Server) GetStateGexMajors(ctx context.Context, request generated.GetSta
 	ticker := request.Ticker
 	typeParam := string(request.Type)
 	apiKey := request.Params.Key
+	loader, date, positionKey, ok := s.dataForDate(apiKey, request.Params.Date)
+	if !ok {
+		return generated.GetStateGexMajors404JSONResponse{
+			Error: ptr("Date not loaded: " + *request.Params.Date),
+		}, nil
+	}
 
 	// Map type to internal category format
 	category := "gex_" + typeParam // full→gex_full, zero→gex_zero, one→gex_one
@@ -617,14 +845,14 @@ func (s *Server) GetStateGexMajors(ctx context.Context, request generated.GetSta
 	)
 
 	// Check if data exists
-	if !s.loader.Exists(ticker, pkg, category) {
+	if !loader.Exists(ticker, pkg, category) {
 		return generated.GetStateGexMajors404JSONResponse{
 			Error: ptr("Data not found for " + ticker + "/state/" + typeParam),
 		}, nil
 	}
 
 	// Get data length
-	length, err := s.loader.GetLength(ticker, pkg, category)
+	length, err := loader.GetLength(ticker, pkg, category)
 	if err != nil {
 		return generated.GetStateGexMajors404JSONResponse{
 			Error: ptr(err.Error()),
@@ -634,14 +862,15 @@ func (s *Server) GetStateGexMajors(ctx context.Context, request generated.GetSta
 	// Build cache key based on endpoint cache mode
 	var cacheKey string
 	if s.config.EndpointCacheMode == "shared" {
-		cacheKey = data.SharedCacheKey(ticker, pkg, apiKey)
+		cacheKey = data.SharedCacheKey(ticker, pkg, positionKey)
 	} else {
 		// Independent mode - include category with _majors suffix
-		cacheKey = data.CacheKey(ticker, pkg, category+"_majors", apiKey)
+		cacheKey = data.CacheKey(ticker, pkg, category+data.MajorsSuffix, positionKey)
 	}
 
 	// Get index and check exhaustion
-	idx, exhausted := s.cache.GetAndAdvance(cacheKey, length)
+	idx, exhausted := s.advance(ctx, loader, ticker, pkg, category, cacheKey, length, request.Params.Mode)
+	audit.SetIndex(ctx, idx)
 
 	if exhausted {
 		s.logger.Debug("data exhausted",
@@ -654,8 +883,14 @@ func (s *Server) GetStateGexMajors(ctx context.Context, request generated.GetSta
 		}, nil
 	}
 
+	// Serve a previously marshalled body for this record if cached
+	respKey := s.responseKey(date, "state_majors", ticker, pkg, category, idx)
+	if body, ok := s.responses.Get(respKey); ok {
+		return cachedJSONResponse(body), nil
+	}
+
 	// Get data at index
-	gexData, err := s.loader.GetAtIndex(ctx, ticker, pkg, category, idx)
+	gexData, err := loader.GetAtIndex(ctx, ticker, pkg, category, idx)
 	if err != nil {
 		if errors.Is(err, data.ErrIndexOutOfBounds) {
 			return generated.GetStateGexMajors404JSONResponse{
@@ -673,7 +908,7 @@ func (s *Server) GetStateGexMajors(ctx context.Context, request generated.GetSta
 		zap.Int64("timestamp", gexData.Timestamp),
 	)
 
-	return generated.GetStateGexMajors200JSONResponse{
+	response := generated.GetStateGexMajors200JSONResponse{
 		Timestamp: gexData.Timestamp,
 		Ticker:    gexData.Ticker,
 		Spot:      &gexData.Spot,
@@ -684,7 +919,11 @@ func (s *Server) GetStateGexMajors(ctx context.Context, request generated.GetSta
 		ZeroGamma: &gexData.ZeroGamma,
 		NetGexVol: &gexData.SumGexVol,
 		NetGexOi:  &gexData.SumGexOI,
-	}, nil
+	}
+	if body, ok := s.responses.Store(respKey, response); ok {
+		return cachedJSONResponse(body), nil
+	}
+	return response, nil
 }
 
 // GetStateGexMaxChange implements generated.StrictServerInterface
@@ -692,6 +931,12 @@ func (s *Server) GetStateGexMaxChange(ctx context.Context, request generated.Get
 	ticker := request.Ticker
 	typeParam := string(request.Type)
 	apiKey := request.Params.Key
+	loader, date, positionKey, ok := s.dataForDate(apiKey, request.Params.Date)
+	if !ok {
+		return generated.GetStateGexMaxChange404JSONResponse{
+			Error: ptr("Date not loaded: " + *request.Params.Date),
+		}, nil
+	}
 
 	// Map type to internal category format
 	category := "gex_" + typeParam // full→gex_full, zero→gex_zero, one→gex_one
@@ -705,14 +950,14 @@ func (s *Server) GetStateGexMaxChange(ctx context.Context, request generated.Get
 	)
 
 	// Check if data exists
-	if !s.loader.Exists(ticker, pkg, category) {
+	if !loader.Exists(ticker, pkg, category) {
 		return generated.GetStateGexMaxChange404JSONResponse{
 			Error: ptr("Data not found for " + ticker + "/state/" + typeParam),
 		}, nil
 	}
 
 	// Get data length
-	length, err := s.loader.GetLength(ticker, pkg, category)
+	length, err := loader.GetLength(ticker, pkg, category)
 	if err != nil {
 		return generated.GetStateGexMaxChange404JSONResponse{
 			Error: ptr(err.Error()),
@@ -722,14 +967,15 @@ func (s *Server) GetStateGexMaxChange(ctx context.Context, request generated.Get
 	// Build cache key based on endpoint cache mode
 	var cacheKey string
 	if s.config.EndpointCacheMode == "shared" {
-		cacheKey = data.SharedCacheKey(ticker, pkg, apiKey)
+		cacheKey = data.SharedCacheKey(ticker, pkg, positionKey)
 	} else {
 		// Independent mode - include category with _maxchange suffix
-		cacheKey = data.CacheKey(ticker, pkg, category+"_maxchange", apiKey)
+		cacheKey = data.CacheKey(ticker, pkg, category+data.MaxChangeSuffix, positionKey)
 	}
 
 	// Get index and check exhaustion
-	idx, exhausted := s.cache.GetAndAdvance(cacheKey, length)
+	idx, exhausted := s.advance(ctx, loader, ticker, pkg, category, cacheKey, length, request.Params.Mode)
+	audit.SetIndex(ctx, idx)
 
 	if exhausted {
 		s.logger.Debug("data exhausted",
@@ -742,8 +988,14 @@ func (s *Server) GetStateGexMaxChange(ctx context.Context, request generated.Get
 		}, nil
 	}
 
+	// Serve a previously marshalled body for this record if cached
+	respKey := s.responseKey(date, "state_maxchange", ticker, pkg, category, idx)
+	if body, ok := s.responses.Get(respKey); ok {
+		return cachedJSONResponse(body), nil
+	}
+
 	// Get data at index
-	gexData, err := s.loader.GetAtIndex(ctx, ticker, pkg, category, idx)
+	gexData, err := loader.GetAtIndex(ctx, ticker, pkg, category, idx)
 	if err != nil {
 		if errors.Is(err, data.ErrIndexOutOfBounds) {
 			return generated.GetStateGexMaxChange404JSONResponse{
@@ -784,6 +1036,9 @@ func (s *Server) GetStateGexMaxChange(ctx context.Context, request generated.Get
 		response.Thirty = &maxPriors[5]
 	}
 
+	if body, ok := s.responses.Store(respKey, response); ok {
+		return cachedJSONResponse(body), nil
+	}
 	return response, nil
 }
 
@@ -791,6 +1046,12 @@ func (s *Server) GetStateGexMaxChange(ctx context.Context, request generated.Get
 func (s *Server) GetOrderflowLatest(ctx context.Context, request generated.GetOrderflowLatestRequestObject) (generated.GetOrderflowLatestResponseObject, error) {
 	ticker := request.Ticker
 	apiKey := request.Params.Key
+	loader, date, positionKey, ok := s.dataForDate(apiKey, request.Params.Date)
+	if !ok {
+		return generated.GetOrderflowLatest404JSONResponse{
+			Error: ptr("Date not loaded: " + *request.Params.Date),
+		}, nil
+	}
 	pkg := "orderflow"
 	category := "orderflow"
 
@@ -800,14 +1061,14 @@ func (s *Server) GetOrderflowLatest(ctx context.Context, request generated.GetOr
 	)
 
 	// Check if data exists
-	if !s.loader.Exists(ticker, pkg, category) {
+	if !loader.Exists(ticker, pkg, category) {
 		return generated.GetOrderflowLatest404JSONResponse{
 			Error: ptr("Data not found for " + ticker + "/orderflow/orderflow"),
 		}, nil
 	}
 
 	// Get data length
-	length, err := s.loader.GetLength(ticker, pkg, category)
+	length, err := loader.GetLength(ticker, pkg, category)
 	if err != nil {
 		return generated.GetOrderflowLatest404JSONResponse{
 			Error: ptr(err.Error()),
@@ -817,12 +1078,13 @@ func (s *Server) GetOrderflowLatest(ctx context.Context, request generated.GetOr
 	// Build cache key based on endpoint cache mode
 	var cacheKey string
 	if s.config.EndpointCacheMode == "shared" {
-		cacheKey = data.SharedCacheKey(ticker, pkg, apiKey)
+		cacheKey = data.SharedCacheKey(ticker, pkg, positionKey)
 	} else {
-		cacheKey = data.CacheKey(ticker, pkg, category, apiKey)
+		cacheKey = data.CacheKey(ticker, pkg, category, positionKey)
 	}
 
-	idx, exhausted := s.cache.GetAndAdvance(cacheKey, length)
+	idx, exhausted := s.advance(ctx, loader, ticker, pkg, category, cacheKey, length, request.Params.Mode)
+	audit.SetIndex(ctx, idx)
 
 	if exhausted {
 		s.logger.Debug("data exhausted",
@@ -835,8 +1097,14 @@ func (s *Server) GetOrderflowLatest(ctx context.Context, request generated.GetOr
 		}, nil
 	}
 
+	// Serve a previously marshalled body for this record if cached
+	respKey := s.responseKey(date, "orderflow", ticker, pkg, category, idx)
+	if body, ok := s.responses.Get(respKey); ok {
+		return cachedJSONResponse(body), nil
+	}
+
 	// Get raw data and parse
-	rawData, err := s.loader.GetRawAtIndex(ctx, ticker, pkg, category, idx)
+	rawData, err := loader.GetRawAtIndex(ctx, ticker, pkg, category, idx)
 	if err != nil {
 		if errors.Is(err, data.ErrIndexOutOfBounds) {
 			return generated.GetOrderflowLatest404JSONResponse{
@@ -862,7 +1130,16 @@ func (s *Server) GetOrderflowLatest(ctx context.Context, request generated.GetOr
 		zap.Int64("timestamp", ofData.Timestamp),
 	)
 
-	return generated.GetOrderflowLatest200JSONResponse{
+	response := generated.GetOrderflowLatest200JSONResponse(orderflowRecord(ofData))
+	if body, ok := s.responses.Store(respKey, response); ok {
+		return cachedJSONResponse(body), nil
+	}
+	return response, nil
+}
+
+// orderflowRecord converts parsed orderflow data to its API representation.
+func orderflowRecord(ofData data.OrderflowData) generated.OrderflowData {
+	return generated.OrderflowData{
 		Timestamp:     ofData.Timestamp,
 		Ticker:        ofData.Ticker,
 		Spot:          &ofData.Spot,
@@ -900,11 +1177,19 @@ func (s *Server) GetOrderflowLatest(ctx context.Context, request generated.GetOr
 		OneDexoflow:   f32ptr(ofData.OneDexoflow),
 		OneGexoflow:   f32ptr(ofData.OneGexoflow),
 		OneCvroflow:   f32ptr(ofData.OneCvroflow),
-	}, nil
+	}
 }
 
 func ptr[T any](v T) *T { return &v }
 
+// derefString returns the value of an optional string parameter, or "".
+func derefString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
 // f32ptr converts float64 to *float32 for OpenAPI response fields
 func f32ptr(v float64) *float32 {
 	f := float32(v)
@@ -922,7 +1207,7 @@ func maskAPIKey(key string) string {
 // maskCacheKey masks the API key portion of a cache key (format: ticker/pkg/category/apiKey)
 func maskCacheKey(cacheKey string) string {
 	parts := strings.Split(cacheKey, "/")
-	if len(parts) >= 4 {
+	if len(parts) >= 3 {
 		parts[len(parts)-1] = maskAPIKey(parts[len(parts)-1])
 		return strings.Join(parts, "/")
 	}
@@ -1061,6 +1346,8 @@ func (s *Server) GetAvailableData(ctx context.Context, request generated.GetAvai
 				packageName = generated.State
 			case "orderflow":
 				packageName = generated.Orderflow
+			case "volatility":
+				packageName = generated.Volatility
 			default:
 				continue
 			}
@@ -1078,8 +1365,7 @@ func (s *Server) GetAvailableData(ctx context.Context, request generated.GetAvai
 					continue
 				}
 				fileName := catEntry.Name()
-				if strings.HasSuffix(fileName, ".jsonl") {
-					category := strings.TrimSuffix(fileName, ".jsonl")
+				if category, ok := data.JSONLCategory(fileName); ok {
 					categories = append(categories, category)
 					totalFiles++
 				}
@@ -1131,23 +1417,28 @@ type downloadFileResponse struct {
 	filename string
 }
 
+// Compressed .jsonl.zst and encrypted files are served decoded, without a
+// Content-Length.
 func (r *downloadFileResponse) serveFile(w http.ResponseWriter) error {
-	file, err := os.Open(r.filePath)
+	stat, err := os.Stat(r.filePath)
 	if err != nil {
-		http.Error(w, "Failed to open file", http.StatusInternalServerError)
+		http.Error(w, "Failed to stat file", http.StatusInternalServerError)
 		return err
 	}
-	defer func() { _ = file.Close() }()
 
-	stat, err := file.Stat()
+	file, err := data.OpenJSONL(r.filePath)
 	if err != nil {
-		http.Error(w, "Failed to stat file", http.StatusInternalServerError)
+		http.Error(w, "Failed to open file", http.StatusInternalServerError)
 		return err
 	}
+	defer func() { _ = file.Close() }()
 
 	w.Header().Set("Content-Type", "application/x-ndjson")
 	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, r.filename))
-	w.Header().Set("Content-Length", strconv.FormatInt(stat.Size(), 10))
+	encrypted, _ := data.IsEncrypted(r.filePath)
+	if !strings.HasSuffix(r.filePath, data.JSONLZstdExt) && !encrypted {
+		w.Header().Set("Content-Length", strconv.FormatInt(stat.Size(), 10))
+	}
 	w.WriteHeader(http.StatusOK)
 
 	_, err = io.Copy(w, file)
@@ -1171,15 +1462,16 @@ func (s *Server) DownloadClassicGex(ctx context.Context, request generated.Downl
 
 	// Construct file path: {DataDir}/{date}/{ticker}/classic/gex_{aggregation}.jsonl
 	category := "gex_" + aggregation
-	filePath := filepath.Join(s.config.DataDir, date, ticker, "classic", category+".jsonl")
+	base := filepath.Join(s.config.DataDir, date, ticker, "classic", category)
+	filePath, ok := data.FindJSONL(base)
 
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	// Check if file exists (plain or zstd-compressed)
+	if !ok {
 		s.logger.Warn("download file not found",
 			zap.String("date", date),
 			zap.String("ticker", ticker),
 			zap.String("aggregation", aggregation),
-			zap.String("filePath", filePath),
+			zap.String("filePath", base+data.JSONLExt),
 		)
 		return generated.DownloadClassicGex404JSONResponse{
 			Error: ptr(fmt.Sprintf("File not found: %s/%s/classic/%s.jsonl", date, ticker, category)),
@@ -1227,15 +1519,16 @@ func (s *Server) DownloadStateData(ctx context.Context, request generated.Downlo
 	}
 
 	// Construct file path: {DataDir}/{date}/{ticker}/state/{category}.jsonl
-	filePath := filepath.Join(s.config.DataDir, date, ticker, "state", category+".jsonl")
+	base := filepath.Join(s.config.DataDir, date, ticker, "state", category)
+	filePath, ok := data.FindJSONL(base)
 
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	// Check if file exists (plain or zstd-compressed)
+	if !ok {
 		s.logger.Warn("download file not found",
 			zap.String("date", date),
 			zap.String("ticker", ticker),
 			zap.String("type", typeParam),
-			zap.String("filePath", filePath),
+			zap.String("filePath", base+data.JSONLExt),
 		)
 		return generated.DownloadStateData404JSONResponse{
 			Error: ptr(fmt.Sprintf("File not found: %s/%s/state/%s.jsonl", date, ticker, category)),
@@ -1270,14 +1563,15 @@ func (s *Server) DownloadOrderflow(ctx context.Context, request generated.Downlo
 	ticker := request.Ticker
 
 	// Construct file path: {DataDir}/{date}/{ticker}/orderflow/orderflow.jsonl
-	filePath := filepath.Join(s.config.DataDir, date, ticker, "orderflow", "orderflow.jsonl")
+	base := filepath.Join(s.config.DataDir, date, ticker, "orderflow", "orderflow")
+	filePath, ok := data.FindJSONL(base)
 
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	// Check if file exists (plain or zstd-compressed)
+	if !ok {
 		s.logger.Warn("download file not found",
 			zap.String("date", date),
 			zap.String("ticker", ticker),
-			zap.String("filePath", filePath),
+			zap.String("filePath", base+data.JSONLExt),
 		)
 		return generated.DownloadOrderflow404JSONResponse{
 			Error: ptr(fmt.Sprintf("File not found: %s/%s/orderflow/orderflow.jsonl", date, ticker)),
@@ -1315,6 +1609,8 @@ func buildDownloadPath(date, ticker, pkg, category string) string {
 		return fmt.Sprintf("/download/%s/%s/state/%s", date, ticker, categoryToPathParam(pkg, category))
 	case "orderflow":
 		return fmt.Sprintf("/download/%s/%s/orderflow", date, ticker)
+	case "volatility":
+		return fmt.Sprintf("/download/%s/%s/volatility/%s", date, ticker, category)
 	}
 	return ""
 }
@@ -1356,7 +1652,7 @@ func (s *Server) GetDownloadLinks(ctx context.Context, request generated.GetDown
 		pkgName := pkgEntry.Name()
 
 		// Only process known packages
-		if pkgName != "classic" && pkgName != "state" && pkgName != "orderflow" {
+		if pkgName != "classic" && pkgName != "state" && pkgName != "orderflow" && pkgName != "volatility" {
 			continue
 		}
 
@@ -1376,11 +1672,11 @@ func (s *Server) GetDownloadLinks(ctx context.Context, request generated.GetDown
 				continue
 			}
 			fileName := catEntry.Name()
-			if !strings.HasSuffix(fileName, ".jsonl") {
+			category, ok := data.JSONLCategory(fileName)
+			if !ok {
 				continue
 			}
 
-			category := strings.TrimSuffix(fileName, ".jsonl")
 			path := buildDownloadPath(date, ticker, pkgName, category)
 
 			pkgLinks = append(pkgLinks, path)
@@ -1474,3 +1770,134 @@ func (s *Server) ReloadDate(ctx context.Context, request generated.ReloadDateReq
 		FilesLoaded:  &result.FilesLoaded,
 	}, nil
 }
+
+// AdminReload implements generated.StrictServerInterface. The admin token
+// is checked by adminTokenMiddleware.
+func (s *Server) AdminReload(ctx context.Context, request generated.AdminReloadRequestObject) (generated.AdminReloadResponseObject, error) {
+	if s.reloadManager == nil {
+		return generated.AdminReload500JSONResponse{
+			Error: ptr("Reload not available: server not configured for hot reload"),
+		}, nil
+	}
+
+	newDate, err := config.ResolveDataDate(s.config.DataDir, request.Body.Date, time.Now())
+	if err != nil {
+		return generated.AdminReload400JSONResponse{Error: ptr(err.Error())}, nil
+	}
+
+	s.logger.Info("admin reload request",
+		zap.String("date", request.Body.Date),
+		zap.String("currentDate", s.reloadManager.CurrentDate()),
+		zap.String("newDate", newDate),
+	)
+
+	result, err := s.reloadManager.Reload(ctx, newDate)
+	if err != nil {
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "already in progress") {
+			return generated.AdminReload409JSONResponse{Error: ptr(errMsg)}, nil
+		}
+		if strings.Contains(errMsg, "not found") || strings.Contains(errMsg, "invalid date format") {
+			return generated.AdminReload400JSONResponse{Error: ptr(errMsg)}, nil
+		}
+		return generated.AdminReload500JSONResponse{Error: ptr(errMsg)}, nil
+	}
+
+	s.loadedAt = result.LoadedAt
+	status := "success"
+	return generated.AdminReload200JSONResponse{
+		Status:       &status,
+		PreviousDate: &result.PreviousDate,
+		NewDate:      &result.NewDate,
+		LoadedAt:     &result.LoadedAt,
+		FilesLoaded:  &result.FilesLoaded,
+	}, nil
+}
+
+// GetPreflightReport implements generated.StrictServerInterface
+func (s *Server) GetPreflightReport(ctx context.Context, request generated.GetPreflightReportRequestObject) (generated.GetPreflightReportResponseObject, error) {
+	var report *PreflightReport
+	if s.reloadManager != nil {
+		report = s.reloadManager.Preflight()
+	}
+	if report == nil {
+		report = BuildPreflightReport(s.config.DataDir, s.config.DataDate, s.loader, false)
+	}
+
+	// Initialize as empty slices (not nil) for consistent JSON
+	response := generated.GetPreflightReport200JSONResponse{
+		Ok:                 report.OK(),
+		Date:               report.Date,
+		GeneratedAt:        report.GeneratedAt,
+		FilesFound:         report.FilesFound,
+		KeysLoaded:         report.KeysLoaded,
+		EmptyFiles:         append([]string{}, report.EmptyFiles...),
+		NotLoaded:          append([]string{}, report.NotLoaded...),
+		ParseFailures:      []generated.PreflightParseFailure{},
+		MissingCategories:  []generated.PreflightMissing{},
+		ManifestMismatches: []generated.PreflightManifestMismatch{},
+	}
+
+	for _, f := range report.ParseFailures {
+		failure := generated.PreflightParseFailure{File: f.File, Error: f.Error}
+		if f.Line > 0 {
+			failure.Line = ptr(f.Line)
+		}
+		response.ParseFailures = append(response.ParseFailures, failure)
+	}
+	for _, m := range report.MissingCategories {
+		response.MissingCategories = append(response.MissingCategories, generated.PreflightMissing{
+			Ticker:     m.Ticker,
+			Package:    m.Package,
+			Categories: m.Categories,
+		})
+	}
+	for _, m := range report.ManifestMismatches {
+		response.ManifestMismatches = append(response.ManifestMismatches, generated.PreflightManifestMismatch{
+			File:  m.File,
+			Error: m.Error,
+		})
+	}
+
+	return response, nil
+}
+
+// GetAuditLog implements generated.StrictServerInterface
+func (s *Server) GetAuditLog(ctx context.Context, request generated.GetAuditLogRequestObject) (generated.GetAuditLogResponseObject, error) {
+	if s.auditLog == nil {
+		return generated.GetAuditLog404JSONResponse{
+			Error: ptr("audit logging is disabled (set AUDIT_ENABLED=true)"),
+		}, nil
+	}
+
+	apiKey := ""
+	if request.Params.ApiKey != nil {
+		apiKey = *request.Params.ApiKey
+	}
+	limit := 100
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+
+	records := s.auditLog.Query(apiKey, limit)
+	entries := make([]generated.AuditEntry, 0, len(records))
+	for _, r := range records {
+		entry := generated.AuditEntry{
+			Time:     r.Time,
+			Kind:     generated.AuditEntryKind(r.Kind),
+			ApiKey:   r.APIKey,
+			KeyHash:  r.KeyHash,
+			Endpoint: r.Endpoint,
+			Index:    r.Index,
+		}
+		if r.Status != 0 {
+			entry.Status = ptr(r.Status)
+		}
+		entries = append(entries, entry)
+	}
+
+	return generated.GetAuditLog200JSONResponse{
+		Count:   len(entries),
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// writeRecords writes one SPX record per timestamp to date/SPX/pkg/category.
+func writeRecords(t *testing.T, dir, date, pkg, category string, timestamps ...int64) {
+	t.Helper()
+	pkgDir := filepath.Join(dir, date, "SPX", pkg)
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	for _, ts := range timestamps {
+		fmt.Fprintf(&b, `{"timestamp":%d,"ticker":"SPX"}`+"\n", ts)
+	}
+	if err := os.WriteFile(filepath.Join(pkgDir, category+".jsonl"), []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Market open of the test server's dates, in Unix seconds.
+const (
+	open1 = 1735828200 // 2025-01-02 09:30 New York
+	open2 = open1 + 86400
+)
+
+// minutes returns one timestamp a minute from open.
+func minutes(open int64, n int) []int64 {
+	ts := make([]int64, n)
+	for i := range ts {
+		ts[i] = open + int64(i)*60
+	}
+	return ts
+}
+
+// newTestServer serves 2025-01-02 from memory with 2025-01-03 preloaded for
+// the date parameter and DatedKey positions. Records are a minute apart from
+// the open: on 2025-01-02 five of classic/gex_full and three each of
+// classic/gex_zero and orderflow, on 2025-01-03 three of classic/gex_full
+// and orderflow.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	dir := t.TempDir()
+	writeRecords(t, dir, "2025-01-02", "classic", "gex_full", minutes(open1, 5)...)
+	writeRecords(t, dir, "2025-01-02", "classic", "gex_zero", minutes(open1, 3)...)
+	writeRecords(t, dir, "2025-01-02", "orderflow", "orderflow", minutes(open1, 3)...)
+	writeRecords(t, dir, "2025-01-03", "classic", "gex_full", minutes(open2, 3)...)
+	writeRecords(t, dir, "2025-01-03", "orderflow", "orderflow", minutes(open2, 3)...)
+
+	initial, err := data.NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.ServerConfig{DataDir: dir, DataDate: "2025-01-02", DataMode: "memory"}
+	cache := data.NewIndexCache(data.CacheModeExhaust)
+	rm := NewReloadManager(data.NewReloadableLoader(initial), cache, cfg, zap.NewNop())
+	t.Cleanup(func() { _ = rm.Close() })
+	if err := rm.PreloadDate("2025-01-03"); err != nil {
+		t.Fatal(err)
+	}
+	return NewServer(rm.loader, cache, cfg, zap.NewNop(), rm, nil, nil)
+}
+
+func TestGetTickersDetail(t *testing.T) {
+	dir := t.TempDir()
+	dateDir := filepath.Join(dir, "2025-01-02")
+	for _, rel := range []string{"SPX/classic/gex_zero", "SPX/classic/gex_full", "SPX/state/gex_zero", "ES_SPX/classic/gex_full", "AAPL/orderflow/orderflow"} {
+		writePreflightFile(t, dateDir, rel+".jsonl", `{"timestamp":1}`+"\n")
+	}
+	loader, err := data.NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = loader.Close() }()
+	// NDX is a configured index without data, so it is not listed
+	cfg := &config.ServerConfig{DataDir: dir, DataDate: "2025-01-02", TickerIndexes: map[string]bool{"SPX": true, "NDX": true}}
+	s := NewServer(loader, data.NewIndexCache(data.CacheModeExhaust), cfg, zap.NewNop(), nil, nil, nil)
+
+	resp, err := s.GetTickersDetail(context.Background(), generated.GetTickersDetailRequestObject{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, ticker := range resp.(generated.GetTickersDetail200JSONResponse).Tickers {
+		entry := fmt.Sprintf("%s(%s)", ticker.Ticker, ticker.Type)
+		for _, pkg := range ticker.Packages {
+			entry += fmt.Sprintf(" %s:%s", pkg.Package, strings.Join(pkg.Categories, ","))
+		}
+		got = append(got, entry)
+	}
+	want := []string{
+		"AAPL(stocks) orderflow:orderflow",
+		"ES_SPX(futures) classic:gex_full",
+		"SPX(indexes) classic:gex_full,gex_zero state:gex_zero",
+	}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Errorf("tickers = %q, want %q", got, want)
+	}
+}
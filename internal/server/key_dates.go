@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/audit"
+)
+
+// keyDatesResponse lists pinned keys (masked) sorted by date, then key hash.
+func (s *Server) keyDatesResponse() generated.KeyDatesResponse {
+	resp := generated.KeyDatesResponse{
+		DataDate:    s.config.DataDate,
+		Keys:        []generated.KeyDateAssignment{},
+		LoadedDates: []string{},
+	}
+	if s.reloadManager == nil {
+		return resp
+	}
+	resp.DataDate = s.reloadManager.CurrentDate()
+	resp.LoadedDates = s.reloadManager.PinnedDates()
+
+	for key, date := range s.reloadManager.KeyDates() {
+		resp.Keys = append(resp.Keys, generated.KeyDateAssignment{
+			ApiKey:  audit.MaskKey(key),
+			KeyHash: audit.HashKey(key),
+			Date:    date,
+		})
+	}
+	sort.Slice(resp.Keys, func(i, j int) bool {
+		if resp.Keys[i].Date != resp.Keys[j].Date {
+			return resp.Keys[i].Date < resp.Keys[j].Date
+		}
+		return resp.Keys[i].KeyHash < resp.Keys[j].KeyHash
+	})
+	return resp
+}
+
+// GetKeyDates implements generated.StrictServerInterface
+func (s *Server) GetKeyDates(ctx context.Context, request generated.GetKeyDatesRequestObject) (generated.GetKeyDatesResponseObject, error) {
+	return generated.GetKeyDates200JSONResponse(s.keyDatesResponse()), nil
+}
+
+// SetKeyDate implements generated.StrictServerInterface
+func (s *Server) SetKeyDate(ctx context.Context, request generated.SetKeyDateRequestObject) (generated.SetKeyDateResponseObject, error) {
+	if s.reloadManager == nil {
+		return generated.SetKeyDate400JSONResponse{
+			Error: ptr("per-key dates are not available"),
+		}, nil
+	}
+	if err := s.reloadManager.AssignDate(request.Body.Key, request.Body.Date); err != nil {
+		return generated.SetKeyDate400JSONResponse{
+			Error: ptr(err.Error()),
+		}, nil
+	}
+	return generated.SetKeyDate200JSONResponse(s.keyDatesResponse()), nil
+}
+
+// DeleteKeyDate implements generated.StrictServerInterface
+func (s *Server) DeleteKeyDate(ctx context.Context, request generated.DeleteKeyDateRequestObject) (generated.DeleteKeyDateResponseObject, error) {
+	if s.reloadManager == nil || !s.reloadManager.UnassignDate(request.Params.Key) {
+		return generated.DeleteKeyDate404JSONResponse{
+			Error: ptr("API key is not pinned to a date"),
+		}, nil
+	}
+	return generated.DeleteKeyDate200JSONResponse(s.keyDatesResponse()), nil
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/audit"
+	"github.com/dgnsrekt/gexbot-downloader/internal/ws"
+)
+
+// SetKeyIntervals exposes the streamers' per-key interval overrides through
+// /admin/ws-intervals. Call before serving.
+func (s *Server) SetKeyIntervals(intervals *ws.KeyIntervals) {
+	s.wsIntervals = intervals
+}
+
+// keyIntervalsResponse lists overrides (masked) sorted by interval, then key hash.
+func (s *Server) keyIntervalsResponse() generated.KeyIntervalsResponse {
+	resp := generated.KeyIntervalsResponse{
+		DefaultInterval: s.config.WSStreamInterval.String(),
+		Keys:            []generated.KeyIntervalAssignment{},
+	}
+
+	type entry struct {
+		assignment generated.KeyIntervalAssignment
+		interval   time.Duration
+	}
+	var entries []entry
+	for key, d := range s.wsIntervals.All() {
+		entries = append(entries, entry{
+			assignment: generated.KeyIntervalAssignment{
+				ApiKey:   audit.MaskKey(key),
+				KeyHash:  audit.HashKey(key),
+				Interval: d.String(),
+			},
+			interval: d,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].interval != entries[j].interval {
+			return entries[i].interval < entries[j].interval
+		}
+		return entries[i].assignment.KeyHash < entries[j].assignment.KeyHash
+	})
+	for _, e := range entries {
+		resp.Keys = append(resp.Keys, e.assignment)
+	}
+	return resp
+}
+
+// GetKeyIntervals implements generated.StrictServerInterface
+func (s *Server) GetKeyIntervals(ctx context.Context, request generated.GetKeyIntervalsRequestObject) (generated.GetKeyIntervalsResponseObject, error) {
+	return generated.GetKeyIntervals200JSONResponse(s.keyIntervalsResponse()), nil
+}
+
+// SetKeyInterval implements generated.StrictServerInterface
+func (s *Server) SetKeyInterval(ctx context.Context, request generated.SetKeyIntervalRequestObject) (generated.SetKeyIntervalResponseObject, error) {
+	if s.wsIntervals == nil {
+		return generated.SetKeyInterval400JSONResponse{
+			Error: ptr("WebSocket streaming is disabled"),
+		}, nil
+	}
+	d, err := time.ParseDuration(request.Body.Interval)
+	if err != nil {
+		return generated.SetKeyInterval400JSONResponse{
+			Error: ptr("invalid interval: " + request.Body.Interval),
+		}, nil
+	}
+	if err := s.wsIntervals.Set(request.Body.Key, d); err != nil {
+		return generated.SetKeyInterval400JSONResponse{
+			Error: ptr(err.Error()),
+		}, nil
+	}
+
+	s.logger.Info("websocket key interval set",
+		zap.String("apiKey", maskAPIKey(request.Body.Key)),
+		zap.Duration("interval", d),
+	)
+	return generated.SetKeyInterval200JSONResponse(s.keyIntervalsResponse()), nil
+}
+
+// DeleteKeyInterval implements generated.StrictServerInterface
+func (s *Server) DeleteKeyInterval(ctx context.Context, request generated.DeleteKeyIntervalRequestObject) (generated.DeleteKeyIntervalResponseObject, error) {
+	if s.wsIntervals == nil || !s.wsIntervals.Delete(request.Params.Key) {
+		return generated.DeleteKeyInterval404JSONResponse{
+			Error: ptr("API key has no interval override"),
+		}, nil
+	}
+
+	s.logger.Info("websocket key interval cleared",
+		zap.String("apiKey", maskAPIKey(request.Params.Key)),
+	)
+	return generated.DeleteKeyInterval200JSONResponse(s.keyIntervalsResponse()), nil
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/maintenance"
+)
+
+func newMaintenanceFromConfig(cfg *config.ServerConfig) *maintenance.Controller {
+	return maintenance.NewController(cfg.MaintenanceWindows, cfg.MaintenanceLocation, cfg.MaintenanceMessage)
+}
+
+// Maintenance returns the maintenance simulation controller.
+func (s *Server) Maintenance() *maintenance.Controller {
+	return s.maintenance
+}
+
+// maintenanceMiddleware answers 503 with the maintenance message while a
+// window is active. Admin and health endpoints stay reachable so the window
+// can be inspected and ended.
+func maintenanceMiddleware(ctrl *maintenance.Controller) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/health" || isAdminPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			status := ctrl.Status()
+			if !status.Active {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !status.Until.IsZero() {
+				retry := math.Ceil(time.Until(status.Until).Seconds())
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", int64(math.Max(retry, 1))))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(generated.ErrorResponse{Error: ptr(status.Message)})
+		})
+	}
+}
+
+func maintenanceStatusResponse(s maintenance.Status, ctrl *maintenance.Controller, loc *time.Location) generated.MaintenanceStatus {
+	windows := make([]string, 0, len(ctrl.Windows()))
+	for _, w := range ctrl.Windows() {
+		windows = append(windows, w.String())
+	}
+	resp := generated.MaintenanceStatus{
+		Active:   s.Active,
+		Windows:  windows,
+		Timezone: loc.String(),
+	}
+	if s.Active {
+		source := generated.MaintenanceStatusSource(s.Source)
+		resp.Source = &source
+		resp.Message = ptr(s.Message)
+		if !s.Until.IsZero() {
+			resp.Until = ptr(s.Until)
+		}
+	}
+	return resp
+}
+
+// GetMaintenance implements generated.StrictServerInterface
+func (s *Server) GetMaintenance(ctx context.Context, request generated.GetMaintenanceRequestObject) (generated.GetMaintenanceResponseObject, error) {
+	status := s.maintenance.Status()
+	return generated.GetMaintenance200JSONResponse(maintenanceStatusResponse(status, s.maintenance, s.config.MaintenanceLocation)), nil
+}
+
+// SetMaintenance implements generated.StrictServerInterface
+func (s *Server) SetMaintenance(ctx context.Context, request generated.SetMaintenanceRequestObject) (generated.SetMaintenanceResponseObject, error) {
+	body := request.Body
+
+	var status maintenance.Status
+	if body.Enabled {
+		var duration time.Duration
+		if body.Duration != nil && *body.Duration != "" {
+			d, err := time.ParseDuration(*body.Duration)
+			if err != nil || d < 0 {
+				return generated.SetMaintenance400JSONResponse{
+					Error: ptr(fmt.Sprintf("invalid duration %q (use e.g. 15m)", *body.Duration)),
+				}, nil
+			}
+			duration = d
+		}
+		status = s.maintenance.Enable(derefString(body.Message), duration)
+		s.logger.Info("maintenance started",
+			zap.String("message", status.Message),
+			zap.Duration("duration", duration),
+		)
+	} else {
+		status = s.maintenance.Disable()
+		s.logger.Info("manual maintenance ended", zap.Bool("stillActive", status.Active))
+	}
+
+	return generated.SetMaintenance200JSONResponse(maintenanceStatusResponse(status, s.maintenance, s.config.MaintenanceLocation)), nil
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// GetOrderflowHistory implements generated.StrictServerInterface.
+// It returns records the key has already been served, without advancing it.
+func (s *Server) GetOrderflowHistory(ctx context.Context, request generated.GetOrderflowHistoryRequestObject) (generated.GetOrderflowHistoryResponseObject, error) {
+	ticker := request.Ticker
+	params := request.Params
+	apiKey := params.Key
+	loader, _, positionKey, ok := s.dataForDate(apiKey, params.Date)
+	if !ok {
+		return generated.GetOrderflowHistory404JSONResponse{
+			Error: ptr("Date not loaded: " + *params.Date),
+		}, nil
+	}
+	pkg := "orderflow"
+	category := "orderflow"
+
+	s.logger.Debug("orderflow history request",
+		zap.String("ticker", ticker),
+		zap.String("apiKey", maskAPIKey(apiKey)),
+	)
+
+	if params.Minutes == nil && params.From == nil {
+		return generated.GetOrderflowHistory400JSONResponse{
+			Error: ptr("Either minutes or from is required"),
+		}, nil
+	}
+	if params.Minutes != nil && (params.From != nil || params.To != nil) {
+		return generated.GetOrderflowHistory400JSONResponse{
+			Error: ptr("minutes cannot be combined with from/to"),
+		}, nil
+	}
+	if params.From != nil && params.To != nil && *params.From > *params.To {
+		return generated.GetOrderflowHistory400JSONResponse{
+			Error: ptr("from must not be after to"),
+		}, nil
+	}
+
+	if !loader.Exists(ticker, pkg, category) {
+		return generated.GetOrderflowHistory404JSONResponse{
+			Error: ptr("Data not found for " + ticker + "/orderflow/orderflow"),
+		}, nil
+	}
+
+	length, err := loader.GetLength(ticker, pkg, category)
+	if err != nil {
+		return generated.GetOrderflowHistory404JSONResponse{
+			Error: ptr(err.Error()),
+		}, nil
+	}
+
+	// Same position the latest endpoint advances
+	var cacheKey string
+	if s.config.EndpointCacheMode == "shared" {
+		cacheKey = data.SharedCacheKey(ticker, pkg, positionKey)
+	} else {
+		cacheKey = data.CacheKey(ticker, pkg, category, positionKey)
+	}
+	end := min(s.cache.GetIndex(cacheKey), length)
+	if end == 0 {
+		return generated.GetOrderflowHistory200JSONResponse{}, nil
+	}
+
+	// Resolve the window to [start, end) over the records already served
+	var start int
+	if params.Minutes != nil {
+		latest, err := data.RecordTimestamp(ctx, loader, ticker, pkg, category, end-1)
+		if err != nil {
+			return generated.GetOrderflowHistory404JSONResponse{
+				Error: ptr(err.Error()),
+			}, nil
+		}
+		start, err = data.SearchTimestamp(ctx, loader, ticker, pkg, category, end, latest-int64(*params.Minutes)*60)
+		if err != nil {
+			return generated.GetOrderflowHistory404JSONResponse{
+				Error: ptr(err.Error()),
+			}, nil
+		}
+	} else {
+		start, err = data.SearchTimestamp(ctx, loader, ticker, pkg, category, end, *params.From)
+		if err != nil {
+			return generated.GetOrderflowHistory404JSONResponse{
+				Error: ptr(err.Error()),
+			}, nil
+		}
+		if params.To != nil {
+			// First record after to bounds the window
+			if end, err = data.SearchTimestamp(ctx, loader, ticker, pkg, category, end, *params.To+1); err != nil {
+				return generated.GetOrderflowHistory404JSONResponse{
+					Error: ptr(err.Error()),
+				}, nil
+			}
+		}
+	}
+
+	if start >= end {
+		return generated.GetOrderflowHistory200JSONResponse{}, nil
+	}
+
+	records, err := data.GetRawRange(ctx, loader, ticker, pkg, category, start, end)
+	if err != nil {
+		return generated.GetOrderflowHistory404JSONResponse{
+			Error: ptr(err.Error()),
+		}, nil
+	}
+
+	response := make(generated.GetOrderflowHistory200JSONResponse, 0, len(records))
+	for _, raw := range records {
+		var ofData data.OrderflowData
+		if err := json.Unmarshal(raw, &ofData); err != nil {
+			s.logger.Error("failed to parse orderflow data", zap.Error(err))
+			return generated.GetOrderflowHistory404JSONResponse{
+				Error: ptr("Failed to parse orderflow data"),
+			}, nil
+		}
+		response = append(response, orderflowRecord(ofData))
+	}
+
+	s.logger.Debug("returning orderflow history",
+		zap.String("cacheKey", maskCacheKey(cacheKey)),
+		zap.Int("start", start),
+		zap.Int("end", end),
+	)
+
+	return response, nil
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+func TestGetOrderflowHistoryDate(t *testing.T) {
+	s := newTestServer(t)
+
+	// Three records served on the key's own date, one on the preloaded date
+	s.cache.SetIndex(data.CacheKey("SPX", "orderflow", "orderflow", "k"), 3)
+	s.cache.SetIndex(data.CacheKey("SPX", "orderflow", "orderflow", data.DatedKey("k", "2025-01-03")), 1)
+
+	tests := []struct {
+		name string
+		date *string
+		want []int64
+	}{
+		{"own date", nil, minutes(open1, 3)},
+		{"own date explicit", ptr("2025-01-02"), minutes(open1, 3)},
+		{"preloaded date", ptr("2025-01-03"), minutes(open2, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetOrderflowHistory(context.Background(), generated.GetOrderflowHistoryRequestObject{
+				Ticker: "SPX",
+				Params: generated.GetOrderflowHistoryParams{Key: "k", From: ptr(int64(0)), Date: tt.date},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			records, ok := resp.(generated.GetOrderflowHistory200JSONResponse)
+			if !ok {
+				t.Fatalf("response = %T, want 200", resp)
+			}
+			var got []int64
+			for _, r := range records {
+				got = append(got, r.Timestamp)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("timestamps = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	resp, err := s.GetOrderflowHistory(context.Background(), generated.GetOrderflowHistoryRequestObject{
+		Ticker: "SPX",
+		Params: generated.GetOrderflowHistoryParams{Key: "k", Minutes: ptr(5), Date: ptr("2025-02-01")},
+	})
+	if _, ok := resp.(generated.GetOrderflowHistory404JSONResponse); err != nil || !ok {
+		t.Errorf("unloaded date: response = %T, %v; want 404", resp, err)
+	}
+}
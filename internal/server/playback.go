@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// SetPlaybackClock serves the record due at the clock's simulated time
+// instead of one record per request. Call before serving.
+func (s *Server) SetPlaybackClock(clock *data.PlaybackClock) {
+	s.clock = clock
+}
+
+// advance returns the record of ticker/pkg/category to serve for cacheKey and
+// whether its data is exhausted. A mode query parameter of peek returns the
+// current record without advancing; rotation or exhaust override the cache
+// mode for this request only. Empty data is always exhausted.
+func (s *Server) advance(ctx context.Context, loader data.DataLoader, ticker, pkg, category, cacheKey string, length int, mode *generated.PlaybackMode) (int, bool) {
+	if length == 0 {
+		return 0, true
+	}
+
+	var cacheMode data.CacheMode
+	peek := false
+	if mode != nil {
+		peek = *mode == generated.PlaybackModePeek
+		if !peek {
+			cacheMode = data.CacheMode(*mode)
+		}
+	}
+
+	switch {
+	case s.clock != nil && peek:
+		return s.clock.Peek(ctx, s.cache, cacheKey, loader, ticker, pkg, category, length, cacheMode)
+	case s.clock != nil:
+		return s.clock.Advance(ctx, s.cache, cacheKey, loader, ticker, pkg, category, length, cacheMode)
+	case peek:
+		return s.cache.Peek(cacheKey, length)
+	default:
+		return s.cache.GetAndAdvanceMode(cacheKey, length, cacheMode)
+	}
+}
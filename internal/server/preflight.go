@@ -0,0 +1,269 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+// PreflightReport summarizes the health of a loaded data directory.
+type PreflightReport struct {
+	Date              string
+	GeneratedAt       time.Time
+	FilesFound        int
+	KeysLoaded        int
+	EmptyFiles        []string
+	ParseFailures     []PreflightParseFailure
+	NotLoaded         []string
+	MissingCategories []PreflightMissing
+	// ManifestMismatches lists files from the date's manifest.json that are
+	// missing or differ in size. Empty when the date has no manifest.
+	ManifestMismatches []PreflightManifestMismatch
+}
+
+// PreflightParseFailure describes a JSONL line that is not valid JSON.
+type PreflightParseFailure struct {
+	File  string
+	Line  int
+	Error string
+}
+
+// PreflightMissing lists expected categories absent for a ticker/package.
+type PreflightMissing struct {
+	Ticker     string
+	Package    string
+	Categories []string
+}
+
+// PreflightManifestMismatch is a manifest entry the data directory does not match.
+type PreflightManifestMismatch struct {
+	File  string
+	Error string
+}
+
+// OK reports whether the preflight found no problems.
+func (r *PreflightReport) OK() bool {
+	return len(r.EmptyFiles) == 0 && len(r.ParseFailures) == 0 &&
+		len(r.NotLoaded) == 0 && len(r.MissingCategories) == 0 &&
+		len(r.ManifestMismatches) == 0
+}
+
+// maxScanFailures caps the parse failures a full scan reports per file, as an
+// unconverted JSON array fails on every line.
+const maxScanFailures = 10
+
+// BuildPreflightReport scans {dataDir}/{date} and cross-checks it against the loader.
+// By default the files are not read again: the loader's first and last record
+// of each key are parsed, which catches unconverted JSON arrays and truncated
+// downloads. With scan, every line of every file is read and validated.
+func BuildPreflightReport(dataDir, date string, loader data.DataLoader, scan bool) *PreflightReport {
+	report := &PreflightReport{
+		Date:        date,
+		GeneratedAt: time.Now(),
+	}
+
+	loaded := make(map[string]bool)
+	for _, key := range loader.GetLoadedKeys() {
+		loaded[key] = true
+	}
+	report.KeysLoaded = len(loaded)
+
+	// ticker -> package -> categories present on disk
+	present := make(map[string]map[string]map[string]bool)
+
+	dateDir := filepath.Join(dataDir, date)
+	_ = filepath.Walk(dateDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		category, ok := data.JSONLCategory(info.Name())
+		if !ok {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(dateDir, path)
+		ticker := filepath.Dir(filepath.Dir(rel))
+		pkg := filepath.Base(filepath.Dir(rel))
+		key := data.DataKey(ticker, pkg, category)
+
+		report.FilesFound++
+		if present[ticker] == nil {
+			present[ticker] = make(map[string]map[string]bool)
+		}
+		if present[ticker][pkg] == nil {
+			present[ticker][pkg] = make(map[string]bool)
+		}
+		present[ticker][pkg][category] = true
+
+		if !loaded[key] {
+			report.NotLoaded = append(report.NotLoaded, rel)
+			if !scan {
+				return nil
+			}
+		}
+
+		var (
+			lines    int
+			failures []PreflightParseFailure
+		)
+		if scan {
+			lines, failures, err = scanPreflightFile(path)
+		} else {
+			lines, failures, err = samplePreflightKey(loader, ticker, pkg, category)
+		}
+		if err != nil {
+			report.ParseFailures = append(report.ParseFailures, PreflightParseFailure{File: rel, Error: err.Error()})
+			return nil
+		}
+		if lines == 0 {
+			report.EmptyFiles = append(report.EmptyFiles, rel)
+		}
+		for _, f := range failures {
+			f.File = rel
+			report.ParseFailures = append(report.ParseFailures, f)
+		}
+		return nil
+	})
+
+	// Compare present categories against the expected set for each package
+	for ticker, pkgs := range present {
+		for pkg, cats := range pkgs {
+			var missing []string
+			for _, expected := range config.ValidCategories[config.Package(pkg)] {
+				if !cats[expected] {
+					missing = append(missing, expected)
+				}
+			}
+			if len(missing) > 0 {
+				report.MissingCategories = append(report.MissingCategories, PreflightMissing{
+					Ticker:     ticker,
+					Package:    pkg,
+					Categories: missing,
+				})
+			}
+		}
+	}
+
+	report.ManifestMismatches = checkManifest(dateDir)
+
+	sort.Strings(report.EmptyFiles)
+	sort.Strings(report.NotLoaded)
+	sort.Slice(report.MissingCategories, func(i, j int) bool {
+		a, b := report.MissingCategories[i], report.MissingCategories[j]
+		if a.Ticker != b.Ticker {
+			return a.Ticker < b.Ticker
+		}
+		return a.Package < b.Package
+	})
+
+	return report
+}
+
+// samplePreflightKey returns the record count of a loaded key and validates
+// its first and last record as JSON. Failures carry the record number, which
+// is the line number when the file has no blank lines.
+func samplePreflightKey(loader data.DataLoader, ticker, pkg, category string) (int, []PreflightParseFailure, error) {
+	length, err := loader.GetLength(ticker, pkg, category)
+	if err != nil || length == 0 {
+		return 0, nil, err
+	}
+
+	var failures []PreflightParseFailure
+	raw, err := loader.GetRawAtIndex(context.Background(), ticker, pkg, category, 0)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !json.Valid(raw) {
+		failures = append(failures, PreflightParseFailure{Line: 1, Error: "invalid JSON on first line"})
+	}
+	if length > 1 {
+		if raw, err = loader.GetRawAtIndex(context.Background(), ticker, pkg, category, length-1); err != nil {
+			return 0, nil, err
+		}
+		if !json.Valid(raw) {
+			failures = append(failures, PreflightParseFailure{
+				Line:  length,
+				Error: fmt.Sprintf("invalid JSON on last line (%d bytes)", len(bytes.TrimSpace(raw))),
+			})
+		}
+	}
+	return length, failures, nil
+}
+
+// scanPreflightFile reads the whole file to count non-empty lines and
+// validates each as JSON, reporting up to maxScanFailures.
+func scanPreflightFile(path string) (int, []PreflightParseFailure, error) {
+	file, err := data.OpenJSONL(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var (
+		count    int
+		lineNum  int
+		failures []PreflightParseFailure
+	)
+	reader := bufio.NewReader(file)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			lineNum++
+			trimmed := bytes.TrimSpace(line)
+			if len(trimmed) > 0 {
+				count++
+				if !json.Valid(trimmed) && len(failures) < maxScanFailures {
+					failures = append(failures, PreflightParseFailure{
+						Line:  lineNum,
+						Error: fmt.Sprintf("invalid JSON (%d bytes)", len(trimmed)),
+					})
+				}
+			}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+	return count, failures, nil
+}
+
+// checkManifest compares the files listed in a date's manifest.json, written
+// by the downloader, against the disk. Only sizes are compared; the
+// downloader's verify command checks digests.
+func checkManifest(dateDir string) []PreflightManifestMismatch {
+	m, err := manifest.Read(filepath.Join(dateDir, manifest.Name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return []PreflightManifestMismatch{{File: manifest.Name, Error: err.Error()}}
+	}
+
+	var mismatches []PreflightManifestMismatch
+	for _, f := range m.Files {
+		info, err := os.Stat(filepath.Join(dateDir, filepath.FromSlash(f.Path)))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			mismatches = append(mismatches, PreflightManifestMismatch{File: f.Path, Error: "missing"})
+		case err != nil:
+			mismatches = append(mismatches, PreflightManifestMismatch{File: f.Path, Error: err.Error()})
+		case info.Size() != f.Size:
+			mismatches = append(mismatches, PreflightManifestMismatch{
+				File:  f.Path,
+				Error: fmt.Sprintf("size %d, manifest lists %d", info.Size(), f.Size),
+			})
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+func writePreflightFile(t *testing.T, dateDir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dateDir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildPreflightReport(t *testing.T) {
+	dir := t.TempDir()
+	dateDir := filepath.Join(dir, "2025-01-02")
+	writePreflightFile(t, dateDir, "SPX/classic/gex_full.jsonl", `{"timestamp":1}`+"\n"+`{"timestamp":2}`+"\n")
+	writePreflightFile(t, dateDir, "SPX/classic/gex_zero.jsonl", "\n\n")
+	// A truncated download: the last record is cut off
+	writePreflightFile(t, dateDir, "SPX/classic/gex_one.jsonl", `{"timestamp":1}`+"\n"+`{"timestamp":2}`+"\n"+`{"times`)
+	// An unconverted JSON array
+	writePreflightFile(t, dateDir, "SPX/orderflow/orderflow.jsonl", "[\n"+`{"timestamp":1}`+"\n")
+
+	loader, err := data.NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = loader.Close() }()
+
+	// Written after the load, so not served
+	writePreflightFile(t, dateDir, "NDX/volatility/iv_zero.jsonl", `{"timestamp":1}`+"\n")
+
+	m := manifest.New("2025-01-02")
+	m.Set(manifest.File{Path: "SPX/classic/gex_full.jsonl", Size: 32})
+	m.Set(manifest.File{Path: "SPX/state/gex_full.jsonl", Size: 10})
+	if err := m.Write(filepath.Join(dateDir, manifest.Name)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both modes find the same problems in these files
+	for _, scan := range []bool{false, true} {
+		t.Run(fmt.Sprintf("scan=%v", scan), func(t *testing.T) {
+			report := BuildPreflightReport(dir, "2025-01-02", loader, scan)
+
+			if report.FilesFound != 5 || report.KeysLoaded != 4 {
+				t.Errorf("FilesFound = %d, KeysLoaded = %d; want 5 and 4", report.FilesFound, report.KeysLoaded)
+			}
+			if !slices.Equal(report.EmptyFiles, []string{"SPX/classic/gex_zero.jsonl"}) {
+				t.Errorf("EmptyFiles = %v", report.EmptyFiles)
+			}
+			if !slices.Equal(report.NotLoaded, []string{"NDX/volatility/iv_zero.jsonl"}) {
+				t.Errorf("NotLoaded = %v", report.NotLoaded)
+			}
+
+			failures := make(map[string]int)
+			for _, f := range report.ParseFailures {
+				failures[f.File] = f.Line
+			}
+			want := map[string]int{"SPX/classic/gex_one.jsonl": 3, "SPX/orderflow/orderflow.jsonl": 1}
+			if len(failures) != len(want) || failures["SPX/classic/gex_one.jsonl"] != 3 || failures["SPX/orderflow/orderflow.jsonl"] != 1 {
+				t.Errorf("ParseFailures = %+v, want lines %v", report.ParseFailures, want)
+			}
+
+			if len(report.MissingCategories) != 1 || report.MissingCategories[0].Ticker != "NDX" ||
+				!slices.Equal(report.MissingCategories[0].Categories, []string{"iv_one"}) {
+				t.Errorf("MissingCategories = %+v, want NDX volatility iv_one", report.MissingCategories)
+			}
+
+			mismatches := make(map[string]string)
+			for _, mm := range report.ManifestMismatches {
+				mismatches[mm.File] = mm.Error
+			}
+			if len(mismatches) != 1 || mismatches["SPX/state/gex_full.jsonl"] != "missing" {
+				t.Errorf("ManifestMismatches = %+v, want only the missing state file", report.ManifestMismatches)
+			}
+			if report.OK() {
+				t.Error("OK() = true for a report with problems")
+			}
+		})
+	}
+}
+
+func TestBuildPreflightReportClean(t *testing.T) {
+	dir := t.TempDir()
+	dateDir := filepath.Join(dir, "2025-01-02")
+	for _, category := range []string{"iv_zero", "iv_one"} {
+		writePreflightFile(t, dateDir, "SPX/volatility/"+category+".jsonl", `{"timestamp":1}`+"\n")
+	}
+	loader, err := data.NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = loader.Close() }()
+
+	if report := BuildPreflightReport(dir, "2025-01-02", loader, false); !report.OK() {
+		t.Errorf("report = %+v, want OK", report)
+	}
+}
+
+func TestBuildPreflightReportScan(t *testing.T) {
+	dir := t.TempDir()
+	dateDir := filepath.Join(dir, "2025-01-02")
+	// Only the middle record is broken
+	writePreflightFile(t, dateDir, "SPX/volatility/iv_zero.jsonl", `{"timestamp":1}`+"\n"+`{"timest`+"\n"+`{"timestamp":3}`+"\n")
+	writePreflightFile(t, dateDir, "SPX/volatility/iv_one.jsonl", `{"timestamp":1}`+"\n")
+	loader, err := data.NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = loader.Close() }()
+
+	if report := BuildPreflightReport(dir, "2025-01-02", loader, false); !report.OK() {
+		t.Errorf("sampled report = %+v, want OK", report)
+	}
+	report := BuildPreflightReport(dir, "2025-01-02", loader, true)
+	if len(report.ParseFailures) != 1 || report.ParseFailures[0].File != "SPX/volatility/iv_zero.jsonl" || report.ParseFailures[0].Line != 2 {
+		t.Errorf("ParseFailures = %+v, want iv_zero line 2", report.ParseFailures)
+	}
+}
@@ -26,11 +26,19 @@ type ReloadManager struct {
 
 	// Reload state
 	isReloading atomic.Bool
-	reloadMu    sync.Mutex // prevents concurrent reloads
+	reloadMu    sync.Mutex  // prevents concurrent reloads
+	forceStream atomic.Bool // set by the memory watchdog to degrade new loads
+
+	// API keys pinned to dates other than the loaded one
+	keyDates *data.KeyDateRouter
+
+	alerter *Alerter         // nil when alerts are disabled
+	store   *data.CacheStore // nil when positions are not persisted
 
 	// Current state
 	currentDate string
 	loadedAt    time.Time
+	preflight   *PreflightReport
 	stateMu     sync.RWMutex
 }
 
@@ -41,7 +49,7 @@ func NewReloadManager(
 	cfg *config.ServerConfig,
 	logger *zap.Logger,
 ) *ReloadManager {
-	return &ReloadManager{
+	rm := &ReloadManager{
 		loader:      loader,
 		cache:       cache,
 		config:      cfg,
@@ -49,6 +57,21 @@ func NewReloadManager(
 		currentDate: cfg.DataDate,
 		loadedAt:    time.Now(),
 	}
+	rm.keyDates = data.NewKeyDateRouter(loader, cfg.DataDate, rm.openDate)
+	return rm
+}
+
+// SetAlerter alerts on failed reloads and once every playback position is
+// exhausted.
+func (rm *ReloadManager) SetAlerter(a *Alerter) {
+	rm.alerter = a
+	rm.cache.OnAllExhausted(func() { a.Exhausted(rm.CurrentDate()) })
+}
+
+// SetCacheStore saves the persisted positions after each reload, so they
+// are not restored for the previous date.
+func (rm *ReloadManager) SetCacheStore(store *data.CacheStore) {
+	rm.store = store
 }
 
 // IsReloading returns true if a reload is currently in progress.
@@ -71,6 +94,41 @@ func (rm *ReloadManager) LoadedAt() time.Time {
 	return rm.loadedAt
 }
 
+// Preflight returns the most recent preflight report, or nil if none has run.
+func (rm *ReloadManager) Preflight() *PreflightReport {
+	rm.stateMu.RLock()
+	defer rm.stateMu.RUnlock()
+	return rm.preflight
+}
+
+// RunPreflight builds a preflight report for the currently loaded date and stores it.
+// Problems are logged as warnings so misconfigured data dirs are visible at startup.
+// scan reads every line of every file; see BuildPreflightReport.
+func (rm *ReloadManager) RunPreflight(scan bool) *PreflightReport {
+	report := BuildPreflightReport(rm.config.DataDir, rm.CurrentDate(), rm.loader, scan)
+
+	rm.stateMu.Lock()
+	rm.preflight = report
+	rm.stateMu.Unlock()
+
+	fields := []zap.Field{
+		zap.String("date", report.Date),
+		zap.Int("filesFound", report.FilesFound),
+		zap.Int("keysLoaded", report.KeysLoaded),
+		zap.Int("emptyFiles", len(report.EmptyFiles)),
+		zap.Int("parseFailures", len(report.ParseFailures)),
+		zap.Int("notLoaded", len(report.NotLoaded)),
+		zap.Int("tickersMissingCategories", len(report.MissingCategories)),
+		zap.Int("manifestMismatches", len(report.ManifestMismatches)),
+	}
+	if report.OK() {
+		rm.logger.Info("preflight passed", fields...)
+	} else {
+		rm.logger.Warn("preflight found problems (see /admin/preflight)", fields...)
+	}
+	return report
+}
+
 // ReloadResult contains the result of a successful reload operation.
 type ReloadResult struct {
 	PreviousDate string
@@ -88,6 +146,15 @@ func (rm *ReloadManager) Reload(ctx context.Context, newDate string) (*ReloadRes
 	}
 	defer rm.reloadMu.Unlock()
 
+	result, err := rm.reload(newDate)
+	if err != nil {
+		rm.alerter.ReloadFailed(newDate, rm.CurrentDate(), err)
+	}
+	return result, err
+}
+
+// reload performs a reload; rm.reloadMu must be held.
+func (rm *ReloadManager) reload(newDate string) (*ReloadResult, error) {
 	previousDate := rm.CurrentDate()
 
 	rm.logger.Info("starting hot reload",
@@ -95,38 +162,16 @@ func (rm *ReloadManager) Reload(ctx context.Context, newDate string) (*ReloadRes
 		zap.String("newDate", newDate),
 	)
 
-	// Validate date format
-	if !isValidDateFormat(newDate) {
-		return nil, fmt.Errorf("invalid date format: %s (expected YYYY-MM-DD)", newDate)
-	}
-
-	// Check if date directory exists
-	datePath := filepath.Join(rm.config.DataDir, newDate)
-	info, err := os.Stat(datePath)
-	if os.IsNotExist(err) {
-		return nil, fmt.Errorf("date not found: %s", newDate)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to check date directory: %w", err)
-	}
-	if !info.IsDir() {
-		return nil, fmt.Errorf("date path is not a directory: %s", newDate)
+	if err := rm.validateDate(newDate); err != nil {
+		return nil, err
 	}
 
 	// Create new loader for the new date
-	newLoader, err := rm.createLoader(newDate)
+	newLoader, err := rm.openDate(newDate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load data for %s: %w", newDate, err)
+		return nil, err
 	}
-
-	// Check if we actually loaded any data
 	loadedKeys := newLoader.GetLoadedKeys()
-	if len(loadedKeys) == 0 {
-		if closeErr := newLoader.Close(); closeErr != nil {
-			rm.logger.Warn("failed to close new loader after empty load", zap.Error(closeErr))
-		}
-		return nil, fmt.Errorf("no data files found for date: %s", newDate)
-	}
 
 	// Signal streamers to pause
 	rm.isReloading.Store(true)
@@ -137,8 +182,12 @@ func (rm *ReloadManager) Reload(ctx context.Context, newDate string) (*ReloadRes
 	// Swap the loader atomically
 	oldLoader := rm.loader.Swap(newLoader)
 
-	// Reset all cache positions
-	resetCount := rm.cache.Reset("")
+	// Reset cache positions, except for keys pinned to their own date
+	pinned := make(map[string]bool)
+	for key := range rm.keyDates.Assignments() {
+		pinned[key] = true
+	}
+	resetCount := rm.cache.ResetExceptKeys(pinned)
 
 	// Update current state
 	rm.stateMu.Lock()
@@ -151,11 +200,20 @@ func (rm *ReloadManager) Reload(ctx context.Context, newDate string) (*ReloadRes
 	// Resume streamers
 	rm.isReloading.Store(false)
 
-	// Close old loader (release resources)
-	if err := oldLoader.Close(); err != nil {
+	if rm.store != nil {
+		if err := rm.store.Snapshot(); err != nil {
+			rm.logger.Warn("failed to save cache positions after reload", zap.Error(err))
+		}
+	}
+
+	// Close old loader (release resources) unless keys are pinned to its date
+	if err := rm.keyDates.SetPrimaryDate(newDate, previousDate, oldLoader); err != nil {
 		rm.logger.Warn("failed to close old loader", zap.Error(err))
 	}
 
+	// Refresh preflight report for the new date, without the full scan
+	rm.RunPreflight(false)
+
 	rm.logger.Info("hot reload complete",
 		zap.String("previousDate", previousDate),
 		zap.String("newDate", newDate),
@@ -172,8 +230,152 @@ func (rm *ReloadManager) Reload(ctx context.Context, newDate string) (*ReloadRes
 	}, nil
 }
 
-// createLoader creates a new DataLoader based on the configured data mode.
+// validateDate checks the date format and that its directory exists.
+func (rm *ReloadManager) validateDate(date string) error {
+	if !isValidDateFormat(date) {
+		return fmt.Errorf("invalid date format: %s (expected YYYY-MM-DD)", date)
+	}
+
+	datePath := filepath.Join(rm.config.DataDir, date)
+	info, err := os.Stat(datePath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("date not found: %s", date)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check date directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("date path is not a directory: %s", date)
+	}
+	return nil
+}
+
+// openDate loads the data for date, failing when nothing was loaded.
+func (rm *ReloadManager) openDate(date string) (data.DataLoader, error) {
+	loader, err := rm.createLoader(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data for %s: %w", date, err)
+	}
+	if len(loader.GetLoadedKeys()) == 0 {
+		if closeErr := loader.Close(); closeErr != nil {
+			rm.logger.Warn("failed to close new loader after empty load", zap.Error(closeErr))
+		}
+		return nil, fmt.Errorf("no data files found for date: %s", date)
+	}
+	return loader, nil
+}
+
+// Resolve returns the loader and date serving apiKey: its pinned date when
+// assigned, otherwise the currently loaded date.
+func (rm *ReloadManager) Resolve(apiKey string) (data.DataLoader, string) {
+	return rm.keyDates.Resolve(apiKey)
+}
+
+// ResolveDate returns the loader serving date, if it is the loaded date, a
+// pinned date or a preloaded one.
+func (rm *ReloadManager) ResolveDate(date string) (data.DataLoader, bool) {
+	return rm.keyDates.ResolveDate(date)
+}
+
+// PreloadDate loads date alongside the current one, so requests can replay
+// it with the date query parameter. It stays loaded across hot reloads.
+func (rm *ReloadManager) PreloadDate(date string) error {
+	if err := rm.validateDate(date); err != nil {
+		return err
+	}
+	if err := rm.keyDates.Preload(date); err != nil {
+		return err
+	}
+	rm.logger.Info("date preloaded", zap.String("date", date))
+	return nil
+}
+
+// KeyDates returns the API keys pinned to a date.
+func (rm *ReloadManager) KeyDates() map[string]string {
+	return rm.keyDates.Assignments()
+}
+
+// PinnedDates returns the extra dates loaded for pinned keys or preloaded.
+func (rm *ReloadManager) PinnedDates() []string {
+	return rm.keyDates.Dates()
+}
+
+// AssignDate pins apiKey to date, loading the date if needed, and restarts
+// the key's playback positions. The pin survives hot reloads of the main date.
+func (rm *ReloadManager) AssignDate(apiKey, date string) error {
+	if apiKey == "" {
+		return fmt.Errorf("api key is required")
+	}
+	if err := rm.validateDate(date); err != nil {
+		return err
+	}
+	if err := rm.keyDates.Assign(apiKey, date); err != nil {
+		return err
+	}
+	reset := rm.cache.Reset(apiKey)
+
+	rm.logger.Info("api key pinned to date",
+		zap.String("apiKey", maskAPIKey(apiKey)),
+		zap.String("date", date),
+		zap.Int("cachePositionsReset", reset),
+	)
+	return nil
+}
+
+// UnassignDate returns apiKey to the currently loaded date and restarts its
+// playback positions. Reports whether the key was pinned.
+func (rm *ReloadManager) UnassignDate(apiKey string) bool {
+	pinned, err := rm.keyDates.Unassign(apiKey)
+	if err != nil {
+		rm.logger.Warn("failed to close unused loader", zap.Error(err))
+	}
+	if !pinned {
+		return false
+	}
+	reset := rm.cache.Reset(apiKey)
+
+	rm.logger.Info("api key unpinned",
+		zap.String("apiKey", maskAPIKey(apiKey)),
+		zap.Int("cachePositionsReset", reset),
+	)
+	return true
+}
+
+// Close releases loaders held for pinned dates.
+func (rm *ReloadManager) Close() error {
+	return rm.keyDates.Close()
+}
+
+// ForceStreamMode makes all subsequent reloads use stream mode regardless of
+// the configured DATA_MODE. Used by the memory watchdog to degrade gracefully.
+func (rm *ReloadManager) ForceStreamMode() {
+	if !rm.forceStream.Swap(true) {
+		rm.logger.Warn("memory pressure: future reloads will use stream mode",
+			zap.String("configuredMode", rm.config.DataMode),
+		)
+	}
+}
+
+// StreamModeForced reports whether the watchdog has forced stream mode.
+func (rm *ReloadManager) StreamModeForced() bool {
+	return rm.forceStream.Load()
+}
+
+// createLoader creates a new DataLoader based on the configured data mode,
+// stitching DATA_DAYS dates from date on into one replay.
 func (rm *ReloadManager) createLoader(date string) (data.DataLoader, error) {
+	dates, err := config.StitchedDates(rm.config.DataDir, date, rm.config.DataDays)
+	if err != nil {
+		return nil, err
+	}
+	return data.OpenDays(dates, rm.openDay)
+}
+
+// openDay loads one date based on the configured data mode.
+func (rm *ReloadManager) openDay(date string) (data.DataLoader, error) {
+	if rm.forceStream.Load() {
+		return data.NewStreamLoader(rm.config.DataDir, date, rm.logger)
+	}
 	switch rm.config.DataMode {
 	case "memory":
 		return data.NewMemoryLoader(rm.config.DataDir, date, rm.logger)
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"container/list"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/stats"
+)
+
+// ResponseCache is a byte-bounded LRU of marshalled REST data responses.
+// Many API keys replaying the same date at similar positions hit the same
+// (endpoint, ticker, category, index) tuples, so the unmarshal/transform/marshal
+// work is done once per record instead of once per request.
+// A nil *ResponseCache is valid and caches nothing.
+type ResponseCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	order    *list.List // front = most recently used
+	entries  map[string]*list.Element
+}
+
+type responseCacheEntry struct {
+	key  string
+	body []byte
+}
+
+// NewResponseCache creates a cache holding at most maxBytes of response bodies.
+func NewResponseCache(maxBytes int64) *ResponseCache {
+	return &ResponseCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// newResponseCacheFromConfig returns nil (disabled) unless RESPONSE_CACHE_MB is set.
+func newResponseCacheFromConfig(cfg *config.ServerConfig) *ResponseCache {
+	if cfg.ResponseCacheMB <= 0 {
+		return nil
+	}
+	return NewResponseCache(int64(cfg.ResponseCacheMB) * 1024 * 1024)
+}
+
+// Get returns the cached body for key.
+func (c *ResponseCache) Get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		stats.ResponseCacheMisses.Add(1)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	stats.ResponseCacheHits.Add(1)
+	return elem.Value.(*responseCacheEntry).body, true
+}
+
+// Store marshals resp, caches it under key and returns the body.
+// Returns false when the cache is disabled or resp cannot be marshalled,
+// in which case the caller should return resp unchanged.
+func (c *ResponseCache) Store(key string, resp any) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return nil, false
+	}
+	// Match json.Encoder output used by the generated responses
+	body = append(body, '\n')
+
+	if int64(len(body)) > c.maxBytes {
+		return body, true // too large to cache, still serve the marshalled bytes
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*responseCacheEntry).body, true
+	}
+
+	c.entries[key] = c.order.PushFront(&responseCacheEntry{key: key, body: body})
+	c.size += int64(len(body))
+
+	for c.size > c.maxBytes {
+		oldest := c.order.Back()
+		entry := oldest.Value.(*responseCacheEntry)
+		c.order.Remove(oldest)
+		delete(c.entries, entry.key)
+		c.size -= int64(len(entry.body))
+	}
+
+	return body, true
+}
+
+// responseKey identifies a data record for one endpoint shape and date.
+// The loaded-at timestamp scopes entries to the current dataset, so reloads
+// never serve stale bodies; old entries simply age out of the LRU.
+func (s *Server) responseKey(date, endpoint, ticker, pkg, category string, idx int) string {
+	generation := s.loadedAt.UnixNano()
+	if s.reloadManager != nil {
+		generation = s.reloadManager.LoadedAt().UnixNano()
+	}
+	return strconv.FormatInt(generation, 36) + "/" + date + "/" + endpoint + "/" + ticker + "/" + pkg + "/" + category + "/" + strconv.Itoa(idx)
+}
+
+// cachedJSONResponse writes a pre-marshalled 200 JSON body.
+// It satisfies the response interfaces of every cached data endpoint.
+type cachedJSONResponse []byte
+
+func (r cachedJSONResponse) write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := w.Write(r)
+	return err
+}
+
+func (r cachedJSONResponse) VisitGetClassicGexMajorsResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r cachedJSONResponse) VisitGetClassicGexMaxChangeResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r cachedJSONResponse) VisitGetClassicGexChainResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r cachedJSONResponse) VisitGetStateProfileResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r cachedJSONResponse) VisitGetStateGexMajorsResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r cachedJSONResponse) VisitGetStateGexMaxChangeResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r cachedJSONResponse) VisitGetOrderflowLatestResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r cachedJSONResponse) VisitGetVolatilityResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	// Each body is a 10-byte string plus quotes and newline: 13 bytes
+	c := NewResponseCache(30)
+	for _, key := range []string{"a", "b"} {
+		if _, ok := c.Store(key, strings.Repeat(key, 10)); !ok {
+			t.Fatalf("Store(%q) not cached", key)
+		}
+	}
+	// Touch a so that b is the oldest
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("a missing before eviction")
+	}
+	c.Store("c", strings.Repeat("c", 10))
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if body, ok := c.Get(key); !ok || string(body) != `"`+strings.Repeat(key, 10)+`"`+"\n" {
+			t.Errorf("Get(%q) = %q, %v", key, body, ok)
+		}
+	}
+	if c.size != 26 {
+		t.Errorf("size = %d, want 26", c.size)
+	}
+}
+
+func TestResponseCacheOversizeBody(t *testing.T) {
+	c := NewResponseCache(8)
+	body, ok := c.Store("big", strings.Repeat("x", 20))
+	if !ok || len(body) != 23 {
+		t.Fatalf("Store = %q, %v; want the marshalled body", body, ok)
+	}
+	if _, ok := c.Get("big"); ok {
+		t.Error("oversize body should not be cached")
+	}
+	if c.size != 0 || c.order.Len() != 0 {
+		t.Errorf("size = %d, entries = %d; want an empty cache", c.size, c.order.Len())
+	}
+}
+
+func TestResponseCacheNil(t *testing.T) {
+	var c *ResponseCache
+	if _, ok := c.Store("k", "v"); ok {
+		t.Error("nil cache should not store")
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Error("nil cache should not hit")
+	}
+}
+
+func TestResponseKeyChangesOnReload(t *testing.T) {
+	rm := &ReloadManager{loadedAt: time.Unix(1000, 0)}
+	s := &Server{loadedAt: time.Unix(1, 0), reloadManager: rm, responses: NewResponseCache(1024)}
+
+	before := s.responseKey("2025-01-02", "classic", "SPX", "gex", "zero", 5)
+	s.responses.Store(before, "body")
+
+	rm.stateMu.Lock()
+	rm.loadedAt = time.Unix(2000, 0)
+	rm.stateMu.Unlock()
+
+	after := s.responseKey("2025-01-02", "classic", "SPX", "gex", "zero", 5)
+	if after == before {
+		t.Fatalf("key %q did not change after reload", after)
+	}
+	if _, ok := s.responses.Get(after); ok {
+		t.Error("body from the previous dataset served after reload")
+	}
+}
@@ -1,6 +1,9 @@
 package server
 
 import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"strings"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/dgnsrekt/gexbot-downloader/api"
 	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/stats"
 	"github.com/dgnsrekt/gexbot-downloader/internal/sync"
 	"github.com/dgnsrekt/gexbot-downloader/internal/ws"
 )
@@ -23,6 +27,34 @@ type WebSocketHubs struct {
 	Classic         *ws.Hub
 	StateGreeksZero *ws.Hub
 	StateGreeksOne  *ws.Hub
+	Volatility      *ws.Hub
+}
+
+// DisconnectAll sends every client on every hub a disconnect notice and
+// closes its connection.
+func (h *WebSocketHubs) DisconnectAll(reason string) int {
+	total := 0
+	for _, hub := range []*ws.Hub{h.Orderflow, h.StateGex, h.Classic, h.StateGreeksZero, h.StateGreeksOne, h.Volatility} {
+		if hub != nil {
+			total += hub.DisconnectAll(reason)
+		}
+	}
+	return total
+}
+
+// Drain waits for every configured hub to flush close frames to its clients.
+// Returns the first error encountered (typically a context deadline).
+func (h *WebSocketHubs) Drain(ctx context.Context) error {
+	var firstErr error
+	for _, hub := range []*ws.Hub{h.Orderflow, h.StateGex, h.Classic, h.StateGreeksZero, h.StateGreeksOne, h.Volatility} {
+		if hub == nil {
+			continue
+		}
+		if err := hub.Drain(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
 func NewRouter(server *Server, wsHubs *WebSocketHubs, negotiateHandler *ws.NegotiateHandler, syncBroadcaster *sync.SyncBroadcaster, logger *zap.Logger) (http.Handler, error) {
@@ -39,6 +71,7 @@ func NewRouter(server *Server, wsHubs *WebSocketHubs, negotiateHandler *ws.Negot
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Recoverer)
+	r.Use(panicCounterMiddleware)
 	r.Use(corsMiddleware)
 	r.Use(zapLoggerMiddleware(logger))
 
@@ -47,28 +80,35 @@ func NewRouter(server *Server, wsHubs *WebSocketHubs, negotiateHandler *ws.Negot
 	r.Get("/docs", swaggerUIHandler)
 	r.Get("/swagger-ui.js", swaggerUIBundleHandler)
 	r.Get("/swagger-ui.css", swaggerUICSSHandler)
+	r.Get("/metrics", metricsHandler)
 
 	// WebSocket routes (outside OpenAPI validation)
-	if negotiateHandler != nil {
-		r.Get("/negotiate", negotiateHandler.HandleNegotiate)
-	}
-	if wsHubs != nil {
-		if wsHubs.Orderflow != nil {
-			r.HandleFunc("/ws/orderflow", wsHubs.Orderflow.HandleOrderflowWS)
-		}
-		if wsHubs.StateGex != nil {
-			r.HandleFunc("/ws/state_gex", wsHubs.StateGex.HandleOrderflowWS)
-		}
-		if wsHubs.Classic != nil {
-			r.HandleFunc("/ws/classic", wsHubs.Classic.HandleOrderflowWS)
+	r.Group(func(wsRouter chi.Router) {
+		wsRouter.Use(maintenanceMiddleware(server.maintenance))
+		if negotiateHandler != nil {
+			wsRouter.Get("/negotiate", negotiateHandler.HandleNegotiate)
 		}
-		if wsHubs.StateGreeksZero != nil {
-			r.HandleFunc("/ws/state_greeks_zero", wsHubs.StateGreeksZero.HandleOrderflowWS)
+		if wsHubs != nil {
+			if wsHubs.Orderflow != nil {
+				wsRouter.HandleFunc("/ws/orderflow", wsHubs.Orderflow.HandleOrderflowWS)
+			}
+			if wsHubs.StateGex != nil {
+				wsRouter.HandleFunc("/ws/state_gex", wsHubs.StateGex.HandleOrderflowWS)
+			}
+			if wsHubs.Classic != nil {
+				wsRouter.HandleFunc("/ws/classic", wsHubs.Classic.HandleOrderflowWS)
+			}
+			if wsHubs.StateGreeksZero != nil {
+				wsRouter.HandleFunc("/ws/state_greeks_zero", wsHubs.StateGreeksZero.HandleOrderflowWS)
+			}
+			if wsHubs.StateGreeksOne != nil {
+				wsRouter.HandleFunc("/ws/state_greeks_one", wsHubs.StateGreeksOne.HandleOrderflowWS)
+			}
+			if wsHubs.Volatility != nil {
+				wsRouter.HandleFunc("/ws/volatility", wsHubs.Volatility.HandleOrderflowWS)
+			}
 		}
-		if wsHubs.StateGreeksOne != nil {
-			r.HandleFunc("/ws/state_greeks_one", wsHubs.StateGreeksOne.HandleOrderflowWS)
-		}
-	}
+	})
 
 	// Sync Broadcast System route (SSE stream, outside OpenAPI validation)
 	if syncBroadcaster != nil {
@@ -78,9 +118,19 @@ func NewRouter(server *Server, wsHubs *WebSocketHubs, negotiateHandler *ws.Negot
 	// API routes with compression and OpenAPI validation
 	r.Group(func(apiRouter chi.Router) {
 		apiRouter.Use(middleware.Compress(5))
-		apiRouter.Use(oapimiddleware.OapiRequestValidator(swagger))
+		apiRouter.Use(maintenanceMiddleware(server.maintenance))
+		apiRouter.Use(authHeaderKeyMiddleware)
+		apiRouter.Use(adminTokenMiddleware(server.config.AdminToken))
+		if server.auditLog != nil {
+			apiRouter.Use(server.auditLog.Middleware)
+		}
+		apiRouter.Use(requestValidationMiddleware(oapimiddleware.OapiRequestValidator(swagger), server.config.RequestValidation))
+		apiRouter.Use(serverErrorMiddleware)
 
-		strictHandler := generated.NewStrictHandler(server, nil)
+		strictHandler := generated.NewStrictHandlerWithOptions(server, nil, generated.StrictHTTPServerOptions{
+			RequestErrorHandlerFunc:  countingErrorHandler(logger, http.StatusBadRequest),
+			ResponseErrorHandlerFunc: countingErrorHandler(logger, http.StatusInternalServerError),
+		})
 		generated.HandlerFromMux(strictHandler, apiRouter)
 	})
 
@@ -102,6 +152,158 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// panicCounterMiddleware counts panics before re-raising them for middleware.Recoverer.
+// Must be registered after Recoverer so it sits inside the recovery scope.
+func panicCounterMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				if rvr != http.ErrAbortHandler {
+					stats.RecoveredPanics.Add(1)
+				}
+				panic(rvr)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestValidationMiddleware applies OpenAPI validation according to mode:
+// "all" validates every request, "non-data" skips the high-frequency data
+// endpoints (/{ticker}/{classic,state,orderflow,volatility}/...), "off" skips validation.
+// Skipped requests are still bound by the generated wrappers, which reject
+// missing or malformed parameters.
+func requestValidationMiddleware(validator func(http.Handler) http.Handler, mode string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		validated := validator(next)
+		switch mode {
+		case "off":
+			return next
+		case "non-data":
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if isDataPath(r.URL.Path) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				validated.ServeHTTP(w, r)
+			})
+		default:
+			return validated
+		}
+	}
+}
+
+// isDataPath reports whether a path is a playback data endpoint.
+func isDataPath(path string) bool {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(parts) < 3 || parts[0] == "download" {
+		return false
+	}
+	switch parts[1] {
+	case "classic", "state", "orderflow", "volatility":
+		return true
+	default:
+		return false
+	}
+}
+
+// countingErrorHandler mirrors the strict handler's default error response
+// while counting request errors in stats.HandlerErrors. 500s are counted by
+// serverErrorMiddleware instead.
+func countingErrorHandler(logger *zap.Logger, status int) func(w http.ResponseWriter, r *http.Request, err error) {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		if status != http.StatusInternalServerError {
+			stats.HandlerErrors.Add(1)
+		}
+		logger.Warn("handler error",
+			zap.String("path", r.URL.Path),
+			zap.Int("status", status),
+			zap.Error(err),
+		)
+		http.Error(w, err.Error(), status)
+	}
+}
+
+// serverErrorMiddleware counts 500 responses in stats.HandlerErrors, both the
+// typed 500s handlers return and failures to write a response.
+func serverErrorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+		if ww.Status() == http.StatusInternalServerError {
+			stats.HandlerErrors.Add(1)
+		}
+	})
+}
+
+// adminTokenMiddleware requires "Authorization: Bearer <token>" on the
+// admin routes (see isAdminPath) once a token is configured. Without one,
+// /admin/reload stays disabled and the other admin routes are open.
+func adminTokenMiddleware(token string) func(http.Handler) http.Handler {
+	want := []byte("Bearer " + token)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isAdminPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			switch {
+			case token == "" && r.URL.Path == "/admin/reload":
+				writeAdminError(w, http.StatusForbidden, "admin reload disabled: ADMIN_TOKEN is not set")
+			case token == "":
+				next.ServeHTTP(w, r)
+			case subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1:
+				writeAdminError(w, http.StatusUnauthorized, "missing or invalid admin token")
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// adminRoutes are the operator endpoints outside /admin/.
+var adminRoutes = map[string]bool{
+	"/reset-cache": true,
+	"/reload-date": true,
+}
+
+// isAdminPath reports whether a path is an operator endpoint: guarded by
+// ADMIN_TOKEN and exempt from simulated maintenance.
+func isAdminPath(path string) bool {
+	return adminRoutes[path] || strings.HasPrefix(path, "/admin/")
+}
+
+func writeAdminError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(generated.ErrorResponse{Error: ptr(msg)})
+}
+
+// authHeaderKeyMiddleware copies the API key from "Authorization: Basic <key>"
+// into the "key" query parameter when it is absent, matching the real API.
+// Must run before OpenAPI validation, which requires the query parameter.
+func authHeaderKeyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("key") == "" {
+			if apiKey := apiKeyFromAuthHeader(r); apiKey != "" {
+				query.Set("key", apiKey)
+				r.URL.RawQuery = query.Encode()
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// apiKeyFromAuthHeader extracts the key from "Authorization: Basic <key>".
+func apiKeyFromAuthHeader(r *http.Request) string {
+	authHeader := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authHeader, "Basic ") {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(authHeader, "Basic "))
+}
+
 func zapLoggerMiddleware(logger *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -139,6 +341,11 @@ func maskQueryKey(rawQuery string) string {
 	return strings.Join(parts, "&")
 }
 
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = stats.WritePrometheus(w)
+}
+
 func openapiHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/yaml")
 	_, _ = w.Write(api.OpenAPISpec)
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/stats"
+)
+
+func TestAdminTokenMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		auth  string
+		want  int
+	}{
+		{"no token: reload disabled", "", "/admin/reload", "", http.StatusForbidden},
+		{"no token: other admin routes open", "", "/admin/cache", "", http.StatusOK},
+		{"no token: reload-date open", "", "/reload-date", "", http.StatusOK},
+		{"no token: reset-cache open", "", "/reset-cache", "", http.StatusOK},
+		{"reload with token", "secret", "/admin/reload", "Bearer secret", http.StatusOK},
+		{"reload-date without token", "secret", "/reload-date", "", http.StatusUnauthorized},
+		{"reset-cache without token", "secret", "/reset-cache", "", http.StatusUnauthorized},
+		{"reset-cache with token", "secret", "/reset-cache", "Bearer secret", http.StatusOK},
+		{"admin route with wrong token", "secret", "/admin/cache/bulk", "Bearer nope", http.StatusUnauthorized},
+		{"admin route with token", "secret", "/admin/maintenance", "Bearer secret", http.StatusOK},
+		{"seek without token", "secret", "/admin/seek", "", http.StatusUnauthorized},
+		{"position without token", "secret", "/admin/cache/position", "", http.StatusUnauthorized},
+		{"positions listing without token", "secret", "/admin/cache", "", http.StatusUnauthorized},
+		{"data route needs no token", "secret", "/SPX/classic/full", "", http.StatusOK},
+		{"health needs no token", "secret", "/health", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			adminTokenMiddleware(tt.token)(ok).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerErrorMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    int64
+	}{
+		{"typed 500", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}, 1},
+		{"response error", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "encoding failed", http.StatusInternalServerError)
+		}, 1},
+		{"ok", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("{}"))
+		}, 0},
+		{"not found", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}, 0},
+		{"unavailable", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := stats.HandlerErrors.Load()
+			serverErrorMiddleware(tt.handler).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/SPX/classic/gex_full", nil))
+			if got := stats.HandlerErrors.Load() - before; got != tt.want {
+				t.Errorf("HandlerErrors += %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthHeaderKeyMiddleware(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		auth  string
+		want  string // key query parameter the handler sees
+	}{
+		{"header only", "?limit=1", "Basic abc123", "abc123"},
+		{"query wins over header", "?key=fromquery", "Basic fromheader", "fromquery"},
+		{"header padding trimmed", "", "Basic   abc123  ", "abc123"},
+		{"missing key", "", "", ""},
+		{"bearer is not an API key", "", "Bearer abc123", ""},
+		{"scheme is case sensitive", "", "basic abc123", ""},
+		{"empty basic credentials", "", "Basic ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got, limit string
+			handler := authHeaderKeyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, limit = r.URL.Query().Get("key"), r.URL.Query().Get("limit")
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/SPX/classic/full"+tt.query, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("key = %q, want %q", got, tt.want)
+			}
+			if tt.query == "?limit=1" && limit != "1" {
+				t.Errorf("limit = %q, other parameters lost", limit)
+			}
+		})
+	}
+}
+
+func TestAuthHeaderKeyRoute(t *testing.T) {
+	router, err := NewRouter(newTestServer(t), nil, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		auth string
+		want int
+	}{
+		{"basic header", "Basic abc123", http.StatusOK},
+		// The OpenAPI validator requires the key
+		{"missing key", "", http.StatusBadRequest},
+		{"malformed header", "Token abc123", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/SPX/classic/full", nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestRequestValidationMiddleware(t *testing.T) {
+	// Rejects everything it is asked to validate
+	reject := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		})
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		mode string
+		path string
+		want int
+	}{
+		{"all", "/SPX/classic/full", http.StatusBadRequest},
+		{"all", "/admin/cache", http.StatusBadRequest},
+		{"non-data", "/SPX/classic/full", http.StatusOK},
+		{"non-data", "/SPX/orderflow/orderflow", http.StatusOK},
+		{"non-data", "/download/2025-01-02/SPX/classic", http.StatusBadRequest},
+		{"non-data", "/admin/cache", http.StatusBadRequest},
+		{"off", "/SPX/classic/full", http.StatusOK},
+		{"off", "/admin/cache", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode+" "+tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			requestValidationMiddleware(reject, tt.mode)(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestValidationRoute(t *testing.T) {
+	tests := []struct {
+		mode string
+		path string
+		want int
+	}{
+		{"all", "/SPX/classic/full?key=a", http.StatusOK},
+		// The ticker pattern is upper case
+		{"all", "/spx/classic/full?key=a", http.StatusBadRequest},
+		{"all", "/orderflow/SPX/history?key=a&minutes=0", http.StatusBadRequest},
+		// Unvalidated, the handler finds no data for the ticker
+		{"non-data", "/spx/classic/full?key=a", http.StatusNotFound},
+		{"non-data", "/SPX/classic/full?key=a", http.StatusOK},
+		{"non-data", "/orderflow/SPX/history?key=a&minutes=0", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode+" "+tt.path, func(t *testing.T) {
+			s := newTestServer(t)
+			s.config.RequestValidation = tt.mode
+			router, err := NewRouter(s, nil, nil, nil, zap.NewNop())
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
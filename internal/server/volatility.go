@@ -0,0 +1,191 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api/generated"
+	"github.com/dgnsrekt/gexbot-downloader/internal/audit"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+var volatilityCategories = map[string]bool{"iv_zero": true, "iv_one": true}
+
+// GetVolatility implements generated.StrictServerInterface
+func (s *Server) GetVolatility(ctx context.Context, request generated.GetVolatilityRequestObject) (generated.GetVolatilityResponseObject, error) {
+	ticker := request.Ticker
+	category := string(request.Category)
+	apiKey := request.Params.Key
+	loader, date, positionKey, ok := s.dataForDate(apiKey, request.Params.Date)
+	if !ok {
+		return generated.GetVolatility404JSONResponse{
+			Error: ptr("Date not loaded: " + *request.Params.Date),
+		}, nil
+	}
+	pkg := "volatility"
+
+	s.logger.Debug("volatility request",
+		zap.String("ticker", ticker),
+		zap.String("category", category),
+		zap.String("apiKey", maskAPIKey(apiKey)),
+	)
+
+	if !volatilityCategories[category] {
+		return generated.GetVolatility400JSONResponse{
+			Error: ptr("Invalid category parameter: " + category),
+		}, nil
+	}
+
+	// Check if data exists
+	if !loader.Exists(ticker, pkg, category) {
+		return generated.GetVolatility404JSONResponse{
+			Error: ptr("Data not found for " + ticker + "/volatility/" + category),
+		}, nil
+	}
+
+	// Get data length
+	length, err := loader.GetLength(ticker, pkg, category)
+	if err != nil {
+		return generated.GetVolatility404JSONResponse{
+			Error: ptr(err.Error()),
+		}, nil
+	}
+
+	// Build cache key based on endpoint cache mode
+	var cacheKey string
+	if s.config.EndpointCacheMode == "shared" {
+		cacheKey = data.SharedCacheKey(ticker, pkg, positionKey)
+	} else {
+		cacheKey = data.CacheKey(ticker, pkg, category, positionKey)
+	}
+
+	idx, exhausted := s.advance(ctx, loader, ticker, pkg, category, cacheKey, length, request.Params.Mode)
+	audit.SetIndex(ctx, idx)
+
+	if exhausted {
+		s.logger.Debug("data exhausted",
+			zap.String("cacheKey", maskCacheKey(cacheKey)),
+			zap.Int("index", idx),
+			zap.Int("length", length),
+		)
+		return generated.GetVolatility404JSONResponse{
+			Error: ptr("No more data available"),
+		}, nil
+	}
+
+	// Serve a previously marshalled body for this record if cached
+	respKey := s.responseKey(date, "volatility", ticker, pkg, category, idx)
+	if body, ok := s.responses.Get(respKey); ok {
+		return cachedJSONResponse(body), nil
+	}
+
+	// Get raw data and parse
+	rawData, err := loader.GetRawAtIndex(ctx, ticker, pkg, category, idx)
+	if err != nil {
+		if errors.Is(err, data.ErrIndexOutOfBounds) {
+			return generated.GetVolatility404JSONResponse{
+				Error: ptr("Index out of bounds"),
+			}, nil
+		}
+		return generated.GetVolatility404JSONResponse{
+			Error: ptr(err.Error()),
+		}, nil
+	}
+
+	var volData data.VolatilityData
+	if err := json.Unmarshal(rawData, &volData); err != nil {
+		s.logger.Error("failed to parse volatility data", zap.Error(err))
+		return generated.GetVolatility404JSONResponse{
+			Error: ptr("Failed to parse volatility data"),
+		}, nil
+	}
+
+	var strikes [][]float64
+	if len(volData.Strikes) > 0 {
+		if err := json.Unmarshal(volData.Strikes, &strikes); err != nil {
+			s.logger.Error("failed to parse volatility strikes", zap.Error(err))
+			return generated.GetVolatility404JSONResponse{
+				Error: ptr("Failed to parse volatility data"),
+			}, nil
+		}
+	}
+
+	s.logger.Debug("returning volatility data",
+		zap.String("cacheKey", maskCacheKey(cacheKey)),
+		zap.Int("index", idx),
+		zap.Int64("timestamp", volData.Timestamp),
+	)
+
+	response := generated.GetVolatility200JSONResponse{
+		Timestamp:       volData.Timestamp,
+		Ticker:          volData.Ticker,
+		Spot:            &volData.Spot,
+		MinDte:          &volData.MinDTE,
+		SecMinDte:       &volData.SecMinDTE,
+		AtmIv:           &volData.AtmIV,
+		RiskReversal25d: &volData.RiskReversal25d,
+		Butterfly25d:    &volData.Butterfly25d,
+		Strikes:         &strikes,
+	}
+	if body, ok := s.responses.Store(respKey, response); ok {
+		return cachedJSONResponse(body), nil
+	}
+	return response, nil
+}
+
+// volatilityDownloadResponse wraps downloadFileResponse for volatility data downloads
+type volatilityDownloadResponse struct {
+	downloadFileResponse
+}
+
+func (r *volatilityDownloadResponse) VisitDownloadVolatilityResponse(w http.ResponseWriter) error {
+	return r.serveFile(w)
+}
+
+// DownloadVolatility implements generated.StrictServerInterface
+func (s *Server) DownloadVolatility(ctx context.Context, request generated.DownloadVolatilityRequestObject) (generated.DownloadVolatilityResponseObject, error) {
+	date := request.Date
+	ticker := request.Ticker
+	category := string(request.Category)
+
+	if !volatilityCategories[category] {
+		return generated.DownloadVolatility404JSONResponse{
+			Error: ptr("Invalid category parameter: " + category),
+		}, nil
+	}
+
+	// Construct file path: {DataDir}/{date}/{ticker}/volatility/{category}.jsonl
+	base := filepath.Join(s.config.DataDir, date, ticker, "volatility", category)
+	filePath, ok := data.FindJSONL(base)
+
+	// Check if file exists (plain or zstd-compressed)
+	if !ok {
+		s.logger.Warn("download file not found",
+			zap.String("date", date),
+			zap.String("ticker", ticker),
+			zap.String("category", category),
+			zap.String("filePath", base+data.JSONLExt),
+		)
+		return generated.DownloadVolatility404JSONResponse{
+			Error: ptr(fmt.Sprintf("File not found: %s/%s/volatility/%s.jsonl", date, ticker, category)),
+		}, nil
+	}
+
+	filename := fmt.Sprintf("%s_%s_volatility_%s.jsonl", date, ticker, category)
+
+	s.logger.Info("download volatility request",
+		zap.String("date", date),
+		zap.String("ticker", ticker),
+		zap.String("category", category),
+	)
+
+	return &volatilityDownloadResponse{
+		downloadFileResponse: downloadFileResponse{filePath: filePath, filename: filename},
+	}, nil
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// evictFraction is the share of in-memory keys demoted per check while over the limit.
+const evictFraction = 0.25
+
+// MemoryWatchdog samples process RSS and degrades the server when it exceeds
+// the configured limit: cold MemoryLoader entries are demoted to on-disk reads,
+// and future reloads are switched to stream mode.
+type MemoryWatchdog struct {
+	loader        data.Evictor
+	reloadManager *ReloadManager
+	limitBytes    uint64
+	interval      time.Duration
+	logger        *zap.Logger
+
+	mu          sync.RWMutex
+	rssBytes    uint64
+	degraded    bool
+	evictedKeys int
+}
+
+// MemoryStatus is a point-in-time view of the watchdog state.
+type MemoryStatus struct {
+	RSSBytes    uint64
+	LimitBytes  uint64
+	Degraded    bool
+	EvictedKeys int
+}
+
+// NewMemoryWatchdog creates a watchdog with the given RSS limit in MiB.
+func NewMemoryWatchdog(loader data.Evictor, reloadManager *ReloadManager, limitMB int, interval time.Duration, logger *zap.Logger) *MemoryWatchdog {
+	return &MemoryWatchdog{
+		loader:        loader,
+		reloadManager: reloadManager,
+		limitBytes:    uint64(limitMB) * 1024 * 1024,
+		interval:      interval,
+		logger:        logger,
+	}
+}
+
+// Run samples RSS on each interval until ctx is cancelled. Call in a goroutine.
+func (w *MemoryWatchdog) Run(ctx context.Context) {
+	w.logger.Info("memory watchdog started",
+		zap.Uint64("limitBytes", w.limitBytes),
+		zap.Duration("interval", w.interval),
+	)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("memory watchdog stopping")
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// check samples RSS and evicts cold data if the limit is exceeded.
+func (w *MemoryWatchdog) check() {
+	rss := readRSS()
+
+	w.mu.Lock()
+	w.rssBytes = rss
+	w.mu.Unlock()
+
+	if rss <= w.limitBytes {
+		return
+	}
+
+	evicted := w.loader.EvictCold(evictFraction)
+	if w.reloadManager != nil {
+		w.reloadManager.ForceStreamMode()
+	}
+
+	// Return freed pages to the OS so RSS actually drops
+	debug.FreeOSMemory()
+
+	w.mu.Lock()
+	w.degraded = true
+	w.evictedKeys += evicted
+	total := w.evictedKeys
+	w.mu.Unlock()
+
+	w.logger.Warn("memory limit exceeded, degrading",
+		zap.Uint64("rssBytes", rss),
+		zap.Uint64("limitBytes", w.limitBytes),
+		zap.Int("evictedKeys", evicted),
+		zap.Int("totalEvictedKeys", total),
+	)
+}
+
+// Status returns the latest watchdog state.
+func (w *MemoryWatchdog) Status() MemoryStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return MemoryStatus{
+		RSSBytes:    w.rssBytes,
+		LimitBytes:  w.limitBytes,
+		Degraded:    w.degraded,
+		EvictedKeys: w.evictedKeys,
+	}
+}
+
+// readRSS returns the resident set size of the current process.
+// Uses /proc/self/statm on Linux and falls back to Go runtime stats elsewhere.
+func readRSS() uint64 {
+	if raw, err := os.ReadFile("/proc/self/statm"); err == nil {
+		fields := strings.Fields(string(raw))
+		if len(fields) >= 2 {
+			if pages, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+				return pages * uint64(os.Getpagesize())
+			}
+		}
+	}
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.Sys - ms.HeapReleased
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+type fakeEvictor struct {
+	fractions []float64
+}
+
+func (f *fakeEvictor) EvictCold(fraction float64) int {
+	f.fractions = append(f.fractions, fraction)
+	return 3
+}
+
+// writeDate writes a one-record data file for date under dir.
+func writeDate(t *testing.T, dir, date string) {
+	t.Helper()
+	pkgDir := filepath.Join(dir, date, "SPX", "classic")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pkgDir, "gex_full.jsonl"), []byte(`{"timestamp":1}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestReloadManager serves date1 from memory, with date2 available to reload.
+func newTestReloadManager(t *testing.T) *ReloadManager {
+	t.Helper()
+	dir := t.TempDir()
+	writeDate(t, dir, "2025-01-02")
+	writeDate(t, dir, "2025-01-03")
+
+	initial, err := data.NewMemoryLoader(dir, "2025-01-02", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.ServerConfig{DataDir: dir, DataDate: "2025-01-02", DataMode: "memory"}
+	rm := NewReloadManager(data.NewReloadableLoader(initial), data.NewIndexCache(data.CacheModeExhaust), cfg, zap.NewNop())
+	t.Cleanup(func() { _ = rm.Close() })
+	return rm
+}
+
+func TestMemoryWatchdogDegradesOverLimit(t *testing.T) {
+	evictor := &fakeEvictor{}
+	rm := newTestReloadManager(t)
+
+	// A zero limit is always exceeded
+	w := NewMemoryWatchdog(evictor, rm, 0, time.Minute, zap.NewNop())
+	w.check()
+	w.check()
+
+	if len(evictor.fractions) != 2 || evictor.fractions[0] != evictFraction {
+		t.Errorf("EvictCold calls = %v, want two of %v", evictor.fractions, evictFraction)
+	}
+	status := w.Status()
+	if !status.Degraded || status.EvictedKeys != 6 || status.RSSBytes == 0 {
+		t.Errorf("status = %+v, want degraded with 6 evicted keys", status)
+	}
+	if !rm.StreamModeForced() {
+		t.Error("stream mode not forced")
+	}
+}
+
+func TestMemoryWatchdogUnderLimit(t *testing.T) {
+	evictor := &fakeEvictor{}
+	w := NewMemoryWatchdog(evictor, nil, 1<<20, time.Minute, zap.NewNop())
+	w.check()
+
+	if len(evictor.fractions) != 0 || w.Status().Degraded {
+		t.Errorf("under the limit: evictions = %v, status = %+v", evictor.fractions, w.Status())
+	}
+}
+
+func TestReloadUsesForcedStreamMode(t *testing.T) {
+	rm := newTestReloadManager(t)
+	rm.ForceStreamMode()
+
+	if _, err := rm.Reload(context.Background(), "2025-01-03"); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	// A stream loader holds nothing in memory to evict
+	if n := rm.loader.EvictCold(1); n != 0 {
+		t.Errorf("EvictCold after reload = %d, want 0 from a stream loader", n)
+	}
+	if length, err := rm.loader.GetLength("SPX", "classic", "gex_full"); err != nil || length != 1 {
+		t.Errorf("GetLength after reload = %d, %v; want 1", length, err)
+	}
+}
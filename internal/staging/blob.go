@@ -0,0 +1,307 @@
+package staging
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// blobAPIVersion is the Blob service version requests are made against.
+const blobAPIVersion = "2021-08-06"
+
+// BlobCredentials authenticate requests to Azure Blob Storage, with either
+// the account's Shared Key or a SAS token.
+type BlobCredentials struct {
+	AccountKey string // base64, as shown in the portal
+	SASToken   string // without the leading ?
+	Endpoint   string // empty uses https://{account}.blob.core.windows.net
+}
+
+// BlobCredentialsFromEnv reads AZURE_STORAGE_KEY (or
+// AZURE_STORAGE_ACCOUNT_KEY), AZURE_STORAGE_SAS_TOKEN and, for Azurite or
+// sovereign clouds, AZURE_STORAGE_ENDPOINT.
+func BlobCredentialsFromEnv() BlobCredentials {
+	return BlobCredentials{
+		AccountKey: firstEnv("AZURE_STORAGE_KEY", "AZURE_STORAGE_ACCOUNT_KEY"),
+		SASToken:   strings.TrimPrefix(os.Getenv("AZURE_STORAGE_SAS_TOKEN"), "?"),
+		Endpoint:   os.Getenv("AZURE_STORAGE_ENDPOINT"),
+	}
+}
+
+// BlobStorage stores files as block blobs in an Azure Storage container.
+type BlobStorage struct {
+	account   string
+	container string
+	prefix    string
+	key       []byte // decoded Shared Key; nil with a SAS token
+	sas       string
+	client    *http.Client
+	baseURL   *url.URL
+}
+
+// NewBlobStorage creates storage for account/container/prefix.
+func NewBlobStorage(account, container, prefix string, creds BlobCredentials) (*BlobStorage, error) {
+	s := &BlobStorage{
+		account:   account,
+		container: container,
+		prefix:    prefix,
+		sas:       creds.SASToken,
+		client:    &http.Client{Timeout: 10 * time.Minute},
+	}
+	switch {
+	case creds.AccountKey != "":
+		key, err := base64.StdEncoding.DecodeString(creds.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("AZURE_STORAGE_KEY is not valid base64: %w", err)
+		}
+		s.key = key
+	case s.sas == "":
+		return nil, fmt.Errorf("azblob:// output requires AZURE_STORAGE_KEY or AZURE_STORAGE_SAS_TOKEN")
+	}
+
+	endpoint := creds.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", account)
+	}
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid storage endpoint %q", endpoint)
+	}
+	s.baseURL = u
+	return s, nil
+}
+
+func (s *BlobStorage) String() string {
+	uri := "azblob://" + s.account + "/" + s.container
+	if s.prefix != "" {
+		uri += "/" + s.prefix
+	}
+	return uri
+}
+
+// blobName returns the blob name for a key relative to the prefix.
+func (s *BlobStorage) blobName(key string) string {
+	return path.Join(s.prefix, key)
+}
+
+// blobURL returns the URL of a key, with the SAS token when it is used.
+func (s *BlobStorage) blobURL(key string) *url.URL {
+	u := *s.baseURL
+	u.Path = s.baseURL.Path + "/" + s.container + "/" + s.blobName(key)
+	if s.key == nil {
+		u.RawQuery = s.sas
+	}
+	return &u
+}
+
+func (s *BlobStorage) Exists(ctx context.Context, key string) (bool, error) {
+	resp, err := s.do(ctx, http.MethodHead, key, nil, -1, nil)
+	if err != nil {
+		return false, err
+	}
+	_ = resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode/100 == 2:
+		return true, nil
+	default:
+		return false, fmt.Errorf("HEAD %s: %s", s.blobName(key), resp.Status)
+	}
+}
+
+func (s *BlobStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %w", s.blobName(key), os.ErrNotExist)
+	case resp.StatusCode/100 == 2:
+		return resp.Body, nil
+	default:
+		return nil, checkResponse(resp, "GET "+s.blobName(key))
+	}
+}
+
+func (s *BlobStorage) Upload(ctx context.Context, src, key string) error {
+	return s.UploadChecksum(ctx, src, key, nil)
+}
+
+// UploadChecksum stores src at key as a single Put Blob (up to 5000 MiB).
+// With md5sum, the service rejects a body that does not match it.
+func (s *BlobStorage) UploadChecksum(ctx context.Context, src, key string, md5sum []byte) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{"X-Ms-Blob-Type": {"BlockBlob"}}
+	if md5sum != nil {
+		header.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5sum))
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, f, info.Size(), header)
+	if err != nil {
+		return err
+	}
+	return checkResponse(resp, "PUT "+s.blobName(key))
+}
+
+// Rename copies src to dst server-side, waits for the copy to finish and
+// deletes src.
+func (s *BlobStorage) Rename(ctx context.Context, src, dst string) error {
+	header := http.Header{"X-Ms-Copy-Source": {s.blobURL(src).String()}}
+	resp, err := s.do(ctx, http.MethodPut, dst, nil, 0, header)
+	if err != nil {
+		return err
+	}
+	status := resp.Header.Get("X-Ms-Copy-Status")
+	op := "COPY " + s.blobName(src) + " to " + s.blobName(dst)
+	if err := checkResponse(resp, op); err != nil {
+		return err
+	}
+
+	// Copies within an account usually finish at once; poll the rest
+	for status == "pending" {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+		resp, err := s.do(ctx, http.MethodHead, dst, nil, -1, nil)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("%s: %s", op, resp.Status)
+		}
+		status = resp.Header.Get("X-Ms-Copy-Status")
+	}
+	if status != "" && status != "success" {
+		return fmt.Errorf("%s: copy %s", op, status)
+	}
+	return s.Delete(ctx, src)
+}
+
+func (s *BlobStorage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, -1, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil
+	}
+	return checkResponse(resp, "DELETE "+s.blobName(key))
+}
+
+func (s *BlobStorage) do(ctx context.Context, method, key string, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.blobURL(key).String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("X-Ms-Date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("X-Ms-Version", blobAPIVersion)
+	if s.key != nil {
+		signSharedKey(req, s.account, s.key)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, s.blobName(key), err)
+	}
+	return resp, nil
+}
+
+// signSharedKey adds a Shared Key Authorization header to req. Every x-ms-*
+// header and query parameter is signed.
+func signSharedKey(req *http.Request, account string, key []byte) {
+	// Content-Length is empty rather than 0 as of version 2015-02-21
+	length := ""
+	if req.ContentLength > 0 {
+		length = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	headers := make(map[string]string)
+	for k, v := range req.Header {
+		if lk := strings.ToLower(k); strings.HasPrefix(lk, "x-ms-") {
+			headers[lk] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, h := range []string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		length,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		req.Header.Get("Date"),
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	} {
+		b.WriteString(h + "\n")
+	}
+	for _, k := range names {
+		b.WriteString(k + ":" + headers[k] + "\n")
+	}
+
+	b.WriteString("/" + account + req.URL.EscapedPath())
+	query := make(map[string][]string)
+	for k, v := range req.URL.Query() {
+		lk := strings.ToLower(k)
+		query[lk] = append(query[lk], v...)
+	}
+	params := make([]string, 0, len(query))
+	for k := range query {
+		params = append(params, k)
+	}
+	sort.Strings(params)
+	for _, k := range params {
+		vs := query[k]
+		sort.Strings(vs)
+		b.WriteString("\n" + k + ":" + strings.Join(vs, ","))
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(b.String()))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	req.Header.Set("Authorization", "SharedKey "+account+":"+signature)
+}
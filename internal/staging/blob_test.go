@@ -0,0 +1,125 @@
+package staging
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeBlob is a minimal Azure Blob service for the devstoreaccount1
+// account, addressed like Azurite.
+type fakeBlob struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func (f *fakeBlob) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey devstoreaccount1:") || r.Header.Get("X-Ms-Version") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/devstoreaccount1/")
+	switch r.Method {
+	case http.MethodHead:
+		if _, ok := f.blobs[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case http.MethodGet:
+		data, ok := f.blobs[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodPut:
+		if src := r.Header.Get("X-Ms-Copy-Source"); src != "" {
+			u, _ := http.NewRequest(http.MethodGet, src, nil)
+			f.blobs[name] = f.blobs[strings.TrimPrefix(u.URL.Path, "/devstoreaccount1/")]
+			w.Header().Set("X-Ms-Copy-Status", "success")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if r.Header.Get("X-Ms-Blob-Type") != "BlockBlob" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		if want := r.Header.Get("Content-MD5"); want != "" {
+			sum := md5.Sum(data)
+			if base64.StdEncoding.EncodeToString(sum[:]) != want {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, "<Error><Code>Md5Mismatch</Code><Message>mismatch</Message></Error>")
+				return
+			}
+		}
+		f.blobs[name] = data
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		if _, ok := f.blobs[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func TestBlobStorage(t *testing.T) {
+	fake := &fakeBlob{blobs: make(map[string][]byte)}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	storage, err := NewBlobStorage("devstoreaccount1", "archive", "gexbot", BlobCredentials{
+		AccountKey: base64.StdEncoding.EncodeToString([]byte("secret")),
+		Endpoint:   srv.URL + "/devstoreaccount1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := storage.String(); got != "azblob://devstoreaccount1/archive/gexbot" {
+		t.Errorf("String() = %q", got)
+	}
+
+	src := filepath.Join(t.TempDir(), "gex_zero.jsonl")
+	if err := os.WriteFile(src, []byte("payload"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	sum := md5.Sum([]byte("payload"))
+	if err := storage.UploadChecksum(ctx, src, ".staging/2025-11-14/gex_zero.jsonl", sum[:]); err != nil {
+		t.Fatalf("UploadChecksum() error = %v", err)
+	}
+	bad := md5.Sum([]byte("other"))
+	if err := storage.UploadChecksum(ctx, src, "2025-11-14/bad.jsonl", bad[:]); err == nil || !strings.Contains(err.Error(), "Md5Mismatch") {
+		t.Errorf("UploadChecksum() with a wrong MD5 error = %v", err)
+	}
+
+	if err := storage.Rename(ctx, ".staging/2025-11-14/gex_zero.jsonl", "2025-11-14/gex_zero.jsonl"); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if ok, err := storage.Exists(ctx, ".staging/2025-11-14/gex_zero.jsonl"); err != nil || ok {
+		t.Errorf("renamed source still exists: %v, %v", ok, err)
+	}
+	rc, err := storage.Open(ctx, "2025-11-14/gex_zero.jsonl")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+	if data, _ := io.ReadAll(rc); string(data) != "payload" {
+		t.Errorf("Open() read %q", data)
+	}
+	if _, ok := fake.blobs["archive/gexbot/2025-11-14/gex_zero.jsonl"]; !ok {
+		t.Errorf("blob not stored under container/prefix: %v", fake.blobs)
+	}
+}
@@ -0,0 +1,73 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LockFile is the file in the staging root that runs lock.
+const LockFile = ".lock"
+
+// lockPollInterval is how often a waiting run retries the lock.
+const lockPollInterval = time.Second
+
+var errLockUnsupported = errors.New("file locking not supported on this platform")
+
+// Lock takes an exclusive advisory lock on the staging area, so a manual
+// download and the daemon never stage or commit at the same time. While
+// another process holds it, Lock waits until ctx is done, calling waiting
+// once with the holder as "pid program". The returned function releases
+// the lock. Where file locking is unsupported, Lock does not lock.
+func (m *Manager) Lock(ctx context.Context, waiting func(holder string)) (unlock func(), err error) {
+	if err := os.MkdirAll(m.stagingRoot, 0750); err != nil {
+		return nil, fmt.Errorf("creating staging directory: %w", err)
+	}
+	path := filepath.Join(m.stagingRoot, LockFile)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("opening lock file: %w", err)
+	}
+
+	for notified := false; ; {
+		locked, err := lockFile(f)
+		if errors.Is(err, errLockUnsupported) {
+			_ = f.Close()
+			return func() {}, nil
+		}
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("locking %s: %w", path, err)
+		}
+		if locked {
+			break
+		}
+		if !notified && waiting != nil {
+			holder, _ := os.ReadFile(path)
+			waiting(strings.TrimSpace(string(holder)))
+			notified = true
+		}
+		select {
+		case <-ctx.Done():
+			_ = f.Close()
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+
+	// Name the holder for runs that wait
+	holder := strconv.Itoa(os.Getpid()) + " " + filepath.Base(os.Args[0]) + "\n"
+	if err := f.Truncate(0); err == nil {
+		_, _ = f.WriteAt([]byte(holder), 0)
+	}
+	return func() {
+		_ = f.Truncate(0)
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
@@ -0,0 +1,23 @@
+//go:build linux || darwin || freebsd
+
+package staging
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockFile tries to take an exclusive flock on f without blocking and
+// reports whether it did.
+func lockFile(f *os.File) (bool, error) {
+	err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
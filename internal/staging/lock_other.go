@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd
+
+package staging
+
+import "os"
+
+func lockFile(*os.File) (bool, error) {
+	return false, errLockUnsupported
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
@@ -0,0 +1,106 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+// stagedFiles describes the data files in a date's staging directory for the
+// manifest. Temp files and anything not laid out as
+// {ticker}/{package}/{category}.{ext} are left out.
+func stagedFiles(stagingDir string) ([]manifest.File, error) {
+	var files []manifest.File
+	err := filepath.Walk(stagingDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(path, ".tmp") || info.Name() == manifest.Name {
+			return nil
+		}
+		rel, err := filepath.Rel(stagingDir, path)
+		if err != nil {
+			return err
+		}
+		if !manifest.IsDataPath(rel) {
+			return nil
+		}
+		f, err := manifest.NewFile(stagingDir, rel)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return files, err
+}
+
+// mergeManifest adds files to m, creating it when nil.
+func mergeManifest(m *manifest.Manifest, date string, files []manifest.File) *manifest.Manifest {
+	if m == nil {
+		m = manifest.New(date)
+	}
+	for _, f := range files {
+		m.Set(f)
+	}
+	return m
+}
+
+// updateManifest merges files into the local manifest of a committed date.
+func (m *Manager) updateManifest(date string, files []manifest.File) error {
+	if len(files) == 0 {
+		return nil
+	}
+	path := filepath.Join(m.baseDir, date, manifest.Name)
+	existing, err := manifest.Read(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+	if err := mergeManifest(existing, date, files).Write(path); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return nil
+}
+
+// updateRemoteManifest merges files into a date's manifest in remote
+// storage. The merged manifest is written to the staging directory and
+// committed like any other file.
+func (m *Manager) updateRemoteManifest(ctx context.Context, date string, files []manifest.File) error {
+	if len(files) == 0 {
+		return nil
+	}
+	key := date + "/" + manifest.Name
+
+	var existing *manifest.Manifest
+	r, err := m.storage.Open(ctx, key)
+	switch {
+	case err == nil:
+		existing, err = manifest.Decode(r)
+		_ = r.Close()
+		if err != nil {
+			return err
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+
+	local := filepath.Join(m.StagingDir(date), manifest.Name)
+	if err := mergeManifest(existing, date, files).Write(local); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	if err := m.storage.Upload(ctx, local, ".staging/"+key); err != nil {
+		return fmt.Errorf("uploading %s: %w", key, err)
+	}
+	if err := m.storage.Rename(ctx, ".staging/"+key, key); err != nil {
+		return fmt.Errorf("committing %s: %w", key, err)
+	}
+	return os.Remove(local)
+}
@@ -0,0 +1,354 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// unsignedPayload skips hashing upload bodies; TLS protects them in transit.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// ObjectCredentials authenticate requests to an S3-compatible API.
+type ObjectCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // optional, for temporary AWS credentials
+	Region          string
+	Endpoint        string // empty uses the provider default
+}
+
+// ObjectCredentialsFromEnv reads credentials for a storage scheme.
+//
+// s3: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN,
+// AWS_REGION (or AWS_DEFAULT_REGION, default us-east-1) and
+// AWS_ENDPOINT_URL_S3 (or AWS_ENDPOINT_URL) for S3-compatible stores such
+// as MinIO.
+//
+// gs: GCS_ACCESS_KEY_ID and GCS_SECRET_ACCESS_KEY, an HMAC key of a service
+// account with write access to the bucket.
+func ObjectCredentialsFromEnv(scheme string) ObjectCredentials {
+	if scheme == "gs" {
+		return ObjectCredentials{
+			AccessKeyID:     os.Getenv("GCS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("GCS_SECRET_ACCESS_KEY"),
+			Region:          "auto",
+			Endpoint:        "https://storage.googleapis.com",
+		}
+	}
+
+	creds := ObjectCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		Region:          firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"),
+		Endpoint:        firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"),
+	}
+	if creds.Region == "" {
+		creds.Region = "us-east-1"
+	}
+	return creds
+}
+
+func firstEnv(keys ...string) string {
+	for _, k := range keys {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ObjectStorage stores files in an S3 bucket, or a GCS bucket through its
+// S3-compatible XML API. Requests are signed with AWS Signature Version 4.
+type ObjectStorage struct {
+	scheme string
+	bucket string
+	prefix string
+	creds  ObjectCredentials
+	client *http.Client
+
+	// Virtual-hosted style (bucket in the host) for AWS; path style for
+	// custom endpoints and GCS.
+	baseURL    *url.URL
+	pathPrefix string
+}
+
+// NewObjectStorage creates storage for bucket/prefix. scheme is s3 or gs.
+func NewObjectStorage(scheme, bucket, prefix string, creds ObjectCredentials) (*ObjectStorage, error) {
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		if scheme == "gs" {
+			return nil, fmt.Errorf("gs:// output requires GCS_ACCESS_KEY_ID and GCS_SECRET_ACCESS_KEY (an HMAC key)")
+		}
+		return nil, fmt.Errorf("s3:// output requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+
+	s := &ObjectStorage{
+		scheme: scheme,
+		bucket: bucket,
+		prefix: prefix,
+		creds:  creds,
+		client: &http.Client{Timeout: 10 * time.Minute},
+	}
+
+	endpoint := creds.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, creds.Region)
+	} else {
+		s.pathPrefix = "/" + bucket
+	}
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid storage endpoint %q", endpoint)
+	}
+	s.baseURL = u
+	return s, nil
+}
+
+func (s *ObjectStorage) String() string {
+	if s.prefix == "" {
+		return s.scheme + "://" + s.bucket
+	}
+	return s.scheme + "://" + s.bucket + "/" + s.prefix
+}
+
+// objectKey returns the bucket key for a key relative to the prefix.
+func (s *ObjectStorage) objectKey(key string) string {
+	return path.Join(s.prefix, key)
+}
+
+func (s *ObjectStorage) Exists(ctx context.Context, key string) (bool, error) {
+	resp, err := s.do(ctx, http.MethodHead, key, nil, -1, nil)
+	if err != nil {
+		return false, err
+	}
+	_ = resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode/100 == 2:
+		return true, nil
+	default:
+		return false, fmt.Errorf("HEAD %s: %s", s.objectKey(key), resp.Status)
+	}
+}
+
+func (s *ObjectStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %w", s.objectKey(key), os.ErrNotExist)
+	case resp.StatusCode/100 == 2:
+		return resp.Body, nil
+	default:
+		return nil, checkResponse(resp, "GET "+s.objectKey(key))
+	}
+}
+
+func (s *ObjectStorage) Upload(ctx context.Context, src, key string) error {
+	return s.UploadChecksum(ctx, src, key, nil)
+}
+
+// UploadChecksum stores src at key in a single PUT (up to 5 GB). With
+// md5sum, the service rejects a body that does not match it.
+func (s *ObjectStorage) UploadChecksum(ctx context.Context, src, key string, md5sum []byte) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	var header http.Header
+	if md5sum != nil {
+		header = http.Header{"Content-Md5": {base64.StdEncoding.EncodeToString(md5sum)}}
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, f, info.Size(), header)
+	if err != nil {
+		return err
+	}
+	return checkResponse(resp, "PUT "+s.objectKey(key))
+}
+
+// Rename copies src to dst server-side and deletes src. Single-request
+// copies are limited to 5 GB.
+func (s *ObjectStorage) Rename(ctx context.Context, src, dst string) error {
+	source := "/" + s.bucket + "/" + s.objectKey(src)
+	header := http.Header{"X-Amz-Copy-Source": {(&url.URL{Path: source}).EscapedPath()}}
+	resp, err := s.do(ctx, http.MethodPut, dst, nil, 0, header)
+	if err != nil {
+		return err
+	}
+	if err := checkResponse(resp, "COPY "+s.objectKey(src)+" to "+s.objectKey(dst)); err != nil {
+		return err
+	}
+	return s.Delete(ctx, src)
+}
+
+func (s *ObjectStorage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, -1, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil
+	}
+	return checkResponse(resp, "DELETE "+s.objectKey(key))
+}
+
+func (s *ObjectStorage) do(ctx context.Context, method, key string, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	u := *s.baseURL
+	u.Path = s.pathPrefix + "/" + s.objectKey(key)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	signV4(req, s.creds, "s3", unsignedPayload, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, s.objectKey(key), err)
+	}
+	return resp, nil
+}
+
+// checkResponse consumes resp and returns an error for failures, including
+// copy errors that S3 reports in the body of a 200 response.
+func checkResponse(resp *http.Response, op string) error {
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	var s3err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	isError := bytes.Contains(body, []byte("<Error>")) && xml.Unmarshal(body, &s3err) == nil
+	if resp.StatusCode/100 == 2 && !isError {
+		return nil
+	}
+	if isError {
+		return fmt.Errorf("%s: %s: %s (%s)", op, resp.Status, s3err.Code, s3err.Message)
+	}
+	return fmt.Errorf("%s: %s", op, resp.Status)
+}
+
+// signV4 adds AWS Signature Version 4 headers to req. Host and every
+// X-Amz-* header are signed.
+func signV4(req *http.Request, creds ObjectCredentials, service, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		if lk := strings.ToLower(k); strings.HasPrefix(lk, "x-amz-") {
+			headers[lk] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalPath := req.URL.EscapedPath()
+	if canonicalPath == "" {
+		canonicalPath = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + creds.Region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hashHex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), day)
+	key = hmacSHA256(key, creds.Region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsEscape percent-encodes everything but unreserved characters.
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hashHex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
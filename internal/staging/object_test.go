@@ -0,0 +1,162 @@
+package staging
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+func TestSignV4(t *testing.T) {
+	// get-vanilla from the AWS Signature Version 4 test suite
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	creds := ObjectCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		Region:          "us-east-1",
+	}
+	emptyHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	signV4(req, creds, "service", emptyHash, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// fakeS3 is a minimal path-style S3 server.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	ops     []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	key := r.URL.Path
+	switch r.Method {
+	case http.MethodHead:
+		if _, ok := f.objects[key]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodPut:
+		if src := r.Header.Get("X-Amz-Copy-Source"); src != "" {
+			f.ops = append(f.ops, "copy "+src+" "+key)
+			data, ok := f.objects[src]
+			if !ok {
+				w.WriteHeader(http.StatusOK)
+				_, _ = io.WriteString(w, "<Error><Code>NoSuchKey</Code><Message>missing</Message></Error>")
+				return
+			}
+			f.objects[key] = data
+			return
+		}
+		f.ops = append(f.ops, "put "+key)
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+	case http.MethodDelete:
+		f.ops = append(f.ops, "delete "+key)
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestRemoteCommit(t *testing.T) {
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	storage, err := NewObjectStorage("s3", "bucket", "gex", ObjectCredentials{
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		Region:          "us-east-1",
+		Endpoint:        srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workDir := t.TempDir()
+	mgr := NewRemoteManager(workDir, storage)
+	var prepared string
+	mgr.SetPrepare(func(dir string) error {
+		prepared = dir
+		return nil
+	})
+
+	staged := filepath.Join(mgr.StagingDir("2025-11-14"), "SPX", "state", "gex_zero.json")
+	if err := os.MkdirAll(filepath.Dir(staged), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(staged, []byte(`[{"timestamp":1}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mgr.CommitStaging("2025-11-14"); err != nil {
+		t.Fatalf("CommitStaging: %v", err)
+	}
+
+	if prepared != mgr.StagingDir("2025-11-14") {
+		t.Errorf("prepare ran on %q", prepared)
+	}
+	wantOps := []string{
+		"put /bucket/gex/.staging/2025-11-14/SPX/state/gex_zero.json",
+		"copy /bucket/gex/.staging/2025-11-14/SPX/state/gex_zero.json /bucket/gex/2025-11-14/SPX/state/gex_zero.json",
+		"delete /bucket/gex/.staging/2025-11-14/SPX/state/gex_zero.json",
+		"put /bucket/gex/.staging/2025-11-14/manifest.json",
+		"copy /bucket/gex/.staging/2025-11-14/manifest.json /bucket/gex/2025-11-14/manifest.json",
+		"delete /bucket/gex/.staging/2025-11-14/manifest.json",
+	}
+	if strings.Join(fake.ops, "\n") != strings.Join(wantOps, "\n") {
+		t.Errorf("ops =\n%s\nwant\n%s", strings.Join(fake.ops, "\n"), strings.Join(wantOps, "\n"))
+	}
+	if got := string(fake.objects["/bucket/gex/2025-11-14/SPX/state/gex_zero.json"]); got != `[{"timestamp":1}]` {
+		t.Errorf("committed object = %q", got)
+	}
+	m, err := manifest.Decode(bytes.NewReader(fake.objects["/bucket/gex/2025-11-14/manifest.json"]))
+	if err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	if len(m.Files) != 1 || m.Files[0].Path != "SPX/state/gex_zero.json" || m.Files[0].Size != 17 {
+		t.Errorf("manifest files = %+v", m.Files)
+	}
+	if _, err := os.Stat(staged); !os.IsNotExist(err) {
+		t.Errorf("staged file not removed after upload")
+	}
+
+	ctx := t.Context()
+	if ok, err := mgr.Exists(ctx, filepath.Join("2025-11-14", "SPX", "state", "gex_zero.json")); err != nil || !ok {
+		t.Errorf("Exists = %v, %v; want true", ok, err)
+	}
+	if ok, err := mgr.Exists(ctx, filepath.Join("2025-11-14", "SPX", "state", "gex_one.json")); err != nil || ok {
+		t.Errorf("Exists(missing) = %v, %v; want false", ok, err)
+	}
+
+	// A copy error reported in a 200 body fails the rename
+	if err := storage.Rename(ctx, "nope", "dst"); err == nil || !strings.Contains(err.Error(), "NoSuchKey") {
+		t.Errorf("Rename of missing key: %v", err)
+	}
+}
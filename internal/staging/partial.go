@@ -0,0 +1,84 @@
+package staging
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api"
+)
+
+// PartialDir is the directory under the staging root that keeps interrupted
+// downloads, so a later run can resume them with a Range request instead of
+// starting over. Each file has a {file}.resume record next to it.
+const PartialDir = "partial"
+
+// partialRecord describes a kept partial download.
+type partialRecord struct {
+	Size int64 `json:"size"`
+	api.Validators
+}
+
+// partialPath returns where the partial download of a staged file is kept.
+func (m *Manager) partialPath(destPath string) (string, bool) {
+	rel, err := filepath.Rel(m.stagingRoot, destPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return filepath.Join(m.stagingRoot, PartialDir, rel), true
+}
+
+// takePartial moves the partial download of destPath, if any, to tmpPath and
+// returns the number of bytes it holds and the validators of the remote file
+// they came from. A partial that cannot be resumed is discarded.
+func (m *Manager) takePartial(destPath, tmpPath string) (int64, api.Validators) {
+	path, ok := m.partialPath(destPath)
+	if !ok {
+		return 0, api.Validators{}
+	}
+	data, err := os.ReadFile(path + ".resume")
+	if err != nil {
+		return 0, api.Validators{}
+	}
+	_ = os.Remove(path + ".resume")
+
+	var rec partialRecord
+	info, statErr := os.Stat(path)
+	if json.Unmarshal(data, &rec) != nil || statErr != nil || info.Size() < rec.Size || rec.Size <= 0 || !rec.CanResume() {
+		_ = os.Remove(path)
+		return 0, api.Validators{}
+	}
+	if err := os.Rename(path, tmpPath); err != nil {
+		_ = os.Remove(path)
+		return 0, api.Validators{}
+	}
+	return rec.Size, rec.Validators
+}
+
+// keepPartial moves an interrupted download from tmpPath into the partial
+// directory when err says its bytes can be resumed. It reports whether the
+// file was kept.
+func (m *Manager) keepPartial(destPath, tmpPath string, err error) bool {
+	var partialErr *api.PartialDownloadError
+	if !errors.As(err, &partialErr) {
+		return false
+	}
+	path, ok := m.partialPath(destPath)
+	if !ok {
+		return false
+	}
+	data, jsonErr := json.Marshal(partialRecord{Size: partialErr.Written, Validators: partialErr.Validators})
+	if jsonErr != nil {
+		return false
+	}
+	if os.MkdirAll(filepath.Dir(path), 0750) != nil || os.Rename(tmpPath, path) != nil {
+		return false
+	}
+	if os.WriteFile(path+".resume", data, 0600) != nil {
+		_ = os.Remove(path)
+		return false
+	}
+	return true
+}
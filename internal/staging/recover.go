@@ -0,0 +1,168 @@
+package staging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+// RecoveryResult summarizes what Recover did with leftover staging data.
+type RecoveryResult struct {
+	Dates     []string // staging dates that were found and resolved
+	Committed int      // complete files moved to the final directory
+	Discarded int      // partial, invalid or superseded files removed
+}
+
+// Recover resolves staging data left behind by a process that died between
+// download and commit. Complete files that parse as JSON are committed unless
+// the final directory already has that file; temp files from interrupted
+// downloads and invalid files are discarded; downloads kept for resuming in
+// the partial directory are left alone. Each date's staging directory is
+// removed afterwards.
+func (m *Manager) Recover() (*RecoveryResult, error) {
+	result := &RecoveryResult{}
+
+	entries, err := os.ReadDir(m.stagingRoot)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading staging root: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == RejectedDir || entry.Name() == PartialDir {
+			continue
+		}
+		date := entry.Name()
+		if err := m.recoverDate(date, result); err != nil {
+			return result, fmt.Errorf("recovering %s: %w", date, err)
+		}
+		result.Dates = append(result.Dates, date)
+	}
+	sort.Strings(result.Dates)
+
+	return result, nil
+}
+
+func (m *Manager) recoverDate(date string, result *RecoveryResult) error {
+	ctx := context.Background()
+	stagingDir := m.StagingDir(date)
+	finalDir := filepath.Join(m.baseDir, date)
+	var files []manifest.File
+
+	err := filepath.Walk(stagingDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(stagingDir, path)
+		if err != nil {
+			return err
+		}
+		destPath := filepath.Join(finalDir, relPath)
+
+		// A manifest left by an interrupted remote commit is rebuilt below
+		if info.Name() == manifest.Name || !strings.HasSuffix(path, ".json") || validateJSONFile(path) != nil {
+			result.Discarded++
+			return os.Remove(path)
+		}
+		// A converted copy counts as already committed
+		rel := filepath.Join(date, strings.TrimSuffix(relPath, ".json"))
+		for _, ext := range []string{".json", ".jsonl", ".jsonl.zst", ".parquet"} {
+			exists, err := m.Exists(ctx, rel+ext)
+			if err != nil {
+				return err
+			}
+			if exists {
+				result.Discarded++
+				return os.Remove(path)
+			}
+		}
+
+		// Remote files are uploaded together below
+		if m.storage != nil {
+			return nil
+		}
+
+		if manifest.IsDataPath(relPath) {
+			file, err := manifest.NewFile(stagingDir, relPath)
+			if err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+		if err := os.MkdirAll(filepath.Dir(destPath), 0750); err != nil {
+			return err
+		}
+		if err := moveFile(path, destPath); err != nil {
+			return err
+		}
+		result.Committed++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := m.updateManifest(date, files); err != nil {
+		return err
+	}
+
+	if m.storage != nil {
+		n, err := m.commitRemote(ctx, date)
+		result.Committed += n
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.CleanupStaging(date)
+}
+
+// validateJSONFile checks that path holds exactly one syntactically valid
+// JSON value, streaming tokens so large files are not loaded into memory.
+func validateJSONFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	dec := json.NewDecoder(f)
+	depth, values := 0, 0
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+		if depth == 0 {
+			values++
+		}
+	}
+	if values != 1 || depth != 0 {
+		return fmt.Errorf("expected a single JSON value, found %d", values)
+	}
+	return nil
+}
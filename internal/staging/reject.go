@@ -0,0 +1,40 @@
+package staging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RejectedDir is the directory under the staging root that holds downloads
+// quarantined because their payload did not match the data model.
+const RejectedDir = "rejected"
+
+// Reject moves a staged file into the rejected directory, keeping its path
+// relative to the staging root, and writes the problems found next to it as
+// {file}.report. The file is never committed. Returns the quarantined path.
+func (m *Manager) Reject(stagingPath string, problems []string) (string, error) {
+	rel, err := filepath.Rel(m.stagingRoot, stagingPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is not in staging", stagingPath)
+	}
+	dest := filepath.Join(m.stagingRoot, RejectedDir, rel)
+	if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+		return "", fmt.Errorf("creating rejected directory: %w", err)
+	}
+	if err := moveFile(stagingPath, dest); err != nil {
+		return "", fmt.Errorf("quarantining %s: %w", rel, err)
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "%s rejected %s\n", time.Now().UTC().Format(time.RFC3339), filepath.ToSlash(rel))
+	for _, p := range problems {
+		fmt.Fprintf(&report, "%s\n", p)
+	}
+	if err := os.WriteFile(dest+".report", []byte(report.String()), 0600); err != nil {
+		return dest, fmt.Errorf("writing rejection report: %w", err)
+	}
+	return dest, nil
+}
@@ -2,10 +2,13 @@ package staging
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/dgnsrekt/gexbot-downloader/internal/api"
 )
@@ -13,6 +16,11 @@ import (
 type Manager struct {
 	baseDir     string
 	stagingRoot string
+
+	// storage receives committed files when the output is remote; baseDir
+	// then only holds local state such as validators.
+	storage Storage
+	prepare func(dir string) error
 }
 
 func NewManager(baseDir string) *Manager {
@@ -22,6 +30,71 @@ func NewManager(baseDir string) *Manager {
 	}
 }
 
+// NewRemoteManager stages downloads in workDir/{date} and commits them to
+// storage: each file is uploaded to a temp key under .staging/ and then
+// renamed into place, so readers never see a partial file.
+func NewRemoteManager(workDir string, storage Storage) *Manager {
+	return &Manager{
+		baseDir:     workDir,
+		stagingRoot: workDir,
+		storage:     storage,
+	}
+}
+
+// Remote reports whether files are committed to remote storage.
+func (m *Manager) Remote() bool {
+	return m.storage != nil
+}
+
+// Location returns where committed files end up, for logs.
+func (m *Manager) Location() string {
+	if m.storage != nil {
+		return m.storage.String()
+	}
+	return m.baseDir
+}
+
+// SetPrepare sets a function run on a date's staging directory before it is
+// uploaded to remote storage, e.g. to convert formats. Local commits do not
+// call it.
+func (m *Manager) SetPrepare(fn func(dir string) error) {
+	m.prepare = fn
+}
+
+// Exists reports whether a committed file exists. rel is relative to the
+// output root, e.g. 2025-11-14/SPX/state/gex_zero.json.
+func (m *Manager) Exists(ctx context.Context, rel string) (bool, error) {
+	if m.storage != nil {
+		return m.storage.Exists(ctx, filepath.ToSlash(rel))
+	}
+	_, err := os.Stat(filepath.Join(m.baseDir, rel))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// LocalPath returns the local path of a committed file; ok is false when the
+// output is remote.
+func (m *Manager) LocalPath(rel string) (path string, ok bool) {
+	if m.storage != nil {
+		return "", false
+	}
+	return filepath.Join(m.baseDir, rel), true
+}
+
+// Remove deletes a committed file. Missing files are not an error.
+func (m *Manager) Remove(ctx context.Context, rel string) error {
+	if m.storage != nil {
+		return m.storage.Delete(ctx, filepath.ToSlash(rel))
+	}
+	err := os.Remove(filepath.Join(m.baseDir, rel))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
 func (m *Manager) FinalDir() string {
 	return m.baseDir
 }
@@ -40,25 +113,82 @@ func (m *Manager) PrepareStaging(date string) error {
 }
 
 func (m *Manager) DownloadToStaging(ctx context.Context, client api.Client, url, destPath string) (int64, error) {
+	return m.downloadToStaging(destPath, nil, func(f *os.File) (int64, error) {
+		return client.DownloadFile(ctx, url, f)
+	})
+}
+
+// DownloadToStagingConditional is DownloadToStaging with a conditional
+// request against prev. When the remote file is unchanged it returns
+// api.ErrNotModified and leaves nothing in staging. If client is an
+// api.ResumableClient, a transfer interrupted by an earlier run continues
+// from the bytes kept in the partial directory.
+func (m *Manager) DownloadToStagingConditional(ctx context.Context, client api.ConditionalClient, url, destPath string, prev api.Validators) (int64, api.Validators, error) {
+	var (
+		validators api.Validators
+		resume     func(f *os.File, offset int64, partial api.Validators) (int64, error)
+	)
+	if rc, ok := client.(api.ResumableClient); ok {
+		resume = func(f *os.File, offset int64, partial api.Validators) (int64, error) {
+			n, v, err := rc.ResumeFile(ctx, url, offset, partial, f)
+			validators = v
+			return n, err
+		}
+	}
+	size, err := m.downloadToStaging(destPath, resume, func(f *os.File) (int64, error) {
+		n, v, err := client.DownloadFileConditional(ctx, url, prev, f)
+		validators = v
+		return n, err
+	})
+	return size, validators, err
+}
+
+// downloadToStaging downloads into destPath.tmp and renames it to destPath.
+// When resume is set, a partial download kept from an earlier run is handed
+// to it instead of starting over, and an interrupted transfer is kept for
+// the next run.
+func (m *Manager) downloadToStaging(destPath string, resume func(f *os.File, offset int64, partial api.Validators) (int64, error), download func(f *os.File) (int64, error)) (int64, error) {
 	// Create parent directories
 	if err := os.MkdirAll(filepath.Dir(destPath), 0750); err != nil {
 		return 0, fmt.Errorf("creating directories: %w", err)
 	}
 
-	// Download to temp file
+	// Download to temp file, continuing a kept partial one
 	tmpPath := destPath + ".tmp"
-	f, err := os.Create(tmpPath)
+	var (
+		offset  int64
+		partial api.Validators
+	)
+	if resume != nil {
+		offset, partial = m.takePartial(destPath, tmpPath)
+	}
+	flags := os.O_RDWR | os.O_CREATE | os.O_TRUNC
+	if offset > 0 {
+		flags &^= os.O_TRUNC
+	}
+	f, err := os.OpenFile(tmpPath, flags, 0600)
 	if err != nil {
 		return 0, fmt.Errorf("creating temp file: %w", err)
 	}
 
-	size, err := client.DownloadFile(ctx, url, f)
+	var size int64
+	if offset > 0 {
+		size, err = resume(f, offset, partial)
+	} else {
+		size, err = download(f)
+	}
+	if err == nil {
+		// Flush data before the rename makes the file visible
+		err = f.Sync()
+	}
 	if closeErr := f.Close(); closeErr != nil && err == nil {
 		err = closeErr
 	}
 
 	if err != nil {
-		_ = os.Remove(tmpPath)
+		if resume == nil || !m.keepPartial(destPath, tmpPath, err) {
+			_ = os.Remove(tmpPath)
+		}
 		return 0, fmt.Errorf("downloading file: %w", err)
 	}
 
@@ -67,14 +197,53 @@ func (m *Manager) DownloadToStaging(ctx context.Context, client api.Client, url,
 		_ = os.Remove(tmpPath)
 		return 0, fmt.Errorf("renaming temp file: %w", err)
 	}
+	if err := syncDir(filepath.Dir(destPath)); err != nil {
+		return 0, fmt.Errorf("syncing directory: %w", err)
+	}
 
 	return size, nil
 }
 
+// CommitStaging moves a date's staged files into the final directory and
+// records them in the date's manifest. A date not yet present is swapped in
+// with a single directory rename; otherwise files are moved one by one. Every
+// move is synced to disk.
 func (m *Manager) CommitStaging(date string) error {
+	if m.storage != nil {
+		_, err := m.commitRemote(context.Background(), date)
+		return err
+	}
+
+	files, err := stagedFiles(m.StagingDir(date))
+	if err != nil {
+		return fmt.Errorf("hashing staged files: %w", err)
+	}
+	if err := m.commitLocal(date); err != nil {
+		return err
+	}
+	return m.updateManifest(date, files)
+}
+
+func (m *Manager) commitLocal(date string) error {
 	stagingDir := m.StagingDir(date)
 	finalDir := filepath.Join(m.baseDir, date)
 
+	if _, err := os.Stat(finalDir); errors.Is(err, os.ErrNotExist) {
+		err := os.Rename(stagingDir, finalDir)
+		if err == nil {
+			if err := syncDir(m.stagingRoot); err != nil {
+				return err
+			}
+			return syncDir(m.baseDir)
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			return nil // nothing staged
+		}
+		if !errors.Is(err, syscall.EXDEV) {
+			return err
+		}
+	}
+
 	// Walk staging and move files
 	return filepath.Walk(stagingDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -94,8 +263,57 @@ func (m *Manager) CommitStaging(date string) error {
 			return err
 		}
 
-		return os.Rename(path, destPath)
+		return moveFile(path, destPath)
+	})
+}
+
+// commitRemote prepares a date's staging directory and uploads every file in
+// it, removing each local copy once it is in place, then the updated
+// manifest. Returns the number of files committed.
+func (m *Manager) commitRemote(ctx context.Context, date string) (int, error) {
+	stagingDir := m.StagingDir(date)
+	if _, err := os.Stat(stagingDir); errors.Is(err, os.ErrNotExist) {
+		return 0, nil // nothing staged
+	}
+	if m.prepare != nil {
+		if err := m.prepare(stagingDir); err != nil {
+			return 0, fmt.Errorf("preparing %s: %w", date, err)
+		}
+	}
+	files, err := stagedFiles(stagingDir)
+	if err != nil {
+		return 0, fmt.Errorf("hashing staged files: %w", err)
+	}
+
+	committed := 0
+	err = filepath.Walk(stagingDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(m.stagingRoot, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relPath)
+		tmpKey := ".staging/" + key
+
+		if err := m.storage.Upload(ctx, path, tmpKey); err != nil {
+			return fmt.Errorf("uploading %s: %w", key, err)
+		}
+		if err := m.storage.Rename(ctx, tmpKey, key); err != nil {
+			return fmt.Errorf("committing %s: %w", key, err)
+		}
+		committed++
+		return os.Remove(path)
 	})
+	if err != nil {
+		return committed, err
+	}
+	return committed, m.updateRemoteManifest(ctx, date, files)
 }
 
 func (m *Manager) CleanupStaging(date string) error {
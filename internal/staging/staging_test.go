@@ -2,10 +2,23 @@ package staging
 
 import (
 	"context"
+	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/api"
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
 )
 
 type mockClient struct {
@@ -16,6 +29,10 @@ func (m *mockClient) GetDownloadURL(ctx context.Context, ticker, pkg, category,
 	return "https://example.com/file.json", nil
 }
 
+func (m *mockClient) GetTickers(ctx context.Context) ([]string, error) {
+	return []string{"SPX"}, nil
+}
+
 func (m *mockClient) DownloadFile(ctx context.Context, url string, dest io.Writer) (int64, error) {
 	n, err := dest.Write(m.data)
 	return int64(n), err
@@ -97,3 +114,210 @@ func TestStagingManager(t *testing.T) {
 		t.Error("staging directory should be removed after cleanup")
 	}
 }
+
+func TestDownloadToStagingResumesPartial(t *testing.T) {
+	const body = `{"timestamp":1,"ticker":"SPX"}`
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range")+" "+r.Header.Get("If-Range"))
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("Range") == "" {
+			w.Header().Set("Content-Length", "30")
+			_, _ = w.Write([]byte(body[:10]))
+			return // connection closes short of Content-Length
+		}
+		w.Header().Set("Content-Range", "bytes 10-29/30")
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte(body[10:]))
+	}))
+	defer server.Close()
+
+	mgr := NewManager(t.TempDir())
+	client := api.NewClient(server.URL, "k", 10, 5*time.Second, time.Millisecond, 0, zap.NewNop())
+	destPath := filepath.Join(mgr.StagingDir("2025-11-14"), "SPX", "state", "gex_full.json")
+
+	// The interrupted transfer is kept outside the date's staging directory
+	_, _, err := mgr.DownloadToStagingConditional(context.Background(), client, server.URL+"/f.json", destPath, api.Validators{})
+	if !errors.Is(err, api.ErrIncompleteDownload) {
+		t.Fatalf("expected ErrIncompleteDownload, got %v", err)
+	}
+	if err := mgr.CleanupStaging("2025-11-14"); err != nil {
+		t.Fatal(err)
+	}
+	partial := filepath.Join(mgr.StagingRoot(), PartialDir, "2025-11-14", "SPX", "state", "gex_full.json")
+	if got, _ := os.ReadFile(partial); string(got) != body[:10] {
+		t.Fatalf("partial file = %q, want %q", got, body[:10])
+	}
+
+	size, validators, err := mgr.DownloadToStagingConditional(context.Background(), client, server.URL+"/f.json", destPath, api.Validators{})
+	if err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	got, _ := os.ReadFile(destPath)
+	if size != 30 || string(got) != body || validators.ETag != `"v1"` {
+		t.Errorf("got %d bytes %q with %+v", size, got, validators)
+	}
+	if len(ranges) != 2 || ranges[1] != `bytes=10- "v1"` {
+		t.Errorf("requests = %q, want a ranged resume", ranges)
+	}
+	if _, err := os.Stat(partial + ".resume"); !os.IsNotExist(err) {
+		t.Error("resume record should be removed after resuming")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stagingDir := mgr.StagingDir("2025-11-14")
+	write(filepath.Join(stagingDir, "SPX", "state", "gex_full.json"), `[{"a":1},{"a":2}]`)
+	write(filepath.Join(stagingDir, "SPX", "state", "gex_zero.json"), `[{"a":1},{"a"`)
+	write(filepath.Join(stagingDir, "SPX", "state", "gex_one.json.tmp"), `[{"a":1}]`)
+	write(filepath.Join(stagingDir, "SPX", "classic", "gex_full.json"), `[]`)
+	write(filepath.Join(tmpDir, "2025-11-14", "SPX", "classic", "gex_full.jsonl"), "{}\n")
+
+	result, err := mgr.Recover()
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if len(result.Dates) != 1 || result.Committed != 1 || result.Discarded != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "2025-11-14", "SPX", "state", "gex_full.json")); err != nil {
+		t.Error("valid staged file was not committed")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2025-11-14", "SPX", "state", "gex_zero.json")); err == nil {
+		t.Error("truncated staged file was committed")
+	}
+	if _, err := os.Stat(stagingDir); !os.IsNotExist(err) {
+		t.Error("staging directory was not cleaned up")
+	}
+}
+
+func TestCommitStagingMergesIntoExistingDate(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	client := &mockClient{data: []byte(`[]`)}
+
+	existing := filepath.Join(tmpDir, "2025-11-14", "SPX", "state", "gex_full.jsonl")
+	if err := os.MkdirAll(filepath.Dir(existing), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	staged := filepath.Join(mgr.StagingDir("2025-11-14"), "SPX", "state", "gex_zero.json")
+	if _, err := mgr.DownloadToStaging(context.Background(), client, "https://example.com/file.json", staged); err != nil {
+		t.Fatalf("DownloadToStaging failed: %v", err)
+	}
+	if err := mgr.CommitStaging("2025-11-14"); err != nil {
+		t.Fatalf("CommitStaging failed: %v", err)
+	}
+
+	for _, path := range []string{existing, filepath.Join(tmpDir, "2025-11-14", "SPX", "state", "gex_zero.json")} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s after commit: %v", path, err)
+		}
+	}
+}
+
+func TestCommitStagingWritesManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+
+	commit := func(name, data string) {
+		t.Helper()
+		staged := filepath.Join(mgr.StagingDir("2025-11-14"), "SPX", "state", name)
+		client := &mockClient{data: []byte(data)}
+		if _, err := mgr.DownloadToStaging(context.Background(), client, "https://example.com/file.json", staged); err != nil {
+			t.Fatalf("DownloadToStaging failed: %v", err)
+		}
+		if err := mgr.CommitStaging("2025-11-14"); err != nil {
+			t.Fatalf("CommitStaging failed: %v", err)
+		}
+	}
+	commit("gex_zero.json", `[{"a":1}]`)
+	commit("gex_one.json", `[]`)
+	commit("gex_zero.json", `[{"a":2},{"a":3}]`)
+
+	m, err := manifest.Read(filepath.Join(tmpDir, "2025-11-14", manifest.Name))
+	if err != nil {
+		t.Fatalf("reading manifest: %v", err)
+	}
+	if m.Date != "2025-11-14" || len(m.Files) != 2 {
+		t.Fatalf("manifest = %+v", m)
+	}
+	// Sorted by path; the re-download replaced the first entry
+	one, zero := m.Files[0], m.Files[1]
+	if one.Path != "SPX/state/gex_one.json" || one.Category != "gex_one" || one.Size != 2 {
+		t.Errorf("gex_one entry = %+v", one)
+	}
+	_, sum, _ := manifest.HashFile(filepath.Join(tmpDir, "2025-11-14", "SPX", "state", "gex_zero.json"))
+	if zero.Size != 17 || zero.SHA256 != sum || zero.Ticker != "SPX" || zero.Package != "state" {
+		t.Errorf("gex_zero entry = %+v", zero)
+	}
+}
+
+func TestCopyFileAtomic(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "src.json")
+	dst := filepath.Join(tmpDir, "dst.json")
+	if err := os.WriteFile(src, []byte(`[1,2,3]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := copyFileAtomic(src, dst); err != nil {
+		t.Fatalf("copyFileAtomic failed: %v", err)
+	}
+	got, _ := os.ReadFile(dst)
+	if string(got) != `[1,2,3]` {
+		t.Errorf("unexpected content %q", got)
+	}
+	if _, err := os.Stat(dst + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temp file left behind")
+	}
+}
+
+func TestLock(t *testing.T) {
+	if !slices.Contains([]string{"linux", "darwin", "freebsd"}, runtime.GOOS) {
+		t.Skip("file locking not supported")
+	}
+	m := NewManager(t.TempDir())
+	unlock, err := m.Lock(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(m.StagingRoot(), LockFile)); err != nil {
+		t.Fatalf("lock file: %v", err)
+	}
+
+	// A second holder waits until the first releases the lock
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var holder string
+	if _, err := m.Lock(ctx, func(h string) { holder = h }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second Lock() error = %v, want deadline exceeded", err)
+	}
+	if !strings.HasPrefix(holder, strconv.Itoa(os.Getpid())+" ") {
+		t.Errorf("holder = %q, want this process", holder)
+	}
+
+	unlock()
+	unlock, err = m.Lock(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Lock() after unlock error = %v", err)
+	}
+	unlock()
+}
@@ -0,0 +1,57 @@
+package staging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Storage is a remote destination for committed files. Keys are
+// slash-separated paths relative to the output root, e.g.
+// 2025-11-14/SPX/state/gex_zero.json.
+type Storage interface {
+	// Exists reports whether key is present.
+	Exists(ctx context.Context, key string) (bool, error)
+	// Open returns the contents of key. A missing key returns an error
+	// satisfying errors.Is(err, os.ErrNotExist).
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Upload stores the local file src at key.
+	Upload(ctx context.Context, src, key string) error
+	// Rename moves src to dst, replacing dst.
+	Rename(ctx context.Context, src, dst string) error
+	// Delete removes key. Missing keys are not an error.
+	Delete(ctx context.Context, key string) error
+	// String returns the storage URI for logs.
+	String() string
+}
+
+// ChecksumUploader is implemented by storage that can have the service
+// verify an upload against the file's MD5, so a transfer corrupted on the
+// way is rejected instead of stored.
+type ChecksumUploader interface {
+	UploadChecksum(ctx context.Context, src, key string, md5sum []byte) error
+}
+
+// OpenStorage returns the Storage for an s3://bucket/prefix,
+// gs://bucket/prefix or azblob://account/container/prefix URI, with
+// credentials from the environment (see ObjectCredentialsFromEnv and
+// BlobCredentialsFromEnv).
+func OpenStorage(uri string) (Storage, error) {
+	scheme, rest, ok := strings.Cut(uri, "://")
+	if !ok || (scheme != "s3" && scheme != "gs" && scheme != "azblob") {
+		return nil, fmt.Errorf("unsupported storage URI %q (want s3://bucket/prefix, gs://bucket/prefix or azblob://account/container/prefix)", uri)
+	}
+	bucket, prefix, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("storage URI %q has no bucket", uri)
+	}
+	if scheme == "azblob" {
+		container, prefix, _ := strings.Cut(strings.Trim(prefix, "/"), "/")
+		if container == "" {
+			return nil, fmt.Errorf("storage URI %q has no container", uri)
+		}
+		return NewBlobStorage(bucket, container, strings.Trim(prefix, "/"), BlobCredentialsFromEnv())
+	}
+	return NewObjectStorage(scheme, bucket, strings.Trim(prefix, "/"), ObjectCredentialsFromEnv(scheme))
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// Process-wide error counters. These are incremented from anywhere in the
+// server (HTTP middleware, handlers, WebSocket streamers) and surfaced via
+// /health and /metrics so silent failures become visible.
+var (
+	// RecoveredPanics counts panics caught by the HTTP recovery middleware.
+	RecoveredPanics atomic.Int64
+
+	// HandlerErrors counts REST handler and response-writing errors.
+	HandlerErrors atomic.Int64
+
+	// EncodeFailures counts payloads that failed protobuf/zstd encoding.
+	EncodeFailures atomic.Int64
+
+	// ResponseCacheHits and ResponseCacheMisses track the REST response cache.
+	ResponseCacheHits   atomic.Int64
+	ResponseCacheMisses atomic.Int64
+)
+
+// Snapshot is a point-in-time copy of all counters.
+type Snapshot struct {
+	RecoveredPanics int64
+	HandlerErrors   int64
+	EncodeFailures  int64
+	// Response cache effectiveness (not errors; omitted from /health)
+	ResponseCacheHits   int64
+	ResponseCacheMisses int64
+}
+
+// Read returns the current counter values.
+func Read() Snapshot {
+	return Snapshot{
+		RecoveredPanics: RecoveredPanics.Load(),
+		HandlerErrors:   HandlerErrors.Load(),
+		EncodeFailures:  EncodeFailures.Load(),
+		// Response cache
+		ResponseCacheHits:   ResponseCacheHits.Load(),
+		ResponseCacheMisses: ResponseCacheMisses.Load(),
+	}
+}
+
+// WritePrometheus writes all counters in the Prometheus text exposition format.
+func WritePrometheus(w io.Writer) error {
+	snap := Read()
+	metrics := []struct {
+		name  string
+		help  string
+		value int64
+	}{
+		{"gexfaker_recovered_panics_total", "Panics recovered by the HTTP middleware.", snap.RecoveredPanics},
+		{"gexfaker_handler_errors_total", "REST handler and response-writing errors.", snap.HandlerErrors},
+		{"gexfaker_encode_failures_total", "WebSocket payload encode failures.", snap.EncodeFailures},
+		{"gexfaker_response_cache_hits_total", "REST responses served from the response cache.", snap.ResponseCacheHits},
+		{"gexfaker_response_cache_misses_total", "REST responses built because the response cache missed.", snap.ResponseCacheMisses},
+	}
+
+	for _, m := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", m.name, m.help, m.name, m.name, m.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ type SyncBroadcaster struct {
 	loader        data.DataLoader
 	config        *config.ServerConfig
 	logger        *zap.Logger
+	resolver      data.KeyResolver // nil when every key replays the loaded date
 
 	mu       gosync.RWMutex
 	sequence uint64
@@ -62,6 +63,20 @@ func NewSyncBroadcaster(
 	}
 }
 
+// SetKeyResolver routes API keys pinned to another date to that date's data,
+// so their positions and data_date reflect what they actually replay.
+func (sb *SyncBroadcaster) SetKeyResolver(resolver data.KeyResolver) {
+	sb.resolver = resolver
+}
+
+// resolve returns the loader and date serving apiKey.
+func (sb *SyncBroadcaster) resolve(apiKey string) (data.DataLoader, string) {
+	if sb.resolver == nil {
+		return sb.loader, sb.config.DataDate
+	}
+	return sb.resolver.Resolve(apiKey)
+}
+
 // Run starts the periodic broadcast loop.
 func (sb *SyncBroadcaster) Run(ctx context.Context) {
 	sb.logger.Info("sync broadcaster starting",
@@ -164,7 +179,8 @@ func (sb *SyncBroadcaster) removeClient(client *sseClient) {
 }
 
 func (sb *SyncBroadcaster) buildSnapshot(ctx context.Context, apiKey string) *SyncSnapshot {
-	positions := sb.buildPositions(ctx, apiKey)
+	loader, date := sb.resolve(apiKey)
+	positions := sb.buildPositions(ctx, loader, apiKey)
 
 	sb.mu.Lock()
 	sb.sequence++
@@ -173,8 +189,8 @@ func (sb *SyncBroadcaster) buildSnapshot(ctx context.Context, apiKey string) *Sy
 
 	return &SyncSnapshot{
 		BroadcasterID: sb.broadcasterID,
-		DataDate:      sb.config.DataDate,
-		CacheMode:     sb.config.CacheMode,
+		DataDate:      date,
+		CacheMode:     string(sb.cache.GetMode()),
 		Timestamp:     time.Now().UnixMilli(),
 		Sequence:      seq,
 		Positions:     positions,
@@ -182,7 +198,8 @@ func (sb *SyncBroadcaster) buildSnapshot(ctx context.Context, apiKey string) *Sy
 }
 
 func (sb *SyncBroadcaster) buildBatch(ctx context.Context, apiKey string) *SyncBatch {
-	positions := sb.buildPositions(ctx, apiKey)
+	loader, date := sb.resolve(apiKey)
+	positions := sb.buildPositions(ctx, loader, apiKey)
 
 	sb.mu.Lock()
 	sb.sequence++
@@ -191,15 +208,15 @@ func (sb *SyncBroadcaster) buildBatch(ctx context.Context, apiKey string) *SyncB
 
 	return &SyncBatch{
 		BroadcasterID: sb.broadcasterID,
-		DataDate:      sb.config.DataDate,
-		CacheMode:     sb.config.CacheMode,
+		DataDate:      date,
+		CacheMode:     string(sb.cache.GetMode()),
 		Timestamp:     time.Now().UnixMilli(),
 		Sequence:      seq,
 		Positions:     positions,
 	}
 }
 
-func (sb *SyncBroadcaster) buildPositions(ctx context.Context, apiKey string) []SyncPosition {
+func (sb *SyncBroadcaster) buildPositions(ctx context.Context, loader data.DataLoader, apiKey string) []SyncPosition {
 	cachePositions := sb.cache.GetPositionsByAPIKey(apiKey)
 	positions := make([]SyncPosition, 0, len(cachePositions))
 
@@ -211,7 +228,7 @@ func (sb *SyncBroadcaster) buildPositions(ctx context.Context, apiKey string) []
 		}
 
 		// Get data length
-		length, err := sb.loader.GetLength(ticker, pkg, category)
+		length, err := loader.GetLength(ticker, pkg, category)
 		if err != nil {
 			sb.logger.Debug("failed to get data length",
 				zap.String("cache_key", cacheKey),
@@ -222,14 +239,14 @@ func (sb *SyncBroadcaster) buildPositions(ctx context.Context, apiKey string) []
 
 		// Check if exhausted
 		exhausted := false
-		if sb.cache.GetMode() == data.CacheModeExhaust && index >= length {
+		if sb.cache.ModeFor(cacheKey) == data.CacheModeExhaust && index >= length {
 			exhausted = true
 		}
 
 		// Get data timestamp at current position
 		dataTimestamp := int64(0)
 		if !exhausted && index < length {
-			dataTimestamp = sb.getDataTimestamp(ctx, ticker, pkg, category, index)
+			dataTimestamp = sb.getDataTimestamp(ctx, loader, ticker, pkg, category, index)
 		}
 
 		positions = append(positions, SyncPosition{
@@ -291,6 +308,8 @@ func (sb *SyncBroadcaster) pkgDefaultCategory(pkg string) string {
 		return "gex_full"
 	case "orderflow":
 		return "orderflow"
+	case "volatility":
+		return "iv_zero"
 	default:
 		return ""
 	}
@@ -305,13 +324,15 @@ func (sb *SyncBroadcaster) hubToPkg(hub string) string {
 		return "classic"
 	case "state_gex", "state_greeks_zero", "state_greeks_one":
 		return "state"
+	case "volatility":
+		return "volatility"
 	default:
 		return hub
 	}
 }
 
-func (sb *SyncBroadcaster) getDataTimestamp(ctx context.Context, ticker, pkg, category string, index int) int64 {
-	rawJSON, err := sb.loader.GetRawAtIndex(ctx, ticker, pkg, category, index)
+func (sb *SyncBroadcaster) getDataTimestamp(ctx context.Context, loader data.DataLoader, ticker, pkg, category string, index int) int64 {
+	rawJSON, err := loader.GetRawAtIndex(ctx, ticker, pkg, category, index)
 	if err != nil {
 		return 0
 	}
@@ -0,0 +1,364 @@
+// Package verify checks a downloaded date directory for integrity: every
+// record parses into its model, timestamps never go backwards, files match
+// the date's manifest, and no expected ticker/package/category is missing.
+package verify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/config"
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+// maxIssuesPerFile bounds the record-level issues reported for one file.
+const maxIssuesPerFile = 10
+
+// dataExts are the file formats a data file may be stored in.
+var dataExts = []string{".json", data.JSONLExt, data.JSONLZstdExt, ".parquet"}
+
+// Issue is a problem found in a date directory.
+type Issue struct {
+	File    string // relative to the date directory, slash-separated
+	Record  int    // line (JSONL) or 1-based record number; 0 for the whole file
+	Problem string
+}
+
+func (i Issue) String() string {
+	if i.Record > 0 {
+		return fmt.Sprintf("%s:%d: %s", i.File, i.Record, i.Problem)
+	}
+	return i.File + ": " + i.Problem
+}
+
+// Report is the result of verifying one date directory.
+type Report struct {
+	Files    int      // data files checked
+	Records  int      // records read across all files
+	Manifest bool     // whether a manifest.json was found and checked
+	Issues   []Issue  // parse, ordering and manifest problems
+	Missing  []string // expected ticker/package/category with no data file
+}
+
+// OK reports whether verification found no problems.
+func (r *Report) OK() bool {
+	return len(r.Issues) == 0 && len(r.Missing) == 0
+}
+
+// Dir verifies the date directory dateDir. expected lists the
+// ticker/package/category combinations that should be present; a
+// combination counts as present in any of the .json, .jsonl, .jsonl.zst or
+// .parquet formats.
+func Dir(dateDir string, expected []string) (*Report, error) {
+	if _, err := os.Stat(dateDir); err != nil {
+		return nil, err
+	}
+	report := &Report{}
+
+	present := make(map[string]bool)
+	err := filepath.Walk(dateDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dateDir, path)
+		if err != nil {
+			return err
+		}
+		ext := dataExt(info.Name())
+		if !manifest.IsDataPath(rel) || ext == "" {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		present[strings.TrimSuffix(rel, ext)] = true
+
+		records, issues := checkFile(path, rel)
+		report.Files++
+		report.Records += records
+		report.Issues = append(report.Issues, issues...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking %s: %w", dateDir, err)
+	}
+
+	issues, found, err := checkManifest(dateDir)
+	if err != nil {
+		return nil, err
+	}
+	report.Manifest = found
+	report.Issues = append(report.Issues, issues...)
+
+	for _, key := range expected {
+		if !present[key] {
+			report.Missing = append(report.Missing, key)
+		}
+	}
+	sort.Strings(report.Missing)
+	sort.SliceStable(report.Issues, func(i, j int) bool { return report.Issues[i].File < report.Issues[j].File })
+
+	return report, nil
+}
+
+// dataExt returns the data format extension of a file name, or "".
+func dataExt(name string) string {
+	for _, ext := range dataExts {
+		if strings.HasSuffix(name, ext) && !strings.Contains(strings.TrimSuffix(name, ext), ".") {
+			return ext
+		}
+	}
+	return ""
+}
+
+// checkManifest compares the files listed in the date's manifest with their
+// recorded sizes and SHA-256 digests. found is false without a manifest.
+func checkManifest(dateDir string) (issues []Issue, found bool, err error) {
+	m, err := manifest.Read(filepath.Join(dateDir, manifest.Name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return []Issue{{File: manifest.Name, Problem: err.Error()}}, true, nil
+	}
+
+	for _, f := range m.Files {
+		size, sum, err := manifest.HashFile(filepath.Join(dateDir, filepath.FromSlash(f.Path)))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			issues = append(issues, Issue{File: f.Path, Problem: "listed in manifest but missing"})
+		case err != nil:
+			issues = append(issues, Issue{File: f.Path, Problem: err.Error()})
+		case size != f.Size:
+			issues = append(issues, Issue{File: f.Path, Problem: fmt.Sprintf("size %d, manifest lists %d", size, f.Size)})
+		case sum != f.SHA256:
+			issues = append(issues, Issue{File: f.Path, Problem: "sha256 does not match manifest"})
+		}
+	}
+	return issues, true, nil
+}
+
+// File verifies the data file at path, rel naming it as
+// {ticker}/{package}/{category}.{ext}: every record must parse into the
+// package's model, carry a timestamp and the ticker, and come in time order.
+// It returns the records read and the problems found.
+func File(path, rel string) (int, []Issue) {
+	return checkFile(path, rel)
+}
+
+// checkFile verifies one data file laid out as
+// {ticker}/{package}/{category}.{ext}, returning the records read.
+func checkFile(path, rel string) (int, []Issue) {
+	parts := strings.Split(rel, "/")
+	ticker, pkg := parts[0], parts[1]
+	category := strings.TrimSuffix(parts[2], dataExt(parts[2]))
+
+	switch config.Package(pkg) {
+	case config.PackageOrderflow:
+		return checkRecords[data.OrderflowData](path, rel, ticker)
+	case config.PackageVolatility:
+		return checkRecords[data.VolatilityData](path, rel, ticker)
+	case config.PackageState, config.PackageClassic:
+		if strings.HasPrefix(category, "gex_") {
+			return checkRecords[data.GexData](path, rel, ticker)
+		}
+		return checkRecords[data.GreekData](path, rel, ticker)
+	default:
+		return 0, []Issue{{File: rel, Problem: "unknown package " + pkg}}
+	}
+}
+
+// checker accumulates the record-level checks of one file.
+type checker struct {
+	rel     string
+	ticker  string
+	model   string
+	records int
+	last    int64
+	lastAt  int
+	issues  []Issue
+	dropped int
+}
+
+func (c *checker) fail(record int, format string, args ...any) {
+	if len(c.issues) >= maxIssuesPerFile {
+		c.dropped++
+		return
+	}
+	c.issues = append(c.issues, Issue{File: c.rel, Record: record, Problem: fmt.Sprintf(format, args...)})
+}
+
+// check validates a decoded record's timestamp and ticker.
+func (c *checker) check(record int, timestamp int64, ticker string) {
+	c.records++
+	if timestamp <= 0 {
+		c.fail(record, "missing timestamp")
+		return
+	}
+	if ticker != "" && ticker != c.ticker {
+		c.fail(record, "ticker %s, expected %s", ticker, c.ticker)
+	}
+	if timestamp < c.last {
+		c.fail(record, "timestamp %d before %d at record %d", timestamp, c.last, c.lastAt)
+	}
+	c.last, c.lastAt = timestamp, record
+}
+
+func (c *checker) result() (int, []Issue) {
+	if c.records == 0 && len(c.issues) == 0 {
+		c.issues = append(c.issues, Issue{File: c.rel, Problem: "no records"})
+	}
+	if c.dropped > 0 {
+		c.issues = append(c.issues, Issue{File: c.rel, Problem: fmt.Sprintf("%d more problems not shown", c.dropped)})
+	}
+	return c.records, c.issues
+}
+
+func checkRecords[T any](path, rel, ticker string) (int, []Issue) {
+	var zero T
+	c := &checker{rel: rel, ticker: ticker, model: fmt.Sprintf("%T", zero)}
+
+	if strings.HasSuffix(path, ".parquet") {
+		f, err := os.Open(path)
+		if err != nil {
+			return 0, []Issue{{File: rel, Problem: err.Error()}}
+		}
+		defer func() { _ = f.Close() }()
+		readParquet[T](f, c)
+		return c.result()
+	}
+
+	r, err := data.OpenJSONL(path)
+	if err != nil {
+		return 0, []Issue{{File: rel, Problem: err.Error()}}
+	}
+	defer func() { _ = r.Close() }()
+
+	if strings.HasSuffix(path, ".json") {
+		readJSONArray[T](r, c)
+	} else {
+		readJSONL[T](r, c)
+	}
+	return c.result()
+}
+
+// decode parses raw into the model and checks it.
+func decode[T any](c *checker, record int, raw []byte) {
+	var rec T
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			c.fail(record, "invalid JSON: %v", err)
+		} else {
+			c.fail(record, "does not match %s: %v", c.model, err)
+		}
+		c.records++
+		return
+	}
+	timestamp, ticker := fields(&rec)
+	c.check(record, timestamp, ticker)
+}
+
+// fields returns the timestamp and ticker of a record.
+func fields(rec any) (int64, string) {
+	switch r := rec.(type) {
+	case *data.GexData:
+		return r.Timestamp, r.Ticker
+	case *data.GreekData:
+		return r.Timestamp, r.Ticker
+	case *data.VolatilityData:
+		return r.Timestamp, r.Ticker
+	case *data.OrderflowData:
+		return r.Timestamp, r.Ticker
+	default:
+		return 0, ""
+	}
+}
+
+func readJSONL[T any](r io.Reader, c *checker) {
+	reader := bufio.NewReader(r)
+	line := 0
+	for {
+		raw, err := reader.ReadBytes('\n')
+		if len(raw) > 0 {
+			line++
+			if raw = bytes.TrimSpace(raw); len(raw) > 0 {
+				decode[T](c, line, raw)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			if len(raw) > 0 {
+				c.fail(line, "truncated: last line has no newline")
+			}
+			return
+		}
+		if err != nil {
+			c.fail(0, "reading: %v", err)
+			return
+		}
+	}
+}
+
+func readJSONArray[T any](r io.Reader, c *checker) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		c.fail(0, "not a JSON array")
+		return
+	}
+	for n := 1; dec.More(); n++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			c.fail(n, "invalid JSON: %v", err)
+			return
+		}
+		decode[T](c, n, raw)
+	}
+	if _, err := dec.Token(); err != nil {
+		c.fail(0, "truncated: %v", err)
+	}
+}
+
+func readParquet[T any](f *os.File, c *checker) {
+	info, err := f.Stat()
+	if err != nil {
+		c.fail(0, "%v", err)
+		return
+	}
+	file, err := parquet.OpenFile(f, info.Size())
+	if err != nil {
+		c.fail(0, "invalid Parquet: %v", err)
+		return
+	}
+	reader := parquet.NewGenericReader[T](file)
+	defer func() { _ = reader.Close() }()
+
+	rows := make([]T, 1024)
+	n := 0
+	for {
+		count, err := reader.Read(rows)
+		for i := range rows[:count] {
+			n++
+			timestamp, ticker := fields(&rows[i])
+			c.check(n, timestamp, ticker)
+		}
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			c.fail(0, "reading Parquet: %v", err)
+			return
+		}
+	}
+}
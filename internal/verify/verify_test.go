@@ -0,0 +1,127 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/export"
+	"github.com/dgnsrekt/gexbot-downloader/internal/manifest"
+)
+
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDirClean(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "SPX/state/gex_zero.jsonl",
+		`{"timestamp":1,"ticker":"SPX","spot":1}`+"\n"+`{"timestamp":2,"ticker":"SPX","spot":2}`+"\n")
+	if err := os.MkdirAll(filepath.Join(dir, "SPX", "volatility"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, "SPX/orderflow/orderflow.json", `[{"timestamp":5,"ticker":"SPX"},{"timestamp":5,"ticker":"SPX"}]`)
+
+	m := manifest.New("2025-11-14")
+	for _, rel := range []string{"SPX/state/gex_zero.jsonl", "SPX/orderflow/orderflow.json"} {
+		f, err := manifest.NewFile(dir, rel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Set(f)
+	}
+	if err := m.Write(filepath.Join(dir, manifest.Name)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A Parquet copy is read as well
+	if _, err := export.ConvertToParquet(filepath.Join(dir, "SPX", "state", "gex_zero.jsonl"),
+		filepath.Join(dir, "SPX", "state", "gex_zero.parquet"), "state", "gex_zero"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Compressed JSONL is read too
+	w, err := data.CreateJSONL(filepath.Join(dir, "SPX", "volatility", "iv_zero.jsonl.zst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(`{"timestamp":1,"ticker":"SPX"}` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Dir(dir, []string{"SPX/state/gex_zero", "SPX/orderflow/orderflow", "SPX/volatility/iv_zero"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() {
+		t.Errorf("expected OK, got missing %v, issues %v", report.Missing, report.Issues)
+	}
+	if report.Files != 4 || report.Records != 7 || !report.Manifest {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestDirProblems(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "SPX/state/gex_zero.jsonl", `{"timestamp":1,"ticker":"SPX"}`+"\n"+
+		`{"timestamp":"x"}`+"\n"+
+		`{"timestamp":3,"ticker":"NDX"}`+"\n"+
+		`{"timestamp":2,"ticker":"SPX"}`+"\n"+
+		`{"timestamp":4`)
+	writeFile(t, dir, "SPX/state/delta_zero.json", `[{"timestamp":1},`)
+	writeFile(t, dir, "SPX/state/gamma_zero.jsonl", "")
+
+	m := manifest.New("2025-11-14")
+	m.Set(manifest.File{Path: "SPX/state/gamma_zero.jsonl", Ticker: "SPX", Package: "state", Category: "gamma_zero", Size: 10})
+	m.Set(manifest.File{Path: "SPX/state/charm_zero.jsonl", Ticker: "SPX", Package: "state", Category: "charm_zero", Size: 1})
+	if err := m.Write(filepath.Join(dir, manifest.Name)); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Dir(dir, []string{"SPX/state/gex_zero", "SPX/state/gex_one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Missing) != 1 || report.Missing[0] != "SPX/state/gex_one" {
+		t.Errorf("missing = %v", report.Missing)
+	}
+
+	var got []string
+	for _, issue := range report.Issues {
+		got = append(got, issue.String())
+	}
+	for _, want := range []string{
+		"SPX/state/charm_zero.jsonl: listed in manifest but missing",
+		"SPX/state/delta_zero.json:2: invalid JSON",
+		"SPX/state/gamma_zero.jsonl: no records",
+		"SPX/state/gamma_zero.jsonl: size 0, manifest lists 10",
+		"SPX/state/gex_zero.jsonl:2: does not match data.GexData",
+		"SPX/state/gex_zero.jsonl:3: ticker NDX, expected SPX",
+		"SPX/state/gex_zero.jsonl:4: timestamp 2 before 3 at record 3",
+		"SPX/state/gex_zero.jsonl:5: invalid JSON",
+		"SPX/state/gex_zero.jsonl:5: truncated",
+	} {
+		found := false
+		for _, g := range got {
+			if strings.HasPrefix(g, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing issue %q in:\n%s", want, strings.Join(got, "\n"))
+		}
+	}
+}
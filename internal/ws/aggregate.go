@@ -0,0 +1,113 @@
+package ws
+
+import (
+	"strings"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// AllTickers is the ticker placeholder of aggregate groups such as
+// blue_ALL_orderflow_orderflow. An aggregate group receives the next record of
+// every loaded ticker for its category on each tick, so market-wide dashboards
+// need one subscription instead of one group per symbol.
+const AllTickers = "ALL"
+
+// subscription is one ticker/category stream and the groups receiving it.
+type subscription struct {
+	ticker   string
+	category string
+	groups   []string
+}
+
+// expandSubscriptions resolves active groups into ticker/category streams.
+// Aggregate groups join the stream of every ticker loaded for their category
+// in pkg, so an API key subscribed to both SPX and ALL still advances once per
+// tick and receives the same record on both groups.
+func expandSubscriptions(groups []string, parse func(group string) (ticker, category string), loader data.DataLoader, pkg string) []*subscription {
+	var subs []*subscription
+	byKey := make(map[string]*subscription)
+	add := func(ticker, category, group string) {
+		key := ticker + "/" + category
+		sub, ok := byKey[key]
+		if !ok {
+			sub = &subscription{ticker: ticker, category: category}
+			byKey[key] = sub
+			subs = append(subs, sub)
+		}
+		sub.groups = append(sub.groups, group)
+	}
+
+	aggregates := make(map[string][]string) // category -> aggregate groups
+	for _, group := range groups {
+		ticker, category := parse(group)
+		if ticker == "" || category == "" {
+			continue
+		}
+		if ticker == AllTickers {
+			aggregates[category] = append(aggregates[category], group)
+			continue
+		}
+		add(ticker, category, group)
+	}
+
+	if len(aggregates) > 0 {
+		// Loaded keys are ticker/pkg/category
+		for _, key := range loader.GetLoadedKeys() {
+			parts := strings.SplitN(key, "/", 3)
+			if len(parts) != 3 || parts[1] != pkg {
+				continue
+			}
+			for _, group := range aggregates[parts[2]] {
+				add(parts[0], parts[2], group)
+			}
+		}
+	}
+	return subs
+}
+
+// groupClients are one API key's subscribers to a stream, by group.
+type groupClients map[string][]*Client
+
+// clientsByAPIKey collects the subscription's clients by API key and group.
+func (sub *subscription) clientsByAPIKey(h *Hub) map[string]groupClients {
+	result := make(map[string]groupClients)
+	for _, group := range sub.groups {
+		for apiKey, clients := range h.GetClientsByAPIKey(group) {
+			if result[apiKey] == nil {
+				result[apiKey] = make(groupClients)
+			}
+			result[apiKey][group] = clients
+		}
+	}
+	return result
+}
+
+// broadcast sends one record to every group, labelled with that group's name.
+// The record is encoded once per schema version among the clients.
+func (gc groupClients) broadcast(h *Hub, enc *Encoder, kind string, rawJSON []byte) error {
+	encoded := make(map[*SchemaVersion][]byte)
+	for group, clients := range gc {
+		for _, client := range clients {
+			schema := h.schemaFor(client, group)
+			data, ok := encoded[schema]
+			if !ok {
+				var err error
+				if data, err = enc.Encode(kind, rawJSON, schema.Scale()); err != nil {
+					return err
+				}
+				encoded[schema] = data
+			}
+			h.deliver(client, group, client.buildDataMsg(group, data, schema.TypeURL(kind)))
+		}
+	}
+	return nil
+}
+
+// count returns the number of clients across all groups.
+func (gc groupClients) count() int {
+	n := 0
+	for _, clients := range gc {
+		n += len(clients)
+	}
+	return n
+}
@@ -0,0 +1,49 @@
+package ws
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+)
+
+// keysLoader is a stub DataLoader exposing a fixed set of loaded keys.
+type keysLoader []string
+
+func (l keysLoader) GetAtIndex(ctx context.Context, ticker, pkg, category string, index int) (*data.GexData, error) {
+	return nil, data.ErrNotFound
+}
+
+func (l keysLoader) GetRawAtIndex(ctx context.Context, ticker, pkg, category string, index int) ([]byte, error) {
+	return nil, data.ErrNotFound
+}
+
+func (l keysLoader) GetLength(ticker, pkg, category string) (int, error) { return 0, data.ErrNotFound }
+func (l keysLoader) Exists(ticker, pkg, category string) bool            { return false }
+func (l keysLoader) GetLoadedKeys() []string                             { return l }
+func (l keysLoader) Close() error                                        { return nil }
+
+func TestExpandSubscriptions(t *testing.T) {
+	loader := keysLoader{
+		"SPX/classic/gex_full", "NDX/classic/gex_full", "NDX/classic/gex_zero", "SPX/state/gex_full",
+	}
+	groups := []string{"blue_SPX_classic_gex_full", "blue_ALL_classic_gex_full", "blue_QQQ_classic_gex_zero"}
+
+	got := make(map[string][]string)
+	for _, sub := range expandSubscriptions(groups, extractClassicTickerAndCategory, loader, "classic") {
+		sort.Strings(sub.groups)
+		got[sub.ticker+"/"+sub.category] = sub.groups
+	}
+
+	want := map[string][]string{
+		// SPX advances once for both its own group and the aggregate
+		"SPX/gex_full": {"blue_ALL_classic_gex_full", "blue_SPX_classic_gex_full"},
+		"NDX/gex_full": {"blue_ALL_classic_gex_full"},
+		"QQQ/gex_zero": {"blue_QQQ_classic_gex_zero"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,85 @@
+package ws
+
+import (
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// ChaosConfig injects WebSocket delivery faults so client reconnection and
+// gap-detection logic can be exercised against the faker.
+// Rates are per data message probabilities in [0,1] and must sum to at most 1.
+type ChaosConfig struct {
+	DropRate       float64       // data message silently dropped
+	DuplicateRate  float64       // data message delivered twice
+	DisconnectRate float64       // connection dropped without a close frame
+	AckDelay       time.Duration // delay before join/leave acks are sent
+	Keys           []string      // only affect these API keys (empty = all)
+	Groups         []string      // only affect groups containing one of these substrings (empty = all)
+}
+
+// chaosAction is the fault chosen for a single data message.
+type chaosAction int
+
+const (
+	chaosNone chaosAction = iota
+	chaosDrop
+	chaosDuplicate
+	chaosDisconnect
+)
+
+// applies reports whether faults are injected for this key and group.
+// An empty group matches any group filter (used for acks of unknown groups).
+func (c *ChaosConfig) applies(apiKey, group string) bool {
+	if c == nil {
+		return false
+	}
+	if len(c.Keys) > 0 && !containsString(c.Keys, apiKey) {
+		return false
+	}
+	if len(c.Groups) > 0 && group != "" {
+		for _, g := range c.Groups {
+			if strings.Contains(group, g) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// dataAction rolls the fault for a data message to the given key and group.
+func (c *ChaosConfig) dataAction(apiKey, group string) chaosAction {
+	if !c.applies(apiKey, group) {
+		return chaosNone
+	}
+
+	r := rand.Float64()
+	switch {
+	case r < c.DisconnectRate:
+		return chaosDisconnect
+	case r < c.DisconnectRate+c.DropRate:
+		return chaosDrop
+	case r < c.DisconnectRate+c.DropRate+c.DuplicateRate:
+		return chaosDuplicate
+	default:
+		return chaosNone
+	}
+}
+
+// ackDelay returns how long to hold back an ack for this key and group.
+func (c *ChaosConfig) ackDelay(apiKey, group string) time.Duration {
+	if c == nil || c.AckDelay <= 0 || !c.applies(apiKey, group) {
+		return 0
+	}
+	return c.AckDelay
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package ws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChaosApplies(t *testing.T) {
+	tests := []struct {
+		name   string
+		chaos  *ChaosConfig
+		apiKey string
+		group  string
+		want   bool
+	}{
+		{"nil config", nil, "key", "blue_SPX_orderflow_orderflow", false},
+		{"no scope", &ChaosConfig{}, "key", "blue_SPX_orderflow_orderflow", true},
+		{"key listed", &ChaosConfig{Keys: []string{"a", "key"}}, "key", "blue_SPX_orderflow_orderflow", true},
+		{"key not listed", &ChaosConfig{Keys: []string{"a"}}, "key", "blue_SPX_orderflow_orderflow", false},
+		{"group matches", &ChaosConfig{Groups: []string{"_SPX_"}}, "key", "blue_SPX_orderflow_orderflow", true},
+		{"group does not match", &ChaosConfig{Groups: []string{"_NDX_"}}, "key", "blue_SPX_orderflow_orderflow", false},
+		{"empty group matches any group filter", &ChaosConfig{Groups: []string{"_NDX_"}}, "key", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.chaos.applies(tt.apiKey, tt.group); got != tt.want {
+				t.Errorf("applies = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChaosDataAction(t *testing.T) {
+	const group = "blue_SPX_orderflow_orderflow"
+	tests := []struct {
+		name  string
+		chaos *ChaosConfig
+		want  chaosAction
+	}{
+		{"nil config", nil, chaosNone},
+		{"no faults", &ChaosConfig{}, chaosNone},
+		{"always drop", &ChaosConfig{DropRate: 1}, chaosDrop},
+		{"always duplicate", &ChaosConfig{DuplicateRate: 1}, chaosDuplicate},
+		{"always disconnect", &ChaosConfig{DisconnectRate: 1}, chaosDisconnect},
+		{"out of scope", &ChaosConfig{DropRate: 1, Keys: []string{"other"}}, chaosNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				if got := tt.chaos.dataAction("key", group); got != tt.want {
+					t.Fatalf("dataAction = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	// Rates split the draws between the faults
+	chaos := &ChaosConfig{DropRate: 0.5, DuplicateRate: 0.5}
+	seen := make(map[chaosAction]int)
+	for range 1000 {
+		seen[chaos.dataAction("key", group)]++
+	}
+	if seen[chaosDrop] == 0 || seen[chaosDuplicate] == 0 || seen[chaosNone]+seen[chaosDisconnect] != 0 {
+		t.Errorf("drop/duplicate split = %v", seen)
+	}
+}
+
+func TestChaosAckDelay(t *testing.T) {
+	chaos := &ChaosConfig{AckDelay: time.Second, Keys: []string{"key"}}
+	if got := chaos.ackDelay("key", "g"); got != time.Second {
+		t.Errorf("ackDelay = %s, want 1s", got)
+	}
+	if got := chaos.ackDelay("other", "g"); got != 0 {
+		t.Errorf("ackDelay of an unaffected key = %s, want 0", got)
+	}
+	var none *ChaosConfig
+	if got := none.ackDelay("key", "g"); got != 0 {
+		t.Errorf("ackDelay without chaos = %s, want 0", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -25,6 +26,15 @@ const (
 
 	// Send buffer size per client.
 	sendBufferSize = 256
+
+	// Sustained upstream (join/leave/ping) messages allowed per second per connection.
+	upstreamRateLimit = 20
+
+	// Upstream messages allowed in a single burst before the rate limit applies.
+	upstreamRateBurst = 50
+
+	// Maximum number of groups a single connection may join.
+	maxGroupsPerClient = 100
 )
 
 var upgrader = websocket.Upgrader{
@@ -48,6 +58,11 @@ type Client struct {
 	groups   map[string]bool
 	logger   *zap.Logger
 	protocol string // "protobuf" or "json"
+	limiter  *rate.Limiter
+
+	schema       *SchemaVersion            // wire format for groups joined without one
+	groupSchemas map[string]*SchemaVersion // per-group overrides, guarded by hub.mu
+	closeMsg     []byte                    // close frame payload once send is closed, guarded by hub.mu
 }
 
 // HandleOrderflowWS handles WebSocket upgrade for the orderflow hub.
@@ -64,6 +79,13 @@ func (h *Hub) HandleOrderflowWS(w http.ResponseWriter, r *http.Request) {
 	apiKey := parts[0]
 	connID := uuid.New().String() // Generate new connID for this connection
 
+	// Wire-format version requested at negotiate, carried on the URL
+	schema, ok := h.schemas.Lookup(r.URL.Query().Get("schema"))
+	if !ok {
+		http.Error(w, "unknown schema version", http.StatusBadRequest)
+		return
+	}
+
 	// Negotiate subprotocol - check what client requested
 	protocol := "protobuf" // default
 	var responseHeader http.Header
@@ -102,18 +124,28 @@ func (h *Hub) HandleOrderflowWS(w http.ResponseWriter, r *http.Request) {
 		groups:   make(map[string]bool),
 		logger:   h.logger,
 		protocol: protocol,
-	}
+		limiter:  rate.NewLimiter(rate.Limit(upstreamRateLimit), upstreamRateBurst),
 
-	h.register <- client
+		schema:       schema,
+		groupSchemas: make(map[string]*SchemaVersion),
+	}
 
-	// Send ConnectedMessage per negotiated protocol
-	var connectedMsg []byte
+	// Queue the ConnectedMessage per negotiated protocol before the hub can
+	// see the client, so send cannot be closed under it
 	if protocol == "json" {
-		connectedMsg = buildConnectedMessageJSON(connID, apiKey)
+		client.send <- buildConnectedMessageJSON(connID, apiKey)
 	} else {
-		connectedMsg = buildConnectedMessage(connID, apiKey)
+		client.send <- buildConnectedMessage(connID, apiKey)
+	}
+
+	// Reject connections that arrive while the hub is shutting down. The hub
+	// counts the write pump when it registers the client, so Drain waits for it.
+	select {
+	case h.register <- client:
+	case <-h.done:
+		_ = conn.Close()
+		return
 	}
-	client.send <- connectedMsg
 
 	// Start read/write pumps
 	go client.writePump()
@@ -123,8 +155,12 @@ func (h *Hub) HandleOrderflowWS(w http.ResponseWriter, r *http.Request) {
 // readPump reads messages from the WebSocket connection.
 func (c *Client) readPump() {
 	defer func() {
-		c.hub.unregister <- c
-		_ = c.conn.Close()
+		// Hub may already be shut down, in which case it has released this client
+		select {
+		case c.hub.unregister <- c:
+		case <-c.hub.done:
+		}
+		// writePump closes the connection once it has flushed send
 	}()
 
 	c.conn.SetReadLimit(maxMessageSize)
@@ -147,16 +183,37 @@ func (c *Client) readPump() {
 			}
 			break
 		}
-		c.handleMessage(message)
+		if !c.limiter.AllowN(c.hub.now(), 1) {
+			c.closeWithPolicyViolation("upstream message rate exceeded")
+			break
+		}
+		if !c.handleMessage(message) {
+			break
+		}
 	}
 }
 
+// closeWithPolicyViolation makes writePump close the connection of an abusive
+// client with a 1008 close frame, after the messages already queued (such
+// as a negative ack). The caller then stops reading, which unregisters it.
+func (c *Client) closeWithPolicyViolation(reason string) {
+	c.logger.Warn("closing abusive websocket client",
+		zap.String("hub", c.hub.name),
+		zap.String("connID", c.connID),
+		zap.String("reason", reason),
+	)
+	c.hub.mu.Lock()
+	c.closeMsg = websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason)
+	c.hub.mu.Unlock()
+}
+
 // writePump writes messages to the WebSocket connection.
 func (c *Client) writePump() {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
 		ticker.Stop()
 		_ = c.conn.Close()
+		c.hub.pumps.Done()
 	}()
 
 	// Determine message type based on protocol
@@ -173,7 +230,10 @@ func (c *Client) writePump() {
 			}
 			if !ok {
 				// Channel closed, send close message
-				_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				c.hub.mu.RLock()
+				closeMsg := c.closeMsg
+				c.hub.mu.RUnlock()
+				_ = c.conn.WriteMessage(websocket.CloseMessage, closeMsg)
 				return
 			}
 			if err := c.conn.WriteMessage(msgType, message); err != nil {
@@ -196,7 +256,8 @@ func (c *Client) writePump() {
 }
 
 // handleMessage processes an incoming upstream message.
-func (c *Client) handleMessage(data []byte) {
+// Returns false when the connection should be closed.
+func (c *Client) handleMessage(data []byte) bool {
 	// Parse based on protocol
 	var msg any
 	var err error
@@ -212,34 +273,75 @@ func (c *Client) handleMessage(data []byte) {
 			zap.String("protocol", c.protocol),
 			zap.Error(err),
 		)
-		return
+		return true
 	}
 
 	switch m := msg.(type) {
 	case *joinGroupRequest:
-		if c.hub.ValidateGroup(m.group) {
-			c.hub.JoinGroup(c, m.group)
-			if m.ackID != nil {
-				c.send <- c.buildAck(*m.ackID, true)
+		accepted := c.hub.ValidateGroup(m.group)
+		var schema *SchemaVersion
+		if accepted && m.schema != "" {
+			schema, accepted = c.hub.schemas.Lookup(m.schema)
+		}
+		if c.hub.joinRecorder != nil {
+			c.hub.joinRecorder.RecordJoin(c.apiKey, c.hub.name, m.group, accepted)
+		}
+		if accepted {
+			if !c.hub.JoinGroup(c, m.group, schema) {
+				if m.ackID != nil {
+					c.queue(c.buildAck(*m.ackID, false))
+				}
+				c.closeWithPolicyViolation("too many groups")
+				return false
 			}
+			c.ack(m.ackID, m.group, true)
 		} else {
-			c.logger.Debug("invalid group name",
+			c.logger.Debug("invalid group name or schema",
 				zap.String("connID", c.connID),
 				zap.String("group", m.group),
+				zap.String("schema", m.schema),
 			)
-			if m.ackID != nil {
-				c.send <- c.buildAck(*m.ackID, false)
-			}
+			c.ack(m.ackID, m.group, false)
 		}
 
 	case *leaveGroupRequest:
 		c.hub.LeaveGroup(c, m.group)
-		if m.ackID != nil {
-			c.send <- c.buildAck(*m.ackID, true)
-		}
+		c.ack(m.ackID, m.group, true)
 
 	case *pingRequest:
-		c.send <- c.buildPong()
+		c.queue(c.buildPong())
+	}
+	return true
+}
+
+// ack queues the ack of a join or leave when the client asked for one. A
+// chaos ack delay holds it back on a timer, so reads (pongs included) go on
+// meanwhile.
+func (c *Client) ack(ackID *uint64, group string, success bool) {
+	if ackID == nil {
+		return
+	}
+	msg := c.buildAck(*ackID, success)
+	if delay := c.hub.chaos.ackDelay(c.apiKey, group); delay > 0 {
+		time.AfterFunc(delay, func() { c.queue(msg) })
+		return
+	}
+	c.queue(msg)
+}
+
+// queue sends msg to the client unless the hub has released it. Safe from any
+// goroutine: the hub closes send only under its lock. A full buffer drops msg.
+func (c *Client) queue(msg []byte) bool {
+	c.hub.mu.RLock()
+	defer c.hub.mu.RUnlock()
+	if !c.hub.clients[c] {
+		return false
+	}
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		return false
 	}
 }
 
@@ -353,3 +455,20 @@ func IsValidStateGreeksOneGroup(group string) bool {
 		strings.HasSuffix(group, "_state_vanna_one") ||
 		strings.HasSuffix(group, "_state_charm_one")
 }
+
+// IsValidVolatilityGroup validates the volatility group name format.
+// Expected format: {prefix}_{ticker}_volatility_{iv_zero|iv_one}
+func IsValidVolatilityGroup(group string) bool {
+	// Must contain _volatility_ separator
+	idx := strings.Index(group, "_volatility_")
+	if idx <= 0 {
+		return false
+	}
+	// Ensure there's a prefix before _volatility_ (prefix_ticker)
+	if !strings.Contains(group[:idx], "_") {
+		return false
+	}
+	// Must end with one of the valid volatility categories
+	return strings.HasSuffix(group, "_volatility_iv_zero") ||
+		strings.HasSuffix(group, "_volatility_iv_one")
+}
@@ -11,6 +11,7 @@ import (
 	gexpb "github.com/dgnsrekt/gexbot-downloader/internal/ws/generated/gex"
 	greekpb "github.com/dgnsrekt/gexbot-downloader/internal/ws/generated/greek"
 	ofpb "github.com/dgnsrekt/gexbot-downloader/internal/ws/generated/orderflow"
+	volpb "github.com/dgnsrekt/gexbot-downloader/internal/ws/generated/volatility"
 )
 
 // Encoder converts JSON orderflow data to wire format (Protobuf + Zstd).
@@ -27,9 +28,48 @@ func NewEncoder() (*Encoder, error) {
 	return &Encoder{zstdEncoder: enc}, nil
 }
 
-// EncodeOrderflow converts JSON orderflow data to Zstd-compressed protobuf.
-// The result is ready to be wrapped in a DataMessage.
+// Encode converts JSON data of the given message kind to Zstd-compressed
+// protobuf, scaling float fields by sc. The result is ready to be wrapped in
+// a DataMessage.
+func (e *Encoder) Encode(kind string, jsonData []byte, sc Scale) ([]byte, error) {
+	switch kind {
+	case KindOrderflow:
+		return e.encodeOrderflow(jsonData, sc)
+	case KindGex:
+		return e.encodeGex(jsonData, sc)
+	case KindGreek:
+		return e.encodeGreek(jsonData, sc)
+	case KindVolatility:
+		return e.encodeVolatility(jsonData, sc)
+	default:
+		return nil, fmt.Errorf("unknown message kind %q", kind)
+	}
+}
+
+// EncodeOrderflow converts JSON orderflow data to Zstd-compressed protobuf
+// in the v1 format.
 func (e *Encoder) EncodeOrderflow(jsonData []byte) ([]byte, error) {
+	return e.encodeOrderflow(jsonData, defaultScale)
+}
+
+// EncodeGex converts JSON GEX data to Zstd-compressed protobuf in the v1 format.
+func (e *Encoder) EncodeGex(jsonData []byte) ([]byte, error) {
+	return e.encodeGex(jsonData, defaultScale)
+}
+
+// EncodeGreek converts JSON Greek data to Zstd-compressed protobuf in the v1 format.
+func (e *Encoder) EncodeGreek(jsonData []byte) ([]byte, error) {
+	return e.encodeGreek(jsonData, defaultScale)
+}
+
+// EncodeVolatility converts JSON volatility data to Zstd-compressed protobuf
+// in the v1 format.
+func (e *Encoder) EncodeVolatility(jsonData []byte) ([]byte, error) {
+	return e.encodeVolatility(jsonData, defaultScale)
+}
+
+// encodeOrderflow converts JSON orderflow data to Zstd-compressed protobuf using scale sc.
+func (e *Encoder) encodeOrderflow(jsonData []byte, sc Scale) ([]byte, error) {
 	// 1. Parse JSON into OrderflowData
 	var of data.OrderflowData
 	if err := json.Unmarshal(jsonData, &of); err != nil {
@@ -37,21 +77,21 @@ func (e *Encoder) EncodeOrderflow(jsonData []byte) ([]byte, error) {
 	}
 
 	// 2. Convert to protobuf with integer scaling
-	// Fields multiplied by 100: spot, gamma fields
+	// Fields multiplied by the level scale (v1: 100): spot, gamma fields
 	// Fields with no multiplier: state and orderflow fields
 	pbMsg := &ofpb.Orderflow{
 		Timestamp: of.Timestamp,
 		Ticker:    of.Ticker,
-		// Gamma fields: multiply by 100
-		Spot:                uint32(of.Spot * 100),
-		ZeroMajorLongGamma:  uint32(of.ZMlgamma * 100),
-		ZeroMajorShortGamma: uint32(of.ZMsgamma * 100),
-		OneMajorLongGamma:   uint32(of.OMlgamma * 100),
-		OneMajorShortGamma:  uint32(of.OMsgamma * 100),
-		ZeroMajorCallGamma:  uint32(of.ZeroMcall * 100),
-		ZeroMajorPutGamma:   uint32(of.ZeroMput * 100),
-		OneMajorCallGamma:   uint32(of.OneMcall * 100),
-		OneMajorPutGamma:    uint32(of.OneMput * 100),
+		// Gamma fields: multiply by level scale
+		Spot:                uint32(of.Spot * sc.Level),
+		ZeroMajorLongGamma:  uint32(of.ZMlgamma * sc.Level),
+		ZeroMajorShortGamma: uint32(of.ZMsgamma * sc.Level),
+		OneMajorLongGamma:   uint32(of.OMlgamma * sc.Level),
+		OneMajorShortGamma:  uint32(of.OMsgamma * sc.Level),
+		ZeroMajorCallGamma:  uint32(of.ZeroMcall * sc.Level),
+		ZeroMajorPutGamma:   uint32(of.ZeroMput * sc.Level),
+		OneMajorCallGamma:   uint32(of.OneMcall * sc.Level),
+		OneMajorPutGamma:    uint32(of.OneMput * sc.Level),
 		// State fields: no multiplier (sint32)
 		ZeroConvexityRatio: int32(of.Zcvr),
 		OneConvexityRatio:  int32(of.Ocvr),
@@ -94,9 +134,8 @@ func (e *Encoder) EncodeOrderflow(jsonData []byte) ([]byte, error) {
 	return compressed, nil
 }
 
-// EncodeGex converts JSON GEX data to Zstd-compressed protobuf.
-// The result is ready to be wrapped in a DataMessage.
-func (e *Encoder) EncodeGex(jsonData []byte) ([]byte, error) {
+// encodeGex converts JSON GEX data to Zstd-compressed protobuf using scale sc.
+func (e *Encoder) encodeGex(jsonData []byte, sc Scale) ([]byte, error) {
 	// 1. Parse JSON into GexData
 	var gex data.GexData
 	if err := json.Unmarshal(jsonData, &gex); err != nil {
@@ -128,9 +167,9 @@ func (e *Encoder) EncodeGex(jsonData []byte) ([]byte, error) {
 		}
 
 		strike := &gexpb.Strike{
-			StrikePrice: uint32(strikePrice * 100),
-			Value_1:     int32(value1 * 100),
-			Value_2:     int32(value2 * 100),
+			StrikePrice: uint32(strikePrice * sc.Level),
+			Value_1:     int32(value1 * sc.Level),
+			Value_2:     int32(value2 * sc.Level),
 		}
 
 		// Parse priors if present
@@ -139,7 +178,7 @@ func (e *Encoder) EncodeGex(jsonData []byte) ([]byte, error) {
 			if err := json.Unmarshal(s[3], &priors); err == nil && len(priors) > 0 {
 				priorValues := make([]int32, len(priors))
 				for i, p := range priors {
-					priorValues[i] = int32(p * 100)
+					priorValues[i] = int32(p * sc.Level)
 				}
 				strike.Priors = &gexpb.Priors{Values: priorValues}
 			}
@@ -156,8 +195,8 @@ func (e *Encoder) EncodeGex(jsonData []byte) ([]byte, error) {
 			for _, mp := range rawMaxPriors {
 				if len(mp) >= 2 {
 					tuples = append(tuples, &gexpb.MaxPriorsTuple{
-						FirstValue:  int32(mp[0] * 100),
-						SecondValue: int32(mp[1] * 1000),
+						FirstValue:  int32(mp[0] * sc.Level),
+						SecondValue: int32(mp[1] * sc.Ratio),
 					})
 				}
 			}
@@ -176,18 +215,18 @@ func (e *Encoder) EncodeGex(jsonData []byte) ([]byte, error) {
 		Ticker:     gex.Ticker,
 		MinDte:     &minDte,
 		SecMinDte:  &secMinDte,
-		// Fields multiplied by 100
-		Spot:        uint32(gex.Spot * 100),
-		ZeroGamma:   uint32(gex.ZeroGamma * 100),
-		MajorPosVol: uint32(gex.MajorPosVol * 100),
-		MajorPosOi:  uint32(gex.MajorPosOI * 100),
-		MajorNegVol: uint32(gex.MajorNegVol * 100),
-		MajorNegOi:  uint32(gex.MajorNegOI * 100),
+		// Fields multiplied by the level scale (v1: 100)
+		Spot:        uint32(gex.Spot * sc.Level),
+		ZeroGamma:   uint32(gex.ZeroGamma * sc.Level),
+		MajorPosVol: uint32(gex.MajorPosVol * sc.Level),
+		MajorPosOi:  uint32(gex.MajorPosOI * sc.Level),
+		MajorNegVol: uint32(gex.MajorNegVol * sc.Level),
+		MajorNegOi:  uint32(gex.MajorNegOI * sc.Level),
 		Strikes:     pbStrikes,
-		// Fields multiplied by 1000
-		SumGexVol:         int32(gex.SumGexVol * 1000),
-		SumGexOi:          int32(gex.SumGexOI * 1000),
-		DeltaRiskReversal: int32(gex.DeltaRiskReversal * 1000),
+		// Fields multiplied by the ratio scale (v1: 1000)
+		SumGexVol:         int32(gex.SumGexVol * sc.Ratio),
+		SumGexOi:          int32(gex.SumGexOI * sc.Ratio),
+		DeltaRiskReversal: int32(gex.DeltaRiskReversal * sc.Ratio),
 		MaxPriors:         pbMaxPriors,
 	}
 
@@ -203,9 +242,8 @@ func (e *Encoder) EncodeGex(jsonData []byte) ([]byte, error) {
 	return compressed, nil
 }
 
-// EncodeGreek converts JSON Greek data to Zstd-compressed protobuf.
-// The result is ready to be wrapped in a DataMessage.
-func (e *Encoder) EncodeGreek(jsonData []byte) ([]byte, error) {
+// encodeGreek converts JSON Greek data to Zstd-compressed protobuf using scale sc.
+func (e *Encoder) encodeGreek(jsonData []byte, sc Scale) ([]byte, error) {
 	// 1. Parse JSON into GreekData
 	var greek data.GreekData
 	if err := json.Unmarshal(jsonData, &greek); err != nil {
@@ -242,18 +280,18 @@ func (e *Encoder) EncodeGreek(jsonData []byte) ([]byte, error) {
 		}
 
 		contract := &greekpb.MiniContract{
-			Strike:      uint32(strike * 100),
-			CallIvol:    uint32(callIvol * 1000),
-			PutIvol:     uint32(putIvol * 1000),
-			CallCvolume: int32(callCvolume * 100),
+			Strike:      uint32(strike * sc.Level),
+			CallIvol:    uint32(callIvol * sc.Ratio),
+			PutIvol:     uint32(putIvol * sc.Ratio),
+			CallCvolume: int32(callCvolume * sc.Level),
 		}
 
-		// Parse call_cvolume_priors (index 4) - array of floats × 100
+		// Parse call_cvolume_priors (index 4) - array of floats × level scale
 		var callPriors []float64
 		if err := json.Unmarshal(c[4], &callPriors); err == nil && len(callPriors) > 0 {
 			priorValues := make([]int32, len(callPriors))
 			for i, p := range callPriors {
-				priorValues[i] = int32(p * 100)
+				priorValues[i] = int32(p * sc.Level)
 			}
 			contract.CallCvolumePriors = priorValues
 		}
@@ -285,13 +323,13 @@ func (e *Encoder) EncodeGreek(jsonData []byte) ([]byte, error) {
 	pbMsg := &greekpb.OptionProfile{
 		Timestamp:       greek.Timestamp,
 		Ticker:          greek.Ticker,
-		Spot:            uint32(greek.Spot * 100),
+		Spot:            uint32(greek.Spot * sc.Level),
 		MinDte:          &minDte,
 		SecMinDte:       &secMinDte,
-		MajorCallGamma:  uint32(greek.MajorPositive * 100),
-		MajorPutGamma:   uint32(greek.MajorNegative * 100),
-		MajorLongGamma:  uint32(greek.MajorLongGamma * 100),
-		MajorShortGamma: uint32(greek.MajorShortGamma * 100),
+		MajorCallGamma:  uint32(greek.MajorPositive * sc.Level),
+		MajorPutGamma:   uint32(greek.MajorNegative * sc.Level),
+		MajorLongGamma:  uint32(greek.MajorLongGamma * sc.Level),
+		MajorShortGamma: uint32(greek.MajorShortGamma * sc.Level),
 		MiniContracts:   pbContracts,
 	}
 
@@ -307,6 +345,62 @@ func (e *Encoder) EncodeGreek(jsonData []byte) ([]byte, error) {
 	return compressed, nil
 }
 
+// encodeVolatility converts JSON volatility data to Zstd-compressed protobuf using scale sc.
+func (e *Encoder) encodeVolatility(jsonData []byte, sc Scale) ([]byte, error) {
+	// 1. Parse JSON into VolatilityData
+	var vol data.VolatilityData
+	if err := json.Unmarshal(jsonData, &vol); err != nil {
+		return nil, fmt.Errorf("unmarshal volatility json: %w", err)
+	}
+
+	// 2. Parse strikes array: [[strike, call_iv, put_iv], ...]
+	var rawStrikes [][]float64
+	if len(vol.Strikes) > 0 {
+		if err := json.Unmarshal(vol.Strikes, &rawStrikes); err != nil {
+			return nil, fmt.Errorf("unmarshal strikes: %w", err)
+		}
+	}
+
+	pbStrikes := make([]*volpb.VolatilityStrike, 0, len(rawStrikes))
+	for _, s := range rawStrikes {
+		if len(s) < 3 {
+			continue
+		}
+		pbStrikes = append(pbStrikes, &volpb.VolatilityStrike{
+			StrikePrice: uint32(s[0] * sc.Level),
+			CallIv:      uint32(s[1] * sc.Ratio),
+			PutIv:       uint32(s[2] * sc.Ratio),
+		})
+	}
+
+	// 3. Build Volatility protobuf message with integer scaling
+	minDte := int32(vol.MinDTE)       //nolint:gosec // DTE values are always 0-365, safe for int32
+	secMinDte := int32(vol.SecMinDTE) //nolint:gosec // DTE values are always 0-365, safe for int32
+
+	pbMsg := &volpb.Volatility{
+		Timestamp:        vol.Timestamp,
+		Ticker:           vol.Ticker,
+		MinDte:           &minDte,
+		SecMinDte:        &secMinDte,
+		Spot:             uint32(vol.Spot * sc.Level),
+		AtmIv:            uint32(vol.AtmIV * sc.Ratio),
+		RiskReversal_25D: int32(vol.RiskReversal25d * sc.Ratio),
+		Butterfly_25D:    int32(vol.Butterfly25d * sc.Ratio),
+		Strikes:          pbStrikes,
+	}
+
+	// 4. Serialize to protobuf bytes
+	pbData, err := proto.Marshal(pbMsg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal volatility protobuf: %w", err)
+	}
+
+	// 5. Compress with Zstd
+	compressed := e.zstdEncoder.EncodeAll(pbData, nil)
+
+	return compressed, nil
+}
+
 // Close releases encoder resources.
 func (e *Encoder) Close() {
 	if e.zstdEncoder != nil {
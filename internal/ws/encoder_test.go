@@ -0,0 +1,47 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/protobuf/proto"
+
+	volpb "github.com/dgnsrekt/gexbot-downloader/internal/ws/generated/volatility"
+)
+
+func TestEncodeVolatility(t *testing.T) {
+	enc, err := NewEncoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer enc.Close()
+
+	raw := []byte(`{"timestamp":1764340202,"ticker":"SPX","spot":6822.95,"min_dte":0,"sec_min_dte":3,` +
+		`"atm_iv":0.142,"risk_reversal_25d":-0.031,"butterfly_25d":0.006,"strikes":[[6800,0.151,0.163],[6825,0.14]]}`)
+	encoded, err := enc.EncodeVolatility(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	pbData, err := dec.DecodeAll(encoded, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var msg volpb.Volatility
+	if err := proto.Unmarshal(pbData, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Ticker != "SPX" || msg.Spot != 682295 || msg.AtmIv != 142 || msg.RiskReversal_25D != -31 || msg.GetSecMinDte() != 3 {
+		t.Fatalf("unexpected message: %v", &msg)
+	}
+	// Short strike rows are skipped
+	if len(msg.Strikes) != 1 || msg.Strikes[0].StrikePrice != 680000 || msg.Strikes[0].PutIv != 163 {
+		t.Fatalf("unexpected strikes: %v", msg.Strikes)
+	}
+}
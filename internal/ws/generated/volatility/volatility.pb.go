@@ -0,0 +1,269 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.28.3
+// source: volatility.proto
+
+package volatility
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Volatility represents the implied volatility skew for a specific ticker at a given timestamp.
+type Volatility struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp
+	Ticker    string                 `protobuf:"bytes,2,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	MinDte    *int32                 `protobuf:"zigzag32,3,opt,name=min_dte,json=minDte,proto3,oneof" json:"min_dte,omitempty"`            // Optional Minimum Days to Expiration
+	SecMinDte *int32                 `protobuf:"zigzag32,4,opt,name=sec_min_dte,json=secMinDte,proto3,oneof" json:"sec_min_dte,omitempty"` // Optional Second Minimum Days to Expiration
+	Spot      uint32                 `protobuf:"varint,5,opt,name=spot,proto3" json:"spot,omitempty"`                                      // Spot price * 100
+	// Implied volatility values multiplied by 1000
+	AtmIv            uint32              `protobuf:"varint,6,opt,name=atm_iv,json=atmIv,proto3" json:"atm_iv,omitempty"`                                   // At-the-money implied volatility * 1000
+	RiskReversal_25D int32               `protobuf:"zigzag32,7,opt,name=risk_reversal_25d,json=riskReversal25d,proto3" json:"risk_reversal_25d,omitempty"` // 25-delta risk reversal * 1000
+	Butterfly_25D    int32               `protobuf:"zigzag32,8,opt,name=butterfly_25d,json=butterfly25d,proto3" json:"butterfly_25d,omitempty"`            // 25-delta butterfly * 1000
+	Strikes          []*VolatilityStrike `protobuf:"bytes,9,rep,name=strikes,proto3" json:"strikes,omitempty"`                                             // Per-strike implied volatility
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Volatility) Reset() {
+	*x = Volatility{}
+	mi := &file_volatility_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Volatility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Volatility) ProtoMessage() {}
+
+func (x *Volatility) ProtoReflect() protoreflect.Message {
+	mi := &file_volatility_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Volatility.ProtoReflect.Descriptor instead.
+func (*Volatility) Descriptor() ([]byte, []int) {
+	return file_volatility_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Volatility) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Volatility) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Volatility) GetMinDte() int32 {
+	if x != nil && x.MinDte != nil {
+		return *x.MinDte
+	}
+	return 0
+}
+
+func (x *Volatility) GetSecMinDte() int32 {
+	if x != nil && x.SecMinDte != nil {
+		return *x.SecMinDte
+	}
+	return 0
+}
+
+func (x *Volatility) GetSpot() uint32 {
+	if x != nil {
+		return x.Spot
+	}
+	return 0
+}
+
+func (x *Volatility) GetAtmIv() uint32 {
+	if x != nil {
+		return x.AtmIv
+	}
+	return 0
+}
+
+func (x *Volatility) GetRiskReversal_25D() int32 {
+	if x != nil {
+		return x.RiskReversal_25D
+	}
+	return 0
+}
+
+func (x *Volatility) GetButterfly_25D() int32 {
+	if x != nil {
+		return x.Butterfly_25D
+	}
+	return 0
+}
+
+func (x *Volatility) GetStrikes() []*VolatilityStrike {
+	if x != nil {
+		return x.Strikes
+	}
+	return nil
+}
+
+// Represents the call and put implied volatility at one strike.
+type VolatilityStrike struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StrikePrice   uint32                 `protobuf:"varint,1,opt,name=strike_price,json=strikePrice,proto3" json:"strike_price,omitempty"` // Strike price * 100
+	CallIv        uint32                 `protobuf:"varint,2,opt,name=call_iv,json=callIv,proto3" json:"call_iv,omitempty"`                // Call implied volatility * 1000
+	PutIv         uint32                 `protobuf:"varint,3,opt,name=put_iv,json=putIv,proto3" json:"put_iv,omitempty"`                   // Put implied volatility * 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolatilityStrike) Reset() {
+	*x = VolatilityStrike{}
+	mi := &file_volatility_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolatilityStrike) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolatilityStrike) ProtoMessage() {}
+
+func (x *VolatilityStrike) ProtoReflect() protoreflect.Message {
+	mi := &file_volatility_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolatilityStrike.ProtoReflect.Descriptor instead.
+func (*VolatilityStrike) Descriptor() ([]byte, []int) {
+	return file_volatility_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VolatilityStrike) GetStrikePrice() uint32 {
+	if x != nil {
+		return x.StrikePrice
+	}
+	return 0
+}
+
+func (x *VolatilityStrike) GetCallIv() uint32 {
+	if x != nil {
+		return x.CallIv
+	}
+	return 0
+}
+
+func (x *VolatilityStrike) GetPutIv() uint32 {
+	if x != nil {
+		return x.PutIv
+	}
+	return 0
+}
+
+var File_volatility_proto protoreflect.FileDescriptor
+
+const file_volatility_proto_rawDesc = "" +
+	"\n" +
+	"\x10volatility.proto\x12\n" +
+	"volatility\"\xd5\x02\n" +
+	"\n" +
+	"Volatility\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x16\n" +
+	"\x06ticker\x18\x02 \x01(\tR\x06ticker\x12\x1c\n" +
+	"\amin_dte\x18\x03 \x01(\x11H\x00R\x06minDte\x88\x01\x01\x12#\n" +
+	"\vsec_min_dte\x18\x04 \x01(\x11H\x01R\tsecMinDte\x88\x01\x01\x12\x12\n" +
+	"\x04spot\x18\x05 \x01(\rR\x04spot\x12\x15\n" +
+	"\x06atm_iv\x18\x06 \x01(\rR\x05atmIv\x12*\n" +
+	"\x11risk_reversal_25d\x18\a \x01(\x11R\x0friskReversal25d\x12#\n" +
+	"\rbutterfly_25d\x18\b \x01(\x11R\fbutterfly25d\x126\n" +
+	"\astrikes\x18\t \x03(\v2\x1c.volatility.VolatilityStrikeR\astrikesB\n" +
+	"\n" +
+	"\b_min_dteB\x0e\n" +
+	"\f_sec_min_dte\"e\n" +
+	"\x10VolatilityStrike\x12!\n" +
+	"\fstrike_price\x18\x01 \x01(\rR\vstrikePrice\x12\x17\n" +
+	"\acall_iv\x18\x02 \x01(\rR\x06callIv\x12\x15\n" +
+	"\x06put_iv\x18\x03 \x01(\rR\x05putIvBHZFgithub.com/dgnsrekt/gexbot-downloader/internal/ws/generated/volatilityb\x06proto3"
+
+var (
+	file_volatility_proto_rawDescOnce sync.Once
+	file_volatility_proto_rawDescData []byte
+)
+
+func file_volatility_proto_rawDescGZIP() []byte {
+	file_volatility_proto_rawDescOnce.Do(func() {
+		file_volatility_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_volatility_proto_rawDesc), len(file_volatility_proto_rawDesc)))
+	})
+	return file_volatility_proto_rawDescData
+}
+
+var file_volatility_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_volatility_proto_goTypes = []any{
+	(*Volatility)(nil),       // 0: volatility.Volatility
+	(*VolatilityStrike)(nil), // 1: volatility.VolatilityStrike
+}
+var file_volatility_proto_depIdxs = []int32{
+	1, // 0: volatility.Volatility.strikes:type_name -> volatility.VolatilityStrike
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_volatility_proto_init() }
+func file_volatility_proto_init() {
+	if File_volatility_proto != nil {
+		return
+	}
+	file_volatility_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_volatility_proto_rawDesc), len(file_volatility_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_volatility_proto_goTypes,
+		DependencyIndexes: file_volatility_proto_depIdxs,
+		MessageInfos:      file_volatility_proto_msgTypes,
+	}.Build()
+	File_volatility_proto = out.File
+	file_volatility_proto_goTypes = nil
+	file_volatility_proto_depIdxs = nil
+}
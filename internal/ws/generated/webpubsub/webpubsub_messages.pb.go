@@ -510,6 +510,7 @@ type UpstreamMessage_JoinGroupMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
 	AckId         *uint64                `protobuf:"varint,2,opt,name=ack_id,json=ackId,proto3,oneof" json:"ack_id,omitempty"`
+	Schema        *string                `protobuf:"bytes,3,opt,name=schema,proto3,oneof" json:"schema,omitempty"` // Faker extension: wire-format version for this group
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -558,6 +559,13 @@ func (x *UpstreamMessage_JoinGroupMessage) GetAckId() uint64 {
 	return 0
 }
 
+func (x *UpstreamMessage_JoinGroupMessage) GetSchema() string {
+	if x != nil && x.Schema != nil {
+		return *x.Schema
+	}
+	return ""
+}
+
 type UpstreamMessage_LeaveGroupMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
@@ -1082,7 +1090,7 @@ var File_webpubsub_messages_proto protoreflect.FileDescriptor
 
 const file_webpubsub_messages_proto_rawDesc = "" +
 	"\n" +
-	"\x18webpubsub_messages.proto\x12\x12azure.webpubsub.v1\x1a\x19google/protobuf/any.proto\"\xe8\b\n" +
+	"\x18webpubsub_messages.proto\x12\x12azure.webpubsub.v1\x1a\x19google/protobuf/any.proto\"\x90\t\n" +
 	"\x0fUpstreamMessage\x12k\n" +
 	"\x15send_to_group_message\x18\x01 \x01(\v26.azure.webpubsub.v1.UpstreamMessage.SendToGroupMessageH\x00R\x12sendToGroupMessage\x12W\n" +
 	"\revent_message\x18\x05 \x01(\v20.azure.webpubsub.v1.UpstreamMessage.EventMessageH\x00R\feventMessage\x12d\n" +
@@ -1099,11 +1107,13 @@ const file_webpubsub_messages_proto_rawDesc = "" +
 	"\x05event\x18\x01 \x01(\tR\x05event\x123\n" +
 	"\x04data\x18\x02 \x01(\v2\x1f.azure.webpubsub.v1.MessageDataR\x04data\x12\x1a\n" +
 	"\x06ack_id\x18\x03 \x01(\x04H\x00R\x05ackId\x88\x01\x01B\t\n" +
-	"\a_ack_id\x1aO\n" +
+	"\a_ack_id\x1aw\n" +
 	"\x10JoinGroupMessage\x12\x14\n" +
 	"\x05group\x18\x01 \x01(\tR\x05group\x12\x1a\n" +
-	"\x06ack_id\x18\x02 \x01(\x04H\x00R\x05ackId\x88\x01\x01B\t\n" +
-	"\a_ack_id\x1aP\n" +
+	"\x06ack_id\x18\x02 \x01(\x04H\x00R\x05ackId\x88\x01\x01\x12\x1b\n" +
+	"\x06schema\x18\x03 \x01(\tH\x01R\x06schema\x88\x01\x01B\t\n" +
+	"\a_ack_idB\t\n" +
+	"\a_schema\x1aP\n" +
 	"\x11LeaveGroupMessage\x12\x14\n" +
 	"\x05group\x18\x01 \x01(\tR\x05group\x12\x1a\n" +
 	"\x06ack_id\x18\x02 \x01(\x04H\x00R\x05ackId\x88\x01\x01B\t\n" +
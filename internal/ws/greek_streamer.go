@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/stats"
 )
 
 // GreekStreamer broadcasts Greek profile data from JSONL files to subscribed clients.
@@ -21,6 +22,8 @@ type GreekStreamer struct {
 	interval      time.Duration
 	logger        *zap.Logger
 	reloadChecker ReloadChecker
+	schedule      *keySchedule
+	clock         *data.PlaybackClock // nil plays one record per tick
 }
 
 // NewGreekStreamer creates a new GreekStreamer with shared cache for per-API-key tracking.
@@ -38,9 +41,22 @@ func NewGreekStreamer(hub *Hub, loader data.DataLoader, cache *data.IndexCache,
 		interval:      interval,
 		logger:        logger,
 		reloadChecker: reloadChecker,
+		schedule:      newKeySchedule(interval),
 	}, nil
 }
 
+// SetKeyIntervals installs per-API-key interval overrides.
+// Call before Run.
+func (s *GreekStreamer) SetKeyIntervals(intervals *KeyIntervals) {
+	s.schedule.intervals = intervals
+}
+
+// SetPlaybackClock replays records at their timestamps, scaled by the
+// clock's speed, instead of one per tick. Call before Run.
+func (s *GreekStreamer) SetPlaybackClock(clock *data.PlaybackClock) {
+	s.clock = clock
+}
+
 // Run starts the streaming loop. Call in a goroutine.
 // Returns when context is cancelled.
 func (s *GreekStreamer) Run(ctx context.Context) {
@@ -61,7 +77,7 @@ func (s *GreekStreamer) Run(ctx context.Context) {
 	case <-time.After(time.Until(nextSecond)):
 	}
 
-	ticker := time.NewTicker(s.interval)
+	ticker := time.NewTicker(s.schedule.start())
 	defer ticker.Stop()
 
 	s.logger.Info("greek streamer started",
@@ -75,7 +91,8 @@ func (s *GreekStreamer) Run(ctx context.Context) {
 			s.encoder.Close()
 			return
 
-		case <-ticker.C:
+		case now := <-ticker.C:
+			s.schedule.begin(now, ticker)
 			s.broadcastNext(ctx)
 		}
 	}
@@ -94,34 +111,38 @@ func (s *GreekStreamer) broadcastNext(ctx context.Context) {
 		return
 	}
 
-	for _, group := range groups {
-		// Parse group name: blue_{ticker}_state_{category}
-		ticker, category := extractGreekTickerAndCategory(group)
-		if ticker == "" || category == "" {
-			continue
-		}
+	// Group names: blue_{ticker}_state_{category}, or blue_ALL_state_{category} for every ticker
+	for _, sub := range expandSubscriptions(groups, extractGreekTickerAndCategory, s.loader, "state") {
+		ticker, category := sub.ticker, sub.category
 
-		// Get data length once for this ticker:category
-		length, err := s.loader.GetLength(ticker, "state", category)
-		if err != nil {
-			s.logger.Debug("failed to get data length",
-				zap.String("ticker", ticker),
-				zap.String("category", category),
-				zap.Error(err),
-			)
-			continue
-		}
-
-		// Get clients grouped by API key
-		clientsByAPIKey := s.hub.GetClientsByAPIKey(group)
+		// Get clients grouped by API key across per-ticker and aggregate groups
+		clientsByAPIKey := sub.clientsByAPIKey(s.hub)
 		if len(clientsByAPIKey) == 0 {
 			continue
 		}
 
 		// For each API key, get their position and broadcast their data
 		for apiKey, clients := range clientsByAPIKey {
+			// Keys with their own interval are skipped until due
+			if !s.schedule.due(apiKey) {
+				continue
+			}
+
+			// Keys pinned to another date replay their own dataset
+			loader := loaderFor(s.loader, s.reloadChecker, apiKey)
+			length, err := loader.GetLength(ticker, "state", category)
+			if err != nil {
+				s.logger.Debug("failed to get data length",
+					zap.String("ticker", ticker),
+					zap.String("category", category),
+					zap.String("apiKey", maskAPIKey(apiKey)),
+					zap.Error(err),
+				)
+				continue
+			}
+
 			cacheKey := data.WSCacheKey("state_greeks_zero", ticker, category, apiKey)
-			idx, exhausted := s.cache.GetAndAdvance(cacheKey, length)
+			idx, exhausted, due := nextRecord(ctx, s.cache, s.clock, loader, cacheKey, ticker, "state", category, length)
 
 			// In exhaust mode, skip this API key if exhausted
 			if exhausted {
@@ -133,8 +154,13 @@ func (s *GreekStreamer) broadcastNext(ctx context.Context) {
 				continue
 			}
 
+			// The clock has not reached a new record yet
+			if !due {
+				continue
+			}
+
 			// Get raw JSON data at this API key's index
-			rawJSON, err := s.loader.GetRawAtIndex(ctx, ticker, "state", category, idx)
+			rawJSON, err := loader.GetRawAtIndex(ctx, ticker, "state", category, idx)
 			if err != nil {
 				s.logger.Debug("failed to get data at index",
 					zap.String("ticker", ticker),
@@ -145,9 +171,9 @@ func (s *GreekStreamer) broadcastNext(ctx context.Context) {
 				continue
 			}
 
-			// Encode to protobuf + zstd
-			encoded, err := s.encoder.EncodeGreek(rawJSON)
-			if err != nil {
+			// Encode per schema version and broadcast to all clients with this API key
+			if err := clients.broadcast(s.hub, s.encoder, KindGreek, rawJSON); err != nil {
+				stats.EncodeFailures.Add(1)
 				s.logger.Debug("failed to encode greek",
 					zap.String("ticker", ticker),
 					zap.String("category", category),
@@ -156,15 +182,12 @@ func (s *GreekStreamer) broadcastNext(ctx context.Context) {
 				continue
 			}
 
-			// Broadcast to all clients with this API key
-			s.hub.BroadcastToClients(clients, group, encoded, rawJSON, "proto.greek")
-
 			s.logger.Debug("broadcast greek",
 				zap.String("ticker", ticker),
 				zap.String("category", category),
 				zap.String("apiKey", maskAPIKey(apiKey)),
 				zap.Int("index", idx),
-				zap.Int("clientCount", len(clients)),
+				zap.Int("clientCount", clients.count()),
 			)
 		}
 	}
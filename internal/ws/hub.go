@@ -3,6 +3,7 @@ package ws
 import (
 	"context"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -21,6 +22,14 @@ type Hub struct {
 	mu             sync.RWMutex
 	logger         *zap.Logger
 	groupValidator GroupValidator
+	joinRecorder   JoinRecorder // optional audit hook for group joins
+	chaos          *ChaosConfig // optional delivery fault injection
+	schemas        *SchemaRegistry
+	now            func() time.Time // clock of the upstream rate limit
+
+	// Shutdown tracking
+	done  chan struct{}  // closed once Run has shut down all clients
+	pumps sync.WaitGroup // tracks write pumps of registered clients
 }
 
 // GroupMessage represents a message to broadcast to a group.
@@ -40,9 +49,35 @@ func NewHub(name string, logger *zap.Logger, validator GroupValidator) *Hub {
 		broadcast:      make(chan *GroupMessage, 256),
 		logger:         logger,
 		groupValidator: validator,
+		schemas:        defaultSchemas,
+		done:           make(chan struct{}),
+		now:            time.Now,
 	}
 }
 
+// JoinRecorder receives every group join attempt on a hub.
+type JoinRecorder interface {
+	RecordJoin(apiKey, hub, group string, accepted bool)
+}
+
+// SetJoinRecorder installs an audit hook for group joins.
+// Call before the hub starts accepting connections.
+func (h *Hub) SetJoinRecorder(recorder JoinRecorder) {
+	h.joinRecorder = recorder
+}
+
+// SetChaos enables delivery fault injection for data messages and acks.
+// Call before the hub starts accepting connections.
+func (h *Hub) SetChaos(chaos *ChaosConfig) {
+	h.chaos = chaos
+}
+
+// SetSchemas sets the wire-format versions clients may request.
+// Call before the hub starts accepting connections.
+func (h *Hub) SetSchemas(schemas *SchemaRegistry) {
+	h.schemas = schemas
+}
+
 // ValidateGroup checks if a group name is valid for this hub.
 func (h *Hub) ValidateGroup(group string) bool {
 	if h.groupValidator == nil {
@@ -59,9 +94,12 @@ func (h *Hub) Run(ctx context.Context) {
 		case <-ctx.Done():
 			h.logger.Info("hub shutting down", zap.String("hub", h.name))
 			h.shutdown()
+			close(h.done)
 			return
 
 		case client := <-h.register:
+			// Counted here, before done is closed, so Add never races Drain's Wait
+			h.pumps.Add(1)
 			h.mu.Lock()
 			h.clients[client] = true
 			h.mu.Unlock()
@@ -122,22 +160,100 @@ func (h *Hub) shutdown() {
 	h.groups = make(map[string]map[*Client]bool)
 }
 
-// JoinGroup adds a client to a group.
-func (h *Hub) JoinGroup(client *Client, group string) {
+// DisconnectAll sends every connected client a system "disconnected" message
+// carrying reason, then closes its connection. Clients are free to
+// reconnect; use this to simulate service-side disconnects.
+func (h *Hub) DisconnectAll(reason string) int {
+	// Queue the notice under the read lock so send cannot be closed by a
+	// concurrent unregister
+	h.mu.RLock()
+	clients := make([]*Client, 0, len(h.clients))
+	for client := range h.clients {
+		msg := buildDisconnectedMessage(reason)
+		if client.protocol == "json" {
+			msg = buildDisconnectedMessageJSON(reason)
+		}
+		select {
+		case client.send <- msg:
+		default:
+		}
+		clients = append(clients, client)
+	}
+	h.mu.RUnlock()
+
+	// Unregistering closes send after the queued notice, so writePump
+	// flushes it before the close frame
+	for _, client := range clients {
+		go func(c *Client) {
+			select {
+			case h.unregister <- c:
+			case <-h.done:
+			}
+		}(client)
+	}
+
+	if len(clients) > 0 {
+		h.logger.Info("disconnected all clients",
+			zap.String("hub", h.name),
+			zap.Int("clients", len(clients)),
+			zap.String("reason", reason),
+		)
+	}
+	return len(clients)
+}
+
+// Drain blocks until the hub has shut down and every client write pump has
+// flushed its close frame, or until ctx expires.
+// Call after cancelling the Run context and before shutting down the HTTP server.
+func (h *Hub) Drain(ctx context.Context) error {
+	select {
+	case <-h.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	pumpsDone := make(chan struct{})
+	go func() {
+		h.pumps.Wait()
+		close(pumpsDone)
+	}()
+
+	select {
+	case <-pumpsDone:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// JoinGroup adds a client to a group. A non-nil schema overrides the
+// connection's wire format for this group.
+// Returns false when the client is already at maxGroupsPerClient.
+func (h *Hub) JoinGroup(client *Client, group string, schema *SchemaVersion) bool {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	if !client.groups[group] && len(client.groups) >= maxGroupsPerClient {
+		return false
+	}
+
 	if h.groups[group] == nil {
 		h.groups[group] = make(map[*Client]bool)
 	}
 	h.groups[group][client] = true
 	client.groups[group] = true
+	if schema != nil {
+		client.groupSchemas[group] = schema
+	} else {
+		delete(client.groupSchemas, group)
+	}
 
 	h.logger.Debug("client joined group",
 		zap.String("hub", h.name),
 		zap.String("connID", client.connID),
 		zap.String("group", group),
 	)
+	return true
 }
 
 // LeaveGroup removes a client from a group.
@@ -152,6 +268,7 @@ func (h *Hub) LeaveGroup(client *Client, group string) {
 		}
 	}
 	delete(client.groups, group)
+	delete(client.groupSchemas, group)
 
 	h.logger.Debug("client left group",
 		zap.String("hub", h.name),
@@ -160,6 +277,16 @@ func (h *Hub) LeaveGroup(client *Client, group string) {
 	)
 }
 
+// schemaFor returns the wire format a client receives on a group.
+func (h *Hub) schemaFor(client *Client, group string) *SchemaVersion {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if schema, ok := client.groupSchemas[group]; ok {
+		return schema
+	}
+	return client.schema
+}
+
 // GetActiveGroups returns all groups with at least one subscriber.
 func (h *Hub) GetActiveGroups() []string {
 	h.mu.RLock()
@@ -199,14 +326,7 @@ func (h *Hub) BroadcastData(group string, encodedData []byte, typeUrl string) {
 	for _, client := range clientList {
 		// Build message in client's protocol format
 		msg := client.buildDataMsg(group, encodedData, typeUrl)
-		select {
-		case client.send <- msg:
-		default:
-			// Buffer full, schedule disconnect
-			go func(c *Client) {
-				h.unregister <- c
-			}(client)
-		}
+		h.deliver(client, group, msg)
 	}
 }
 
@@ -237,14 +357,7 @@ func (h *Hub) BroadcastDataDual(group string, encodedData []byte, rawJSON []byte
 			// Protobuf clients get binary format
 			msg = buildDataMessage(group, encodedData, typeUrl)
 		}
-		select {
-		case client.send <- msg:
-		default:
-			// Buffer full, schedule disconnect
-			go func(c *Client) {
-				h.unregister <- c
-			}(client)
-		}
+		h.deliver(client, group, msg)
 	}
 }
 
@@ -278,6 +391,35 @@ func (h *Hub) BroadcastToClients(clients []*Client, group string, encodedData []
 			// Protobuf clients get binary format
 			msg = buildDataMessage(group, encodedData, typeUrl)
 		}
+		h.deliver(client, group, msg)
+	}
+}
+
+// deliver queues a data message for a client, applying chaos faults if configured.
+func (h *Hub) deliver(client *Client, group string, msg []byte) {
+	copies := 1
+	switch h.chaos.dataAction(client.apiKey, group) {
+	case chaosDrop:
+		h.logger.Debug("chaos: dropping data message",
+			zap.String("hub", h.name),
+			zap.String("connID", client.connID),
+			zap.String("group", group),
+		)
+		return
+	case chaosDuplicate:
+		copies = 2
+	case chaosDisconnect:
+		h.logger.Debug("chaos: forcing disconnect",
+			zap.String("hub", h.name),
+			zap.String("connID", client.connID),
+			zap.String("group", group),
+		)
+		// Close without a close frame; readPump unregisters the client
+		_ = client.conn.Close()
+		return
+	}
+
+	for i := 0; i < copies; i++ {
 		select {
 		case client.send <- msg:
 		default:
@@ -285,6 +427,7 @@ func (h *Hub) BroadcastToClients(clients []*Client, group string, encodedData []
 			go func(c *Client) {
 				h.unregister <- c
 			}(client)
+			return
 		}
 	}
 }
@@ -0,0 +1,158 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// startHub runs a hub serving WebSocket connections until the test ends.
+func startHub(t *testing.T, chaos *ChaosConfig) (*Hub, context.CancelFunc, string) {
+	t.Helper()
+	hub := NewHub("orderflow", zap.NewNop(), IsValidOrderflowGroup)
+	hub.SetChaos(chaos)
+	ctx, cancel := context.WithCancel(context.Background())
+	go hub.Run(ctx)
+	srv := httptest.NewServer(http.HandlerFunc(hub.HandleOrderflowWS))
+	t.Cleanup(func() {
+		cancel()
+		srv.Close()
+	})
+	return hub, cancel, "ws" + strings.TrimPrefix(srv.URL, "http") + "?access_token=key"
+}
+
+// dialJSON connects with the JSON subprotocol and reads the connected message.
+func dialJSON(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+	dialer := websocket.Dialer{Subprotocols: []string{"json.webpubsub.azure.v1"}}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	if msg := readJSON(t, conn); msg["event"] != "connected" {
+		t.Fatalf("first message = %v, want connected", msg)
+	}
+	return conn
+}
+
+func readJSON(t *testing.T, conn *websocket.Conn) map[string]any {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg map[string]any
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestDrainWaitsForConnectedClients(t *testing.T) {
+	hub, cancel, url := startHub(t, nil)
+	conn := dialJSON(t, url)
+
+	cancel()
+	ctx, stop := context.WithTimeout(context.Background(), 5*time.Second)
+	defer stop()
+	if err := hub.Drain(ctx); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+
+	// The write pump flushed its close frame before Drain returned
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNoStatusReceived) {
+		t.Errorf("after drain: err = %v, want close frame", err)
+	}
+}
+
+func TestDelayedAckDoesNotBlockReads(t *testing.T) {
+	_, _, url := startHub(t, &ChaosConfig{AckDelay: 300 * time.Millisecond})
+	conn := dialJSON(t, url)
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"joinGroup","group":"blue_SPX_orderflow_orderflow","ackId":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"ping"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readJSON(t, conn); msg["type"] != "pong" {
+		t.Fatalf("first reply = %v, want the pong ahead of the delayed ack", msg)
+	}
+	if msg := readJSON(t, conn); msg["type"] != "ack" || msg["success"] != true {
+		t.Errorf("second reply = %v, want a successful ack", msg)
+	}
+}
+
+// expectPolicyClose reads until the connection closes and checks it was closed
+// with 1008.
+func expectPolicyClose(t *testing.T, conn *websocket.Conn) {
+	t.Helper()
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if _, _, err := conn.ReadMessage(); err != nil {
+			if !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+				t.Errorf("err = %v, want a policy violation close", err)
+			}
+			return
+		}
+	}
+}
+
+func TestMaxGroupsPerClient(t *testing.T) {
+	hub, _, url := startHub(t, nil)
+	start := time.Now()
+	var elapsed atomic.Int64
+	hub.now = func() time.Time { return start.Add(time.Duration(elapsed.Load())) }
+	conn := dialJSON(t, url)
+
+	join := func(i int) {
+		msg := fmt.Sprintf(`{"type":"joinGroup","group":"blue_T%d_orderflow_orderflow","ackId":%d}`, i, i)
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Join in rounds the rate limit allows, a second apart on the hub's clock
+	for i := range maxGroupsPerClient {
+		join(i)
+		if msg := readJSON(t, conn); msg["type"] != "ack" || msg["success"] != true {
+			t.Fatalf("join %d: reply = %v, want a successful ack", i, msg)
+		}
+		if i%upstreamRateLimit == upstreamRateLimit-1 {
+			elapsed.Add(int64(time.Second))
+		}
+	}
+
+	// The join past the limit is refused, and the refusal arrives before the close
+	join(maxGroupsPerClient)
+	if msg := readJSON(t, conn); msg["type"] != "ack" || msg["success"] != false {
+		t.Fatalf("join past the limit: reply = %v, want a failed ack", msg)
+	}
+	expectPolicyClose(t, conn)
+}
+
+func TestUpstreamRateLimit(t *testing.T) {
+	_, _, url := startHub(t, nil)
+	conn := dialJSON(t, url)
+
+	// One past the burst: any more would be left unread by the server, whose
+	// close then resets the connection
+	for range upstreamRateBurst + 1 {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"ping"}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Pongs of the allowed pings are flushed, then the connection is closed
+	expectPolicyClose(t, conn)
+}
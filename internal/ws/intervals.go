@@ -0,0 +1,174 @@
+package ws
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+// MinKeyInterval is the fastest per-key stream interval accepted.
+const MinKeyInterval = 10 * time.Millisecond
+
+// staleSchedule is how long a key may go unchecked before its schedule is dropped.
+const staleSchedule = time.Minute
+
+// KeyIntervals holds per-API-key stream intervals that override the global
+// WS_STREAM_INTERVAL, so a real-time client and a slow logger can share one
+// instance. Safe for concurrent use; a nil *KeyIntervals has no overrides.
+type KeyIntervals struct {
+	mu        sync.RWMutex
+	intervals map[string]time.Duration
+}
+
+// NewKeyIntervals creates a registry from initial overrides (may be nil).
+func NewKeyIntervals(intervals map[string]time.Duration) (*KeyIntervals, error) {
+	k := &KeyIntervals{intervals: make(map[string]time.Duration, len(intervals))}
+	for apiKey, d := range intervals {
+		if err := k.Set(apiKey, d); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// Set overrides the stream interval for apiKey.
+func (k *KeyIntervals) Set(apiKey string, d time.Duration) error {
+	if apiKey == "" {
+		return fmt.Errorf("empty API key")
+	}
+	if d < MinKeyInterval {
+		return fmt.Errorf("interval %s is below the minimum of %s", d, MinKeyInterval)
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.intervals[apiKey] = d
+	return nil
+}
+
+// Delete removes the override for apiKey. Returns false if there was none.
+func (k *KeyIntervals) Delete(apiKey string) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.intervals[apiKey]; !ok {
+		return false
+	}
+	delete(k.intervals, apiKey)
+	return true
+}
+
+// Get returns the override for apiKey.
+func (k *KeyIntervals) Get(apiKey string) (time.Duration, bool) {
+	if k == nil {
+		return 0, false
+	}
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	d, ok := k.intervals[apiKey]
+	return d, ok
+}
+
+// All returns a copy of every override.
+func (k *KeyIntervals) All() map[string]time.Duration {
+	if k == nil {
+		return map[string]time.Duration{}
+	}
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return maps.Clone(k.intervals)
+}
+
+// fastest returns the shortest override, or 0 when there are none.
+func (k *KeyIntervals) fastest() time.Duration {
+	if k == nil {
+		return 0
+	}
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	var fastest time.Duration
+	for _, d := range k.intervals {
+		if fastest == 0 || d < fastest {
+			fastest = d
+		}
+	}
+	return fastest
+}
+
+// keySchedule decides which API keys a streamer serves on each tick. The
+// streamer ticks at the fastest interval in use; keys with a slower interval
+// are skipped until they are due, so their replay position advances at their
+// own cadence. Intervals are honoured to the resolution of that tick.
+// Not safe for concurrent use: it belongs to one streamer goroutine.
+type keySchedule struct {
+	base      time.Duration // global stream interval
+	intervals *KeyIntervals
+
+	tick    time.Duration        // period of the current tick
+	now     time.Time            // time of the current tick
+	next    map[string]time.Time // when each slower key is next due
+	decided map[string]bool      // due decisions made this tick
+}
+
+func newKeySchedule(base time.Duration) *keySchedule {
+	return &keySchedule{
+		base:    base,
+		tick:    base,
+		next:    make(map[string]time.Time),
+		decided: make(map[string]bool),
+	}
+}
+
+// period returns the tick period: the global interval, or the fastest
+// override when that is shorter.
+func (ks *keySchedule) period() time.Duration {
+	if f := ks.intervals.fastest(); f > 0 && f < ks.base {
+		return f
+	}
+	return ks.base
+}
+
+// start returns the initial tick period.
+func (ks *keySchedule) start() time.Duration {
+	ks.tick = ks.period()
+	return ks.tick
+}
+
+// begin starts a tick, retuning ticker when overrides changed the period.
+func (ks *keySchedule) begin(now time.Time, ticker *time.Ticker) {
+	if p := ks.period(); p != ks.tick {
+		ks.tick = p
+		ticker.Reset(p)
+	}
+	ks.now = now
+	clear(ks.decided)
+	for apiKey, next := range ks.next {
+		if now.Sub(next) > staleSchedule {
+			delete(ks.next, apiKey)
+		}
+	}
+}
+
+// due reports whether apiKey receives a record on this tick. Every stream
+// a key subscribes to gets the same answer within a tick.
+func (ks *keySchedule) due(apiKey string) bool {
+	if d, ok := ks.decided[apiKey]; ok {
+		return d
+	}
+
+	interval := ks.base
+	if d, ok := ks.intervals.Get(apiKey); ok {
+		interval = d
+	}
+
+	due := true
+	if interval > ks.tick {
+		next, seen := ks.next[apiKey]
+		due = !seen || !ks.now.Before(next)
+		if due {
+			// Half a tick of slack absorbs ticker jitter
+			ks.next[apiKey] = ks.now.Add(interval - ks.tick/2)
+		}
+	}
+	ks.decided[apiKey] = due
+	return due
+}
@@ -0,0 +1,55 @@
+package ws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeySchedule(t *testing.T) {
+	intervals, err := NewKeyIntervals(map[string]time.Duration{
+		"fast": 250 * time.Millisecond,
+		"slow": 2 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks := newKeySchedule(time.Second)
+	ks.intervals = intervals
+	if got := ks.start(); got != 250*time.Millisecond {
+		t.Fatalf("period = %s, want 250ms", got)
+	}
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	// Four seconds of ticks
+	sent := map[string]int{}
+	start := time.Now()
+	for i := range 16 {
+		ks.begin(start.Add(time.Duration(i)*250*time.Millisecond), ticker)
+		for _, key := range []string{"fast", "default", "slow"} {
+			if ks.due(key) {
+				sent[key]++
+			}
+			// Same answer for every stream of the key within a tick
+			if ks.due(key) != ks.due(key) {
+				t.Fatal("due changed within a tick")
+			}
+		}
+	}
+	want := map[string]int{"fast": 16, "default": 4, "slow": 2}
+	for key, n := range want {
+		if sent[key] != n {
+			t.Errorf("%s: sent %d records, want %d", key, sent[key], n)
+		}
+	}
+
+	// Removing the only faster key returns to the global interval
+	intervals.Delete("fast")
+	ks.begin(start.Add(4*time.Second), ticker)
+	if ks.tick != time.Second {
+		t.Errorf("tick = %s after delete, want 1s", ks.tick)
+	}
+	if err := intervals.Set("fast", time.Millisecond); err == nil {
+		t.Error("expected error for interval below minimum")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/google/uuid"
@@ -18,13 +19,19 @@ type NegotiateResponse struct {
 
 // NegotiateHandler handles the /negotiate endpoint.
 type NegotiateHandler struct {
-	logger *zap.Logger
-	prefix string
+	logger  *zap.Logger
+	prefix  string
+	schemas *SchemaRegistry
 }
 
 // NewNegotiateHandler creates a new NegotiateHandler.
 func NewNegotiateHandler(logger *zap.Logger, prefix string) *NegotiateHandler {
-	return &NegotiateHandler{logger: logger, prefix: prefix}
+	return &NegotiateHandler{logger: logger, prefix: prefix, schemas: defaultSchemas}
+}
+
+// SetSchemas sets the wire-format versions clients may request with ?schema=.
+func (h *NegotiateHandler) SetSchemas(schemas *SchemaRegistry) {
+	h.schemas = schemas
 }
 
 // HandleNegotiate handles GET /negotiate
@@ -45,6 +52,14 @@ func (h *NegotiateHandler) HandleNegotiate(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Optional wire-format version, passed through to the hub URLs
+	schema := r.URL.Query().Get("schema")
+	if _, ok := h.schemas.Lookup(schema); !ok {
+		h.logger.Debug("negotiate request with unknown schema", zap.String("schema", schema))
+		http.Error(w, fmt.Sprintf(`{"error":"unknown schema version, available: %s"}`, strings.Join(h.schemas.Names(), ", ")), http.StatusBadRequest)
+		return
+	}
+
 	// Generate simple access token (apiKey:connectionID)
 	// This is simplified for the faker - real API uses JWT
 	connID := uuid.New().String()
@@ -57,14 +72,19 @@ func (h *NegotiateHandler) HandleNegotiate(w http.ResponseWriter, r *http.Reques
 	}
 
 	baseURL := fmt.Sprintf("%s://%s/ws", scheme, r.Host)
+	query := "access_token=" + token
+	if schema != "" {
+		query += "&schema=" + url.QueryEscape(schema)
+	}
 
 	response := NegotiateResponse{
 		WebsocketURLs: map[string]string{
-			"orderflow":         fmt.Sprintf("%s/orderflow?access_token=%s", baseURL, token),
-			"state_gex":         fmt.Sprintf("%s/state_gex?access_token=%s", baseURL, token),
-			"classic":           fmt.Sprintf("%s/classic?access_token=%s", baseURL, token),
-			"state_greeks_zero": fmt.Sprintf("%s/state_greeks_zero?access_token=%s", baseURL, token),
-			"state_greeks_one":  fmt.Sprintf("%s/state_greeks_one?access_token=%s", baseURL, token),
+			"orderflow":         fmt.Sprintf("%s/orderflow?%s", baseURL, query),
+			"state_gex":         fmt.Sprintf("%s/state_gex?%s", baseURL, query),
+			"classic":           fmt.Sprintf("%s/classic?%s", baseURL, query),
+			"state_greeks_zero": fmt.Sprintf("%s/state_greeks_zero?%s", baseURL, query),
+			"state_greeks_one":  fmt.Sprintf("%s/state_greeks_one?%s", baseURL, query),
+			"volatility":        fmt.Sprintf("%s/volatility?%s", baseURL, query),
 		},
 		Prefix: h.prefix,
 	}
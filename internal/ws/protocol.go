@@ -1,9 +1,12 @@
 package ws
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 
 	pb "github.com/dgnsrekt/gexbot-downloader/internal/ws/generated/webpubsub"
 	"google.golang.org/protobuf/proto"
@@ -13,8 +16,9 @@ import (
 // Upstream message types for internal routing
 type (
 	joinGroupRequest struct {
-		group string
-		ackID *uint64
+		group  string
+		ackID  *uint64
+		schema string // requested schema version, "" for the connection's
 	}
 	leaveGroupRequest struct {
 		group string
@@ -23,21 +27,74 @@ type (
 	pingRequest struct{}
 )
 
+const (
+	// maxUpstreamMessageBytes bounds a single upstream control message.
+	// Join/leave/ping frames are tiny; anything larger is hostile or broken.
+	maxUpstreamMessageBytes = 4 * 1024
+
+	// maxGroupNameLength bounds group names accepted from clients.
+	maxGroupNameLength = 128
+)
+
+var errUpstreamTooLarge = errors.New("upstream message too large")
+
+// validateGroupName rejects empty, oversized or non [A-Za-z0-9_.-] group names
+// before they reach hub maps, logs or group validators.
+func validateGroupName(group string) error {
+	if group == "" {
+		return errors.New("missing group")
+	}
+	if len(group) > maxGroupNameLength {
+		return fmt.Errorf("group name exceeds %d bytes", maxGroupNameLength)
+	}
+	for i := 0; i < len(group); i++ {
+		c := group[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-', c == '.':
+		default:
+			return fmt.Errorf("invalid character %q in group name", c)
+		}
+	}
+	return nil
+}
+
 // parseUpstreamMessage parses a protobuf-encoded UpstreamMessage.
+// Messages carrying unknown fields, oversized payloads or invalid group names
+// are rejected. Must never panic: the input comes straight off the socket.
 func parseUpstreamMessage(data []byte) (any, error) {
+	if len(data) > maxUpstreamMessageBytes {
+		return nil, errUpstreamTooLarge
+	}
+
 	var msg pb.UpstreamMessage
 	if err := proto.Unmarshal(data, &msg); err != nil {
 		return nil, fmt.Errorf("unmarshal upstream message: %w", err)
 	}
+	if len(msg.ProtoReflect().GetUnknown()) > 0 {
+		return nil, errors.New("upstream message has unknown fields")
+	}
 
 	switch m := msg.Message.(type) {
 	case *pb.UpstreamMessage_JoinGroupMessage_:
+		if m.JoinGroupMessage == nil || len(m.JoinGroupMessage.ProtoReflect().GetUnknown()) > 0 {
+			return nil, errors.New("malformed joinGroup message")
+		}
+		if err := validateGroupName(m.JoinGroupMessage.Group); err != nil {
+			return nil, err
+		}
 		return &joinGroupRequest{
-			group: m.JoinGroupMessage.Group,
-			ackID: m.JoinGroupMessage.AckId,
+			group:  m.JoinGroupMessage.Group,
+			ackID:  m.JoinGroupMessage.AckId,
+			schema: m.JoinGroupMessage.GetSchema(),
 		}, nil
 
 	case *pb.UpstreamMessage_LeaveGroupMessage_:
+		if m.LeaveGroupMessage == nil || len(m.LeaveGroupMessage.ProtoReflect().GetUnknown()) > 0 {
+			return nil, errors.New("malformed leaveGroup message")
+		}
+		if err := validateGroupName(m.LeaveGroupMessage.Group); err != nil {
+			return nil, err
+		}
 		return &leaveGroupRequest{
 			group: m.LeaveGroupMessage.Group,
 			ackID: m.LeaveGroupMessage.AckId,
@@ -69,6 +126,24 @@ func buildConnectedMessage(connectionID, userID string) []byte {
 	return data
 }
 
+// buildDisconnectedMessage creates a system DisconnectedMessage telling the
+// client why the service is closing its connection.
+func buildDisconnectedMessage(reason string) []byte {
+	msg := &pb.DownstreamMessage{
+		Message: &pb.DownstreamMessage_SystemMessage_{
+			SystemMessage: &pb.DownstreamMessage_SystemMessage{
+				Message: &pb.DownstreamMessage_SystemMessage_DisconnectedMessage_{
+					DisconnectedMessage: &pb.DownstreamMessage_SystemMessage_DisconnectedMessage{
+						Reason: reason,
+					},
+				},
+			},
+		},
+	}
+	data, _ := proto.Marshal(msg)
+	return data
+}
+
 // buildAckMessage creates an acknowledgment message.
 func buildAckMessage(ackID uint64, success bool) []byte {
 	msg := &pb.DownstreamMessage{
@@ -137,6 +212,17 @@ func buildConnectedMessageJSON(connectionID, userID string) []byte {
 	return data
 }
 
+// buildDisconnectedMessageJSON creates a JSON system disconnected message.
+func buildDisconnectedMessageJSON(reason string) []byte {
+	msg := map[string]interface{}{
+		"type":    "system",
+		"event":   "disconnected",
+		"message": reason,
+	}
+	data, _ := json.Marshal(msg)
+	return data
+}
+
 // buildAckMessageJSON creates a JSON acknowledgment message.
 func buildAckMessageJSON(ackID uint64, success bool) []byte {
 	msg := map[string]interface{}{
@@ -179,38 +265,49 @@ func buildPongMessageJSON() []byte {
 	return data
 }
 
+// upstreamMessageJSON is the accepted shape of JSON upstream control messages.
+type upstreamMessageJSON struct {
+	Type   string  `json:"type"`
+	Group  string  `json:"group"`
+	AckID  *uint64 `json:"ackId"`
+	Schema string  `json:"schema"` // joinGroup only; faker extension
+}
+
 // parseUpstreamMessageJSON parses a JSON-encoded upstream message.
+// Unknown fields, trailing data, non-integer ackIds, oversized payloads and
+// invalid group names are rejected.
 func parseUpstreamMessageJSON(data []byte) (any, error) {
-	var msg map[string]interface{}
-	if err := json.Unmarshal(data, &msg); err != nil {
-		return nil, fmt.Errorf("unmarshal JSON upstream message: %w", err)
+	if len(data) > maxUpstreamMessageBytes {
+		return nil, errUpstreamTooLarge
 	}
 
-	msgType, _ := msg["type"].(string)
+	var msg upstreamMessageJSON
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&msg); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON upstream message: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON upstream message")
+	}
 
-	switch msgType {
+	switch msg.Type {
 	case "joinGroup":
-		group, _ := msg["group"].(string)
-		var ackID *uint64
-		if v, ok := msg["ackId"].(float64); ok {
-			id := uint64(v)
-			ackID = &id
+		if err := validateGroupName(msg.Group); err != nil {
+			return nil, err
 		}
-		return &joinGroupRequest{group: group, ackID: ackID}, nil
+		return &joinGroupRequest{group: msg.Group, ackID: msg.AckID, schema: msg.Schema}, nil
 
 	case "leaveGroup":
-		group, _ := msg["group"].(string)
-		var ackID *uint64
-		if v, ok := msg["ackId"].(float64); ok {
-			id := uint64(v)
-			ackID = &id
+		if err := validateGroupName(msg.Group); err != nil {
+			return nil, err
 		}
-		return &leaveGroupRequest{group: group, ackID: ackID}, nil
+		return &leaveGroupRequest{group: msg.Group, ackID: msg.AckID}, nil
 
 	case "ping":
 		return &pingRequest{}, nil
 
 	default:
-		return nil, fmt.Errorf("unknown JSON message type: %s", msgType)
+		return nil, fmt.Errorf("unknown JSON message type: %q", msg.Type)
 	}
 }
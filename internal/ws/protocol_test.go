@@ -0,0 +1,125 @@
+package ws
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/dgnsrekt/gexbot-downloader/internal/ws/generated/webpubsub"
+	"google.golang.org/protobuf/proto"
+)
+
+func marshalUpstream(t testing.TB, msg *pb.UpstreamMessage) []byte {
+	t.Helper()
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func joinMessage(t testing.TB, group string) []byte {
+	ackID := uint64(1)
+	return marshalUpstream(t, &pb.UpstreamMessage{
+		Message: &pb.UpstreamMessage_JoinGroupMessage_{
+			JoinGroupMessage: &pb.UpstreamMessage_JoinGroupMessage{Group: group, AckId: &ackID},
+		},
+	})
+}
+
+// checkParsed asserts invariants every successfully parsed message must hold.
+func checkParsed(t *testing.T, msg any) {
+	switch m := msg.(type) {
+	case *joinGroupRequest:
+		if err := validateGroupName(m.group); err != nil {
+			t.Fatalf("accepted invalid join group %q: %v", m.group, err)
+		}
+	case *leaveGroupRequest:
+		if err := validateGroupName(m.group); err != nil {
+			t.Fatalf("accepted invalid leave group %q: %v", m.group, err)
+		}
+	case *pingRequest:
+	default:
+		t.Fatalf("unexpected message type %T", msg)
+	}
+}
+
+func TestParseUpstreamMessageJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		ok    bool
+	}{
+		{"join", `{"type":"joinGroup","group":"blue_SPX_classic_gex_zero","ackId":1}`, true},
+		{"leave without ack", `{"type":"leaveGroup","group":"blue_SPX_orderflow_orderflow"}`, true},
+		{"ping", `{"type":"ping"}`, true},
+		{"unknown field", `{"type":"joinGroup","group":"g","extra":true}`, false},
+		{"negative ack", `{"type":"joinGroup","group":"g","ackId":-1}`, false},
+		{"fractional ack", `{"type":"joinGroup","group":"g","ackId":1.5}`, false},
+		{"missing group", `{"type":"joinGroup"}`, false},
+		{"bad group chars", `{"type":"joinGroup","group":"a/b"}`, false},
+		{"long group", `{"type":"joinGroup","group":"` + strings.Repeat("a", maxGroupNameLength+1) + `"}`, false},
+		{"trailing data", `{"type":"ping"}{"type":"ping"}`, false},
+		{"unknown type", `{"type":"sendToGroup"}`, false},
+		{"not json", `\x00\x01`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := parseUpstreamMessageJSON([]byte(tt.input))
+			if (err == nil) != tt.ok {
+				t.Fatalf("parseUpstreamMessageJSON(%s) error = %v, want ok=%v", tt.input, err, tt.ok)
+			}
+			if err == nil {
+				checkParsed(t, msg)
+			}
+		})
+	}
+}
+
+func TestParseUpstreamMessageRejectsBadGroups(t *testing.T) {
+	if _, err := parseUpstreamMessage(joinMessage(t, "blue_SPX_classic_gex_zero")); err != nil {
+		t.Fatalf("valid join rejected: %v", err)
+	}
+	for _, group := range []string{"", "a b", strings.Repeat("x", maxGroupNameLength+1)} {
+		if _, err := parseUpstreamMessage(joinMessage(t, group)); err == nil {
+			t.Errorf("join with group %q accepted", group)
+		}
+	}
+
+	// Unknown field 15 (varint) appended to a valid message
+	withUnknown := append(joinMessage(t, "g"), 0x78, 0x01)
+	if _, err := parseUpstreamMessage(withUnknown); err == nil {
+		t.Error("message with unknown fields accepted")
+	}
+}
+
+func FuzzParseUpstreamMessage(f *testing.F) {
+	f.Add(joinMessage(f, "blue_SPX_classic_gex_zero"))
+	f.Add(marshalUpstream(f, &pb.UpstreamMessage{
+		Message: &pb.UpstreamMessage_PingMessage_{PingMessage: &pb.UpstreamMessage_PingMessage{}},
+	}))
+	f.Add([]byte{})
+	f.Add([]byte{0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg, err := parseUpstreamMessage(data)
+		if err == nil {
+			checkParsed(t, msg)
+		}
+	})
+}
+
+func FuzzParseUpstreamMessageJSON(f *testing.F) {
+	f.Add([]byte(`{"type":"joinGroup","group":"blue_SPX_classic_gex_zero","ackId":1}`))
+	f.Add([]byte(`{"type":"leaveGroup","group":"g"}`))
+	f.Add([]byte(`{"type":"ping"}`))
+	f.Add([]byte(`{"type":"joinGroup","group":"g","ackId":1e400}`))
+	f.Add([]byte(`[`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg, err := parseUpstreamMessageJSON(data)
+		if err == nil {
+			checkParsed(t, msg)
+		}
+	})
+}
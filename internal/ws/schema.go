@@ -0,0 +1,155 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Message kinds streamed by the hubs. The v1 type URL of each is "proto.<kind>".
+const (
+	KindOrderflow  = "orderflow"
+	KindGex        = "gex"
+	KindGreek      = "greek"
+	KindVolatility = "volatility"
+)
+
+// DefaultSchemaVersion is the built-in wire format matching the current GexBot API.
+const DefaultSchemaVersion = "v1"
+
+// Scale holds the integer scaling factors applied to float fields before
+// they are written to protobuf.
+type Scale struct {
+	Level float64 // prices, gamma levels and strike values (v1: 100)
+	Ratio float64 // IV, ratios and GEX sums (v1: 1000)
+}
+
+// defaultScale is the v1 scaling.
+var defaultScale = Scale{Level: 100, Ratio: 1000}
+
+// SchemaVersion is one wire-format revision: the typeUrl each message kind is
+// labelled with and the scaling applied to its fields. Serving several versions
+// side by side lets clients test against an upcoming format before it ships.
+type SchemaVersion struct {
+	Name       string            `json:"name"`
+	TypeURLs   map[string]string `json:"type_urls,omitempty"`   // kind -> typeUrl; missing kinds use v1
+	LevelScale float64           `json:"level_scale,omitempty"` // 0 uses v1
+	RatioScale float64           `json:"ratio_scale,omitempty"` // 0 uses v1
+}
+
+// TypeURL returns the typeUrl for a message kind.
+func (v *SchemaVersion) TypeURL(kind string) string {
+	if u, ok := v.TypeURLs[kind]; ok {
+		return u
+	}
+	return "proto." + kind
+}
+
+// Scale returns the version's scaling factors.
+func (v *SchemaVersion) Scale() Scale {
+	s := Scale{Level: v.LevelScale, Ratio: v.RatioScale}
+	if s.Level == 0 {
+		s.Level = defaultScale.Level
+	}
+	if s.Ratio == 0 {
+		s.Ratio = defaultScale.Ratio
+	}
+	return s
+}
+
+// SchemaRegistry holds the schema versions clients may request.
+type SchemaRegistry struct {
+	versions map[string]*SchemaVersion
+	def      *SchemaVersion
+}
+
+// defaultSchemas serves v1 only; used by hubs without a registry.
+var defaultSchemas = mustSchemaRegistry(nil, "")
+
+// NewSchemaRegistry creates a registry of the built-in v1 plus versions.
+// A version named v1 replaces the built-in one. defaultName selects the
+// version served to clients that do not ask for one ("" means v1).
+func NewSchemaRegistry(versions []SchemaVersion, defaultName string) (*SchemaRegistry, error) {
+	r := &SchemaRegistry{
+		versions: map[string]*SchemaVersion{
+			DefaultSchemaVersion: {Name: DefaultSchemaVersion},
+		},
+	}
+	for i := range versions {
+		v := versions[i]
+		if v.Name == "" {
+			return nil, fmt.Errorf("schema version %d: missing name", i)
+		}
+		if v.LevelScale < 0 || v.RatioScale < 0 {
+			return nil, fmt.Errorf("schema version %s: scales must not be negative", v.Name)
+		}
+		for kind := range v.TypeURLs {
+			switch kind {
+			case KindOrderflow, KindGex, KindGreek, KindVolatility:
+			default:
+				return nil, fmt.Errorf("schema version %s: unknown message kind %q", v.Name, kind)
+			}
+		}
+		r.versions[v.Name] = &v
+	}
+
+	if defaultName == "" {
+		defaultName = DefaultSchemaVersion
+	}
+	def, ok := r.versions[defaultName]
+	if !ok {
+		return nil, fmt.Errorf("default schema version %q is not defined", defaultName)
+	}
+	r.def = def
+	return r, nil
+}
+
+func mustSchemaRegistry(versions []SchemaVersion, defaultName string) *SchemaRegistry {
+	r, err := NewSchemaRegistry(versions, defaultName)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// LoadSchemaRegistry reads a JSON array of schema versions from path, e.g.
+// [{"name": "v2", "type_urls": {"gex": "gexbot.v2.Gex"}, "ratio_scale": 10000}].
+// An empty path serves v1 only.
+func LoadSchemaRegistry(path, defaultName string) (*SchemaRegistry, error) {
+	var versions []SchemaVersion
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading WS_SCHEMA_FILE: %w", err)
+		}
+		if err := json.Unmarshal(raw, &versions); err != nil {
+			return nil, fmt.Errorf("parsing WS_SCHEMA_FILE %s: %w", path, err)
+		}
+	}
+	return NewSchemaRegistry(versions, defaultName)
+}
+
+// Lookup returns the named version, or the default for "".
+func (r *SchemaRegistry) Lookup(name string) (*SchemaVersion, bool) {
+	if name == "" {
+		return r.def, true
+	}
+	v, ok := r.versions[name]
+	return v, ok
+}
+
+// Default returns the version served when a client does not ask for one.
+func (r *SchemaRegistry) Default() *SchemaVersion {
+	return r.def
+}
+
+// Names returns the sorted names of all versions.
+func (r *SchemaRegistry) Names() []string {
+	names := make([]string, 0, len(r.versions))
+	for name := range r.versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,114 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	volpb "github.com/dgnsrekt/gexbot-downloader/internal/ws/generated/volatility"
+	pb "github.com/dgnsrekt/gexbot-downloader/internal/ws/generated/webpubsub"
+)
+
+func TestSchemaRegistry(t *testing.T) {
+	r, err := NewSchemaRegistry([]SchemaVersion{
+		{Name: "v2", TypeURLs: map[string]string{KindGex: "gexbot.v2.Gex"}, RatioScale: 10000},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v1, _ := r.Lookup("")
+	if v1.Name != "v1" || v1.TypeURL(KindGex) != "proto.gex" || v1.Scale() != defaultScale {
+		t.Errorf("default version: got %+v", v1)
+	}
+	v2, ok := r.Lookup("v2")
+	if !ok {
+		t.Fatal("v2 not registered")
+	}
+	if v2.TypeURL(KindGex) != "gexbot.v2.Gex" || v2.TypeURL(KindGreek) != "proto.greek" {
+		t.Errorf("v2 type URLs: gex=%s greek=%s", v2.TypeURL(KindGex), v2.TypeURL(KindGreek))
+	}
+	if got := v2.Scale(); got.Level != 100 || got.Ratio != 10000 {
+		t.Errorf("v2 scale: got %+v", got)
+	}
+	if _, ok := r.Lookup("v3"); ok {
+		t.Error("lookup of undefined version succeeded")
+	}
+
+	if _, err := NewSchemaRegistry(nil, "v2"); err == nil {
+		t.Error("expected error for undefined default version")
+	}
+	if _, err := NewSchemaRegistry([]SchemaVersion{{Name: "v2", TypeURLs: map[string]string{"bogus": "x"}}}, ""); err == nil {
+		t.Error("expected error for unknown message kind")
+	}
+}
+
+func TestBroadcastPerSchema(t *testing.T) {
+	schemas, err := NewSchemaRegistry([]SchemaVersion{
+		{Name: "v2", TypeURLs: map[string]string{KindVolatility: "gexbot.v2.Volatility"}, RatioScale: 10000},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHub("volatility", zap.NewNop(), IsValidVolatilityGroup)
+	h.SetSchemas(schemas)
+	enc, err := NewEncoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer enc.Close()
+
+	group := "blue_SPX_volatility_iv_zero"
+	newClient := func() *Client {
+		return &Client{
+			hub:          h,
+			send:         make(chan []byte, 1),
+			apiKey:       "key",
+			groups:       make(map[string]bool),
+			protocol:     "protobuf",
+			schema:       schemas.Default(),
+			groupSchemas: make(map[string]*SchemaVersion),
+		}
+	}
+	current, upcoming := newClient(), newClient()
+	v2, _ := schemas.Lookup("v2")
+	h.JoinGroup(current, group, nil)
+	h.JoinGroup(upcoming, group, v2)
+
+	raw := []byte(`{"timestamp":1,"ticker":"SPX","spot":6000,"atm_iv":0.25}`)
+	gc := groupClients{group: {current, upcoming}}
+	if err := gc.broadcast(h, enc, KindVolatility, raw); err != nil {
+		t.Fatal(err)
+	}
+
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	receive := func(c *Client) (string, *volpb.Volatility) {
+		var msg pb.DownstreamMessage
+		if err := proto.Unmarshal(<-c.send, &msg); err != nil {
+			t.Fatal(err)
+		}
+		payload := msg.GetDataMessage().GetData().GetProtobufData()
+		pbData, err := dec.DecodeAll(payload.GetValue(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var vol volpb.Volatility
+		if err := proto.Unmarshal(pbData, &vol); err != nil {
+			t.Fatal(err)
+		}
+		return payload.GetTypeUrl(), &vol
+	}
+
+	if typeURL, vol := receive(current); typeURL != "proto.volatility" || vol.AtmIv != 250 {
+		t.Errorf("v1 client: typeUrl=%s atm_iv=%d", typeURL, vol.AtmIv)
+	}
+	if typeURL, vol := receive(upcoming); typeURL != "gexbot.v2.Volatility" || vol.AtmIv != 2500 {
+		t.Errorf("v2 client: typeUrl=%s atm_iv=%d", typeURL, vol.AtmIv)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/stats"
 )
 
 // ReloadChecker provides a way to check if a data reload is in progress.
@@ -16,6 +17,30 @@ type ReloadChecker interface {
 	IsReloading() bool
 }
 
+// loaderFor returns the loader serving apiKey. When the reload checker also
+// resolves per-key dates (the server's ReloadManager does), keys pinned to
+// another date read from that date's loader instead of the shared one.
+func loaderFor(loader data.DataLoader, rc ReloadChecker, apiKey string) data.DataLoader {
+	if resolver, ok := rc.(data.KeyResolver); ok {
+		keyLoader, _ := resolver.Resolve(apiKey)
+		return keyLoader
+	}
+	return loader
+}
+
+// nextRecord advances cacheKey and returns the record to stream. Without a
+// playback clock every call advances one record; with one, due is false
+// until the clock reaches a record not sent yet.
+func nextRecord(ctx context.Context, cache *data.IndexCache, clock *data.PlaybackClock, loader data.DataLoader, cacheKey, ticker, pkg, category string, length int) (idx int, exhausted, due bool) {
+	if clock == nil {
+		idx, exhausted = cache.GetAndAdvance(cacheKey, length)
+		return idx, exhausted, true
+	}
+	before := cache.GetIndex(cacheKey)
+	idx, exhausted = clock.Advance(ctx, cache, cacheKey, loader, ticker, pkg, category, length, "")
+	return idx, exhausted, cache.GetIndex(cacheKey) != before
+}
+
 // Streamer broadcasts data from JSONL files to subscribed clients.
 // Uses per-API-key position tracking via shared IndexCache.
 type Streamer struct {
@@ -26,6 +51,8 @@ type Streamer struct {
 	interval      time.Duration
 	logger        *zap.Logger
 	reloadChecker ReloadChecker
+	schedule      *keySchedule
+	clock         *data.PlaybackClock // nil plays one record per tick
 }
 
 // NewStreamer creates a new Streamer with shared cache for per-API-key tracking.
@@ -43,9 +70,22 @@ func NewStreamer(hub *Hub, loader data.DataLoader, cache *data.IndexCache, inter
 		interval:      interval,
 		logger:        logger,
 		reloadChecker: reloadChecker,
+		schedule:      newKeySchedule(interval),
 	}, nil
 }
 
+// SetKeyIntervals installs per-API-key interval overrides.
+// Call before Run.
+func (s *Streamer) SetKeyIntervals(intervals *KeyIntervals) {
+	s.schedule.intervals = intervals
+}
+
+// SetPlaybackClock replays records at their timestamps, scaled by the
+// clock's speed, instead of one per tick. Call before Run.
+func (s *Streamer) SetPlaybackClock(clock *data.PlaybackClock) {
+	s.clock = clock
+}
+
 // Run starts the streaming loop. Call in a goroutine.
 // Returns when context is cancelled.
 func (s *Streamer) Run(ctx context.Context) {
@@ -66,7 +106,7 @@ func (s *Streamer) Run(ctx context.Context) {
 	case <-time.After(time.Until(nextSecond)):
 	}
 
-	ticker := time.NewTicker(s.interval)
+	ticker := time.NewTicker(s.schedule.start())
 	defer ticker.Stop()
 
 	s.logger.Info("streamer started",
@@ -80,7 +120,8 @@ func (s *Streamer) Run(ctx context.Context) {
 			s.encoder.Close()
 			return
 
-		case <-ticker.C:
+		case now := <-ticker.C:
+			s.schedule.begin(now, ticker)
 			s.broadcastNext(ctx)
 		}
 	}
@@ -99,33 +140,37 @@ func (s *Streamer) broadcastNext(ctx context.Context) {
 		return
 	}
 
-	for _, group := range groups {
-		// Parse group name: blue_{ticker}_orderflow_orderflow
-		ticker := extractTicker(group)
-		if ticker == "" {
-			continue
-		}
+	// Group names: blue_{ticker}_orderflow_orderflow, or blue_ALL_orderflow_orderflow for every ticker
+	for _, sub := range expandSubscriptions(groups, extractOrderflowTickerAndCategory, s.loader, "orderflow") {
+		ticker := sub.ticker
 
-		// Get data length once for this ticker
-		length, err := s.loader.GetLength(ticker, "orderflow", "orderflow")
-		if err != nil {
-			s.logger.Debug("failed to get data length",
-				zap.String("ticker", ticker),
-				zap.Error(err),
-			)
-			continue
-		}
-
-		// Get clients grouped by API key
-		clientsByAPIKey := s.hub.GetClientsByAPIKey(group)
+		// Get clients grouped by API key across per-ticker and aggregate groups
+		clientsByAPIKey := sub.clientsByAPIKey(s.hub)
 		if len(clientsByAPIKey) == 0 {
 			continue
 		}
 
 		// For each API key, get their position and broadcast their data
 		for apiKey, clients := range clientsByAPIKey {
+			// Keys with their own interval are skipped until due
+			if !s.schedule.due(apiKey) {
+				continue
+			}
+
+			// Keys pinned to another date replay their own dataset
+			loader := loaderFor(s.loader, s.reloadChecker, apiKey)
+			length, err := loader.GetLength(ticker, "orderflow", "orderflow")
+			if err != nil {
+				s.logger.Debug("failed to get data length",
+					zap.String("ticker", ticker),
+					zap.String("apiKey", maskAPIKey(apiKey)),
+					zap.Error(err),
+				)
+				continue
+			}
+
 			cacheKey := data.WSCacheKey("orderflow", ticker, "orderflow", apiKey)
-			idx, exhausted := s.cache.GetAndAdvance(cacheKey, length)
+			idx, exhausted, due := nextRecord(ctx, s.cache, s.clock, loader, cacheKey, ticker, "orderflow", "orderflow", length)
 
 			// In exhaust mode, skip this API key if exhausted
 			if exhausted {
@@ -136,8 +181,13 @@ func (s *Streamer) broadcastNext(ctx context.Context) {
 				continue
 			}
 
+			// The clock has not reached a new record yet
+			if !due {
+				continue
+			}
+
 			// Get raw JSON data at this API key's index
-			rawJSON, err := s.loader.GetRawAtIndex(ctx, ticker, "orderflow", "orderflow", idx)
+			rawJSON, err := loader.GetRawAtIndex(ctx, ticker, "orderflow", "orderflow", idx)
 			if err != nil {
 				s.logger.Debug("failed to get data at index",
 					zap.String("ticker", ticker),
@@ -147,9 +197,9 @@ func (s *Streamer) broadcastNext(ctx context.Context) {
 				continue
 			}
 
-			// Encode to protobuf + zstd
-			encoded, err := s.encoder.EncodeOrderflow(rawJSON)
-			if err != nil {
+			// Encode per schema version and broadcast to all clients with this API key
+			if err := clients.broadcast(s.hub, s.encoder, KindOrderflow, rawJSON); err != nil {
+				stats.EncodeFailures.Add(1)
 				s.logger.Debug("failed to encode orderflow",
 					zap.String("ticker", ticker),
 					zap.Error(err),
@@ -157,19 +207,24 @@ func (s *Streamer) broadcastNext(ctx context.Context) {
 				continue
 			}
 
-			// Broadcast to all clients with this API key
-			s.hub.BroadcastToClients(clients, group, encoded, rawJSON, "proto.orderflow")
-
 			s.logger.Debug("broadcast orderflow",
 				zap.String("ticker", ticker),
 				zap.String("apiKey", maskAPIKey(apiKey)),
 				zap.Int("index", idx),
-				zap.Int("clientCount", len(clients)),
+				zap.Int("clientCount", clients.count()),
 			)
 		}
 	}
 }
 
+// extractOrderflowTickerAndCategory adapts extractTicker to expandSubscriptions.
+func extractOrderflowTickerAndCategory(group string) (ticker, category string) {
+	if ticker = extractTicker(group); ticker == "" {
+		return "", ""
+	}
+	return ticker, "orderflow"
+}
+
 // extractTicker extracts the ticker from an orderflow group name.
 // Group format: {prefix}_{ticker}_orderflow_orderflow
 func extractTicker(group string) string {
@@ -0,0 +1,228 @@
+package ws
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dgnsrekt/gexbot-downloader/internal/data"
+	"github.com/dgnsrekt/gexbot-downloader/internal/stats"
+)
+
+// VolatilityStreamer broadcasts implied volatility surfaces from JSONL files to subscribed clients.
+// Supports iv_zero and iv_one categories.
+// Uses per-API-key position tracking via shared IndexCache.
+type VolatilityStreamer struct {
+	hub           *Hub
+	loader        data.DataLoader
+	cache         *data.IndexCache
+	encoder       *Encoder
+	interval      time.Duration
+	logger        *zap.Logger
+	reloadChecker ReloadChecker
+	schedule      *keySchedule
+	clock         *data.PlaybackClock // nil plays one record per tick
+}
+
+// NewVolatilityStreamer creates a new VolatilityStreamer with shared cache for per-API-key tracking.
+func NewVolatilityStreamer(hub *Hub, loader data.DataLoader, cache *data.IndexCache, interval time.Duration, logger *zap.Logger, reloadChecker ReloadChecker) (*VolatilityStreamer, error) {
+	enc, err := NewEncoder()
+	if err != nil {
+		return nil, err
+	}
+
+	return &VolatilityStreamer{
+		hub:           hub,
+		loader:        loader,
+		cache:         cache,
+		encoder:       enc,
+		interval:      interval,
+		logger:        logger,
+		reloadChecker: reloadChecker,
+		schedule:      newKeySchedule(interval),
+	}, nil
+}
+
+// SetKeyIntervals installs per-API-key interval overrides.
+// Call before Run.
+func (s *VolatilityStreamer) SetKeyIntervals(intervals *KeyIntervals) {
+	s.schedule.intervals = intervals
+}
+
+// SetPlaybackClock replays records at their timestamps, scaled by the
+// clock's speed, instead of one per tick. Call before Run.
+func (s *VolatilityStreamer) SetPlaybackClock(clock *data.PlaybackClock) {
+	s.clock = clock
+}
+
+// Run starts the streaming loop. Call in a goroutine.
+// Returns when context is cancelled.
+func (s *VolatilityStreamer) Run(ctx context.Context) {
+	// Align first tick to top of second for predictable timing
+	now := time.Now()
+	nextSecond := now.Truncate(time.Second).Add(time.Second)
+	s.logger.Debug("aligning to next second",
+		zap.Time("now", now),
+		zap.Time("nextSecond", nextSecond),
+		zap.Duration("wait", time.Until(nextSecond)),
+	)
+
+	select {
+	case <-ctx.Done():
+		s.logger.Info("volatility streamer cancelled during alignment")
+		s.encoder.Close()
+		return
+	case <-time.After(time.Until(nextSecond)):
+	}
+
+	ticker := time.NewTicker(s.schedule.start())
+	defer ticker.Stop()
+
+	s.logger.Info("volatility streamer started",
+		zap.Duration("interval", s.interval),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("volatility streamer stopping")
+			s.encoder.Close()
+			return
+
+		case now := <-ticker.C:
+			s.schedule.begin(now, ticker)
+			s.broadcastNext(ctx)
+		}
+	}
+}
+
+// broadcastNext sends the next data point to all active groups.
+// Each API key receives data from its own position in the stream.
+func (s *VolatilityStreamer) broadcastNext(ctx context.Context) {
+	// Skip broadcast during data reload
+	if s.reloadChecker != nil && s.reloadChecker.IsReloading() {
+		return
+	}
+
+	groups := s.hub.GetActiveGroups()
+	if len(groups) == 0 {
+		return
+	}
+
+	// Group names: blue_{ticker}_volatility_{category}, or blue_ALL_volatility_{category} for every ticker
+	for _, sub := range expandSubscriptions(groups, extractVolatilityTickerAndCategory, s.loader, "volatility") {
+		ticker, category := sub.ticker, sub.category
+
+		// Get clients grouped by API key across per-ticker and aggregate groups
+		clientsByAPIKey := sub.clientsByAPIKey(s.hub)
+		if len(clientsByAPIKey) == 0 {
+			continue
+		}
+
+		// For each API key, get their position and broadcast their data
+		for apiKey, clients := range clientsByAPIKey {
+			// Keys with their own interval are skipped until due
+			if !s.schedule.due(apiKey) {
+				continue
+			}
+
+			// Keys pinned to another date replay their own dataset
+			loader := loaderFor(s.loader, s.reloadChecker, apiKey)
+			length, err := loader.GetLength(ticker, "volatility", category)
+			if err != nil {
+				s.logger.Debug("failed to get data length",
+					zap.String("ticker", ticker),
+					zap.String("category", category),
+					zap.String("apiKey", maskAPIKey(apiKey)),
+					zap.Error(err),
+				)
+				continue
+			}
+
+			cacheKey := data.WSCacheKey("volatility", ticker, category, apiKey)
+			idx, exhausted, due := nextRecord(ctx, s.cache, s.clock, loader, cacheKey, ticker, "volatility", category, length)
+
+			// In exhaust mode, skip this API key if exhausted
+			if exhausted {
+				s.logger.Debug("data exhausted for API key",
+					zap.String("ticker", ticker),
+					zap.String("category", category),
+					zap.String("apiKey", maskAPIKey(apiKey)),
+				)
+				continue
+			}
+
+			// The clock has not reached a new record yet
+			if !due {
+				continue
+			}
+
+			// Get raw JSON data at this API key's index
+			rawJSON, err := loader.GetRawAtIndex(ctx, ticker, "volatility", category, idx)
+			if err != nil {
+				s.logger.Debug("failed to get data at index",
+					zap.String("ticker", ticker),
+					zap.String("category", category),
+					zap.Int("index", idx),
+					zap.Error(err),
+				)
+				continue
+			}
+
+			// Encode per schema version and broadcast to all clients with this API key
+			if err := clients.broadcast(s.hub, s.encoder, KindVolatility, rawJSON); err != nil {
+				stats.EncodeFailures.Add(1)
+				s.logger.Debug("failed to encode volatility",
+					zap.String("ticker", ticker),
+					zap.String("category", category),
+					zap.Error(err),
+				)
+				continue
+			}
+
+			s.logger.Debug("broadcast volatility",
+				zap.String("ticker", ticker),
+				zap.String("category", category),
+				zap.String("apiKey", maskAPIKey(apiKey)),
+				zap.Int("index", idx),
+				zap.Int("clientCount", clients.count()),
+			)
+		}
+	}
+}
+
+// extractVolatilityTickerAndCategory extracts the ticker and category from a volatility group name.
+// Group format: {prefix}_{ticker}_volatility_{category}
+// Examples:
+//   - blue_SPX_volatility_iv_zero -> ticker="SPX", category="iv_zero"
+//   - blue_ES_SPX_volatility_iv_one -> ticker="ES_SPX", category="iv_one"
+func extractVolatilityTickerAndCategory(group string) (ticker, category string) {
+	// Find _volatility_ separator to isolate prefix_ticker and category
+	separator := "_volatility_"
+	separatorIdx := strings.Index(group, separator)
+	if separatorIdx < 0 {
+		return "", ""
+	}
+
+	// Everything before _volatility_ is prefix_ticker
+	prefixAndTicker := group[:separatorIdx]
+
+	// Find first underscore to separate prefix from ticker
+	firstUnderscore := strings.Index(prefixAndTicker, "_")
+	if firstUnderscore < 0 || firstUnderscore >= len(prefixAndTicker)-1 {
+		return "", ""
+	}
+
+	ticker = prefixAndTicker[firstUnderscore+1:]
+	category = group[separatorIdx+len(separator):]
+
+	// Validate category is one of the expected volatility categories
+	switch category {
+	case "iv_zero", "iv_one":
+		return ticker, category
+	default:
+		return "", ""
+	}
+}